@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"github.com/opendatahub-io/odh-platform-utilities/api/common"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,6 +21,16 @@ const (
 	ModuleDisabled ModuleOverrideState = "Disabled"
 )
 
+// ModuleLogLevel is the log level of a module BFF, passed as LOG_LEVEL.
+type ModuleLogLevel string
+
+const (
+	ModuleLogLevelDebug ModuleLogLevel = "debug"
+	ModuleLogLevelInfo  ModuleLogLevel = "info"
+	ModuleLogLevelWarn  ModuleLogLevel = "warn"
+	ModuleLogLevelError ModuleLogLevel = "error"
+)
+
 // ModulePhase represents the deployment lifecycle of an individual module.
 type ModulePhase string
 
@@ -67,7 +78,8 @@ type ComponentAvailability struct {
 // +kubebuilder:object:generate=true
 
 // ModuleOverride allows the orchestrator or admin to override the
-// automatic dependency-based module enablement decision.
+// automatic dependency-based module enablement decision, and the
+// resources, scaling and scheduling of the module's workload.
 type ModuleOverride struct {
 	// State overrides automatic module enablement.
 	// If empty or omitted, the controller uses dependency resolution.
@@ -75,6 +87,48 @@ type ModuleOverride struct {
 	// +kubebuilder:validation:Enum=Enabled;Disabled
 	// +optional
 	State ModuleOverrideState `json:"state,omitempty"`
+
+	// Resources overrides the requests and limits of the module container.
+	// Only the listed resources (e.g. cpu, memory) are changed; the others
+	// keep the values from the module manifests.
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Replicas overrides the replica count of the module Deployment.
+	// Only applied in Standalone mode: in Sidecar mode the module shares the
+	// dashboard pod, and the ModuleOverridesApplied condition reports it as unsupported.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// NodeSelector overrides the node selector of the module pods.
+	// Only applied in Standalone mode, see Replicas.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations overrides the tolerations of the module pods.
+	// Only applied in Standalone mode, see Replicas.
+	//
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Env adds environment variables to the module container, replacing
+	// any variable of the same name from the module manifests.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// LogLevel sets the LOG_LEVEL environment variable of the module
+	// container. It takes precedence over a LOG_LEVEL entry in Env.
+	//
+	// +kubebuilder:validation:Enum=debug;info;warn;error
+	// +optional
+	LogLevel ModuleLogLevel `json:"logLevel,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -141,6 +195,29 @@ type ModuleStatus struct {
 
 	// +optional
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Drift lists the spec.modules overrides whose value in the live
+	// Deployment differs from the desired value.
+	// +optional
+	Drift []ModuleOverrideDrift `json:"drift,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// ModuleOverrideDrift reports one overridden field whose live value differs
+// from the value requested in spec.modules.
+type ModuleOverrideDrift struct {
+	// Field is the overridden field, e.g. "replicas", "resources.limits.cpu"
+	// or "env.LOG_LEVEL".
+	Field string `json:"field"`
+
+	// Desired is the value requested in spec.modules.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+
+	// Actual is the value found in the live Deployment.
+	// +optional
+	Actual string `json:"actual,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -34,7 +34,10 @@ import (
 )
 
 const dashboardFinalizer = "components.platform.opendatahub.io/cleanup"
-const conditionObservabilityAvailable = "ObservabilityAvailable"
+const (
+	conditionObservabilityAvailable = "ObservabilityAvailable"
+	conditionModuleOverridesApplied = "ModuleOverridesApplied"
+)
 
 var operatorDeploymentName = getOperatorDeploymentName()
 
@@ -144,6 +147,7 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			string(common.ConditionTypeProvisioningSucceeded),
 			string(common.ConditionTypeDegraded),
 			conditionObservabilityAvailable,
+			conditionModuleOverridesApplied,
 		)
 		cm.MarkFalse(string(common.ConditionTypeProvisioningSucceeded),
 			conditions.WithReason("Removed"),
@@ -156,6 +160,10 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			conditions.WithReason("Removed"),
 			conditions.WithMessage("Dashboard has been removed"),
 			conditions.WithSeverity(common.ConditionSeverityInfo))
+		cm.MarkFalse(conditionModuleOverridesApplied,
+			conditions.WithReason("Removed"),
+			conditions.WithMessage("Dashboard has been removed"),
+			conditions.WithSeverity(common.ConditionSeverityInfo))
 		cm.MarkFalse(string(common.ConditionTypeReady),
 			conditions.WithReason("Removed"),
 			conditions.WithMessage("Dashboard has been removed via managementState"))
@@ -194,6 +202,7 @@ func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		string(common.ConditionTypeProvisioningSucceeded),
 		string(common.ConditionTypeDegraded),
 		conditionObservabilityAvailable,
+		conditionModuleOverridesApplied,
 	)
 
 	result, err := r.reconcile(ctx, dashboard, cm, cfg)
@@ -292,6 +301,14 @@ func (r *DashboardReconciler) reconcileSidecar(
 
 	remapRayDashboardGatewayRBAC(allResources)
 
+	if err := applyModuleOverrides(allResources, dashboard.Spec.Modules, false); err != nil {
+		cm.MarkFalse(string(common.ConditionTypeProvisioningSucceeded),
+			conditions.WithReason("ModuleOverridesFailed"),
+			conditions.WithError(err))
+
+		return ctrl.Result{}, fmt.Errorf("failed to apply module overrides: %w", err)
+	}
+
 	if err := sanitizeDeploymentProbes(ctx, r.Client, allResources); err != nil {
 		cm.MarkFalse(string(common.ConditionTypeProvisioningSucceeded),
 			conditions.WithReason("ProbeSanitizeFailed"),
@@ -312,7 +329,7 @@ func (r *DashboardReconciler) reconcileSidecar(
 		deploy.WithFieldOwner("dashboard-operator"),
 		deploy.WithLabel(labels.PlatformPartOf, strings.ToLower(v1alpha1.DashboardKind)),
 		deploy.WithApplyOrder(),
-		deploy.WithMergeStrategy(deploymentGVK, deploy.MergeDeployments),
+		deploy.WithMergeStrategy(deploymentGVK, mergeDeploymentsWithOverrides(dashboard.Spec.Modules, false)),
 	)
 
 	if err := deployer.Deploy(ctx, deploy.DeployInput{
@@ -331,6 +348,7 @@ func (r *DashboardReconciler) reconcileSidecar(
 	cm.MarkTrue(string(common.ConditionTypeProvisioningSucceeded),
 		conditions.WithReason("ResourcesApplied"),
 		conditions.WithMessage("Dashboard manifests applied successfully"))
+	markModuleOverridesApplied(cm, dashboard.Spec.Modules, true)
 
 	r.reconcileObservability(ctx, dashboard, cm)
 
@@ -355,6 +373,7 @@ func (r *DashboardReconciler) reconcileSidecar(
 	}
 
 	overlayContainerReadiness(nextStatuses, podList.Items)
+	r.overlayModuleOverrideDrift(ctx, dashboard, nextStatuses, true)
 
 	for name, next := range nextStatuses {
 		if prev, ok := dashboard.Status.ModuleStatuses[name]; ok &&
@@ -517,10 +536,12 @@ func (r *DashboardReconciler) reconcileStandalone(
 	cm.MarkTrue(string(common.ConditionTypeProvisioningSucceeded),
 		conditions.WithReason("ResourcesApplied"),
 		conditions.WithMessage("Dashboard and module manifests applied successfully"))
+	markModuleOverridesApplied(cm, dashboard.Spec.Modules, false)
 
 	// Step 5: Overlay readiness from standalone deployments (before federation ConfigMap
 	// so the ConfigMap reflects actual deployment health, e.g. Degraded modules)
 	r.overlayStandaloneReadiness(ctx, nextStatuses)
+	r.overlayModuleOverrideDrift(ctx, dashboard, nextStatuses, false)
 
 	// Persist module statuses now so early returns from steps 6-8 don't leave
 	// stale status on the CR (the outer Reconcile always calls Status().Update).
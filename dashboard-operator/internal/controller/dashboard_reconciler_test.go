@@ -754,6 +754,7 @@ func TestReconcile_StatusContract(t *testing.T) {
 			}
 			if tt.wantProvisioned {
 				assert.True(t, conditions.IsStatusConditionTrue(updated, string(common.ConditionTypeProvisioningSucceeded)), "ProvisioningSucceeded should be True")
+				assert.True(t, conditions.IsStatusConditionTrue(updated, "ModuleOverridesApplied"), "ModuleOverridesApplied should be True")
 			} else {
 				cond := conditions.FindStatusCondition(updated, string(common.ConditionTypeProvisioningSucceeded))
 				if cond != nil {
@@ -217,12 +217,20 @@ func (r *DashboardReconciler) deployModuleManifests(
 			return fmt.Errorf("failed to render manifests for module %s: %w", name, err)
 		}
 
+		overrides := map[string]v1alpha1.ModuleOverride{}
+		if override, ok := dashboard.Spec.Modules[name]; ok {
+			overrides[name] = override
+		}
+		if err := applyModuleOverrides(rendered, overrides, true); err != nil {
+			return fmt.Errorf("failed to apply overrides for module %s: %w", name, err)
+		}
+
 		deployer := deploy.NewDeployer(
 			deploy.WithFieldOwner("dashboard-operator"),
 			deploy.WithLabel(labels.PlatformPartOf, strings.ToLower(v1alpha1.DashboardKind)),
 			deploy.WithLabel(moduleComponentLabel, mod.ManifestSlug),
 			deploy.WithApplyOrder(),
-			deploy.WithMergeStrategy(deploymentGVK, deploy.MergeDeployments),
+			deploy.WithMergeStrategy(deploymentGVK, mergeDeploymentsWithOverrides(overrides, true)),
 		)
 
 		if err := deployer.Deploy(ctx, deploy.DeployInput{
@@ -0,0 +1,590 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/odh-platform-utilities/api/common"
+	"github.com/opendatahub-io/odh-platform-utilities/pkg/controller/conditions"
+	"github.com/opendatahub-io/odh-platform-utilities/pkg/deploy"
+	"github.com/opendatahub-io/odh-platform-utilities/pkg/metadata/labels"
+
+	v1alpha1 "github.com/opendatahub-io/odh-dashboard/dashboard-operator/api/v1alpha1"
+)
+
+// moduleLogLevelEnvVar is the environment variable module BFFs read their log level from.
+const moduleLogLevelEnvVar = "LOG_LEVEL"
+
+// moduleOverriddenFieldsAnnotation records, on a Deployment, the fields set by
+// spec.modules overrides which deploy.MergeDeployments keeps from the live
+// object (replicas and container resources), so that they can be reset to the
+// manifest values once the override is removed.
+const moduleOverriddenFieldsAnnotation = "dashboard.opendatahub.io/module-overridden-fields"
+
+// --- Apply spec.modules overrides to rendered manifests ---
+
+// applyModuleOverrides applies spec.modules overrides to every Deployment in
+// resources that runs a module container. Container-level overrides
+// (resources, env, log level) are applied to the module container. Pod-level
+// overrides (replicas, node selector, tolerations) are only applied when
+// podLevel is true, i.e. when the Deployment belongs to a single module
+// (Standalone mode); in Sidecar mode they would affect every module.
+func applyModuleOverrides(resources []unstructured.Unstructured, overrides map[string]v1alpha1.ModuleOverride, podLevel bool) error {
+	for i := range resources {
+		res := &resources[i]
+		if res.GetKind() != "Deployment" || res.GroupVersionKind().Group != "apps" {
+			continue
+		}
+
+		if err := applyDeploymentOverrides(res, overrides, podLevel); err != nil {
+			return fmt.Errorf("applying module overrides to deployment %s: %w", res.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// mergeDeploymentsWithOverrides wraps deploy.MergeDeployments, which keeps the
+// live replicas and container resources, so that spec.modules overrides still
+// take precedence over the live values, and fields which are no longer
+// overridden go back to the manifest values instead of keeping the old override.
+func mergeDeploymentsWithOverrides(
+	overrides map[string]v1alpha1.ModuleOverride,
+	podLevel bool,
+) func(existing, desired *unstructured.Unstructured) error {
+	return func(existing, desired *unstructured.Unstructured) error {
+		manifest := desired.DeepCopy()
+
+		if err := deploy.MergeDeployments(existing, desired); err != nil {
+			return err
+		}
+
+		if err := applyDeploymentOverrides(desired, overrides, podLevel); err != nil {
+			return err
+		}
+
+		current := make(map[string]bool)
+		for _, field := range overriddenFields(desired) {
+			current[field] = true
+		}
+
+		for _, field := range overriddenFields(existing) {
+			if current[field] {
+				continue
+			}
+			if err := resetOverriddenField(desired, manifest, field); err != nil {
+				return fmt.Errorf("resetting %s: %w", field, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// overriddenFields returns the fields recorded in the
+// moduleOverriddenFieldsAnnotation of a Deployment.
+func overriddenFields(deployment *unstructured.Unstructured) []string {
+	value := deployment.GetAnnotations()[moduleOverriddenFieldsAnnotation]
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+// setOverriddenFields records the fields set by the overrides in the
+// moduleOverriddenFieldsAnnotation, or removes the annotation if there are none.
+func setOverriddenFields(deployment *unstructured.Unstructured, fields []string) {
+	annotations := deployment.GetAnnotations()
+	if len(fields) == 0 {
+		if _, ok := annotations[moduleOverriddenFieldsAnnotation]; ok {
+			delete(annotations, moduleOverriddenFieldsAnnotation)
+			deployment.SetAnnotations(annotations)
+		}
+
+		return
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	sort.Strings(fields)
+	annotations[moduleOverriddenFieldsAnnotation] = strings.Join(fields, ",")
+	deployment.SetAnnotations(annotations)
+}
+
+// resetOverriddenField sets a field recorded by setOverriddenFields back to its
+// value in the manifest, removing it if the manifest does not set it.
+// Fields are either "replicas" or "<container>.resources.<requests|limits>.<resource>".
+func resetOverriddenField(desired, manifest *unstructured.Unstructured, field string) error {
+	if field == "replicas" {
+		return copyNestedField(desired.Object, manifest.Object, "spec", "replicas")
+	}
+
+	parts := strings.SplitN(field, ".", 4)
+	if len(parts) != 4 || parts[1] != "resources" {
+		return nil
+	}
+
+	containers, found, err := unstructured.NestedSlice(desired.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		return err
+	}
+	container := findUnstructuredContainer(containers, parts[0])
+	if container == nil {
+		return nil
+	}
+
+	manifestContainers, _, err := unstructured.NestedSlice(manifest.Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		return err
+	}
+	manifestContainer := findUnstructuredContainer(manifestContainers, parts[0])
+	if manifestContainer == nil {
+		manifestContainer = map[string]interface{}{}
+	}
+
+	if err := copyNestedField(container, manifestContainer, "resources", parts[2], parts[3]); err != nil {
+		return err
+	}
+
+	return unstructured.SetNestedSlice(desired.Object, containers, "spec", "template", "spec", "containers")
+}
+
+func copyNestedField(dst, src map[string]interface{}, fields ...string) error {
+	value, found, err := unstructured.NestedFieldCopy(src, fields...)
+	if err != nil {
+		return err
+	}
+	if !found {
+		unstructured.RemoveNestedField(dst, fields...)
+		return nil
+	}
+
+	return unstructured.SetNestedField(dst, value, fields...)
+}
+
+func applyDeploymentOverrides(deployment *unstructured.Unstructured, overrides map[string]v1alpha1.ModuleOverride, podLevel bool) error {
+	containers, found, err := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		return fmt.Errorf("reading containers: %w", err)
+	}
+	if !found {
+		return nil
+	}
+
+	applied := false
+	var fields []string
+	for name, override := range overrides {
+		mod, ok := moduleRegistry[name]
+		if !ok {
+			continue
+		}
+
+		container := findUnstructuredContainer(containers, mod.ContainerName)
+		if container == nil {
+			continue
+		}
+
+		containerFields, err := applyContainerOverrides(container, &override)
+		if err != nil {
+			return fmt.Errorf("container %s: %w", mod.ContainerName, err)
+		}
+		fields = append(fields, containerFields...)
+
+		if podLevel {
+			podFields, err := applyPodOverrides(deployment, &override)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, podFields...)
+		}
+
+		applied = true
+	}
+
+	setOverriddenFields(deployment, fields)
+
+	if !applied {
+		return nil
+	}
+
+	return unstructured.SetNestedSlice(deployment.Object, containers, "spec", "template", "spec", "containers")
+}
+
+func findUnstructuredContainer(containers []interface{}, name string) map[string]interface{} {
+	for _, c := range containers {
+		cMap, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cName, _ := cMap["name"].(string); cName == name {
+			return cMap
+		}
+	}
+
+	return nil
+}
+
+// applyContainerOverrides applies the container-level overrides to the module
+// container, and returns the overridden resources (see setOverriddenFields).
+func applyContainerOverrides(container map[string]interface{}, override *v1alpha1.ModuleOverride) ([]string, error) {
+	var fields []string
+	if override.Resources != nil {
+		containerName, _ := container["name"].(string)
+		for field, list := range map[string]corev1.ResourceList{
+			"requests": override.Resources.Requests,
+			"limits":   override.Resources.Limits,
+		} {
+			for name, quantity := range list {
+				if err := unstructured.SetNestedField(container, quantity.String(), "resources", field, string(name)); err != nil {
+					return nil, fmt.Errorf("setting resources.%s.%s: %w", field, name, err)
+				}
+				fields = append(fields, fmt.Sprintf("%s.resources.%s.%s", containerName, field, name))
+			}
+		}
+	}
+
+	env := moduleOverrideEnv(override)
+	if len(env) == 0 {
+		return fields, nil
+	}
+
+	liveEnv, _, err := unstructured.NestedSlice(container, "env")
+	if err != nil {
+		return nil, fmt.Errorf("reading env: %w", err)
+	}
+
+	for i := range env {
+		envVar, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&env[i])
+		if err != nil {
+			return nil, fmt.Errorf("converting env var %s: %w", env[i].Name, err)
+		}
+
+		replaced := false
+		for j, e := range liveEnv {
+			if eMap, ok := e.(map[string]interface{}); ok && eMap["name"] == env[i].Name {
+				liveEnv[j] = envVar
+				replaced = true
+
+				break
+			}
+		}
+		if !replaced {
+			liveEnv = append(liveEnv, envVar)
+		}
+	}
+
+	return fields, unstructured.SetNestedSlice(container, liveEnv, "env")
+}
+
+// applyPodOverrides applies the pod-level overrides to the module Deployment,
+// and returns the overridden replicas (see setOverriddenFields).
+func applyPodOverrides(deployment *unstructured.Unstructured, override *v1alpha1.ModuleOverride) ([]string, error) {
+	var fields []string
+	if override.Replicas != nil {
+		if err := unstructured.SetNestedField(deployment.Object, int64(*override.Replicas), "spec", "replicas"); err != nil {
+			return nil, fmt.Errorf("setting replicas: %w", err)
+		}
+		fields = append(fields, "replicas")
+	}
+
+	if len(override.NodeSelector) > 0 {
+		if err := unstructured.SetNestedStringMap(deployment.Object, override.NodeSelector,
+			"spec", "template", "spec", "nodeSelector"); err != nil {
+			return nil, fmt.Errorf("setting nodeSelector: %w", err)
+		}
+	}
+
+	if len(override.Tolerations) > 0 {
+		tolerations := make([]interface{}, 0, len(override.Tolerations))
+		for i := range override.Tolerations {
+			toleration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&override.Tolerations[i])
+			if err != nil {
+				return nil, fmt.Errorf("converting toleration: %w", err)
+			}
+			tolerations = append(tolerations, toleration)
+		}
+		if err := unstructured.SetNestedSlice(deployment.Object, tolerations,
+			"spec", "template", "spec", "tolerations"); err != nil {
+			return nil, fmt.Errorf("setting tolerations: %w", err)
+		}
+	}
+
+	return fields, nil
+}
+
+// moduleOverrideEnv returns the environment variables an override sets on the
+// module container, with LogLevel taking precedence over a LOG_LEVEL entry in Env.
+func moduleOverrideEnv(override *v1alpha1.ModuleOverride) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0, len(override.Env)+1)
+	for _, e := range override.Env {
+		if override.LogLevel != "" && e.Name == moduleLogLevelEnvVar {
+			continue
+		}
+		env = append(env, e)
+	}
+
+	if override.LogLevel != "" {
+		env = append(env, corev1.EnvVar{Name: moduleLogLevelEnvVar, Value: string(override.LogLevel)})
+	}
+
+	return env
+}
+
+// markModuleOverridesApplied sets the ModuleOverridesApplied condition. In
+// Sidecar mode the pod-level overrides (replicas, node selector, tolerations)
+// of the modules are not applied, which is reported with severity Info so
+// that it does not block Ready.
+func markModuleOverridesApplied(cm *conditions.Manager, overrides map[string]v1alpha1.ModuleOverride, sidecar bool) {
+	var unsupported []string
+	if sidecar {
+		for name, override := range overrides {
+			if _, ok := moduleRegistry[name]; !ok {
+				continue
+			}
+			if fields := podLevelOverrideFields(&override); len(fields) > 0 {
+				unsupported = append(unsupported, fmt.Sprintf("%s (%s)", name, strings.Join(fields, ", ")))
+			}
+		}
+	}
+
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		cm.MarkFalse(conditionModuleOverridesApplied,
+			conditions.WithReason("UnsupportedInSidecarMode"),
+			conditions.WithMessage("Pod-level module overrides are only applied in Standalone mode: %s", strings.Join(unsupported, "; ")),
+			conditions.WithSeverity(common.ConditionSeverityInfo))
+
+		return
+	}
+
+	cm.MarkTrue(conditionModuleOverridesApplied,
+		conditions.WithReason("Applied"),
+		conditions.WithMessage("Module overrides applied successfully"))
+}
+
+// podLevelOverrideFields returns the pod-level fields set by an override.
+func podLevelOverrideFields(override *v1alpha1.ModuleOverride) []string {
+	var fields []string
+	if override.Replicas != nil {
+		fields = append(fields, "replicas")
+	}
+	if len(override.NodeSelector) > 0 {
+		fields = append(fields, "nodeSelector")
+	}
+	if len(override.Tolerations) > 0 {
+		fields = append(fields, "tolerations")
+	}
+
+	return fields
+}
+
+// --- Override drift detection ---
+
+// overlayModuleOverrideDrift records, in the status of each deployed module
+// with spec.modules overrides, the overridden fields whose value in the live
+// Deployment differs from the desired value. In Sidecar mode the live
+// Deployment is the main dashboard Deployment, and pod-level overrides are
+// skipped since they are not applied in that mode (see markModuleOverridesApplied).
+func (r *DashboardReconciler) overlayModuleOverrideDrift(
+	ctx context.Context,
+	dashboard *v1alpha1.Dashboard,
+	statuses map[string]v1alpha1.ModuleStatus,
+	sidecar bool,
+) {
+	logger := log.FromContext(ctx)
+
+	var mainDeployment *appsv1.Deployment
+
+	for name, override := range dashboard.Spec.Modules {
+		mod, ok := moduleRegistry[name]
+		if !ok {
+			continue
+		}
+
+		s, ok := statuses[name]
+		if !ok || (s.Phase != v1alpha1.ModulePhaseDeployed && s.Phase != v1alpha1.ModulePhaseDegraded) {
+			continue
+		}
+
+		var live *appsv1.Deployment
+		if sidecar {
+			if mainDeployment == nil {
+				mainDeployment = &appsv1.Deployment{}
+				key := client.ObjectKey{Name: mainDashboardDeploymentName(r.Platform), Namespace: r.ApplicationsNamespace}
+				if err := r.Get(ctx, key, mainDeployment); err != nil {
+					logger.Error(err, "Failed to get dashboard deployment, skipping module override drift detection")
+					return
+				}
+			}
+			live = mainDeployment
+		} else {
+			var deployList appsv1.DeploymentList
+			if err := r.List(ctx, &deployList,
+				client.InNamespace(r.ApplicationsNamespace),
+				client.MatchingLabels{
+					labels.PlatformPartOf: strings.ToLower(v1alpha1.DashboardKind),
+					moduleComponentLabel:  mod.ManifestSlug,
+				},
+			); err != nil {
+				logger.Error(err, "Failed to list module deployments, skipping override drift detection", "module", name)
+				continue
+			}
+			for i := range deployList.Items {
+				if findContainer(deployList.Items[i].Spec.Template.Spec.Containers, mod.ContainerName) != nil {
+					live = &deployList.Items[i]
+					break
+				}
+			}
+		}
+
+		if live == nil {
+			continue
+		}
+
+		s.Drift = moduleOverrideDrift(live, mod.ContainerName, &override, !sidecar)
+		statuses[name] = s
+	}
+}
+
+// moduleOverrideDrift compares an override with the live Deployment running
+// the module container, and returns the overridden fields which differ.
+// Pod-level overrides are only compared when podLevel is true, like in
+// applyModuleOverrides.
+func moduleOverrideDrift(deployment *appsv1.Deployment, containerName string, override *v1alpha1.ModuleOverride, podLevel bool) []v1alpha1.ModuleOverrideDrift {
+	podSpec := &deployment.Spec.Template.Spec
+	container := findContainer(podSpec.Containers, containerName)
+	if container == nil {
+		return nil
+	}
+
+	var drift []v1alpha1.ModuleOverrideDrift
+
+	if podLevel && override.Replicas != nil {
+		actual := int32(1)
+		if deployment.Spec.Replicas != nil {
+			actual = *deployment.Spec.Replicas
+		}
+		if actual != *override.Replicas {
+			drift = append(drift, v1alpha1.ModuleOverrideDrift{
+				Field:   "replicas",
+				Desired: fmt.Sprintf("%d", *override.Replicas),
+				Actual:  fmt.Sprintf("%d", actual),
+			})
+		}
+	}
+
+	if override.Resources != nil {
+		drift = append(drift, resourceListDrift("resources.requests", override.Resources.Requests, container.Resources.Requests)...)
+		drift = append(drift, resourceListDrift("resources.limits", override.Resources.Limits, container.Resources.Limits)...)
+	}
+
+	if podLevel && len(override.NodeSelector) > 0 && !maps.Equal(override.NodeSelector, podSpec.NodeSelector) {
+		drift = append(drift, v1alpha1.ModuleOverrideDrift{
+			Field:   "nodeSelector",
+			Desired: k8slabels.Set(override.NodeSelector).String(),
+			Actual:  k8slabels.Set(podSpec.NodeSelector).String(),
+		})
+	}
+
+	if podLevel && len(override.Tolerations) > 0 && !equality.Semantic.DeepEqual(override.Tolerations, podSpec.Tolerations) {
+		drift = append(drift, v1alpha1.ModuleOverrideDrift{
+			Field:   "tolerations",
+			Desired: marshalDriftValue(override.Tolerations),
+			Actual:  marshalDriftValue(podSpec.Tolerations),
+		})
+	}
+
+	for _, desired := range moduleOverrideEnv(override) {
+		var actual *corev1.EnvVar
+		for i := range container.Env {
+			if container.Env[i].Name == desired.Name {
+				actual = &container.Env[i]
+				break
+			}
+		}
+		if actual != nil && equality.Semantic.DeepEqual(desired, *actual) {
+			continue
+		}
+
+		d := v1alpha1.ModuleOverrideDrift{
+			Field:   "env." + desired.Name,
+			Desired: envVarDriftValue(&desired),
+		}
+		if actual != nil {
+			d.Actual = envVarDriftValue(actual)
+		}
+		drift = append(drift, d)
+	}
+
+	return drift
+}
+
+func resourceListDrift(field string, desired, actual corev1.ResourceList) []v1alpha1.ModuleOverrideDrift {
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var drift []v1alpha1.ModuleOverrideDrift
+	for _, name := range names {
+		want := desired[corev1.ResourceName(name)]
+		got, ok := actual[corev1.ResourceName(name)]
+		if ok && got.Cmp(want) == 0 {
+			continue
+		}
+
+		d := v1alpha1.ModuleOverrideDrift{
+			Field:   field + "." + name,
+			Desired: want.String(),
+		}
+		if ok {
+			d.Actual = got.String()
+		}
+		drift = append(drift, d)
+	}
+
+	return drift
+}
+
+func findContainer(containers []corev1.Container, name string) *corev1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+
+	return nil
+}
+
+func envVarDriftValue(envVar *corev1.EnvVar) string {
+	if envVar.ValueFrom != nil {
+		return marshalDriftValue(envVar.ValueFrom)
+	}
+
+	return envVar.Value
+}
+
+func marshalDriftValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(data)
+}
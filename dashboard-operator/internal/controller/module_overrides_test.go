@@ -0,0 +1,402 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/opendatahub-io/odh-platform-utilities/pkg/cluster"
+
+	v1alpha1 "github.com/opendatahub-io/odh-dashboard/dashboard-operator/api/v1alpha1"
+)
+
+func moduleContainerEntry(name string) map[string]interface{} {
+	c := containerEntry(name)
+	c["resources"] = map[string]interface{}{
+		"requests": map[string]interface{}{"cpu": "100m", "memory": "256Mi"},
+		"limits":   map[string]interface{}{"memory": "1Gi"},
+	}
+	c["env"] = []interface{}{
+		map[string]interface{}{"name": "LOG_LEVEL", "value": "INFO"},
+		map[string]interface{}{"name": "PORT", "value": "8143"},
+	}
+	return c
+}
+
+func nestedContainer(t *testing.T, deployment *unstructured.Unstructured, name string) map[string]interface{} {
+	t.Helper()
+	containers, _, err := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	require.NoError(t, err)
+	container := findUnstructuredContainer(containers, name)
+	require.NotNil(t, container, "container %s not found", name)
+	return container
+}
+
+func TestApplyModuleOverrides_Standalone(t *testing.T) {
+	desired := deploymentWithContainers("gen-ai-ui", "ns", []interface{}{
+		moduleContainerEntry("gen-ai-ui"),
+		containerEntry("kube-rbac-proxy"),
+	})
+
+	overrides := map[string]v1alpha1.ModuleOverride{
+		"genAi": {
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+			Replicas:     int32Ptr(3),
+			NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+			Tolerations: []corev1.Toleration{{
+				Key:      "infra",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+			Env:      []corev1.EnvVar{{Name: "EXTRA", Value: "1"}},
+			LogLevel: v1alpha1.ModuleLogLevelDebug,
+		},
+	}
+
+	resources := []unstructured.Unstructured{*desired}
+	require.NoError(t, applyModuleOverrides(resources, overrides, true))
+	got := &resources[0]
+
+	replicas, _, _ := unstructured.NestedInt64(got.Object, "spec", "replicas")
+	assert.Equal(t, int64(3), replicas)
+
+	nodeSelector, _, _ := unstructured.NestedStringMap(got.Object, "spec", "template", "spec", "nodeSelector")
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, nodeSelector)
+
+	tolerations, _, _ := unstructured.NestedSlice(got.Object, "spec", "template", "spec", "tolerations")
+	require.Len(t, tolerations, 1)
+	assert.Equal(t, "infra", tolerations[0].(map[string]interface{})["key"])
+
+	container := nestedContainer(t, got, "gen-ai-ui")
+	cpu, _, _ := unstructured.NestedString(container, "resources", "requests", "cpu")
+	assert.Equal(t, "500m", cpu)
+	memoryRequest, _, _ := unstructured.NestedString(container, "resources", "requests", "memory")
+	assert.Equal(t, "256Mi", memoryRequest, "resources not in the override must be kept")
+	memoryLimit, _, _ := unstructured.NestedString(container, "resources", "limits", "memory")
+	assert.Equal(t, "2Gi", memoryLimit)
+
+	env, _, _ := unstructured.NestedSlice(container, "env")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+		map[string]interface{}{"name": "PORT", "value": "8143"},
+		map[string]interface{}{"name": "EXTRA", "value": "1"},
+	}, env)
+
+	proxy := nestedContainer(t, got, "kube-rbac-proxy")
+	_, hasEnv := proxy["env"]
+	assert.False(t, hasEnv, "other containers must not be changed")
+
+	assert.Equal(t,
+		"gen-ai-ui.resources.limits.memory,gen-ai-ui.resources.requests.cpu,replicas",
+		got.GetAnnotations()[moduleOverriddenFieldsAnnotation])
+}
+
+func TestApplyModuleOverrides_SidecarSkipsPodLevelOverrides(t *testing.T) {
+	desired := deploymentWithContainers("odh-dashboard", "ns", []interface{}{
+		containerEntry("odh-dashboard"),
+		moduleContainerEntry("gen-ai-ui"),
+		moduleContainerEntry("maas-ui"),
+	})
+	require.NoError(t, unstructured.SetNestedField(desired.Object, int64(2), "spec", "replicas"))
+
+	overrides := map[string]v1alpha1.ModuleOverride{
+		"genAi": {
+			Replicas:     int32Ptr(5),
+			NodeSelector: map[string]string{"zone": "a"},
+			LogLevel:     v1alpha1.ModuleLogLevelWarn,
+		},
+	}
+
+	resources := []unstructured.Unstructured{*desired}
+	require.NoError(t, applyModuleOverrides(resources, overrides, false))
+	got := &resources[0]
+
+	replicas, _, _ := unstructured.NestedInt64(got.Object, "spec", "replicas")
+	assert.Equal(t, int64(2), replicas, "replicas of the shared pod must not be changed")
+
+	_, found, _ := unstructured.NestedStringMap(got.Object, "spec", "template", "spec", "nodeSelector")
+	assert.False(t, found, "nodeSelector of the shared pod must not be changed")
+
+	env, _, _ := unstructured.NestedSlice(nestedContainer(t, got, "gen-ai-ui"), "env")
+	assert.Contains(t, env, map[string]interface{}{"name": "LOG_LEVEL", "value": "warn"})
+
+	env, _, _ = unstructured.NestedSlice(nestedContainer(t, got, "maas-ui"), "env")
+	assert.Contains(t, env, map[string]interface{}{"name": "LOG_LEVEL", "value": "INFO"}, "other modules must not be changed")
+}
+
+func TestApplyModuleOverrides_SkipsUnknownModulesAndNonDeployments(t *testing.T) {
+	cm := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "gen-ai-ui"},
+	}}
+	desired := deploymentWithContainers("gen-ai-ui", "ns", []interface{}{moduleContainerEntry("gen-ai-ui")})
+	before := desired.DeepCopy()
+
+	resources := []unstructured.Unstructured{cm, *desired}
+	overrides := map[string]v1alpha1.ModuleOverride{
+		"notAModule": {Replicas: int32Ptr(3)},
+	}
+
+	require.NoError(t, applyModuleOverrides(resources, overrides, true))
+	assert.Equal(t, before.Object, resources[1].Object)
+}
+
+func TestMergeDeploymentsWithOverrides_OverridesWinOverLive(t *testing.T) {
+	existing := deploymentWithContainers("gen-ai-ui", "ns", []interface{}{moduleContainerEntry("gen-ai-ui")})
+	require.NoError(t, unstructured.SetNestedField(existing.Object, int64(4), "spec", "replicas"))
+
+	desired := deploymentWithContainers("gen-ai-ui", "ns", []interface{}{moduleContainerEntry("gen-ai-ui")})
+	require.NoError(t, unstructured.SetNestedField(desired.Object, int64(1), "spec", "replicas"))
+
+	merge := mergeDeploymentsWithOverrides(map[string]v1alpha1.ModuleOverride{
+		"genAi": {
+			Replicas: int32Ptr(2),
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+			},
+		},
+	}, true)
+	require.NoError(t, merge(existing, desired))
+
+	replicas, _, _ := unstructured.NestedInt64(desired.Object, "spec", "replicas")
+	assert.Equal(t, int64(2), replicas)
+
+	memory, _, _ := unstructured.NestedString(nestedContainer(t, desired, "gen-ai-ui"), "resources", "requests", "memory")
+	assert.Equal(t, "512Mi", memory)
+}
+
+func TestMergeDeploymentsWithOverrides_ResetsRemovedOverrides(t *testing.T) {
+	overrides := map[string]v1alpha1.ModuleOverride{
+		"genAi": {
+			Replicas: int32Ptr(3),
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+		},
+	}
+
+	// the live memory request was changed by hand, and is not overridden
+	liveContainer := moduleContainerEntry("gen-ai-ui")
+	require.NoError(t, unstructured.SetNestedField(liveContainer, "300Mi", "resources", "requests", "memory"))
+	existing := deploymentWithContainers("gen-ai-ui", "ns", []interface{}{liveContainer})
+	require.NoError(t, applyModuleOverrides([]unstructured.Unstructured{*existing}, overrides, true))
+
+	desired := deploymentWithContainers("gen-ai-ui", "ns", []interface{}{moduleContainerEntry("gen-ai-ui")})
+	require.NoError(t, unstructured.SetNestedField(desired.Object, int64(1), "spec", "replicas"))
+
+	merge := mergeDeploymentsWithOverrides(map[string]v1alpha1.ModuleOverride{
+		"genAi": {
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+		},
+	}, true)
+	require.NoError(t, merge(existing, desired))
+
+	replicas, _, _ := unstructured.NestedInt64(desired.Object, "spec", "replicas")
+	assert.Equal(t, int64(1), replicas, "replicas must go back to the manifest value")
+
+	container := nestedContainer(t, desired, "gen-ai-ui")
+	cpuRequest, _, _ := unstructured.NestedString(container, "resources", "requests", "cpu")
+	assert.Equal(t, "2", cpuRequest, "remaining overrides must be kept")
+	_, found, _ := unstructured.NestedString(container, "resources", "limits", "cpu")
+	assert.False(t, found, "resources not in the manifest must be removed")
+	memoryRequest, _, _ := unstructured.NestedString(container, "resources", "requests", "memory")
+	assert.Equal(t, "300Mi", memoryRequest, "live values of fields never overridden must be kept")
+
+	assert.Equal(t, "gen-ai-ui.resources.requests.cpu", desired.GetAnnotations()[moduleOverriddenFieldsAnnotation])
+
+	// removing the last override
+	existing = desired
+	desired = deploymentWithContainers("gen-ai-ui", "ns", []interface{}{moduleContainerEntry("gen-ai-ui")})
+	require.NoError(t, mergeDeploymentsWithOverrides(nil, true)(existing, desired))
+
+	cpuRequest, _, _ = unstructured.NestedString(nestedContainer(t, desired, "gen-ai-ui"), "resources", "requests", "cpu")
+	assert.Equal(t, "100m", cpuRequest)
+	assert.NotContains(t, desired.GetAnnotations(), moduleOverriddenFieldsAnnotation)
+}
+
+func TestModuleOverrideEnv_LogLevelTakesPrecedence(t *testing.T) {
+	env := moduleOverrideEnv(&v1alpha1.ModuleOverride{
+		Env: []corev1.EnvVar{
+			{Name: "LOG_LEVEL", Value: "error"},
+			{Name: "EXTRA", Value: "1"},
+		},
+		LogLevel: v1alpha1.ModuleLogLevelDebug,
+	})
+
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "EXTRA", Value: "1"},
+		{Name: "LOG_LEVEL", Value: "debug"},
+	}, env)
+}
+
+func liveModuleDeployment(name string, replicas int32, container corev1.Container) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(replicas),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{container},
+				},
+			},
+		},
+	}
+}
+
+func TestModuleOverrideDrift(t *testing.T) {
+	override := &v1alpha1.ModuleOverride{
+		Replicas: int32Ptr(2),
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+		},
+		NodeSelector: map[string]string{"zone": "a"},
+		LogLevel:     v1alpha1.ModuleLogLevelDebug,
+	}
+
+	tests := []struct {
+		name      string
+		replicas  int32
+		container corev1.Container
+		podSpec   func(*corev1.PodSpec)
+		want      []v1alpha1.ModuleOverrideDrift
+	}{
+		{
+			name:     "no drift with equivalent quantities",
+			replicas: 2,
+			container: corev1.Container{
+				Name: "gen-ai-ui",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1000m"),
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				},
+				Env: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+			},
+			podSpec: func(spec *corev1.PodSpec) {
+				spec.NodeSelector = map[string]string{"zone": "a"}
+			},
+		},
+		{
+			name:     "drift on every field",
+			replicas: 1,
+			container: corev1.Container{
+				Name: "gen-ai-ui",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("500m"),
+					},
+				},
+				Env: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}},
+			},
+			want: []v1alpha1.ModuleOverrideDrift{
+				{Field: "replicas", Desired: "2", Actual: "1"},
+				{Field: "resources.requests.cpu", Desired: "1", Actual: "500m"},
+				{Field: "resources.requests.memory", Desired: "512Mi"},
+				{Field: "nodeSelector", Desired: "zone=a"},
+				{Field: "env.LOG_LEVEL", Desired: "debug", Actual: "info"},
+			},
+		},
+		{
+			name:      "module container not found",
+			replicas:  1,
+			container: corev1.Container{Name: "other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := liveModuleDeployment("gen-ai-ui", tt.replicas, tt.container)
+			if tt.podSpec != nil {
+				tt.podSpec(&deployment.Spec.Template.Spec)
+			}
+
+			assert.Equal(t, tt.want, moduleOverrideDrift(deployment, "gen-ai-ui", override, true))
+		})
+	}
+}
+
+func TestModuleOverrideDrift_SkipsPodLevelOverrides(t *testing.T) {
+	deployment := liveModuleDeployment("odh-dashboard", 2, corev1.Container{
+		Name: "gen-ai-ui",
+		Env:  []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+	})
+
+	drift := moduleOverrideDrift(deployment, "gen-ai-ui", &v1alpha1.ModuleOverride{
+		Replicas:     int32Ptr(3),
+		NodeSelector: map[string]string{"zone": "a"},
+		Tolerations:  []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists}},
+		LogLevel:     v1alpha1.ModuleLogLevelDebug,
+	}, false)
+
+	assert.Empty(t, drift)
+}
+
+func TestPodLevelOverrideFields(t *testing.T) {
+	assert.Empty(t, podLevelOverrideFields(&v1alpha1.ModuleOverride{LogLevel: v1alpha1.ModuleLogLevelDebug}))
+	assert.Equal(t, []string{"replicas", "nodeSelector", "tolerations"}, podLevelOverrideFields(&v1alpha1.ModuleOverride{
+		Replicas:     int32Ptr(3),
+		NodeSelector: map[string]string{"zone": "a"},
+		Tolerations:  []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists}},
+	}))
+}
+
+func TestOverlayModuleOverrideDrift_Sidecar(t *testing.T) {
+	s := newScheme(t)
+	live := liveModuleDeployment("odh-dashboard", 2, corev1.Container{
+		Name: "gen-ai-ui",
+		Env:  []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}},
+	})
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(live).Build()
+
+	r := &DashboardReconciler{
+		Client:                cli,
+		Platform:              cluster.OpenDataHub,
+		ApplicationsNamespace: "ns",
+	}
+
+	dashboard := &v1alpha1.Dashboard{
+		Spec: v1alpha1.DashboardSpec{
+			Modules: map[string]v1alpha1.ModuleOverride{
+				"genAi": {Replicas: int32Ptr(3), LogLevel: v1alpha1.ModuleLogLevelDebug},
+				"maas":  {LogLevel: v1alpha1.ModuleLogLevelDebug},
+			},
+		},
+	}
+	statuses := map[string]v1alpha1.ModuleStatus{
+		"genAi": {Phase: v1alpha1.ModulePhaseDeployed},
+		"maas":  {Phase: v1alpha1.ModulePhaseDisabled},
+	}
+
+	r.overlayModuleOverrideDrift(context.Background(), dashboard, statuses, true)
+
+	assert.Equal(t, []v1alpha1.ModuleOverrideDrift{
+		{Field: "env.LOG_LEVEL", Desired: "debug", Actual: "info"},
+	}, statuses["genAi"].Drift, "pod-level overrides are not applied in Sidecar mode, so they never drift")
+	assert.Empty(t, statuses["maas"].Drift, "disabled modules are not checked")
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
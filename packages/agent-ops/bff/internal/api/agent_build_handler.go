@@ -0,0 +1,82 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	helper "github.com/opendatahub-io/mod-arch-library/bff/internal/helpers"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+)
+
+type AgentBuildEnvelope Envelope[*models.AgentBuildStatus, None]
+
+// GetAgentBuildHandler handles GET /api/v1/agents/builds/:ns/:name.
+func (app *App) GetAgentBuildHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName("ns")
+	name := ps.ByName("name")
+	if err := validateAgentPathParams(namespace, name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	logger := helper.GetContextLoggerFromReq(r)
+	logger.Info("Fetching agent build status", slog.String("namespace", namespace), slog.String("name", name))
+
+	result, err := app.repositories.AgentRuntimes.GetAgentBuildStatus(r.Context(), namespace, name)
+	if err != nil {
+		logger.Error("Failed to get agent build status",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Any("error", err))
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+
+	envelope := AgentBuildEnvelope{
+		Data: result,
+	}
+	if err := app.WriteJSON(w, http.StatusOK, envelope, nil); err != nil {
+		logger.Error("Failed to write JSON response",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Int("status", http.StatusOK),
+			slog.Any("error", err))
+	}
+}
+
+// DeployAgentBuildHandler handles POST /api/v1/agents/builds/:ns/:name/deploy.
+// It deploys the image of a succeeded build; 409 is returned while the build has
+// not succeeded or when the agent already exists.
+func (app *App) DeployAgentBuildHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName("ns")
+	name := ps.ByName("name")
+	if err := validateAgentPathParams(namespace, name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	logger := helper.GetContextLoggerFromReq(r)
+	logger.Info("Deploying agent from build", slog.String("namespace", namespace), slog.String("name", name))
+
+	result, err := app.repositories.AgentRuntimes.DeployAgentBuild(r.Context(), namespace, name)
+	if err != nil {
+		logger.Error("Failed to deploy agent from build",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Any("error", err))
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+
+	envelope := DeployAgentEnvelope{
+		Data: result,
+	}
+	if err := app.WriteJSON(w, http.StatusCreated, envelope, nil); err != nil {
+		logger.Error("Failed to write JSON response",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Int("status", http.StatusCreated),
+			slog.Any("error", err))
+	}
+}
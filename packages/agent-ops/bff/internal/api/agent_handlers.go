@@ -68,6 +68,10 @@ func (app *App) handleAgentRepositoryError(w http.ResponseWriter, r *http.Reques
 		app.forbiddenResponse(w, r, "user does not have permission to access the requested agent")
 		return
 	}
+	if errors.Is(err, bfferrors.ErrInvalidRequest) {
+		app.badRequestResponse(w, r, err)
+		return
+	}
 	if errors.Is(err, bfferrors.ErrUpstreamUnavailable) {
 		app.serviceUnavailableResponse(w, r, err)
 		return
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	helper "github.com/opendatahub-io/mod-arch-library/bff/internal/helpers"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+)
+
+const (
+	maxAgentMessageTextLength = 32 * 1024
+	maxAgentMessageIDLength   = 256
+)
+
+type AgentMessageEnvelope Envelope[*models.AgentMessageEvent, None]
+
+// SendAgentMessageHandler handles POST /api/v1/agents/runtimes/:ns/:name/messages.
+// Agents whose card advertises streaming answer with a text/event-stream of
+// AgentMessageEvent frames; other agents answer with a single JSON envelope.
+func (app *App) SendAgentMessageHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName("ns")
+	name := ps.ByName("name")
+	if err := validateAgentPathParams(namespace, name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var req models.AgentMessageRequest
+	if err := app.ReadJSON(w, r, &req); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if err := validateAgentMessageRequest(&req); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	logger := helper.GetContextLoggerFromReq(r)
+	logger.Info("Sending agent test message",
+		slog.String("namespace", namespace),
+		slog.String("name", name),
+		slog.String("skillId", req.SkillID))
+
+	stream := &agentEventStream{w: w}
+	result, err := app.repositories.AgentMessages.SendAgentMessage(r.Context(), namespace, name, req, stream.write)
+	if err != nil {
+		logger.Error("Failed to send agent message",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Any("error", err))
+		if stream.started {
+			stream.writeError(err)
+			return
+		}
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+	if stream.started {
+		return
+	}
+	if result == nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("agent %s/%s returned no events", namespace, name))
+		return
+	}
+
+	envelope := AgentMessageEnvelope{
+		Data: result,
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, envelope, nil); err != nil {
+		logger.Error("Failed to write JSON response",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Int("status", http.StatusOK),
+			slog.Any("error", err))
+	}
+}
+
+func validateAgentMessageRequest(req *models.AgentMessageRequest) error {
+	if strings.TrimSpace(req.Text) == "" && len(req.Data) == 0 {
+		return errors.New("text or data is required")
+	}
+	if len(req.Text) > maxAgentMessageTextLength {
+		return fmt.Errorf("text exceeds maximum length of %d bytes", maxAgentMessageTextLength)
+	}
+	for field, value := range map[string]string{"skillId": req.SkillID, "contextId": req.ContextID, "taskId": req.TaskID} {
+		if len(value) > maxAgentMessageIDLength {
+			return fmt.Errorf("%s exceeds maximum length of %d", field, maxAgentMessageIDLength)
+		}
+	}
+	return nil
+}
+
+// agentEventStream writes server-sent events, sending the SSE headers lazily so
+// failures before the first event can still be reported as regular JSON errors.
+type agentEventStream struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (s *agentEventStream) write(event models.AgentMessageEvent) error {
+	return s.send(event.Kind, event)
+}
+
+func (s *agentEventStream) writeError(err error) {
+	_ = s.send("error", ErrorPayload{Code: ErrCodeServiceUnavailable, Message: err.Error()})
+}
+
+func (s *agentEventStream) send(eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if !s.started {
+		s.w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		s.w.Header().Set("Cache-Control", "no-cache, no-transform")
+		s.w.Header().Set("Connection", "keep-alive")
+		s.w.Header().Set("X-Accel-Buffering", "no")
+		// Agent tasks can outlive the server WriteTimeout; the request context still bounds the stream.
+		_ = http.NewResponseController(s.w).SetWriteDeadline(time.Time{})
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", eventType, payload); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
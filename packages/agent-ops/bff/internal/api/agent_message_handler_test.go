@@ -0,0 +1,128 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/config"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	agentsmock "github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents/mocks"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func agentMessageApp(t *testing.T, streaming bool) *App {
+	t.Helper()
+	server := agentsmock.NewA2AServer()
+	t.Cleanup(server.Close)
+
+	client := agentsmock.NewClient()
+	client.Details["agent-ops-demo/echo-agent"] = agents.AgentDetail{
+		Metadata: agents.AgentMetadata{Name: "echo-agent", Namespace: "agent-ops-demo"},
+		Service: &agents.AgentService{
+			Name:  "echo-agent",
+			Ports: []agents.AgentServicePort{{Name: "http", Port: 8080}},
+		},
+		AgentCard: &agents.AgentCardObserved{Name: "echo-agent", Streaming: streaming},
+	}
+	factory := &agentsmock.Factory{Client: client}
+	repos := repositories.NewRepositories(factory)
+	repos.AgentMessages = repositories.NewAgentMessagesRepository(factory, server.A2AClient())
+	return NewTestApp(config.EnvConfig{}, nil, nil, repos)
+}
+
+func agentMessageRequest(t *testing.T, body models.AgentMessageRequest) *http.Request {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	return httptest.NewRequest(http.MethodPost, ApiPathPrefix+"/agents/runtimes/agent-ops-demo/echo-agent/messages", bytes.NewReader(payload))
+}
+
+var echoAgentParams = httprouter.Params{
+	{Key: "ns", Value: "agent-ops-demo"},
+	{Key: "name", Value: "echo-agent"},
+}
+
+func TestSendAgentMessageHandler_JSON(t *testing.T) {
+	app := agentMessageApp(t, false)
+	rr := httptest.NewRecorder()
+
+	app.SendAgentMessageHandler(rr, agentMessageRequest(t, models.AgentMessageRequest{Text: "hello"}), echoAgentParams)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "application/json")
+	var envelope AgentMessageEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&envelope))
+	require.NotNil(t, envelope.Data)
+	assert.Equal(t, "task", envelope.Data.Kind)
+	assert.Equal(t, "completed", envelope.Data.State)
+	require.Len(t, envelope.Data.Artifacts, 1)
+	assert.Equal(t, "echo: hello", envelope.Data.Artifacts[0].Parts[0].Text)
+}
+
+func TestSendAgentMessageHandler_Streams(t *testing.T) {
+	app := agentMessageApp(t, true)
+	rr := httptest.NewRecorder()
+
+	app.SendAgentMessageHandler(rr, agentMessageRequest(t, models.AgentMessageRequest{Text: "hello"}), echoAgentParams)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Type"), "text/event-stream")
+
+	var eventTypes []string
+	var last models.AgentMessageEvent
+	scanner := bufio.NewScanner(rr.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if eventType, ok := strings.CutPrefix(line, "event: "); ok {
+			eventTypes = append(eventTypes, eventType)
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			require.NoError(t, json.Unmarshal([]byte(data), &last))
+		}
+	}
+	assert.Equal(t, []string{"task", "status-update", "artifact-update", "status-update"}, eventTypes)
+	assert.True(t, last.Final)
+	assert.Equal(t, "completed", last.State)
+}
+
+func TestSendAgentMessageHandler_BadRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body models.AgentMessageRequest
+	}{
+		{"empty message", models.AgentMessageRequest{}},
+		{"oversized text", models.AgentMessageRequest{Text: strings.Repeat("a", maxAgentMessageTextLength+1)}},
+		{"oversized task id", models.AgentMessageRequest{Text: "hi", TaskID: strings.Repeat("t", maxAgentMessageIDLength+1)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := agentMessageApp(t, false)
+			rr := httptest.NewRecorder()
+
+			app.SendAgentMessageHandler(rr, agentMessageRequest(t, tc.body), echoAgentParams)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestSendAgentMessageHandler_NotFound(t *testing.T) {
+	app := agentMessageApp(t, false)
+	rr := httptest.NewRecorder()
+
+	app.SendAgentMessageHandler(rr, agentMessageRequest(t, models.AgentMessageRequest{Text: "hi"}), httprouter.Params{
+		{Key: "ns", Value: "agent-ops-demo"},
+		{Key: "name", Value: "missing-agent"},
+	})
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
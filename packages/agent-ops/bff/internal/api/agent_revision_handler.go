@@ -0,0 +1,235 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	helper "github.com/opendatahub-io/mod-arch-library/bff/internal/helpers"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+)
+
+// maxDNS1123LabelLength bounds the agent name so the canary workload and Service
+// name (name + agents.CanarySuffix) is still a valid DNS-1123 label.
+const maxDNS1123LabelLength = 63
+
+type AgentRevisionListEnvelope Envelope[*models.AgentRevisionList, None]
+
+type AgentRevisionEnvelope Envelope[*models.AgentRevision, None]
+
+type AgentCanaryEnvelope Envelope[*models.AgentCanaryStatus, None]
+
+// ListAgentRevisionsHandler handles GET /api/v1/agents/runtimes/:ns/:name/revisions.
+func (app *App) ListAgentRevisionsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName("ns")
+	name := ps.ByName("name")
+	if err := validateAgentPathParams(namespace, name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	logger := helper.GetContextLoggerFromReq(r)
+	result, err := app.repositories.AgentRuntimes.ListAgentRevisions(r.Context(), namespace, name)
+	if err != nil {
+		logger.Error("Failed to list agent revisions",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Any("error", err))
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, AgentRevisionListEnvelope{Data: result}, nil); err != nil {
+		logger.Error("Failed to write response", slog.Any("error", err))
+	}
+}
+
+// RedeployAgentHandler handles PUT /api/v1/agents/runtimes/:ns/:name. The body is a
+// deploy request for a prebuilt image; the agent is replaced in place and a new
+// revision is recorded.
+func (app *App) RedeployAgentHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName("ns")
+	name := ps.ByName("name")
+	if err := validateAgentPathParams(namespace, name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var req models.DeployAgentRequest
+	if err := app.ReadJSON(w, r, &req); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if (req.Name != "" && req.Name != name) || (req.Namespace != "" && req.Namespace != namespace) {
+		app.badRequestResponse(w, r, fmt.Errorf("name and namespace must match the agent being redeployed"))
+		return
+	}
+	if req.Source != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("source is not supported on redeploy; build the image and redeploy with containerImage"))
+		return
+	}
+	req.Name = name
+	req.Namespace = namespace
+	applyDeployDefaults(&req)
+	if err := validateDeployRequest(&req); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	logger := helper.GetContextLoggerFromReq(r)
+	logger.Info("Redeploying agent", slog.String("namespace", namespace), slog.String("name", name))
+
+	result, err := app.repositories.AgentRuntimes.RedeployAgent(r.Context(), mapDeployRequestToParams(&req))
+	if err != nil {
+		logger.Error("Failed to redeploy agent",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Any("error", err))
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, AgentRevisionEnvelope{Data: result}, nil); err != nil {
+		logger.Error("Failed to write response", slog.Any("error", err))
+	}
+}
+
+// RollbackAgentHandler handles POST /api/v1/agents/runtimes/:ns/:name/rollback.
+func (app *App) RollbackAgentHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName("ns")
+	name := ps.ByName("name")
+	if err := validateAgentPathParams(namespace, name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var req models.RollbackAgentRequest
+	if err := app.ReadJSON(w, r, &req); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if req.Revision < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("revision must be a positive revision number"))
+		return
+	}
+
+	logger := helper.GetContextLoggerFromReq(r)
+	logger.Info("Rolling back agent",
+		slog.String("namespace", namespace),
+		slog.String("name", name),
+		slog.Int64("revision", req.Revision))
+
+	result, err := app.repositories.AgentRuntimes.RollbackAgent(r.Context(), namespace, name, req.Revision)
+	if err != nil {
+		logger.Error("Failed to roll back agent",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Any("error", err))
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, AgentRevisionEnvelope{Data: result}, nil); err != nil {
+		logger.Error("Failed to write response", slog.Any("error", err))
+	}
+}
+
+// SetAgentCanaryHandler handles PUT /api/v1/agents/runtimes/:ns/:name/canary.
+func (app *App) SetAgentCanaryHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName("ns")
+	name := ps.ByName("name")
+	if err := validateAgentPathParams(namespace, name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if len(name)+len(agents.CanarySuffix) > maxDNS1123LabelLength {
+		app.badRequestResponse(w, r, fmt.Errorf("agent name %q is too long for a canary; at most %d characters are supported",
+			name, maxDNS1123LabelLength-len(agents.CanarySuffix)))
+		return
+	}
+
+	var req models.AgentCanaryRequest
+	if err := app.ReadJSON(w, r, &req); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if req.Revision < 1 {
+		app.badRequestResponse(w, r, fmt.Errorf("revision must be a positive revision number"))
+		return
+	}
+	if req.Weight < 1 || req.Weight > 99 {
+		app.badRequestResponse(w, r, fmt.Errorf("weight must be between 1 and 99"))
+		return
+	}
+
+	logger := helper.GetContextLoggerFromReq(r)
+	logger.Info("Configuring agent canary",
+		slog.String("namespace", namespace),
+		slog.String("name", name),
+		slog.Int64("revision", req.Revision),
+		slog.Int("weight", int(req.Weight)))
+
+	result, err := app.repositories.AgentRuntimes.SetAgentCanary(r.Context(), namespace, name, req.Revision, req.Weight)
+	if err != nil {
+		logger.Error("Failed to configure agent canary",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Any("error", err))
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, AgentCanaryEnvelope{Data: result}, nil); err != nil {
+		logger.Error("Failed to write response", slog.Any("error", err))
+	}
+}
+
+// EndAgentCanaryHandler handles DELETE /api/v1/agents/runtimes/:ns/:name/canary.
+// With ?promote=true the canary revision is deployed as the agent's new revision,
+// which is returned; otherwise traffic returns to the current revision and the
+// response has no content.
+func (app *App) EndAgentCanaryHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName("ns")
+	name := ps.ByName("name")
+	if err := validateAgentPathParams(namespace, name); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	promote := false
+	if raw := r.URL.Query().Get("promote"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			app.badRequestResponse(w, r, fmt.Errorf("invalid promote %q: must be true or false", raw))
+			return
+		}
+		promote = parsed
+	}
+
+	logger := helper.GetContextLoggerFromReq(r)
+	logger.Info("Ending agent canary",
+		slog.String("namespace", namespace),
+		slog.String("name", name),
+		slog.Bool("promote", promote))
+
+	result, err := app.repositories.AgentRuntimes.EndAgentCanary(r.Context(), namespace, name, promote)
+	if err != nil {
+		logger.Error("Failed to end agent canary",
+			slog.String("namespace", namespace),
+			slog.String("name", name),
+			slog.Any("error", err))
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+
+	if result == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := app.WriteJSON(w, http.StatusOK, AgentRevisionEnvelope{Data: result}, nil); err != nil {
+		logger.Error("Failed to write response", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/config"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	agentsmock "github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents/mocks"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func revisionApp(t *testing.T) *App {
+	t.Helper()
+	client := agentsmock.NewClient()
+	_, err := client.DeployAgent(context.Background(), &agents.DeployAgentParams{
+		Name:           "echo-agent",
+		Namespace:      "agent-ops-demo",
+		ContainerImage: "quay.io/example/echo",
+		ImageTag:       "v1",
+	})
+	require.NoError(t, err)
+	return &App{
+		config:       config.EnvConfig{AuthMethod: config.AuthMethodDisabled},
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		repositories: repositories.NewRepositories(&agentsmock.Factory{Client: client}),
+	}
+}
+
+func jsonBody(t *testing.T, v any) io.Reader {
+	t.Helper()
+	payload, err := json.Marshal(v)
+	require.NoError(t, err)
+	return bytes.NewReader(payload)
+}
+
+func redeployEcho(t *testing.T, app *App, tag string) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	app.RedeployAgentHandler(rr, httptest.NewRequest(http.MethodPut, AgentRuntimeDetailPath,
+		jsonBody(t, models.DeployAgentRequest{ContainerImage: "quay.io/example/echo", ImageTag: tag})), echoAgentParams)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+}
+
+func TestRedeployAndRollbackAgentHandlers(t *testing.T) {
+	app := revisionApp(t)
+	redeployEcho(t, app, "v2")
+
+	rr := httptest.NewRecorder()
+	app.ListAgentRevisionsHandler(rr, httptest.NewRequest(http.MethodGet, AgentRevisionsPath, nil), echoAgentParams)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var list AgentRevisionListEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&list))
+	require.Len(t, list.Data.Items, 2)
+	assert.Equal(t, int64(2), list.Data.Items[0].Revision)
+	assert.True(t, list.Data.Items[0].Current)
+	assert.Equal(t, "quay.io/example/echo:v2", list.Data.Items[0].ContainerImage)
+	assert.False(t, list.Data.Items[1].Current)
+
+	rr = httptest.NewRecorder()
+	app.RollbackAgentHandler(rr, httptest.NewRequest(http.MethodPost, AgentRollbackPath,
+		jsonBody(t, models.RollbackAgentRequest{Revision: 1})), echoAgentParams)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var rolledBack AgentRevisionEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&rolledBack))
+	assert.Equal(t, int64(3), rolledBack.Data.Revision)
+	assert.Equal(t, "quay.io/example/echo:v1", rolledBack.Data.ContainerImage)
+
+	rr = httptest.NewRecorder()
+	app.RollbackAgentHandler(rr, httptest.NewRequest(http.MethodPost, AgentRollbackPath,
+		jsonBody(t, models.RollbackAgentRequest{Revision: 9})), echoAgentParams)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestRedeployAgentHandler_BadRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body models.DeployAgentRequest
+	}{
+		{"name mismatch", models.DeployAgentRequest{Name: "other", ContainerImage: "quay.io/example/echo", ImageTag: "v2"}},
+		{"source", models.DeployAgentRequest{Source: &models.DeploySource{RepoURL: "https://github.com/example/echo"}}},
+		{"missing image", models.DeployAgentRequest{ImageTag: "v2"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := revisionApp(t)
+			rr := httptest.NewRecorder()
+			app.RedeployAgentHandler(rr, httptest.NewRequest(http.MethodPut, AgentRuntimeDetailPath, jsonBody(t, tc.body)), echoAgentParams)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
+
+func TestAgentCanaryHandlers(t *testing.T) {
+	app := revisionApp(t)
+	redeployEcho(t, app, "v2")
+
+	rr := httptest.NewRecorder()
+	app.SetAgentCanaryHandler(rr, httptest.NewRequest(http.MethodPut, AgentCanaryPath,
+		jsonBody(t, models.AgentCanaryRequest{Revision: 1, Weight: 10})), echoAgentParams)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var canary AgentCanaryEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&canary))
+	assert.Equal(t, int32(10), canary.Data.Weight)
+	assert.Equal(t, "echo-agent-canary", canary.Data.WorkloadName)
+
+	rr = httptest.NewRecorder()
+	app.GetAgentRuntimeDetailHandler(rr, httptest.NewRequest(http.MethodGet, AgentRuntimeDetailPath, nil), echoAgentParams)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var detail AgentRuntimeDetailEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&detail))
+	assert.Equal(t, int64(2), detail.Data.Revision)
+	require.NotNil(t, detail.Data.Canary)
+	assert.Equal(t, int64(1), detail.Data.Canary.Revision)
+	assert.Equal(t, int32(10), detail.Data.Canary.Weight)
+
+	// Redeploying while a canary runs would leave the split pointing at a stale baseline.
+	rr = httptest.NewRecorder()
+	app.RedeployAgentHandler(rr, httptest.NewRequest(http.MethodPut, AgentRuntimeDetailPath,
+		jsonBody(t, models.DeployAgentRequest{ContainerImage: "quay.io/example/echo", ImageTag: "v3"})), echoAgentParams)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = httptest.NewRecorder()
+	app.EndAgentCanaryHandler(rr, httptest.NewRequest(http.MethodDelete, AgentCanaryPath+"?promote=true", nil), echoAgentParams)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var promoted AgentRevisionEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&promoted))
+	assert.Equal(t, int64(3), promoted.Data.Revision)
+	assert.Equal(t, "quay.io/example/echo:v1", promoted.Data.ContainerImage)
+
+	rr = httptest.NewRecorder()
+	app.EndAgentCanaryHandler(rr, httptest.NewRequest(http.MethodDelete, AgentCanaryPath, nil), echoAgentParams)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestSetAgentCanaryHandler_BadRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		params httprouter.Params
+		body   models.AgentCanaryRequest
+	}{
+		{"zero weight", echoAgentParams, models.AgentCanaryRequest{Revision: 1, Weight: 0}},
+		{"full weight", echoAgentParams, models.AgentCanaryRequest{Revision: 1, Weight: 100}},
+		{"missing revision", echoAgentParams, models.AgentCanaryRequest{Weight: 10}},
+		{"current revision", echoAgentParams, models.AgentCanaryRequest{Revision: 1, Weight: 10}},
+		{"name too long", httprouter.Params{
+			{Key: "ns", Value: "agent-ops-demo"},
+			{Key: "name", Value: strings.Repeat("a", 60)},
+		}, models.AgentCanaryRequest{Revision: 1, Weight: 10}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := revisionApp(t)
+			rr := httptest.NewRecorder()
+			app.SetAgentCanaryHandler(rr, httptest.NewRequest(http.MethodPut, AgentCanaryPath, jsonBody(t, tc.body)), tc.params)
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+}
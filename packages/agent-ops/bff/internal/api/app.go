@@ -42,6 +42,12 @@ const (
 	AgentStopPath          = AgentRuntimeDetailPath + "/stop"
 	AgentStartPath         = AgentRuntimeDetailPath + "/start"
 	AgentRestartPath       = AgentRuntimeDetailPath + "/restart"
+	AgentBuildPath         = ApiPathPrefix + "/agents/builds/:ns/:name"
+	AgentBuildDeployPath   = AgentBuildPath + "/deploy"
+	AgentMessagesPath      = AgentRuntimeDetailPath + "/messages"
+	AgentRevisionsPath     = AgentRuntimeDetailPath + "/revisions"
+	AgentRollbackPath      = AgentRuntimeDetailPath + "/rollback"
+	AgentCanaryPath        = AgentRuntimeDetailPath + "/canary"
 )
 
 var hashPattern = regexp.MustCompile(`[.\-][0-9a-f]{8,}`)
@@ -246,6 +252,30 @@ func (app *App) Routes() http.Handler {
 	apiRouter.POST(AgentRestartPath,
 		app.AttachNamespaceFromParam("ns",
 			app.RequireAuthenticatedForAgents(app.RestartAgentHandler)))
+	apiRouter.GET(AgentBuildPath,
+		app.AttachNamespaceFromParam("ns",
+			app.RequireAuthenticatedForAgents(app.GetAgentBuildHandler)))
+	apiRouter.POST(AgentBuildDeployPath,
+		app.AttachNamespaceFromParam("ns",
+			app.RequireAuthenticatedForAgents(app.DeployAgentBuildHandler)))
+	apiRouter.POST(AgentMessagesPath,
+		app.AttachNamespaceFromParam("ns",
+			app.RequireAuthenticatedForAgents(app.SendAgentMessageHandler)))
+	apiRouter.PUT(AgentRuntimeDetailPath,
+		app.AttachNamespaceFromParam("ns",
+			app.RequireAuthenticatedForAgents(app.RedeployAgentHandler)))
+	apiRouter.GET(AgentRevisionsPath,
+		app.AttachNamespaceFromParam("ns",
+			app.RequireAuthenticatedForAgents(app.ListAgentRevisionsHandler)))
+	apiRouter.POST(AgentRollbackPath,
+		app.AttachNamespaceFromParam("ns",
+			app.RequireAuthenticatedForAgents(app.RollbackAgentHandler)))
+	apiRouter.PUT(AgentCanaryPath,
+		app.AttachNamespaceFromParam("ns",
+			app.RequireAuthenticatedForAgents(app.SetAgentCanaryHandler)))
+	apiRouter.DELETE(AgentCanaryPath,
+		app.AttachNamespaceFromParam("ns",
+			app.RequireAuthenticatedForAgents(app.EndAgentCanaryHandler)))
 	apiRouter.DELETE(AgentRuntimeDetailPath,
 		app.AttachNamespaceFromParam("ns",
 			app.RequireAuthenticatedForAgents(app.DeleteAgentHandler)))
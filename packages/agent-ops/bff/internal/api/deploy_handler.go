@@ -6,6 +6,7 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	helper "github.com/opendatahub-io/mod-arch-library/bff/internal/helpers"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
 	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
 )
 
@@ -32,6 +33,11 @@ func (app *App) DeployAgentHandler(w http.ResponseWriter, r *http.Request, _ htt
 
 	params := mapDeployRequestToParams(&req)
 
+	if params.Source != nil {
+		app.deployAgentFromSource(w, r, params)
+		return
+	}
+
 	result, err := app.repositories.AgentRuntimes.DeployAgent(ctx, params)
 	if err != nil {
 		logger.Error("Failed to deploy agent",
@@ -58,3 +64,35 @@ func (app *App) DeployAgentHandler(w http.ResponseWriter, r *http.Request, _ htt
 			slog.Any("error", err))
 	}
 }
+
+// deployAgentFromSource starts the image build and answers 202 Accepted; the
+// agent is deployed through the build deploy endpoint once the build succeeds.
+func (app *App) deployAgentFromSource(w http.ResponseWriter, r *http.Request, params *agents.DeployAgentParams) {
+	logger := helper.GetContextLoggerFromReq(r)
+
+	result, err := app.repositories.AgentRuntimes.DeployAgentFromSource(r.Context(), params)
+	if err != nil {
+		logger.Error("Failed to start agent build",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Any("error", err))
+		app.handleAgentRepositoryError(w, r, err)
+		return
+	}
+
+	logger.Info("Agent build started",
+		slog.String("name", result.Name),
+		slog.String("namespace", result.Namespace),
+		slog.String("repoUrl", params.Source.RepoURL))
+
+	envelope := DeployAgentEnvelope{
+		Data: result,
+	}
+	if err := app.WriteJSON(w, http.StatusAccepted, envelope, nil); err != nil {
+		logger.Error("Failed to write JSON response",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", http.StatusAccepted),
+			slog.Any("error", err))
+	}
+}
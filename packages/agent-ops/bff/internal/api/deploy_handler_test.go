@@ -141,3 +141,68 @@ func TestDeployAgentHandler_AuthDisabledSkipsRBAC(t *testing.T) {
 
 	require.Equal(t, http.StatusCreated, rr.Code)
 }
+
+func TestDeployAgentHandler_FromSourceStartsBuild(t *testing.T) {
+	app := deployApp()
+	req := deployRequest(t, models.DeployAgentRequest{
+		Name:      "src-agent",
+		Namespace: "default",
+		Source:    &models.DeploySource{RepoURL: "https://github.com/example/agent.git", Ref: "main"},
+	})
+	rr := httptest.NewRecorder()
+
+	app.DeployAgentHandler(rr, req, httprouter.Params{})
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+	var envelope DeployAgentEnvelope
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&envelope))
+	require.NotNil(t, envelope.Data.Build)
+	assert.Equal(t, "shipwright", envelope.Data.Build.BuildStrategy)
+	assert.Equal(t, "https://github.com/example/agent.git", envelope.Data.Build.RepoURL)
+
+	statusReq := httptest.NewRequest(http.MethodGet, "/api/v1/agents/builds/default/src-agent", nil)
+	statusRR := httptest.NewRecorder()
+	app.GetAgentBuildHandler(statusRR, statusReq, httprouter.Params{
+		{Key: "ns", Value: "default"},
+		{Key: "name", Value: "src-agent"},
+	})
+
+	require.Equal(t, http.StatusOK, statusRR.Code)
+	var buildEnvelope AgentBuildEnvelope
+	require.NoError(t, json.NewDecoder(statusRR.Body).Decode(&buildEnvelope))
+	assert.Equal(t, "Succeeded", buildEnvelope.Data.Phase)
+	assert.False(t, buildEnvelope.Data.Deployed, "reading the build status must not deploy the agent")
+
+	deployReq := httptest.NewRequest(http.MethodPost, "/api/v1/agents/builds/default/src-agent/deploy", nil)
+	deployRR := httptest.NewRecorder()
+	app.DeployAgentBuildHandler(deployRR, deployReq, httprouter.Params{
+		{Key: "ns", Value: "default"},
+		{Key: "name", Value: "src-agent"},
+	})
+
+	require.Equal(t, http.StatusCreated, deployRR.Code)
+	var deployEnvelope DeployAgentEnvelope
+	require.NoError(t, json.NewDecoder(deployRR.Body).Decode(&deployEnvelope))
+	require.NotNil(t, deployEnvelope.Data.Build)
+	assert.True(t, deployEnvelope.Data.Build.Deployed)
+
+	againRR := httptest.NewRecorder()
+	app.DeployAgentBuildHandler(againRR, deployReq, httprouter.Params{
+		{Key: "ns", Value: "default"},
+		{Key: "name", Value: "src-agent"},
+	})
+	assert.Equal(t, http.StatusConflict, againRR.Code)
+}
+
+func TestGetAgentBuildHandler_NotFound(t *testing.T) {
+	app := deployApp()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/agents/builds/default/missing", nil)
+	rr := httptest.NewRecorder()
+
+	app.GetAgentBuildHandler(rr, req, httprouter.Params{
+		{Key: "ns", Value: "default"},
+		{Key: "name", Value: "missing"},
+	})
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -2,15 +2,30 @@ package api
 
 import (
 	"fmt"
+	"net/url"
+	"path"
 	"regexp"
 	"strings"
 
 	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
 	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
 
+var gitRefRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+var dns1123SubdomainRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+var configKeyRegex = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+var validBuildStrategies = map[string]bool{
+	agents.BuildStrategyShipwright:  true,
+	agents.BuildStrategyBuildConfig: true,
+	"":                              true,
+}
+
 var validProtocols = map[string]bool{
 	"a2a": true,
 	"mcp": true,
@@ -26,6 +41,12 @@ var validServicePortProtocols = map[string]bool{
 const (
 	maxDeployEnvVars      = 100
 	maxDeployServicePorts = 20
+	maxDeployVolumeMounts = 20
+
+	maxAgentReplicas                 = 1
+	defaultScaleToZeroIdleTimeoutSec = 300
+	minScaleToZeroIdleTimeoutSec     = 30
+	maxScaleToZeroIdleTimeoutSec     = 86400
 )
 
 func validateDeployRequest(req *models.DeployAgentRequest) error {
@@ -35,14 +56,20 @@ func validateDeployRequest(req *models.DeployAgentRequest) error {
 	if !isValidDNS1123Label(req.Namespace) {
 		return fmt.Errorf("invalid namespace %q", req.Namespace)
 	}
-	if req.ContainerImage == "" {
-		return fmt.Errorf("containerImage is required")
-	}
-	if strings.Contains(req.ContainerImage, ":") {
-		return fmt.Errorf("containerImage must not include a tag — use imageTag instead")
-	}
-	if req.ImageTag == "" {
-		return fmt.Errorf("imageTag is required")
+	if req.Source != nil {
+		if err := validateDeploySource(req); err != nil {
+			return err
+		}
+	} else {
+		if req.ContainerImage == "" {
+			return fmt.Errorf("containerImage is required")
+		}
+		if strings.Contains(req.ContainerImage, ":") {
+			return fmt.Errorf("containerImage must not include a tag — use imageTag instead")
+		}
+		if req.ImageTag == "" {
+			return fmt.Errorf("imageTag is required")
+		}
 	}
 	if req.Framework != "" && !isValidLabelValue(req.Framework) {
 		return fmt.Errorf("invalid framework %q: must be a valid Kubernetes label value (max 63 chars, alphanumeric, '-', '_', or '.')", req.Framework)
@@ -66,6 +93,11 @@ func validateDeployRequest(req *models.DeployAgentRequest) error {
 		if !envVarNameRegex.MatchString(e.Name) {
 			return fmt.Errorf("envVars[%d].name %q is not a valid C_IDENTIFIER", i, e.Name)
 		}
+		if e.ValueFrom != nil {
+			if err := validateEnvVarSource(i, e); err != nil {
+				return err
+			}
+		}
 	}
 	for i, p := range req.ServicePorts {
 		if p.Port < 1 || p.Port > 65535 {
@@ -78,6 +110,186 @@ func validateDeployRequest(req *models.DeployAgentRequest) error {
 			return fmt.Errorf("servicePorts[%d].protocol %q must be one of TCP, UDP, SCTP", i, p.Protocol)
 		}
 	}
+	if err := validateDeployResources(req.Resources); err != nil {
+		return err
+	}
+	if err := validateDeployScaling(req.Scaling); err != nil {
+		return err
+	}
+	return validateVolumeMounts(req.VolumeMounts)
+}
+
+// validateEnvVarSource checks the shape of a Secret or ConfigMap reference. Whether the
+// object and key exist is checked against the target namespace at deploy time.
+func validateEnvVarSource(i int, e models.EnvVar) error {
+	if e.Value != "" {
+		return fmt.Errorf("envVars[%d] must set either value or valueFrom, not both", i)
+	}
+	src := e.ValueFrom
+	if (src.SecretKeyRef == nil) == (src.ConfigMapKeyRef == nil) {
+		return fmt.Errorf("envVars[%d].valueFrom must set exactly one of secretKeyRef, configMapKeyRef", i)
+	}
+	field, ref := "secretKeyRef", src.SecretKeyRef
+	if ref == nil {
+		field, ref = "configMapKeyRef", src.ConfigMapKeyRef
+	}
+	if !isValidDNS1123Subdomain(ref.Name) {
+		return fmt.Errorf("envVars[%d].valueFrom.%s.name %q is not a valid object name", i, field, ref.Name)
+	}
+	if len(ref.Key) > 253 || !configKeyRegex.MatchString(ref.Key) {
+		return fmt.Errorf("envVars[%d].valueFrom.%s.key %q is not a valid key", i, field, ref.Key)
+	}
+	return nil
+}
+
+func validateDeployResources(res *models.ResourceRequirements) error {
+	if res == nil {
+		return nil
+	}
+	requests, err := parseResourceList("resources.requests", res.Requests)
+	if err != nil {
+		return err
+	}
+	limits, err := parseResourceList("resources.limits", res.Limits)
+	if err != nil {
+		return err
+	}
+	for name, request := range requests {
+		if limit, ok := limits[name]; ok && request.Cmp(limit) > 0 {
+			return fmt.Errorf("resources.requests.%s must not exceed resources.limits.%s", name, name)
+		}
+	}
+	return nil
+}
+
+func parseResourceList(field string, list *models.ResourceList) (map[string]resource.Quantity, error) {
+	parsed := map[string]resource.Quantity{}
+	if list == nil {
+		return parsed, nil
+	}
+	for name, value := range map[string]string{"cpu": list.CPU, "memory": list.Memory} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil || quantity.Sign() <= 0 {
+			return nil, fmt.Errorf("%s.%s %q must be a positive Kubernetes quantity", field, name, value)
+		}
+		parsed[name] = quantity
+	}
+	return parsed, nil
+}
+
+// validateDeployScaling checks autoscaling settings. An agent runs as a single-pod
+// Sandbox, so scaling can only move it between zero and one replica.
+func validateDeployScaling(scaling *models.ScalingSpec) error {
+	if scaling == nil {
+		return nil
+	}
+	if !scaling.ScaleToZero {
+		return fmt.Errorf("scaling requires scaleToZero; an agent runs a single replica")
+	}
+	if scaling.MinReplicas != 0 {
+		return fmt.Errorf("scaling.minReplicas must be 0 or omitted when scaleToZero is set")
+	}
+	if scaling.MaxReplicas != maxAgentReplicas {
+		return fmt.Errorf("scaling.maxReplicas must be %d; an agent runs a single replica", maxAgentReplicas)
+	}
+	if scaling.IdleTimeoutSeconds < minScaleToZeroIdleTimeoutSec || scaling.IdleTimeoutSeconds > maxScaleToZeroIdleTimeoutSec {
+		return fmt.Errorf("scaling.idleTimeoutSeconds must be between %d and %d", minScaleToZeroIdleTimeoutSec, maxScaleToZeroIdleTimeoutSec)
+	}
+	return nil
+}
+
+func validateVolumeMounts(mounts []models.VolumeMount) error {
+	if len(mounts) > maxDeployVolumeMounts {
+		return fmt.Errorf("volumeMounts exceeds maximum of %d items", maxDeployVolumeMounts)
+	}
+	names := make(map[string]bool, len(mounts))
+	paths := make(map[string]bool, len(mounts))
+	for i, m := range mounts {
+		if !isValidDNS1123Label(m.Name) {
+			return fmt.Errorf("volumeMounts[%d].name %q is not a valid DNS-1123 label", i, m.Name)
+		}
+		if names[m.Name] {
+			return fmt.Errorf("volumeMounts[%d].name %q is used more than once", i, m.Name)
+		}
+		names[m.Name] = true
+
+		if !strings.HasPrefix(m.MountPath, "/") || m.MountPath == "/" || path.Clean(m.MountPath) != m.MountPath {
+			return fmt.Errorf("volumeMounts[%d].mountPath must be a clean absolute path other than /", i)
+		}
+		if paths[m.MountPath] {
+			return fmt.Errorf("volumeMounts[%d].mountPath %q is used more than once", i, m.MountPath)
+		}
+		paths[m.MountPath] = true
+		if err := validateRelativePath(fmt.Sprintf("volumeMounts[%d].subPath", i), m.SubPath); err != nil {
+			return err
+		}
+
+		sources := 0
+		for _, source := range []string{m.Secret, m.ConfigMap, m.PersistentVolumeClaim} {
+			if source == "" {
+				continue
+			}
+			sources++
+			if !isValidDNS1123Subdomain(source) {
+				return fmt.Errorf("volumeMounts[%d] references invalid object name %q", i, source)
+			}
+		}
+		if sources != 1 {
+			return fmt.Errorf("volumeMounts[%d] must set exactly one of secret, configMap, persistentVolumeClaim", i)
+		}
+	}
+	return nil
+}
+
+func isValidDNS1123Subdomain(name string) bool {
+	return len(name) > 0 && len(name) <= 253 && dns1123SubdomainRegex.MatchString(name)
+}
+
+// validateDeploySource checks a deploy-from-source request. The output image is
+// chosen by the BFF, so containerImage must be empty; imageTag may name the output tag.
+func validateDeploySource(req *models.DeployAgentRequest) error {
+	src := req.Source
+	if req.ContainerImage != "" {
+		return fmt.Errorf("containerImage must be empty when deploying from source")
+	}
+	if req.ImageTag != "" && !isValidLabelValue(req.ImageTag) {
+		return fmt.Errorf("invalid imageTag %q", req.ImageTag)
+	}
+	if src.RepoURL == "" {
+		return fmt.Errorf("source.repoUrl is required")
+	}
+	u, err := url.Parse(src.RepoURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("source.repoUrl must be an https git URL")
+	}
+	if u.User != nil {
+		return fmt.Errorf("source.repoUrl must not embed credentials — use imagePullSecret or a source secret instead")
+	}
+	if src.Ref != "" && (!gitRefRegex.MatchString(src.Ref) || strings.Contains(src.Ref, "..")) {
+		return fmt.Errorf("invalid source.ref %q", src.Ref)
+	}
+	if err := validateRelativePath("source.contextDir", src.ContextDir); err != nil {
+		return err
+	}
+	if err := validateRelativePath("source.dockerfile", src.Dockerfile); err != nil {
+		return err
+	}
+	if !validBuildStrategies[src.BuildStrategy] {
+		return fmt.Errorf("invalid source.buildStrategy %q: must be one of shipwright, buildconfig", src.BuildStrategy)
+	}
+	return nil
+}
+
+func validateRelativePath(field, value string) error {
+	if value == "" {
+		return nil
+	}
+	if strings.HasPrefix(value, "/") || path.Clean(value) != value || strings.HasPrefix(value, "..") {
+		return fmt.Errorf("%s must be a clean relative path", field)
+	}
 	return nil
 }
 
@@ -90,6 +302,14 @@ func applyDeployDefaults(req *models.DeployAgentRequest) {
 			{Name: "http", Port: 8080, TargetPort: 8000, Protocol: "TCP"},
 		}
 	}
+	if s := req.Scaling; s != nil {
+		if s.MaxReplicas == 0 {
+			s.MaxReplicas = maxAgentReplicas
+		}
+		if s.ScaleToZero && s.IdleTimeoutSeconds == 0 {
+			s.IdleTimeoutSeconds = defaultScaleToZeroIdleTimeoutSec
+		}
+	}
 }
 
 func mapDeployRequestToParams(req *models.DeployAgentRequest) *agents.DeployAgentParams {
@@ -103,10 +323,20 @@ func mapDeployRequestToParams(req *models.DeployAgentRequest) *agents.DeployAgen
 		Framework:       req.Framework,
 		Description:     req.Description,
 	}
+	if req.Source != nil {
+		params.Source = &agents.AgentSourceSpec{
+			RepoURL:       req.Source.RepoURL,
+			Ref:           req.Source.Ref,
+			ContextDir:    req.Source.ContextDir,
+			Dockerfile:    req.Source.Dockerfile,
+			BuildStrategy: req.Source.BuildStrategy,
+		}
+	}
 	for _, e := range req.EnvVars {
 		params.EnvVars = append(params.EnvVars, agents.AgentEnvVar{
-			Name:  e.Name,
-			Value: e.Value,
+			Name:      e.Name,
+			Value:     e.Value,
+			ValueFrom: mapEnvVarSource(e.ValueFrom),
 		})
 	}
 	for _, p := range req.ServicePorts {
@@ -117,5 +347,54 @@ func mapDeployRequestToParams(req *models.DeployAgentRequest) *agents.DeployAgen
 			Protocol:   p.Protocol,
 		})
 	}
+	if res := req.Resources; res != nil {
+		params.Resources = &agents.AgentResources{
+			Requests: mapResourceList(res.Requests),
+			Limits:   mapResourceList(res.Limits),
+		}
+	}
+	if s := req.Scaling; s != nil {
+		params.Scaling = &agents.AgentScaling{
+			MinReplicas:        s.MinReplicas,
+			MaxReplicas:        s.MaxReplicas,
+			ScaleToZero:        s.ScaleToZero,
+			IdleTimeoutSeconds: s.IdleTimeoutSeconds,
+		}
+	}
+	for _, m := range req.VolumeMounts {
+		params.VolumeMounts = append(params.VolumeMounts, agents.AgentVolumeMount{
+			Name:                  m.Name,
+			MountPath:             m.MountPath,
+			SubPath:               m.SubPath,
+			ReadOnly:              m.ReadOnly,
+			Secret:                m.Secret,
+			ConfigMap:             m.ConfigMap,
+			PersistentVolumeClaim: m.PersistentVolumeClaim,
+		})
+	}
 	return params
 }
+
+func mapEnvVarSource(src *models.EnvVarSource) *agents.AgentEnvVarSource {
+	if src == nil {
+		return nil
+	}
+	return &agents.AgentEnvVarSource{
+		SecretKeyRef:    mapKeySelector(src.SecretKeyRef),
+		ConfigMapKeyRef: mapKeySelector(src.ConfigMapKeyRef),
+	}
+}
+
+func mapKeySelector(ref *models.KeySelector) *agents.AgentKeyRef {
+	if ref == nil {
+		return nil
+	}
+	return &agents.AgentKeyRef{Name: ref.Name, Key: ref.Key, Optional: ref.Optional}
+}
+
+func mapResourceList(list *models.ResourceList) agents.AgentResourceList {
+	if list == nil {
+		return agents.AgentResourceList{}
+	}
+	return agents.AgentResourceList{CPU: list.CPU, Memory: list.Memory}
+}
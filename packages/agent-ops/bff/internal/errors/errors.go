@@ -16,3 +16,6 @@ var ErrAlreadyExists = errors.New("resource already exists")
 
 // ErrConflict is returned when a request conflicts with the current state of the resource.
 var ErrConflict = errors.New("resource state conflict")
+
+// ErrInvalidRequest is returned when a request is well-formed but invalid for the target agent.
+var ErrInvalidRequest = errors.New("invalid request")
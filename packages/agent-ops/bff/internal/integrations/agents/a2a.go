@@ -0,0 +1,106 @@
+package agents
+
+// A2A protocol DTOs exchanged with agent JSON-RPC endpoints. Field names follow the
+// A2A specification so payloads round-trip without translation; mappers convert them
+// into BFF models for the frontend.
+
+// A2A message roles.
+const (
+	A2ARoleUser  = "user"
+	A2ARoleAgent = "agent"
+)
+
+// A2A result and stream event kinds.
+const (
+	A2AKindTask           = "task"
+	A2AKindMessage        = "message"
+	A2AKindStatusUpdate   = "status-update"
+	A2AKindArtifactUpdate = "artifact-update"
+)
+
+// A2A part kinds.
+const (
+	A2APartKindText = "text"
+	A2APartKindData = "data"
+	A2APartKindFile = "file"
+)
+
+// A2A task states.
+const (
+	A2ATaskStateSubmitted     = "submitted"
+	A2ATaskStateWorking       = "working"
+	A2ATaskStateInputRequired = "input-required"
+	A2ATaskStateCompleted     = "completed"
+	A2ATaskStateCanceled      = "canceled"
+	A2ATaskStateFailed        = "failed"
+	A2ATaskStateRejected      = "rejected"
+	A2ATaskStateAuthRequired  = "auth-required"
+	A2ATaskStateUnknown       = "unknown"
+)
+
+// A2AMessageMetadataSkillID is the message metadata key used to address a specific card skill.
+const A2AMessageMetadataSkillID = "skillId"
+
+// A2AFile is file content carried by a file part, either inline (bytes) or by reference (uri).
+type A2AFile struct {
+	Name     string `json:"name,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Bytes    string `json:"bytes,omitempty"`
+	URI      string `json:"uri,omitempty"`
+}
+
+// A2APart is one part of an A2A message or artifact.
+type A2APart struct {
+	Kind string         `json:"kind"`
+	Text string         `json:"text,omitempty"`
+	Data map[string]any `json:"data,omitempty"`
+	File *A2AFile       `json:"file,omitempty"`
+}
+
+// A2AMessage is a single user or agent turn.
+type A2AMessage struct {
+	Kind      string         `json:"kind"`
+	Role      string         `json:"role"`
+	MessageID string         `json:"messageId"`
+	ContextID string         `json:"contextId,omitempty"`
+	TaskID    string         `json:"taskId,omitempty"`
+	Parts     []A2APart      `json:"parts"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// A2ATaskStatus is the current state of an A2A task.
+type A2ATaskStatus struct {
+	State     string      `json:"state"`
+	Message   *A2AMessage `json:"message,omitempty"`
+	Timestamp string      `json:"timestamp,omitempty"`
+}
+
+// A2AArtifact is output produced by an agent for a task.
+type A2AArtifact struct {
+	ArtifactID  string    `json:"artifactId"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Parts       []A2APart `json:"parts"`
+}
+
+// A2AEvent is a message/send result or a message/stream event. The populated fields
+// depend on Kind: a task carries ID, Status and Artifacts; a message carries Role,
+// MessageID and Parts; status and artifact updates carry TaskID with Status or Artifact.
+type A2AEvent struct {
+	Kind      string `json:"kind"`
+	ID        string `json:"id,omitempty"`
+	TaskID    string `json:"taskId,omitempty"`
+	ContextID string `json:"contextId,omitempty"`
+
+	Status    *A2ATaskStatus `json:"status,omitempty"`
+	Artifacts []A2AArtifact  `json:"artifacts,omitempty"`
+
+	Role      string    `json:"role,omitempty"`
+	MessageID string    `json:"messageId,omitempty"`
+	Parts     []A2APart `json:"parts,omitempty"`
+
+	Artifact  *A2AArtifact `json:"artifact,omitempty"`
+	Append    bool         `json:"append,omitempty"`
+	LastChunk bool         `json:"lastChunk,omitempty"`
+	Final     bool         `json:"final,omitempty"`
+}
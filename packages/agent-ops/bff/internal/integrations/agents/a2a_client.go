@@ -0,0 +1,249 @@
+package agents
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	a2aMethodSend   = "message/send"
+	a2aMethodStream = "message/stream"
+
+	// maxA2AResponseBytes bounds a non-streaming message/send response body.
+	maxA2AResponseBytes = 4 << 20
+	// maxA2AEventBytes bounds a single SSE line in a message/stream response.
+	maxA2AEventBytes = 1 << 20
+
+	// a2aDialTimeout bounds connecting to an agent.
+	a2aDialTimeout = 10 * time.Second
+	// a2aResponseHeaderTimeout bounds waiting for the response headers of an agent. A blocking
+	// message/send only answers once the task is done, so this is sized for agent work.
+	a2aResponseHeaderTimeout = 2 * time.Minute
+	// a2aSendTimeout bounds a whole non-streaming message/send call, including the response body.
+	a2aSendTimeout = 3 * time.Minute
+)
+
+// ErrA2AStreamingUnsupported indicates the agent answered message/stream without an event stream.
+var ErrA2AStreamingUnsupported = errors.New("agent did not return an event stream")
+
+// A2AError is a JSON-RPC error returned by an agent.
+type A2AError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *A2AError) Error() string {
+	return fmt.Sprintf("a2a error %d: %s", e.Code, e.Message)
+}
+
+type a2aRequest struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      string           `json:"id"`
+	Method  string           `json:"method"`
+	Params  a2aMessageParams `json:"params"`
+}
+
+type a2aMessageParams struct {
+	Message       A2AMessage            `json:"message"`
+	Configuration *a2aSendConfiguration `json:"configuration,omitempty"`
+}
+
+type a2aSendConfiguration struct {
+	Blocking bool `json:"blocking"`
+}
+
+type a2aResponse struct {
+	JSONRPC string    `json:"jsonrpc"`
+	ID      any       `json:"id"`
+	Result  *A2AEvent `json:"result,omitempty"`
+	Error   *A2AError `json:"error,omitempty"`
+}
+
+// A2AClient sends messages to agents over the A2A JSON-RPC transport.
+type A2AClient struct {
+	httpClient  *http.Client
+	sendTimeout time.Duration
+}
+
+// NewA2AHTTPClient returns the HTTP client for A2A calls. Its transport bounds dialing and
+// waiting for response headers, but it sets no overall Timeout, which would cut event streams.
+func NewA2AHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   a2aDialTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.ResponseHeaderTimeout = a2aResponseHeaderTimeout
+	return &http.Client{Transport: transport}
+}
+
+// NewA2AClient returns an A2A client using httpClient, or NewA2AHTTPClient when nil.
+// Streaming calls are bounded by the request context, so httpClient should not set a
+// short overall Timeout.
+func NewA2AClient(httpClient *http.Client) *A2AClient {
+	if httpClient == nil {
+		httpClient = NewA2AHTTPClient()
+	}
+	return &A2AClient{httpClient: httpClient, sendTimeout: a2aSendTimeout}
+}
+
+// SendMessage calls message/send and returns the resulting task or message.
+// Unlike StreamMessage, the call is bounded by a2aSendTimeout.
+func (c *A2AClient) SendMessage(ctx context.Context, endpoint string, msg A2AMessage) (*A2AEvent, error) {
+	sendCtx, cancel := context.WithTimeout(ctx, c.sendTimeout)
+	defer cancel()
+
+	resp, err := c.do(sendCtx, endpoint, a2aMethodSend, msg, "application/json")
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			return nil, &UnavailableError{Message: fmt.Sprintf("agent did not respond within %s", c.sendTimeout)}
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxA2AResponseBytes))
+	if err != nil {
+		return nil, &UnavailableError{Message: fmt.Sprintf("reading agent response: %v", err)}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &UnavailableError{Message: fmt.Sprintf("agent returned HTTP %d", resp.StatusCode)}
+	}
+
+	var rpcResp a2aResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return nil, &UnavailableError{Message: fmt.Sprintf("decoding agent response: %v", err)}
+	}
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+	if rpcResp.Result == nil {
+		return nil, &UnavailableError{Message: "agent response has no result"}
+	}
+	return rpcResp.Result, nil
+}
+
+// StreamMessage calls message/stream and invokes handle for every event until the agent
+// closes the stream, a final status update is received, or handle returns an error.
+func (c *A2AClient) StreamMessage(ctx context.Context, endpoint string, msg A2AMessage, handle func(A2AEvent) error) error {
+	resp, err := c.do(ctx, endpoint, a2aMethodStream, msg, "text/event-stream")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &UnavailableError{Message: fmt.Sprintf("agent returned HTTP %d", resp.StatusCode)}
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return ErrA2AStreamingUnsupported
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxA2AEventBytes)
+
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			if payload, ok := strings.CutPrefix(line, "data:"); ok {
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(strings.TrimPrefix(payload, " "))
+			}
+			continue
+		}
+		if data.Len() == 0 {
+			continue
+		}
+
+		event, err := decodeA2AStreamEvent(data.String())
+		data.Reset()
+		if err != nil {
+			return err
+		}
+		if err := handle(*event); err != nil {
+			return err
+		}
+		if event.Kind == A2AKindStatusUpdate && event.Final {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return &UnavailableError{Message: fmt.Sprintf("reading agent event stream: %v", err)}
+	}
+	return nil
+}
+
+func (c *A2AClient) do(ctx context.Context, endpoint, method string, msg A2AMessage, accept string) (*http.Response, error) {
+	if SanitizeHTTPURL(endpoint) == "" {
+		return nil, fmt.Errorf("invalid A2A endpoint URL %q", endpoint)
+	}
+	if msg.Kind == "" {
+		msg.Kind = A2AKindMessage
+	}
+	if msg.Role == "" {
+		msg.Role = A2ARoleUser
+	}
+	if msg.MessageID == "" {
+		msg.MessageID = uuid.NewString()
+	}
+
+	payload, err := json.Marshal(a2aRequest{
+		JSONRPC: "2.0",
+		ID:      uuid.NewString(),
+		Method:  method,
+		Params: a2aMessageParams{
+			Message:       msg,
+			Configuration: &a2aSendConfiguration{Blocking: method == a2aMethodSend},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding A2A request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("creating A2A request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &UnavailableError{Message: fmt.Sprintf("calling agent: %v", err)}
+	}
+	return resp, nil
+}
+
+func decodeA2AStreamEvent(data string) (*A2AEvent, error) {
+	var rpcResp a2aResponse
+	if err := json.Unmarshal([]byte(data), &rpcResp); err != nil {
+		return nil, &UnavailableError{Message: fmt.Sprintf("decoding agent stream event: %v", err)}
+	}
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+	if rpcResp.Result == nil {
+		return nil, &UnavailableError{Message: "agent stream event has no result"}
+	}
+	return rpcResp.Result, nil
+}
@@ -0,0 +1,104 @@
+package agents_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	agentsmock "github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func userMessage(text string) agents.A2AMessage {
+	return agents.A2AMessage{Parts: []agents.A2APart{{Kind: agents.A2APartKindText, Text: text}}}
+}
+
+func TestA2AClientSendMessage(t *testing.T) {
+	server := agentsmock.NewA2AServer()
+	defer server.Close()
+
+	result, err := agents.NewA2AClient(nil).SendMessage(context.Background(), server.URL+"/", userMessage("hello"))
+
+	require.NoError(t, err)
+	assert.Equal(t, agents.A2AKindTask, result.Kind)
+	require.NotNil(t, result.Status)
+	assert.Equal(t, agents.A2ATaskStateCompleted, result.Status.State)
+	require.Len(t, result.Artifacts, 1)
+	assert.Equal(t, "echo: hello", result.Artifacts[0].Parts[0].Text)
+
+	received := server.Messages()
+	require.Len(t, received, 1)
+	assert.Equal(t, agents.A2ARoleUser, received[0].Role)
+	assert.Equal(t, agents.A2AKindMessage, received[0].Kind)
+	assert.NotEmpty(t, received[0].MessageID)
+}
+
+func TestA2AClientStreamMessage(t *testing.T) {
+	server := agentsmock.NewA2AServer()
+	defer server.Close()
+
+	var kinds []string
+	var finalState string
+	err := agents.NewA2AClient(nil).StreamMessage(context.Background(), server.URL+"/", userMessage("hi"), func(event agents.A2AEvent) error {
+		kinds = append(kinds, event.Kind)
+		if event.Final {
+			finalState = event.Status.State
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		agents.A2AKindTask,
+		agents.A2AKindStatusUpdate,
+		agents.A2AKindArtifactUpdate,
+		agents.A2AKindStatusUpdate,
+	}, kinds)
+	assert.Equal(t, agents.A2ATaskStateCompleted, finalState)
+}
+
+func TestA2AClientErrors(t *testing.T) {
+	t.Run("json-rpc error", func(t *testing.T) {
+		server := agentsmock.NewA2AServer()
+		defer server.Close()
+		server.FailWith = &agents.A2AError{Code: -32602, Message: "invalid params"}
+
+		_, err := agents.NewA2AClient(nil).SendMessage(context.Background(), server.URL+"/", userMessage("hi"))
+
+		var rpcErr *agents.A2AError
+		require.ErrorAs(t, err, &rpcErr)
+		assert.Equal(t, -32602, rpcErr.Code)
+	})
+
+	t.Run("stream without event-stream content type", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+
+		err := agents.NewA2AClient(nil).StreamMessage(context.Background(), server.URL, userMessage("hi"), func(agents.A2AEvent) error { return nil })
+
+		assert.ErrorIs(t, err, agents.ErrA2AStreamingUnsupported)
+	})
+
+	t.Run("unreachable agent", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+
+		_, err := agents.NewA2AClient(nil).SendMessage(context.Background(), url, userMessage("hi"))
+
+		assert.ErrorIs(t, err, agents.ErrUnavailable)
+	})
+
+	t.Run("rejects non-http endpoint", func(t *testing.T) {
+		_, err := agents.NewA2AClient(nil).SendMessage(context.Background(), "file:///etc/passwd", userMessage("hi"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid A2A endpoint URL")
+	})
+}
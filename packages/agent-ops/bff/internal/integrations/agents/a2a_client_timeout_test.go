@@ -0,0 +1,65 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewA2AHTTPClientTimeouts(t *testing.T) {
+	httpClient := NewA2AHTTPClient()
+
+	assert.Zero(t, httpClient.Timeout, "an overall timeout would cut event streams")
+	transport, ok := httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, a2aResponseHeaderTimeout, transport.ResponseHeaderTimeout)
+	assert.NotNil(t, transport.DialContext)
+}
+
+func TestA2AClientSendTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &A2AClient{httpClient: NewA2AHTTPClient(), sendTimeout: 50 * time.Millisecond}
+	_, err := client.SendMessage(context.Background(), server.URL+"/", A2AMessage{})
+
+	var unavailable *UnavailableError
+	require.ErrorAs(t, err, &unavailable)
+	assert.Contains(t, unavailable.Message, "did not respond within")
+}
+
+func TestA2AClientStreamIsNotBoundBySendTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		time.Sleep(150 * time.Millisecond)
+		fmt.Fprintf(w, "data: %s\n\n", `{"jsonrpc":"2.0","id":"1","result":{"kind":"status-update","final":true,"status":{"state":"completed"}}}`)
+	}))
+	defer server.Close()
+
+	client := &A2AClient{httpClient: NewA2AHTTPClient(), sendTimeout: 50 * time.Millisecond}
+	var events []A2AEvent
+	err := client.StreamMessage(context.Background(), server.URL+"/", A2AMessage{}, func(event A2AEvent) error {
+		events = append(events, event)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.True(t, events[0].Final)
+}
@@ -15,6 +15,25 @@ type Client interface {
 	StopAgent(ctx context.Context, namespace, name string) error
 	StartAgent(ctx context.Context, namespace, name string) error
 	RestartAgent(ctx context.Context, namespace, name string) error
+
+	// CreateAgentBuild creates the image build resources for a deploy-from-source request.
+	CreateAgentBuild(ctx context.Context, params *DeployAgentParams) (*AgentBuild, error)
+	// GetAgentBuild returns the latest build for an agent deployed from source.
+	GetAgentBuild(ctx context.Context, namespace, name string) (*AgentBuild, error)
+	// GetAgentBuildDeployParams returns the deploy parameters recorded when the build was created.
+	GetAgentBuildDeployParams(ctx context.Context, namespace, name string) (*DeployAgentParams, error)
+
+	// ListAgentRevisions returns the recorded revisions of an agent, newest first.
+	ListAgentRevisions(ctx context.Context, namespace, name string) ([]AgentRevision, error)
+	// RedeployAgent replaces the agent workload in place and records a new revision.
+	RedeployAgent(ctx context.Context, params *DeployAgentParams) (*AgentRevision, error)
+	// RollbackAgent redeploys the spec recorded in revision as a new revision.
+	RollbackAgent(ctx context.Context, namespace, name string, revision int64) (*AgentRevision, error)
+	// SetAgentCanary runs revision next to the agent and routes weight percent of traffic to it.
+	SetAgentCanary(ctx context.Context, namespace, name string, revision int64, weight int32) (*AgentCanary, error)
+	// EndAgentCanary removes the canary. When promote is true the canary revision is
+	// first redeployed as the primary agent.
+	EndAgentCanary(ctx context.Context, namespace, name string, promote bool) (*AgentRevision, error)
 }
 
 // ClientFactory creates a Client for the current request (e.g. with caller identity from context).
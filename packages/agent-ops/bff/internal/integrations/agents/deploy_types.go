@@ -11,6 +11,12 @@ type DeployAgentParams struct {
 	Description     string
 	EnvVars         []AgentEnvVar
 	ServicePorts    []AgentServicePortSpec
+	Resources       *AgentResources
+	Scaling         *AgentScaling
+	VolumeMounts    []AgentVolumeMount
+	// Source, when set, builds the image from git before deploying.
+	// ContainerImage and ImageTag then name the build output.
+	Source *AgentSourceSpec
 }
 
 type DeployAgentResult struct {
@@ -21,6 +27,54 @@ type DeployAgentResult struct {
 type AgentEnvVar struct {
 	Name  string
 	Value string
+	// ValueFrom, when set, reads the value from a Secret or ConfigMap key instead of Value.
+	ValueFrom *AgentEnvVarSource
+}
+
+// AgentEnvVarSource selects exactly one of SecretKeyRef or ConfigMapKeyRef.
+type AgentEnvVarSource struct {
+	SecretKeyRef    *AgentKeyRef
+	ConfigMapKeyRef *AgentKeyRef
+}
+
+// AgentKeyRef names a key in a Secret or ConfigMap in the agent namespace.
+type AgentKeyRef struct {
+	Name     string
+	Key      string
+	Optional bool
+}
+
+// AgentResources holds container requests and limits as Kubernetes quantity strings.
+type AgentResources struct {
+	Requests AgentResourceList
+	Limits   AgentResourceList
+}
+
+type AgentResourceList struct {
+	CPU    string
+	Memory string
+}
+
+// AgentScaling configures autoscaling. A Sandbox runs a single pod, so the only
+// supported mode is ScaleToZero: a KEDA ScaledObject scales the agent between
+// MinReplicas (0) and MaxReplicas (1), down after IdleTimeoutSeconds without traffic.
+type AgentScaling struct {
+	MinReplicas        int32
+	MaxReplicas        int32
+	ScaleToZero        bool
+	IdleTimeoutSeconds int32
+}
+
+// AgentVolumeMount mounts exactly one of Secret, ConfigMap or PersistentVolumeClaim
+// from the agent namespace into the agent container.
+type AgentVolumeMount struct {
+	Name                  string
+	MountPath             string
+	SubPath               string
+	ReadOnly              bool
+	Secret                string
+	ConfigMap             string
+	PersistentVolumeClaim string
 }
 
 type AgentServicePortSpec struct {
@@ -29,3 +83,38 @@ type AgentServicePortSpec struct {
 	TargetPort int32
 	Protocol   string
 }
+
+type AgentSourceSpec struct {
+	RepoURL       string
+	Ref           string
+	ContextDir    string
+	Dockerfile    string
+	BuildStrategy string
+}
+
+const (
+	BuildStrategyShipwright  = "shipwright"
+	BuildStrategyBuildConfig = "buildconfig"
+
+	BuildPhasePending   = "Pending"
+	BuildPhaseRunning   = "Running"
+	BuildPhaseSucceeded = "Succeeded"
+	BuildPhaseFailed    = "Failed"
+)
+
+// AgentBuild is the observed state of a deploy-from-source image build.
+type AgentBuild struct {
+	Name           string
+	Namespace      string
+	BuildStrategy  string
+	BuildRunName   string
+	RepoURL        string
+	Ref            string
+	Phase          string
+	Message        string
+	OutputImage    string
+	OutputTag      string
+	ImageDigest    string
+	StartTime      string
+	CompletionTime string
+}
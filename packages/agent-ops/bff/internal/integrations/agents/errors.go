@@ -16,6 +16,9 @@ var (
 	ErrAlreadyExists = errors.New("agent already exists")
 	// ErrConflict indicates the agent is in a state that conflicts with the requested operation.
 	ErrConflict = errors.New("agent state conflict")
+	// ErrInvalidSpec indicates the deploy spec references objects that are missing or unusable
+	// in the agent namespace.
+	ErrInvalidSpec = errors.New("invalid agent spec")
 )
 
 // UnavailableError provides context for ErrUnavailable.
@@ -0,0 +1,580 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	shipwrightBuildGVR = schema.GroupVersionResource{
+		Group:    "shipwright.io",
+		Version:  "v1beta1",
+		Resource: "builds",
+	}
+	shipwrightBuildRunGVR = schema.GroupVersionResource{
+		Group:    "shipwright.io",
+		Version:  "v1beta1",
+		Resource: "buildruns",
+	}
+	buildConfigGVR = schema.GroupVersionResource{
+		Group:    "build.openshift.io",
+		Version:  "v1",
+		Resource: "buildconfigs",
+	}
+	openshiftBuildGVR = schema.GroupVersionResource{
+		Group:    "build.openshift.io",
+		Version:  "v1",
+		Resource: "builds",
+	}
+)
+
+const (
+	// annotationDeploySpecSecret names the Secret holding the deploy parameters
+	// used to deploy the agent once the build finishes. The parameters may carry
+	// literal env var values, so they are kept out of the build resource itself.
+	annotationDeploySpecSecret = "opendatahub.io/agent-deploy-spec-secret"
+	annotationBuildRepo        = "opendatahub.io/agent-source-repo"
+	annotationBuildRef         = "opendatahub.io/agent-source-ref"
+
+	deploySpecSecretKey = "deploySpec.json"
+
+	labelShipwrightBuildName   = "build.shipwright.io/name"
+	labelOpenShiftBuildConfig  = "openshift.io/build-config.name"
+	annotationOpenShiftBuildNo = "openshift.io/build.number"
+
+	buildComponentValue = "agent-build"
+
+	// shipwrightClusterStrategy is the Shipwright ClusterBuildStrategy used to
+	// build agent images from a Dockerfile.
+	shipwrightClusterStrategy = "buildah"
+
+	// internalRegistryHost is the OpenShift internal image registry service.
+	internalRegistryHost = "image-registry.openshift-image-registry.svc:5000"
+
+	defaultSourceRef        = "main"
+	defaultSourceDockerfile = "Dockerfile"
+	defaultSourceImageTag   = "latest"
+)
+
+// DefaultBuildOutputImage returns the internal registry repository an agent
+// built from source is pushed to.
+func DefaultBuildOutputImage(namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", internalRegistryHost, namespace, name)
+}
+
+// CreateAgentBuild creates the build resources for an agent deployed from source.
+// Shipwright gets a Build plus a BuildRun; OpenShift gets a BuildConfig with a
+// ConfigChange trigger, which starts the first build on creation.
+func (c *Client) CreateAgentBuild(ctx context.Context, params *agents.DeployAgentParams) (*agents.AgentBuild, error) {
+	if params == nil || params.Source == nil {
+		return nil, fmt.Errorf("deploy-from-source params must include a source")
+	}
+
+	dynamicClient, err := c.k8sClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+
+	resolved := resolveBuildParams(params)
+	deploySpec, err := encodeDeploySpec(resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		buildGVR schema.GroupVersionResource
+		desired  *unstructured.Unstructured
+	)
+	switch resolved.Source.BuildStrategy {
+	case agents.BuildStrategyBuildConfig:
+		buildGVR, desired = buildConfigGVR, buildBuildConfigCR(resolved)
+	default:
+		buildGVR, desired = shipwrightBuildGVR, buildShipwrightBuildCR(resolved)
+	}
+
+	created, err := dynamicClient.Resource(buildGVR).Namespace(resolved.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("%s %q already exists: %w", desired.GetKind(), resolved.Name, agents.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to create %s: %w", desired.GetKind(), mapBuildError(err))
+	}
+
+	// The Secret is owned by the build resource so it is garbage-collected with it.
+	// A build without its deploy spec can never be deployed, so it is removed again
+	// when the Secret cannot be written.
+	if err := c.createDeploySpecSecret(ctx, created, deploySpec); err != nil {
+		c.deleteBuildBestEffort(ctx, dynamicClient, buildGVR, created)
+		return nil, err
+	}
+
+	// Without a BuildRun the Build never produces an image, so it is removed as
+	// above, and its Secret is deleted too rather than left to garbage collection.
+	if buildGVR == shipwrightBuildGVR {
+		if _, err := c.startShipwrightBuildRun(ctx, dynamicClient, resolved.Namespace, resolved.Name); err != nil {
+			c.deleteBuildBestEffort(ctx, dynamicClient, buildGVR, created)
+			c.deleteDeploySpecSecretBestEffort(ctx, created)
+			return nil, err
+		}
+	}
+
+	c.logger.Info("Created agent build",
+		slog.String("name", resolved.Name),
+		slog.String("namespace", resolved.Namespace),
+		slog.String("strategy", resolved.Source.BuildStrategy))
+
+	return c.GetAgentBuild(ctx, resolved.Namespace, resolved.Name)
+}
+
+// GetAgentBuild returns the latest build for an agent, looking for a Shipwright
+// Build first and an OpenShift BuildConfig second.
+func (c *Client) GetAgentBuild(ctx context.Context, namespace, name string) (*agents.AgentBuild, error) {
+	dynamicClient, err := c.k8sClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+
+	obj, buildGVR, err := getBuildObject(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if buildGVR == buildConfigGVR {
+		return c.buildConfigStatus(ctx, dynamicClient, obj)
+	}
+	return c.shipwrightBuildStatus(ctx, dynamicClient, obj)
+}
+
+// GetAgentBuildDeployParams returns the deploy parameters recorded when the build
+// was created, read from the Secret the build resource references.
+func (c *Client) GetAgentBuildDeployParams(ctx context.Context, namespace, name string) (*agents.DeployAgentParams, error) {
+	dynamicClient, err := c.k8sClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+
+	obj, _, err := getBuildObject(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	secretName := obj.GetAnnotations()[annotationDeploySpecSecret]
+	if secretName == "" {
+		return nil, fmt.Errorf("build %s/%s has no recorded deploy spec", namespace, name)
+	}
+
+	secret, err := c.k8sClient.KubernetesClientset().CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("deploy spec of build %s/%s was removed: %w", namespace, name, agents.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get build deploy spec: %w", mapK8sError(err))
+	}
+	if !metav1.IsControlledBy(secret, obj) {
+		return nil, fmt.Errorf("deploy spec Secret %q is not owned by build %s/%s", secretName, namespace, name)
+	}
+
+	params := decodeDeploySpec(string(secret.Data[deploySpecSecretKey]))
+	if params == nil {
+		return nil, fmt.Errorf("build %s/%s has no readable deploy spec", namespace, name)
+	}
+	params.Name = name
+	params.Namespace = namespace
+	return params, nil
+}
+
+// getBuildObject returns the Shipwright Build or, failing that, the OpenShift
+// BuildConfig named name, along with the resource it was found as.
+func getBuildObject(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) (*unstructured.Unstructured, schema.GroupVersionResource, error) {
+	build, err := dynamicClient.Resource(shipwrightBuildGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return build, shipwrightBuildGVR, nil
+	}
+	if !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("failed to get Shipwright Build: %w", mapK8sError(err))
+	}
+
+	bc, err := dynamicClient.Resource(buildConfigGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, schema.GroupVersionResource{}, agents.ErrNotFound
+		}
+		return nil, schema.GroupVersionResource{}, fmt.Errorf("failed to get BuildConfig: %w", mapK8sError(err))
+	}
+	return bc, buildConfigGVR, nil
+}
+
+func deploySpecSecretName(name string) string {
+	return name + "-deploy-spec"
+}
+
+func (c *Client) createDeploySpecSecret(ctx context.Context, owner *unstructured.Unstructured, deploySpec string) error {
+	controller := true
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploySpecSecretName(owner.GetName()),
+			Namespace: owner.GetNamespace(),
+			Labels: map[string]string{
+				labelManagedBy: managedByValue,
+				labelAppName:   owner.GetName(),
+				labelComponent: buildComponentValue,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: owner.GetAPIVersion(),
+				Kind:       owner.GetKind(),
+				Name:       owner.GetName(),
+				UID:        owner.GetUID(),
+				Controller: &controller,
+			}},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{deploySpecSecretKey: []byte(deploySpec)},
+	}
+	if _, err := c.k8sClient.KubernetesClientset().CoreV1().Secrets(owner.GetNamespace()).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("Secret %q already exists: %w", secret.Name, agents.ErrAlreadyExists)
+		}
+		return fmt.Errorf("failed to store build deploy spec: %w", mapK8sError(err))
+	}
+	return nil
+}
+
+func (c *Client) deleteBuildBestEffort(ctx context.Context, dynamicClient dynamic.Interface, buildGVR schema.GroupVersionResource, obj *unstructured.Unstructured) {
+	if err := dynamicClient.Resource(buildGVR).Namespace(obj.GetNamespace()).Delete(ctx, obj.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		c.logger.Warn("failed to remove agent build after a failed create",
+			slog.String("name", obj.GetName()),
+			slog.String("namespace", obj.GetNamespace()),
+			slog.Any("error", err))
+	}
+}
+
+func (c *Client) deleteDeploySpecSecretBestEffort(ctx context.Context, owner *unstructured.Unstructured) {
+	name := deploySpecSecretName(owner.GetName())
+	if err := c.k8sClient.KubernetesClientset().CoreV1().Secrets(owner.GetNamespace()).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		c.logger.Warn("failed to remove agent build deploy spec after a failed create",
+			slog.String("name", name),
+			slog.String("namespace", owner.GetNamespace()),
+			slog.Any("error", err))
+	}
+}
+
+func (c *Client) startShipwrightBuildRun(ctx context.Context, dynamicClient dynamic.Interface, namespace, buildName string) (*unstructured.Unstructured, error) {
+	labels := buildLabels(buildName)
+	labels[labelShipwrightBuildName] = buildName
+	run := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": shipwrightBuildRunGVR.Group + "/" + shipwrightBuildRunGVR.Version,
+			"kind":       "BuildRun",
+			"metadata": map[string]any{
+				"name":      fmt.Sprintf("%s-%s", buildName, utilrand.String(5)),
+				"namespace": namespace,
+				"labels":    labels,
+			},
+			"spec": map[string]any{
+				"build": map[string]any{"name": buildName},
+			},
+		},
+	}
+	created, err := dynamicClient.Resource(shipwrightBuildRunGVR).Namespace(namespace).Create(ctx, run, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Shipwright BuildRun: %w", mapK8sError(err))
+	}
+	return created, nil
+}
+
+func (c *Client) shipwrightBuildStatus(ctx context.Context, dynamicClient dynamic.Interface, build *unstructured.Unstructured) (*agents.AgentBuild, error) {
+	result := buildFromAnnotations(build, agents.BuildStrategyShipwright)
+	outputImage, _, _ := unstructured.NestedString(build.Object, "spec", "output", "image")
+	result.OutputImage, result.OutputTag = splitImageTag(outputImage)
+
+	runs, err := dynamicClient.Resource(shipwrightBuildRunGVR).Namespace(build.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: labelShipwrightBuildName + "=" + build.GetName(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Shipwright BuildRuns: %w", mapK8sError(err))
+	}
+	latest := latestByCreation(runs.Items)
+	if latest == nil {
+		result.Phase = agents.BuildPhasePending
+		return result, nil
+	}
+
+	result.BuildRunName = latest.GetName()
+	result.Phase, result.Message = shipwrightRunPhase(latest)
+	result.StartTime, _, _ = unstructured.NestedString(latest.Object, "status", "startTime")
+	result.CompletionTime, _, _ = unstructured.NestedString(latest.Object, "status", "completionTime")
+	result.ImageDigest, _, _ = unstructured.NestedString(latest.Object, "status", "output", "digest")
+	return result, nil
+}
+
+func (c *Client) buildConfigStatus(ctx context.Context, dynamicClient dynamic.Interface, bc *unstructured.Unstructured) (*agents.AgentBuild, error) {
+	result := buildFromAnnotations(bc, agents.BuildStrategyBuildConfig)
+	outputImage, _, _ := unstructured.NestedString(bc.Object, "spec", "output", "to", "name")
+	result.OutputImage, result.OutputTag = splitImageTag(outputImage)
+
+	builds, err := dynamicClient.Resource(openshiftBuildGVR).Namespace(bc.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: labelOpenShiftBuildConfig + "=" + bc.GetName(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OpenShift Builds: %w", mapK8sError(err))
+	}
+	latest := latestByBuildNumber(builds.Items)
+	if latest == nil {
+		result.Phase = agents.BuildPhasePending
+		return result, nil
+	}
+
+	result.BuildRunName = latest.GetName()
+	phase, _, _ := unstructured.NestedString(latest.Object, "status", "phase")
+	result.Phase = openshiftBuildPhase(phase)
+	result.Message, _, _ = unstructured.NestedString(latest.Object, "status", "message")
+	result.StartTime, _, _ = unstructured.NestedString(latest.Object, "status", "startTimestamp")
+	result.CompletionTime, _, _ = unstructured.NestedString(latest.Object, "status", "completionTimestamp")
+	result.ImageDigest, _, _ = unstructured.NestedString(latest.Object, "status", "output", "to", "imageDigest")
+	return result, nil
+}
+
+// resolveBuildParams fills source and output defaults without mutating the caller's params.
+func resolveBuildParams(params *agents.DeployAgentParams) *agents.DeployAgentParams {
+	resolved := *params
+	source := *params.Source
+	resolved.Source = &source
+
+	if source.Ref == "" {
+		resolved.Source.Ref = defaultSourceRef
+	}
+	if source.Dockerfile == "" {
+		resolved.Source.Dockerfile = defaultSourceDockerfile
+	}
+	if source.BuildStrategy == "" {
+		resolved.Source.BuildStrategy = agents.BuildStrategyShipwright
+	}
+	if resolved.ContainerImage == "" {
+		resolved.ContainerImage = DefaultBuildOutputImage(params.Namespace, params.Name)
+	}
+	if resolved.ImageTag == "" {
+		resolved.ImageTag = defaultSourceImageTag
+	}
+	return &resolved
+}
+
+func buildLabels(name string) map[string]any {
+	return map[string]any{
+		labelManagedBy: managedByValue,
+		labelAppName:   name,
+		labelComponent: buildComponentValue,
+	}
+}
+
+func buildAnnotations(params *agents.DeployAgentParams) map[string]any {
+	return map[string]any{
+		annotationDeploySpecSecret: deploySpecSecretName(params.Name),
+		annotationBuildRepo:        params.Source.RepoURL,
+		annotationBuildRef:         params.Source.Ref,
+	}
+}
+
+func buildShipwrightBuildCR(params *agents.DeployAgentParams) *unstructured.Unstructured {
+	git := map[string]any{
+		"url":      params.Source.RepoURL,
+		"revision": params.Source.Ref,
+	}
+	source := map[string]any{
+		"type": "Git",
+		"git":  git,
+	}
+	if params.Source.ContextDir != "" {
+		source["contextDir"] = params.Source.ContextDir
+	}
+
+	output := map[string]any{
+		"image": fmt.Sprintf("%s:%s", params.ContainerImage, params.ImageTag),
+	}
+	if params.ImagePullSecret != "" {
+		output["pushSecret"] = params.ImagePullSecret
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": shipwrightBuildGVR.Group + "/" + shipwrightBuildGVR.Version,
+			"kind":       "Build",
+			"metadata": map[string]any{
+				"name":        params.Name,
+				"namespace":   params.Namespace,
+				"labels":      buildLabels(params.Name),
+				"annotations": buildAnnotations(params),
+			},
+			"spec": map[string]any{
+				"source": source,
+				"strategy": map[string]any{
+					"name": shipwrightClusterStrategy,
+					"kind": "ClusterBuildStrategy",
+				},
+				"paramValues": []any{
+					map[string]any{"name": "dockerfile", "value": params.Source.Dockerfile},
+				},
+				"output": output,
+			},
+		},
+	}
+}
+
+func buildBuildConfigCR(params *agents.DeployAgentParams) *unstructured.Unstructured {
+	source := map[string]any{
+		"type": "Git",
+		"git": map[string]any{
+			"uri": params.Source.RepoURL,
+			"ref": params.Source.Ref,
+		},
+	}
+	if params.Source.ContextDir != "" {
+		source["contextDir"] = params.Source.ContextDir
+	}
+
+	output := map[string]any{
+		"to": map[string]any{
+			"kind": "DockerImage",
+			"name": fmt.Sprintf("%s:%s", params.ContainerImage, params.ImageTag),
+		},
+	}
+	if params.ImagePullSecret != "" {
+		output["pushSecret"] = map[string]any{"name": params.ImagePullSecret}
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": buildConfigGVR.Group + "/" + buildConfigGVR.Version,
+			"kind":       "BuildConfig",
+			"metadata": map[string]any{
+				"name":        params.Name,
+				"namespace":   params.Namespace,
+				"labels":      buildLabels(params.Name),
+				"annotations": buildAnnotations(params),
+			},
+			"spec": map[string]any{
+				"source": source,
+				"strategy": map[string]any{
+					"type": "Docker",
+					"dockerStrategy": map[string]any{
+						"dockerfilePath": params.Source.Dockerfile,
+					},
+				},
+				"output": output,
+				"triggers": []any{
+					map[string]any{"type": "ConfigChange"},
+				},
+			},
+		},
+	}
+}
+
+func buildFromAnnotations(obj *unstructured.Unstructured, strategy string) *agents.AgentBuild {
+	annotations := obj.GetAnnotations()
+	result := &agents.AgentBuild{
+		Name:          obj.GetName(),
+		Namespace:     obj.GetNamespace(),
+		BuildStrategy: strategy,
+		RepoURL:       annotations[annotationBuildRepo],
+		Ref:           annotations[annotationBuildRef],
+	}
+	return result
+}
+
+// shipwrightRunPhase maps the BuildRun Succeeded condition to a build phase.
+func shipwrightRunPhase(run *unstructured.Unstructured) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(run.Object, "status", "conditions")
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]any)
+		if !ok || stringField(condition["type"]) != "Succeeded" {
+			continue
+		}
+		message := stringField(condition["message"])
+		switch stringField(condition["status"]) {
+		case "True":
+			return agents.BuildPhaseSucceeded, message
+		case "False":
+			return agents.BuildPhaseFailed, message
+		default:
+			if stringField(condition["reason"]) == "Pending" {
+				return agents.BuildPhasePending, message
+			}
+			return agents.BuildPhaseRunning, message
+		}
+	}
+	return agents.BuildPhasePending, ""
+}
+
+func openshiftBuildPhase(phase string) string {
+	switch phase {
+	case "Running":
+		return agents.BuildPhaseRunning
+	case "Complete":
+		return agents.BuildPhaseSucceeded
+	case "Failed", "Error", "Cancelled":
+		return agents.BuildPhaseFailed
+	default:
+		return agents.BuildPhasePending
+	}
+}
+
+func latestByCreation(items []unstructured.Unstructured) *unstructured.Unstructured {
+	if len(items) == 0 {
+		return nil
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		ti := items[i].GetCreationTimestamp()
+		tj := items[j].GetCreationTimestamp()
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return items[i].GetName() < items[j].GetName()
+	})
+	return &items[len(items)-1]
+}
+
+func latestByBuildNumber(items []unstructured.Unstructured) *unstructured.Unstructured {
+	var latest *unstructured.Unstructured
+	latestNumber := -1
+	for i := range items {
+		number, err := strconv.Atoi(items[i].GetAnnotations()[annotationOpenShiftBuildNo])
+		if err != nil {
+			number = 0
+		}
+		if number > latestNumber {
+			latest = &items[i]
+			latestNumber = number
+		}
+	}
+	return latest
+}
+
+// splitImageTag splits "repo:tag" into repo and tag, ignoring registry ports.
+func splitImageTag(image string) (string, string) {
+	slash := strings.LastIndex(image, "/")
+	colon := strings.LastIndex(image, ":")
+	if colon > slash {
+		return image[:colon], image[colon+1:]
+	}
+	return image, ""
+}
+
+// mapBuildError reports a missing build API (Shipwright or OpenShift builds not
+// installed) as unavailable rather than not found.
+func mapBuildError(err error) error {
+	if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+		return &agents.UnavailableError{Message: "build API is not installed on the cluster"}
+	}
+	return mapK8sError(err)
+}
@@ -0,0 +1,229 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func sourceDeployParams(strategy string) *agents.DeployAgentParams {
+	return &agents.DeployAgentParams{
+		Name:      "my-agent",
+		Namespace: "test-ns",
+		Protocol:  "a2a",
+		Framework: "langgraph",
+		EnvVars:   []agents.AgentEnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+		Source: &agents.AgentSourceSpec{
+			RepoURL:       "https://github.com/example/agent.git",
+			Ref:           "v1.2.0",
+			ContextDir:    "agents/support",
+			BuildStrategy: strategy,
+		},
+	}
+}
+
+func TestCreateAgentBuild_Shipwright(t *testing.T) {
+	client, dynamicClient := newDeployTestClient(t)
+
+	build, err := client.CreateAgentBuild(context.Background(), sourceDeployParams(""))
+
+	require.NoError(t, err)
+	assert.Equal(t, agents.BuildStrategyShipwright, build.BuildStrategy)
+	assert.Equal(t, agents.BuildPhasePending, build.Phase)
+	assert.Equal(t, DefaultBuildOutputImage("test-ns", "my-agent"), build.OutputImage)
+	assert.Equal(t, "latest", build.OutputTag)
+	assert.Equal(t, "https://github.com/example/agent.git", build.RepoURL)
+
+	obj, err := dynamicClient.Resource(shipwrightBuildGVR).Namespace("test-ns").Get(context.Background(), "my-agent", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "my-agent-deploy-spec", obj.GetAnnotations()[annotationDeploySpecSecret])
+	for _, value := range obj.GetAnnotations() {
+		assert.NotContains(t, value, "debug", "env var values must not be stored on the build")
+	}
+	url, _, _ := unstructured.NestedString(obj.Object, "spec", "source", "git", "url")
+	revision, _, _ := unstructured.NestedString(obj.Object, "spec", "source", "git", "revision")
+	contextDir, _, _ := unstructured.NestedString(obj.Object, "spec", "source", "contextDir")
+	assert.Equal(t, "https://github.com/example/agent.git", url)
+	assert.Equal(t, "v1.2.0", revision)
+	assert.Equal(t, "agents/support", contextDir)
+
+	runs, err := dynamicClient.Resource(shipwrightBuildRunGVR).Namespace("test-ns").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, runs.Items, 1)
+	assert.Equal(t, "my-agent", runs.Items[0].GetLabels()[labelShipwrightBuildName])
+	assert.Equal(t, runs.Items[0].GetName(), build.BuildRunName)
+}
+
+func TestCreateAgentBuild_BuildConfig(t *testing.T) {
+	client, dynamicClient := newDeployTestClient(t)
+
+	build, err := client.CreateAgentBuild(context.Background(), sourceDeployParams(agents.BuildStrategyBuildConfig))
+
+	require.NoError(t, err)
+	assert.Equal(t, agents.BuildStrategyBuildConfig, build.BuildStrategy)
+
+	obj, err := dynamicClient.Resource(buildConfigGVR).Namespace("test-ns").Get(context.Background(), "my-agent", metav1.GetOptions{})
+	require.NoError(t, err)
+	triggers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "triggers")
+	require.Len(t, triggers, 1)
+	outputKind, _, _ := unstructured.NestedString(obj.Object, "spec", "output", "to", "kind")
+	assert.Equal(t, "DockerImage", outputKind)
+}
+
+func TestCreateAgentBuild_AlreadyExists(t *testing.T) {
+	client, _ := newDeployTestClient(t)
+
+	_, err := client.CreateAgentBuild(context.Background(), sourceDeployParams(""))
+	require.NoError(t, err)
+	_, err = client.CreateAgentBuild(context.Background(), sourceDeployParams(""))
+
+	assert.ErrorIs(t, err, agents.ErrAlreadyExists)
+}
+
+func TestGetAgentBuildDeployParams_ReadsOwnedSecret(t *testing.T) {
+	client, _ := newDeployTestClient(t)
+	_, err := client.CreateAgentBuild(context.Background(), sourceDeployParams(agents.BuildStrategyBuildConfig))
+	require.NoError(t, err)
+
+	secret, err := client.k8sClient.KubernetesClientset().CoreV1().Secrets("test-ns").Get(context.Background(), "my-agent-deploy-spec", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, secret.OwnerReferences, 1)
+	assert.Equal(t, "BuildConfig", secret.OwnerReferences[0].Kind)
+	assert.Contains(t, string(secret.Data[deploySpecSecretKey]), `"envVars":[{"name":"LOG_LEVEL","value":"debug"}]`)
+
+	params, err := client.GetAgentBuildDeployParams(context.Background(), "test-ns", "my-agent")
+
+	require.NoError(t, err)
+	assert.Equal(t, "my-agent", params.Name)
+	assert.Equal(t, "test-ns", params.Namespace)
+	assert.Nil(t, params.Source)
+	assert.Equal(t, "langgraph", params.Framework)
+	assert.Equal(t, DefaultBuildOutputImage("test-ns", "my-agent"), params.ContainerImage)
+	assert.Equal(t, []agents.AgentEnvVar{{Name: "LOG_LEVEL", Value: "debug"}}, params.EnvVars)
+}
+
+func TestCreateAgentBuild_RemovesBuildWhenSpecCannotBeStored(t *testing.T) {
+	client, dynamicClient := newDeployTestClient(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-agent-deploy-spec", Namespace: "test-ns"},
+	})
+
+	_, err := client.CreateAgentBuild(context.Background(), sourceDeployParams(""))
+
+	require.ErrorIs(t, err, agents.ErrAlreadyExists)
+	builds, err := dynamicClient.Resource(shipwrightBuildGVR).Namespace("test-ns").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, builds.Items)
+	runs, err := dynamicClient.Resource(shipwrightBuildRunGVR).Namespace("test-ns").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, runs.Items)
+}
+
+func TestCreateAgentBuild_RemovesBuildWhenBuildRunCannotBeCreated(t *testing.T) {
+	client, dynamicClient := newDeployTestClient(t)
+	dynamicClient.PrependReactor("create", "buildruns", func(ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewInternalError(errors.New("admission webhook unavailable"))
+	})
+
+	_, err := client.CreateAgentBuild(context.Background(), sourceDeployParams(""))
+
+	require.Error(t, err)
+	builds, err := dynamicClient.Resource(shipwrightBuildGVR).Namespace("test-ns").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, builds.Items)
+	_, err = client.k8sClient.KubernetesClientset().CoreV1().Secrets("test-ns").Get(context.Background(), "my-agent-deploy-spec", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "deploy spec Secret must be removed, got %v", err)
+}
+
+func TestGetAgentBuild_ShipwrightPhases(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition map[string]any
+		phase     string
+	}{
+		{"succeeded", map[string]any{"type": "Succeeded", "status": "True"}, agents.BuildPhaseSucceeded},
+		{"failed", map[string]any{"type": "Succeeded", "status": "False", "message": "step-build failed"}, agents.BuildPhaseFailed},
+		{"running", map[string]any{"type": "Succeeded", "status": "Unknown", "reason": "Running"}, agents.BuildPhaseRunning},
+		{"pending", map[string]any{"type": "Succeeded", "status": "Unknown", "reason": "Pending"}, agents.BuildPhasePending},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, dynamicClient := newDeployTestClient(t)
+			created, err := client.CreateAgentBuild(context.Background(), sourceDeployParams(""))
+			require.NoError(t, err)
+
+			run, err := dynamicClient.Resource(shipwrightBuildRunGVR).Namespace("test-ns").Get(context.Background(), created.BuildRunName, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.NoError(t, unstructured.SetNestedSlice(run.Object, []any{tc.condition}, "status", "conditions"))
+			require.NoError(t, unstructured.SetNestedField(run.Object, "sha256:abc", "status", "output", "digest"))
+			_, err = dynamicClient.Resource(shipwrightBuildRunGVR).Namespace("test-ns").Update(context.Background(), run, metav1.UpdateOptions{})
+			require.NoError(t, err)
+
+			build, err := client.GetAgentBuild(context.Background(), "test-ns", "my-agent")
+			require.NoError(t, err)
+			assert.Equal(t, tc.phase, build.Phase)
+			assert.Equal(t, "sha256:abc", build.ImageDigest)
+		})
+	}
+}
+
+func TestGetAgentBuild_BuildConfigLatestBuild(t *testing.T) {
+	client, dynamicClient := newDeployTestClient(t)
+	_, err := client.CreateAgentBuild(context.Background(), sourceDeployParams(agents.BuildStrategyBuildConfig))
+	require.NoError(t, err)
+
+	for _, b := range []struct {
+		name, number, phase string
+	}{
+		{"my-agent-1", "1", "Failed"},
+		{"my-agent-2", "2", "Complete"},
+	} {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": openshiftBuildGVR.Group + "/" + openshiftBuildGVR.Version,
+			"kind":       "Build",
+			"metadata": map[string]any{
+				"name":        b.name,
+				"namespace":   "test-ns",
+				"labels":      map[string]any{labelOpenShiftBuildConfig: "my-agent"},
+				"annotations": map[string]any{annotationOpenShiftBuildNo: b.number},
+			},
+			"status": map[string]any{"phase": b.phase},
+		}}
+		_, err := dynamicClient.Resource(openshiftBuildGVR).Namespace("test-ns").Create(context.Background(), obj, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	build, err := client.GetAgentBuild(context.Background(), "test-ns", "my-agent")
+
+	require.NoError(t, err)
+	assert.Equal(t, "my-agent-2", build.BuildRunName)
+	assert.Equal(t, agents.BuildPhaseSucceeded, build.Phase)
+}
+
+func TestGetAgentBuild_NotFound(t *testing.T) {
+	client, _ := newDeployTestClient(t)
+
+	_, err := client.GetAgentBuild(context.Background(), "test-ns", "missing")
+
+	assert.ErrorIs(t, err, agents.ErrNotFound)
+}
+
+func TestSplitImageTag(t *testing.T) {
+	repo, tag := splitImageTag("image-registry.openshift-image-registry.svc:5000/ns/agent:v1")
+	assert.Equal(t, "image-registry.openshift-image-registry.svc:5000/ns/agent", repo)
+	assert.Equal(t, "v1", tag)
+
+	repo, tag = splitImageTag("registry:5000/ns/agent")
+	assert.Equal(t, "registry:5000/ns/agent", repo)
+	assert.Empty(t, tag)
+}
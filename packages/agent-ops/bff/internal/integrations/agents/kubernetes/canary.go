@@ -0,0 +1,307 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const canaryRouteComponentValue = "agent-canary-route"
+
+// SetAgentCanary runs revision in a second Sandbox next to the agent and splits the
+// traffic of every Route in front of the agent Service by weight. When no Route
+// targets the agent yet, one named after the agent is created.
+func (c *Client) SetAgentCanary(ctx context.Context, namespace, name string, revision int64, weight int32) (*agents.AgentCanary, error) {
+	if weight < 1 || weight > 99 {
+		return nil, fmt.Errorf("canary weight must be between 1 and 99, got %d", weight)
+	}
+	dynamicClient, err := c.k8sClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+
+	primary, err := getSandbox(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, mapK8sError(err)
+	}
+	params, err := c.revisionParams(ctx, primary, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	canary, err := c.upsertCanarySandbox(ctx, dynamicClient, primary, params, revision)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := c.splitAgentRoutes(ctx, dynamicClient, namespace, name, weight)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": map[string]any{
+		agents.AnnotationCanaryRevision: strconv.FormatInt(revision, 10),
+		agents.AnnotationCanaryWeight:   strconv.FormatInt(int64(weight), 10),
+	}}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode canary annotations: %w", err)
+	}
+	if _, err := dynamicClient.Resource(sandboxGVR).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to annotate agent canary: %w", mapK8sError(err))
+	}
+
+	c.logger.Info("Agent canary configured",
+		slog.String("name", name),
+		slog.String("namespace", namespace),
+		slog.Int64("revision", revision),
+		slog.Int("weight", int(weight)))
+
+	return &agents.AgentCanary{
+		Revision:     revision,
+		Weight:       weight,
+		WorkloadName: canary.GetName(),
+		ReadyStatus:  sandboxPhase(*canary),
+		Routes:       routes,
+	}, nil
+}
+
+// EndAgentCanary restores full traffic to the agent and deletes the canary Sandbox.
+// With promote, the canary revision is first redeployed as the agent's new revision.
+func (c *Client) EndAgentCanary(ctx context.Context, namespace, name string, promote bool) (*agents.AgentRevision, error) {
+	dynamicClient, err := c.k8sClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+
+	primary, err := getSandbox(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, mapK8sError(err)
+	}
+	canary := canaryFromSandbox(*primary)
+	if canary == nil {
+		return nil, fmt.Errorf("agent %s/%s has no active canary: %w", namespace, name, agents.ErrConflict)
+	}
+
+	var promoted *agents.AgentRevision
+	if promote {
+		params, err := c.revisionParams(ctx, primary, canary.Revision)
+		if err != nil {
+			return nil, err
+		}
+		promoted, err = c.applyRevision(ctx, params, fmt.Sprintf("promote canary revision %d", canary.Revision))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.restoreAgentRoutes(ctx, dynamicClient, namespace, name); err != nil {
+		return nil, err
+	}
+	err = dynamicClient.Resource(sandboxGVR).Namespace(namespace).Delete(ctx, canary.WorkloadName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to delete canary Sandbox: %w", mapK8sError(err))
+	}
+
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": map[string]any{
+		agents.AnnotationCanaryRevision: nil,
+		agents.AnnotationCanaryWeight:   nil,
+	}}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode canary annotations: %w", err)
+	}
+	if _, err := dynamicClient.Resource(sandboxGVR).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to clear agent canary: %w", mapK8sError(err))
+	}
+
+	c.logger.Info("Agent canary ended",
+		slog.String("name", name),
+		slog.String("namespace", namespace),
+		slog.Int64("revision", canary.Revision),
+		slog.Bool("promoted", promote))
+	return promoted, nil
+}
+
+// upsertCanarySandbox creates or updates the canary Sandbox. The canary carries no
+// OpenShell discovery label so it is not listed as a separate agent, and it is owned
+// by the primary Sandbox so deleting the agent removes it.
+func (c *Client) upsertCanarySandbox(
+	ctx context.Context,
+	dynamicClient dynamic.Interface,
+	primary *unstructured.Unstructured,
+	params *agents.DeployAgentParams,
+	revision int64,
+) (*unstructured.Unstructured, error) {
+	canaryParams := *params
+	canaryParams.Name = agents.CanaryWorkloadName(primary.GetName())
+	desired := buildSandboxCR(&canaryParams)
+
+	labels := desired.GetLabels()
+	delete(labels, agents.LabelOpenShellManagedBy)
+	labels[agents.LabelCanaryOf] = primary.GetName()
+	desired.SetLabels(labels)
+	desired.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: primary.GetAPIVersion(),
+		Kind:       primary.GetKind(),
+		Name:       primary.GetName(),
+		UID:        primary.GetUID(),
+	}})
+
+	resource := dynamicClient.Resource(sandboxGVR).Namespace(primary.GetNamespace())
+	existing, err := resource.Get(ctx, canaryParams.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		annotations := desired.GetAnnotations()
+		annotations[agents.AnnotationRevision] = strconv.FormatInt(revision, 10)
+		desired.SetAnnotations(annotations)
+		created, err := resource.Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create canary Sandbox: %w", mapK8sError(err))
+		}
+		return created, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get canary Sandbox: %w", mapK8sError(err))
+	}
+	if existing.GetLabels()[agents.LabelCanaryOf] != primary.GetName() {
+		return nil, fmt.Errorf("Sandbox %q exists and is not a canary of %q: %w", canaryParams.Name, primary.GetName(), agents.ErrConflict)
+	}
+
+	applySandboxTemplate(existing, desired, revision)
+	updated, err := resource.Update(ctx, existing, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update canary Sandbox: %w", mapK8sError(err))
+	}
+	return updated, nil
+}
+
+// splitAgentRoutes points every Route targeting the agent Service at both the agent
+// and canary Services with the requested weights, returning the Route names.
+func (c *Client) splitAgentRoutes(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string, weight int32) ([]string, error) {
+	routes, err := agentRoutes(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := dynamicClient.Resource(openshiftRouteGVR).Namespace(namespace)
+	if len(routes) == 0 {
+		route := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": openshiftRouteGVR.Group + "/" + openshiftRouteGVR.Version,
+			"kind":       "Route",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": namespace,
+				"labels": map[string]any{
+					labelManagedBy: managedByValue,
+					labelAppName:   name,
+					labelComponent: canaryRouteComponentValue,
+				},
+			},
+			"spec": map[string]any{},
+		}}
+		setRouteWeights(route, name, weight)
+		if _, err := resource.Create(ctx, route, metav1.CreateOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to create canary Route: %w", mapRouteError(err))
+		}
+		return []string{name}, nil
+	}
+
+	names := make([]string, 0, len(routes))
+	for i := range routes {
+		route := &routes[i]
+		setRouteWeights(route, name, weight)
+		if _, err := resource.Update(ctx, route, metav1.UpdateOptions{}); err != nil {
+			return nil, fmt.Errorf("failed to update Route %q: %w", route.GetName(), mapRouteError(err))
+		}
+		names = append(names, route.GetName())
+	}
+	return names, nil
+}
+
+// restoreAgentRoutes sends all traffic back to the agent Service. Routes created only
+// for the canary are deleted.
+func (c *Client) restoreAgentRoutes(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) error {
+	routes, err := agentRoutes(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	resource := dynamicClient.Resource(openshiftRouteGVR).Namespace(namespace)
+	for i := range routes {
+		route := &routes[i]
+		if route.GetLabels()[labelComponent] == canaryRouteComponentValue {
+			if err := resource.Delete(ctx, route.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete canary Route %q: %w", route.GetName(), mapRouteError(err))
+			}
+			continue
+		}
+		unstructured.RemoveNestedField(route.Object, "spec", "alternateBackends")
+		_ = unstructured.SetNestedField(route.Object, int64(100), "spec", "to", "weight")
+		if _, err := resource.Update(ctx, route, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update Route %q: %w", route.GetName(), mapRouteError(err))
+		}
+	}
+	return nil
+}
+
+func agentRoutes(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) ([]unstructured.Unstructured, error) {
+	list, err := dynamicClient.Resource(openshiftRouteGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Routes: %w", mapRouteError(err))
+	}
+	routes := make([]unstructured.Unstructured, 0, len(list.Items))
+	for _, item := range list.Items {
+		item := item
+		if routeTargetsService(&item, name) {
+			routes = append(routes, item)
+		}
+	}
+	return routes, nil
+}
+
+func setRouteWeights(route *unstructured.Unstructured, name string, weight int32) {
+	_ = unstructured.SetNestedMap(route.Object, map[string]any{
+		"kind":   "Service",
+		"name":   name,
+		"weight": int64(100 - weight),
+	}, "spec", "to")
+	_ = unstructured.SetNestedSlice(route.Object, []any{
+		map[string]any{
+			"kind":   "Service",
+			"name":   agents.CanaryWorkloadName(name),
+			"weight": int64(weight),
+		},
+	}, "spec", "alternateBackends")
+}
+
+// canaryFromSandbox reads the active canary recorded on the primary Sandbox.
+func canaryFromSandbox(sandbox unstructured.Unstructured) *agents.AgentCanary {
+	annotations := sandbox.GetAnnotations()
+	revision, err := strconv.ParseInt(annotations[agents.AnnotationCanaryRevision], 10, 64)
+	if err != nil {
+		return nil
+	}
+	weight, _ := strconv.ParseInt(annotations[agents.AnnotationCanaryWeight], 10, 32)
+	return &agents.AgentCanary{
+		Revision:     revision,
+		Weight:       int32(weight),
+		WorkloadName: agents.CanaryWorkloadName(sandbox.GetName()),
+		ReadyStatus:  statusPending,
+	}
+}
+
+// mapRouteError reports a cluster without OpenShift Routes as unavailable, since
+// canary traffic splitting has no other backend.
+func mapRouteError(err error) error {
+	if meta.IsNoMatchError(err) {
+		return &agents.UnavailableError{Message: "OpenShift Routes are not available; canary traffic splitting requires route.openshift.io"}
+	}
+	return mapK8sError(err)
+}
@@ -135,6 +135,13 @@ func (c *Client) getAgentDetail(ctx context.Context, namespace, name string) (*a
 
 	service := mapService(c.getServiceBestEffort(ctx, namespace, name))
 	detail := sandboxToDetail(*obj, service)
+	detail.Revision = revisionFromSandbox(*obj)
+	if canary := canaryFromSandbox(*obj); canary != nil {
+		if canaryObj, err := getSandbox(ctx, dynamicClient, namespace, canary.WorkloadName); err == nil {
+			canary.ReadyStatus = sandboxPhase(*canaryObj)
+		}
+		detail.Canary = canary
+	}
 	return detail, nil
 }
 
@@ -12,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 )
 
 // DeployAgent creates a Sandbox CR for the agent.
@@ -26,14 +27,30 @@ func (c *Client) DeployAgent(ctx context.Context, params *agents.DeployAgentPara
 		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
 	}
 
+	if err := c.validateDeployReferences(ctx, params); err != nil {
+		return nil, err
+	}
+
 	sandboxCR := buildSandboxCR(params)
-	_, err = dynamicClient.Resource(sandboxGVR).Namespace(params.Namespace).Create(ctx, sandboxCR, metav1.CreateOptions{})
+	annotations := sandboxCR.GetAnnotations()
+	annotations[agents.AnnotationRevision] = "1"
+	sandboxCR.SetAnnotations(annotations)
+	created, err := dynamicClient.Resource(sandboxGVR).Namespace(params.Namespace).Create(ctx, sandboxCR, metav1.CreateOptions{})
 	if err != nil {
 		if apierrors.IsAlreadyExists(err) {
 			return nil, fmt.Errorf("Sandbox %q already exists: %w", params.Name, agents.ErrAlreadyExists)
 		}
 		return nil, fmt.Errorf("failed to create Sandbox CR: %w", mapK8sError(err))
 	}
+	if err := c.recordInitialRevision(ctx, dynamicClient, created, params); err != nil {
+		c.removeSandboxBestEffort(ctx, dynamicClient, params, "revision could not be recorded")
+		return nil, fmt.Errorf("failed to record agent revision: %w", err)
+	}
+
+	if err := c.reconcileAgentScaling(ctx, dynamicClient, created, params.Scaling); err != nil {
+		c.removeSandboxBestEffort(ctx, dynamicClient, params, "autoscaler setup failed")
+		return nil, fmt.Errorf("failed to configure agent autoscaling: %w", err)
+	}
 
 	return &agents.DeployAgentResult{
 		Name:      params.Name,
@@ -41,6 +58,18 @@ func (c *Client) DeployAgent(ctx context.Context, params *agents.DeployAgentPara
 	}, nil
 }
 
+// removeSandboxBestEffort deletes a Sandbox created by a deploy that failed
+// afterwards, so the caller can retry the deploy as a whole.
+func (c *Client) removeSandboxBestEffort(ctx context.Context, dynamicClient dynamic.Interface, params *agents.DeployAgentParams, reason string) {
+	err := dynamicClient.Resource(sandboxGVR).Namespace(params.Namespace).Delete(ctx, params.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		c.logger.Warn("failed to remove agent after "+reason,
+			slog.String("name", params.Name),
+			slog.String("namespace", params.Namespace),
+			slog.Any("error", err))
+	}
+}
+
 // DeleteAgent removes a Sandbox CR (agents.x-k8s.io/v1beta1).
 // The sandbox controller handles pod and service cleanup.
 func (c *Client) DeleteAgent(ctx context.Context, namespace, name string) error {
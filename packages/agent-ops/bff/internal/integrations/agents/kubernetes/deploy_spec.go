@@ -0,0 +1,191 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+)
+
+// deploySpec is the persisted form of agents.DeployAgentParams, stored in agent
+// revisions and in the Secret holding a deploy-from-source request. It has its
+// own JSON tags so renaming a Go field in the agents package never breaks
+// decoding of data that is already stored in the cluster. Name, namespace and
+// source are not stored; they are known from the owning object.
+type deploySpec struct {
+	ContainerImage  string                  `json:"containerImage,omitempty"`
+	ImageTag        string                  `json:"imageTag,omitempty"`
+	ImagePullSecret string                  `json:"imagePullSecret,omitempty"`
+	Protocol        string                  `json:"protocol,omitempty"`
+	Framework       string                  `json:"framework,omitempty"`
+	Description     string                  `json:"description,omitempty"`
+	EnvVars         []deploySpecEnvVar      `json:"envVars,omitempty"`
+	ServicePorts    []deploySpecPort        `json:"servicePorts,omitempty"`
+	Resources       *deploySpecResources    `json:"resources,omitempty"`
+	Scaling         *deploySpecScaling      `json:"scaling,omitempty"`
+	VolumeMounts    []deploySpecVolumeMount `json:"volumeMounts,omitempty"`
+}
+
+type deploySpecEnvVar struct {
+	Name      string                  `json:"name"`
+	Value     string                  `json:"value,omitempty"`
+	ValueFrom *deploySpecEnvVarSource `json:"valueFrom,omitempty"`
+}
+
+type deploySpecEnvVarSource struct {
+	SecretKeyRef    *deploySpecKeyRef `json:"secretKeyRef,omitempty"`
+	ConfigMapKeyRef *deploySpecKeyRef `json:"configMapKeyRef,omitempty"`
+}
+
+type deploySpecKeyRef struct {
+	Name     string `json:"name"`
+	Key      string `json:"key"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+type deploySpecPort struct {
+	Name       string `json:"name,omitempty"`
+	Port       int32  `json:"port"`
+	TargetPort int32  `json:"targetPort,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+}
+
+type deploySpecResources struct {
+	Requests deploySpecResourceList `json:"requests,omitempty"`
+	Limits   deploySpecResourceList `json:"limits,omitempty"`
+}
+
+type deploySpecResourceList struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+type deploySpecScaling struct {
+	MinReplicas        int32 `json:"minReplicas,omitempty"`
+	MaxReplicas        int32 `json:"maxReplicas,omitempty"`
+	ScaleToZero        bool  `json:"scaleToZero,omitempty"`
+	IdleTimeoutSeconds int32 `json:"idleTimeoutSeconds,omitempty"`
+}
+
+type deploySpecVolumeMount struct {
+	Name                  string `json:"name"`
+	MountPath             string `json:"mountPath"`
+	SubPath               string `json:"subPath,omitempty"`
+	ReadOnly              bool   `json:"readOnly,omitempty"`
+	Secret                string `json:"secret,omitempty"`
+	ConfigMap             string `json:"configMap,omitempty"`
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+}
+
+func encodeDeploySpec(params *agents.DeployAgentParams) (string, error) {
+	raw, err := json.Marshal(deploySpecFromParams(params))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode deploy spec: %w", err)
+	}
+	return string(raw), nil
+}
+
+// decodeDeploySpec returns the params stored in raw, without name and namespace,
+// or nil when raw is empty or unreadable.
+func decodeDeploySpec(raw string) *agents.DeployAgentParams {
+	if raw == "" {
+		return nil
+	}
+	var spec deploySpec
+	if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+		return nil
+	}
+	return spec.toParams()
+}
+
+func deploySpecFromParams(params *agents.DeployAgentParams) *deploySpec {
+	spec := &deploySpec{
+		ContainerImage:  params.ContainerImage,
+		ImageTag:        params.ImageTag,
+		ImagePullSecret: params.ImagePullSecret,
+		Protocol:        params.Protocol,
+		Framework:       params.Framework,
+		Description:     params.Description,
+	}
+	for _, env := range params.EnvVars {
+		specEnv := deploySpecEnvVar{Name: env.Name, Value: env.Value}
+		if env.ValueFrom != nil {
+			specEnv.ValueFrom = &deploySpecEnvVarSource{
+				SecretKeyRef:    keyRefToSpec(env.ValueFrom.SecretKeyRef),
+				ConfigMapKeyRef: keyRefToSpec(env.ValueFrom.ConfigMapKeyRef),
+			}
+		}
+		spec.EnvVars = append(spec.EnvVars, specEnv)
+	}
+	for _, port := range params.ServicePorts {
+		spec.ServicePorts = append(spec.ServicePorts, deploySpecPort(port))
+	}
+	if params.Resources != nil {
+		spec.Resources = &deploySpecResources{
+			Requests: deploySpecResourceList(params.Resources.Requests),
+			Limits:   deploySpecResourceList(params.Resources.Limits),
+		}
+	}
+	if params.Scaling != nil {
+		scaling := deploySpecScaling(*params.Scaling)
+		spec.Scaling = &scaling
+	}
+	for _, mount := range params.VolumeMounts {
+		spec.VolumeMounts = append(spec.VolumeMounts, deploySpecVolumeMount(mount))
+	}
+	return spec
+}
+
+func (s *deploySpec) toParams() *agents.DeployAgentParams {
+	params := &agents.DeployAgentParams{
+		ContainerImage:  s.ContainerImage,
+		ImageTag:        s.ImageTag,
+		ImagePullSecret: s.ImagePullSecret,
+		Protocol:        s.Protocol,
+		Framework:       s.Framework,
+		Description:     s.Description,
+	}
+	for _, specEnv := range s.EnvVars {
+		env := agents.AgentEnvVar{Name: specEnv.Name, Value: specEnv.Value}
+		if specEnv.ValueFrom != nil {
+			env.ValueFrom = &agents.AgentEnvVarSource{
+				SecretKeyRef:    keyRefFromSpec(specEnv.ValueFrom.SecretKeyRef),
+				ConfigMapKeyRef: keyRefFromSpec(specEnv.ValueFrom.ConfigMapKeyRef),
+			}
+		}
+		params.EnvVars = append(params.EnvVars, env)
+	}
+	for _, port := range s.ServicePorts {
+		params.ServicePorts = append(params.ServicePorts, agents.AgentServicePortSpec(port))
+	}
+	if s.Resources != nil {
+		params.Resources = &agents.AgentResources{
+			Requests: agents.AgentResourceList(s.Resources.Requests),
+			Limits:   agents.AgentResourceList(s.Resources.Limits),
+		}
+	}
+	if s.Scaling != nil {
+		scaling := agents.AgentScaling(*s.Scaling)
+		params.Scaling = &scaling
+	}
+	for _, mount := range s.VolumeMounts {
+		params.VolumeMounts = append(params.VolumeMounts, agents.AgentVolumeMount(mount))
+	}
+	return params
+}
+
+func keyRefToSpec(ref *agents.AgentKeyRef) *deploySpecKeyRef {
+	if ref == nil {
+		return nil
+	}
+	specRef := deploySpecKeyRef(*ref)
+	return &specRef
+}
+
+func keyRefFromSpec(ref *deploySpecKeyRef) *agents.AgentKeyRef {
+	if ref == nil {
+		return nil
+	}
+	agentRef := agents.AgentKeyRef(*ref)
+	return &agentRef
+}
@@ -76,8 +76,12 @@ func newDeployTestClient(t *testing.T, objects ...runtime.Object) (*Client, *fak
 	clientset := fake.NewClientset(objects...)
 	scheme := runtime.NewScheme()
 	gvrToListKind := map[schema.GroupVersionResource]string{
-		sandboxGVR:        "SandboxList",
-		openshiftRouteGVR: "RouteList",
+		sandboxGVR:            "SandboxList",
+		openshiftRouteGVR:     "RouteList",
+		shipwrightBuildGVR:    "BuildList",
+		shipwrightBuildRunGVR: "BuildRunList",
+		buildConfigGVR:        "BuildConfigList",
+		openshiftBuildGVR:     "BuildList",
 	}
 	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(scheme, gvrToListKind)
 
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -45,6 +46,13 @@ func buildSandboxCR(params *agents.DeployAgentParams) *unstructured.Unstructured
 		},
 		"env": envVars,
 	}
+	if resources := buildResourcesForSandbox(params.Resources); resources != nil {
+		container["resources"] = resources
+	}
+	volumes, volumeMounts := buildVolumesForSandbox(params.VolumeMounts)
+	if len(volumeMounts) > 0 {
+		container["volumeMounts"] = volumeMounts
+	}
 
 	labels := map[string]any{
 		agents.LabelOpenShellManagedBy: agents.OpenShellManagedByValue,
@@ -75,6 +83,9 @@ func buildSandboxCR(params *agents.DeployAgentParams) *unstructured.Unstructured
 			map[string]any{"name": params.ImagePullSecret},
 		}
 	}
+	if len(volumes) > 0 {
+		podTemplateSpec["volumes"] = volumes
+	}
 
 	return &unstructured.Unstructured{
 		Object: map[string]any{
@@ -134,11 +145,164 @@ func buildEnvVarsForSandbox(params *agents.DeployAgentParams) []any {
 		}
 	}
 	for _, ev := range params.EnvVars {
-		result = append(result, map[string]any{"name": ev.Name, "value": ev.Value})
+		result = append(result, buildEnvVar(ev))
+	}
+	return result
+}
+
+func buildEnvVar(ev agents.AgentEnvVar) map[string]any {
+	if ev.ValueFrom == nil {
+		return map[string]any{"name": ev.Name, "value": ev.Value}
+	}
+	valueFrom := map[string]any{}
+	if ref := ev.ValueFrom.SecretKeyRef; ref != nil {
+		valueFrom["secretKeyRef"] = keyRefSelector(ref)
+	}
+	if ref := ev.ValueFrom.ConfigMapKeyRef; ref != nil {
+		valueFrom["configMapKeyRef"] = keyRefSelector(ref)
+	}
+	return map[string]any{"name": ev.Name, "valueFrom": valueFrom}
+}
+
+func keyRefSelector(ref *agents.AgentKeyRef) map[string]any {
+	selector := map[string]any{"name": ref.Name, "key": ref.Key}
+	if ref.Optional {
+		selector["optional"] = true
+	}
+	return selector
+}
+
+func buildResourcesForSandbox(resources *agents.AgentResources) map[string]any {
+	if resources == nil {
+		return nil
+	}
+	result := map[string]any{}
+	if list := resourceList(resources.Requests); len(list) > 0 {
+		result["requests"] = list
+	}
+	if list := resourceList(resources.Limits); len(list) > 0 {
+		result["limits"] = list
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func resourceList(list agents.AgentResourceList) map[string]any {
+	result := map[string]any{}
+	if list.CPU != "" {
+		result["cpu"] = list.CPU
+	}
+	if list.Memory != "" {
+		result["memory"] = list.Memory
 	}
 	return result
 }
 
+// buildVolumesForSandbox returns the pod volumes and container volumeMounts for mounts.
+// Each mount gets its own volume named after the mount.
+func buildVolumesForSandbox(mounts []agents.AgentVolumeMount) ([]any, []any) {
+	var volumes, volumeMounts []any
+	for _, m := range mounts {
+		volume := map[string]any{"name": m.Name}
+		switch {
+		case m.Secret != "":
+			volume["secret"] = map[string]any{"secretName": m.Secret}
+		case m.ConfigMap != "":
+			volume["configMap"] = map[string]any{"name": m.ConfigMap}
+		case m.PersistentVolumeClaim != "":
+			volume["persistentVolumeClaim"] = map[string]any{"claimName": m.PersistentVolumeClaim, "readOnly": m.ReadOnly}
+		}
+		volumes = append(volumes, volume)
+
+		mount := map[string]any{"name": m.Name, "mountPath": m.MountPath}
+		if m.SubPath != "" {
+			mount["subPath"] = m.SubPath
+		}
+		if m.ReadOnly {
+			mount["readOnly"] = true
+		}
+		volumeMounts = append(volumeMounts, mount)
+	}
+	return volumes, volumeMounts
+}
+
+func scalingLabels(name string) map[string]string {
+	return map[string]string{
+		labelManagedBy: managedByValue,
+		labelAppName:   name,
+		labelComponent: scalingComponentValue,
+	}
+}
+
+func sandboxOwnerReference(sandbox *unstructured.Unstructured) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: sandbox.GetAPIVersion(),
+		Kind:       sandbox.GetKind(),
+		Name:       sandbox.GetName(),
+		UID:        sandbox.GetUID(),
+	}
+}
+
+// buildScaledObject scales the single-pod Sandbox between zero and one replica with
+// KEDA through its scale subresource. A Prometheus trigger on the request rate of
+// routes, the Routes serving the agent, wakes an idle agent; the first requests after
+// an idle period are answered by the router while the agent starts.
+func buildScaledObject(sandbox *unstructured.Unstructured, scaling *agents.AgentScaling, routes []string) *unstructured.Unstructured {
+	name, namespace := sandbox.GetName(), sandbox.GetNamespace()
+	labels := map[string]any{}
+	for key, value := range scalingLabels(name) {
+		labels[key] = value
+	}
+	owner := sandboxOwnerReference(sandbox)
+	query := fmt.Sprintf(`sum(rate(haproxy_backend_http_responses_total{exported_namespace=%q,route=~%q}[2m]))`,
+		namespace, "^("+strings.Join(routes, "|")+")$")
+
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": kedaScaledObjectGVR.Group + "/" + kedaScaledObjectGVR.Version,
+		"kind":       "ScaledObject",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+			"labels":    labels,
+			"ownerReferences": []any{map[string]any{
+				"apiVersion": owner.APIVersion,
+				"kind":       owner.Kind,
+				"name":       owner.Name,
+				"uid":        string(owner.UID),
+			}},
+		},
+		"spec": map[string]any{
+			"scaleTargetRef": map[string]any{
+				"apiVersion": sandbox.GetAPIVersion(),
+				"kind":       sandbox.GetKind(),
+				"name":       name,
+			},
+			"minReplicaCount": int64(scaling.MinReplicas),
+			"maxReplicaCount": int64(scaling.MaxReplicas),
+			"cooldownPeriod":  int64(scaling.IdleTimeoutSeconds),
+			"triggers": []any{
+				map[string]any{
+					"type": "prometheus",
+					"authenticationRef": map[string]any{
+						"name": scaleToZeroTriggerAuthentication,
+						"kind": "ClusterTriggerAuthentication",
+					},
+					"metadata": map[string]any{
+						"serverAddress":       scaleToZeroPrometheusURL,
+						"query":               query,
+						"threshold":           "1",
+						"activationThreshold": "0",
+						"authModes":           "bearer",
+						"namespace":           namespace,
+					},
+				},
+			},
+		},
+	}}
+}
+
 func agentEndpointURL(name, namespace string, port int32) string {
 	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/", name, namespace, port)
 }
@@ -97,3 +97,64 @@ func TestBuildSandboxCR_ImagePullSecret(t *testing.T) {
 	require.Len(t, secrets, 1)
 	assert.Equal(t, "my-secret", secrets[0].(map[string]any)["name"])
 }
+
+func TestBuildSandboxCR_ResourcesEnvFromAndVolumes(t *testing.T) {
+	params := &agents.DeployAgentParams{
+		Name:           "my-agent",
+		Namespace:      "test-ns",
+		ContainerImage: "quay.io/example/agent",
+		EnvVars: []agents.AgentEnvVar{
+			{Name: "API_KEY", ValueFrom: &agents.AgentEnvVarSource{
+				SecretKeyRef: &agents.AgentKeyRef{Name: "model-creds", Key: "api-key"},
+			}},
+		},
+		Resources: &agents.AgentResources{
+			Requests: agents.AgentResourceList{CPU: "250m", Memory: "512Mi"},
+			Limits:   agents.AgentResourceList{Memory: "1Gi"},
+		},
+		VolumeMounts: []agents.AgentVolumeMount{
+			{Name: "creds", MountPath: "/var/run/creds", ReadOnly: true, Secret: "model-creds"},
+			{Name: "models", MountPath: "/models", SubPath: "llama", PersistentVolumeClaim: "model-cache"},
+		},
+	}
+
+	obj := buildSandboxCR(params)
+	spec := obj.Object["spec"].(map[string]any)
+	podSpec := spec["podTemplate"].(map[string]any)["spec"].(map[string]any)
+	container := podSpec["containers"].([]any)[0].(map[string]any)
+
+	var apiKey map[string]any
+	for _, ev := range container["env"].([]any) {
+		if e := ev.(map[string]any); e["name"] == "API_KEY" {
+			apiKey = e
+		}
+	}
+	require.NotNil(t, apiKey)
+	assert.NotContains(t, apiKey, "value")
+	assert.Equal(t, map[string]any{"secretKeyRef": map[string]any{"name": "model-creds", "key": "api-key"}}, apiKey["valueFrom"])
+
+	assert.Equal(t, map[string]any{
+		"requests": map[string]any{"cpu": "250m", "memory": "512Mi"},
+		"limits":   map[string]any{"memory": "1Gi"},
+	}, container["resources"])
+
+	assert.Equal(t, []any{
+		map[string]any{"name": "creds", "secret": map[string]any{"secretName": "model-creds"}},
+		map[string]any{"name": "models", "persistentVolumeClaim": map[string]any{"claimName": "model-cache", "readOnly": false}},
+	}, podSpec["volumes"])
+	assert.Equal(t, []any{
+		map[string]any{"name": "creds", "mountPath": "/var/run/creds", "readOnly": true},
+		map[string]any{"name": "models", "mountPath": "/models", "subPath": "llama"},
+	}, container["volumeMounts"])
+}
+
+func TestBuildSandboxCR_NoResourcesOrVolumes(t *testing.T) {
+	obj := buildSandboxCR(&agents.DeployAgentParams{Name: "my-agent", Namespace: "test-ns", ContainerImage: "quay.io/example/agent"})
+	spec := obj.Object["spec"].(map[string]any)
+	podSpec := spec["podTemplate"].(map[string]any)["spec"].(map[string]any)
+	container := podSpec["containers"].([]any)[0].(map[string]any)
+
+	assert.NotContains(t, container, "resources")
+	assert.NotContains(t, container, "volumeMounts")
+	assert.NotContains(t, podSpec, "volumes")
+}
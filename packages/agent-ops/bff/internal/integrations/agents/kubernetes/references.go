@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// validateDeployReferences checks that the Secrets, ConfigMaps and PersistentVolumeClaims
+// referenced by params exist in the agent namespace, and that referenced keys are present
+// unless marked optional. Lookups run as the caller, so a reference the caller cannot read
+// is rejected as forbidden rather than mounted on their behalf.
+func (c *Client) validateDeployReferences(ctx context.Context, params *agents.DeployAgentParams) error {
+	refs := &referenceCache{
+		client:     c,
+		namespace:  params.Namespace,
+		secrets:    map[string]map[string]bool{},
+		configMaps: map[string]map[string]bool{},
+	}
+
+	for _, ev := range params.EnvVars {
+		if ev.ValueFrom == nil {
+			continue
+		}
+		if ref := ev.ValueFrom.SecretKeyRef; ref != nil {
+			if err := refs.checkKey(ctx, "Secret", ref, refs.secretKeys); err != nil {
+				return fmt.Errorf("envVars %q: %w", ev.Name, err)
+			}
+		}
+		if ref := ev.ValueFrom.ConfigMapKeyRef; ref != nil {
+			if err := refs.checkKey(ctx, "ConfigMap", ref, refs.configMapKeys); err != nil {
+				return fmt.Errorf("envVars %q: %w", ev.Name, err)
+			}
+		}
+	}
+
+	for _, m := range params.VolumeMounts {
+		var err error
+		switch {
+		case m.Secret != "":
+			err = refs.checkExists(ctx, "Secret", m.Secret, refs.secretKeys)
+		case m.ConfigMap != "":
+			err = refs.checkExists(ctx, "ConfigMap", m.ConfigMap, refs.configMapKeys)
+		case m.PersistentVolumeClaim != "":
+			err = refs.checkPersistentVolumeClaim(ctx, m.PersistentVolumeClaim)
+		}
+		if err != nil {
+			return fmt.Errorf("volumeMounts %q: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+// referenceCache avoids reading the same Secret or ConfigMap once per key. A nil key
+// set means the object does not exist.
+type referenceCache struct {
+	client     *Client
+	namespace  string
+	secrets    map[string]map[string]bool
+	configMaps map[string]map[string]bool
+}
+
+type keyLookup func(ctx context.Context, name string) (map[string]bool, error)
+
+func (r *referenceCache) checkKey(ctx context.Context, kind string, ref *agents.AgentKeyRef, lookup keyLookup) error {
+	keys, err := lookup(ctx, ref.Name)
+	if err != nil {
+		return err
+	}
+	if ref.Optional {
+		return nil
+	}
+	if keys == nil {
+		return r.notFound(kind, ref.Name)
+	}
+	if !keys[ref.Key] {
+		return fmt.Errorf("%s %q has no key %q: %w", kind, ref.Name, ref.Key, agents.ErrInvalidSpec)
+	}
+	return nil
+}
+
+func (r *referenceCache) checkExists(ctx context.Context, kind, name string, lookup keyLookup) error {
+	keys, err := lookup(ctx, name)
+	if err != nil {
+		return err
+	}
+	if keys == nil {
+		return r.notFound(kind, name)
+	}
+	return nil
+}
+
+func (r *referenceCache) secretKeys(ctx context.Context, name string) (map[string]bool, error) {
+	if keys, ok := r.secrets[name]; ok {
+		return keys, nil
+	}
+	secret, err := r.client.k8sClient.KubernetesClientset().CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, r.lookupError("Secret", name, err)
+	}
+	var keys map[string]bool
+	if err == nil {
+		keys = make(map[string]bool, len(secret.Data)+len(secret.StringData))
+		for key := range secret.Data {
+			keys[key] = true
+		}
+		for key := range secret.StringData {
+			keys[key] = true
+		}
+	}
+	r.secrets[name] = keys
+	return keys, nil
+}
+
+func (r *referenceCache) configMapKeys(ctx context.Context, name string) (map[string]bool, error) {
+	if keys, ok := r.configMaps[name]; ok {
+		return keys, nil
+	}
+	configMap, err := r.client.k8sClient.KubernetesClientset().CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, r.lookupError("ConfigMap", name, err)
+	}
+	var keys map[string]bool
+	if err == nil {
+		keys = make(map[string]bool, len(configMap.Data)+len(configMap.BinaryData))
+		for key := range configMap.Data {
+			keys[key] = true
+		}
+		for key := range configMap.BinaryData {
+			keys[key] = true
+		}
+	}
+	r.configMaps[name] = keys
+	return keys, nil
+}
+
+func (r *referenceCache) checkPersistentVolumeClaim(ctx context.Context, name string) error {
+	_, err := r.client.k8sClient.KubernetesClientset().CoreV1().PersistentVolumeClaims(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return r.notFound("PersistentVolumeClaim", name)
+	}
+	if err != nil {
+		return r.lookupError("PersistentVolumeClaim", name, err)
+	}
+	return nil
+}
+
+func (r *referenceCache) notFound(kind, name string) error {
+	return fmt.Errorf("%s %q not found in namespace %q: %w", kind, name, r.namespace, agents.ErrInvalidSpec)
+}
+
+func (r *referenceCache) lookupError(kind, name string, err error) error {
+	if apierrors.IsForbidden(err) {
+		return fmt.Errorf("cannot read %s %q in namespace %q: %w", kind, name, r.namespace, agents.ErrForbidden)
+	}
+	return fmt.Errorf("failed to read %s %q: %w", kind, name, mapK8sError(err))
+}
@@ -0,0 +1,300 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	revisionComponentValue = "agent-revision"
+	annotationChangeCause  = "kubernetes.io/change-cause"
+
+	changeCauseDeploy   = "deploy"
+	changeCauseRedeploy = "redeploy"
+)
+
+// Revisions are stored as ControllerRevisions owned by the agent Sandbox, so they
+// are garbage-collected with the agent. Data holds the JSON-encoded deploy spec.
+// Revisions of an earlier agent with the same name may linger until garbage
+// collection catches up, so reads only consider revisions owned by the current
+// Sandbox UID.
+
+func revisionName(name string, number int64) string {
+	return fmt.Sprintf("%s-r%d", name, number)
+}
+
+func revisionSelector(name string) string {
+	return fmt.Sprintf("%s=%s,%s=%s,%s=%s",
+		labelManagedBy, managedByValue,
+		labelAppName, name,
+		labelComponent, revisionComponentValue)
+}
+
+// ListAgentRevisions returns the recorded revisions of an agent, newest first.
+func (c *Client) ListAgentRevisions(ctx context.Context, namespace, name string) ([]agents.AgentRevision, error) {
+	dynamicClient, err := c.k8sClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+	sandbox, err := getSandbox(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, mapK8sError(err)
+	}
+
+	items, err := c.listRevisionObjects(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]agents.AgentRevision, 0, len(items))
+	for i := range items {
+		if !ownedBySandbox(&items[i], sandbox) {
+			continue
+		}
+		revisions = append(revisions, revisionFromObject(&items[i]))
+	}
+	return revisions, nil
+}
+
+// RedeployAgent replaces the Sandbox pod template in place and records a new revision.
+func (c *Client) RedeployAgent(ctx context.Context, params *agents.DeployAgentParams) (*agents.AgentRevision, error) {
+	if params == nil {
+		return nil, fmt.Errorf("deploy params must not be nil")
+	}
+	return c.applyRevision(ctx, params, changeCauseRedeploy)
+}
+
+// RollbackAgent redeploys the spec recorded in revision. The rollback is itself
+// recorded as a new revision so the history stays append-only.
+func (c *Client) RollbackAgent(ctx context.Context, namespace, name string, revision int64) (*agents.AgentRevision, error) {
+	dynamicClient, err := c.k8sClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+	sandbox, err := getSandbox(ctx, dynamicClient, namespace, name)
+	if err != nil {
+		return nil, mapK8sError(err)
+	}
+
+	params, err := c.revisionParams(ctx, sandbox, revision)
+	if err != nil {
+		return nil, err
+	}
+	return c.applyRevision(ctx, params, fmt.Sprintf("rollback to revision %d", revision))
+}
+
+// revisionParams returns the deploy params recorded in revision of the agent backed
+// by sandbox.
+func (c *Client) revisionParams(ctx context.Context, sandbox *unstructured.Unstructured, revision int64) (*agents.DeployAgentParams, error) {
+	namespace, name := sandbox.GetNamespace(), sandbox.GetName()
+	obj, err := c.k8sClient.KubernetesClientset().AppsV1().ControllerRevisions(namespace).Get(ctx, revisionName(name, revision), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("revision %d of agent %s/%s: %w", revision, namespace, name, agents.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get revision %d: %w", revision, mapK8sError(err))
+	}
+	if obj.Labels[labelAppName] != name || !ownedBySandbox(obj, sandbox) {
+		return nil, fmt.Errorf("revision %d of agent %s/%s: %w", revision, namespace, name, agents.ErrNotFound)
+	}
+	params := decodeDeploySpec(string(obj.Data.Raw))
+	if params == nil {
+		return nil, fmt.Errorf("revision %d of agent %s/%s has no readable deploy spec", revision, namespace, name)
+	}
+	params.Name = name
+	params.Namespace = namespace
+	return params, nil
+}
+
+// applyRevision records params as the next revision and updates the Sandbox to match.
+// The revision is written first and removed again if the Sandbox update fails, so a
+// conflicting concurrent deploy never leaves an orphaned revision behind.
+func (c *Client) applyRevision(ctx context.Context, params *agents.DeployAgentParams, changeCause string) (*agents.AgentRevision, error) {
+	dynamicClient, err := c.k8sClient.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dynamic client: %w", err)
+	}
+
+	sandbox, err := getSandbox(ctx, dynamicClient, params.Namespace, params.Name)
+	if err != nil {
+		return nil, mapK8sError(err)
+	}
+
+	if err := c.validateDeployReferences(ctx, params); err != nil {
+		return nil, err
+	}
+
+	revision, err := c.recordRevision(ctx, sandbox, params, changeCause)
+	if err != nil {
+		return nil, err
+	}
+
+	applySandboxTemplate(sandbox, buildSandboxCR(params), revision.Number)
+	updated, err := dynamicClient.Resource(sandboxGVR).Namespace(params.Namespace).Update(ctx, sandbox, metav1.UpdateOptions{})
+	if err != nil {
+		c.deleteRevisionBestEffort(ctx, params.Namespace, revision.Name)
+		return nil, fmt.Errorf("failed to update Sandbox: %w", mapK8sError(err))
+	}
+	if err := c.reconcileAgentScaling(ctx, dynamicClient, updated, params.Scaling); err != nil {
+		return nil, fmt.Errorf("revision %d was applied but autoscaling could not be configured: %w", revision.Number, err)
+	}
+
+	c.logger.Info("Applied agent revision",
+		slog.String("name", params.Name),
+		slog.String("namespace", params.Namespace),
+		slog.Int64("revision", revision.Number),
+		slog.String("changeCause", changeCause))
+	return revision, nil
+}
+
+// applySandboxTemplate copies the pod template and agent annotations of desired onto
+// sandbox, leaving operatingMode and controller-owned fields untouched.
+func applySandboxTemplate(sandbox, desired *unstructured.Unstructured, revision int64) {
+	podTemplate, _, _ := unstructured.NestedMap(desired.Object, "spec", "podTemplate")
+	_ = unstructured.SetNestedMap(sandbox.Object, podTemplate, "spec", "podTemplate")
+
+	annotations := sandbox.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range desired.GetAnnotations() {
+		annotations[key] = value
+	}
+	annotations[agents.AnnotationRevision] = strconv.FormatInt(revision, 10)
+	sandbox.SetAnnotations(annotations)
+}
+
+func (c *Client) recordRevision(ctx context.Context, sandbox *unstructured.Unstructured, params *agents.DeployAgentParams, changeCause string) (*agents.AgentRevision, error) {
+	namespace, name := sandbox.GetNamespace(), sandbox.GetName()
+	existing, err := c.listRevisionObjects(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var next int64 = 1
+	if len(existing) > 0 {
+		next = existing[0].Revision + 1
+	}
+
+	data, err := encodeDeploySpec(params)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionName(name, next),
+			Namespace: namespace,
+			Labels: map[string]string{
+				labelManagedBy: managedByValue,
+				labelAppName:   name,
+				labelComponent: revisionComponentValue,
+			},
+			Annotations: map[string]string{annotationChangeCause: changeCause},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: sandbox.GetAPIVersion(),
+				Kind:       sandbox.GetKind(),
+				Name:       name,
+				UID:        sandbox.GetUID(),
+			}},
+		},
+		Data:     runtime.RawExtension{Raw: []byte(data)},
+		Revision: next,
+	}
+
+	created, err := c.k8sClient.KubernetesClientset().AppsV1().ControllerRevisions(namespace).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("revision %d of agent %s/%s was recorded concurrently: %w", next, namespace, name, agents.ErrConflict)
+		}
+		return nil, fmt.Errorf("failed to record revision: %w", mapK8sError(err))
+	}
+	revision := revisionFromObject(created)
+	return &revision, nil
+}
+
+// listRevisionObjects returns every revision labeled for the agent name, newest first,
+// including revisions of an earlier agent with the same name. Callers that read
+// revision contents filter with ownedBySandbox.
+func (c *Client) listRevisionObjects(ctx context.Context, namespace, name string) ([]appsv1.ControllerRevision, error) {
+	list, err := c.k8sClient.KubernetesClientset().AppsV1().ControllerRevisions(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: revisionSelector(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent revisions: %w", mapK8sError(err))
+	}
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool { return items[i].Revision > items[j].Revision })
+	return items, nil
+}
+
+// ownedBySandbox reports whether obj is owned by the Sandbox with sandbox's UID.
+func ownedBySandbox(obj metav1.Object, sandbox *unstructured.Unstructured) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == sandbox.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) deleteRevisionBestEffort(ctx context.Context, namespace, name string) {
+	if err := c.k8sClient.KubernetesClientset().AppsV1().ControllerRevisions(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		c.logger.Warn("failed to remove unapplied agent revision",
+			slog.String("namespace", namespace),
+			slog.String("revision", name),
+			slog.Any("error", err))
+	}
+}
+
+func revisionFromObject(obj *appsv1.ControllerRevision) agents.AgentRevision {
+	return agents.AgentRevision{
+		Number:      obj.Revision,
+		Name:        obj.Name,
+		CreatedAt:   formatTimestamp(obj.CreationTimestamp),
+		ChangeCause: obj.Annotations[annotationChangeCause],
+		Params:      decodeDeploySpec(string(obj.Data.Raw)),
+	}
+}
+
+// revisionFromSandbox reads the deployed revision number from the Sandbox annotation.
+func revisionFromSandbox(sandbox unstructured.Unstructured) int64 {
+	revision, err := strconv.ParseInt(sandbox.GetAnnotations()[agents.AnnotationRevision], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+// recordInitialRevision records the first revision of a newly created Sandbox and
+// points the Sandbox revision annotation at it. The caller removes the Sandbox
+// when this fails, so an agent never references a revision that does not exist.
+func (c *Client) recordInitialRevision(ctx context.Context, dynamicClient dynamic.Interface, sandbox *unstructured.Unstructured, params *agents.DeployAgentParams) error {
+	revision, err := c.recordRevision(ctx, sandbox, params, changeCauseDeploy)
+	if err != nil {
+		return err
+	}
+	if revision.Number == 1 {
+		return nil
+	}
+	// Stale revisions from an earlier agent with the same name were not yet
+	// garbage-collected; point the Sandbox at the number actually recorded.
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, agents.AnnotationRevision, strconv.FormatInt(revision.Number, 10))
+	if _, err := dynamicClient.Resource(sandboxGVR).Namespace(params.Namespace).Patch(
+		ctx, params.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{},
+	); err != nil {
+		c.deleteRevisionBestEffort(ctx, params.Namespace, revision.Name)
+		return fmt.Errorf("failed to annotate agent revision: %w", mapK8sError(err))
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const revisionTestNamespace = "test-ns"
+
+func revisionDeployParams(tag string) *agents.DeployAgentParams {
+	return &agents.DeployAgentParams{
+		Name:           "my-agent",
+		Namespace:      revisionTestNamespace,
+		ContainerImage: "quay.io/example/agent",
+		ImageTag:       tag,
+		EnvVars:        []agents.AgentEnvVar{{Name: "MODEL", Value: tag}},
+		ServicePorts:   []agents.AgentServicePortSpec{{Name: "http", Port: 8080, TargetPort: 8000}},
+	}
+}
+
+func deployRevisionTestAgent(t *testing.T) (*Client, *fakedynamic.FakeDynamicClient) {
+	t.Helper()
+	client, dynamicClient := newDeployTestClient(t)
+	_, err := client.DeployAgent(context.Background(), revisionDeployParams("v1"))
+	require.NoError(t, err)
+	return client, dynamicClient
+}
+
+func sandboxImage(t *testing.T, dynamicClient *fakedynamic.FakeDynamicClient, name string) string {
+	t.Helper()
+	sandbox, err := dynamicClient.Resource(sandboxGVR).Namespace(revisionTestNamespace).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	containers, _, _ := unstructured.NestedSlice(sandbox.Object, "spec", "podTemplate", "spec", "containers")
+	require.NotEmpty(t, containers)
+	image, _, _ := unstructured.NestedString(containers[0].(map[string]any), "image")
+	return image
+}
+
+func TestDeployAgent_RecordsInitialRevision(t *testing.T) {
+	client, _ := deployRevisionTestAgent(t)
+
+	revisions, err := client.ListAgentRevisions(context.Background(), revisionTestNamespace, "my-agent")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, int64(1), revisions[0].Number)
+	assert.Equal(t, "my-agent-r1", revisions[0].Name)
+	assert.Equal(t, changeCauseDeploy, revisions[0].ChangeCause)
+	require.NotNil(t, revisions[0].Params)
+	assert.Equal(t, "v1", revisions[0].Params.ImageTag)
+	assert.Equal(t, []agents.AgentEnvVar{{Name: "MODEL", Value: "v1"}}, revisions[0].Params.EnvVars)
+
+	detail, err := client.GetAgent(context.Background(), revisionTestNamespace, "my-agent")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), detail.Revision)
+	assert.Nil(t, detail.Canary)
+}
+
+func TestDeployAgent_FailsWhenRevisionCannotBeRecorded(t *testing.T) {
+	client, dynamicClient := newDeployTestClient(t)
+	clientset := client.k8sClient.(*deployTestK8sClient).clientset
+	clientset.PrependReactor("create", "controllerrevisions", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "controllerrevisions"}, "", nil)
+	})
+
+	_, err := client.DeployAgent(context.Background(), revisionDeployParams("v1"))
+
+	require.ErrorIs(t, err, agents.ErrForbidden)
+	_, err = dynamicClient.Resource(sandboxGVR).Namespace(revisionTestNamespace).Get(context.Background(), "my-agent", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "the Sandbox must not be left pointing at an unrecorded revision")
+}
+
+func TestRevisions_IgnoreRevisionsOfEarlierAgent(t *testing.T) {
+	stale := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-agent-r1",
+			Namespace: revisionTestNamespace,
+			Labels: map[string]string{
+				labelManagedBy: managedByValue,
+				labelAppName:   "my-agent",
+				labelComponent: revisionComponentValue,
+			},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Sandbox", Name: "my-agent", UID: types.UID("deleted-agent")}},
+		},
+		Data:     runtime.RawExtension{Raw: []byte(`{"containerImage":"quay.io/example/old","imageTag":"v0"}`)},
+		Revision: 1,
+	}
+	client, _ := newDeployTestClient(t, stale)
+	ctx := context.Background()
+
+	_, err := client.DeployAgent(ctx, revisionDeployParams("v1"))
+	require.NoError(t, err)
+
+	revisions, err := client.ListAgentRevisions(ctx, revisionTestNamespace, "my-agent")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, int64(2), revisions[0].Number)
+
+	detail, err := client.GetAgent(ctx, revisionTestNamespace, "my-agent")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), detail.Revision)
+
+	_, err = client.RollbackAgent(ctx, revisionTestNamespace, "my-agent", 1)
+	assert.ErrorIs(t, err, agents.ErrNotFound)
+}
+
+func TestRedeployAndRollbackAgent(t *testing.T) {
+	client, dynamicClient := deployRevisionTestAgent(t)
+	ctx := context.Background()
+
+	redeployed, err := client.RedeployAgent(ctx, revisionDeployParams("v2"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), redeployed.Number)
+	assert.Equal(t, "quay.io/example/agent:v2", sandboxImage(t, dynamicClient, "my-agent"))
+
+	rolledBack, err := client.RollbackAgent(ctx, revisionTestNamespace, "my-agent", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), rolledBack.Number)
+	assert.Equal(t, "rollback to revision 1", rolledBack.ChangeCause)
+	assert.Equal(t, "quay.io/example/agent:v1", sandboxImage(t, dynamicClient, "my-agent"))
+
+	revisions, err := client.ListAgentRevisions(ctx, revisionTestNamespace, "my-agent")
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	assert.Equal(t, []int64{3, 2, 1}, []int64{revisions[0].Number, revisions[1].Number, revisions[2].Number})
+
+	detail, err := client.GetAgent(ctx, revisionTestNamespace, "my-agent")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), detail.Revision)
+}
+
+func TestRollbackAgent_UnknownRevision(t *testing.T) {
+	client, _ := deployRevisionTestAgent(t)
+
+	_, err := client.RollbackAgent(context.Background(), revisionTestNamespace, "my-agent", 7)
+	assert.ErrorIs(t, err, agents.ErrNotFound)
+}
+
+func TestSetAgentCanary_SplitsExistingRoute(t *testing.T) {
+	client, dynamicClient := deployRevisionTestAgent(t)
+	ctx := context.Background()
+	_, err := client.RedeployAgent(ctx, revisionDeployParams("v2"))
+	require.NoError(t, err)
+
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata":   map[string]any{"name": "public", "namespace": revisionTestNamespace},
+		"spec":       map[string]any{"to": map[string]any{"kind": "Service", "name": "my-agent"}},
+	}}
+	_, err = dynamicClient.Resource(openshiftRouteGVR).Namespace(revisionTestNamespace).Create(ctx, route, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	canary, err := client.SetAgentCanary(ctx, revisionTestNamespace, "my-agent", 1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), canary.Revision)
+	assert.Equal(t, int32(20), canary.Weight)
+	assert.Equal(t, "my-agent-canary", canary.WorkloadName)
+	assert.Equal(t, []string{"public"}, canary.Routes)
+
+	canarySandbox, err := dynamicClient.Resource(sandboxGVR).Namespace(revisionTestNamespace).Get(ctx, "my-agent-canary", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "my-agent", canarySandbox.GetLabels()[agents.LabelCanaryOf])
+	assert.Empty(t, canarySandbox.GetLabels()[agents.LabelOpenShellManagedBy])
+	assert.Equal(t, "quay.io/example/agent:v1", sandboxImage(t, dynamicClient, "my-agent-canary"))
+
+	updated, err := dynamicClient.Resource(openshiftRouteGVR).Namespace(revisionTestNamespace).Get(ctx, "public", metav1.GetOptions{})
+	require.NoError(t, err)
+	primaryWeight, _, _ := unstructured.NestedInt64(updated.Object, "spec", "to", "weight")
+	assert.Equal(t, int64(80), primaryWeight)
+	backends, _, _ := unstructured.NestedSlice(updated.Object, "spec", "alternateBackends")
+	require.Len(t, backends, 1)
+	assert.Equal(t, "my-agent-canary", backends[0].(map[string]any)["name"])
+	assert.Equal(t, int64(20), backends[0].(map[string]any)["weight"])
+
+	detail, err := client.GetAgent(ctx, revisionTestNamespace, "my-agent")
+	require.NoError(t, err)
+	require.NotNil(t, detail.Canary)
+	assert.Equal(t, int64(1), detail.Canary.Revision)
+	assert.Equal(t, int32(20), detail.Canary.Weight)
+
+	_, err = client.EndAgentCanary(ctx, revisionTestNamespace, "my-agent", false)
+	require.NoError(t, err)
+
+	restored, err := dynamicClient.Resource(openshiftRouteGVR).Namespace(revisionTestNamespace).Get(ctx, "public", metav1.GetOptions{})
+	require.NoError(t, err)
+	primaryWeight, _, _ = unstructured.NestedInt64(restored.Object, "spec", "to", "weight")
+	assert.Equal(t, int64(100), primaryWeight)
+	_, found, _ := unstructured.NestedSlice(restored.Object, "spec", "alternateBackends")
+	assert.False(t, found)
+	assert.Equal(t, "quay.io/example/agent:v2", sandboxImage(t, dynamicClient, "my-agent"))
+}
+
+func TestEndAgentCanary_PromoteRemovesCreatedRoute(t *testing.T) {
+	client, dynamicClient := deployRevisionTestAgent(t)
+	ctx := context.Background()
+	_, err := client.RedeployAgent(ctx, revisionDeployParams("v2"))
+	require.NoError(t, err)
+
+	canary, err := client.SetAgentCanary(ctx, revisionTestNamespace, "my-agent", 1, 50)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"my-agent"}, canary.Routes)
+
+	promoted, err := client.EndAgentCanary(ctx, revisionTestNamespace, "my-agent", true)
+	require.NoError(t, err)
+	require.NotNil(t, promoted)
+	assert.Equal(t, int64(3), promoted.Number)
+	assert.Equal(t, "promote canary revision 1", promoted.ChangeCause)
+	assert.Equal(t, "quay.io/example/agent:v1", sandboxImage(t, dynamicClient, "my-agent"))
+
+	routes, err := dynamicClient.Resource(openshiftRouteGVR).Namespace(revisionTestNamespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, routes.Items)
+	_, err = dynamicClient.Resource(sandboxGVR).Namespace(revisionTestNamespace).Get(ctx, "my-agent-canary", metav1.GetOptions{})
+	assert.Error(t, err)
+
+	detail, err := client.GetAgent(ctx, revisionTestNamespace, "my-agent")
+	require.NoError(t, err)
+	assert.Nil(t, detail.Canary)
+	assert.Equal(t, int64(3), detail.Revision)
+}
+
+func TestEndAgentCanary_NoCanary(t *testing.T) {
+	client, _ := deployRevisionTestAgent(t)
+
+	_, err := client.EndAgentCanary(context.Background(), revisionTestNamespace, "my-agent", false)
+	assert.ErrorIs(t, err, agents.ErrConflict)
+}
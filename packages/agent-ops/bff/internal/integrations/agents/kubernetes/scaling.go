@@ -0,0 +1,210 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	scalingComponentValue      = "agent-autoscaler"
+	scalingRouteComponentValue = "agent-scaling-route"
+
+	// scaleToZeroPrometheusURL is the cluster monitoring query endpoint read by KEDA, and
+	// scaleToZeroTriggerAuthentication the ClusterTriggerAuthentication holding its token.
+	scaleToZeroPrometheusURL         = "https://thanos-querier.openshift-monitoring.svc.cluster.local:9092"
+	scaleToZeroTriggerAuthentication = "agent-ops-prometheus"
+)
+
+var kedaScaledObjectGVR = schema.GroupVersionResource{
+	Group:    "keda.sh",
+	Version:  "v1alpha1",
+	Resource: "scaledobjects",
+}
+
+// reconcileAgentScaling makes the autoscaler of an agent match scaling: a KEDA
+// ScaledObject when scale-to-zero is enabled and none otherwise. The ScaledObject is
+// owned by the Sandbox and shares its name. KEDA wakes the agent on router traffic, so
+// a Route is created for agents that have none; it is removed with the ScaledObject.
+func (c *Client) reconcileAgentScaling(ctx context.Context, dynamicClient dynamic.Interface, sandbox *unstructured.Unstructured, scaling *agents.AgentScaling) error {
+	namespace, name := sandbox.GetNamespace(), sandbox.GetName()
+
+	if scaling == nil || !scaling.ScaleToZero {
+		if err := c.deleteScaledObject(ctx, dynamicClient, namespace, name); err != nil {
+			return err
+		}
+		return c.deleteScalingRoute(ctx, dynamicClient, namespace, name)
+	}
+
+	routes, err := c.ensureScalingRoute(ctx, dynamicClient, sandbox)
+	if err != nil {
+		return err
+	}
+	return c.upsertScaledObject(ctx, dynamicClient, buildScaledObject(sandbox, scaling, routes))
+}
+
+// ensureScalingRoute returns the names of the Routes serving the agent, creating one
+// owned by the Sandbox when there are none. A Route created for a canary is adopted so
+// that ending the canary does not remove the Route the ScaledObject queries.
+func (c *Client) ensureScalingRoute(ctx context.Context, dynamicClient dynamic.Interface, sandbox *unstructured.Unstructured) ([]string, error) {
+	namespace, name := sandbox.GetNamespace(), sandbox.GetName()
+	resource := dynamicClient.Resource(openshiftRouteGVR).Namespace(namespace)
+	list, err := resource.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Routes: %w", mapScalingRouteError(err))
+	}
+
+	owner := sandboxOwnerReference(sandbox)
+	names := []string{}
+	for i := range list.Items {
+		route := &list.Items[i]
+		if !routeTargetsService(route, name) {
+			continue
+		}
+		if route.GetLabels()[labelComponent] == canaryRouteComponentValue {
+			setRouteComponent(route, scalingRouteComponentValue)
+			route.SetOwnerReferences([]metav1.OwnerReference{owner})
+			if _, err := resource.Update(ctx, route, metav1.UpdateOptions{}); err != nil {
+				return nil, fmt.Errorf("failed to update Route %q: %w", route.GetName(), mapScalingRouteError(err))
+			}
+		}
+		names = append(names, route.GetName())
+	}
+	if len(names) > 0 {
+		return names, nil
+	}
+
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": openshiftRouteGVR.Group + "/" + openshiftRouteGVR.Version,
+		"kind":       "Route",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+			"labels": map[string]any{
+				labelManagedBy: managedByValue,
+				labelAppName:   name,
+				labelComponent: scalingRouteComponentValue,
+			},
+			"ownerReferences": []any{map[string]any{
+				"apiVersion": owner.APIVersion,
+				"kind":       owner.Kind,
+				"name":       owner.Name,
+				"uid":        string(owner.UID),
+			}},
+		},
+		"spec": map[string]any{
+			"to": map[string]any{"kind": "Service", "name": name, "weight": int64(100)},
+		},
+	}}
+	if _, err := resource.Create(ctx, route, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create agent Route: %w", mapScalingRouteError(err))
+	}
+	return []string{name}, nil
+}
+
+// deleteScalingRoute removes the Route created for scale-to-zero, if any. While a
+// canary splits its traffic the Route is handed back to the canary instead, which
+// deletes it when the canary ends.
+func (c *Client) deleteScalingRoute(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) error {
+	resource := dynamicClient.Resource(openshiftRouteGVR).Namespace(namespace)
+	existing, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get agent Route: %w", mapK8sError(err))
+	}
+	if existing.GetLabels()[labelComponent] != scalingRouteComponentValue {
+		return nil
+	}
+	if _, splitting, _ := unstructured.NestedSlice(existing.Object, "spec", "alternateBackends"); splitting {
+		setRouteComponent(existing, canaryRouteComponentValue)
+		existing.SetOwnerReferences(nil)
+		if _, err := resource.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update agent Route: %w", mapK8sError(err))
+		}
+		return nil
+	}
+	if err := resource.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete agent Route: %w", mapK8sError(err))
+	}
+	return nil
+}
+
+func setRouteComponent(route *unstructured.Unstructured, component string) {
+	labels := route.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[labelComponent] = component
+	route.SetLabels(labels)
+}
+
+func (c *Client) upsertScaledObject(ctx context.Context, dynamicClient dynamic.Interface, desired *unstructured.Unstructured) error {
+	resource := dynamicClient.Resource(kedaScaledObjectGVR).Namespace(desired.GetNamespace())
+
+	existing, err := resource.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if _, err := resource.Create(ctx, desired, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create ScaledObject: %w", mapScaledObjectError(err))
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get ScaledObject: %w", mapScaledObjectError(err))
+	}
+	if existing.GetLabels()[labelManagedBy] != managedByValue {
+		return fmt.Errorf("ScaledObject %q is not managed by agent-ops: %w", desired.GetName(), agents.ErrConflict)
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	existing.SetOwnerReferences(desired.GetOwnerReferences())
+	if _, err := resource.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update ScaledObject: %w", mapScaledObjectError(err))
+	}
+	return nil
+}
+
+func (c *Client) deleteScaledObject(ctx context.Context, dynamicClient dynamic.Interface, namespace, name string) error {
+	resource := dynamicClient.Resource(kedaScaledObjectGVR).Namespace(namespace)
+	existing, err := resource.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get ScaledObject: %w", mapK8sError(err))
+	}
+	if existing.GetLabels()[labelManagedBy] != managedByValue {
+		return nil
+	}
+	if err := resource.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ScaledObject: %w", mapK8sError(err))
+	}
+	c.logger.Debug("Deleted agent ScaledObject", slog.String("namespace", namespace), slog.String("name", name))
+	return nil
+}
+
+// mapScaledObjectError reports a cluster without KEDA as unavailable, since
+// scale-to-zero has no other backend.
+func mapScaledObjectError(err error) error {
+	if meta.IsNoMatchError(err) {
+		return &agents.UnavailableError{Message: "KEDA is not installed; scale-to-zero requires the Custom Metrics Autoscaler (keda.sh)"}
+	}
+	return mapK8sError(err)
+}
+
+// mapScalingRouteError reports a cluster without OpenShift Routes as unavailable, since
+// scale-to-zero is woken by router traffic.
+func mapScalingRouteError(err error) error {
+	if meta.IsNoMatchError(err) {
+		return &agents.UnavailableError{Message: "OpenShift Routes are not available; scale-to-zero requires route.openshift.io"}
+	}
+	return mapK8sError(err)
+}
@@ -0,0 +1,133 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func scaledDeployParams(scaling *agents.AgentScaling) *agents.DeployAgentParams {
+	params := revisionDeployParams("v1")
+	params.Scaling = scaling
+	return params
+}
+
+func scaledObjectQuery(t *testing.T, scaled *unstructured.Unstructured) string {
+	t.Helper()
+	triggers, _, _ := unstructured.NestedSlice(scaled.Object, "spec", "triggers")
+	require.Len(t, triggers, 1)
+	query, _, _ := unstructured.NestedString(triggers[0].(map[string]any), "metadata", "query")
+	return query
+}
+
+func TestDeployAgent_ScaleToZeroCreatesRouteAndScaledObject(t *testing.T) {
+	client, dynamicClient := newDeployTestClient(t)
+	ctx := context.Background()
+
+	_, err := client.DeployAgent(ctx, scaledDeployParams(&agents.AgentScaling{
+		MaxReplicas: 1, ScaleToZero: true, IdleTimeoutSeconds: 600,
+	}))
+	require.NoError(t, err)
+
+	scaled, err := dynamicClient.Resource(kedaScaledObjectGVR).Namespace(revisionTestNamespace).Get(ctx, "my-agent", metav1.GetOptions{})
+	require.NoError(t, err)
+	kind, _, _ := unstructured.NestedString(scaled.Object, "spec", "scaleTargetRef", "kind")
+	minReplicas, _, _ := unstructured.NestedInt64(scaled.Object, "spec", "minReplicaCount")
+	maxReplicas, _, _ := unstructured.NestedInt64(scaled.Object, "spec", "maxReplicaCount")
+	cooldown, _, _ := unstructured.NestedInt64(scaled.Object, "spec", "cooldownPeriod")
+	assert.Equal(t, "Sandbox", kind)
+	assert.Zero(t, minReplicas)
+	assert.Equal(t, int64(1), maxReplicas)
+	assert.Equal(t, int64(600), cooldown)
+	assert.Contains(t, scaledObjectQuery(t, scaled), `route=~"^(my-agent)$"`)
+
+	route, err := dynamicClient.Resource(openshiftRouteGVR).Namespace(revisionTestNamespace).Get(ctx, "my-agent", metav1.GetOptions{})
+	require.NoError(t, err, "scale-to-zero needs a Route for the traffic trigger")
+	assert.Equal(t, scalingRouteComponentValue, route.GetLabels()[labelComponent])
+	assert.True(t, routeTargetsService(route, "my-agent"))
+
+	// Redeploying without scaling removes the autoscaler and its Route again.
+	_, err = client.RedeployAgent(ctx, revisionDeployParams("v2"))
+	require.NoError(t, err)
+	_, err = dynamicClient.Resource(kedaScaledObjectGVR).Namespace(revisionTestNamespace).Get(ctx, "my-agent", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	_, err = dynamicClient.Resource(openshiftRouteGVR).Namespace(revisionTestNamespace).Get(ctx, "my-agent", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestDeployAgent_ScaleToZeroQueriesExistingRoutes(t *testing.T) {
+	client, dynamicClient := newDeployTestClient(t)
+	ctx := context.Background()
+	route := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": openshiftRouteGVR.Group + "/" + openshiftRouteGVR.Version,
+		"kind":       "Route",
+		"metadata":   map[string]any{"name": "public", "namespace": revisionTestNamespace},
+		"spec":       map[string]any{"to": map[string]any{"kind": "Service", "name": "my-agent"}},
+	}}
+	_, err := dynamicClient.Resource(openshiftRouteGVR).Namespace(revisionTestNamespace).Create(ctx, route, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = client.DeployAgent(ctx, scaledDeployParams(&agents.AgentScaling{
+		MaxReplicas: 1, ScaleToZero: true, IdleTimeoutSeconds: 300,
+	}))
+	require.NoError(t, err)
+
+	scaled, err := dynamicClient.Resource(kedaScaledObjectGVR).Namespace(revisionTestNamespace).Get(ctx, "my-agent", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, scaledObjectQuery(t, scaled), `route=~"^(public)$"`)
+	_, err = dynamicClient.Resource(openshiftRouteGVR).Namespace(revisionTestNamespace).Get(ctx, "my-agent", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "no Route is created when one already serves the agent")
+}
+
+func TestDeployAgent_ValidatesReferences(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "model-creds", Namespace: revisionTestNamespace},
+		Data:       map[string][]byte{"api-key": []byte("x")},
+	}
+	withEnv := func(ref *agents.AgentKeyRef) *agents.DeployAgentParams {
+		params := revisionDeployParams("v1")
+		params.EnvVars = []agents.AgentEnvVar{{Name: "API_KEY", ValueFrom: &agents.AgentEnvVarSource{SecretKeyRef: ref}}}
+		return params
+	}
+	withMount := func(mount agents.AgentVolumeMount) *agents.DeployAgentParams {
+		params := revisionDeployParams("v1")
+		params.VolumeMounts = []agents.AgentVolumeMount{mount}
+		return params
+	}
+
+	tests := []struct {
+		name    string
+		params  *agents.DeployAgentParams
+		wantErr string
+	}{
+		{"existing secret key", withEnv(&agents.AgentKeyRef{Name: "model-creds", Key: "api-key"}), ""},
+		{"missing secret", withEnv(&agents.AgentKeyRef{Name: "other-creds", Key: "api-key"}), `Secret "other-creds" not found`},
+		{"missing key", withEnv(&agents.AgentKeyRef{Name: "model-creds", Key: "token"}), `has no key "token"`},
+		{"optional missing secret", withEnv(&agents.AgentKeyRef{Name: "other-creds", Key: "api-key", Optional: true}), ""},
+		{"mounted secret", withMount(agents.AgentVolumeMount{Name: "creds", MountPath: "/creds", Secret: "model-creds"}), ""},
+		{"missing pvc", withMount(agents.AgentVolumeMount{Name: "models", MountPath: "/models", PersistentVolumeClaim: "model-cache"}), `PersistentVolumeClaim "model-cache" not found`},
+		{"missing configmap", withMount(agents.AgentVolumeMount{Name: "config", MountPath: "/config", ConfigMap: "model-config"}), `ConfigMap "model-config" not found`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, dynamicClient := newDeployTestClient(t, secret)
+
+			_, err := client.DeployAgent(context.Background(), tc.params)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, agents.ErrInvalidSpec)
+			assert.Contains(t, err.Error(), tc.wantErr)
+			_, getErr := dynamicClient.Resource(sandboxGVR).Namespace(revisionTestNamespace).Get(context.Background(), "my-agent", metav1.GetOptions{})
+			assert.True(t, apierrors.IsNotFound(getErr), "no Sandbox should be created for an invalid spec")
+		})
+	}
+}
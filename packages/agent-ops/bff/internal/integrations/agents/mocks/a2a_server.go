@@ -0,0 +1,161 @@
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+)
+
+// A2AServer is an in-process A2A agent for tests. It answers message/send with a
+// completed task and message/stream with submitted → working → artifact → completed
+// events, echoing the text of the incoming message back as the artifact.
+type A2AServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages []agents.A2AMessage
+
+	// FailWith, when set, makes every call return this JSON-RPC error.
+	FailWith *agents.A2AError
+}
+
+// NewA2AServer starts a mock A2A agent. Callers must Close it.
+func NewA2AServer() *A2AServer {
+	s := &A2AServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/agent-card.json", s.handleCard)
+	mux.HandleFunc("POST /", s.handleRPC)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// A2AClient returns an A2A client that dials this server for every host, so agents
+// resolved to in-cluster Service URLs reach the mock in tests.
+func (s *A2AServer) A2AClient() *agents.A2AClient {
+	addr := s.Listener.Addr().String()
+	return agents.NewA2AClient(&http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}})
+}
+
+// Messages returns the messages received so far.
+func (s *A2AServer) Messages() []agents.A2AMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]agents.A2AMessage(nil), s.messages...)
+}
+
+func (s *A2AServer) handleCard(w http.ResponseWriter, _ *http.Request) {
+	writeA2AJSON(w, map[string]any{
+		"name":               "mock-agent",
+		"description":        "Echoes user messages",
+		"url":                s.URL + "/",
+		"version":            "1.0.0",
+		"capabilities":       map[string]any{"streaming": true},
+		"defaultInputModes":  []string{"text"},
+		"defaultOutputModes": []string{"text"},
+		"skills": []map[string]any{
+			{"id": "echo", "name": "Echo", "description": "Repeats the input", "tags": []string{"test"}},
+		},
+	})
+}
+
+func (s *A2AServer) handleRPC(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			Message agents.A2AMessage `json:"message"`
+		} `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeA2AJSON(w, rpcEnvelope(nil, nil, &agents.A2AError{Code: -32700, Message: "parse error"}))
+		return
+	}
+
+	s.mu.Lock()
+	s.messages = append(s.messages, req.Params.Message)
+	failWith := s.FailWith
+	s.mu.Unlock()
+
+	if failWith != nil {
+		writeA2AJSON(w, rpcEnvelope(req.ID, nil, failWith))
+		return
+	}
+
+	msg := req.Params.Message
+	taskID := "task-" + msg.MessageID
+	contextID := msg.ContextID
+	if contextID == "" {
+		contextID = "ctx-" + msg.MessageID
+	}
+	artifact := agents.A2AArtifact{
+		ArtifactID: "artifact-1",
+		Name:       "echo",
+		Parts:      []agents.A2APart{{Kind: agents.A2APartKindText, Text: "echo: " + messageText(msg)}},
+	}
+
+	switch req.Method {
+	case "message/send":
+		writeA2AJSON(w, rpcEnvelope(req.ID, &agents.A2AEvent{
+			Kind:      agents.A2AKindTask,
+			ID:        taskID,
+			ContextID: contextID,
+			Status:    &agents.A2ATaskStatus{State: agents.A2ATaskStateCompleted},
+			Artifacts: []agents.A2AArtifact{artifact},
+		}, nil))
+	case "message/stream":
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		events := []agents.A2AEvent{
+			{Kind: agents.A2AKindTask, ID: taskID, ContextID: contextID, Status: &agents.A2ATaskStatus{State: agents.A2ATaskStateSubmitted}},
+			{Kind: agents.A2AKindStatusUpdate, TaskID: taskID, ContextID: contextID, Status: &agents.A2ATaskStatus{State: agents.A2ATaskStateWorking}},
+			{Kind: agents.A2AKindArtifactUpdate, TaskID: taskID, ContextID: contextID, Artifact: &artifact, LastChunk: true},
+			{Kind: agents.A2AKindStatusUpdate, TaskID: taskID, ContextID: contextID, Status: &agents.A2ATaskStatus{State: agents.A2ATaskStateCompleted}, Final: true},
+		}
+		flusher, _ := w.(http.Flusher)
+		for i := range events {
+			payload, _ := json.Marshal(rpcEnvelope(req.ID, &events[i], nil))
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", payload)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	default:
+		writeA2AJSON(w, rpcEnvelope(req.ID, nil, &agents.A2AError{Code: -32601, Message: "method not found"}))
+	}
+}
+
+func messageText(msg agents.A2AMessage) string {
+	var texts []string
+	for _, part := range msg.Parts {
+		if part.Kind == agents.A2APartKindText {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func rpcEnvelope(id any, result *agents.A2AEvent, rpcErr *agents.A2AError) map[string]any {
+	envelope := map[string]any{"jsonrpc": "2.0", "id": id}
+	if rpcErr != nil {
+		envelope["error"] = rpcErr
+	} else {
+		envelope["result"] = result
+	}
+	return envelope
+}
+
+func writeA2AJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
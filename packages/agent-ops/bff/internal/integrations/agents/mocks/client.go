@@ -16,6 +16,15 @@ type Client struct {
 	Namespaces []string
 	Agents     map[string][]agents.AgentSummary
 	Details    map[string]agents.AgentDetail
+	Builds     map[string]agents.AgentBuild
+	// BuildDeployParams holds the deploy params recorded per build key.
+	BuildDeployParams map[string]agents.DeployAgentParams
+	// Revisions holds recorded revisions per agent key, oldest first.
+	Revisions map[string][]agents.AgentRevision
+
+	// BuildPhase is the phase assigned to builds created by CreateAgentBuild.
+	// Defaults to Succeeded so the built agent can be deployed right away.
+	BuildPhase string
 
 	ListNamespacesErr error
 	ListAgentsErr     error
@@ -25,13 +34,19 @@ type Client struct {
 	StartAgentErr     error
 	RestartAgentErr   error
 	DeleteAgentErr    error
+	CreateBuildErr    error
+	GetBuildErr       error
+	RevisionErr       error
 }
 
 // NewClient returns a mock client with no data.
 func NewClient() *Client {
 	return &Client{
-		Agents:  map[string][]agents.AgentSummary{},
-		Details: map[string]agents.AgentDetail{},
+		Agents:            map[string][]agents.AgentSummary{},
+		Details:           map[string]agents.AgentDetail{},
+		Builds:            map[string]agents.AgentBuild{},
+		BuildDeployParams: map[string]agents.DeployAgentParams{},
+		Revisions:         map[string][]agents.AgentRevision{},
 	}
 }
 
@@ -107,6 +122,7 @@ func (c *Client) DeployAgent(ctx context.Context, params *agents.DeployAgentPara
 		},
 		WorkloadType: agents.WorkloadTypeSandbox,
 	}
+	c.recordRevisionLocked(params, "deploy")
 	return &agents.DeployAgentResult{
 		Name:      params.Name,
 		Namespace: params.Namespace,
@@ -126,6 +142,7 @@ func (c *Client) DeleteAgent(ctx context.Context, namespace, name string) error
 		return agents.ErrNotFound
 	}
 	delete(c.Details, key)
+	delete(c.Revisions, key)
 	if agentList, ok := c.Agents[namespace]; ok {
 		filtered := make([]agents.AgentSummary, 0, len(agentList))
 		for _, a := range agentList {
@@ -195,6 +212,99 @@ func (c *Client) StartAgent(ctx context.Context, namespace, name string) error {
 	return nil
 }
 
+// CreateAgentBuild implements agents.Client.
+func (c *Client) CreateAgentBuild(ctx context.Context, params *agents.DeployAgentParams) (*agents.AgentBuild, error) {
+	_ = ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if params == nil || params.Source == nil {
+		return nil, fmt.Errorf("deploy-from-source params must include a source")
+	}
+	if c.CreateBuildErr != nil {
+		return nil, c.CreateBuildErr
+	}
+	if c.Builds == nil {
+		c.Builds = make(map[string]agents.AgentBuild)
+	}
+	key := detailKey(params.Namespace, params.Name)
+	if _, ok := c.Builds[key]; ok {
+		return nil, agents.ErrAlreadyExists
+	}
+
+	phase := c.BuildPhase
+	if phase == "" {
+		phase = agents.BuildPhaseSucceeded
+	}
+	deployParams := *params
+	deployParams.Source = nil
+	if deployParams.ContainerImage == "" {
+		deployParams.ContainerImage = "image-registry.openshift-image-registry.svc:5000/" + params.Namespace + "/" + params.Name
+	}
+	if deployParams.ImageTag == "" {
+		deployParams.ImageTag = "latest"
+	}
+	strategy := params.Source.BuildStrategy
+	if strategy == "" {
+		strategy = agents.BuildStrategyShipwright
+	}
+
+	build := agents.AgentBuild{
+		Name:          params.Name,
+		Namespace:     params.Namespace,
+		BuildStrategy: strategy,
+		BuildRunName:  params.Name + "-1",
+		RepoURL:       params.Source.RepoURL,
+		Ref:           params.Source.Ref,
+		Phase:         phase,
+		OutputImage:   deployParams.ContainerImage,
+		OutputTag:     deployParams.ImageTag,
+	}
+	c.Builds[key] = build
+	if c.BuildDeployParams == nil {
+		c.BuildDeployParams = make(map[string]agents.DeployAgentParams)
+	}
+	c.BuildDeployParams[key] = deployParams
+	return cloneAgentBuild(build), nil
+}
+
+// GetAgentBuild implements agents.Client.
+func (c *Client) GetAgentBuild(ctx context.Context, namespace, name string) (*agents.AgentBuild, error) {
+	_ = ctx
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.GetBuildErr != nil {
+		return nil, c.GetBuildErr
+	}
+	build, ok := c.Builds[detailKey(namespace, name)]
+	if !ok {
+		return nil, agents.ErrNotFound
+	}
+	return cloneAgentBuild(build), nil
+}
+
+// GetAgentBuildDeployParams implements agents.Client.
+func (c *Client) GetAgentBuildDeployParams(ctx context.Context, namespace, name string) (*agents.DeployAgentParams, error) {
+	_ = ctx
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.GetBuildErr != nil {
+		return nil, c.GetBuildErr
+	}
+	params, ok := c.BuildDeployParams[detailKey(namespace, name)]
+	if !ok {
+		return nil, agents.ErrNotFound
+	}
+	params.EnvVars = append([]agents.AgentEnvVar(nil), params.EnvVars...)
+	params.ServicePorts = append([]agents.AgentServicePortSpec(nil), params.ServicePorts...)
+	params.VolumeMounts = append([]agents.AgentVolumeMount(nil), params.VolumeMounts...)
+	return &params, nil
+}
+
+func cloneAgentBuild(build agents.AgentBuild) *agents.AgentBuild {
+	clone := build
+	return &clone
+}
+
 // cloneAgentDetail returns a defensive copy of detail. Spec and Status use maps.Clone
 // (shallow copy only); nested maps and slices inside those values remain shared.
 func cloneAgentDetail(detail agents.AgentDetail) agents.AgentDetail {
@@ -204,6 +314,12 @@ func cloneAgentDetail(detail agents.AgentDetail) agents.AgentDetail {
 		Status:       maps.Clone(detail.Status),
 		WorkloadType: detail.WorkloadType,
 		ReadyStatus:  detail.ReadyStatus,
+		Revision:     detail.Revision,
+	}
+	if detail.Canary != nil {
+		canary := *detail.Canary
+		canary.Routes = append([]string(nil), detail.Canary.Routes...)
+		copy.Canary = &canary
 	}
 	if detail.Service != nil {
 		service := *detail.Service
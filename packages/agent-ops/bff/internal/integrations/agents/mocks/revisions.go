@@ -0,0 +1,180 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+)
+
+// ListAgentRevisions implements agents.Client.
+func (c *Client) ListAgentRevisions(ctx context.Context, namespace, name string) ([]agents.AgentRevision, error) {
+	_ = ctx
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RevisionErr != nil {
+		return nil, c.RevisionErr
+	}
+	key := detailKey(namespace, name)
+	if _, ok := c.Details[key]; !ok {
+		return nil, agents.ErrNotFound
+	}
+	revisions := make([]agents.AgentRevision, 0, len(c.Revisions[key]))
+	for _, revision := range slices.Backward(c.Revisions[key]) {
+		revisions = append(revisions, cloneAgentRevision(revision))
+	}
+	return revisions, nil
+}
+
+// RedeployAgent implements agents.Client.
+func (c *Client) RedeployAgent(ctx context.Context, params *agents.DeployAgentParams) (*agents.AgentRevision, error) {
+	_ = ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if params == nil {
+		return nil, fmt.Errorf("deploy params must not be nil")
+	}
+	if c.RevisionErr != nil {
+		return nil, c.RevisionErr
+	}
+	if _, ok := c.Details[detailKey(params.Namespace, params.Name)]; !ok {
+		return nil, agents.ErrNotFound
+	}
+	return c.recordRevisionLocked(params, "redeploy"), nil
+}
+
+// RollbackAgent implements agents.Client.
+func (c *Client) RollbackAgent(ctx context.Context, namespace, name string, revision int64) (*agents.AgentRevision, error) {
+	_ = ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RevisionErr != nil {
+		return nil, c.RevisionErr
+	}
+	target, err := c.findRevisionLocked(namespace, name, revision)
+	if err != nil {
+		return nil, err
+	}
+	return c.recordRevisionLocked(target.Params, fmt.Sprintf("rollback to revision %d", revision)), nil
+}
+
+// SetAgentCanary implements agents.Client.
+func (c *Client) SetAgentCanary(ctx context.Context, namespace, name string, revision int64, weight int32) (*agents.AgentCanary, error) {
+	_ = ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if weight < 1 || weight > 99 {
+		return nil, fmt.Errorf("canary weight must be between 1 and 99, got %d", weight)
+	}
+	if c.RevisionErr != nil {
+		return nil, c.RevisionErr
+	}
+	if _, err := c.findRevisionLocked(namespace, name, revision); err != nil {
+		return nil, err
+	}
+	key := detailKey(namespace, name)
+	detail := c.Details[key]
+	detail.Canary = &agents.AgentCanary{
+		Revision:     revision,
+		Weight:       weight,
+		WorkloadName: agents.CanaryWorkloadName(name),
+		ReadyStatus:  "ready",
+		Routes:       []string{name},
+	}
+	c.Details[key] = detail
+	canary := *detail.Canary
+	canary.Routes = append([]string(nil), detail.Canary.Routes...)
+	return &canary, nil
+}
+
+// EndAgentCanary implements agents.Client.
+func (c *Client) EndAgentCanary(ctx context.Context, namespace, name string, promote bool) (*agents.AgentRevision, error) {
+	_ = ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.RevisionErr != nil {
+		return nil, c.RevisionErr
+	}
+	key := detailKey(namespace, name)
+	detail, ok := c.Details[key]
+	if !ok {
+		return nil, agents.ErrNotFound
+	}
+	if detail.Canary == nil {
+		return nil, agents.ErrConflict
+	}
+
+	var promoted *agents.AgentRevision
+	if promote {
+		target, err := c.findRevisionLocked(namespace, name, detail.Canary.Revision)
+		if err != nil {
+			return nil, err
+		}
+		promoted = c.recordRevisionLocked(target.Params, fmt.Sprintf("promote canary revision %d", detail.Canary.Revision))
+	}
+	detail = c.Details[key]
+	detail.Canary = nil
+	c.Details[key] = detail
+	return promoted, nil
+}
+
+// recordRevisionLocked appends the next revision for params and marks it deployed.
+// Callers must hold c.mu for writing.
+func (c *Client) recordRevisionLocked(params *agents.DeployAgentParams, changeCause string) *agents.AgentRevision {
+	if c.Revisions == nil {
+		c.Revisions = make(map[string][]agents.AgentRevision)
+	}
+	key := detailKey(params.Namespace, params.Name)
+	history := c.Revisions[key]
+	var number int64 = 1
+	if len(history) > 0 {
+		number = history[len(history)-1].Number + 1
+	}
+	stored := *params
+	stored.Source = nil
+	stored.EnvVars = append([]agents.AgentEnvVar(nil), params.EnvVars...)
+	stored.ServicePorts = append([]agents.AgentServicePortSpec(nil), params.ServicePorts...)
+	revision := agents.AgentRevision{
+		Number:      number,
+		Name:        fmt.Sprintf("%s-r%d", params.Name, number),
+		CreatedAt:   time.Now().UTC().Format(time.RFC3339),
+		ChangeCause: changeCause,
+		Params:      &stored,
+	}
+	c.Revisions[key] = append(history, revision)
+
+	if detail, ok := c.Details[key]; ok {
+		detail.Revision = number
+		c.Details[key] = detail
+	}
+	clone := cloneAgentRevision(revision)
+	return &clone
+}
+
+func (c *Client) findRevisionLocked(namespace, name string, number int64) (*agents.AgentRevision, error) {
+	key := detailKey(namespace, name)
+	if _, ok := c.Details[key]; !ok {
+		return nil, agents.ErrNotFound
+	}
+	for _, revision := range c.Revisions[key] {
+		if revision.Number == number {
+			clone := cloneAgentRevision(revision)
+			return &clone, nil
+		}
+	}
+	return nil, agents.ErrNotFound
+}
+
+func cloneAgentRevision(revision agents.AgentRevision) agents.AgentRevision {
+	clone := revision
+	if revision.Params != nil {
+		params := *revision.Params
+		params.EnvVars = append([]agents.AgentEnvVar(nil), revision.Params.EnvVars...)
+		params.ServicePorts = append([]agents.AgentServicePortSpec(nil), revision.Params.ServicePorts...)
+		params.VolumeMounts = append([]agents.AgentVolumeMount(nil), revision.Params.VolumeMounts...)
+		clone.Params = &params
+	}
+	return clone
+}
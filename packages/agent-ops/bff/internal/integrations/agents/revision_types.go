@@ -0,0 +1,39 @@
+package agents
+
+const (
+	// AnnotationRevision records the revision number currently deployed on an agent.
+	AnnotationRevision = "opendatahub.io/agent-revision"
+	// AnnotationCanaryRevision and AnnotationCanaryWeight record an active canary on the primary agent.
+	AnnotationCanaryRevision = "opendatahub.io/agent-canary-revision"
+	AnnotationCanaryWeight   = "opendatahub.io/agent-canary-weight"
+	// LabelCanaryOf marks a canary workload with the name of the agent it shadows.
+	LabelCanaryOf = "opendatahub.io/agent-canary-of"
+
+	// CanarySuffix is appended to the agent name to form the canary workload and Service name.
+	CanarySuffix = "-canary"
+)
+
+// AgentRevision is one recorded deploy of an agent. Params holds the image, env vars
+// and ports applied by that deploy so the revision can be restored.
+type AgentRevision struct {
+	Number      int64
+	Name        string
+	CreatedAt   string
+	ChangeCause string
+	Params      *DeployAgentParams
+}
+
+// AgentCanary is an active canary: a second workload running Revision that receives
+// Weight percent of the traffic on the Routes in front of the agent Service.
+type AgentCanary struct {
+	Revision     int64
+	Weight       int32
+	WorkloadName string
+	ReadyStatus  string
+	Routes       []string
+}
+
+// CanaryWorkloadName returns the workload (and Service) name used for an agent's canary.
+func CanaryWorkloadName(name string) string {
+	return name + CanarySuffix
+}
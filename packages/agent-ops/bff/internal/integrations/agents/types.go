@@ -40,6 +40,9 @@ type AgentDetail struct {
 	Service            *AgentService
 	AgentCard          *AgentCardObserved
 	ServiceAccountName string
+	// Revision is the currently deployed revision number, or 0 when none was recorded.
+	Revision int64
+	Canary   *AgentCanary
 }
 
 // AgentCardSkillParameterObserved is a skill parameter from an observed agent card.
@@ -112,6 +112,8 @@ func AgentDetailToRuntimeDetail(detail *agents.AgentDetail) *models.AgentRuntime
 		WorkloadStatus:   readyStatus,
 		ServiceEndpoints: serviceEndpoints,
 		Conditions:       conditions,
+		Revision:         detail.Revision,
+		Canary:           AgentCanaryToStatus(detail.Canary),
 	}
 }
 
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+)
+
+// AgentBuildToStatus maps a deploy-from-source build to the BFF build status.
+func AgentBuildToStatus(build *agents.AgentBuild, deployed bool) *models.AgentBuildStatus {
+	if build == nil {
+		return nil
+	}
+
+	outputImage := build.OutputImage
+	if outputImage != "" && build.OutputTag != "" {
+		outputImage += ":" + build.OutputTag
+	}
+
+	return &models.AgentBuildStatus{
+		Name:           build.Name,
+		Namespace:      build.Namespace,
+		BuildStrategy:  build.BuildStrategy,
+		BuildRunName:   build.BuildRunName,
+		Phase:          build.Phase,
+		Message:        build.Message,
+		RepoURL:        build.RepoURL,
+		Ref:            build.Ref,
+		OutputImage:    outputImage,
+		ImageDigest:    build.ImageDigest,
+		StartTime:      timePtr(ParseTime(build.StartTime)),
+		CompletionTime: timePtr(ParseTime(build.CompletionTime)),
+		Deployed:       deployed,
+	}
+}
@@ -0,0 +1,101 @@
+package mapper
+
+import (
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+)
+
+// AgentMessageRequestToA2A builds the outgoing A2A user message for a test console request.
+func AgentMessageRequestToA2A(req models.AgentMessageRequest) agents.A2AMessage {
+	parts := make([]agents.A2APart, 0, 2)
+	if req.Text != "" {
+		parts = append(parts, agents.A2APart{Kind: agents.A2APartKindText, Text: req.Text})
+	}
+	if len(req.Data) > 0 {
+		parts = append(parts, agents.A2APart{Kind: agents.A2APartKindData, Data: req.Data})
+	}
+
+	msg := agents.A2AMessage{
+		Kind:      agents.A2AKindMessage,
+		Role:      agents.A2ARoleUser,
+		ContextID: req.ContextID,
+		TaskID:    req.TaskID,
+		Parts:     parts,
+	}
+	if req.SkillID != "" {
+		msg.Metadata = map[string]any{agents.A2AMessageMetadataSkillID: req.SkillID}
+	}
+	return msg
+}
+
+// A2AEventToAgentMessageEvent maps an A2A result or stream event to the console event model.
+func A2AEventToAgentMessageEvent(event agents.A2AEvent) models.AgentMessageEvent {
+	out := models.AgentMessageEvent{
+		Kind:      event.Kind,
+		TaskID:    event.TaskID,
+		ContextID: event.ContextID,
+	}
+	if event.Status != nil {
+		out.State = event.Status.State
+		if event.Status.Message != nil {
+			out.StatusMessage = mapA2AParts(event.Status.Message.Parts)
+		}
+	}
+
+	switch event.Kind {
+	case agents.A2AKindTask:
+		out.TaskID = event.ID
+		for _, artifact := range event.Artifacts {
+			out.Artifacts = append(out.Artifacts, mapA2AArtifact(artifact))
+		}
+		out.Final = isTerminalTaskState(out.State)
+	case agents.A2AKindMessage:
+		out.Role = event.Role
+		out.Parts = mapA2AParts(event.Parts)
+		out.Final = true
+	case agents.A2AKindArtifactUpdate:
+		if event.Artifact != nil {
+			artifact := mapA2AArtifact(*event.Artifact)
+			artifact.Append = event.Append
+			artifact.LastChunk = event.LastChunk
+			out.Artifacts = []models.AgentTaskArtifact{artifact}
+		}
+	case agents.A2AKindStatusUpdate:
+		out.Final = event.Final
+	}
+	return out
+}
+
+func isTerminalTaskState(state string) bool {
+	switch state {
+	case agents.A2ATaskStateCompleted, agents.A2ATaskStateCanceled, agents.A2ATaskStateFailed,
+		agents.A2ATaskStateRejected, agents.A2ATaskStateInputRequired, agents.A2ATaskStateAuthRequired:
+		return true
+	default:
+		return false
+	}
+}
+
+func mapA2AArtifact(artifact agents.A2AArtifact) models.AgentTaskArtifact {
+	return models.AgentTaskArtifact{
+		ArtifactID:  artifact.ArtifactID,
+		Name:        artifact.Name,
+		Description: artifact.Description,
+		Parts:       mapA2AParts(artifact.Parts),
+	}
+}
+
+func mapA2AParts(parts []agents.A2APart) []models.AgentMessagePart {
+	out := make([]models.AgentMessagePart, 0, len(parts))
+	for _, part := range parts {
+		mapped := models.AgentMessagePart{Kind: part.Kind, Text: part.Text, Data: part.Data}
+		if part.File != nil {
+			mapped.FileName = part.File.Name
+			mapped.MimeType = part.File.MimeType
+			// Inline file bytes are not relayed to the console; only references are.
+			mapped.FileURI = agents.SanitizeHTTPURL(part.File.URI)
+		}
+		out = append(out, mapped)
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package mapper
+
+import (
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+)
+
+// AgentRevisionToModel maps a recorded agent revision. current marks the revision
+// the agent is running.
+func AgentRevisionToModel(revision agents.AgentRevision, current int64) models.AgentRevision {
+	out := models.AgentRevision{
+		Revision:     revision.Number,
+		Name:         revision.Name,
+		CreatedAt:    ParseTime(revision.CreatedAt),
+		ChangeCause:  revision.ChangeCause,
+		EnvVars:      []models.EnvVar{},
+		ServicePorts: []models.ServicePort{},
+		Current:      revision.Number == current,
+	}
+	if params := revision.Params; params != nil {
+		out.ContainerImage = params.ContainerImage
+		if params.ImageTag != "" {
+			out.ContainerImage += ":" + params.ImageTag
+		}
+		for _, env := range params.EnvVars {
+			out.EnvVars = append(out.EnvVars, models.EnvVar{Name: env.Name, Value: env.Value, ValueFrom: envVarSourceToModel(env.ValueFrom)})
+		}
+		for _, port := range params.ServicePorts {
+			out.ServicePorts = append(out.ServicePorts, models.ServicePort{
+				Name:       port.Name,
+				Port:       port.Port,
+				TargetPort: port.TargetPort,
+				Protocol:   port.Protocol,
+			})
+		}
+	}
+	return out
+}
+
+// AgentCanaryToStatus maps an active agent canary; nil when no canary is running.
+func AgentCanaryToStatus(canary *agents.AgentCanary) *models.AgentCanaryStatus {
+	if canary == nil {
+		return nil
+	}
+	return &models.AgentCanaryStatus{
+		Revision:     canary.Revision,
+		Weight:       canary.Weight,
+		WorkloadName: canary.WorkloadName,
+		ReadyStatus:  canary.ReadyStatus,
+		Routes:       append([]string(nil), canary.Routes...),
+	}
+}
+
+func envVarSourceToModel(src *agents.AgentEnvVarSource) *models.EnvVarSource {
+	if src == nil {
+		return nil
+	}
+	out := &models.EnvVarSource{}
+	if ref := src.SecretKeyRef; ref != nil {
+		out.SecretKeyRef = &models.KeySelector{Name: ref.Name, Key: ref.Key, Optional: ref.Optional}
+	}
+	if ref := src.ConfigMapKeyRef; ref != nil {
+		out.ConfigMapKeyRef = &models.KeySelector{Name: ref.Name, Key: ref.Key, Optional: ref.Optional}
+	}
+	return out
+}
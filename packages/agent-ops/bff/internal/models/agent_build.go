@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// AgentBuildStatus reports the progress of a deploy-from-source image build.
+type AgentBuildStatus struct {
+	Name           string     `json:"name"`
+	Namespace      string     `json:"namespace"`
+	BuildStrategy  string     `json:"buildStrategy"`
+	BuildRunName   string     `json:"buildRunName,omitempty"`
+	Phase          string     `json:"phase"`
+	Message        string     `json:"message,omitempty"`
+	RepoURL        string     `json:"repoUrl,omitempty"`
+	Ref            string     `json:"ref,omitempty"`
+	OutputImage    string     `json:"outputImage,omitempty"`
+	ImageDigest    string     `json:"imageDigest,omitempty"`
+	StartTime      *time.Time `json:"startTime,omitempty"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	// Deployed is true once the built image has been deployed as the agent.
+	Deployed bool `json:"deployed"`
+}
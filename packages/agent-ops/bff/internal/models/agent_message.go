@@ -0,0 +1,48 @@
+package models
+
+// AgentMessageRequest is a test console message sent to a deployed A2A agent.
+type AgentMessageRequest struct {
+	// SkillID addresses a skill advertised in the agent card. Optional.
+	SkillID string `json:"skillId,omitempty"`
+	Text    string `json:"text"`
+	// Data carries structured skill parameters and is sent as an A2A data part.
+	Data map[string]any `json:"data,omitempty"`
+	// ContextID and TaskID continue an earlier conversation or input-required task.
+	ContextID string `json:"contextId,omitempty"`
+	TaskID    string `json:"taskId,omitempty"`
+}
+
+// AgentMessagePart is one part of an agent message or artifact.
+type AgentMessagePart struct {
+	Kind     string         `json:"kind"`
+	Text     string         `json:"text,omitempty"`
+	Data     map[string]any `json:"data,omitempty"`
+	FileName string         `json:"fileName,omitempty"`
+	MimeType string         `json:"mimeType,omitempty"`
+	FileURI  string         `json:"fileUri,omitempty"`
+}
+
+// AgentTaskArtifact is output produced by an agent for a task.
+type AgentTaskArtifact struct {
+	ArtifactID  string             `json:"artifactId"`
+	Name        string             `json:"name,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Parts       []AgentMessagePart `json:"parts"`
+	Append      bool               `json:"append,omitempty"`
+	LastChunk   bool               `json:"lastChunk,omitempty"`
+}
+
+// AgentMessageEvent is a task, message, status update or artifact update from an agent.
+// Non-streaming calls return a single task or message event; streaming calls emit one
+// event per server-sent event.
+type AgentMessageEvent struct {
+	Kind          string              `json:"kind"`
+	TaskID        string              `json:"taskId,omitempty"`
+	ContextID     string              `json:"contextId,omitempty"`
+	State         string              `json:"state,omitempty"`
+	StatusMessage []AgentMessagePart  `json:"statusMessage,omitempty"`
+	Role          string              `json:"role,omitempty"`
+	Parts         []AgentMessagePart  `json:"parts,omitempty"`
+	Artifacts     []AgentTaskArtifact `json:"artifacts,omitempty"`
+	Final         bool                `json:"final"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// AgentRevision is one recorded deploy of an agent.
+type AgentRevision struct {
+	Revision       int64         `json:"revision"`
+	Name           string        `json:"name"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	ChangeCause    string        `json:"changeCause,omitempty"`
+	ContainerImage string        `json:"containerImage,omitempty"`
+	EnvVars        []EnvVar      `json:"envVars"`
+	ServicePorts   []ServicePort `json:"servicePorts"`
+	// Current is true for the revision the agent is running.
+	Current bool `json:"current"`
+}
+
+// AgentRevisionList is the revision history of an agent, newest first.
+type AgentRevisionList struct {
+	Items []AgentRevision `json:"items"`
+}
+
+// AgentCanaryStatus reports an active canary of an agent.
+type AgentCanaryStatus struct {
+	Revision     int64    `json:"revision"`
+	Weight       int32    `json:"weight"`
+	WorkloadName string   `json:"workloadName"`
+	ReadyStatus  string   `json:"readyStatus"`
+	Routes       []string `json:"routes,omitempty"`
+}
+
+// RollbackAgentRequest selects the revision to roll an agent back to.
+type RollbackAgentRequest struct {
+	Revision int64 `json:"revision"`
+}
+
+// AgentCanaryRequest starts or reweights a canary of revision receiving weight percent of traffic.
+type AgentCanaryRequest struct {
+	Revision int64 `json:"revision"`
+	Weight   int32 `json:"weight"`
+}
@@ -33,4 +33,7 @@ type AgentRuntimeDetail struct {
 	WorkloadStatus   string                  `json:"workloadStatus"`
 	ServiceEndpoints []AgentServiceEndpoint  `json:"serviceEndpoints"`
 	Conditions       []AgentRuntimeCondition `json:"conditions"`
+	// Revision is the deployed revision number; zero for agents deployed before revisions were recorded.
+	Revision int64              `json:"revision,omitempty"`
+	Canary   *AgentCanaryStatus `json:"canary,omitempty"`
 }
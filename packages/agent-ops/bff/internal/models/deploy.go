@@ -3,6 +3,53 @@ package models
 type EnvVar struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	// ValueFrom reads the value from a Secret or ConfigMap key; Value must be empty when set.
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty"`
+}
+
+// EnvVarSource selects exactly one of SecretKeyRef or ConfigMapKeyRef.
+type EnvVarSource struct {
+	SecretKeyRef    *KeySelector `json:"secretKeyRef,omitempty"`
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// KeySelector names a key in a Secret or ConfigMap in the agent namespace.
+type KeySelector struct {
+	Name     string `json:"name"`
+	Key      string `json:"key"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// ResourceList holds CPU and memory as Kubernetes quantities, e.g. "500m" and "1Gi".
+type ResourceList struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+type ResourceRequirements struct {
+	Requests *ResourceList `json:"requests,omitempty"`
+	Limits   *ResourceList `json:"limits,omitempty"`
+}
+
+// ScalingSpec enables scale-to-zero: the agent is scaled down to zero replicas after
+// idleTimeoutSeconds without Route traffic and back to its single replica on a request.
+type ScalingSpec struct {
+	MinReplicas        int32 `json:"minReplicas,omitempty"`
+	MaxReplicas        int32 `json:"maxReplicas"`
+	ScaleToZero        bool  `json:"scaleToZero,omitempty"`
+	IdleTimeoutSeconds int32 `json:"idleTimeoutSeconds,omitempty"`
+}
+
+// VolumeMount mounts exactly one of secret, configMap or persistentVolumeClaim from
+// the agent namespace at mountPath.
+type VolumeMount struct {
+	Name                  string `json:"name"`
+	MountPath             string `json:"mountPath"`
+	SubPath               string `json:"subPath,omitempty"`
+	ReadOnly              bool   `json:"readOnly,omitempty"`
+	Secret                string `json:"secret,omitempty"`
+	ConfigMap             string `json:"configMap,omitempty"`
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
 }
 
 type ServicePort struct {
@@ -12,6 +59,16 @@ type ServicePort struct {
 	Protocol   string `json:"protocol,omitempty"`
 }
 
+// DeploySource describes a git repository to build the agent image from
+// instead of deploying a prebuilt containerImage.
+type DeploySource struct {
+	RepoURL       string `json:"repoUrl"`
+	Ref           string `json:"ref,omitempty"`
+	ContextDir    string `json:"contextDir,omitempty"`
+	Dockerfile    string `json:"dockerfile,omitempty"`
+	BuildStrategy string `json:"buildStrategy,omitempty"`
+}
+
 type DeployAgentRequest struct {
 	Name            string        `json:"name"`
 	Namespace       string        `json:"namespace"`
@@ -23,6 +80,11 @@ type DeployAgentRequest struct {
 	Description     string        `json:"description,omitempty"`
 	EnvVars         []EnvVar      `json:"envVars,omitempty"`
 	ServicePorts    []ServicePort `json:"servicePorts,omitempty"`
+	Source          *DeploySource `json:"source,omitempty"`
+
+	Resources    *ResourceRequirements `json:"resources,omitempty"`
+	Scaling      *ScalingSpec          `json:"scaling,omitempty"`
+	VolumeMounts []VolumeMount         `json:"volumeMounts,omitempty"`
 }
 
 type DeployAgentResponse struct {
@@ -30,4 +92,7 @@ type DeployAgentResponse struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
 	Message   string `json:"message"`
+	// Build is set when the agent is deployed from source; the agent is
+	// deployed once the build succeeds.
+	Build *AgentBuildStatus `json:"build,omitempty"`
 }
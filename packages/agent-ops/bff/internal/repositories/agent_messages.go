@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	bfferrors "github.com/opendatahub-io/mod-arch-library/bff/internal/errors"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/mapper"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+)
+
+// AgentMessagesRepository sends test console messages to deployed A2A agents.
+type AgentMessagesRepository struct {
+	agentSourceFactory agents.ClientFactory
+	a2aClient          *agents.A2AClient
+}
+
+// NewAgentMessagesRepository creates a repository that resolves agents through
+// agentSourceFactory and talks to them with a2aClient.
+func NewAgentMessagesRepository(agentSourceFactory agents.ClientFactory, a2aClient *agents.A2AClient) *AgentMessagesRepository {
+	return &AgentMessagesRepository{agentSourceFactory: agentSourceFactory, a2aClient: a2aClient}
+}
+
+// SendAgentMessage delivers a message to the agent's in-cluster A2A endpoint. When the
+// agent card advertises streaming, every event is passed to onEvent and the returned
+// event is nil; otherwise the single task or message result is returned.
+func (r *AgentMessagesRepository) SendAgentMessage(
+	ctx context.Context,
+	namespace, name string,
+	req models.AgentMessageRequest,
+	onEvent func(models.AgentMessageEvent) error,
+) (*models.AgentMessageEvent, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	detail, err := client.GetAgent(ctx, namespace, name)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	if detail == nil {
+		return nil, bfferrors.ErrNotFound
+	}
+	if strings.EqualFold(detail.Metadata.Annotations[agents.AnnotationProtocol], "mcp") {
+		return nil, fmt.Errorf("%w: agent %s/%s does not use the A2A protocol", bfferrors.ErrInvalidRequest, namespace, name)
+	}
+	if err := validateAgentSkill(detail.AgentCard, req.SkillID); err != nil {
+		return nil, err
+	}
+
+	endpoint := a2aEndpointURL(detail, namespace, name)
+	if endpoint == "" {
+		return nil, fmt.Errorf("%w: agent %s/%s has no reachable service endpoint", bfferrors.ErrUpstreamUnavailable, namespace, name)
+	}
+
+	msg := mapper.AgentMessageRequestToA2A(req)
+	if detail.AgentCard == nil || !detail.AgentCard.Streaming {
+		result, err := r.a2aClient.SendMessage(ctx, endpoint, msg)
+		if err != nil {
+			return nil, translateA2AError(err)
+		}
+		event := mapper.A2AEventToAgentMessageEvent(*result)
+		return &event, nil
+	}
+
+	err = r.a2aClient.StreamMessage(ctx, endpoint, msg, func(event agents.A2AEvent) error {
+		return onEvent(mapper.A2AEventToAgentMessageEvent(event))
+	})
+	if err != nil {
+		return nil, translateA2AError(err)
+	}
+	return nil, nil
+}
+
+func validateAgentSkill(card *agents.AgentCardObserved, skillID string) error {
+	if skillID == "" || card == nil || len(card.Skills) == 0 {
+		return nil
+	}
+	for _, skill := range card.Skills {
+		if skill.ID == skillID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: skill %q is not advertised by the agent card", bfferrors.ErrInvalidRequest, skillID)
+}
+
+// a2aEndpointURL targets the agent's in-cluster Service. Only the path of the card's
+// advertised URL is honoured so a card cannot redirect the BFF to an arbitrary host.
+func a2aEndpointURL(detail *agents.AgentDetail, namespace, name string) string {
+	serviceName := name
+	var ports []agents.AgentServicePort
+	if detail.Service != nil {
+		if strings.TrimSpace(detail.Service.Name) != "" {
+			serviceName = detail.Service.Name
+		}
+		ports = detail.Service.Ports
+	}
+
+	base, err := url.Parse(mapper.BuildPrimaryEndpointURL(serviceName, namespace, ports))
+	if err != nil || base.Host == "" {
+		return ""
+	}
+
+	path := "/"
+	if detail.AgentCard != nil {
+		if cardURL, err := url.Parse(agents.SanitizeHTTPURL(detail.AgentCard.URL)); err == nil && cardURL.Path != "" {
+			path = cardURL.Path
+		}
+	}
+	return agents.BuildSanitizedHTTPURL(base.Scheme, base.Host, path)
+}
+
+func translateA2AError(err error) error {
+	var rpcErr *agents.A2AError
+	if errors.As(err, &rpcErr) {
+		return fmt.Errorf("%w: %s", bfferrors.ErrUpstreamUnavailable, rpcErr.Error())
+	}
+	if errors.Is(err, agents.ErrA2AStreamingUnsupported) {
+		return fmt.Errorf("%w: %s", bfferrors.ErrUpstreamUnavailable, err.Error())
+	}
+	return translateAgentError(err)
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	bfferrors "github.com/opendatahub-io/mod-arch-library/bff/internal/errors"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	agentsmock "github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents/mocks"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func a2aAgentClient(streaming bool) *agentsmock.Client {
+	client := agentsmock.NewClient()
+	client.Details["agent-ops-demo/echo-agent"] = agents.AgentDetail{
+		Metadata: agents.AgentMetadata{Name: "echo-agent", Namespace: "agent-ops-demo"},
+		Service: &agents.AgentService{
+			Name:  "echo-agent",
+			Ports: []agents.AgentServicePort{{Name: "http", Port: 8080}},
+		},
+		AgentCard: &agents.AgentCardObserved{
+			Name:      "echo-agent",
+			URL:       "https://attacker.example.com/a2a",
+			Streaming: streaming,
+			Skills:    []agents.AgentCardSkillObserved{{ID: "echo", Name: "Echo"}},
+		},
+	}
+	return client
+}
+
+func TestSendAgentMessageNonStreaming(t *testing.T) {
+	server := agentsmock.NewA2AServer()
+	defer server.Close()
+	repo := NewAgentMessagesRepository(&agentsmock.Factory{Client: a2aAgentClient(false)}, server.A2AClient())
+
+	result, err := repo.SendAgentMessage(context.Background(), "agent-ops-demo", "echo-agent",
+		models.AgentMessageRequest{SkillID: "echo", Text: "hello"},
+		func(models.AgentMessageEvent) error {
+			t.Fatal("non-streaming agents must not emit events")
+			return nil
+		})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, agents.A2AKindTask, result.Kind)
+	assert.Equal(t, agents.A2ATaskStateCompleted, result.State)
+	assert.True(t, result.Final)
+	require.Len(t, result.Artifacts, 1)
+	assert.Equal(t, "echo: hello", result.Artifacts[0].Parts[0].Text)
+
+	received := server.Messages()
+	require.Len(t, received, 1)
+	assert.Equal(t, "echo", received[0].Metadata[agents.A2AMessageMetadataSkillID])
+}
+
+func TestSendAgentMessageStreaming(t *testing.T) {
+	server := agentsmock.NewA2AServer()
+	defer server.Close()
+	repo := NewAgentMessagesRepository(&agentsmock.Factory{Client: a2aAgentClient(true)}, server.A2AClient())
+
+	var events []models.AgentMessageEvent
+	result, err := repo.SendAgentMessage(context.Background(), "agent-ops-demo", "echo-agent",
+		models.AgentMessageRequest{Text: "hello"},
+		func(event models.AgentMessageEvent) error {
+			events = append(events, event)
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	require.Len(t, events, 4)
+	assert.Equal(t, agents.A2ATaskStateSubmitted, events[0].State)
+	assert.Equal(t, agents.A2AKindArtifactUpdate, events[2].Kind)
+	assert.True(t, events[2].Artifacts[0].LastChunk)
+	assert.True(t, events[3].Final)
+	assert.Equal(t, events[0].TaskID, events[3].TaskID)
+}
+
+func TestSendAgentMessageValidation(t *testing.T) {
+	server := agentsmock.NewA2AServer()
+	defer server.Close()
+
+	t.Run("unknown skill", func(t *testing.T) {
+		repo := NewAgentMessagesRepository(&agentsmock.Factory{Client: a2aAgentClient(false)}, server.A2AClient())
+		_, err := repo.SendAgentMessage(context.Background(), "agent-ops-demo", "echo-agent",
+			models.AgentMessageRequest{SkillID: "translate", Text: "hola"}, nil)
+		assert.ErrorIs(t, err, bfferrors.ErrInvalidRequest)
+	})
+
+	t.Run("mcp agent", func(t *testing.T) {
+		client := a2aAgentClient(false)
+		detail := client.Details["agent-ops-demo/echo-agent"]
+		detail.Metadata.Annotations = map[string]string{agents.AnnotationProtocol: "mcp"}
+		client.Details["agent-ops-demo/echo-agent"] = detail
+
+		repo := NewAgentMessagesRepository(&agentsmock.Factory{Client: client}, server.A2AClient())
+		_, err := repo.SendAgentMessage(context.Background(), "agent-ops-demo", "echo-agent",
+			models.AgentMessageRequest{Text: "hi"}, nil)
+		assert.ErrorIs(t, err, bfferrors.ErrInvalidRequest)
+	})
+
+	t.Run("agent error is upstream unavailable", func(t *testing.T) {
+		failing := agentsmock.NewA2AServer()
+		defer failing.Close()
+		failing.FailWith = &agents.A2AError{Code: -32603, Message: "internal error"}
+
+		repo := NewAgentMessagesRepository(&agentsmock.Factory{Client: a2aAgentClient(false)}, failing.A2AClient())
+		_, err := repo.SendAgentMessage(context.Background(), "agent-ops-demo", "echo-agent",
+			models.AgentMessageRequest{Text: "hi"}, nil)
+		assert.ErrorIs(t, err, bfferrors.ErrUpstreamUnavailable)
+	})
+
+	assert.Empty(t, server.Messages())
+}
+
+func TestA2AEndpointURLUsesServiceHost(t *testing.T) {
+	detail := a2aAgentClient(false).Details["agent-ops-demo/echo-agent"]
+
+	assert.Equal(t, "http://echo-agent.agent-ops-demo.svc.cluster.local:8080/a2a",
+		a2aEndpointURL(&detail, "agent-ops-demo", "echo-agent"))
+
+	detail.Service = nil
+	assert.Empty(t, a2aEndpointURL(&detail, "agent-ops-demo", "echo-agent"))
+}
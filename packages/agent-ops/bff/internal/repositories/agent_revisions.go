@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	bfferrors "github.com/opendatahub-io/mod-arch-library/bff/internal/errors"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/integrations/agents"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/mapper"
+	"github.com/opendatahub-io/mod-arch-library/bff/internal/models"
+)
+
+// ListAgentRevisions returns the revision history of an agent, newest first.
+func (r *AgentRuntimesRepository) ListAgentRevisions(ctx context.Context, namespace, name string) (*models.AgentRevisionList, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	detail, err := client.GetAgent(ctx, namespace, name)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	revisions, err := client.ListAgentRevisions(ctx, namespace, name)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	items := make([]models.AgentRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		items = append(items, mapper.AgentRevisionToModel(revision, detail.Revision))
+	}
+	return &models.AgentRevisionList{Items: items}, nil
+}
+
+// RedeployAgent replaces a deployed agent in place, recording a new revision.
+// Redeploying while a canary is running is rejected; end the canary first.
+func (r *AgentRuntimesRepository) RedeployAgent(ctx context.Context, params *agents.DeployAgentParams) (*models.AgentRevision, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	if err := requireNoCanary(ctx, client, params.Namespace, params.Name); err != nil {
+		return nil, err
+	}
+
+	revision, err := client.RedeployAgent(ctx, params)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	result := mapper.AgentRevisionToModel(*revision, revision.Number)
+	return &result, nil
+}
+
+// RollbackAgent redeploys the spec recorded in revision as a new revision.
+func (r *AgentRuntimesRepository) RollbackAgent(ctx context.Context, namespace, name string, revision int64) (*models.AgentRevision, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	if err := requireNoCanary(ctx, client, namespace, name); err != nil {
+		return nil, err
+	}
+
+	applied, err := client.RollbackAgent(ctx, namespace, name, revision)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	slog.Info("rolled back agent",
+		slog.String("namespace", namespace),
+		slog.String("name", name),
+		slog.Int64("toRevision", revision),
+		slog.Int64("newRevision", applied.Number))
+	result := mapper.AgentRevisionToModel(*applied, applied.Number)
+	return &result, nil
+}
+
+// SetAgentCanary starts a canary of revision, or changes the revision and weight of
+// the running canary.
+func (r *AgentRuntimesRepository) SetAgentCanary(ctx context.Context, namespace, name string, revision int64, weight int32) (*models.AgentCanaryStatus, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	detail, err := client.GetAgent(ctx, namespace, name)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	if detail.Revision == revision {
+		return nil, fmt.Errorf("revision %d is already deployed: %w", revision, bfferrors.ErrInvalidRequest)
+	}
+
+	canary, err := client.SetAgentCanary(ctx, namespace, name, revision, weight)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	return mapper.AgentCanaryToStatus(canary), nil
+}
+
+// EndAgentCanary stops the running canary. With promote, the canary revision becomes
+// the agent's new revision and is returned; otherwise the result is nil.
+func (r *AgentRuntimesRepository) EndAgentCanary(ctx context.Context, namespace, name string, promote bool) (*models.AgentRevision, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	revision, err := client.EndAgentCanary(ctx, namespace, name, promote)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	if revision == nil {
+		return nil, nil
+	}
+	result := mapper.AgentRevisionToModel(*revision, revision.Number)
+	return &result, nil
+}
+
+func requireNoCanary(ctx context.Context, client agents.Client, namespace, name string) error {
+	detail, err := client.GetAgent(ctx, namespace, name)
+	if err != nil {
+		return translateAgentError(err)
+	}
+	if detail.Canary != nil {
+		return fmt.Errorf("agent %s/%s has a canary of revision %d running: %w", namespace, name, detail.Canary.Revision, bfferrors.ErrConflict)
+	}
+	return nil
+}
@@ -104,6 +104,119 @@ func (r *AgentRuntimesRepository) DeployAgent(ctx context.Context, params *agent
 	}, nil
 }
 
+// DeployAgentFromSource starts an image build for the agent. The built image is
+// deployed by DeployAgentBuild once the build has succeeded.
+func (r *AgentRuntimesRepository) DeployAgentFromSource(ctx context.Context, params *agents.DeployAgentParams) (*models.DeployAgentResponse, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	if _, err := client.GetAgent(ctx, params.Namespace, params.Name); err == nil {
+		return nil, fmt.Errorf("agent %s/%s: %w", params.Namespace, params.Name, bfferrors.ErrAlreadyExists)
+	} else if !errors.Is(err, agents.ErrNotFound) {
+		return nil, translateAgentError(err)
+	}
+
+	build, err := client.CreateAgentBuild(ctx, params)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	return &models.DeployAgentResponse{
+		Success:   true,
+		Name:      build.Name,
+		Namespace: build.Namespace,
+		Message:   "Agent build started; deploy the agent once the build succeeds",
+		Build:     mapper.AgentBuildToStatus(build, false),
+	}, nil
+}
+
+// GetAgentBuildStatus returns the build status for an agent deployed from source.
+// Deployed reports whether an agent with the build's name currently exists.
+func (r *AgentRuntimesRepository) GetAgentBuildStatus(ctx context.Context, namespace, name string) (*models.AgentBuildStatus, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	build, err := client.GetAgentBuild(ctx, namespace, name)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	deployed, err := agentExists(ctx, client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.AgentBuildToStatus(build, deployed), nil
+}
+
+// DeployAgentBuild deploys the image of a succeeded build through the regular
+// DeployAgent path, using the deploy parameters recorded when the build started.
+func (r *AgentRuntimesRepository) DeployAgentBuild(ctx context.Context, namespace, name string) (*models.DeployAgentResponse, error) {
+	client, err := r.agentSourceFactory.GetClient(ctx)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+
+	build, err := client.GetAgentBuild(ctx, namespace, name)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	if build.Phase != agents.BuildPhaseSucceeded {
+		return nil, fmt.Errorf("build %s/%s is %s, not %s: %w",
+			namespace, name, build.Phase, agents.BuildPhaseSucceeded, bfferrors.ErrConflict)
+	}
+
+	deployed, err := agentExists(ctx, client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if deployed {
+		return nil, fmt.Errorf("agent %s/%s: %w", namespace, name, bfferrors.ErrAlreadyExists)
+	}
+
+	params, err := client.GetAgentBuildDeployParams(ctx, namespace, name)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	params.Source = nil
+	if build.OutputImage != "" {
+		params.ContainerImage = build.OutputImage
+		params.ImageTag = build.OutputTag
+	}
+
+	result, err := client.DeployAgent(ctx, params)
+	if err != nil {
+		return nil, translateAgentError(err)
+	}
+	slog.Info("deployed agent from completed build",
+		slog.String("namespace", namespace),
+		slog.String("name", name),
+		slog.String("image", params.ContainerImage+":"+params.ImageTag))
+
+	return &models.DeployAgentResponse{
+		Success:   true,
+		Name:      result.Name,
+		Namespace: result.Namespace,
+		Message:   "Agent deployed successfully",
+		Build:     mapper.AgentBuildToStatus(build, true),
+	}, nil
+}
+
+func agentExists(ctx context.Context, client agents.Client, namespace, name string) (bool, error) {
+	_, err := client.GetAgent(ctx, namespace, name)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, agents.ErrNotFound):
+		return false, nil
+	default:
+		return false, translateAgentError(err)
+	}
+}
+
 // StopAgent stops a deployed agent via the agent data source.
 func (r *AgentRuntimesRepository) StopAgent(ctx context.Context, namespace, name string) error {
 	client, err := r.agentSourceFactory.GetClient(ctx)
@@ -161,6 +274,10 @@ func translateAgentError(err error) error {
 		return bfferrors.ErrForbidden
 	}
 
+	if errors.Is(err, agents.ErrInvalidSpec) {
+		return fmt.Errorf("%w: %s", bfferrors.ErrInvalidRequest, err.Error())
+	}
+
 	if errors.Is(err, agents.ErrUnavailable) {
 		var unavailable *agents.UnavailableError
 		if errors.As(err, &unavailable) && unavailable.Message != "" {
@@ -123,6 +123,38 @@ func (nilAgentDetailClient) StopAgent(context.Context, string, string) error
 func (nilAgentDetailClient) StartAgent(context.Context, string, string) error   { return nil }
 func (nilAgentDetailClient) RestartAgent(context.Context, string, string) error { return nil }
 
+func (nilAgentDetailClient) CreateAgentBuild(context.Context, *agents.DeployAgentParams) (*agents.AgentBuild, error) {
+	return nil, nil
+}
+
+func (nilAgentDetailClient) GetAgentBuild(context.Context, string, string) (*agents.AgentBuild, error) {
+	return nil, nil
+}
+
+func (nilAgentDetailClient) GetAgentBuildDeployParams(context.Context, string, string) (*agents.DeployAgentParams, error) {
+	return nil, nil
+}
+
+func (nilAgentDetailClient) ListAgentRevisions(context.Context, string, string) ([]agents.AgentRevision, error) {
+	return nil, nil
+}
+
+func (nilAgentDetailClient) RedeployAgent(context.Context, *agents.DeployAgentParams) (*agents.AgentRevision, error) {
+	return nil, nil
+}
+
+func (nilAgentDetailClient) RollbackAgent(context.Context, string, string, int64) (*agents.AgentRevision, error) {
+	return nil, nil
+}
+
+func (nilAgentDetailClient) SetAgentCanary(context.Context, string, string, int64, int32) (*agents.AgentCanary, error) {
+	return nil, nil
+}
+
+func (nilAgentDetailClient) EndAgentCanary(context.Context, string, string, bool) (*agents.AgentRevision, error) {
+	return nil, nil
+}
+
 func TestPaginateAgentRuntimes(t *testing.T) {
 	runtimes := []models.AgentRuntime{
 		{Name: "agent-b", Namespace: "ns-a"},
@@ -171,3 +203,73 @@ func TestPaginateAgentRuntimesInvalidContinueToken(t *testing.T) {
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, ErrInvalidContinueToken))
 }
+
+func TestDeployAgentBuildDeploysSucceededBuild(t *testing.T) {
+	client := agentsmock.NewClient()
+	client.BuildPhase = agents.BuildPhaseRunning
+	repo := NewAgentRuntimesRepository(&agentsmock.Factory{Client: client})
+
+	params := &agents.DeployAgentParams{
+		Name:      "src-agent",
+		Namespace: "ns-a",
+		Framework: "langgraph",
+		Source:    &agents.AgentSourceSpec{RepoURL: "https://github.com/example/agent.git"},
+	}
+	resp, err := repo.DeployAgentFromSource(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Build)
+	assert.Equal(t, agents.BuildPhaseRunning, resp.Build.Phase)
+	assert.False(t, resp.Build.Deployed)
+
+	_, err = repo.DeployAgentBuild(context.Background(), "ns-a", "src-agent")
+	require.ErrorIs(t, err, bfferrors.ErrConflict)
+
+	build := client.Builds["ns-a/src-agent"]
+	build.Phase = agents.BuildPhaseSucceeded
+	client.Builds["ns-a/src-agent"] = build
+
+	resp, err = repo.DeployAgentBuild(context.Background(), "ns-a", "src-agent")
+	require.NoError(t, err)
+	assert.True(t, resp.Build.Deployed)
+	_, err = client.GetAgent(context.Background(), "ns-a", "src-agent")
+	require.NoError(t, err)
+	revisions := client.Revisions["ns-a/src-agent"]
+	require.Len(t, revisions, 1)
+	assert.Equal(t, "langgraph", revisions[0].Params.Framework)
+	assert.Equal(t, "latest", revisions[0].Params.ImageTag)
+
+	_, err = repo.DeployAgentBuild(context.Background(), "ns-a", "src-agent")
+	assert.ErrorIs(t, err, bfferrors.ErrAlreadyExists)
+}
+
+func TestGetAgentBuildStatusDoesNotDeploy(t *testing.T) {
+	client := agentsmock.NewClient()
+	repo := NewAgentRuntimesRepository(&agentsmock.Factory{Client: client})
+
+	_, err := repo.DeployAgentFromSource(context.Background(), &agents.DeployAgentParams{
+		Name:      "src-agent",
+		Namespace: "ns-a",
+		Source:    &agents.AgentSourceSpec{RepoURL: "https://github.com/example/agent.git"},
+	})
+	require.NoError(t, err)
+
+	status, err := repo.GetAgentBuildStatus(context.Background(), "ns-a", "src-agent")
+	require.NoError(t, err)
+	assert.Equal(t, agents.BuildPhaseSucceeded, status.Phase)
+	assert.False(t, status.Deployed)
+	_, err = client.GetAgent(context.Background(), "ns-a", "src-agent")
+	assert.ErrorIs(t, err, agents.ErrNotFound)
+}
+
+func TestDeployAgentFromSourceRejectsExistingAgent(t *testing.T) {
+	client := agentsmock.NewDemoClient()
+	repo := NewAgentRuntimesRepository(&agentsmock.Factory{Client: client})
+
+	_, err := repo.DeployAgentFromSource(context.Background(), &agents.DeployAgentParams{
+		Name:      "sample-support-agent",
+		Namespace: "agent-ops-demo",
+		Source:    &agents.AgentSourceSpec{RepoURL: "https://github.com/example/agent.git"},
+	})
+
+	assert.ErrorIs(t, err, bfferrors.ErrAlreadyExists)
+}
@@ -10,6 +10,7 @@ type Repositories struct {
 	User          *UserRepository
 	Namespace     *NamespaceRepository
 	AgentRuntimes *AgentRuntimesRepository
+	AgentMessages *AgentMessagesRepository
 }
 
 func NewRepositories(agentSourceFactory agents.ClientFactory) *Repositories {
@@ -18,5 +19,6 @@ func NewRepositories(agentSourceFactory agents.ClientFactory) *Repositories {
 		User:          NewUserRepository(),
 		Namespace:     NewNamespaceRepository(),
 		AgentRuntimes: NewAgentRuntimesRepository(agentSourceFactory),
+		AgentMessages: NewAgentMessagesRepository(agentSourceFactory, agents.NewA2AClient(agents.NewA2AHTTPClient())),
 	}
 }
@@ -0,0 +1,53 @@
+/** POST /api/v1/agents/runtimes/:ns/:name/messages request body. */
+export type AgentMessageRequest = {
+  skillId?: string;
+  text: string;
+  data?: Record<string, unknown>;
+  contextId?: string;
+  taskId?: string;
+};
+
+export type AgentMessagePart = {
+  kind: 'text' | 'data' | 'file';
+  text?: string;
+  data?: Record<string, unknown>;
+  fileName?: string;
+  mimeType?: string;
+  fileUri?: string;
+};
+
+export type AgentTaskArtifact = {
+  artifactId: string;
+  name?: string;
+  description?: string;
+  parts: AgentMessagePart[];
+  append?: boolean;
+  lastChunk?: boolean;
+};
+
+export type AgentTaskState =
+  | 'submitted'
+  | 'working'
+  | 'input-required'
+  | 'completed'
+  | 'canceled'
+  | 'failed'
+  | 'rejected'
+  | 'auth-required'
+  | 'unknown';
+
+/**
+ * Task, message, status or artifact event from an agent. Streaming agents emit one per
+ * server-sent event (the SSE `event:` field equals `kind`); others return a single event.
+ */
+export type AgentMessageEvent = {
+  kind: 'task' | 'message' | 'status-update' | 'artifact-update';
+  taskId?: string;
+  contextId?: string;
+  state?: AgentTaskState;
+  statusMessage?: AgentMessagePart[];
+  role?: string;
+  parts?: AgentMessagePart[];
+  artifacts?: AgentTaskArtifact[];
+  final: boolean;
+};
@@ -0,0 +1,37 @@
+import { DeployAgentEnvVarRequest, DeployAgentServicePortRequest } from './deployAgent';
+
+/** A recorded deploy of an agent, from GET .../runtimes/{ns}/{name}/revisions. */
+export type AgentRevision = {
+  revision: number;
+  name: string;
+  createdAt: string;
+  changeCause?: string;
+  containerImage?: string;
+  envVars: DeployAgentEnvVarRequest[];
+  servicePorts: DeployAgentServicePortRequest[];
+  current: boolean;
+};
+
+export type AgentRevisionList = {
+  items: AgentRevision[];
+};
+
+/** An active canary receiving `weight` percent of the agent's Route traffic. */
+export type AgentCanaryStatus = {
+  revision: number;
+  weight: number;
+  workloadName: string;
+  readyStatus: string;
+  routes?: string[];
+};
+
+/** POST .../runtimes/{ns}/{name}/rollback request body. */
+export type RollbackAgentRequest = {
+  revision: number;
+};
+
+/** PUT .../runtimes/{ns}/{name}/canary request body; weight is 1-99. */
+export type AgentCanaryRequest = {
+  revision: number;
+  weight: number;
+};
@@ -1,3 +1,5 @@
+import { AgentCanaryStatus } from './agentRevision';
+
 export type AgentRuntime = {
   name: string;
   namespace: string;
@@ -102,6 +104,8 @@ export type AgentRuntimeDetail = {
   workloadStatus: string;
   serviceEndpoints: AgentServiceEndpoint[];
   conditions: AgentRuntimeCondition[];
+  revision?: number;
+  canary?: AgentCanaryStatus;
   /** @deprecated Not populated in 3.5 discovery scope. */
   agentCard?: AgentCardDetail | null;
 };
@@ -1,6 +1,21 @@
+/** A Secret or ConfigMap key in the agent namespace. */
+export type KeySelector = {
+  name: string;
+  key: string;
+  optional?: boolean;
+};
+
+/** Exactly one of secretKeyRef or configMapKeyRef. */
+export type EnvVarSource = {
+  secretKeyRef?: KeySelector;
+  configMapKeyRef?: KeySelector;
+};
+
+/** Set either value or valueFrom. */
 export type DeployAgentEnvVarRequest = {
   name: string;
   value: string;
+  valueFrom?: EnvVarSource;
 };
 
 export type DeployAgentServicePortRequest = {
@@ -10,6 +25,47 @@ export type DeployAgentServicePortRequest = {
   protocol?: string;
 };
 
+/** CPU and memory as Kubernetes quantities, e.g. "500m" and "1Gi". */
+export type ResourceList = {
+  cpu?: string;
+  memory?: string;
+};
+
+export type ResourceRequirements = {
+  requests?: ResourceList;
+  limits?: ResourceList;
+};
+
+/** Scale-to-zero settings; an agent runs a single replica, so maxReplicas is 1. */
+export type ScalingSpec = {
+  minReplicas?: number;
+  maxReplicas?: number;
+  scaleToZero: boolean;
+  idleTimeoutSeconds?: number;
+};
+
+/** Mounts exactly one of secret, configMap or persistentVolumeClaim. */
+export type VolumeMount = {
+  name: string;
+  mountPath: string;
+  subPath?: string;
+  readOnly?: boolean;
+  secret?: string;
+  configMap?: string;
+  persistentVolumeClaim?: string;
+};
+
+export type DeploySourceBuildStrategy = 'shipwright' | 'buildconfig';
+
+/** Git source for deploy-from-source; containerImage must be empty when set. */
+export type DeploySource = {
+  repoUrl: string;
+  ref?: string;
+  contextDir?: string;
+  dockerfile?: string;
+  buildStrategy?: DeploySourceBuildStrategy;
+};
+
 /** POST /api/v1/agents/deploy request body. */
 export type DeployAgentRequest = {
   name: string;
@@ -22,6 +78,29 @@ export type DeployAgentRequest = {
   description?: string;
   envVars?: DeployAgentEnvVarRequest[];
   servicePorts?: DeployAgentServicePortRequest[];
+  source?: DeploySource;
+  resources?: ResourceRequirements;
+  scaling?: ScalingSpec;
+  volumeMounts?: VolumeMount[];
+};
+
+export type AgentBuildPhase = 'Pending' | 'Running' | 'Succeeded' | 'Failed';
+
+/** GET /api/v1/agents/builds/:ns/:name response body. */
+export type AgentBuildStatus = {
+  name: string;
+  namespace: string;
+  buildStrategy: DeploySourceBuildStrategy;
+  buildRunName?: string;
+  phase: AgentBuildPhase;
+  message?: string;
+  repoUrl?: string;
+  ref?: string;
+  outputImage?: string;
+  imageDigest?: string;
+  startTime?: string;
+  completionTime?: string;
+  deployed: boolean;
 };
 
 export type DeployAgentResponse = {
@@ -29,4 +108,5 @@ export type DeployAgentResponse = {
   name: string;
   namespace: string;
   message?: string;
+  build?: AgentBuildStatus;
 };
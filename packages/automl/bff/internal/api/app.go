@@ -28,19 +28,31 @@ import (
 )
 
 const (
-	Version                 = "1.0.0"
-	PathPrefix              = "/automl"
-	ApiPathPrefix           = "/api/v1"
-	HealthCheckPath         = "/healthcheck"
-	UserPath                = ApiPathPrefix + "/user"
-	NamespacePath           = ApiPathPrefix + "/namespaces"
-	SecretsPath             = ApiPathPrefix + "/secrets"
-	S3FilePath              = ApiPathPrefix + "/s3/files/:key"
-	S3FilesPath             = ApiPathPrefix + "/s3/files"
-	PipelineRunsPath        = ApiPathPrefix + "/pipeline-runs"
-	ModelRegistriesPath     = ApiPathPrefix + "/model-registries"
-	ModelRegistryModelsPath = ModelRegistriesPath + "/:registryId/models"
-	ManagedPipelinesPath    = ApiPathPrefix + "/managed-pipelines/enable"
+	Version                  = "1.0.0"
+	PathPrefix               = "/automl"
+	ApiPathPrefix            = "/api/v1"
+	HealthCheckPath          = "/healthcheck"
+	UserPath                 = ApiPathPrefix + "/user"
+	NamespacePath            = ApiPathPrefix + "/namespaces"
+	SecretsPath              = ApiPathPrefix + "/secrets"
+	S3FilePath               = ApiPathPrefix + "/s3/files/:key"
+	S3FilesPath              = ApiPathPrefix + "/s3/files"
+	S3FileCopyPath           = S3FilePath + "/copy"
+	S3FileMovePath           = S3FilePath + "/move"
+	S3FilePresignPath        = S3FilePath + "/presign"
+	S3UploadsPath            = ApiPathPrefix + "/s3/uploads"
+	S3UploadPartsPath        = S3UploadsPath + "/parts"
+	S3UploadCompletePath     = S3UploadsPath + "/complete"
+	PipelineRunsPath         = ApiPathPrefix + "/pipeline-runs"
+	PipelineRunDAGPath       = PipelineRunsPath + "/:runId/dag"
+	PipelineRunTaskLogsPath  = PipelineRunsPath + "/:runId/tasks/:taskId/logs"
+	PipelineRunArtifactsPath = PipelineRunsPath + "/:runId/artifacts"
+	ModelRegistriesPath      = ApiPathPrefix + "/model-registries"
+	ModelRegistryModelsPath  = ModelRegistriesPath + "/:registryId/models"
+	ManagedPipelinesPath     = ApiPathPrefix + "/managed-pipelines/enable"
+	LeaderboardPath          = ApiPathPrefix + "/leaderboard"
+	RetrainingSchedulesPath  = ApiPathPrefix + "/retraining-schedules"
+	RetrainingSyncPath       = ApiPathPrefix + "/retraining-sync"
 )
 
 var hashPattern = regexp.MustCompile(`[.\-][0-9a-f]{8,}`)
@@ -83,6 +95,9 @@ type App struct {
 	k8s           *K8sHandler
 	s3            *S3Handler
 	pipelines     *PipelinesHandler
+	leaderboard   *LeaderboardHandler
+	runDetails    *RunDetailsHandler
+	retraining    *RetrainingHandler
 	modelRegistry *ModelRegistryHandler
 }
 
@@ -244,6 +259,15 @@ func NewApp(cfg config.EnvConfig, logger *slog.Logger) (*App, error) {
 		mrClient = modelregistry.NewDefaultModelRegistryClient(mrClientCfg)
 	}
 
+	s3Repo := repositories.NewS3Repository(logger, s3Service, k8sService, pipelinesService)
+	pipelinesRepo := repositories.NewPipelinesRepository(logger, pipelinesService, repositories.PipelinesRepositoryConfig{
+		TimeSeriesPipelineName: cfg.AutoMLTimeSeriesPipelineNamePrefix,
+		TabularPipelineName:    cfg.AutoMLTabularPipelineNamePrefix,
+		DefaultPipelineVersion: cfg.PipelineVersionSuffix,
+	})
+	leaderboardRepo := repositories.NewLeaderboardRepository(logger, pipelinesRepo, s3Repo)
+	modelRegistryRepo := repositories.NewModelRegistryRepository(logger, mrClient, k8sService, pipelinesService)
+
 	app := &App{
 		config:             cfg,
 		logger:             logger,
@@ -266,19 +290,27 @@ func NewApp(cfg config.EnvConfig, logger *slog.Logger) (*App, error) {
 		},
 		s3: &S3Handler{
 			logger: logger,
-			repo:   repositories.NewS3Repository(logger, s3Service, k8sService, pipelinesService),
+			repo:   s3Repo,
 		},
 		pipelines: &PipelinesHandler{
 			logger: logger,
-			repo: repositories.NewPipelinesRepository(logger, pipelinesService, repositories.PipelinesRepositoryConfig{
-				TimeSeriesPipelineName: cfg.AutoMLTimeSeriesPipelineNamePrefix,
-				TabularPipelineName:    cfg.AutoMLTabularPipelineNamePrefix,
-				DefaultPipelineVersion: cfg.PipelineVersionSuffix,
-			}),
+			repo:   pipelinesRepo,
+		},
+		leaderboard: &LeaderboardHandler{
+			logger: logger,
+			repo:   leaderboardRepo,
+		},
+		runDetails: &RunDetailsHandler{
+			logger: logger,
+			repo:   repositories.NewRunDetailsRepository(logger, pipelinesRepo, pipelinesService, s3Repo),
+		},
+		retraining: &RetrainingHandler{
+			logger: logger,
+			repo:   repositories.NewRetrainingRepository(logger, k8sService, pipelinesRepo, leaderboardRepo, modelRegistryRepo, s3Repo),
 		},
 		modelRegistry: &ModelRegistryHandler{
 			logger: logger,
-			repo:   repositories.NewModelRegistryRepository(logger, mrClient, k8sService, pipelinesService),
+			repo:   modelRegistryRepo,
 		},
 	}
 	return app, nil
@@ -318,11 +350,41 @@ func (app *App) Routes() http.Handler {
 	apiRouter.POST(PipelineRunsPath+"/:runId/retry", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.pipelines.RetryPipelineRunHandler)))
 	apiRouter.DELETE(PipelineRunsPath+"/:runId", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.pipelines.DeletePipelineRunHandler)))
 
+	// Run task graph, task logs and output artifacts (artifacts are read from Pipeline Server object storage)
+	apiRouter.GET(PipelineRunDAGPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunDAGHandler)))
+	apiRouter.GET(PipelineRunTaskLogsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunTaskLogsHandler)))
+	apiRouter.GET(PipelineRunArtifactsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunArtifactsHandler)))
+	apiRouter.GET(PipelineRunArtifactsPath+"/files", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunArtifactFilesHandler)))
+	apiRouter.GET(PipelineRunArtifactsPath+"/download", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunArtifactDownloadHandler)))
+
+	// Cross-run model comparison built from run artifacts in Pipeline Server object storage
+	apiRouter.GET(LeaderboardPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.leaderboard.LeaderboardHandler)))
+
+	// Scheduled retraining — state is stored in a ConfigMap and advanced by the sync endpoints
+	apiRouter.GET(RetrainingSchedulesPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.retraining.RetrainingSchedulesHandler)))
+	apiRouter.POST(RetrainingSchedulesPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.retraining.CreateRetrainingScheduleHandler)))
+	apiRouter.GET(RetrainingSchedulesPath+"/:scheduleId", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.retraining.RetrainingScheduleHandler)))
+	apiRouter.DELETE(RetrainingSchedulesPath+"/:scheduleId", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.retraining.DeleteRetrainingScheduleHandler)))
+	apiRouter.POST(RetrainingSchedulesPath+"/:scheduleId/sync", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.retraining.SyncRetrainingScheduleHandler)))
+	apiRouter.POST(RetrainingSyncPath, app.retraining.SyncAllRetrainingSchedulesHandler)
+
 	// S3 operations — credentials resolved from explicit secretName query parameter.
 	apiRouter.GET(S3FilePath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.GetS3FileHandler)))
 	apiRouter.GET(S3FilesPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.GetS3FilesHandler)))
 	// POST /s3/files/:key: secretName is required; there is no DSPA fallback.
 	apiRouter.POST(S3FilePath, app.mw.AttachNamespace(app.s3.rejectDeclaredOversizedS3Post(app.mw.RequireAccessToService(app.s3.PostS3FileHandler))))
+	// Resumable multipart uploads for files above the single POST limit; secretName is required.
+	apiRouter.POST(S3UploadsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.CreateS3UploadHandler)))
+	apiRouter.DELETE(S3UploadsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.AbortS3UploadHandler)))
+	apiRouter.GET(S3UploadPartsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.ListS3UploadPartsHandler)))
+	apiRouter.PUT(S3UploadPartsPath+"/:partNumber", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.PutS3UploadPartHandler)))
+	apiRouter.POST(S3UploadCompletePath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.CompleteS3UploadHandler)))
+	// Object browser operations; secretName is required except for presigned downloads.
+	apiRouter.POST(S3FileCopyPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.CopyS3FileHandler)))
+	apiRouter.POST(S3FileMovePath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.MoveS3FileHandler)))
+	apiRouter.POST(S3FilePresignPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.PresignS3FileHandler)))
+	apiRouter.DELETE(S3FilePath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.DeleteS3FileHandler)))
+	apiRouter.DELETE(S3FilesPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.DeleteS3FilesHandler)))
 
 	// Model Registry discovery — CRs are namespace-scoped within rhoai-model-registries
 	// but presented as global in the RHOAI UX; no user-supplied namespace parameter needed.
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/kubeflow/model-registry/pkg/openapi"
 	helper "github.com/opendatahub-io/automl-library/bff/internal/helpers"
@@ -94,6 +95,9 @@ func (m *mockK8sService) PatchResource(ctx context.Context, gvr schema.GroupVers
 func (m *mockK8sService) PatchDeployment(ctx context.Context, namespace, name string, patchType types.PatchType, patchData []byte) error {
 	return nil
 }
+func (m *mockK8sService) GetPodLogs(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
 
 // compile-time check
 var _ kubernetes.Service = (*mockK8sService)(nil)
@@ -142,6 +146,11 @@ func (m *mockS3Repo) GetCSVSchema(ctx context.Context, req repositories.S3Reques
 	return args.Get(0).(helper.CSVSchemaResult), args.Error(1)
 }
 
+func (m *mockS3Repo) GetCSVProfile(ctx context.Context, req repositories.S3RequestContext, key string, sampleRows int) (helper.CSVProfileResult, error) {
+	args := m.Called(ctx, req, key, sampleRows)
+	return args.Get(0).(helper.CSVProfileResult), args.Error(1)
+}
+
 func (m *mockS3Repo) UploadCSVFile(ctx context.Context, req repositories.S3RequestContext, key string, body io.Reader, rawContentType, filename string, maxAttempts int) (string, error) {
 	args := m.Called(ctx, req, key, body, rawContentType, filename, maxAttempts)
 	return args.String(0), args.Error(1)
@@ -155,6 +164,79 @@ func (m *mockS3Repo) ListObjects(ctx context.Context, req repositories.S3Request
 	return args.Get(0).(*s3.ListObjectsResponse), args.Error(1)
 }
 
+func (m *mockS3Repo) CreateCSVUpload(ctx context.Context, req repositories.S3RequestContext, key, rawContentType string, maxAttempts int) (*s3.MultipartUpload, error) {
+	args := m.Called(ctx, req, key, rawContentType, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.MultipartUpload), args.Error(1)
+}
+
+func (m *mockS3Repo) UploadPart(ctx context.Context, req repositories.S3RequestContext, key, uploadID string, partNumber int32, body io.ReadSeeker, size int64) (*s3.UploadedPart, error) {
+	args := m.Called(ctx, req, key, uploadID, partNumber, body, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.UploadedPart), args.Error(1)
+}
+
+func (m *mockS3Repo) ListUploadParts(ctx context.Context, req repositories.S3RequestContext, key, uploadID string) ([]s3.UploadedPart, error) {
+	args := m.Called(ctx, req, key, uploadID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]s3.UploadedPart), args.Error(1)
+}
+
+func (m *mockS3Repo) CompleteUpload(ctx context.Context, req repositories.S3RequestContext, key, uploadID string, parts []s3.UploadedPart) error {
+	args := m.Called(ctx, req, key, uploadID, parts)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) AbortUpload(ctx context.Context, req repositories.S3RequestContext, key, uploadID string) error {
+	args := m.Called(ctx, req, key, uploadID)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) CopyObject(ctx context.Context, req repositories.S3RequestContext, sourceKey, destinationKey string) error {
+	args := m.Called(ctx, req, sourceKey, destinationKey)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) MoveObject(ctx context.Context, req repositories.S3RequestContext, sourceKey, destinationKey string) error {
+	args := m.Called(ctx, req, sourceKey, destinationKey)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) DeleteObject(ctx context.Context, req repositories.S3RequestContext, key string) error {
+	args := m.Called(ctx, req, key)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) DeletePrefix(ctx context.Context, req repositories.S3RequestContext, prefix, confirmationToken string) (*s3.DeletePrefixResult, error) {
+	args := m.Called(ctx, req, prefix, confirmationToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.DeletePrefixResult), args.Error(1)
+}
+
+func (m *mockS3Repo) PresignDownload(ctx context.Context, req repositories.S3RequestContext, key string, expires time.Duration) (*models.S3PresignedURL, error) {
+	args := m.Called(ctx, req, key, expires)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.S3PresignedURL), args.Error(1)
+}
+
+func (m *mockS3Repo) PresignCSVUpload(ctx context.Context, req repositories.S3RequestContext, key string, expires time.Duration, maxAttempts int) (*models.S3PresignedURL, error) {
+	args := m.Called(ctx, req, key, expires, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.S3PresignedURL), args.Error(1)
+}
+
 // --- Mock Pipelines Repository ---
 
 type mockPipelinesRepo struct {
@@ -229,3 +311,112 @@ func (m *mockModelRegistryRepo) RegisterModel(ctx context.Context, registryUID s
 	}
 	return args.String(0), args.Get(1).(*openapi.ModelArtifact), args.Error(2)
 }
+
+// --- Mock Leaderboard Repository ---
+
+type mockLeaderboardRepo struct {
+	mock.Mock
+}
+
+func (m *mockLeaderboardRepo) GetLeaderboard(ctx context.Context, namespace string, runIDs []string, metric string) (*models.Leaderboard, error) {
+	args := m.Called(ctx, namespace, runIDs, metric)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Leaderboard), args.Error(1)
+}
+
+// --- Mock Run Details Repository ---
+
+type mockRunDetailsRepo struct {
+	mock.Mock
+}
+
+func (m *mockRunDetailsRepo) GetRunDAG(ctx context.Context, namespace, runID string) (*pipelines.RunDAG, error) {
+	args := m.Called(ctx, namespace, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pipelines.RunDAG), args.Error(1)
+}
+
+func (m *mockRunDetailsRepo) StreamTaskLogs(ctx context.Context, namespace, runID, taskID string, opts pipelines.TaskLogOptions) (io.ReadCloser, error) {
+	args := m.Called(ctx, namespace, runID, taskID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockRunDetailsRepo) ListRunArtifacts(ctx context.Context, namespace, runID string) ([]pipelines.RunArtifact, error) {
+	args := m.Called(ctx, namespace, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]pipelines.RunArtifact), args.Error(1)
+}
+
+func (m *mockRunDetailsRepo) ListRunArtifactFiles(ctx context.Context, namespace, runID, taskName, name, dir, next string) (*s3.ListObjectsResponse, error) {
+	args := m.Called(ctx, namespace, runID, taskName, name, dir, next)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.ListObjectsResponse), args.Error(1)
+}
+
+func (m *mockRunDetailsRepo) GetRunArtifact(ctx context.Context, namespace, runID, taskName, name, file string) (*repositories.GetObjectResult, string, error) {
+	args := m.Called(ctx, namespace, runID, taskName, name, file)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*repositories.GetObjectResult), args.String(1), args.Error(2)
+}
+
+type mockRetrainingRepo struct {
+	mock.Mock
+}
+
+func (m *mockRetrainingRepo) ListSchedules(ctx context.Context, namespace string) ([]models.RetrainingSchedule, error) {
+	args := m.Called(ctx, namespace)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RetrainingSchedule), args.Error(1)
+}
+
+func (m *mockRetrainingRepo) GetSchedule(ctx context.Context, namespace, id string) (*models.RetrainingSchedule, error) {
+	args := m.Called(ctx, namespace, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RetrainingSchedule), args.Error(1)
+}
+
+func (m *mockRetrainingRepo) CreateSchedule(ctx context.Context, namespace string, req models.CreateRetrainingScheduleRequest) (*models.RetrainingSchedule, error) {
+	args := m.Called(ctx, namespace, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RetrainingSchedule), args.Error(1)
+}
+
+func (m *mockRetrainingRepo) DeleteSchedule(ctx context.Context, namespace, id string) error {
+	args := m.Called(ctx, namespace, id)
+	return args.Error(0)
+}
+
+func (m *mockRetrainingRepo) SyncSchedule(ctx context.Context, namespace, id string) (*models.RetrainingSchedule, error) {
+	args := m.Called(ctx, namespace, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RetrainingSchedule), args.Error(1)
+}
+
+func (m *mockRetrainingRepo) SyncAllSchedules(ctx context.Context) (*models.RetrainingSyncResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RetrainingSyncResult), args.Error(1)
+}
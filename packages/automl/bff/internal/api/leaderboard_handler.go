@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/automl-library/bff/internal/constants"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
+)
+
+type leaderboardRepository interface {
+	GetLeaderboard(ctx context.Context, namespace string, runIDs []string, metric string) (*models.Leaderboard, error)
+}
+
+type LeaderboardHandler struct {
+	logger *slog.Logger
+	repo   leaderboardRepository
+}
+
+type LeaderboardEnvelope Envelope[*models.Leaderboard, None]
+
+// LeaderboardHandler handles GET /api/v1/leaderboard
+// Query parameters:
+//   - runIds (required): comma-separated AutoML run IDs, at most 10. May be repeated.
+//   - metric (optional): metric to rank by; defaults to the first run's eval_metric.
+//     Time-series acronyms such as MASE are accepted and normalized to snake_case.
+//
+// Model artifacts are read from the namespace's Pipeline Server object storage.
+func (h *LeaderboardHandler) LeaderboardHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	namespace, ok := r.Context().Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return
+	}
+
+	query := r.URL.Query()
+	var runIDs []string
+	for _, value := range query["runIds"] {
+		for _, id := range strings.Split(value, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" {
+				badRequestResponse(h.logger, w, r, "query parameter 'runIds' must not contain empty run IDs")
+				return
+			}
+			runIDs = append(runIDs, id)
+		}
+	}
+	if len(runIDs) == 0 {
+		badRequestResponse(h.logger, w, r, "query parameter 'runIds' is required")
+		return
+	}
+
+	result, err := h.repo.GetLeaderboard(r.Context(), namespace, runIDs, query.Get("metric"))
+	if err != nil {
+		h.mapLeaderboardError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, LeaderboardEnvelope{Data: result}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+func (h *LeaderboardHandler) mapLeaderboardError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrPipelineRunNotFound):
+		notFoundResponseWithMessage(h.logger, w, r, err.Error())
+	case errors.Is(err, repositories.ErrManagedPipelinesNotFound):
+		notFoundResponseWithMessage(h.logger, w, r, err.Error())
+	case errors.Is(err, repositories.ErrValidation):
+		badRequestResponse(h.logger, w, r, err.Error())
+	default:
+		writeS3RepoError(h.logger, w, r, err, "")
+	}
+}
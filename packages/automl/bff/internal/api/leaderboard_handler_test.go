@@ -0,0 +1,120 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestLeaderboardHandler() (*LeaderboardHandler, *mockLeaderboardRepo) {
+	repo := new(mockLeaderboardRepo)
+	return &LeaderboardHandler{logger: silentLogger(), repo: repo}, repo
+}
+
+func TestLeaderboardHandler(t *testing.T) {
+	rank := 1
+	score := 0.94
+	board := &models.Leaderboard{
+		Metric: "roc_auc",
+		Runs:   []models.LeaderboardRun{{RunID: "run-1", Status: models.LeaderboardRunStatusOK, ModelCount: 1}},
+		Candidates: []models.LeaderboardCandidate{
+			{Rank: &rank, RunID: "run-1", ModelName: "CatBoost_FULL", Score: &score, Metrics: map[string]float64{"roc_auc": score}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		namespace      string
+		setupMock      func(repo *mockLeaderboardRepo)
+		expectedStatus int
+	}{
+		{
+			name:           "missing namespace",
+			url:            "/api/v1/leaderboard?runIds=run-1",
+			setupMock:      func(repo *mockLeaderboardRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing runIds",
+			url:            "/api/v1/leaderboard",
+			namespace:      "ns",
+			setupMock:      func(repo *mockLeaderboardRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty run ID",
+			url:            "/api/v1/leaderboard?runIds=run-1,,run-2",
+			namespace:      "ns",
+			setupMock:      func(repo *mockLeaderboardRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "comma-separated and repeated runIds",
+			url:       "/api/v1/leaderboard?runIds=run-1,%20run-2&runIds=run-3&metric=MASE",
+			namespace: "ns",
+			setupMock: func(repo *mockLeaderboardRepo) {
+				repo.On("GetLeaderboard", mock.Anything, "ns", []string{"run-1", "run-2", "run-3"}, "MASE").Return(board, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "run not found",
+			url:       "/api/v1/leaderboard?runIds=missing",
+			namespace: "ns",
+			setupMock: func(repo *mockLeaderboardRepo) {
+				repo.On("GetLeaderboard", mock.Anything, "ns", []string{"missing"}, "").
+					Return(nil, fmt.Errorf("run %q: %w", "missing", repositories.ErrPipelineRunNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "validation error",
+			url:       "/api/v1/leaderboard?runIds=run-1,run-1",
+			namespace: "ns",
+			setupMock: func(repo *mockLeaderboardRepo) {
+				repo.On("GetLeaderboard", mock.Anything, "ns", []string{"run-1", "run-1"}, "").
+					Return(nil, repositories.NewValidationError("duplicate run ID"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "object storage access denied",
+			url:       "/api/v1/leaderboard?runIds=run-1",
+			namespace: "ns",
+			setupMock: func(repo *mockLeaderboardRepo) {
+				repo.On("GetLeaderboard", mock.Anything, "ns", []string{"run-1"}, "").Return(nil, s3.ErrAccessDenied)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestLeaderboardHandler()
+			tt.setupMock(repo)
+
+			req := pipelineRequestWithNamespace(http.MethodGet, tt.url, tt.namespace, "")
+			rr := httptest.NewRecorder()
+			handler.LeaderboardHandler(rr, req, nil)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			repo.AssertExpectations(t)
+
+			if tt.expectedStatus == http.StatusOK {
+				var resp LeaderboardEnvelope
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "roc_auc", resp.Data.Metric)
+				assert.Len(t, resp.Data.Candidates, 1)
+			}
+		})
+	}
+}
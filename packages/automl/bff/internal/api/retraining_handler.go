@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/automl-library/bff/internal/constants"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
+	"github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+)
+
+type retrainingRepository interface {
+	ListSchedules(ctx context.Context, namespace string) ([]models.RetrainingSchedule, error)
+	GetSchedule(ctx context.Context, namespace, id string) (*models.RetrainingSchedule, error)
+	CreateSchedule(ctx context.Context, namespace string, req models.CreateRetrainingScheduleRequest) (*models.RetrainingSchedule, error)
+	DeleteSchedule(ctx context.Context, namespace, id string) error
+	SyncSchedule(ctx context.Context, namespace, id string) (*models.RetrainingSchedule, error)
+	SyncAllSchedules(ctx context.Context) (*models.RetrainingSyncResult, error)
+}
+
+type RetrainingHandler struct {
+	logger *slog.Logger
+	repo   retrainingRepository
+}
+
+type RetrainingSchedulesEnvelope Envelope[models.RetrainingSchedulesData, None]
+type RetrainingScheduleEnvelope Envelope[*models.RetrainingSchedule, None]
+type RetrainingSyncEnvelope Envelope[*models.RetrainingSyncResult, None]
+
+// RetrainingSchedulesHandler handles GET /api/v1/retraining-schedules
+func (h *RetrainingHandler) RetrainingSchedulesHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	namespace, ok := r.Context().Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return
+	}
+
+	schedules, err := h.repo.ListSchedules(r.Context(), namespace)
+	if err != nil {
+		h.mapRetrainingError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, RetrainingSchedulesEnvelope{Data: models.RetrainingSchedulesData{Schedules: schedules}}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+// RetrainingScheduleHandler handles GET /api/v1/retraining-schedules/:scheduleId
+func (h *RetrainingHandler) RetrainingScheduleHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	namespace, ok := r.Context().Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return
+	}
+
+	schedule, err := h.repo.GetSchedule(r.Context(), namespace, params.ByName("scheduleId"))
+	if err != nil {
+		h.mapRetrainingError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, RetrainingScheduleEnvelope{Data: schedule}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+// CreateRetrainingScheduleHandler handles POST /api/v1/retraining-schedules
+// The source run's pipeline and parameters are reused for every retraining run. Cron
+// triggers create a recurring run on the namespace's Pipeline Server.
+func (h *RetrainingHandler) CreateRetrainingScheduleHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	namespace, ok := r.Context().Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	var req models.CreateRetrainingScheduleRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			payloadTooLargeResponse(h.logger, w, r, "request body exceeds maximum size")
+			return
+		}
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+	var extra interface{}
+	if err := decoder.Decode(&extra); err != io.EOF {
+		badRequestResponse(h.logger, w, r, "request body must contain only a single JSON object")
+		return
+	}
+
+	schedule, err := h.repo.CreateSchedule(r.Context(), namespace, req)
+	if err != nil {
+		h.mapRetrainingError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusCreated, RetrainingScheduleEnvelope{Data: schedule}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+// DeleteRetrainingScheduleHandler handles DELETE /api/v1/retraining-schedules/:scheduleId
+// Runs already started by the schedule are kept.
+func (h *RetrainingHandler) DeleteRetrainingScheduleHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	namespace, ok := r.Context().Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return
+	}
+
+	if err := h.repo.DeleteSchedule(r.Context(), namespace, params.ByName("scheduleId")); err != nil {
+		h.mapRetrainingError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SyncRetrainingScheduleHandler handles POST /api/v1/retraining-schedules/:scheduleId/sync
+// It syncs one schedule right away; the automl-retraining-sync CronJob syncs all of them.
+func (h *RetrainingHandler) SyncRetrainingScheduleHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	namespace, ok := r.Context().Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return
+	}
+
+	schedule, err := h.repo.SyncSchedule(r.Context(), namespace, params.ByName("scheduleId"))
+	if err != nil {
+		h.mapRetrainingError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, RetrainingScheduleEnvelope{Data: schedule}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+// SyncAllRetrainingSchedulesHandler handles POST /api/v1/retraining-sync
+// Called by the automl-retraining-sync CronJob to start runs on new training data and to
+// evaluate and register finished runs in every project the caller can list.
+func (h *RetrainingHandler) SyncAllRetrainingSchedulesHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	result, err := h.repo.SyncAllSchedules(r.Context())
+	if err != nil {
+		h.mapRetrainingError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, RetrainingSyncEnvelope{Data: result}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+func (h *RetrainingHandler) mapRetrainingError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrRetrainingScheduleNotFound),
+		errors.Is(err, repositories.ErrPipelineRunNotFound),
+		errors.Is(err, repositories.ErrManagedPipelinesNotFound):
+		notFoundResponseWithMessage(h.logger, w, r, err.Error())
+	case errors.Is(err, repositories.ErrModelRegistryNotFound):
+		notFoundResponseWithMessage(h.logger, w, r, "no model registry found for the given registry_id")
+	case errors.Is(err, repositories.ErrModelRegistryForbidden):
+		forbiddenResponse(h.logger, w, r, "insufficient permissions to list model registries")
+	case errors.Is(err, repositories.ErrModelRegistryNotReady):
+		serviceUnavailableResponseWithMessage(h.logger, w, r, err, "model registry is not ready")
+	case errors.Is(err, repositories.ErrValidation),
+		errors.Is(err, pipelines.ErrInvalidInput),
+		errors.Is(err, pipelines.ErrPipelineServerBadRequest):
+		badRequestResponse(h.logger, w, r, err.Error())
+	case errors.Is(err, repositories.ErrRetrainingScheduleConflict):
+		conflictResponse(h.logger, w, r, repositories.ErrRetrainingScheduleConflict.Error())
+	default:
+		writeS3RepoError(h.logger, w, r, err, "")
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
+	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestRetrainingHandler() (*RetrainingHandler, *mockRetrainingRepo) {
+	repo := new(mockRetrainingRepo)
+	return &RetrainingHandler{logger: silentLogger(), repo: repo}, repo
+}
+
+func TestCreateRetrainingScheduleHandler(t *testing.T) {
+	schedule := &models.RetrainingSchedule{ID: "schedule-1", DisplayName: "Weekly churn", Metric: "roc_auc"}
+	cronRequest := models.CreateRetrainingScheduleRequest{
+		DisplayName: "Weekly churn",
+		SourceRunID: "run-1",
+		Trigger:     models.RetrainingTrigger{Type: models.RetrainingTriggerCron, Cron: "0 3 * * 1"},
+	}
+
+	tests := []struct {
+		name           string
+		namespace      string
+		body           string
+		setupMock      func(repo *mockRetrainingRepo)
+		expectedStatus int
+	}{
+		{
+			name:           "missing namespace",
+			body:           `{}`,
+			setupMock:      func(repo *mockRetrainingRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown field",
+			namespace:      "ns",
+			body:           `{"display_name":"x","schedule":"daily"}`,
+			setupMock:      func(repo *mockRetrainingRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "created",
+			namespace: "ns",
+			body:      `{"display_name":"Weekly churn","source_run_id":"run-1","trigger":{"type":"cron","cron":"0 3 * * 1"}}`,
+			setupMock: func(repo *mockRetrainingRepo) {
+				repo.On("CreateSchedule", mock.Anything, "ns", cronRequest).Return(schedule, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:      "validation error",
+			namespace: "ns",
+			body:      `{"display_name":"Weekly churn","source_run_id":"run-1","trigger":{"type":"cron","cron":"0 3 * * 1"}}`,
+			setupMock: func(repo *mockRetrainingRepo) {
+				repo.On("CreateSchedule", mock.Anything, "ns", cronRequest).
+					Return(nil, repositories.NewValidationError("trigger.cron must have five fields"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "source run not found",
+			namespace: "ns",
+			body:      `{"display_name":"Weekly churn","source_run_id":"run-1","trigger":{"type":"cron","cron":"0 3 * * 1"}}`,
+			setupMock: func(repo *mockRetrainingRepo) {
+				repo.On("CreateSchedule", mock.Anything, "ns", cronRequest).Return(nil, repositories.ErrPipelineRunNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "concurrent modification",
+			namespace: "ns",
+			body:      `{"display_name":"Weekly churn","source_run_id":"run-1","trigger":{"type":"cron","cron":"0 3 * * 1"}}`,
+			setupMock: func(repo *mockRetrainingRepo) {
+				repo.On("CreateSchedule", mock.Anything, "ns", cronRequest).Return(nil, repositories.ErrRetrainingScheduleConflict)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestRetrainingHandler()
+			tt.setupMock(repo)
+
+			req := pipelineRequestWithNamespace(http.MethodPost, "/api/v1/retraining-schedules", tt.namespace, tt.body)
+			rr := httptest.NewRecorder()
+			handler.CreateRetrainingScheduleHandler(rr, req, nil)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			repo.AssertExpectations(t)
+
+			if tt.expectedStatus == http.StatusCreated {
+				var resp RetrainingScheduleEnvelope
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, "schedule-1", resp.Data.ID)
+			}
+		})
+	}
+}
+
+func TestRetrainingScheduleActions(t *testing.T) {
+	params := httprouter.Params{{Key: "scheduleId", Value: "schedule-1"}}
+
+	t.Run("list", func(t *testing.T) {
+		handler, repo := newTestRetrainingHandler()
+		repo.On("ListSchedules", mock.Anything, "ns").Return([]models.RetrainingSchedule{{ID: "schedule-1"}}, nil)
+
+		rr := httptest.NewRecorder()
+		handler.RetrainingSchedulesHandler(rr, pipelineRequestWithNamespace(http.MethodGet, "/api/v1/retraining-schedules", "ns", ""), nil)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp RetrainingSchedulesEnvelope
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Len(t, resp.Data.Schedules, 1)
+	})
+
+	t.Run("get not found", func(t *testing.T) {
+		handler, repo := newTestRetrainingHandler()
+		repo.On("GetSchedule", mock.Anything, "ns", "schedule-1").Return(nil, repositories.ErrRetrainingScheduleNotFound)
+
+		rr := httptest.NewRecorder()
+		handler.RetrainingScheduleHandler(rr, pipelineRequestWithNamespace(http.MethodGet, "/api/v1/retraining-schedules/schedule-1", "ns", ""), params)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		handler, repo := newTestRetrainingHandler()
+		repo.On("DeleteSchedule", mock.Anything, "ns", "schedule-1").Return(nil)
+
+		rr := httptest.NewRecorder()
+		handler.DeleteRetrainingScheduleHandler(rr, pipelineRequestWithNamespace(http.MethodDelete, "/api/v1/retraining-schedules/schedule-1", "ns", ""), params)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		handler, repo := newTestRetrainingHandler()
+		repo.On("SyncSchedule", mock.Anything, "ns", "schedule-1").Return(&models.RetrainingSchedule{
+			ID:            "schedule-1",
+			PendingRunIDs: []string{"run-2"},
+		}, nil)
+
+		rr := httptest.NewRecorder()
+		handler.SyncRetrainingScheduleHandler(rr, pipelineRequestWithNamespace(http.MethodPost, "/api/v1/retraining-schedules/schedule-1/sync", "ns", ""), params)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp RetrainingScheduleEnvelope
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, []string{"run-2"}, resp.Data.PendingRunIDs)
+	})
+
+	t.Run("sync all", func(t *testing.T) {
+		handler, repo := newTestRetrainingHandler()
+		repo.On("SyncAllSchedules", mock.Anything).Return(&models.RetrainingSyncResult{
+			Synced:   2,
+			Failures: []models.RetrainingSyncFailure{{Namespace: "team-b", ScheduleID: "schedule-3", Error: "pipeline server not ready"}},
+		}, nil)
+
+		rr := httptest.NewRecorder()
+		handler.SyncAllRetrainingSchedulesHandler(rr, httptest.NewRequest(http.MethodPost, "/api/v1/retraining-sync", nil), nil)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp RetrainingSyncEnvelope
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, 2, resp.Data.Synced)
+		assert.Len(t, resp.Data.Failures, 1)
+	})
+
+	t.Run("sync all forbidden", func(t *testing.T) {
+		handler, repo := newTestRetrainingHandler()
+		repo.On("SyncAllSchedules", mock.Anything).Return(nil, kubernetes.ErrForbidden)
+
+		rr := httptest.NewRecorder()
+		handler.SyncAllRetrainingSchedulesHandler(rr, httptest.NewRequest(http.MethodPost, "/api/v1/retraining-sync", nil), nil)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/automl-library/bff/internal/constants"
+	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
+	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+// maxTaskLogTailLines caps the tailLines query parameter of the task logs endpoint.
+const maxTaskLogTailLines = 100000
+
+type runDetailsRepository interface {
+	GetRunDAG(ctx context.Context, namespace, runID string) (*pipelines.RunDAG, error)
+	StreamTaskLogs(ctx context.Context, namespace, runID, taskID string, opts pipelines.TaskLogOptions) (io.ReadCloser, error)
+	ListRunArtifacts(ctx context.Context, namespace, runID string) ([]pipelines.RunArtifact, error)
+	ListRunArtifactFiles(ctx context.Context, namespace, runID, taskName, name, dir, next string) (*s3.ListObjectsResponse, error)
+	GetRunArtifact(ctx context.Context, namespace, runID, taskName, name, file string) (*repositories.GetObjectResult, string, error)
+}
+
+type RunDetailsHandler struct {
+	logger *slog.Logger
+	repo   runDetailsRepository
+}
+
+type RunDAGEnvelope Envelope[*pipelines.RunDAG, None]
+type RunArtifactsEnvelope Envelope[[]pipelines.RunArtifact, None]
+type RunArtifactFilesEnvelope Envelope[*s3.ListObjectsResponse, None]
+
+// runParams returns the namespace and run ID of a run details request, writing a 400
+// response when either is missing.
+func (h *RunDetailsHandler) runParams(w http.ResponseWriter, r *http.Request, params httprouter.Params) (string, string, bool) {
+	namespace, ok := r.Context().Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return "", "", false
+	}
+	runID := params.ByName("runId")
+	if runID == "" {
+		badRequestResponse(h.logger, w, r, "missing runId parameter")
+		return "", "", false
+	}
+	return namespace, runID, true
+}
+
+// RunDAGHandler handles GET /api/v1/pipeline-runs/:runId/dag
+func (h *RunDetailsHandler) RunDAGHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	namespace, runID, ok := h.runParams(w, r, params)
+	if !ok {
+		return
+	}
+
+	dag, err := h.repo.GetRunDAG(r.Context(), namespace, runID)
+	if err != nil {
+		h.mapRunDetailsError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, RunDAGEnvelope{Data: dag}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+// RunTaskLogsHandler handles GET /api/v1/pipeline-runs/:runId/tasks/:taskId/logs
+// Query parameters:
+//   - podName (optional): pod of the task to read; defaults to the executor pod.
+//   - tailLines (optional): only return the last N lines, 1–100000.
+//   - follow (optional): keep streaming while the task runs.
+//
+// The response is the plain-text log, flushed as it arrives when following.
+func (h *RunDetailsHandler) RunTaskLogsHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	namespace, runID, ok := h.runParams(w, r, params)
+	if !ok {
+		return
+	}
+	taskID := params.ByName("taskId")
+	if taskID == "" {
+		badRequestResponse(h.logger, w, r, "missing taskId parameter")
+		return
+	}
+
+	query := r.URL.Query()
+	opts := pipelines.TaskLogOptions{PodName: query.Get("podName")}
+	if tail := query.Get("tailLines"); tail != "" {
+		parsed, err := strconv.ParseInt(tail, 10, 64)
+		if err != nil || parsed <= 0 || parsed > maxTaskLogTailLines {
+			badRequestResponse(h.logger, w, r, "invalid tailLines parameter: must be between 1 and 100000")
+			return
+		}
+		opts.TailLines = parsed
+	}
+	if follow := query.Get("follow"); follow != "" {
+		parsed, err := strconv.ParseBool(follow)
+		if err != nil {
+			badRequestResponse(h.logger, w, r, "invalid follow parameter: must be true or false")
+			return
+		}
+		opts.Follow = parsed
+	}
+
+	logs, err := h.repo.StreamTaskLogs(r.Context(), namespace, runID, taskID, opts)
+	if err != nil {
+		h.mapRunDetailsError(w, r, err)
+		return
+	}
+	defer logs.Close()
+
+	// A followed stream lasts as long as the task runs; lift the server write timeout
+	// for this response only. The stream still ends when the client disconnects.
+	rc := http.NewResponseController(w)
+	if opts.Follow {
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			h.logger.Debug("could not clear write deadline for task logs", "error", err)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	// Flush after every read so followed logs reach the browser as they are written.
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := logs.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				h.logger.Debug("task log client went away", "run_id", runID, "task_id", taskID, "error", err)
+				return
+			}
+			_ = rc.Flush()
+		}
+		if readErr != nil {
+			if !errors.Is(readErr, io.EOF) && r.Context().Err() == nil {
+				h.logger.Error("error streaming task logs", "run_id", runID, "task_id", taskID, "error", readErr)
+			}
+			return
+		}
+	}
+}
+
+// RunArtifactsHandler handles GET /api/v1/pipeline-runs/:runId/artifacts
+func (h *RunDetailsHandler) RunArtifactsHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	namespace, runID, ok := h.runParams(w, r, params)
+	if !ok {
+		return
+	}
+
+	artifacts, err := h.repo.ListRunArtifacts(r.Context(), namespace, runID)
+	if err != nil {
+		h.mapRunDetailsError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, RunArtifactsEnvelope{Data: artifacts}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+// RunArtifactFilesHandler handles GET /api/v1/pipeline-runs/:runId/artifacts/files
+// Query parameters:
+//   - task, name (required): task name and output name of the artifact.
+//   - path (optional): folder inside the artifact to list.
+//   - next (optional): continuation token from a previous page.
+func (h *RunDetailsHandler) RunArtifactFilesHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	namespace, runID, ok := h.runParams(w, r, params)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	result, err := h.repo.ListRunArtifactFiles(r.Context(), namespace, runID, query.Get("task"), query.Get("name"), query.Get("path"), query.Get("next"))
+	if err != nil {
+		h.mapRunDetailsError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, RunArtifactFilesEnvelope{Data: result}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+// RunArtifactDownloadHandler handles GET /api/v1/pipeline-runs/:runId/artifacts/download
+// Query parameters:
+//   - task, name (required): task name and output name of the artifact.
+//   - file (optional): object inside a directory artifact, relative to the artifact.
+//
+// The artifact is read from the DSPA object storage and always sent as an attachment.
+func (h *RunDetailsHandler) RunArtifactDownloadHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	namespace, runID, ok := h.runParams(w, r, params)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	result, key, err := h.repo.GetRunArtifact(r.Context(), namespace, runID, query.Get("task"), query.Get("name"), query.Get("file"))
+	if err != nil {
+		h.mapRunDetailsError(w, r, err)
+		return
+	}
+	defer result.Body.Close()
+
+	w.Header().Set("Content-Type", result.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(key)}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, result.Body); err != nil {
+		h.logger.Error("error streaming run artifact to response", "error", err, "key", key)
+	}
+}
+
+func (h *RunDetailsHandler) mapRunDetailsError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrPipelineRunNotFound):
+		notFoundResponse(h.logger, w, r)
+	case errors.Is(err, repositories.ErrManagedPipelinesNotFound),
+		errors.Is(err, repositories.ErrRunArtifactNotFound),
+		errors.Is(err, pipelines.ErrTaskNotFound):
+		notFoundResponseWithMessage(h.logger, w, r, err.Error())
+	case errors.Is(err, pipelines.ErrTaskLogsUnavailable):
+		conflictResponse(h.logger, w, r, err.Error())
+	case errors.Is(err, repositories.ErrValidation),
+		errors.Is(err, pipelines.ErrInvalidInput),
+		errors.Is(err, pipelines.ErrPipelineServerBadRequest):
+		badRequestResponse(h.logger, w, r, err.Error())
+	default:
+		writeS3RepoError(h.logger, w, r, err, "")
+	}
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
+	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestRunDetailsHandler() (*RunDetailsHandler, *mockRunDetailsRepo) {
+	repo := new(mockRunDetailsRepo)
+	return &RunDetailsHandler{logger: silentLogger(), repo: repo}, repo
+}
+
+func runParamsFor(runID string, extra ...httprouter.Param) httprouter.Params {
+	return append(httprouter.Params{{Key: "runId", Value: runID}}, extra...)
+}
+
+func TestRunDAGHandler(t *testing.T) {
+	dag := &pipelines.RunDAG{RunID: "run-1", State: pipelines.RunStateRunning, Tasks: []pipelines.DAGTask{
+		{Name: "load-data", State: pipelines.RunStateSucceeded},
+		{Name: "train", DependsOn: []string{"load-data"}, State: pipelines.RunStateRunning},
+	}}
+
+	tests := []struct {
+		name           string
+		namespace      string
+		setupMock      func(repo *mockRunDetailsRepo)
+		expectedStatus int
+	}{
+		{
+			name:           "missing namespace",
+			setupMock:      func(repo *mockRunDetailsRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "success",
+			namespace: "ns",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("GetRunDAG", mock.Anything, "ns", "run-1").Return(dag, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "run not found",
+			namespace: "ns",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("GetRunDAG", mock.Anything, "ns", "run-1").Return(nil, repositories.ErrPipelineRunNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "no pipeline server",
+			namespace: "ns",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("GetRunDAG", mock.Anything, "ns", "run-1").Return(nil, pipelines.ErrNoDSPAFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestRunDetailsHandler()
+			tt.setupMock(repo)
+
+			req := pipelineRequestWithNamespace(http.MethodGet, "/api/v1/pipeline-runs/run-1/dag", tt.namespace, "")
+			rr := httptest.NewRecorder()
+			handler.RunDAGHandler(rr, req, runParamsFor("run-1"))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			repo.AssertExpectations(t)
+
+			if tt.expectedStatus == http.StatusOK {
+				var resp RunDAGEnvelope
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Len(t, resp.Data.Tasks, 2)
+				assert.Equal(t, []string{"load-data"}, resp.Data.Tasks[1].DependsOn)
+			}
+		})
+	}
+}
+
+func TestRunTaskLogsHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(repo *mockRunDetailsRepo)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "streams logs",
+			query: "?tailLines=50&follow=true&podName=pod-exec",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				opts := pipelines.TaskLogOptions{PodName: "pod-exec", TailLines: 50, Follow: true}
+				repo.On("StreamTaskLogs", mock.Anything, "ns", "run-1", "task-1", opts).
+					Return(io.NopCloser(strings.NewReader("line 1\nline 2\n")), nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "line 1\nline 2\n",
+		},
+		{
+			name:           "invalid tailLines",
+			query:          "?tailLines=0",
+			setupMock:      func(repo *mockRunDetailsRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid follow",
+			query:          "?follow=maybe",
+			setupMock:      func(repo *mockRunDetailsRepo) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "task not found",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("StreamTaskLogs", mock.Anything, "ns", "run-1", "task-1", pipelines.TaskLogOptions{}).
+					Return(nil, fmt.Errorf("%w: task-1", pipelines.ErrTaskNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "task has no pods yet",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("StreamTaskLogs", mock.Anything, "ns", "run-1", "task-1", pipelines.TaskLogOptions{}).
+					Return(nil, pipelines.ErrTaskLogsUnavailable)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:  "pod of another task",
+			query: "?podName=other",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("StreamTaskLogs", mock.Anything, "ns", "run-1", "task-1", pipelines.TaskLogOptions{PodName: "other"}).
+					Return(nil, fmt.Errorf("%w: pod other does not belong to task task-1", pipelines.ErrInvalidInput))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestRunDetailsHandler()
+			tt.setupMock(repo)
+
+			req := pipelineRequestWithNamespace(http.MethodGet, "/api/v1/pipeline-runs/run-1/tasks/task-1/logs"+tt.query, "ns", "")
+			rr := httptest.NewRecorder()
+			handler.RunTaskLogsHandler(rr, req, runParamsFor("run-1", httprouter.Param{Key: "taskId", Value: "task-1"}))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			repo.AssertExpectations(t)
+
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+				assert.Equal(t, tt.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestRunArtifactsHandler(t *testing.T) {
+	handler, repo := newTestRunDetailsHandler()
+	artifacts := []pipelines.RunArtifact{{TaskName: "train", Name: "model", Type: "system.Model", Downloadable: true}}
+	repo.On("ListRunArtifacts", mock.Anything, "ns", "run-1").Return(artifacts, nil)
+
+	req := pipelineRequestWithNamespace(http.MethodGet, "/api/v1/pipeline-runs/run-1/artifacts", "ns", "")
+	rr := httptest.NewRecorder()
+	handler.RunArtifactsHandler(rr, req, runParamsFor("run-1"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp RunArtifactsEnvelope
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, "system.Model", resp.Data[0].Type)
+}
+
+func TestRunArtifactFilesHandler(t *testing.T) {
+	handler, repo := newTestRunDetailsHandler()
+	listing := &s3.ListObjectsResponse{CommonPrefixes: []s3.CommonPrefix{{Prefix: "p/run-1/train/7/model/weights/"}}}
+	repo.On("ListRunArtifactFiles", mock.Anything, "ns", "run-1", "train", "model", "weights", "").Return(listing, nil)
+
+	req := pipelineRequestWithNamespace(http.MethodGet, "/api/v1/pipeline-runs/run-1/artifacts/files?task=train&name=model&path=weights", "ns", "")
+	rr := httptest.NewRecorder()
+	handler.RunArtifactFilesHandler(rr, req, runParamsFor("run-1"))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	repo.AssertExpectations(t)
+}
+
+func TestRunArtifactDownloadHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(repo *mockRunDetailsRepo)
+		expectedStatus int
+	}{
+		{
+			name: "downloads artifact",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				result := &repositories.GetObjectResult{Body: io.NopCloser(strings.NewReader("{}")), ContentType: "application/json"}
+				repo.On("GetRunArtifact", mock.Anything, "ns", "run-1", "train", "metrics", "").
+					Return(result, "p/run-1/train/7/metrics", nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "artifact not found",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("GetRunArtifact", mock.Anything, "ns", "run-1", "train", "metrics", "").
+					Return(nil, "", fmt.Errorf("%w: train/metrics", repositories.ErrRunArtifactNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "artifact outside DSPA bucket",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("GetRunArtifact", mock.Anything, "ns", "run-1", "train", "metrics", "").
+					Return(nil, "", repositories.NewValidationError("not stored in the pipeline server bucket"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "object missing in storage",
+			setupMock: func(repo *mockRunDetailsRepo) {
+				repo.On("GetRunArtifact", mock.Anything, "ns", "run-1", "train", "metrics", "").
+					Return(nil, "", s3.ErrObjectNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestRunDetailsHandler()
+			tt.setupMock(repo)
+
+			req := pipelineRequestWithNamespace(http.MethodGet, "/api/v1/pipeline-runs/run-1/artifacts/download?task=train&name=metrics", "ns", "")
+			rr := httptest.NewRecorder()
+			handler.RunArtifactDownloadHandler(rr, req, runParamsFor("run-1"))
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			repo.AssertExpectations(t)
+
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, `attachment; filename=metrics`, rr.Header().Get("Content-Disposition"))
+				assert.Equal(t, "{}", rr.Body.String())
+			}
+		})
+	}
+}
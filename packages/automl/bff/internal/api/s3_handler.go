@@ -11,10 +11,12 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/opendatahub-io/automl-library/bff/internal/constants"
 	helper "github.com/opendatahub-io/automl-library/bff/internal/helpers"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
 	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
 	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
@@ -24,8 +26,20 @@ import (
 type s3Repository interface {
 	GetObject(ctx context.Context, req repositories.S3RequestContext, key string) (*repositories.GetObjectResult, error)
 	GetCSVSchema(ctx context.Context, req repositories.S3RequestContext, key string) (helper.CSVSchemaResult, error)
+	GetCSVProfile(ctx context.Context, req repositories.S3RequestContext, key string, sampleRows int) (helper.CSVProfileResult, error)
 	UploadCSVFile(ctx context.Context, req repositories.S3RequestContext, key string, body io.Reader, rawContentType, filename string, maxAttempts int) (string, error)
 	ListObjects(ctx context.Context, req repositories.S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error)
+	CreateCSVUpload(ctx context.Context, req repositories.S3RequestContext, key, rawContentType string, maxAttempts int) (*s3.MultipartUpload, error)
+	UploadPart(ctx context.Context, req repositories.S3RequestContext, key, uploadID string, partNumber int32, body io.ReadSeeker, size int64) (*s3.UploadedPart, error)
+	ListUploadParts(ctx context.Context, req repositories.S3RequestContext, key, uploadID string) ([]s3.UploadedPart, error)
+	CompleteUpload(ctx context.Context, req repositories.S3RequestContext, key, uploadID string, parts []s3.UploadedPart) error
+	AbortUpload(ctx context.Context, req repositories.S3RequestContext, key, uploadID string) error
+	CopyObject(ctx context.Context, req repositories.S3RequestContext, sourceKey, destinationKey string) error
+	MoveObject(ctx context.Context, req repositories.S3RequestContext, sourceKey, destinationKey string) error
+	DeleteObject(ctx context.Context, req repositories.S3RequestContext, key string) error
+	DeletePrefix(ctx context.Context, req repositories.S3RequestContext, prefix, confirmationToken string) (*s3.DeletePrefixResult, error)
+	PresignDownload(ctx context.Context, req repositories.S3RequestContext, key string, expires time.Duration) (*models.S3PresignedURL, error)
+	PresignCSVUpload(ctx context.Context, req repositories.S3RequestContext, key string, expires time.Duration, maxAttempts int) (*models.S3PresignedURL, error)
 }
 
 type S3Handler struct {
@@ -88,65 +102,83 @@ func (h *S3Handler) buildS3Request(w http.ResponseWriter, r *http.Request, secre
 
 // handleS3RepoError classifies errors from S3 repository calls and writes the appropriate HTTP response.
 func (h *S3Handler) handleS3RepoError(w http.ResponseWriter, r *http.Request, err error, key string) {
+	writeS3RepoError(h.logger, w, r, err, key)
+}
+
+// writeS3RepoError maps credential resolution and S3 errors to HTTP responses. It is
+// shared by handlers that read objects through S3Repository.
+func writeS3RepoError(logger *slog.Logger, w http.ResponseWriter, r *http.Request, err error, key string) {
 	switch {
 	case errors.Is(err, kubernetes.ErrNotFound):
-		notFoundResponseWithMessage(h.logger, w, r, err.Error())
+		notFoundResponseWithMessage(logger, w, r, err.Error())
 		return
 	case errors.Is(err, kubernetes.ErrForbidden):
-		forbiddenResponse(h.logger, w, r, err.Error())
+		forbiddenResponse(logger, w, r, err.Error())
 		return
 	case errors.Is(err, kubernetes.ErrUnauthorized):
-		unauthorizedResponse(h.logger, w, r, err.Error())
+		unauthorizedResponse(logger, w, r, err.Error())
 		return
 	}
 
 	if errors.Is(err, pipelines.ErrNoDSPAFound) {
-		notFoundResponseWithMessage(h.logger, w, r, "no Pipeline Server (DSPipelineApplication) found in namespace")
+		notFoundResponseWithMessage(logger, w, r, "no Pipeline Server (DSPipelineApplication) found in namespace")
 		return
 	}
 	if errors.Is(err, pipelines.ErrDSPANotReady) {
-		serviceUnavailableResponseWithMessage(h.logger, w, r, err,
+		serviceUnavailableResponseWithMessage(logger, w, r, err,
 			"Pipeline Server exists but is not ready - check that the APIServer component is running")
 		return
 	}
 
 	if errors.Is(err, s3.ErrObjectNotFound) {
-		notFoundResponseWithMessage(h.logger, w, r, fmt.Sprintf("object %q not found in S3 storage", key))
+		notFoundResponseWithMessage(logger, w, r, fmt.Sprintf("object %q not found in S3 storage", key))
 		return
 	}
 	if errors.Is(err, s3.ErrBucketNotFound) {
-		notFoundResponseWithMessage(h.logger, w, r, "S3 bucket not found")
+		notFoundResponseWithMessage(logger, w, r, "S3 bucket not found")
 		return
 	}
 	if errors.Is(err, s3.ErrAccessDenied) {
 		if key != "" {
-			forbiddenResponse(h.logger, w, r, fmt.Sprintf("access denied to S3 object %q", key))
+			forbiddenResponse(logger, w, r, fmt.Sprintf("access denied to S3 object %q", key))
 		} else {
-			forbiddenResponse(h.logger, w, r, "access denied to S3 bucket")
+			forbiddenResponse(logger, w, r, "access denied to S3 bucket")
 		}
 		return
 	}
 	if errors.Is(err, s3.ErrObjectAlreadyExists) {
-		conflictResponse(h.logger, w, r, fmt.Sprintf("object key %q already exists in S3 (upload conflict); retry with a different key", key))
+		conflictResponse(logger, w, r, fmt.Sprintf("object key %q already exists in S3 (upload conflict); retry with a different key", key))
+		return
+	}
+	if errors.Is(err, s3.ErrUploadNotFound) {
+		notFoundResponseWithMessage(logger, w, r, fmt.Sprintf("multipart upload for %q not found; it may have been completed or aborted", key))
+		return
+	}
+	if errors.Is(err, s3.ErrInvalidConfirmationToken) {
+		conflictResponse(logger, w, r, fmt.Sprintf("%s; request a new confirmation token for %q", err, key))
 		return
 	}
 
 	if errors.Is(err, repositories.ErrDSPAConfiguration) {
-		serviceUnavailableResponseWithMessage(h.logger, w, r, err, err.Error())
+		serviceUnavailableResponseWithMessage(logger, w, r, err, err.Error())
 		return
 	}
 	if errors.Is(err, s3.ErrInvalidKey) ||
+		errors.Is(err, s3.ErrInvalidUploadID) ||
+		errors.Is(err, s3.ErrInvalidPart) ||
+		errors.Is(err, s3.ErrPrefixTooLarge) ||
+		errors.Is(err, s3.ErrInvalidPresignRequest) ||
 		errors.Is(err, kubernetes.ErrAmbiguousSecretKey) ||
 		errors.Is(err, s3.ErrEndpointValidation) ||
 		errors.Is(err, repositories.ErrS3Configuration) ||
 		errors.Is(err, repositories.ErrCSVUploadValidation) ||
 		errors.Is(err, helper.ErrCSVValidation) {
-		badRequestResponse(h.logger, w, r, err.Error())
+		badRequestResponse(logger, w, r, err.Error())
 		return
 	}
 
 	if s3.IsConnectivityError(err) {
-		badGatewayResponseWithMessage(h.logger, w, r, err,
+		badGatewayResponseWithMessage(logger, w, r, err,
 			"Unable to connect to the S3 storage endpoint. "+
 				"The endpoint may be unreachable from this cluster. "+
 				"If this is a disconnected or air-gapped environment, "+
@@ -155,7 +187,7 @@ func (h *S3Handler) handleS3RepoError(w http.ResponseWriter, r *http.Request, er
 		return
 	}
 
-	serverErrorResponse(h.logger, w, r, err)
+	serverErrorResponse(logger, w, r, err)
 }
 
 // GetS3FileHandler retrieves a file from S3 storage.
@@ -187,19 +219,38 @@ func (h *S3Handler) GetS3FileHandler(w http.ResponseWriter, r *http.Request, ps
 		return
 	}
 
-	if v := queryParams.Get("view"); v != "" && v != "schema" {
-		badRequestResponse(h.logger, w, r, fmt.Sprintf("unsupported view: %q (supported: schema)", v))
+	view := queryParams.Get("view")
+	if view != "" && view != "schema" && view != "profile" {
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("unsupported view: %q (supported: schema, profile)", view))
 		return
 	}
 
+	sampleRows := 0
+	if raw := queryParams.Get("sampleRows"); raw != "" {
+		if view != "profile" {
+			badRequestResponse(h.logger, w, r, "query parameter 'sampleRows' is only supported with view=profile")
+			return
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > helper.MaxProfileSampleRows {
+			badRequestResponse(h.logger, w, r, fmt.Sprintf("query parameter 'sampleRows' must be a number between 1 and %d", helper.MaxProfileSampleRows))
+			return
+		}
+		sampleRows = parsed
+	}
+
 	req, ok := h.buildS3Request(w, r, strings.TrimSpace(secretName), queryParams.Get("bucket"))
 	if !ok {
 		return
 	}
 
-	if queryParams.Get("view") == "schema" {
+	switch view {
+	case "schema":
 		h.getS3FileSchemaHandler(w, r, req, key)
 		return
+	case "profile":
+		h.getS3FileProfileHandler(w, r, req, key, sampleRows)
+		return
 	}
 
 	result, err := h.repo.GetObject(r.Context(), req, key)
@@ -240,6 +291,22 @@ func (h *S3Handler) getS3FileSchemaHandler(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// S3FileProfileEnvelope is the response envelope for GET /api/v1/s3/files/:key?view=profile.
+type S3FileProfileEnvelope Envelope[helper.CSVProfileResult, None]
+
+// getS3FileProfileHandler handles the ?view=profile path for GetS3FileHandler.
+func (h *S3Handler) getS3FileProfileHandler(w http.ResponseWriter, r *http.Request, req repositories.S3RequestContext, key string, sampleRows int) {
+	profile, err := h.repo.GetCSVProfile(r.Context(), req, key, sampleRows)
+	if err != nil {
+		h.handleS3RepoError(w, r, err, key)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, S3FileProfileEnvelope{Data: profile}, nil); err != nil {
+		h.logger.Error("error writing JSON response", "error", err)
+	}
+}
+
 func (h *S3Handler) effectivePostS3CollisionAttempts() int {
 	if h != nil && h.maxCollisionAttempts > 0 {
 		return h.maxCollisionAttempts
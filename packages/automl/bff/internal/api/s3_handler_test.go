@@ -215,6 +215,67 @@ func TestGetS3FileHandler(t *testing.T) {
 			wantStatusCode:   http.StatusBadRequest,
 			wantBodyContains: "csv issue",
 		},
+		{
+			name:        "view=profile success returns column profile",
+			namespace:   "test-ns",
+			key:         "data.csv",
+			queryString: "secretName=my-secret&view=profile&sampleRows=500",
+			setupMock: func(repo *mockS3Repo) {
+				label := "churned"
+				repo.On("GetCSVProfile", mock.Anything, mock.Anything, "data.csv", 500).
+					Return(helper.CSVProfileResult{
+						RowsSampled: 500,
+						Columns: []helper.ColumnProfile{
+							{Name: "churned", Type: "bool", TaskType: "binary", UniqueCount: 2},
+						},
+						Warnings:    []helper.ProfileWarning{},
+						Suggestions: helper.ProfileSuggestions{LabelColumn: &label},
+					}, nil)
+			},
+			wantStatusCode:   http.StatusOK,
+			wantBodyContains: `"label_column": "churned"`,
+		},
+		{
+			name:        "view=profile uses default sample size",
+			namespace:   "test-ns",
+			key:         "data.csv",
+			queryString: "secretName=my-secret&view=profile",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("GetCSVProfile", mock.Anything, mock.Anything, "data.csv", 0).
+					Return(helper.CSVProfileResult{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:             "view=profile rejects out-of-range sampleRows",
+			namespace:        "test-ns",
+			key:              "data.csv",
+			queryString:      "secretName=my-secret&view=profile&sampleRows=0",
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "sampleRows",
+		},
+		{
+			name:             "sampleRows without view=profile returns 400",
+			namespace:        "test-ns",
+			key:              "data.csv",
+			queryString:      "secretName=my-secret&sampleRows=10",
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "only supported with view=profile",
+		},
+		{
+			name:        "view=profile repo error returns appropriate error",
+			namespace:   "test-ns",
+			key:         "data.parquet",
+			queryString: "secretName=my-secret&view=profile",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("GetCSVProfile", mock.Anything, mock.Anything, "data.parquet", 0).
+					Return(helper.CSVProfileResult{}, fmt.Errorf("%w: only CSV files are supported", repositories.ErrCSVUploadValidation))
+			},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "only CSV files",
+		},
 		{
 			name:        "force download sets Content-Disposition",
 			namespace:   "test-ns",
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
+	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+// Resumable uploads let the browser send files larger than the single POST limit as S3
+// multipart uploads: it creates an upload, PUTs parts of up to 32 MiB (in parallel and
+// retrying failed parts), and completes the upload. After a network drop it lists the
+// stored parts and uploads only the missing ones. Every call after create identifies
+// the upload by the key and uploadId query parameters returned by create; keys contain
+// "/" and are therefore not passed as path parameters.
+
+type S3UploadEnvelope Envelope[*s3.MultipartUpload, None]
+type S3UploadPartEnvelope Envelope[*s3.UploadedPart, None]
+type S3UploadPartsEnvelope Envelope[models.S3UploadParts, None]
+
+// s3UploadPartTooLargeMsg is the error message when an upload part exceeds the maximum part size.
+const s3UploadPartTooLargeMsg = "upload part exceeds maximum size of 32 MiB"
+
+// s3UploadTarget identifies an in-progress upload from the request's query parameters.
+type s3UploadTarget struct {
+	req      repositories.S3RequestContext
+	key      string
+	uploadID string
+}
+
+// parseS3SecretRequest validates the required secretName query parameter and builds the
+// S3RequestContext. Resumable uploads, like POST /s3/files, have no DSPA fallback.
+func (h *S3Handler) parseS3SecretRequest(w http.ResponseWriter, r *http.Request) (repositories.S3RequestContext, bool) {
+	queryParams := r.URL.Query()
+	secretName := queryParams.Get("secretName")
+	if secretName == "" {
+		badRequestResponse(h.logger, w, r, "query parameter 'secretName' is required and cannot be empty")
+		return repositories.S3RequestContext{}, false
+	}
+	if err := kubernetes.ValidateResourceName("secretName", secretName); err != nil {
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("invalid secretName: %s", err))
+		return repositories.S3RequestContext{}, false
+	}
+	return h.buildS3Request(w, r, secretName, queryParams.Get("bucket"))
+}
+
+// parseS3UploadTarget reads secretName, bucket, key and uploadId from the query string.
+func (h *S3Handler) parseS3UploadTarget(w http.ResponseWriter, r *http.Request) (s3UploadTarget, bool) {
+	queryParams := r.URL.Query()
+	key := queryParams.Get("key")
+	if key == "" {
+		badRequestResponse(h.logger, w, r, "query parameter 'key' is required and cannot be empty")
+		return s3UploadTarget{}, false
+	}
+	uploadID := queryParams.Get("uploadId")
+	if uploadID == "" {
+		badRequestResponse(h.logger, w, r, "query parameter 'uploadId' is required and cannot be empty")
+		return s3UploadTarget{}, false
+	}
+	req, ok := h.parseS3SecretRequest(w, r)
+	if !ok {
+		return s3UploadTarget{}, false
+	}
+	return s3UploadTarget{req: req, key: key, uploadID: uploadID}, true
+}
+
+// CreateS3UploadHandler starts a resumable CSV upload.
+// Query parameters: namespace, secretName (required); bucket (optional).
+// Request body: {"key": "...", "content_type": "text/csv"}. content_type may be omitted
+// when key ends in .csv. The key is suffixed (-1, -2, …) if an object already exists.
+// Response: 201 with the resolved key and upload ID.
+func (h *S3Handler) CreateS3UploadHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	req, ok := h.parseS3SecretRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var body models.CreateS3UploadRequest
+	if err := readJSON(w, r, &body); err != nil {
+		badRequestResponse(h.logger, w, r, err.Error())
+		return
+	}
+	if body.Key == "" {
+		badRequestResponse(h.logger, w, r, "field 'key' is required and cannot be empty")
+		return
+	}
+
+	upload, err := h.repo.CreateCSVUpload(r.Context(), req, body.Key, body.ContentType, h.effectivePostS3CollisionAttempts())
+	if err != nil {
+		if errors.Is(err, s3.ErrMaxCollisionsExceeded) {
+			conflictResponse(h.logger, w, r,
+				fmt.Sprintf("unable to find unique filename (%s); try a different base name", err))
+			return
+		}
+		h.handleS3RepoError(w, r, err, body.Key)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusCreated, S3UploadEnvelope{Data: upload}, nil); err != nil {
+		h.logger.Error("failed to write upload response", "error", err, "key", upload.Key)
+	}
+}
+
+// PutS3UploadPartHandler uploads one part of a resumable upload.
+// Path parameters: partNumber (1-10000).
+// Query parameters: namespace, secretName, key, uploadId (required); bucket (optional).
+// Request body: the raw part bytes with a Content-Length of at most 32 MiB. Every part
+// except the last must be at least 5 MiB. Re-sending a part number replaces that part.
+func (h *S3Handler) PutS3UploadPartHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	target, ok := h.parseS3UploadTarget(w, r)
+	if !ok {
+		return
+	}
+
+	partNumber, err := strconv.ParseInt(ps.ByName("partNumber"), 10, 32)
+	if err != nil || partNumber < 1 || partNumber > s3.MaxMultipartParts {
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("path parameter 'partNumber' must be an integer between 1 and %d", s3.MaxMultipartParts))
+		return
+	}
+
+	if r.ContentLength <= 0 {
+		badRequestResponse(h.logger, w, r, "Content-Length header with a positive part size is required")
+		return
+	}
+	if r.ContentLength > h.effectiveFilePartMaxBytes() {
+		payloadTooLargeResponse(h.logger, w, r, s3UploadPartTooLargeMsg)
+		return
+	}
+
+	// Buffer the part so the SDK can checksum and, on retries, rewind the body.
+	data := make([]byte, r.ContentLength)
+	if _, err := io.ReadFull(http.MaxBytesReader(w, r.Body, r.ContentLength), data); err != nil {
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("reading upload part: %s", err))
+		return
+	}
+
+	part, err := h.repo.UploadPart(r.Context(), target.req, target.key, target.uploadID, int32(partNumber), bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		h.handleS3RepoError(w, r, err, target.key)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, S3UploadPartEnvelope{Data: part}, nil); err != nil {
+		h.logger.Error("failed to write upload part response", "error", err, "key", target.key)
+	}
+}
+
+// ListS3UploadPartsHandler lists the parts stored for a resumable upload so that an
+// interrupted upload can be resumed.
+// Query parameters: namespace, secretName, key, uploadId (required); bucket (optional).
+func (h *S3Handler) ListS3UploadPartsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	target, ok := h.parseS3UploadTarget(w, r)
+	if !ok {
+		return
+	}
+
+	parts, err := h.repo.ListUploadParts(r.Context(), target.req, target.key, target.uploadID)
+	if err != nil {
+		h.handleS3RepoError(w, r, err, target.key)
+		return
+	}
+
+	resp := S3UploadPartsEnvelope{Data: models.S3UploadParts{Key: target.key, UploadID: target.uploadID, Parts: parts}}
+	if err := writeJSON(w, http.StatusOK, resp, nil); err != nil {
+		h.logger.Error("failed to write upload parts response", "error", err, "key", target.key)
+	}
+}
+
+// CompleteS3UploadHandler assembles the uploaded parts into the object.
+// Query parameters: namespace, secretName, key, uploadId (required); bucket (optional).
+// Request body (optional): {"parts": [{"part_number": 1, "etag": "..."}]}. Without parts,
+// every stored part is used. Returns 409 if an object was created at the key meanwhile.
+func (h *S3Handler) CompleteS3UploadHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	target, ok := h.parseS3UploadTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var body models.CompleteS3UploadRequest
+	if r.ContentLength != 0 {
+		if err := readJSON(w, r, &body); err != nil {
+			badRequestResponse(h.logger, w, r, err.Error())
+			return
+		}
+	}
+
+	if err := h.repo.CompleteUpload(r.Context(), target.req, target.key, target.uploadID, body.Parts); err != nil {
+		h.handleS3RepoError(w, r, err, target.key)
+		return
+	}
+
+	resp := map[string]any{
+		"uploaded": true,
+		"key":      target.key,
+	}
+	if err := writeJSON(w, http.StatusCreated, resp, nil); err != nil {
+		h.logger.Error("failed to write upload response", "error", err, "key", target.key)
+	}
+}
+
+// AbortS3UploadHandler cancels a resumable upload and discards its stored parts.
+// Query parameters: namespace, secretName, key, uploadId (required); bucket (optional).
+func (h *S3Handler) AbortS3UploadHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	target, ok := h.parseS3UploadTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.AbortUpload(r.Context(), target.req, target.key, target.uploadID); err != nil {
+		h.handleS3RepoError(w, r, err, target.key)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/automl-library/bff/internal/repositories"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newS3UploadRequest creates a request for the resumable upload handlers with namespace
+// in context. The query string is set directly on the URL.
+func newS3UploadRequest(method, queryString, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/s3/uploads", strings.NewReader(body))
+	req.URL.RawQuery = queryString
+	return req.WithContext(ctxWithNamespace("test-ns"))
+}
+
+const s3UploadQuery = "secretName=my-secret&key=data%2Fbig.csv&uploadId=upload-1"
+
+func TestCreateS3UploadHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		queryString      string
+		body             string
+		setupMock        func(repo *mockS3Repo)
+		wantStatusCode   int
+		wantBodyContains string
+	}{
+		{
+			name:        "success returns upload id",
+			queryString: "secretName=my-secret",
+			body:        `{"key":"data/big.csv","content_type":"text/csv"}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("CreateCSVUpload", mock.Anything, repositories.S3RequestContext{Namespace: "test-ns", SecretName: "my-secret"}, "data/big.csv", "text/csv", 0).
+					Return(&s3.MultipartUpload{Key: "data/big-1.csv", UploadID: "upload-1"}, nil)
+			},
+			wantStatusCode:   http.StatusCreated,
+			wantBodyContains: `"upload_id": "upload-1"`,
+		},
+		{
+			name:             "missing secretName returns 400",
+			body:             `{"key":"data/big.csv"}`,
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "secretName",
+		},
+		{
+			name:             "missing key returns 400",
+			queryString:      "secretName=my-secret",
+			body:             `{"content_type":"text/csv"}`,
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "key",
+		},
+		{
+			name:             "unknown field returns 400",
+			queryString:      "secretName=my-secret",
+			body:             `{"key":"data/big.csv","size":10}`,
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "unknown key",
+		},
+		{
+			name:        "collision cap returns 409",
+			queryString: "secretName=my-secret",
+			body:        `{"key":"data/big.csv"}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("CreateCSVUpload", mock.Anything, mock.Anything, "data/big.csv", "", 0).
+					Return(nil, s3.ErrMaxCollisionsExceeded)
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockS3Repo)
+			tt.setupMock(repo)
+			handler := &S3Handler{logger: silentLogger(), repo: repo}
+
+			rr := httptest.NewRecorder()
+			handler.CreateS3UploadHandler(rr, newS3UploadRequest(http.MethodPost, tt.queryString, tt.body), nil)
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			if tt.wantBodyContains != "" {
+				assert.Contains(t, rr.Body.String(), tt.wantBodyContains)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPutS3UploadPartHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		queryString      string
+		partNumber       string
+		body             string
+		maxPartBytes     int64
+		setupMock        func(repo *mockS3Repo)
+		wantStatusCode   int
+		wantBodyContains string
+	}{
+		{
+			name:        "success returns etag",
+			queryString: s3UploadQuery,
+			partNumber:  "3",
+			body:        "a,b\n1,2\n",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("UploadPart", mock.Anything, mock.Anything, "data/big.csv", "upload-1", int32(3), mock.Anything, int64(8)).
+					Return(&s3.UploadedPart{PartNumber: 3, ETag: `"etag-3"`, Size: 8}, nil)
+			},
+			wantStatusCode:   http.StatusOK,
+			wantBodyContains: `"part_number": 3`,
+		},
+		{
+			name:             "missing uploadId returns 400",
+			queryString:      "secretName=my-secret&key=data%2Fbig.csv",
+			partNumber:       "1",
+			body:             "x",
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "uploadId",
+		},
+		{
+			name:             "part number out of range returns 400",
+			queryString:      s3UploadQuery,
+			partNumber:       "10001",
+			body:             "x",
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "partNumber",
+		},
+		{
+			name:             "empty part returns 400",
+			queryString:      s3UploadQuery,
+			partNumber:       "1",
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "Content-Length",
+		},
+		{
+			name:           "oversized part returns 413",
+			queryString:    s3UploadQuery,
+			partNumber:     "1",
+			body:           "0123456789",
+			maxPartBytes:   4,
+			setupMock:      func(repo *mockS3Repo) {},
+			wantStatusCode: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "unknown upload returns 404",
+			queryString: s3UploadQuery,
+			partNumber:  "1",
+			body:        "x",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("UploadPart", mock.Anything, mock.Anything, "data/big.csv", "upload-1", int32(1), mock.Anything, int64(1)).
+					Return(nil, s3.ErrUploadNotFound)
+			},
+			wantStatusCode:   http.StatusNotFound,
+			wantBodyContains: "multipart upload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockS3Repo)
+			tt.setupMock(repo)
+			handler := &S3Handler{logger: silentLogger(), repo: repo, maxFilePartBytes: tt.maxPartBytes}
+
+			rr := httptest.NewRecorder()
+			ps := httprouter.Params{{Key: "partNumber", Value: tt.partNumber}}
+			handler.PutS3UploadPartHandler(rr, newS3UploadRequest(http.MethodPut, tt.queryString, tt.body), ps)
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			if tt.wantBodyContains != "" {
+				assert.Contains(t, rr.Body.String(), tt.wantBodyContains)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListS3UploadPartsHandler(t *testing.T) {
+	repo := new(mockS3Repo)
+	repo.On("ListUploadParts", mock.Anything, mock.Anything, "data/big.csv", "upload-1").
+		Return([]s3.UploadedPart{{PartNumber: 1, ETag: `"e1"`, Size: 5 << 20}}, nil)
+	handler := &S3Handler{logger: silentLogger(), repo: repo}
+
+	rr := httptest.NewRecorder()
+	handler.ListS3UploadPartsHandler(rr, newS3UploadRequest(http.MethodGet, s3UploadQuery, ""), nil)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"upload_id": "upload-1"`)
+	assert.Contains(t, rr.Body.String(), `"size": 5242880`)
+	repo.AssertExpectations(t)
+}
+
+func TestCompleteS3UploadHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(repo *mockS3Repo)
+		wantStatusCode int
+	}{
+		{
+			name: "empty body completes with stored parts",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("CompleteUpload", mock.Anything, mock.Anything, "data/big.csv", "upload-1", []s3.UploadedPart(nil)).Return(nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name: "explicit parts are passed through",
+			body: `{"parts":[{"part_number":1,"etag":"\"e1\""}]}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("CompleteUpload", mock.Anything, mock.Anything, "data/big.csv", "upload-1", []s3.UploadedPart{{PartNumber: 1, ETag: `"e1"`}}).Return(nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name: "existing object returns 409",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("CompleteUpload", mock.Anything, mock.Anything, "data/big.csv", "upload-1", []s3.UploadedPart(nil)).Return(s3.ErrObjectAlreadyExists)
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name: "rejected part returns 400",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("CompleteUpload", mock.Anything, mock.Anything, "data/big.csv", "upload-1", []s3.UploadedPart(nil)).Return(s3.ErrInvalidPart)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockS3Repo)
+			tt.setupMock(repo)
+			handler := &S3Handler{logger: silentLogger(), repo: repo}
+
+			rr := httptest.NewRecorder()
+			handler.CompleteS3UploadHandler(rr, newS3UploadRequest(http.MethodPost, s3UploadQuery, tt.body), nil)
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAbortS3UploadHandler(t *testing.T) {
+	repo := new(mockS3Repo)
+	repo.On("AbortUpload", mock.Anything, mock.Anything, "data/big.csv", "upload-1").Return(nil)
+	handler := &S3Handler{logger: silentLogger(), repo: repo}
+
+	rr := httptest.NewRecorder()
+	handler.AbortS3UploadHandler(rr, newS3UploadRequest(http.MethodDelete, s3UploadQuery, ""), nil)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	repo.AssertExpectations(t)
+}
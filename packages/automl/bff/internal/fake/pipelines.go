@@ -65,14 +65,18 @@ const (
 // It holds pipeline runs in memory and simulates state progression so the
 // automl UI can be tested end-to-end without a live cluster.
 type PipelinesClient struct {
-	mu   sync.Mutex
-	runs map[string]*plsvc.PipelineRun
+	mu            sync.Mutex
+	runs          map[string]*plsvc.PipelineRun
+	recurringRuns map[string]*plsvc.RecurringRun
 }
 
 var _ plsvc.Client = (*PipelinesClient)(nil)
 
 func NewPipelinesClient() *PipelinesClient {
-	c := &PipelinesClient{runs: make(map[string]*plsvc.PipelineRun)}
+	c := &PipelinesClient{
+		runs:          make(map[string]*plsvc.PipelineRun),
+		recurringRuns: make(map[string]*plsvc.RecurringRun),
+	}
 	c.seedRuns()
 	return c
 }
@@ -191,6 +195,52 @@ func (c *PipelinesClient) DeleteRun(_ context.Context, _ string, runID string) e
 	return nil
 }
 
+// CreateRecurringRun stores the recurring run; the fake never starts runs from it.
+func (c *PipelinesClient) CreateRecurringRun(_ context.Context, _ string, input *plsvc.CreateRecurringRunInput) (*plsvc.RecurringRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now().UTC().Format(time.RFC3339)
+	rr := &plsvc.RecurringRun{
+		RecurringRunID:           uuid.NewString(),
+		DisplayName:              input.DisplayName,
+		Description:              input.Description,
+		PipelineVersionReference: input.PipelineVersionReference,
+		RuntimeConfig:            input.RuntimeConfig,
+		Trigger:                  input.Trigger,
+		Mode:                     input.Mode,
+		MaxConcurrency:           input.MaxConcurrency,
+		NoCatchup:                input.NoCatchup,
+		Status:                   "ENABLED",
+		CreatedAt:                now,
+		UpdatedAt:                now,
+		ExperimentID:             defaultExperimentID,
+	}
+	c.recurringRuns[rr.RecurringRunID] = rr
+	out := *rr
+	return &out, nil
+}
+
+func (c *PipelinesClient) GetRecurringRun(_ context.Context, _ string, recurringRunID string) (*plsvc.RecurringRun, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rr, ok := c.recurringRuns[recurringRunID]
+	if !ok {
+		return nil, fmt.Errorf("%w: recurring run %q not found", plsvc.ErrPipelineNotFound, recurringRunID)
+	}
+	out := *rr
+	return &out, nil
+}
+
+func (c *PipelinesClient) DeleteRecurringRun(_ context.Context, _ string, recurringRunID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.recurringRuns[recurringRunID]; !ok {
+		return fmt.Errorf("%w: recurring run %q not found", plsvc.ErrPipelineNotFound, recurringRunID)
+	}
+	delete(c.recurringRuns, recurringRunID)
+	return nil
+}
+
 func (c *PipelinesClient) ListPipelines(_ context.Context, _ string, _ string) (*plsvc.PipelinesResponse, error) {
 	return &plsvc.PipelinesResponse{
 		Pipelines: []plsvc.Pipeline{
@@ -255,7 +305,7 @@ func (c *PipelinesClient) progressRun(runID string) {
 
 	for _, name := range taskNames {
 		run.RunDetails.TaskDetails = append(run.RunDetails.TaskDetails, plsvc.TaskDetail{
-			RunID: runID, TaskID: uuid.New().String(), DisplayName: name,
+			RunID: runID, TaskID: uuid.New().String(), DisplayName: name, ChildTasks: fakeTaskPods(runID, name),
 			State: "PENDING", CreateTime: run.CreatedAt, StartTime: now,
 			StateHistory: []plsvc.RuntimeStatus{{UpdateTime: now, State: "PENDING"}},
 		})
@@ -403,6 +453,7 @@ func buildSeedRunDetails(runID string, state plsvc.RunState, createdAt, finished
 			CreateTime:  createdAt,
 			StartTime:   createdAt,
 			EndTime:     finishedAt,
+			ChildTasks:  fakeTaskPods(runID, name),
 			StateHistory: []plsvc.RuntimeStatus{
 				{UpdateTime: createdAt, State: "RUNNING"},
 				{UpdateTime: finishedAt, State: state},
@@ -411,3 +462,10 @@ func buildSeedRunDetails(runID string, state plsvc.RunState, createdAt, finished
 	}
 	return &plsvc.RunDetails{TaskDetails: details}
 }
+
+// fakeTaskPods names the executor pod of a task; the fake K8s client serves canned logs
+// for any pod name.
+func fakeTaskPods(runID, taskName string) []plsvc.ChildTask {
+	prefix, _, _ := strings.Cut(runID, "-")
+	return []plsvc.ChildTask{{PodName: taskName + "-" + prefix + "-system-container-impl"}}
+}
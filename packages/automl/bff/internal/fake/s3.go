@@ -23,6 +23,10 @@ type S3Client struct {
 	mu           sync.Mutex
 	rootDir      string
 	resolvedRoot string
+	// uploads holds in-progress multipart uploads by upload ID (see s3_multipart.go).
+	uploads map[string]*multipartUpload
+	// seedFiles holds the paths of read-only seed files (see s3_objects.go).
+	seedFiles map[string]bool
 }
 
 var _ s3svc.Client = (*S3Client)(nil)
@@ -108,8 +112,9 @@ func NewS3Client() *S3Client {
 	if err != nil {
 		resolved = root
 	}
-	c := &S3Client{rootDir: root, resolvedRoot: resolved}
+	c := &S3Client{rootDir: root, resolvedRoot: resolved, uploads: map[string]*multipartUpload{}, seedFiles: map[string]bool{}}
 	c.cleanNonSeedData()
+	c.recordSeedFiles()
 	return c
 }
 
@@ -281,6 +286,10 @@ func (c *S3Client) ObjectExists(_ context.Context, _ s3svc.ConnectionOptions, in
 }
 
 func (c *S3Client) ListObjects(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.ListObjectsInput) (*s3svc.ListObjectsResponse, error) {
+	if input.Delimiter == "" {
+		return c.listAllObjects(input)
+	}
+
 	originalPrefix := input.Prefix
 	diskPrefix := rewriteToSeedRun(originalPrefix)
 
@@ -0,0 +1,145 @@
+package fake
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // ETags only, matching S3
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	s3svc "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+// multipartUpload is an in-progress upload. Parts are kept in memory until the upload
+// is completed, when they are concatenated into a file under s3-bucket/.
+type multipartUpload struct {
+	key   string
+	parts map[int32][]byte
+}
+
+// maxMultipartUploadBytes caps the total size of one fake multipart upload.
+const maxMultipartUploadBytes = 256 << 20 // 256 MiB
+
+func partETag(data []byte) string {
+	sum := md5.Sum(data) //nolint:gosec // ETags only, matching S3
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// lookupUpload returns the upload for uploadID if it was created for key. Callers hold c.mu.
+func (c *S3Client) lookupUpload(key, uploadID string) (*multipartUpload, error) {
+	upload, ok := c.uploads[uploadID]
+	if !ok || upload.key != key {
+		return nil, s3svc.ErrUploadNotFound
+	}
+	return upload, nil
+}
+
+func (c *S3Client) CreateMultipartUpload(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.CreateMultipartUploadInput) (*s3svc.MultipartUpload, error) {
+	if _, err := c.safePath(input.Key); err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	uploadID := hex.EncodeToString(id)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.uploads[uploadID] = &multipartUpload{key: input.Key, parts: map[int32][]byte{}}
+	return &s3svc.MultipartUpload{Key: input.Key, UploadID: uploadID}, nil
+}
+
+func (c *S3Client) UploadPart(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.UploadPartInput) (*s3svc.UploadedPart, error) {
+	data, err := io.ReadAll(io.LimitReader(input.Body, maxMultipartUploadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	upload, err := c.lookupUpload(input.Key, input.UploadID)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	for n, part := range upload.parts {
+		if n != input.PartNumber {
+			total += int64(len(part))
+		}
+	}
+	if total+int64(len(data)) > maxMultipartUploadBytes {
+		return nil, fmt.Errorf("upload exceeds %d byte limit", maxMultipartUploadBytes)
+	}
+	upload.parts[input.PartNumber] = data
+	return &s3svc.UploadedPart{PartNumber: input.PartNumber, ETag: partETag(data), Size: int64(len(data))}, nil
+}
+
+func (c *S3Client) ListParts(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.ListPartsInput) ([]s3svc.UploadedPart, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	upload, err := c.lookupUpload(input.Key, input.UploadID)
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]s3svc.UploadedPart, 0, len(upload.parts))
+	for n, data := range upload.parts {
+		parts = append(parts, s3svc.UploadedPart{PartNumber: n, ETag: partETag(data), Size: int64(len(data))})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, nil
+}
+
+func (c *S3Client) CompleteMultipartUpload(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.CompleteMultipartUploadInput) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	upload, err := c.lookupUpload(input.Key, input.UploadID)
+	if err != nil {
+		return err
+	}
+
+	var object []byte
+	for i, part := range input.Parts {
+		data, ok := upload.parts[part.PartNumber]
+		if !ok || partETag(data) != part.ETag {
+			return fmt.Errorf("%w: part %d does not match a stored part", s3svc.ErrInvalidPart, part.PartNumber)
+		}
+		if i < len(input.Parts)-1 && int64(len(data)) < s3svc.MinMultipartPartSize {
+			return fmt.Errorf("%w: part %d is smaller than the minimum part size", s3svc.ErrInvalidPart, part.PartNumber)
+		}
+		object = append(object, data...)
+	}
+
+	path, err := c.safePath(input.Key)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return s3svc.ErrObjectAlreadyExists
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := c.resolveAndVerify(path); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, object, 0o644); err != nil {
+		return err
+	}
+	delete(c.uploads, input.UploadID)
+	return nil
+}
+
+func (c *S3Client) AbortMultipartUpload(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.AbortMultipartUploadInput) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.lookupUpload(input.Key, input.UploadID); err != nil {
+		return err
+	}
+	delete(c.uploads, input.UploadID)
+	return nil
+}
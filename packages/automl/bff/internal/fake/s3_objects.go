@@ -0,0 +1,168 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	s3svc "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+// Files found under the seed directories when the client starts ship with the repo.
+// They are read-only so that mock deletes and moves never modify the source tree.
+var errSeedDataReadOnly = fmt.Errorf("%w: seed data is read-only in the mock S3 bucket", s3svc.ErrAccessDenied)
+
+// recordSeedFiles remembers every file under the seed directories.
+func (c *S3Client) recordSeedFiles() {
+	for dir := range seedDirs {
+		_ = filepath.WalkDir(filepath.Join(c.rootDir, dir), func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				c.seedFiles[path] = true
+			}
+			return nil
+		})
+	}
+}
+
+func (c *S3Client) CopyObject(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.CopyObjectInput) error {
+	src, err := c.safePath(input.SourceKey)
+	if err != nil {
+		return err
+	}
+	dst, err := c.safePath(input.DestinationKey)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.resolveAndVerify(src); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s3svc.ErrObjectNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return s3svc.ErrObjectAlreadyExists
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := c.resolveAndVerify(dst); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o644)
+}
+
+func (c *S3Client) DeleteObject(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.DeleteObjectInput) error {
+	path, err := c.safePath(input.Key)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.deleteFile(path)
+}
+
+func (c *S3Client) DeleteObjects(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.DeleteObjectsInput) (*s3svc.DeleteObjectsResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := &s3svc.DeleteObjectsResult{}
+	for _, key := range input.Keys {
+		path, err := c.safePath(key)
+		if err == nil {
+			err = c.deleteFile(path)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, s3svc.DeleteObjectError{Key: key, Code: "AccessDenied", Message: err.Error()})
+			continue
+		}
+		result.Deleted++
+	}
+	return result, nil
+}
+
+// deleteFile removes a file like S3 DeleteObject: missing keys are not an error.
+// Callers hold c.mu.
+func (c *S3Client) deleteFile(path string) error {
+	if c.seedFiles[path] {
+		return errSeedDataReadOnly
+	}
+	if err := c.resolveAndVerify(path); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// PresignObject returns a URL in the shape of a presigned S3 URL. The mock bucket only
+// exists inside this process, so the URL cannot be fetched.
+func (c *S3Client) PresignObject(_ context.Context, _ s3svc.ConnectionOptions, input s3svc.PresignInput) (*s3svc.PresignedURL, error) {
+	if _, err := c.safePath(input.Key); err != nil {
+		return nil, err
+	}
+	presigned := &s3svc.PresignedURL{
+		URL: fmt.Sprintf("http://localhost:9000/%s/%s?X-Amz-Expires=%d&X-Amz-Signature=mock",
+			url.PathEscape(input.Bucket), strings.ReplaceAll(url.PathEscape(input.Key), "%2F", "/"), int(input.Expires.Seconds())),
+		Method:    input.Method,
+		ExpiresAt: time.Now().Add(input.Expires).UTC(),
+	}
+	if input.Method == "PUT" {
+		presigned.Headers = map[string]string{"If-None-Match": "*"}
+	}
+	return presigned, nil
+}
+
+// listAllObjects serves listings without a delimiter, which return every key under the
+// prefix. Prefix deletes use them.
+func (c *S3Client) listAllObjects(input s3svc.ListObjectsInput) (*s3svc.ListObjectsResponse, error) {
+	root, err := c.safePath(input.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("rejected prefix %q: %w", input.Prefix, err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	contents := []s3svc.ObjectInfo{}
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() == ".gitignore" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		contents = append(contents, s3svc.ObjectInfo{
+			Key:          input.Prefix + filepath.ToSlash(rel),
+			LastModified: info.ModTime().UTC().Format(time.RFC3339),
+			Size:         info.Size(),
+			StorageClass: "STANDARD",
+		})
+		return nil
+	})
+
+	return &s3svc.ListObjectsResponse{
+		CommonPrefixes: []s3svc.CommonPrefix{},
+		Contents:       contents,
+		KeyCount:       int32(len(contents)),
+		MaxKeys:        input.Limit,
+		Name:           input.Bucket,
+		Prefix:         input.Prefix,
+	}, nil
+}
@@ -0,0 +1,555 @@
+package helper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultProfileSampleRows is the number of data rows profiled when the caller does
+// not request a specific sample size.
+const DefaultProfileSampleRows = 10000
+
+// MaxProfileSampleRows is the upper bound for a caller-requested sample size.
+const MaxProfileSampleRows = 50000
+
+// maxProfileTrackedValues caps the number of distinct values counted per column so a
+// high-cardinality column cannot grow memory without bound. Once reached, unique_count
+// is a lower bound and top values only reflect values seen before the cap.
+const maxProfileTrackedValues = 10000
+
+// maxProfileTopValues is the number of most frequent values reported per column.
+const maxProfileTopValues = 5
+
+const (
+	// highMissingRatio is the share of empty values at which a column is flagged.
+	highMissingRatio = 0.5
+	// imbalancedMinorityRatio is the minority-class share below which a suggested
+	// label column is flagged as imbalanced.
+	imbalancedMinorityRatio = 0.1
+	// highCardinalityRatio is the distinct/present ratio above which a text column
+	// that is not an identifier is flagged.
+	highCardinalityRatio = 0.5
+)
+
+// Profile warning codes. Column is empty for file-level warnings.
+const (
+	ProfileWarningTooFewRows         = "too_few_rows"
+	ProfileWarningInvalidColumnName  = "invalid_column_name"
+	ProfileWarningDuplicateColumn    = "duplicate_column_name"
+	ProfileWarningEmptyColumn        = "empty_column"
+	ProfileWarningHighMissing        = "high_missing_ratio"
+	ProfileWarningConstantColumn     = "constant_column"
+	ProfileWarningLikelyIdentifier   = "likely_identifier"
+	ProfileWarningHighCardinality    = "high_cardinality"
+	ProfileWarningClassImbalance     = "class_imbalance"
+	ProfileWarningUniqueCountLimited = "unique_count_limited"
+)
+
+var (
+	labelNameHints     = []string{"label", "target", "class", "outcome", "y"}
+	timestampNameHints = []string{"timestamp", "datetime", "date", "time", "ts"}
+	idNameHints        = []string{"id", "uuid", "key"}
+)
+
+// CSVProfileOptions controls ProfileCSV.
+type CSVProfileOptions struct {
+	// MaxRows is the number of data rows to sample (0 = DefaultProfileSampleRows).
+	MaxRows int
+	// MaxBytes is the number of bytes available from the reader, e.g. the size of an
+	// HTTP range (0 = unlimited). When the reader is exhausted at this limit the last,
+	// possibly cut-off row is discarded and the sample is reported as truncated.
+	MaxBytes int64
+	// ColumnNameError reports why a column name cannot be passed to a pipeline. Such
+	// columns are never suggested and are reported with an invalid_column_name warning.
+	ColumnNameError func(name string) error
+}
+
+// ValueCount is a column value with its number of occurrences in the sample.
+type ValueCount struct {
+	Value interface{} `json:"value"`
+	Count int         `json:"count"`
+}
+
+// ColumnProfile holds per-column statistics computed over the sampled rows.
+type ColumnProfile struct {
+	Name         string  `json:"name"`
+	Type         string  `json:"type"`
+	TaskType     string  `json:"task_type"`
+	MissingCount int     `json:"missing_count"`
+	MissingRatio float64 `json:"missing_ratio"`
+	UniqueCount  int     `json:"unique_count"`
+	// UniqueCountLimited is true when the column had more distinct values than are
+	// tracked; UniqueCount is then a lower bound.
+	UniqueCountLimited bool         `json:"unique_count_limited,omitempty"`
+	Min                *float64     `json:"min,omitempty"`
+	Max                *float64     `json:"max,omitempty"`
+	Mean               *float64     `json:"mean,omitempty"`
+	StdDev             *float64     `json:"std_dev,omitempty"`
+	TopValues          []ValueCount `json:"top_values,omitempty"`
+	// MinorityClassRatio is the share of the least frequent value for binary and
+	// multiclass columns with at most 10 distinct values.
+	MinorityClassRatio *float64 `json:"minority_class_ratio,omitempty"`
+	LikelyID           bool     `json:"likely_id"`
+	LikelyTimestamp    bool     `json:"likely_timestamp"`
+	Constant           bool     `json:"constant"`
+}
+
+// ProfileWarning describes a data quality issue found while profiling.
+type ProfileWarning struct {
+	Code    string `json:"code"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProfileSuggestions are suggested run parameters. A field is nil when no column
+// qualifies.
+type ProfileSuggestions struct {
+	LabelColumn     *string `json:"label_column,omitempty"`
+	Target          *string `json:"target,omitempty"`
+	TimestampColumn *string `json:"timestamp_column,omitempty"`
+}
+
+// CSVProfileResult is the outcome of ProfileCSV.
+type CSVProfileResult struct {
+	RowsSampled     int                `json:"rows_sampled"`
+	SampleTruncated bool               `json:"sample_truncated"`
+	ParseWarnings   int                `json:"parse_warnings"`
+	Columns         []ColumnProfile    `json:"columns"`
+	Warnings        []ProfileWarning   `json:"warnings"`
+	Suggestions     ProfileSuggestions `json:"suggestions"`
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// columnAccumulator collects streaming statistics for one column.
+type columnAccumulator struct {
+	colType string
+	present int
+	missing int
+
+	counts  map[string]int
+	rawByID map[string]string
+	order   []string
+	limited bool
+
+	numericCount int
+	mean, m2     float64
+	min, max     float64
+}
+
+func newColumnAccumulator() *columnAccumulator {
+	return &columnAccumulator{
+		colType: "bool",
+		counts:  make(map[string]int),
+		rawByID: make(map[string]string),
+	}
+}
+
+func (a *columnAccumulator) add(raw string) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		a.missing++
+		return
+	}
+	a.present++
+	if a.colType != "string" {
+		a.colType = advanceColumnType(a.colType, value)
+	}
+
+	key := value
+	if num, err := parseFiniteFloat(value); err == nil {
+		key = strconv.FormatFloat(num, 'g', -1, 64)
+		a.addNumeric(num)
+	}
+	if _, ok := a.counts[key]; ok {
+		a.counts[key]++
+		return
+	}
+	if len(a.counts) >= maxProfileTrackedValues {
+		a.limited = true
+		return
+	}
+	a.counts[key] = 1
+	a.rawByID[key] = value
+	a.order = append(a.order, key)
+}
+
+// addNumeric updates the running min/max/mean/variance (Welford's algorithm).
+func (a *columnAccumulator) addNumeric(v float64) {
+	a.numericCount++
+	if a.numericCount == 1 {
+		a.min, a.max = v, v
+	} else {
+		a.min = math.Min(a.min, v)
+		a.max = math.Max(a.max, v)
+	}
+	delta := v - a.mean
+	a.mean += delta / float64(a.numericCount)
+	a.m2 += delta * (v - a.mean)
+}
+
+func (a *columnAccumulator) profile(name string, rows int) ColumnProfile {
+	uniqueValues := make([]string, len(a.order))
+	for i, key := range a.order {
+		uniqueValues[i] = a.rawByID[key]
+	}
+
+	p := ColumnProfile{
+		Name:               name,
+		Type:               a.colType,
+		TaskType:           inferTaskType(uniqueValues),
+		MissingCount:       a.missing,
+		UniqueCount:        len(a.order),
+		UniqueCountLimited: a.limited,
+		Constant:           a.present > 0 && len(a.order) == 1,
+		LikelyTimestamp:    a.present > 0 && a.colType == "timestamp",
+	}
+	if a.present == 0 {
+		// An all-empty column never leaves the initial "bool" state.
+		p.Type = "string"
+	}
+	if rows > 0 {
+		p.MissingRatio = float64(a.missing) / float64(rows)
+	}
+
+	if (p.Type == "integer" || p.Type == "double") && a.numericCount > 0 {
+		minV, maxV, mean := a.min, a.max, a.mean
+		std := math.Sqrt(a.m2 / float64(a.numericCount))
+		p.Min, p.Max, p.Mean, p.StdDev = &minV, &maxV, &mean, &std
+	}
+
+	if p.TaskType != "regression" && a.present > 0 {
+		p.TopValues = a.topValues()
+		if len(a.order) >= 2 && len(a.order) <= maxMulticlassUniqueValues && !a.limited {
+			minority := a.present
+			for _, key := range a.order {
+				minority = min(minority, a.counts[key])
+			}
+			ratio := float64(minority) / float64(a.present)
+			p.MinorityClassRatio = &ratio
+		}
+	}
+
+	p.LikelyID = a.likelyID(name)
+	return p
+}
+
+// likelyID reports whether the column looks like a per-row identifier: fully
+// populated, integer or text, and with a distinct value in every sampled row. When
+// distinct values exceeded the tracking cap, the column name must also look like an ID.
+func (a *columnAccumulator) likelyID(name string) bool {
+	if a.missing > 0 || a.present < 2 {
+		return false
+	}
+	if a.colType != "integer" && a.colType != "string" {
+		return false
+	}
+	if a.limited {
+		return hasNameHint(name, idNameHints)
+	}
+	return len(a.order) == a.present
+}
+
+func (a *columnAccumulator) topValues() []ValueCount {
+	keys := append([]string(nil), a.order...)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return a.counts[keys[i]] > a.counts[keys[j]]
+	})
+	if len(keys) > maxProfileTopValues {
+		keys = keys[:maxProfileTopValues]
+	}
+	raw := make([]string, len(keys))
+	for i, key := range keys {
+		raw[i] = a.rawByID[key]
+	}
+	typed := toTypedValues(raw)
+	out := make([]ValueCount, len(keys))
+	for i, key := range keys {
+		out[i] = ValueCount{Value: typed[i], Count: a.counts[key]}
+	}
+	return out
+}
+
+// ProfileCSV samples up to opts.MaxRows data rows from r in a single streaming pass
+// and returns per-column statistics, data quality warnings and suggested run
+// parameters. Only a bounded number of distinct values is kept per column, so memory
+// does not grow with the sample size.
+//
+// Unlike InferCSVSchema, a file with fewer than 100 data rows is profiled and reported
+// with a too_few_rows warning rather than rejected.
+func ProfileCSV(r io.Reader, opts CSVProfileOptions) (CSVProfileResult, error) {
+	maxRows := opts.MaxRows
+	if maxRows <= 0 {
+		maxRows = DefaultProfileSampleRows
+	}
+	counter := &countingReader{r: r}
+	reader := csv.NewReader(counter)
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return CSVProfileResult{}, fmt.Errorf("%w: file is empty", ErrCSVValidation)
+		}
+		return CSVProfileResult{}, fmt.Errorf("error reading CSV header: %w", err)
+	}
+	header = append([]string(nil), header...)
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	if len(header) == 0 {
+		return CSVProfileResult{}, fmt.Errorf("%w: file has no columns in header", ErrCSVValidation)
+	}
+
+	columns := make([]*columnAccumulator, len(header))
+	for i := range columns {
+		columns[i] = newColumnAccumulator()
+	}
+	accumulate := func(row []string) {
+		for i, col := range columns {
+			if i < len(row) {
+				col.add(row[i])
+			} else {
+				col.add("")
+			}
+		}
+	}
+
+	// Rows are accumulated one step behind the reader so that the final row can be
+	// dropped when the byte limit cut it off mid-record.
+	var pending []string
+	rows, parseWarnings := 0, 0
+	truncated := false
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if parseWarnings == 0 {
+				for _, col := range header {
+					if !utf8.ValidString(col) {
+						return CSVProfileResult{}, fmt.Errorf("%w: file does not appear to be a valid text/CSV file (invalid UTF-8)", ErrCSVValidation)
+					}
+				}
+			}
+			parseWarnings++
+			continue
+		}
+		if pending != nil {
+			accumulate(pending)
+			rows++
+		}
+		if rows >= maxRows {
+			truncated = true
+			pending = nil
+			break
+		}
+		pending = append(pending[:0], row...)
+	}
+	if pending != nil {
+		if opts.MaxBytes > 0 && counter.n >= opts.MaxBytes {
+			truncated = true
+		} else {
+			accumulate(pending)
+			rows++
+		}
+	}
+
+	result := CSVProfileResult{
+		RowsSampled:     rows,
+		SampleTruncated: truncated,
+		ParseWarnings:   parseWarnings,
+		Columns:         make([]ColumnProfile, len(header)),
+		Warnings:        []ProfileWarning{},
+	}
+	for i, name := range header {
+		result.Columns[i] = columns[i].profile(name, rows)
+	}
+
+	eligible := profileColumnWarnings(&result, opts.ColumnNameError)
+	if rows < minDataRowsRequired {
+		result.Warnings = append([]ProfileWarning{{
+			Code:    ProfileWarningTooFewRows,
+			Message: fmt.Sprintf("file must contain at least %d data rows (excluding header) to start a run, found %d", minDataRowsRequired, rows),
+		}}, result.Warnings...)
+	}
+	suggestProfileColumns(&result, eligible)
+	return result, nil
+}
+
+// profileColumnWarnings appends per-column warnings and returns which columns may be
+// suggested as run parameters.
+func profileColumnWarnings(result *CSVProfileResult, nameErr func(string) error) []bool {
+	eligible := make([]bool, len(result.Columns))
+	seen := make(map[string]bool, len(result.Columns))
+	warn := func(code, column, message string) {
+		result.Warnings = append(result.Warnings, ProfileWarning{Code: code, Column: column, Message: message})
+	}
+
+	for i, col := range result.Columns {
+		eligible[i] = true
+		if col.Name == "" || seen[col.Name] {
+			eligible[i] = false
+			warn(ProfileWarningDuplicateColumn, col.Name, fmt.Sprintf("column %d has an empty or duplicate name", i+1))
+		}
+		seen[col.Name] = true
+		if nameErr != nil {
+			if err := nameErr(col.Name); err != nil {
+				eligible[i] = false
+				warn(ProfileWarningInvalidColumnName, col.Name, err.Error())
+			}
+		}
+
+		present := result.RowsSampled - col.MissingCount
+		switch {
+		case result.RowsSampled > 0 && present == 0:
+			eligible[i] = false
+			warn(ProfileWarningEmptyColumn, col.Name, "all sampled values are empty")
+			continue
+		case col.MissingRatio >= highMissingRatio:
+			warn(ProfileWarningHighMissing, col.Name, fmt.Sprintf("%.0f%% of sampled values are empty", col.MissingRatio*100))
+		}
+		if col.Constant {
+			warn(ProfileWarningConstantColumn, col.Name, "column has a single distinct value and carries no signal")
+		}
+		if col.LikelyID {
+			warn(ProfileWarningLikelyIdentifier, col.Name, "column looks like a row identifier and is unlikely to be a useful feature or target")
+		} else if col.Type == "string" && present > maxMulticlassUniqueValues &&
+			float64(col.UniqueCount)/float64(present) > highCardinalityRatio {
+			warn(ProfileWarningHighCardinality, col.Name, fmt.Sprintf("text column has %d distinct values in %d rows", col.UniqueCount, present))
+		}
+		if col.UniqueCountLimited {
+			warn(ProfileWarningUniqueCountLimited, col.Name, fmt.Sprintf("more than %d distinct values; unique_count is a lower bound", maxProfileTrackedValues))
+		}
+	}
+	return eligible
+}
+
+// suggestProfileColumns fills result.Suggestions. Columns with a name hint are
+// preferred; otherwise the timestamp is the first qualifying column and the label and
+// target are the last, following the usual CSV layout.
+func suggestProfileColumns(result *CSVProfileResult, eligible []bool) {
+	usable := func(i int) bool {
+		col := result.Columns[i]
+		return eligible[i] && !col.LikelyID && !col.Constant && col.MissingRatio < highMissingRatio
+	}
+
+	timestampIdx := pickColumn(result.Columns, timestampNameHints, false, func(i int) bool {
+		col := result.Columns[i]
+		return usable(i) && col.LikelyTimestamp && col.MissingCount == 0
+	})
+	labelIdx := pickColumn(result.Columns, labelNameHints, true, func(i int) bool {
+		col := result.Columns[i]
+		if !usable(i) || col.LikelyTimestamp {
+			return false
+		}
+		return col.TaskType == "regression" || col.UniqueCount <= maxMulticlassUniqueValues
+	})
+
+	if timestampIdx >= 0 {
+		name := result.Columns[timestampIdx].Name
+		result.Suggestions.TimestampColumn = &name
+
+		targetIdx := pickColumn(result.Columns, labelNameHints, true, func(i int) bool {
+			col := result.Columns[i]
+			return usable(i) && i != timestampIdx && (col.Type == "integer" || col.Type == "double")
+		})
+		if targetIdx >= 0 {
+			target := result.Columns[targetIdx].Name
+			result.Suggestions.Target = &target
+		}
+	}
+
+	if labelIdx >= 0 {
+		col := result.Columns[labelIdx]
+		name := col.Name
+		result.Suggestions.LabelColumn = &name
+		if col.MinorityClassRatio != nil && *col.MinorityClassRatio < imbalancedMinorityRatio {
+			result.Warnings = append(result.Warnings, ProfileWarning{
+				Code:   ProfileWarningClassImbalance,
+				Column: name,
+				Message: fmt.Sprintf("least frequent class makes up %.1f%% of sampled rows; consider collecting more data for it",
+					*col.MinorityClassRatio*100),
+			})
+		}
+	}
+}
+
+// pickColumn returns the index of the qualifying column whose name matches a hint,
+// falling back to the first (or last, when fromEnd is set) qualifying column. Returns
+// -1 when no column qualifies.
+func pickColumn(columns []ColumnProfile, hints []string, fromEnd bool, qualifies func(int) bool) int {
+	fallback := -1
+	for i := range columns {
+		if !qualifies(i) {
+			continue
+		}
+		if hasNameHint(columns[i].Name, hints) {
+			return i
+		}
+		if fallback < 0 || fromEnd {
+			fallback = i
+		}
+	}
+	return fallback
+}
+
+// hasNameHint reports whether name, split on non-alphanumeric characters and
+// camelCase boundaries, contains one of hints as a whole word.
+func hasNameHint(name string, hints []string) bool {
+	for _, word := range splitColumnName(name) {
+		for _, hint := range hints {
+			if word == hint {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func splitColumnName(name string) []string {
+	var words []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, strings.ToLower(current.String()))
+			current.Reset()
+		}
+	}
+	prevLower := false
+	for _, r := range name {
+		isUpper := r >= 'A' && r <= 'Z'
+		isAlnum := isUpper || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+		if !isAlnum {
+			flush()
+			prevLower = false
+			continue
+		}
+		if isUpper && prevLower {
+			flush()
+		}
+		current.WriteRune(r)
+		prevLower = !isUpper
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,298 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// profileRows builds n rows of a churn-style dataset: a unique customer_id, a
+// signup_date, a numeric monthly_spend, a mostly-empty notes column and a binary
+// churned label where every tenth customer churned.
+func profileRows(n int) [][]string {
+	rows := make([][]string, n)
+	for i := range rows {
+		churned := "no"
+		if i%10 == 0 {
+			churned = "yes"
+		}
+		notes := ""
+		if i%4 == 0 {
+			notes = "called support"
+		}
+		rows[i] = []string{
+			fmt.Sprintf("C%05d", i),
+			fmt.Sprintf("2024-01-%02d", i%28+1),
+			fmt.Sprintf("%d.5", 20+i%50),
+			notes,
+			churned,
+		}
+	}
+	return rows
+}
+
+var profileHeader = []string{"customer_id", "signup_date", "monthly_spend", "notes", "churned"}
+
+func findColumn(t *testing.T, result CSVProfileResult, name string) ColumnProfile {
+	t.Helper()
+	for _, col := range result.Columns {
+		if col.Name == name {
+			return col
+		}
+	}
+	t.Fatalf("column %q not found in profile", name)
+	return ColumnProfile{}
+}
+
+func hasWarning(result CSVProfileResult, code, column string) bool {
+	for _, w := range result.Warnings {
+		if w.Code == code && w.Column == column {
+			return true
+		}
+	}
+	return false
+}
+
+// === ProfileCSV ===
+
+func TestProfileCSV_EmptyFile(t *testing.T) {
+	_, err := ProfileCSV(strings.NewReader(""), CSVProfileOptions{})
+	if !errors.Is(err, ErrCSVValidation) {
+		t.Fatalf("expected ErrCSVValidation, got %v", err)
+	}
+}
+
+func TestProfileCSV_ColumnStatistics(t *testing.T) {
+	data := buildCSV(profileHeader, profileRows(200))
+
+	result, err := ProfileCSV(strings.NewReader(data), CSVProfileOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RowsSampled != 200 || result.SampleTruncated {
+		t.Errorf("RowsSampled = %d, SampleTruncated = %v; want 200, false", result.RowsSampled, result.SampleTruncated)
+	}
+
+	id := findColumn(t, result, "customer_id")
+	if !id.LikelyID || id.UniqueCount != 200 {
+		t.Errorf("customer_id: LikelyID = %v, UniqueCount = %d", id.LikelyID, id.UniqueCount)
+	}
+
+	date := findColumn(t, result, "signup_date")
+	if date.Type != "timestamp" || !date.LikelyTimestamp {
+		t.Errorf("signup_date: Type = %q, LikelyTimestamp = %v", date.Type, date.LikelyTimestamp)
+	}
+
+	spend := findColumn(t, result, "monthly_spend")
+	if spend.Type != "double" || spend.Min == nil || spend.Max == nil || spend.Mean == nil || spend.StdDev == nil {
+		t.Fatalf("monthly_spend: expected numeric statistics, got %+v", spend)
+	}
+	if *spend.Min != 20.5 || *spend.Max != 69.5 {
+		t.Errorf("monthly_spend: min/max = %v/%v, want 20.5/69.5", *spend.Min, *spend.Max)
+	}
+
+	notes := findColumn(t, result, "notes")
+	if notes.MissingCount != 150 || notes.MissingRatio != 0.75 {
+		t.Errorf("notes: MissingCount = %d, MissingRatio = %v", notes.MissingCount, notes.MissingRatio)
+	}
+
+	churned := findColumn(t, result, "churned")
+	if churned.TaskType != "binary" || churned.MinorityClassRatio == nil || *churned.MinorityClassRatio != 0.1 {
+		t.Errorf("churned: TaskType = %q, MinorityClassRatio = %v", churned.TaskType, churned.MinorityClassRatio)
+	}
+	if len(churned.TopValues) != 2 || churned.TopValues[0].Value != "no" || churned.TopValues[0].Count != 180 {
+		t.Errorf("churned: TopValues = %+v", churned.TopValues)
+	}
+}
+
+func TestProfileCSV_WarningsAndSuggestions(t *testing.T) {
+	rows := profileRows(200)
+	for i := range rows {
+		if i%50 == 0 {
+			rows[i][4] = "yes"
+		} else {
+			rows[i][4] = "no"
+		}
+	}
+	data := buildCSV(profileHeader, rows)
+
+	result, err := ProfileCSV(strings.NewReader(data), CSVProfileOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !hasWarning(result, ProfileWarningLikelyIdentifier, "customer_id") {
+		t.Error("expected likely_identifier warning for customer_id")
+	}
+	if !hasWarning(result, ProfileWarningHighMissing, "notes") {
+		t.Error("expected high_missing_ratio warning for notes")
+	}
+	if !hasWarning(result, ProfileWarningClassImbalance, "churned") {
+		t.Error("expected class_imbalance warning for churned")
+	}
+	if hasWarning(result, ProfileWarningTooFewRows, "") {
+		t.Error("did not expect too_few_rows warning for 200 rows")
+	}
+
+	s := result.Suggestions
+	if s.LabelColumn == nil || *s.LabelColumn != "churned" {
+		t.Errorf("LabelColumn = %v, want churned", s.LabelColumn)
+	}
+	if s.TimestampColumn == nil || *s.TimestampColumn != "signup_date" {
+		t.Errorf("TimestampColumn = %v, want signup_date", s.TimestampColumn)
+	}
+	if s.Target == nil || *s.Target != "monthly_spend" {
+		t.Errorf("Target = %v, want monthly_spend", s.Target)
+	}
+}
+
+func TestProfileCSV_LabelNameHintPreferred(t *testing.T) {
+	header := []string{"target", "feature"}
+	rows := make([][]string, 120)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("%d", i%3+2), fmt.Sprintf("%d", i%5+2)}
+	}
+
+	result, err := ProfileCSV(strings.NewReader(buildCSV(header, rows)), CSVProfileOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Suggestions.LabelColumn == nil || *result.Suggestions.LabelColumn != "target" {
+		t.Errorf("LabelColumn = %v, want target", result.Suggestions.LabelColumn)
+	}
+}
+
+func TestProfileCSV_ColumnNameErrorExcludesSuggestion(t *testing.T) {
+	header := []string{"feature", "résultat"}
+	rows := repeatRow([]string{"a", "oui"}, 60)
+	rows = append(rows, repeatRow([]string{"b", "non"}, 60)...)
+
+	result, err := ProfileCSV(strings.NewReader(buildCSV(header, rows)), CSVProfileOptions{
+		ColumnNameError: func(name string) error {
+			if name == "résultat" {
+				return errors.New("non-ASCII column name")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasWarning(result, ProfileWarningInvalidColumnName, "résultat") {
+		t.Error("expected invalid_column_name warning")
+	}
+	if result.Suggestions.LabelColumn == nil || *result.Suggestions.LabelColumn != "feature" {
+		t.Errorf("LabelColumn = %v, want feature", result.Suggestions.LabelColumn)
+	}
+}
+
+func TestProfileCSV_TooFewRowsWarns(t *testing.T) {
+	data := buildCSV([]string{"a", "b"}, repeatRow([]string{"1", "x"}, 10))
+
+	result, err := ProfileCSV(strings.NewReader(data), CSVProfileOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RowsSampled != 10 {
+		t.Errorf("RowsSampled = %d, want 10", result.RowsSampled)
+	}
+	if !hasWarning(result, ProfileWarningTooFewRows, "") {
+		t.Error("expected too_few_rows warning")
+	}
+	if !hasWarning(result, ProfileWarningConstantColumn, "b") {
+		t.Error("expected constant_column warning for b")
+	}
+}
+
+func TestProfileCSV_MaxRowsTruncates(t *testing.T) {
+	data := buildCSV([]string{"id", "v"}, numberedRows(300, 2, "x"))
+
+	result, err := ProfileCSV(strings.NewReader(data), CSVProfileOptions{MaxRows: 150})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RowsSampled != 150 || !result.SampleTruncated {
+		t.Errorf("RowsSampled = %d, SampleTruncated = %v; want 150, true", result.RowsSampled, result.SampleTruncated)
+	}
+}
+
+func TestProfileCSV_MaxRowsExactlyAvailableIsNotTruncated(t *testing.T) {
+	data := buildCSV([]string{"id", "v"}, numberedRows(150, 2, "x"))
+
+	result, err := ProfileCSV(strings.NewReader(data), CSVProfileOptions{MaxRows: 150})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RowsSampled != 150 || result.SampleTruncated {
+		t.Errorf("RowsSampled = %d, SampleTruncated = %v; want 150, false", result.RowsSampled, result.SampleTruncated)
+	}
+}
+
+func TestProfileCSV_ByteLimitDropsPartialRow(t *testing.T) {
+	data := buildCSV([]string{"id", "v"}, numberedRows(150, 2, "value"))
+	// Cut the input in the middle of the last row, as an HTTP range would.
+	limit := int64(len(data) - 3)
+
+	result, err := ProfileCSV(strings.NewReader(data[:limit]), CSVProfileOptions{MaxBytes: limit})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RowsSampled != 149 || !result.SampleTruncated {
+		t.Errorf("RowsSampled = %d, SampleTruncated = %v; want 149, true", result.RowsSampled, result.SampleTruncated)
+	}
+	if v := findColumn(t, result, "v"); v.UniqueCount != 1 {
+		t.Errorf("v: UniqueCount = %d, want 1 (partial row must be dropped)", v.UniqueCount)
+	}
+}
+
+func TestProfileCSV_EmptyColumnNotSuggested(t *testing.T) {
+	data := buildCSV([]string{"x", "label"}, repeatRow([]string{"1", ""}, 120))
+
+	result, err := ProfileCSV(strings.NewReader(data), CSVProfileOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasWarning(result, ProfileWarningEmptyColumn, "label") {
+		t.Error("expected empty_column warning for label")
+	}
+	if col := findColumn(t, result, "label"); col.Type != "string" {
+		t.Errorf("label: Type = %q, want string", col.Type)
+	}
+	if result.Suggestions.LabelColumn != nil {
+		t.Errorf("LabelColumn = %q, want none", *result.Suggestions.LabelColumn)
+	}
+}
+
+func TestAdvanceColumnType_MatchesInferColumnType(t *testing.T) {
+	values := []string{"1", "0", "2", "2.5", "abc"}
+	rows := make([][]string, 0, len(values))
+	current := "bool"
+	for _, v := range values {
+		rows = append(rows, []string{v})
+		current = advanceColumnType(current, v)
+		if want := inferColumnType(rows, 0); current != want {
+			t.Errorf("after %q: advanceColumnType = %q, inferColumnType = %q", v, current, want)
+		}
+	}
+}
+
+func TestHasNameHint(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"target", true},
+		{"sales_target", true},
+		{"isTarget", true},
+		{"Class", true},
+		{"classification", false},
+		{"y", true},
+		{"yield", false},
+	}
+	for _, tt := range tests {
+		if got := hasNameHint(tt.name, labelNameHints); got != tt.want {
+			t.Errorf("hasNameHint(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
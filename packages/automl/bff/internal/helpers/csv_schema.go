@@ -139,34 +139,7 @@ func inferColumnType(rows [][]string, colIndex int) string {
 			continue
 		}
 
-		value := strings.TrimSpace(row[colIndex])
-
-		switch currentType {
-		case "bool":
-			if looksLikeBoolean(value) {
-				continue
-			}
-			currentType = "timestamp"
-			fallthrough
-		case "timestamp":
-			if looksLikeTimestamp(value) {
-				continue
-			}
-			currentType = "integer"
-			fallthrough
-		case "integer":
-			if looksLikeInteger(value) {
-				continue
-			}
-			currentType = "double"
-			fallthrough
-		case "double":
-			if looksLikeDouble(value) {
-				continue
-			}
-			currentType = "string"
-		}
-
+		currentType = advanceColumnType(currentType, strings.TrimSpace(row[colIndex]))
 		if currentType == "string" {
 			break
 		}
@@ -175,6 +148,38 @@ func inferColumnType(rows [][]string, colIndex int) string {
 	return currentType
 }
 
+// advanceColumnType returns the narrowest type, no narrower than currentType, that
+// accepts value. Feeding every non-empty value of a column through it yields the same
+// result as inferColumnType without holding the rows in memory.
+func advanceColumnType(currentType, value string) string {
+	switch currentType {
+	case "bool":
+		if looksLikeBoolean(value) {
+			return currentType
+		}
+		currentType = "timestamp"
+		fallthrough
+	case "timestamp":
+		if looksLikeTimestamp(value) {
+			return currentType
+		}
+		currentType = "integer"
+		fallthrough
+	case "integer":
+		if looksLikeInteger(value) {
+			return currentType
+		}
+		currentType = "double"
+		fallthrough
+	case "double":
+		if looksLikeDouble(value) {
+			return currentType
+		}
+		currentType = "string"
+	}
+	return currentType
+}
+
 func parseFiniteFloat(s string) (float64, error) {
 	v, err := strconv.ParseFloat(s, 64)
 	if err != nil {
@@ -0,0 +1,59 @@
+package models
+
+// Leaderboard run statuses.
+const (
+	LeaderboardRunStatusOK                   = "ok"
+	LeaderboardRunStatusNotSucceeded         = "not_succeeded"
+	LeaderboardRunStatusArtifactsUnavailable = "artifacts_unavailable"
+)
+
+// Leaderboard is a ranked comparison of the candidate models produced by one or more
+// AutoML runs. Metric names are normalized to snake_case for both tabular and
+// time-series runs (e.g. "MASE" becomes "mean_absolute_scaled_error").
+type Leaderboard struct {
+	// Metric is the normalized metric used for ranking. AutoGluon reports every
+	// metric as higher-is-better (error metrics are negated), so candidates are
+	// ranked by descending score.
+	Metric     string                 `json:"metric"`
+	Runs       []LeaderboardRun       `json:"runs"`
+	Candidates []LeaderboardCandidate `json:"candidates"`
+}
+
+// LeaderboardRun summarizes one requested run and whether its artifacts were read.
+type LeaderboardRun struct {
+	RunID        string `json:"run_id"`
+	DisplayName  string `json:"display_name"`
+	PipelineType string `json:"pipeline_type"`
+	TaskType     string `json:"task_type,omitempty"`
+	State        string `json:"state"`
+	// EvalMetric is the run's normalized optimization metric.
+	EvalMetric string `json:"eval_metric,omitempty"`
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+	ModelCount int    `json:"model_count"`
+}
+
+// LeaderboardCandidate is one trained model from a run.
+type LeaderboardCandidate struct {
+	// Rank is 1-based across all runs; nil when the candidate has no value for the
+	// ranking metric.
+	Rank           *int     `json:"rank"`
+	RunID          string   `json:"run_id"`
+	RunDisplayName string   `json:"run_display_name"`
+	ModelName      string   `json:"model_name"`
+	PipelineType   string   `json:"pipeline_type"`
+	TaskType       string   `json:"task_type,omitempty"`
+	Score          *float64 `json:"score,omitempty"`
+	// Metrics are the model's test_data metrics keyed by normalized metric name.
+	Metrics           map[string]float64  `json:"metrics"`
+	Hyperparameters   map[string]any      `json:"hyperparameters,omitempty"`
+	FeatureImportance []FeatureImportance `json:"feature_importance,omitempty"`
+	ModelDirectory    string              `json:"model_directory"`
+	PredictorPath     string              `json:"predictor_path,omitempty"`
+}
+
+// FeatureImportance is the permutation importance of one input feature.
+type FeatureImportance struct {
+	Feature    string  `json:"feature"`
+	Importance float64 `json:"importance"`
+}
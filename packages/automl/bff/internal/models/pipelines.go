@@ -27,6 +27,7 @@ type PipelineRun struct {
 	ExperimentID             string                    `json:"experiment_id,omitempty"`
 	PipelineVersionReference *PipelineVersionReference `json:"pipeline_version_reference,omitempty"`
 	RuntimeConfig            *RuntimeConfig            `json:"runtime_config,omitempty"`
+	RecurringRunID           string                    `json:"recurring_run_id,omitempty"`
 	State                    string                    `json:"state"`
 	StorageState             string                    `json:"storage_state,omitempty"`
 	ServiceAccount           string                    `json:"service_account,omitempty"`
@@ -0,0 +1,139 @@
+package models
+
+// Retraining trigger types.
+const (
+	// RetrainingTriggerCron starts runs on a cron schedule via a pipeline server recurring run.
+	RetrainingTriggerCron = "cron"
+	// RetrainingTriggerS3Prefix starts a run when a new CSV lands under an S3 prefix.
+	RetrainingTriggerS3Prefix = "s3_prefix"
+)
+
+// Retraining history event types.
+const (
+	RetrainingEventRunTriggered       = "run_triggered"
+	RetrainingEventTriggerFailed      = "trigger_failed"
+	RetrainingEventRunFailed          = "run_failed"
+	RetrainingEventModelImproved      = "model_improved"
+	RetrainingEventModelRegistered    = "model_registered"
+	RetrainingEventModelNotImproved   = "model_not_improved"
+	RetrainingEventRegistrationFailed = "registration_failed"
+)
+
+// RetrainingTrigger defines when a retraining schedule starts new runs.
+type RetrainingTrigger struct {
+	// Type is "cron" or "s3_prefix".
+	Type string `json:"type"`
+
+	// Cron is a five-field cron expression (minute hour day-of-month month day-of-week)
+	// evaluated by the pipeline server. Required for cron triggers.
+	Cron string `json:"cron,omitempty"`
+
+	// S3Prefix is a folder in the source run's training data bucket. A CSV file directly
+	// under it that is newer than the last one seen starts a run on the next sync.
+	// Required for s3_prefix triggers.
+	S3Prefix string `json:"s3_prefix,omitempty"`
+}
+
+// RetrainingRegistration identifies where improved models are registered.
+type RetrainingRegistration struct {
+	// RegistryID is the Kubernetes UID of the target ModelRegistry CR.
+	RegistryID string `json:"registry_id"`
+
+	// RegisteredModelID is the existing registered model that new versions are added to.
+	// When empty, the first improving run creates a registered model named ModelName and
+	// its ID is stored here.
+	RegisteredModelID string `json:"registered_model_id,omitempty"`
+
+	// ModelName names the registered model created when RegisteredModelID is empty.
+	ModelName string `json:"model_name,omitempty"`
+}
+
+// CreateRetrainingScheduleRequest is the BFF-level input for creating a retraining schedule.
+// The pipeline and parameters of SourceRunID are reused for every retraining run.
+type CreateRetrainingScheduleRequest struct {
+	DisplayName string            `json:"display_name"`
+	SourceRunID string            `json:"source_run_id"`
+	Trigger     RetrainingTrigger `json:"trigger"`
+
+	// Metric is the metric new models must improve on. Defaults to the source run's
+	// eval_metric. Time-series acronyms such as MASE are accepted.
+	Metric string `json:"metric,omitempty"`
+
+	// Registration is optional. Without it, improving models are only recorded as the
+	// new champion.
+	Registration *RetrainingRegistration `json:"registration,omitempty"`
+}
+
+// RetrainingChampion is the best model seen by a schedule. A retrained model is only
+// registered when its score beats the champion's score.
+type RetrainingChampion struct {
+	RunID     string  `json:"run_id"`
+	ModelName string  `json:"model_name"`
+	Score     float64 `json:"score"`
+	// ModelVersionName is set when the champion was registered by this schedule.
+	ModelVersionName string `json:"model_version_name,omitempty"`
+}
+
+// RetrainingEvent is one entry of a schedule's history, newest last.
+type RetrainingEvent struct {
+	Time    string `json:"time"`
+	Type    string `json:"type"`
+	RunID   string `json:"run_id,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RetrainingSchedule is a recurring AutoML training configuration and its evaluation state.
+type RetrainingSchedule struct {
+	ID           string            `json:"id"`
+	DisplayName  string            `json:"display_name"`
+	SourceRunID  string            `json:"source_run_id"`
+	PipelineType string            `json:"pipeline_type"`
+	Trigger      RetrainingTrigger `json:"trigger"`
+	// Metric is the normalized snake_case metric used for comparison.
+	Metric       string                  `json:"metric"`
+	Registration *RetrainingRegistration `json:"registration,omitempty"`
+
+	// PipelineVersionReference and Parameters are copied from the source run.
+	PipelineVersionReference *PipelineVersionReference `json:"pipeline_version_reference"`
+	Parameters               map[string]any            `json:"parameters"`
+
+	// RecurringRunID is the pipeline server recurring run backing a cron trigger.
+	RecurringRunID string `json:"recurring_run_id,omitempty"`
+	// LastRunCreatedAt is the creation time of the newest run collected from the recurring run.
+	LastRunCreatedAt string `json:"last_run_created_at,omitempty"`
+
+	Champion *RetrainingChampion `json:"champion,omitempty"`
+
+	// LastObjectKey and LastObjectModified are the newest CSV seen under an s3_prefix trigger.
+	LastObjectKey      string `json:"last_object_key,omitempty"`
+	LastObjectModified string `json:"last_object_modified,omitempty"`
+
+	// PendingRunIDs are retraining runs that have not finished yet.
+	PendingRunIDs []string `json:"pending_run_ids"`
+	// EvaluatedRunIDs are the most recent finished runs already compared to the champion.
+	EvaluatedRunIDs []string `json:"evaluated_run_ids"`
+
+	History      []RetrainingEvent `json:"history"`
+	CreatedAt    string            `json:"created_at"`
+	UpdatedAt    string            `json:"updated_at"`
+	LastSyncedAt string            `json:"last_synced_at,omitempty"`
+}
+
+// RetrainingSchedulesData wraps the schedule list for the API response.
+type RetrainingSchedulesData struct {
+	Schedules []RetrainingSchedule `json:"schedules"`
+}
+
+// RetrainingSyncResult is the outcome of syncing every retraining schedule.
+type RetrainingSyncResult struct {
+	Synced   int                     `json:"synced"`
+	Failures []RetrainingSyncFailure `json:"failures"`
+}
+
+// RetrainingSyncFailure is a namespace or schedule that could not be synced. ScheduleID is
+// empty when the namespace's schedules could not be read.
+type RetrainingSyncFailure struct {
+	Namespace  string `json:"namespace"`
+	ScheduleID string `json:"schedule_id,omitempty"`
+	Error      string `json:"error"`
+}
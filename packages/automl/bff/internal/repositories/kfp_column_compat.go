@@ -20,15 +20,7 @@ func containsNonASCII(s string) bool {
 // MySQL-backed deployments reject multibyte UTF-8 in PipelineRuntimeManifest /
 // WorkflowRuntimeManifest, so AutoML blocks these names instead of rewriting CSVs.
 func ValidateASCIIColumnNames(req models.CreateAutoMLRunRequest, pipelineType string) error {
-	check := func(field, name string) error {
-		if name == "" || !containsNonASCII(name) {
-			return nil
-		}
-		return NewValidationError(fmt.Sprintf(
-			"%s %q must contain only ASCII characters because Kubeflow Pipelines does not support non-ASCII column names",
-			field, name,
-		))
-	}
+	check := validateASCIIColumnName
 
 	switch pipelineType {
 	case constants.PipelineTypeTabular:
@@ -64,3 +56,19 @@ func ValidateASCIIColumnNames(req models.CreateAutoMLRunRequest, pipelineType st
 
 	return nil
 }
+
+func validateASCIIColumnName(field, name string) error {
+	if name == "" || !containsNonASCII(name) {
+		return nil
+	}
+	return NewValidationError(fmt.Sprintf(
+		"%s %q must contain only ASCII characters because Kubeflow Pipelines does not support non-ASCII column names",
+		field, name,
+	))
+}
+
+// profileColumnNameError applies the ValidateASCIIColumnNames rule to CSV profiling so
+// columns that a run would reject are never suggested.
+func profileColumnNameError(name string) error {
+	return validateASCIIColumnName("column", name)
+}
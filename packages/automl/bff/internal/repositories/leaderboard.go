@@ -0,0 +1,471 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/opendatahub-io/automl-library/bff/internal/constants"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+// MaxLeaderboardRuns is the maximum number of runs compared in one request.
+const MaxLeaderboardRuns = 10
+
+const (
+	// maxArtifactJSONBytes bounds model.json and metrics files read from S3.
+	maxArtifactJSONBytes = 1 << 20
+	// maxFeatureImportanceEntries is the number of most important features returned
+	// per candidate.
+	maxFeatureImportanceEntries = 20
+	// maxLeaderboardListKeys bounds each S3 listing used to discover model directories.
+	maxLeaderboardListKeys = 1000
+
+	modelsArtifactDir = "models_artifact"
+)
+
+// pipelineOutputLayout describes where a pipeline writes its models:
+// <rootDir>/<runID>/<trainingTask>[-N]/<executionID>/models_artifact/<model>/.
+type pipelineOutputLayout struct {
+	rootDir      string
+	trainingTask string
+}
+
+var pipelineOutputLayouts = map[string]pipelineOutputLayout{
+	constants.PipelineTypeTabular: {
+		rootDir:      "autogluon-tabular-training-pipeline",
+		trainingTask: "autogluon-models-training",
+	},
+	constants.PipelineTypeTimeSeries: {
+		rootDir:      "autogluon-timeseries-training-pipeline",
+		trainingTask: "autogluon-timeseries-models-training",
+	},
+}
+
+// metricAliases maps the acronym metric keys reported by time-series runs to the
+// snake_case keys used by tabular runs.
+var metricAliases = map[string]string{
+	"MAE":   "mean_absolute_error",
+	"MSE":   "mean_squared_error",
+	"RMSE":  "root_mean_squared_error",
+	"RMSLE": "root_mean_squared_logarithmic_error",
+	"MAPE":  "mean_absolute_percentage_error",
+	"SMAPE": "symmetric_mean_absolute_percentage_error",
+	"MASE":  "mean_absolute_scaled_error",
+	"RMSSE": "root_mean_squared_scaled_error",
+	"WAPE":  "weighted_absolute_percentage_error",
+	"WQL":   "weighted_quantile_loss",
+	"SQL":   "scaled_quantile_loss",
+}
+
+// defaultEvalMetricByTask is the metric a run optimizes when eval_metric is unset.
+var defaultEvalMetricByTask = map[string]string{
+	constants.TaskTypeBinary:     "accuracy",
+	constants.TaskTypeMulticlass: "accuracy",
+	constants.TaskTypeRegression: "r2",
+	taskTypeTimeSeries:           "mean_absolute_scaled_error",
+}
+
+const taskTypeTimeSeries = "timeseries"
+
+// NormalizeMetricName maps a metric key to the snake_case form shared by tabular and
+// time-series runs.
+func NormalizeMetricName(name string) string {
+	name = strings.TrimSpace(name)
+	if alias, ok := metricAliases[strings.ToUpper(name)]; ok {
+		return alias
+	}
+	return strings.ToLower(name)
+}
+
+type managedRunReader interface {
+	GetManagedRun(ctx context.Context, namespace, runID string) (*models.PipelineRun, error)
+}
+
+type artifactReader interface {
+	ListObjects(ctx context.Context, req S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error)
+	GetObject(ctx context.Context, req S3RequestContext, key string) (*GetObjectResult, error)
+}
+
+// LeaderboardRepository builds cross-run model comparisons from the model artifacts
+// that AutoML runs write to the pipeline server's object storage.
+type LeaderboardRepository struct {
+	runs      managedRunReader
+	artifacts artifactReader
+	logger    *slog.Logger
+}
+
+func NewLeaderboardRepository(logger *slog.Logger, runs managedRunReader, artifacts artifactReader) *LeaderboardRepository {
+	return &LeaderboardRepository{
+		runs:      runs,
+		artifacts: artifacts,
+		logger:    logger,
+	}
+}
+
+// runArtifacts is the outcome of reading one run.
+type runArtifacts struct {
+	run        models.LeaderboardRun
+	candidates []models.LeaderboardCandidate
+	err        error
+}
+
+// GetLeaderboard reads the candidate models of runIDs and ranks them by metric, or by
+// the optimization metric of the first run when metric is empty. Artifacts are read
+// through the DSPA object storage of namespace. A run that is not an AutoML run
+// fails the request; a run without readable artifacts is reported in Runs with a
+// non-ok status.
+func (r *LeaderboardRepository) GetLeaderboard(ctx context.Context, namespace string, runIDs []string, metric string) (*models.Leaderboard, error) {
+	if len(runIDs) == 0 {
+		return nil, NewValidationError("at least one run ID is required")
+	}
+	if len(runIDs) > MaxLeaderboardRuns {
+		return nil, NewValidationError(fmt.Sprintf("at most %d runs can be compared", MaxLeaderboardRuns))
+	}
+	seen := make(map[string]bool, len(runIDs))
+	for _, id := range runIDs {
+		if seen[id] {
+			return nil, NewValidationError(fmt.Sprintf("run %q is listed more than once", id))
+		}
+		seen[id] = true
+	}
+
+	results := make([]runArtifacts, len(runIDs))
+	var wg sync.WaitGroup
+	for i, runID := range runIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.readRun(ctx, namespace, runID)
+		}()
+	}
+	wg.Wait()
+
+	board := &models.Leaderboard{
+		Metric:     NormalizeMetricName(metric),
+		Runs:       make([]models.LeaderboardRun, 0, len(results)),
+		Candidates: []models.LeaderboardCandidate{},
+	}
+	for _, res := range results {
+		if res.err != nil {
+			return nil, res.err
+		}
+		board.Runs = append(board.Runs, res.run)
+		board.Candidates = append(board.Candidates, res.candidates...)
+		if board.Metric == "" {
+			board.Metric = res.run.EvalMetric
+		}
+	}
+
+	rankCandidates(board.Candidates, board.Metric)
+	return board, nil
+}
+
+func (r *LeaderboardRepository) readRun(ctx context.Context, namespace, runID string) runArtifacts {
+	run, err := r.runs.GetManagedRun(ctx, namespace, runID)
+	if err != nil {
+		if errors.Is(err, ErrPipelineRunNotFound) {
+			err = fmt.Errorf("%w: %s", ErrPipelineRunNotFound, runID)
+		}
+		return runArtifacts{err: err}
+	}
+
+	summary := models.LeaderboardRun{
+		RunID:        run.RunID,
+		DisplayName:  run.DisplayName,
+		PipelineType: run.PipelineType,
+		TaskType:     runTaskType(run),
+		State:        run.State,
+		Status:       models.LeaderboardRunStatusOK,
+	}
+	summary.EvalMetric = runEvalMetric(run, summary.TaskType)
+
+	if run.State != "SUCCEEDED" {
+		summary.Status = models.LeaderboardRunStatusNotSucceeded
+		summary.Message = fmt.Sprintf("run is %s; models are only available for succeeded runs", run.State)
+		return runArtifacts{run: summary}
+	}
+
+	req := S3RequestContext{Namespace: namespace}
+	modelDirs, err := r.findModelDirectories(ctx, req, run)
+	if err != nil {
+		if !isMissingArtifactError(err) {
+			return runArtifacts{err: err}
+		}
+		summary.Status = models.LeaderboardRunStatusArtifactsUnavailable
+		summary.Message = err.Error()
+		return runArtifacts{run: summary}
+	}
+
+	var candidates []models.LeaderboardCandidate
+	var skipped []string
+	for _, dir := range modelDirs {
+		candidate, err := r.readCandidate(ctx, req, dir, summary)
+		if err != nil {
+			if !isMissingArtifactError(err) {
+				return runArtifacts{err: err}
+			}
+			r.logger.Warn("skipping model with unreadable artifacts", "runId", runID, "modelDirectory", dir, "error", err)
+			skipped = append(skipped, path.Base(dir))
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	summary.ModelCount = len(candidates)
+	switch {
+	case len(candidates) == 0:
+		summary.Status = models.LeaderboardRunStatusArtifactsUnavailable
+		summary.Message = "no readable model artifacts found for run"
+	case len(skipped) > 0:
+		summary.Message = fmt.Sprintf("skipped models with unreadable artifacts: %s", strings.Join(skipped, ", "))
+	}
+	return runArtifacts{run: summary, candidates: candidates}
+}
+
+// errArtifactsNotFound marks a run or model whose expected artifacts are missing or
+// malformed. Such runs are reported rather than failing the whole comparison.
+var errArtifactsNotFound = errors.New("model artifacts not found")
+
+func isMissingArtifactError(err error) bool {
+	return errors.Is(err, errArtifactsNotFound) || errors.Is(err, s3.ErrObjectNotFound)
+}
+
+// findModelDirectories returns the models_artifact/<model>/ prefixes of run. The
+// training task directory may carry a numeric suffix that depends on the preset
+// branch taken by the pipeline, so it is discovered by listing.
+func (r *LeaderboardRepository) findModelDirectories(ctx context.Context, req S3RequestContext, run *models.PipelineRun) ([]string, error) {
+	layout, ok := pipelineOutputLayouts[run.PipelineType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown pipeline type %q", errArtifactsNotFound, run.PipelineType)
+	}
+
+	taskDirs, err := r.listPrefixes(ctx, req, layout.rootDir+"/"+run.RunID)
+	if err != nil {
+		return nil, err
+	}
+	trainingDir := ""
+	for _, dir := range taskDirs {
+		if isTrainingTaskDir(path.Base(dir), layout.trainingTask) {
+			trainingDir = dir
+			break
+		}
+	}
+	if trainingDir == "" {
+		return nil, fmt.Errorf("%w: no %s output under %s/%s", errArtifactsNotFound, layout.trainingTask, layout.rootDir, run.RunID)
+	}
+
+	executionDirs, err := r.listPrefixes(ctx, req, trainingDir)
+	if err != nil {
+		return nil, err
+	}
+	var modelDirs []string
+	for _, execDir := range executionDirs {
+		dirs, err := r.listPrefixes(ctx, req, execDir+"/"+modelsArtifactDir)
+		if err != nil {
+			return nil, err
+		}
+		modelDirs = append(modelDirs, dirs...)
+	}
+	if len(modelDirs) == 0 {
+		return nil, fmt.Errorf("%w: no %s directories under %s", errArtifactsNotFound, modelsArtifactDir, trainingDir)
+	}
+	return modelDirs, nil
+}
+
+// listPrefixes returns the immediate sub-directories of dir without trailing slashes.
+func (r *LeaderboardRepository) listPrefixes(ctx context.Context, req S3RequestContext, dir string) ([]string, error) {
+	result, err := r.artifacts.ListObjects(ctx, req, s3.ListObjectsOptions{Path: dir, Limit: maxLeaderboardListKeys})
+	if err != nil {
+		return nil, err
+	}
+	prefixes := make([]string, 0, len(result.CommonPrefixes))
+	for _, p := range result.CommonPrefixes {
+		if trimmed := strings.TrimSuffix(p.Prefix, "/"); trimmed != "" {
+			prefixes = append(prefixes, trimmed)
+		}
+	}
+	return prefixes, nil
+}
+
+// isTrainingTaskDir matches the task name or a KFP condition-branch variant "task-N".
+func isTrainingTaskDir(name, task string) bool {
+	if name == task {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(name, task+"-")
+	if !ok || suffix == "" {
+		return false
+	}
+	for _, c := range suffix {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// modelJSON is the subset of model.json read for the leaderboard. Hyperparameters are
+// optional and only present in artifacts from pipeline versions that record them.
+type modelJSON struct {
+	Name     string `json:"name"`
+	Location struct {
+		Predictor string `json:"predictor"`
+	} `json:"location"`
+	Metrics struct {
+		TestData map[string]float64 `json:"test_data"`
+	} `json:"metrics"`
+	Hyperparameters map[string]any `json:"hyperparameters"`
+}
+
+type featureImportanceJSON struct {
+	Importance map[string]float64 `json:"importance"`
+}
+
+func (r *LeaderboardRepository) readCandidate(ctx context.Context, req S3RequestContext, modelDir string, run models.LeaderboardRun) (models.LeaderboardCandidate, error) {
+	var model modelJSON
+	if err := r.readJSON(ctx, req, modelDir+"/model.json", &model); err != nil {
+		return models.LeaderboardCandidate{}, err
+	}
+
+	name := model.Name
+	if name == "" {
+		name = path.Base(modelDir)
+	}
+	metrics := make(map[string]float64, len(model.Metrics.TestData))
+	for key, value := range model.Metrics.TestData {
+		metrics[NormalizeMetricName(key)] = value
+	}
+
+	candidate := models.LeaderboardCandidate{
+		RunID:           run.RunID,
+		RunDisplayName:  run.DisplayName,
+		ModelName:       name,
+		PipelineType:    run.PipelineType,
+		TaskType:        run.TaskType,
+		Metrics:         metrics,
+		Hyperparameters: model.Hyperparameters,
+		ModelDirectory:  modelDir + "/",
+	}
+	if model.Location.Predictor != "" {
+		// Locations in model.json are relative to models_artifact/.
+		candidate.PredictorPath = path.Dir(modelDir) + "/" + model.Location.Predictor
+	}
+
+	// Feature importance is only produced by the tabular pipeline and is optional.
+	if run.PipelineType == constants.PipelineTypeTabular {
+		var importance featureImportanceJSON
+		err := r.readJSON(ctx, req, modelDir+"/metrics/feature_importance.json", &importance)
+		switch {
+		case err == nil:
+			candidate.FeatureImportance = topFeatureImportance(importance.Importance)
+		case isMissingArtifactError(err):
+			r.logger.Debug("feature importance unavailable", "modelDirectory", modelDir, "error", err)
+		default:
+			return models.LeaderboardCandidate{}, err
+		}
+	}
+	return candidate, nil
+}
+
+// readJSON reads and decodes a JSON artifact. Missing and malformed files are both
+// reported as errArtifactsNotFound so the caller can skip the model.
+func (r *LeaderboardRepository) readJSON(ctx context.Context, req S3RequestContext, key string, v any) error {
+	obj, err := r.artifacts.GetObject(ctx, req, key)
+	if err != nil {
+		return err
+	}
+	defer obj.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(obj.Body, maxArtifactJSONBytes+1))
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", key, err)
+	}
+	if len(data) > maxArtifactJSONBytes {
+		return fmt.Errorf("%w: %s exceeds %d bytes", errArtifactsNotFound, key, maxArtifactJSONBytes)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s is not valid JSON: %v", errArtifactsNotFound, key, err)
+	}
+	return nil
+}
+
+func topFeatureImportance(importance map[string]float64) []models.FeatureImportance {
+	if len(importance) == 0 {
+		return nil
+	}
+	out := make([]models.FeatureImportance, 0, len(importance))
+	for feature, value := range importance {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		out = append(out, models.FeatureImportance{Feature: feature, Importance: value})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Importance != out[j].Importance {
+			return out[i].Importance > out[j].Importance
+		}
+		return out[i].Feature < out[j].Feature
+	})
+	if len(out) > maxFeatureImportanceEntries {
+		out = out[:maxFeatureImportanceEntries]
+	}
+	return out
+}
+
+// rankCandidates sorts candidates by descending metric value and assigns 1-based
+// ranks. Candidates without the metric keep their relative order after the ranked
+// ones and have no rank.
+func rankCandidates(candidates []models.LeaderboardCandidate, metric string) {
+	for i := range candidates {
+		if value, ok := candidates[i].Metrics[metric]; ok && !math.IsNaN(value) {
+			score := value
+			candidates[i].Score = &score
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i].Score, candidates[j].Score
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return *a > *b
+	})
+	for i := range candidates {
+		if candidates[i].Score == nil {
+			break
+		}
+		rank := i + 1
+		candidates[i].Rank = &rank
+	}
+}
+
+func runTaskType(run *models.PipelineRun) string {
+	if run.PipelineType == constants.PipelineTypeTimeSeries {
+		return taskTypeTimeSeries
+	}
+	return runStringParameter(run, "task_type")
+}
+
+func runEvalMetric(run *models.PipelineRun, taskType string) string {
+	if metric := runStringParameter(run, "eval_metric"); metric != "" {
+		return NormalizeMetricName(metric)
+	}
+	return defaultEvalMetricByTask[taskType]
+}
+
+func runStringParameter(run *models.PipelineRun, name string) string {
+	if run.RuntimeConfig == nil {
+		return ""
+	}
+	value, _ := run.RuntimeConfig.Parameters[name].(string)
+	return value
+}
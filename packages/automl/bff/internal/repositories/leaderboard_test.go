@@ -0,0 +1,258 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/automl-library/bff/internal/constants"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+// --- Fakes ---
+
+type fakeRunReader map[string]*models.PipelineRun
+
+func (f fakeRunReader) GetManagedRun(_ context.Context, _ string, runID string) (*models.PipelineRun, error) {
+	run, ok := f[runID]
+	if !ok {
+		return nil, ErrPipelineRunNotFound
+	}
+	return run, nil
+}
+
+// fakeArtifactStore serves objects from an in-memory key → content map and derives
+// delimiter listings from the keys.
+type fakeArtifactStore struct {
+	objects map[string]string
+	getErr  error
+}
+
+func (f *fakeArtifactStore) ListObjects(_ context.Context, _ S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error) {
+	prefix := strings.TrimSuffix(options.Path, "/") + "/"
+	seen := map[string]bool{}
+	var prefixes []s3.CommonPrefix
+	for key := range f.objects {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		if dir, _, found := strings.Cut(rest, "/"); found && !seen[dir] {
+			seen[dir] = true
+			prefixes = append(prefixes, s3.CommonPrefix{Prefix: prefix + dir + "/"})
+		}
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return prefixes[i].Prefix < prefixes[j].Prefix })
+	return &s3.ListObjectsResponse{CommonPrefixes: prefixes}, nil
+}
+
+func (f *fakeArtifactStore) GetObject(_ context.Context, _ S3RequestContext, key string) (*GetObjectResult, error) {
+	if f.getErr != nil {
+		return nil, f.getErr
+	}
+	content, ok := f.objects[key]
+	if !ok {
+		return nil, s3.ErrObjectNotFound
+	}
+	return &GetObjectResult{Body: io.NopCloser(strings.NewReader(content)), ContentType: "application/json"}, nil
+}
+
+func succeededRun(id, pipelineType string, params map[string]any) *models.PipelineRun {
+	return &models.PipelineRun{
+		RunID:         id,
+		DisplayName:   id + "-display",
+		State:         "SUCCEEDED",
+		PipelineType:  pipelineType,
+		RuntimeConfig: &models.RuntimeConfig{Parameters: params},
+	}
+}
+
+func leaderboardFixture() (fakeRunReader, *fakeArtifactStore) {
+	runs := fakeRunReader{
+		"tab-1": succeededRun("tab-1", constants.PipelineTypeTabular, map[string]any{"task_type": "binary", "eval_metric": "roc_auc"}),
+		"tab-2": succeededRun("tab-2", constants.PipelineTypeTabular, map[string]any{"task_type": "binary"}),
+		"ts-1":  succeededRun("ts-1", constants.PipelineTypeTimeSeries, nil),
+		"running": {
+			RunID: "running", DisplayName: "running", State: "RUNNING", PipelineType: constants.PipelineTypeTabular,
+		},
+	}
+	tab1 := "autogluon-tabular-training-pipeline/tab-1/autogluon-models-training-2/exec-a/models_artifact/"
+	tab2 := "autogluon-tabular-training-pipeline/tab-2/autogluon-models-training/exec-b/models_artifact/"
+	ts1 := "autogluon-timeseries-training-pipeline/ts-1/autogluon-timeseries-models-training/exec-c/models_artifact/"
+	store := &fakeArtifactStore{objects: map[string]string{
+		"autogluon-tabular-training-pipeline/tab-1/leaderboard-evaluation/out.json": "{}",
+
+		tab1 + "LightGBM_FULL/model.json": `{"name":"LightGBM_FULL","location":{"predictor":"LightGBM_FULL/predictor"},
+			"metrics":{"test_data":{"roc_auc":0.91,"accuracy":0.85}},"hyperparameters":{"num_boost_round":200}}`,
+		tab1 + "LightGBM_FULL/metrics/feature_importance.json": `{"importance":{"age":0.2,"tenure":0.5,"zip":-0.01}}`,
+		tab1 + "CatBoost_FULL/model.json": `{"name":"CatBoost_FULL","location":{"predictor":"CatBoost_FULL/predictor"},
+			"metrics":{"test_data":{"roc_auc":0.94,"accuracy":0.88}}}`,
+		tab1 + "Broken_FULL/model.json": `not json`,
+
+		tab2 + "XGBoost_FULL/model.json": `{"name":"XGBoost_FULL","location":{"predictor":"XGBoost_FULL/predictor"},
+			"metrics":{"test_data":{"roc_auc":0.92,"accuracy":0.9}}}`,
+
+		ts1 + "Chronos_FULL/model.json": `{"name":"Chronos_FULL","location":{"predictor":"Chronos_FULL/predictor"},
+			"metrics":{"test_data":{"MASE":-0.8,"WQL":-0.1}}}`,
+	}}
+	return runs, store
+}
+
+func TestLeaderboardRepository_RanksAcrossRuns(t *testing.T) {
+	runs, store := leaderboardFixture()
+	repo := NewLeaderboardRepository(slog.Default(), runs, store)
+
+	board, err := repo.GetLeaderboard(context.Background(), "ns", []string{"tab-1", "tab-2"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if board.Metric != "roc_auc" {
+		t.Errorf("Metric = %q, want roc_auc (first run's eval_metric)", board.Metric)
+	}
+	var order []string
+	for _, c := range board.Candidates {
+		order = append(order, c.ModelName)
+	}
+	if got, want := strings.Join(order, ","), "CatBoost_FULL,XGBoost_FULL,LightGBM_FULL"; got != want {
+		t.Errorf("candidate order = %s, want %s", got, want)
+	}
+	for i, c := range board.Candidates {
+		if c.Rank == nil || *c.Rank != i+1 {
+			t.Errorf("%s: Rank = %v, want %d", c.ModelName, c.Rank, i+1)
+		}
+	}
+
+	lgbm := board.Candidates[2]
+	if lgbm.RunID != "tab-1" || lgbm.Hyperparameters["num_boost_round"] != float64(200) {
+		t.Errorf("LightGBM candidate = %+v", lgbm)
+	}
+	if len(lgbm.FeatureImportance) != 3 || lgbm.FeatureImportance[0].Feature != "tenure" {
+		t.Errorf("FeatureImportance = %+v, want tenure first", lgbm.FeatureImportance)
+	}
+	wantPredictor := "autogluon-tabular-training-pipeline/tab-1/autogluon-models-training-2/exec-a/models_artifact/LightGBM_FULL/predictor"
+	if lgbm.PredictorPath != wantPredictor {
+		t.Errorf("PredictorPath = %q, want %q", lgbm.PredictorPath, wantPredictor)
+	}
+
+	if board.Runs[0].ModelCount != 2 || !strings.Contains(board.Runs[0].Message, "Broken_FULL") {
+		t.Errorf("tab-1 summary = %+v, want 2 models and skipped Broken_FULL", board.Runs[0])
+	}
+	if board.Runs[1].EvalMetric != "accuracy" {
+		t.Errorf("tab-2 EvalMetric = %q, want default accuracy", board.Runs[1].EvalMetric)
+	}
+}
+
+func TestLeaderboardRepository_NormalizesTimeSeriesMetrics(t *testing.T) {
+	runs, store := leaderboardFixture()
+	repo := NewLeaderboardRepository(slog.Default(), runs, store)
+
+	board, err := repo.GetLeaderboard(context.Background(), "ns", []string{"ts-1", "tab-2"}, "MASE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Metric != "mean_absolute_scaled_error" {
+		t.Errorf("Metric = %q", board.Metric)
+	}
+	if board.Runs[0].TaskType != "timeseries" || board.Runs[0].EvalMetric != "mean_absolute_scaled_error" {
+		t.Errorf("ts-1 summary = %+v", board.Runs[0])
+	}
+
+	chronos := board.Candidates[0]
+	if chronos.ModelName != "Chronos_FULL" || chronos.Score == nil || *chronos.Score != -0.8 {
+		t.Errorf("first candidate = %+v, want Chronos_FULL scored -0.8", chronos)
+	}
+	if _, ok := chronos.Metrics["weighted_quantile_loss"]; !ok {
+		t.Errorf("Metrics = %v, want normalized keys", chronos.Metrics)
+	}
+	if chronos.FeatureImportance != nil {
+		t.Error("time-series candidates have no feature importance")
+	}
+
+	xgb := board.Candidates[1]
+	if xgb.Rank != nil || xgb.Score != nil {
+		t.Errorf("tabular candidate without the metric should be unranked, got rank %v", xgb.Rank)
+	}
+}
+
+func TestLeaderboardRepository_ReportsUnavailableRuns(t *testing.T) {
+	runs, store := leaderboardFixture()
+	runs["empty"] = succeededRun("empty", constants.PipelineTypeTabular, map[string]any{"task_type": "regression"})
+	repo := NewLeaderboardRepository(slog.Default(), runs, store)
+
+	board, err := repo.GetLeaderboard(context.Background(), "ns", []string{"running", "empty"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Runs[0].Status != models.LeaderboardRunStatusNotSucceeded {
+		t.Errorf("running status = %q", board.Runs[0].Status)
+	}
+	if board.Runs[1].Status != models.LeaderboardRunStatusArtifactsUnavailable {
+		t.Errorf("empty status = %q", board.Runs[1].Status)
+	}
+	if len(board.Candidates) != 0 {
+		t.Errorf("Candidates = %+v, want none", board.Candidates)
+	}
+	if board.Metric != "r2" {
+		t.Errorf("Metric = %q, want r2 from the first run with a task type", board.Metric)
+	}
+}
+
+func TestLeaderboardRepository_Errors(t *testing.T) {
+	runs, store := leaderboardFixture()
+	repo := NewLeaderboardRepository(slog.Default(), runs, store)
+
+	t.Run("unknown run", func(t *testing.T) {
+		_, err := repo.GetLeaderboard(context.Background(), "ns", []string{"tab-1", "missing"}, "")
+		if !errors.Is(err, ErrPipelineRunNotFound) || !strings.Contains(err.Error(), "missing") {
+			t.Errorf("expected ErrPipelineRunNotFound naming the run, got %v", err)
+		}
+	})
+
+	t.Run("duplicate run", func(t *testing.T) {
+		_, err := repo.GetLeaderboard(context.Background(), "ns", []string{"tab-1", "tab-1"}, "")
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected ErrValidation, got %v", err)
+		}
+	})
+
+	t.Run("too many runs", func(t *testing.T) {
+		ids := make([]string, MaxLeaderboardRuns+1)
+		for i := range ids {
+			ids[i] = string(rune('a' + i))
+		}
+		_, err := repo.GetLeaderboard(context.Background(), "ns", ids, "")
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected ErrValidation, got %v", err)
+		}
+	})
+
+	t.Run("access denied fails the request", func(t *testing.T) {
+		denied := &fakeArtifactStore{objects: store.objects, getErr: s3.ErrAccessDenied}
+		repo := NewLeaderboardRepository(slog.Default(), runs, denied)
+		_, err := repo.GetLeaderboard(context.Background(), "ns", []string{"tab-1"}, "")
+		if !errors.Is(err, s3.ErrAccessDenied) {
+			t.Errorf("expected ErrAccessDenied, got %v", err)
+		}
+	})
+}
+
+func TestNormalizeMetricName(t *testing.T) {
+	tests := map[string]string{
+		"MASE":     "mean_absolute_scaled_error",
+		"rmse":     "root_mean_squared_error",
+		"ROC_AUC":  "roc_auc",
+		" r2 ":     "r2",
+		"accuracy": "accuracy",
+	}
+	for in, want := range tests {
+		if got := NormalizeMetricName(in); got != want {
+			t.Errorf("NormalizeMetricName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -174,17 +174,71 @@ func (r *ModelRegistryRepository) RegisterModel(
 	req models.RegisterModelRequest,
 	namespace string,
 ) (string, *openapi.ModelArtifact, error) {
+	target, err := r.resolveRegistrationTarget(ctx, registryUID, namespace)
+	if err != nil {
+		return "", nil, err
+	}
+	req = normalizeRegisterModelRequest(req)
+
+	// 1. Create RegisteredModel
+	regModelCreate := openapi.RegisteredModelCreate{Name: req.ModelName}
+	if req.ModelDescription != "" {
+		regModelCreate.Description = &req.ModelDescription
+	}
+	regModel, err := r.client.CreateRegisteredModel(ctx, target.baseURL, regModelCreate)
+	if err != nil {
+		return "", nil, err
+	}
+	regModelID := regModel.GetId()
+	if regModelID == "" {
+		return "", nil, fmt.Errorf("registered model created but ID is empty")
+	}
+
+	modelArtifact, err := r.createVersionWithArtifact(ctx, target, regModelID, req)
+	if err != nil {
+		return "", nil, err
+	}
+	return regModelID, modelArtifact, nil
+}
+
+// RegisterModelVersion creates a ModelVersion + ModelArtifact under an existing
+// RegisteredModel. ModelName and ModelDescription in req are ignored. The same
+// no-rollback caveat as RegisterModel applies to the artifact step.
+func (r *ModelRegistryRepository) RegisterModelVersion(
+	ctx context.Context,
+	registryUID string,
+	registeredModelID string,
+	req models.RegisterModelRequest,
+	namespace string,
+) (*openapi.ModelArtifact, error) {
+	if strings.TrimSpace(registeredModelID) == "" {
+		return nil, errors.New("registered model ID is required")
+	}
+	target, err := r.resolveRegistrationTarget(ctx, registryUID, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return r.createVersionWithArtifact(ctx, target, registeredModelID, normalizeRegisterModelRequest(req))
+}
+
+// registrationTarget is the resolved registry URL and DSPA storage used to build artifact URIs.
+type registrationTarget struct {
+	baseURL string
+	dspa    *pipelines.DiscoveredDSPA
+}
+
+func (r *ModelRegistryRepository) resolveRegistrationTarget(ctx context.Context, registryUID, namespace string) (*registrationTarget, error) {
 	// Resolve registry and get its URL.
 	reg, err := r.ResolveModelRegistryByUID(ctx, registryUID)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 	// Note: The model-registry-operator may create a NetworkPolicy that blocks in-cluster
 	// traffic to the kube-rbac-proxy on port 8443. If this becomes an issue, prefer
 	// reg.ExternalURL (the Route) when available, falling back to reg.ServerURL.
 	baseURL := strings.TrimSpace(reg.ServerURL)
 	if baseURL == "" {
-		return "", nil, fmt.Errorf("model registry %q has no usable internal URL", reg.Name)
+		return nil, fmt.Errorf("model registry %q has no usable internal URL", reg.Name)
 	}
 
 	// Best-effort DSPA discovery: pull object storage config from the DSPA spec (bucket,
@@ -192,13 +246,17 @@ func (r *ModelRegistryRepository) RegisterModel(
 	// construct the full S3 URI for the model artifact.
 	dspa, err := r.pipelinesService.DiscoverReadyDSPA(ctx, namespace)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to discover DSPA in namespace %s: %w", namespace, err)
+		return nil, fmt.Errorf("failed to discover DSPA in namespace %s: %w", namespace, err)
 	}
 	if dspa.ObjectStorage == nil {
-		return "", nil, fmt.Errorf("DSPA %q in namespace %s has no object storage configured", dspa.Name, namespace)
+		return nil, fmt.Errorf("DSPA %q in namespace %s has no object storage configured", dspa.Name, namespace)
 	}
+	return &registrationTarget{baseURL: baseURL, dspa: dspa}, nil
+}
 
-	// Normalize fields — validation checked for non-blank, now trim for clean API payloads.
+// normalizeRegisterModelRequest trims all fields — validation checked for non-blank,
+// now trim for clean API payloads.
+func normalizeRegisterModelRequest(req models.RegisterModelRequest) models.RegisterModelRequest {
 	req.S3Path = strings.TrimSpace(req.S3Path)
 	req.ModelName = strings.TrimSpace(req.ModelName)
 	req.ModelDescription = strings.TrimSpace(req.ModelDescription)
@@ -208,21 +266,17 @@ func (r *ModelRegistryRepository) RegisterModel(
 	req.ArtifactDescription = strings.TrimSpace(req.ArtifactDescription)
 	req.ModelFormatName = strings.TrimSpace(req.ModelFormatName)
 	req.ModelFormatVersion = strings.TrimSpace(req.ModelFormatVersion)
+	return req
+}
 
-	// 1. Create RegisteredModel
-	regModelCreate := openapi.RegisteredModelCreate{Name: req.ModelName}
-	if req.ModelDescription != "" {
-		regModelCreate.Description = &req.ModelDescription
-	}
-	regModel, err := r.client.CreateRegisteredModel(ctx, baseURL, regModelCreate)
-	if err != nil {
-		return "", nil, err
-	}
-	regModelID := regModel.GetId()
-	if regModelID == "" {
-		return "", nil, fmt.Errorf("registered model created but ID is empty")
-	}
-
+// createVersionWithArtifact creates the ModelVersion under regModelID (step 2) and the
+// ModelArtifact pointing at the S3 URI (step 3).
+func (r *ModelRegistryRepository) createVersionWithArtifact(
+	ctx context.Context,
+	target *registrationTarget,
+	regModelID string,
+	req models.RegisterModelRequest,
+) (*openapi.ModelArtifact, error) {
 	// 2. Create ModelVersion under the RegisteredModel
 	versionCreate := openapi.ModelVersionCreate{
 		Name:              req.VersionName,
@@ -231,26 +285,27 @@ func (r *ModelRegistryRepository) RegisterModel(
 	if req.VersionDescription != "" {
 		versionCreate.Description = &req.VersionDescription
 	}
-	modelVersion, err := r.client.CreateModelVersion(ctx, baseURL, regModelID, versionCreate)
+	modelVersion, err := r.client.CreateModelVersion(ctx, target.baseURL, regModelID, versionCreate)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 	versionID := modelVersion.GetId()
 	if versionID == "" {
-		return "", nil, fmt.Errorf("model version created but ID is empty")
+		return nil, fmt.Errorf("model version created but ID is empty")
 	}
 
 	// 3. Create ModelArtifact pointing to the S3 URI
+	dspa := target.dspa
 	objectStorage := dspa.ObjectStorage
 	if objectStorage.Bucket == "" {
-		return "", nil, fmt.Errorf("DSPA %q object storage is missing bucket name — contact your administrator", dspa.Name)
+		return nil, fmt.Errorf("DSPA %q object storage is missing bucket name — contact your administrator", dspa.Name)
 	}
 	if objectStorage.EndpointURL == "" {
-		return "", nil, fmt.Errorf("DSPA %q object storage is missing endpoint URL — contact your administrator", dspa.Name)
+		return nil, fmt.Errorf("DSPA %q object storage is missing endpoint URL — contact your administrator", dspa.Name)
 	}
 	parsedEndpoint, err := neturl.Parse(objectStorage.EndpointURL)
 	if err != nil || parsedEndpoint.Host == "" || (parsedEndpoint.Scheme != "http" && parsedEndpoint.Scheme != "https") {
-		return "", nil, fmt.Errorf("DSPA %q object storage has invalid endpoint URL: %s", dspa.Name, objectStorage.EndpointURL)
+		return nil, fmt.Errorf("DSPA %q object storage has invalid endpoint URL: %s", dspa.Name, objectStorage.EndpointURL)
 	}
 	artifactURI := buildModelRegistryURI(objectStorage.Bucket, req.S3Path, objectStorage.EndpointURL, objectStorage.Region)
 
@@ -271,12 +326,7 @@ func (r *ModelRegistryRepository) RegisterModel(
 	if req.ModelFormatVersion != "" {
 		artifactCreate.ModelFormatVersion = &req.ModelFormatVersion
 	}
-	modelArtifact, err := r.client.CreateModelArtifact(ctx, baseURL, versionID, artifactCreate)
-	if err != nil {
-		return "", nil, err
-	}
-
-	return regModelID, modelArtifact, nil
+	return r.client.CreateModelArtifact(ctx, target.baseURL, versionID, artifactCreate)
 }
 
 // ResolveModelRegistryByUID lists ModelRegistry CRs visible to the caller and returns the one
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"strings"
 	"testing"
@@ -88,6 +89,9 @@ func (m *mockK8sServiceForMR) PatchResource(context.Context, schema.GroupVersion
 func (m *mockK8sServiceForMR) PatchDeployment(context.Context, string, string, types.PatchType, []byte) error {
 	return nil
 }
+func (m *mockK8sServiceForMR) GetPodLogs(context.Context, string, string, *v1.PodLogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
 
 // --- Helpers ---
 
@@ -196,6 +196,47 @@ func (r *PipelinesRepository) CreateRun(ctx context.Context, namespace string, r
 	return &run, nil
 }
 
+// CreateRunFromInput starts a run from a prepared input without request validation. It is
+// used to re-run a configuration copied from an existing managed run, whose parameters
+// were validated when that run was created.
+func (r *PipelinesRepository) CreateRunFromInput(ctx context.Context, namespace string, input *pipelines.CreatePipelineRunInput, pipelineType string) (*models.PipelineRun, error) {
+	coreRun, err := r.core.CreatePipelineRun(ctx, namespace, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pipeline run: %w", err)
+	}
+	run := toAutoMLRun(coreRun, pipelineType)
+	return &run, nil
+}
+
+// --- Recurring Runs ---
+
+func (r *PipelinesRepository) CreateRecurringRun(ctx context.Context, namespace string, input *pipelines.CreateRecurringRunInput) (*pipelines.RecurringRun, error) {
+	recurringRun, err := r.core.CreateRecurringRun(ctx, namespace, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recurring run: %w", err)
+	}
+	return recurringRun, nil
+}
+
+func (r *PipelinesRepository) DeleteRecurringRun(ctx context.Context, namespace, recurringRunID string) error {
+	return r.core.DeleteRecurringRun(ctx, namespace, recurringRunID)
+}
+
+// ListRecurringRunRuns returns the runs of pipelineID that were started by recurringRunID.
+func (r *PipelinesRepository) ListRecurringRunRuns(ctx context.Context, namespace, pipelineID, recurringRunID, pipelineType string) ([]models.PipelineRun, error) {
+	coreRuns, err := r.core.GetAllPipelineRuns(ctx, namespace, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+	var runs []models.PipelineRun
+	for i := range coreRuns {
+		if coreRuns[i].RecurringRunID == recurringRunID {
+			runs = append(runs, toAutoMLRun(&coreRuns[i], pipelineType))
+		}
+	}
+	return runs, nil
+}
+
 // --- Pipeline Runs: Mutations ---
 // State validation (terminatable/retryable/deletable) is handled by autox-core.
 // Ownership validation (run belongs to a discovered AutoML pipeline) is automl-specific.
@@ -247,6 +288,7 @@ func toAutoMLRun(run *pipelines.PipelineRun, pipelineType string) models.Pipelin
 		ExperimentID:             run.ExperimentID,
 		PipelineVersionReference: run.PipelineVersionReference,
 		RuntimeConfig:            run.RuntimeConfig,
+		RecurringRunID:           run.RecurringRunID,
 		State:                    string(run.State),
 		StorageState:             run.StorageState,
 		ServiceAccount:           run.ServiceAccount,
@@ -0,0 +1,798 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
+	"github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MaxRetrainingSchedules caps the schedules per namespace so the backing ConfigMap stays
+// well below the 1 MiB object size limit.
+const MaxRetrainingSchedules = 20
+
+// RetrainingSchedulesConfigMap is the ConfigMap that stores a namespace's retraining
+// schedules, one JSON document per data key (the schedule ID).
+const RetrainingSchedulesConfigMap = "automl-retraining-schedules"
+
+const (
+	maxRetrainingHistory     = 20
+	maxRetrainingEvaluated   = 100
+	maxRetrainingDisplayName = 256
+	// maxRetrainingListPages bounds the S3 listing done for an s3_prefix trigger.
+	maxRetrainingListPages = 10
+)
+
+var (
+	ErrRetrainingScheduleNotFound = errors.New("retraining schedule not found")
+	// ErrRetrainingScheduleConflict is returned when the schedules were modified
+	// concurrently; the caller should retry.
+	ErrRetrainingScheduleConflict = errors.New("retraining schedules were modified concurrently, retry the request")
+)
+
+var (
+	configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+)
+
+// dashboardProjectLabel marks the namespaces shown as projects in the dashboard; schedules
+// are only created in those.
+const dashboardProjectLabel = "opendatahub.io/dashboard"
+
+// cronFieldPattern matches one field of a standard cron expression.
+var cronFieldPattern = regexp.MustCompile(`^[0-9A-Za-z*/,\-?]+$`)
+
+type resourceStore interface {
+	ListResources(ctx context.Context, gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error)
+	GetResource(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error)
+	CreateResource(ctx context.Context, gvr schema.GroupVersionResource, namespace string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	PatchResource(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string, patchType types.PatchType, patchData []byte) (*unstructured.Unstructured, error)
+}
+
+type retrainingRuns interface {
+	managedRunReader
+	CreateRunFromInput(ctx context.Context, namespace string, input *pipelines.CreatePipelineRunInput, pipelineType string) (*models.PipelineRun, error)
+	CreateRecurringRun(ctx context.Context, namespace string, input *pipelines.CreateRecurringRunInput) (*pipelines.RecurringRun, error)
+	DeleteRecurringRun(ctx context.Context, namespace, recurringRunID string) error
+	ListRecurringRunRuns(ctx context.Context, namespace, pipelineID, recurringRunID, pipelineType string) ([]models.PipelineRun, error)
+}
+
+type leaderboardReader interface {
+	GetLeaderboard(ctx context.Context, namespace string, runIDs []string, metric string) (*models.Leaderboard, error)
+}
+
+type modelRegistrar interface {
+	ResolveModelRegistryByUID(ctx context.Context, registryUID string) (*models.ModelRegistry, error)
+	RegisterModel(ctx context.Context, registryUID string, req models.RegisterModelRequest, namespace string) (string, *openapi.ModelArtifact, error)
+	RegisterModelVersion(ctx context.Context, registryUID, registeredModelID string, req models.RegisterModelRequest, namespace string) (*openapi.ModelArtifact, error)
+}
+
+type objectLister interface {
+	ListObjects(ctx context.Context, req S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error)
+}
+
+// RetrainingRepository manages scheduled retraining of AutoML runs.
+//
+// Cron triggers are delegated to a pipeline server recurring run. Everything else (detecting
+// new CSVs under an s3_prefix trigger, comparing finished runs to the champion, registering
+// improved models) happens in SyncSchedule, which the automl-retraining-sync CronJob runs for
+// every schedule through SyncAllSchedules.
+type RetrainingRepository struct {
+	logger      *slog.Logger
+	store       resourceStore
+	runs        retrainingRuns
+	leaderboard leaderboardReader
+	registry    modelRegistrar
+	objects     objectLister
+	now         func() time.Time
+	newID       func() string
+}
+
+func NewRetrainingRepository(
+	logger *slog.Logger,
+	store resourceStore,
+	runs retrainingRuns,
+	leaderboard leaderboardReader,
+	registry modelRegistrar,
+	objects objectLister,
+) *RetrainingRepository {
+	return &RetrainingRepository{
+		logger:      logger,
+		store:       store,
+		runs:        runs,
+		leaderboard: leaderboard,
+		registry:    registry,
+		objects:     objects,
+		now:         time.Now,
+		newID:       uuid.NewString,
+	}
+}
+
+// --- Storage ---
+
+// retrainingSchedules is the decoded ConfigMap. resourceVersion is sent with every patch so
+// concurrent writers fail with a conflict instead of overwriting each other.
+type retrainingSchedules struct {
+	exists          bool
+	resourceVersion string
+	entries         map[string]string
+}
+
+func (r *RetrainingRepository) load(ctx context.Context, namespace string) (*retrainingSchedules, error) {
+	obj, err := r.store.GetResource(ctx, configMapGVR, namespace, RetrainingSchedulesConfigMap)
+	if err != nil {
+		if errors.Is(err, kubernetes.ErrNotFound) {
+			return &retrainingSchedules{entries: map[string]string{}}, nil
+		}
+		return nil, fmt.Errorf("failed to read retraining schedules: %w", err)
+	}
+	entries, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read retraining schedules: %w", err)
+	}
+	if entries == nil {
+		entries = map[string]string{}
+	}
+	return &retrainingSchedules{exists: true, resourceVersion: obj.GetResourceVersion(), entries: entries}, nil
+}
+
+func (r *RetrainingRepository) decode(id, raw string) (*models.RetrainingSchedule, error) {
+	var schedule models.RetrainingSchedule
+	if err := json.Unmarshal([]byte(raw), &schedule); err != nil {
+		return nil, fmt.Errorf("retraining schedule %s is corrupt: %w", id, err)
+	}
+	return &schedule, nil
+}
+
+func (r *RetrainingRepository) get(ctx context.Context, namespace, id string) (*retrainingSchedules, *models.RetrainingSchedule, error) {
+	set, err := r.load(ctx, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, ok := set.entries[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrRetrainingScheduleNotFound, id)
+	}
+	schedule, err := r.decode(id, raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return set, schedule, nil
+}
+
+func (r *RetrainingRepository) save(ctx context.Context, namespace string, set *retrainingSchedules, schedule *models.RetrainingSchedule) error {
+	schedule.UpdatedAt = r.now().UTC().Format(time.RFC3339)
+	encoded, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to encode retraining schedule: %w", err)
+	}
+	value := string(encoded)
+	return r.write(ctx, namespace, set, schedule.ID, &value)
+}
+
+// write sets (value != nil) or removes (value == nil) one schedule in the ConfigMap.
+func (r *RetrainingRepository) write(ctx context.Context, namespace string, set *retrainingSchedules, id string, value *string) error {
+	if !set.exists {
+		if value == nil {
+			return nil
+		}
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":   RetrainingSchedulesConfigMap,
+				"labels": map[string]any{"app.kubernetes.io/managed-by": "automl-bff"},
+			},
+			"data": map[string]any{id: *value},
+		}}
+		created, err := r.store.CreateResource(ctx, configMapGVR, namespace, obj)
+		if err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return ErrRetrainingScheduleConflict
+			}
+			return fmt.Errorf("failed to store retraining schedule: %w", err)
+		}
+		set.exists = true
+		set.resourceVersion = created.GetResourceVersion()
+		set.entries[id] = *value
+		return nil
+	}
+
+	data := map[string]any{id: nil}
+	if value != nil {
+		data[id] = *value
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{"resourceVersion": set.resourceVersion},
+		"data":     data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode retraining schedule patch: %w", err)
+	}
+	updated, err := r.store.PatchResource(ctx, configMapGVR, namespace, RetrainingSchedulesConfigMap, types.MergePatchType, patch)
+	if err != nil {
+		if errors.Is(err, kubernetes.ErrConflict) {
+			return fmt.Errorf("%w: %v", ErrRetrainingScheduleConflict, err)
+		}
+		return fmt.Errorf("failed to store retraining schedule: %w", err)
+	}
+	set.resourceVersion = updated.GetResourceVersion()
+	if value == nil {
+		delete(set.entries, id)
+	} else {
+		set.entries[id] = *value
+	}
+	return nil
+}
+
+// --- Schedules ---
+
+// ListSchedules returns the namespace's schedules, oldest first. Corrupt entries are
+// logged and skipped.
+func (r *RetrainingRepository) ListSchedules(ctx context.Context, namespace string) ([]models.RetrainingSchedule, error) {
+	set, err := r.load(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]models.RetrainingSchedule, 0, len(set.entries))
+	for id, raw := range set.entries {
+		schedule, err := r.decode(id, raw)
+		if err != nil {
+			r.logger.Warn("skipping retraining schedule", "namespace", namespace, "error", err)
+			continue
+		}
+		schedules = append(schedules, *schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].CreatedAt != schedules[j].CreatedAt {
+			return schedules[i].CreatedAt < schedules[j].CreatedAt
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
+	return schedules, nil
+}
+
+func (r *RetrainingRepository) GetSchedule(ctx context.Context, namespace, id string) (*models.RetrainingSchedule, error) {
+	_, schedule, err := r.get(ctx, namespace, id)
+	return schedule, err
+}
+
+// CreateSchedule validates the request, copies the source run's pipeline and parameters,
+// seeds the champion from the source run's best model and sets up the trigger.
+func (r *RetrainingRepository) CreateSchedule(ctx context.Context, namespace string, req models.CreateRetrainingScheduleRequest) (*models.RetrainingSchedule, error) {
+	req, err := validateCreateRetrainingScheduleRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Registration != nil {
+		if _, err := r.registry.ResolveModelRegistryByUID(ctx, req.Registration.RegistryID); err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := r.runs.GetManagedRun(ctx, namespace, req.SourceRunID)
+	if err != nil {
+		if errors.Is(err, ErrPipelineRunNotFound) {
+			return nil, fmt.Errorf("%w: source run %s", ErrPipelineRunNotFound, req.SourceRunID)
+		}
+		return nil, err
+	}
+	if source.PipelineVersionReference == nil || source.RuntimeConfig == nil || len(source.RuntimeConfig.Parameters) == 0 {
+		return nil, NewValidationError("source run has no pipeline configuration to reuse")
+	}
+
+	set, err := r.load(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(set.entries) >= MaxRetrainingSchedules {
+		return nil, NewValidationError(fmt.Sprintf("a namespace can have at most %d retraining schedules", MaxRetrainingSchedules))
+	}
+
+	metric := NormalizeMetricName(req.Metric)
+	if metric == "" {
+		metric = runEvalMetric(source, runTaskType(source))
+	}
+	now := r.now().UTC().Format(time.RFC3339)
+	reference := *source.PipelineVersionReference
+	schedule := &models.RetrainingSchedule{
+		ID:                       r.newID(),
+		DisplayName:              req.DisplayName,
+		SourceRunID:              source.RunID,
+		PipelineType:             source.PipelineType,
+		Trigger:                  req.Trigger,
+		Metric:                   metric,
+		Registration:             req.Registration,
+		PipelineVersionReference: &reference,
+		Parameters:               cloneParameters(source.RuntimeConfig.Parameters),
+		PendingRunIDs:            []string{},
+		EvaluatedRunIDs:          []string{},
+		History:                  []models.RetrainingEvent{},
+		CreatedAt:                now,
+	}
+
+	if source.State == string(pipelines.RunStateSucceeded) {
+		board, err := r.leaderboard.GetLeaderboard(ctx, namespace, []string{source.RunID}, metric)
+		if err != nil {
+			return nil, err
+		}
+		if best := bestCandidate(board); best != nil {
+			schedule.Champion = &models.RetrainingChampion{RunID: best.RunID, ModelName: best.ModelName, Score: *best.Score}
+		}
+	}
+
+	switch schedule.Trigger.Type {
+	case models.RetrainingTriggerS3Prefix:
+		// Only data that arrives after the schedule is created triggers a run.
+		newest, err := r.newestObject(ctx, namespace, schedule)
+		if err != nil {
+			return nil, err
+		}
+		if newest != nil {
+			schedule.LastObjectKey = newest.Key
+			schedule.LastObjectModified = newest.LastModified
+		}
+	case models.RetrainingTriggerCron:
+		recurringRun, err := r.runs.CreateRecurringRun(ctx, namespace, &pipelines.CreateRecurringRunInput{
+			DisplayName:              schedule.DisplayName,
+			Description:              fmt.Sprintf("AutoML retraining of run %s", source.RunID),
+			PipelineVersionReference: &reference,
+			RuntimeConfig:            &models.RuntimeConfig{Parameters: cloneParameters(schedule.Parameters)},
+			Trigger:                  &pipelines.Trigger{CronSchedule: &pipelines.CronSchedule{Cron: pipelineServerCron(schedule.Trigger.Cron)}},
+			Mode:                     pipelines.RecurringRunModeEnable,
+			MaxConcurrency:           1,
+			NoCatchup:                true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		schedule.RecurringRunID = recurringRun.RecurringRunID
+	}
+
+	if err := r.save(ctx, namespace, set, schedule); err != nil {
+		if schedule.RecurringRunID != "" {
+			if delErr := r.runs.DeleteRecurringRun(ctx, namespace, schedule.RecurringRunID); delErr != nil {
+				r.logger.Error("failed to clean up recurring run", "namespace", namespace, "recurringRunID", schedule.RecurringRunID, "error", delErr)
+			}
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// DeleteSchedule removes a schedule and the recurring run backing its cron trigger. Runs
+// that were already started are left untouched.
+func (r *RetrainingRepository) DeleteSchedule(ctx context.Context, namespace, id string) error {
+	set, schedule, err := r.get(ctx, namespace, id)
+	if err != nil {
+		return err
+	}
+	if schedule.RecurringRunID != "" {
+		if err := r.runs.DeleteRecurringRun(ctx, namespace, schedule.RecurringRunID); err != nil && !errors.Is(err, pipelines.ErrRecurringRunNotFound) {
+			return fmt.Errorf("failed to delete recurring run: %w", err)
+		}
+	}
+	return r.write(ctx, namespace, set, id, nil)
+}
+
+// SyncSchedule collects runs started by the trigger, evaluates finished runs against the
+// champion (registering improved models when configured) and, for s3_prefix triggers,
+// starts a run when newer training data is found. It returns the updated schedule.
+func (r *RetrainingRepository) SyncSchedule(ctx context.Context, namespace, id string) (*models.RetrainingSchedule, error) {
+	set, schedule, err := r.get(ctx, namespace, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule.Trigger.Type == models.RetrainingTriggerCron {
+		if err := r.collectRecurringRuns(ctx, namespace, schedule); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.evaluatePendingRuns(ctx, namespace, schedule); err != nil {
+		return nil, err
+	}
+	if schedule.Trigger.Type == models.RetrainingTriggerS3Prefix {
+		if err := r.triggerOnNewData(ctx, namespace, set, schedule); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule.LastSyncedAt = r.now().UTC().Format(time.RFC3339)
+	if err := r.save(ctx, namespace, set, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// SyncAllSchedules syncs every schedule in the dashboard projects the caller can list. A
+// schedule that fails to sync is reported and does not stop the others; one that changed
+// while it was being synced was synced by another caller, and is skipped.
+func (r *RetrainingRepository) SyncAllSchedules(ctx context.Context) (*models.RetrainingSyncResult, error) {
+	namespaces, err := r.store.ListResources(ctx, namespaceGVR, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	sort.Slice(namespaces.Items, func(i, j int) bool { return namespaces.Items[i].GetName() < namespaces.Items[j].GetName() })
+
+	result := &models.RetrainingSyncResult{Failures: []models.RetrainingSyncFailure{}}
+	for i := range namespaces.Items {
+		if namespaces.Items[i].GetLabels()[dashboardProjectLabel] != "true" {
+			continue
+		}
+		namespace := namespaces.Items[i].GetName()
+		schedules, err := r.ListSchedules(ctx, namespace)
+		if err != nil {
+			result.Failures = append(result.Failures, models.RetrainingSyncFailure{Namespace: namespace, Error: err.Error()})
+			continue
+		}
+		for _, schedule := range schedules {
+			if _, err := r.SyncSchedule(ctx, namespace, schedule.ID); err != nil {
+				if errors.Is(err, ErrRetrainingScheduleConflict) {
+					continue
+				}
+				r.logger.Warn("failed to sync retraining schedule", "namespace", namespace, "scheduleID", schedule.ID, "error", err)
+				result.Failures = append(result.Failures, models.RetrainingSyncFailure{Namespace: namespace, ScheduleID: schedule.ID, Error: err.Error()})
+				continue
+			}
+			result.Synced++
+		}
+	}
+	return result, nil
+}
+
+// collectRecurringRuns adds runs started by the schedule's recurring run since the last sync.
+func (r *RetrainingRepository) collectRecurringRuns(ctx context.Context, namespace string, schedule *models.RetrainingSchedule) error {
+	runs, err := r.runs.ListRecurringRunRuns(ctx, namespace, schedule.PipelineVersionReference.PipelineID, schedule.RecurringRunID, schedule.PipelineType)
+	if err != nil {
+		return err
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].CreatedAt < runs[j].CreatedAt })
+
+	known := map[string]bool{}
+	for _, id := range schedule.PendingRunIDs {
+		known[id] = true
+	}
+	for _, id := range schedule.EvaluatedRunIDs {
+		known[id] = true
+	}
+	for _, run := range runs {
+		// EvaluatedRunIDs is capped, so older runs are excluded by creation time instead.
+		if known[run.RunID] || isBefore(run.CreatedAt, schedule.LastRunCreatedAt) {
+			continue
+		}
+		schedule.PendingRunIDs = append(schedule.PendingRunIDs, run.RunID)
+		r.addEvent(schedule, models.RetrainingEventRunTriggered, run.RunID, "started by cron trigger "+schedule.Trigger.Cron)
+		schedule.LastRunCreatedAt = run.CreatedAt
+	}
+	return nil
+}
+
+func (r *RetrainingRepository) evaluatePendingRuns(ctx context.Context, namespace string, schedule *models.RetrainingSchedule) error {
+	var pending []string
+	for _, runID := range schedule.PendingRunIDs {
+		run, err := r.runs.GetManagedRun(ctx, namespace, runID)
+		if err != nil {
+			if !errors.Is(err, ErrPipelineRunNotFound) {
+				return err
+			}
+			r.addEvent(schedule, models.RetrainingEventRunFailed, runID, "run no longer exists")
+			r.markEvaluated(schedule, runID)
+			continue
+		}
+
+		switch pipelines.RunState(run.State) {
+		case pipelines.RunStateSucceeded:
+			if err := r.evaluateRun(ctx, namespace, schedule, run); err != nil {
+				return err
+			}
+			r.markEvaluated(schedule, runID)
+		case pipelines.RunStateFailed, pipelines.RunStateCanceled:
+			r.addEvent(schedule, models.RetrainingEventRunFailed, runID, "run finished in state "+run.State)
+			r.markEvaluated(schedule, runID)
+		default:
+			pending = append(pending, runID)
+		}
+	}
+	if pending == nil {
+		pending = []string{}
+	}
+	schedule.PendingRunIDs = pending
+	return nil
+}
+
+// evaluateRun compares a succeeded run's best model to the champion and promotes it when
+// it scores higher. AutoGluon reports every metric as higher-is-better.
+func (r *RetrainingRepository) evaluateRun(ctx context.Context, namespace string, schedule *models.RetrainingSchedule, run *models.PipelineRun) error {
+	board, err := r.leaderboard.GetLeaderboard(ctx, namespace, []string{run.RunID}, schedule.Metric)
+	if err != nil {
+		return err
+	}
+	best := bestCandidate(board)
+	if best == nil {
+		r.addEvent(schedule, models.RetrainingEventModelNotImproved, run.RunID, fmt.Sprintf("no model reported %s", schedule.Metric))
+		return nil
+	}
+	if champion := schedule.Champion; champion != nil && *best.Score <= champion.Score {
+		r.addEvent(schedule, models.RetrainingEventModelNotImproved, run.RunID, fmt.Sprintf(
+			"%s scored %s %.6g, champion %s scored %.6g", best.ModelName, schedule.Metric, *best.Score, champion.ModelName, champion.Score))
+		return nil
+	}
+
+	champion := &models.RetrainingChampion{RunID: run.RunID, ModelName: best.ModelName, Score: *best.Score}
+	if schedule.Registration == nil {
+		schedule.Champion = champion
+		r.addEvent(schedule, models.RetrainingEventModelImproved, run.RunID, fmt.Sprintf("%s scored %s %.6g", best.ModelName, schedule.Metric, *best.Score))
+		return nil
+	}
+
+	versionName, err := r.register(ctx, namespace, schedule, run, best)
+	if err != nil {
+		// The champion is kept so the next improving run is registered instead.
+		r.addEvent(schedule, models.RetrainingEventRegistrationFailed, run.RunID, err.Error())
+		return nil
+	}
+	champion.ModelVersionName = versionName
+	schedule.Champion = champion
+	r.addEvent(schedule, models.RetrainingEventModelRegistered, run.RunID, fmt.Sprintf(
+		"%s scored %s %.6g, registered as version %s", best.ModelName, schedule.Metric, *best.Score, versionName))
+	return nil
+}
+
+// register adds the candidate to the schedule's registered model, creating the registered
+// model on first use.
+func (r *RetrainingRepository) register(ctx context.Context, namespace string, schedule *models.RetrainingSchedule, run *models.PipelineRun, best *models.LeaderboardCandidate) (string, error) {
+	registration := schedule.Registration
+	s3Path := best.PredictorPath
+	if s3Path == "" {
+		s3Path = strings.TrimSuffix(best.ModelDirectory, "/")
+	}
+	modelName := registration.ModelName
+	if modelName == "" {
+		modelName = registration.RegisteredModelID
+	}
+	req := models.RegisterModelRequest{
+		S3Path:             s3Path,
+		ModelName:          modelName,
+		VersionName:        "retrain-" + run.RunID,
+		VersionDescription: fmt.Sprintf("%s from run %s, retrained by schedule %q (%s %.6g)", best.ModelName, run.RunID, schedule.DisplayName, schedule.Metric, *best.Score),
+		ArtifactName:       best.ModelName,
+	}
+	if err := ValidateRegisterModelRequest(req); err != nil {
+		return "", err
+	}
+
+	if registration.RegisteredModelID == "" {
+		registeredModelID, _, err := r.registry.RegisterModel(ctx, registration.RegistryID, req, namespace)
+		if err != nil {
+			return "", err
+		}
+		registration.RegisteredModelID = registeredModelID
+		return req.VersionName, nil
+	}
+	if _, err := r.registry.RegisterModelVersion(ctx, registration.RegistryID, registration.RegisteredModelID, req, namespace); err != nil {
+		return "", err
+	}
+	return req.VersionName, nil
+}
+
+// triggerOnNewData starts a run on the newest CSV under the trigger prefix when it is newer
+// than the last one seen. While a run is pending, new data waits for the next sync so
+// bursts of uploads do not start overlapping runs.
+func (r *RetrainingRepository) triggerOnNewData(ctx context.Context, namespace string, set *retrainingSchedules, schedule *models.RetrainingSchedule) error {
+	if len(schedule.PendingRunIDs) > 0 {
+		return nil
+	}
+	newest, err := r.newestObject(ctx, namespace, schedule)
+	if err != nil {
+		return err
+	}
+	if newest == nil || !isNewerObject(*newest, schedule.LastObjectKey, schedule.LastObjectModified) {
+		return nil
+	}
+
+	// Claim the object before starting the run so a concurrent sync fails with a conflict
+	// instead of starting a duplicate run.
+	previousKey, previousModified := schedule.LastObjectKey, schedule.LastObjectModified
+	schedule.LastObjectKey, schedule.LastObjectModified = newest.Key, newest.LastModified
+	if err := r.save(ctx, namespace, set, schedule); err != nil {
+		return err
+	}
+
+	parameters := cloneParameters(schedule.Parameters)
+	parameters["train_data_file_key"] = newest.Key
+	reference := *schedule.PipelineVersionReference
+	run, err := r.runs.CreateRunFromInput(ctx, namespace, &pipelines.CreatePipelineRunInput{
+		DisplayName:              fmt.Sprintf("%s (%s)", schedule.DisplayName, path.Base(newest.Key)),
+		Description:              fmt.Sprintf("AutoML retraining of run %s on %s", schedule.SourceRunID, newest.Key),
+		PipelineVersionReference: &reference,
+		RuntimeConfig:            &models.RuntimeConfig{Parameters: parameters},
+	}, schedule.PipelineType)
+	if err != nil {
+		// Release the object so the next sync retries it.
+		schedule.LastObjectKey, schedule.LastObjectModified = previousKey, previousModified
+		r.addEvent(schedule, models.RetrainingEventTriggerFailed, "", fmt.Sprintf("failed to start run on %s: %v", newest.Key, err))
+		return nil
+	}
+	schedule.PendingRunIDs = append(schedule.PendingRunIDs, run.RunID)
+	r.addEvent(schedule, models.RetrainingEventRunTriggered, run.RunID, "new training data "+newest.Key)
+	return nil
+}
+
+// newestObject returns the most recently modified CSV directly under the trigger prefix,
+// read with the source run's training data connection.
+func (r *RetrainingRepository) newestObject(ctx context.Context, namespace string, schedule *models.RetrainingSchedule) (*s3.ObjectInfo, error) {
+	req := S3RequestContext{
+		Namespace:  namespace,
+		SecretName: parameterString(schedule.Parameters, "train_data_secret_name"),
+		Bucket:     parameterString(schedule.Parameters, "train_data_bucket_name"),
+	}
+	var newest *s3.ObjectInfo
+	next := ""
+	for page := 0; page < maxRetrainingListPages; page++ {
+		result, err := r.objects.ListObjects(ctx, req, s3.ListObjectsOptions{Path: schedule.Trigger.S3Prefix, Next: next})
+		if err != nil {
+			return nil, err
+		}
+		for i := range result.Contents {
+			object := result.Contents[i]
+			if !strings.HasSuffix(strings.ToLower(object.Key), ".csv") {
+				continue
+			}
+			if newest == nil || isNewerObject(object, newest.Key, newest.LastModified) {
+				newest = &object
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		next = result.NextContinuationToken
+	}
+	return newest, nil
+}
+
+func (r *RetrainingRepository) addEvent(schedule *models.RetrainingSchedule, eventType, runID, message string) {
+	schedule.History = append(schedule.History, models.RetrainingEvent{
+		Time:    r.now().UTC().Format(time.RFC3339),
+		Type:    eventType,
+		RunID:   runID,
+		Message: message,
+	})
+	if extra := len(schedule.History) - maxRetrainingHistory; extra > 0 {
+		schedule.History = schedule.History[extra:]
+	}
+}
+
+func (r *RetrainingRepository) markEvaluated(schedule *models.RetrainingSchedule, runID string) {
+	schedule.EvaluatedRunIDs = append(schedule.EvaluatedRunIDs, runID)
+	if extra := len(schedule.EvaluatedRunIDs) - maxRetrainingEvaluated; extra > 0 {
+		schedule.EvaluatedRunIDs = schedule.EvaluatedRunIDs[extra:]
+	}
+}
+
+// --- Helpers ---
+
+func validateCreateRetrainingScheduleRequest(req models.CreateRetrainingScheduleRequest) (models.CreateRetrainingScheduleRequest, error) {
+	req.DisplayName = strings.TrimSpace(req.DisplayName)
+	req.SourceRunID = strings.TrimSpace(req.SourceRunID)
+	if req.DisplayName == "" {
+		return req, NewValidationError("display_name is required")
+	}
+	if len(req.DisplayName) > maxRetrainingDisplayName {
+		return req, NewValidationError(fmt.Sprintf("display_name must be at most %d characters", maxRetrainingDisplayName))
+	}
+	if req.SourceRunID == "" {
+		return req, NewValidationError("source_run_id is required")
+	}
+
+	trigger := &req.Trigger
+	switch trigger.Type {
+	case models.RetrainingTriggerCron:
+		trigger.Cron = strings.Join(strings.Fields(trigger.Cron), " ")
+		if trigger.S3Prefix != "" {
+			return req, NewValidationError("trigger.s3_prefix is not allowed for cron triggers")
+		}
+		fields := strings.Fields(trigger.Cron)
+		if len(fields) != 5 {
+			return req, NewValidationError("trigger.cron must have five fields: minute hour day-of-month month day-of-week")
+		}
+		for _, field := range fields {
+			if !cronFieldPattern.MatchString(field) {
+				return req, NewValidationError(fmt.Sprintf("trigger.cron field %q is invalid", field))
+			}
+		}
+	case models.RetrainingTriggerS3Prefix:
+		trigger.S3Prefix = strings.TrimSpace(trigger.S3Prefix)
+		if trigger.Cron != "" {
+			return req, NewValidationError("trigger.cron is not allowed for s3_prefix triggers")
+		}
+		if trigger.S3Prefix == "" {
+			return req, NewValidationError("trigger.s3_prefix is required for s3_prefix triggers")
+		}
+		if strings.HasPrefix(trigger.S3Prefix, "/") || strings.Contains(trigger.S3Prefix, "..") {
+			return req, NewValidationError("trigger.s3_prefix must be a relative path without '..'")
+		}
+	default:
+		return req, NewValidationError(fmt.Sprintf("trigger.type must be %q or %q", models.RetrainingTriggerCron, models.RetrainingTriggerS3Prefix))
+	}
+
+	if registration := req.Registration; registration != nil {
+		registration.RegistryID = strings.TrimSpace(registration.RegistryID)
+		registration.RegisteredModelID = strings.TrimSpace(registration.RegisteredModelID)
+		registration.ModelName = strings.TrimSpace(registration.ModelName)
+		if registration.RegistryID == "" {
+			return req, NewValidationError("registration.registry_id is required")
+		}
+		if registration.RegisteredModelID == "" && registration.ModelName == "" {
+			return req, NewValidationError("registration requires registered_model_id or model_name")
+		}
+	}
+	return req, nil
+}
+
+// pipelineServerCron converts a five-field cron expression to the pipeline server's
+// six-field format, which starts with a seconds field.
+func pipelineServerCron(cron string) string {
+	return "0 " + cron
+}
+
+// bestCandidate returns the top-ranked candidate, or nil when no candidate has a score.
+func bestCandidate(board *models.Leaderboard) *models.LeaderboardCandidate {
+	if board == nil || len(board.Candidates) == 0 || board.Candidates[0].Score == nil {
+		return nil
+	}
+	return &board.Candidates[0]
+}
+
+// isNewerObject orders objects by modification time, then key.
+func isNewerObject(object s3.ObjectInfo, key, modified string) bool {
+	if object.LastModified != modified {
+		return isBefore(modified, object.LastModified)
+	}
+	return object.Key > key
+}
+
+// isBefore reports whether RFC 3339 timestamp a is before b. An empty or unparsable a is
+// before everything; an empty or unparsable b is before nothing.
+func isBefore(a, b string) bool {
+	tb, err := time.Parse(time.RFC3339, b)
+	if err != nil {
+		return false
+	}
+	ta, err := time.Parse(time.RFC3339, a)
+	if err != nil {
+		return true
+	}
+	return ta.Before(tb)
+}
+
+func parameterString(parameters map[string]any, name string) string {
+	value, _ := parameters[name].(string)
+	return value
+}
+
+func cloneParameters(parameters map[string]any) map[string]any {
+	cloned := make(map[string]any, len(parameters))
+	for k, v := range parameters {
+		cloned[k] = v
+	}
+	return cloned
+}
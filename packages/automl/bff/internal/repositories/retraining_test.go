@@ -0,0 +1,544 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/opendatahub-io/automl-library/bff/internal/constants"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
+	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
+	"github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// --- Fakes ---
+
+// fakeConfigMapStore holds a single ConfigMap and applies JSON merge patches to its data,
+// rejecting patches whose resourceVersion is stale.
+type fakeConfigMapStore struct {
+	data    map[string]string
+	version int
+	exists  bool
+	// namespaces maps namespace names to whether they are dashboard projects.
+	namespaces map[string]bool
+	// readNamespaces records the namespaces whose ConfigMap was read.
+	readNamespaces []string
+}
+
+func (f *fakeConfigMapStore) ListResources(_ context.Context, gvr schema.GroupVersionResource, _ string) (*unstructured.UnstructuredList, error) {
+	if gvr != namespaceGVR {
+		return nil, fmt.Errorf("unexpected list of %s", gvr.Resource)
+	}
+	list := &unstructured.UnstructuredList{}
+	for name, project := range f.namespaces {
+		item := unstructured.Unstructured{Object: map[string]any{}}
+		item.SetName(name)
+		if project {
+			item.SetLabels(map[string]string{dashboardProjectLabel: "true"})
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, nil
+}
+
+func (f *fakeConfigMapStore) object() *unstructured.Unstructured {
+	data := map[string]any{}
+	for k, v := range f.data {
+		data[k] = v
+	}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": RetrainingSchedulesConfigMap, "resourceVersion": strconv.Itoa(f.version)},
+		"data":     data,
+	}}
+}
+
+func (f *fakeConfigMapStore) GetResource(_ context.Context, _ schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	f.readNamespaces = append(f.readNamespaces, namespace)
+	if !f.exists {
+		return nil, &kubernetes.NotFoundError{Resource: "configmaps", Name: name}
+	}
+	return f.object(), nil
+}
+
+func (f *fakeConfigMapStore) CreateResource(_ context.Context, _ schema.GroupVersionResource, _ string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	data, _, _ := unstructured.NestedStringMap(obj.Object, "data")
+	f.data, f.exists = data, true
+	f.version++
+	return f.object(), nil
+}
+
+func (f *fakeConfigMapStore) PatchResource(_ context.Context, _ schema.GroupVersionResource, _, name string, patchType types.PatchType, patchData []byte) (*unstructured.Unstructured, error) {
+	if patchType != types.MergePatchType {
+		return nil, fmt.Errorf("unexpected patch type %s", patchType)
+	}
+	var patch struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+		Data map[string]*string `json:"data"`
+	}
+	if err := json.Unmarshal(patchData, &patch); err != nil {
+		return nil, err
+	}
+	if patch.Metadata.ResourceVersion != strconv.Itoa(f.version) {
+		return nil, &kubernetes.ConflictError{Resource: "configmaps", Name: name}
+	}
+	for k, v := range patch.Data {
+		if v == nil {
+			delete(f.data, k)
+		} else {
+			f.data[k] = *v
+		}
+	}
+	f.version++
+	return f.object(), nil
+}
+
+type fakeRetrainingRuns struct {
+	fakeRunReader
+	recurringInputs  []*pipelines.CreateRecurringRunInput
+	deletedRecurring []string
+	runInputs        []*pipelines.CreatePipelineRunInput
+	recurringRuns    []models.PipelineRun
+	createRunErr     error
+}
+
+func (f *fakeRetrainingRuns) CreateRunFromInput(_ context.Context, _ string, input *pipelines.CreatePipelineRunInput, pipelineType string) (*models.PipelineRun, error) {
+	if f.createRunErr != nil {
+		return nil, f.createRunErr
+	}
+	f.runInputs = append(f.runInputs, input)
+	id := fmt.Sprintf("retrain-%d", len(f.runInputs))
+	run := &models.PipelineRun{RunID: id, State: "PENDING", PipelineType: pipelineType}
+	f.fakeRunReader[id] = run
+	return run, nil
+}
+
+func (f *fakeRetrainingRuns) CreateRecurringRun(_ context.Context, _ string, input *pipelines.CreateRecurringRunInput) (*pipelines.RecurringRun, error) {
+	f.recurringInputs = append(f.recurringInputs, input)
+	return &pipelines.RecurringRun{RecurringRunID: "rr-1", DisplayName: input.DisplayName}, nil
+}
+
+func (f *fakeRetrainingRuns) DeleteRecurringRun(_ context.Context, _, recurringRunID string) error {
+	f.deletedRecurring = append(f.deletedRecurring, recurringRunID)
+	return nil
+}
+
+func (f *fakeRetrainingRuns) ListRecurringRunRuns(_ context.Context, _, _, _, _ string) ([]models.PipelineRun, error) {
+	return f.recurringRuns, nil
+}
+
+// fakeBoards returns a single-candidate leaderboard per run ID.
+type fakeBoards map[string]float64
+
+func (f fakeBoards) GetLeaderboard(_ context.Context, _ string, runIDs []string, metric string) (*models.Leaderboard, error) {
+	board := &models.Leaderboard{Metric: metric}
+	if score, ok := f[runIDs[0]]; ok {
+		board.Candidates = []models.LeaderboardCandidate{{
+			RunID: runIDs[0], ModelName: "CatBoost_FULL", Score: &score,
+			PredictorPath: "pipeline/" + runIDs[0] + "/models_artifact/CatBoost_FULL/predictor",
+		}}
+	}
+	return board, nil
+}
+
+type fakeRegistrar struct {
+	models   []models.RegisterModelRequest
+	versions []string
+}
+
+func (f *fakeRegistrar) ResolveModelRegistryByUID(_ context.Context, registryUID string) (*models.ModelRegistry, error) {
+	if registryUID != "registry-uid" {
+		return nil, ErrModelRegistryNotFound
+	}
+	return &models.ModelRegistry{ID: registryUID}, nil
+}
+
+func (f *fakeRegistrar) RegisterModel(_ context.Context, _ string, req models.RegisterModelRequest, _ string) (string, *openapi.ModelArtifact, error) {
+	f.models = append(f.models, req)
+	return "rm-1", &openapi.ModelArtifact{}, nil
+}
+
+func (f *fakeRegistrar) RegisterModelVersion(_ context.Context, _, registeredModelID string, req models.RegisterModelRequest, _ string) (*openapi.ModelArtifact, error) {
+	f.versions = append(f.versions, registeredModelID+"/"+req.VersionName)
+	return &openapi.ModelArtifact{}, nil
+}
+
+type fakeObjectLister struct {
+	objects []s3.ObjectInfo
+}
+
+func (f *fakeObjectLister) ListObjects(_ context.Context, _ S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error) {
+	var contents []s3.ObjectInfo
+	for _, obj := range f.objects {
+		if strings.HasPrefix(obj.Key, strings.TrimSuffix(options.Path, "/")+"/") {
+			contents = append(contents, obj)
+		}
+	}
+	return &s3.ListObjectsResponse{Contents: contents}, nil
+}
+
+type retrainingFixture struct {
+	repo     *RetrainingRepository
+	store    *fakeConfigMapStore
+	runs     *fakeRetrainingRuns
+	boards   fakeBoards
+	registry *fakeRegistrar
+	objects  *fakeObjectLister
+}
+
+func newRetrainingFixture() *retrainingFixture {
+	source := succeededRun("source", constants.PipelineTypeTabular, map[string]any{
+		"task_type":              "binary",
+		"eval_metric":            "roc_auc",
+		"train_data_secret_name": "data-conn",
+		"train_data_bucket_name": "data",
+		"train_data_file_key":    "churn/2024-01.csv",
+	})
+	source.PipelineVersionReference = &models.PipelineVersionReference{PipelineID: "pipeline-1", PipelineVersionID: "v1"}
+	f := &retrainingFixture{
+		store:    &fakeConfigMapStore{},
+		runs:     &fakeRetrainingRuns{fakeRunReader: fakeRunReader{"source": source}},
+		boards:   fakeBoards{"source": 0.90},
+		registry: &fakeRegistrar{},
+		objects: &fakeObjectLister{objects: []s3.ObjectInfo{
+			{Key: "churn/2024-01.csv", LastModified: "2024-01-31T00:00:00Z"},
+			{Key: "churn/readme.txt", LastModified: "2024-03-01T00:00:00Z"},
+		}},
+	}
+	f.repo = NewRetrainingRepository(slog.Default(), f.store, f.runs, f.boards, f.registry, f.objects)
+	clock := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	f.repo.now = func() time.Time { return clock }
+	f.repo.newID = func() string { return "schedule-1" }
+	return f
+}
+
+func (f *retrainingFixture) finishRun(id string, score float64) {
+	f.runs.fakeRunReader[id].State = "SUCCEEDED"
+	f.boards[id] = score
+}
+
+func lastEvent(schedule *models.RetrainingSchedule) models.RetrainingEvent {
+	return schedule.History[len(schedule.History)-1]
+}
+
+// --- Tests ---
+
+func TestRetrainingRepository_CronScheduleRegistersImprovedModels(t *testing.T) {
+	f := newRetrainingFixture()
+	ctx := context.Background()
+
+	schedule, err := f.repo.CreateSchedule(ctx, "ns", models.CreateRetrainingScheduleRequest{
+		DisplayName:  "Weekly churn",
+		SourceRunID:  "source",
+		Trigger:      models.RetrainingTrigger{Type: models.RetrainingTriggerCron, Cron: "0 3 * *  1"},
+		Registration: &models.RetrainingRegistration{RegistryID: "registry-uid", ModelName: "churn"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Metric != "roc_auc" || schedule.Champion == nil || schedule.Champion.Score != 0.90 {
+		t.Errorf("schedule = %+v, want roc_auc champion from the source run", schedule)
+	}
+	if len(f.runs.recurringInputs) != 1 {
+		t.Fatalf("recurring runs created = %d, want 1", len(f.runs.recurringInputs))
+	}
+	input := f.runs.recurringInputs[0]
+	if input.Trigger.CronSchedule.Cron != "0 0 3 * * 1" || input.MaxConcurrency != 1 || !input.NoCatchup {
+		t.Errorf("recurring run input = %+v", input)
+	}
+	if schedule.RecurringRunID != "rr-1" || f.store.data["schedule-1"] == "" {
+		t.Errorf("schedule was not stored with its recurring run: %+v", schedule)
+	}
+
+	// Two runs started by the pipeline server: the first beats the champion, the second does not.
+	for _, id := range []string{"cron-1", "cron-2"} {
+		f.runs.fakeRunReader[id] = &models.PipelineRun{RunID: id, State: "RUNNING", PipelineType: constants.PipelineTypeTabular}
+	}
+	f.runs.recurringRuns = []models.PipelineRun{
+		{RunID: "cron-1", CreatedAt: "2024-02-05T03:00:00Z"},
+	}
+	schedule, err = f.repo.SyncSchedule(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(schedule.PendingRunIDs, ",") != "cron-1" {
+		t.Errorf("PendingRunIDs = %v, want [cron-1]", schedule.PendingRunIDs)
+	}
+
+	f.finishRun("cron-1", 0.93)
+	schedule, err = f.repo.SyncSchedule(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event := lastEvent(schedule); event.Type != models.RetrainingEventModelRegistered || event.RunID != "cron-1" {
+		t.Errorf("last event = %+v, want model_registered for cron-1", event)
+	}
+	if len(f.registry.models) != 1 || f.registry.models[0].VersionName != "retrain-cron-1" ||
+		f.registry.models[0].S3Path != "pipeline/cron-1/models_artifact/CatBoost_FULL/predictor" {
+		t.Errorf("registered models = %+v", f.registry.models)
+	}
+	if schedule.Registration.RegisteredModelID != "rm-1" || schedule.Champion.RunID != "cron-1" {
+		t.Errorf("schedule = %+v, want rm-1 stored and cron-1 as champion", schedule)
+	}
+
+	f.runs.recurringRuns = append(f.runs.recurringRuns, models.PipelineRun{RunID: "cron-2", CreatedAt: "2024-02-12T03:00:00Z"})
+	f.finishRun("cron-2", 0.92)
+	schedule, err = f.repo.SyncSchedule(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event := lastEvent(schedule); event.Type != models.RetrainingEventModelNotImproved || event.RunID != "cron-2" {
+		t.Errorf("last event = %+v, want model_not_improved for cron-2", event)
+	}
+	if len(f.registry.versions) != 0 || len(schedule.PendingRunIDs) != 0 {
+		t.Errorf("versions = %v, pending = %v, want none", f.registry.versions, schedule.PendingRunIDs)
+	}
+	if strings.Join(schedule.EvaluatedRunIDs, ",") != "cron-1,cron-2" {
+		t.Errorf("EvaluatedRunIDs = %v", schedule.EvaluatedRunIDs)
+	}
+}
+
+func TestRetrainingRepository_S3PrefixTriggersOnNewData(t *testing.T) {
+	f := newRetrainingFixture()
+	ctx := context.Background()
+
+	schedule, err := f.repo.CreateSchedule(ctx, "ns", models.CreateRetrainingScheduleRequest{
+		DisplayName: "Monthly churn",
+		SourceRunID: "source",
+		Trigger:     models.RetrainingTrigger{Type: models.RetrainingTriggerS3Prefix, S3Prefix: "churn/"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.LastObjectKey != "churn/2024-01.csv" {
+		t.Errorf("LastObjectKey = %q, want existing data to be skipped", schedule.LastObjectKey)
+	}
+
+	schedule, err = f.repo.SyncSchedule(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.runs.runInputs) != 0 {
+		t.Fatalf("runs started without new data: %d", len(f.runs.runInputs))
+	}
+
+	f.objects.objects = append(f.objects.objects, s3.ObjectInfo{Key: "churn/2024-02.csv", LastModified: "2024-02-29T00:00:00Z"})
+	schedule, err = f.repo.SyncSchedule(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.runs.runInputs) != 1 {
+		t.Fatalf("runs started = %d, want 1", len(f.runs.runInputs))
+	}
+	params := f.runs.runInputs[0].RuntimeConfig.Parameters
+	if params["train_data_file_key"] != "churn/2024-02.csv" || params["eval_metric"] != "roc_auc" {
+		t.Errorf("run parameters = %v", params)
+	}
+	if schedule.LastObjectKey != "churn/2024-02.csv" || strings.Join(schedule.PendingRunIDs, ",") != "retrain-1" {
+		t.Errorf("schedule = %+v", schedule)
+	}
+
+	// New data waits while a run is pending.
+	f.objects.objects = append(f.objects.objects, s3.ObjectInfo{Key: "churn/2024-03.csv", LastModified: "2024-03-31T00:00:00Z"})
+	if _, err := f.repo.SyncSchedule(ctx, "ns", "schedule-1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.runs.runInputs) != 1 {
+		t.Errorf("runs started = %d, want 1 while a run is pending", len(f.runs.runInputs))
+	}
+
+	f.finishRun("retrain-1", 0.95)
+	schedule, err = f.repo.SyncSchedule(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Champion.RunID != "retrain-1" || len(f.runs.runInputs) != 2 {
+		t.Errorf("champion = %+v, runs = %d; want retrain-1 promoted and the March file started", schedule.Champion, len(f.runs.runInputs))
+	}
+}
+
+func TestRetrainingRepository_S3PrefixTriggerFailureRetries(t *testing.T) {
+	f := newRetrainingFixture()
+	ctx := context.Background()
+	if _, err := f.repo.CreateSchedule(ctx, "ns", models.CreateRetrainingScheduleRequest{
+		DisplayName: "Churn",
+		SourceRunID: "source",
+		Trigger:     models.RetrainingTrigger{Type: models.RetrainingTriggerS3Prefix, S3Prefix: "churn"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	f.objects.objects = append(f.objects.objects, s3.ObjectInfo{Key: "churn/2024-02.csv", LastModified: "2024-02-29T00:00:00Z"})
+	f.runs.createRunErr = pipelines.ErrDSPANotReady
+	schedule, err := f.repo.SyncSchedule(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event := lastEvent(schedule); event.Type != models.RetrainingEventTriggerFailed {
+		t.Errorf("last event = %+v, want trigger_failed", event)
+	}
+	if schedule.LastObjectKey != "churn/2024-01.csv" {
+		t.Errorf("LastObjectKey = %q, want the failed object released", schedule.LastObjectKey)
+	}
+
+	f.runs.createRunErr = nil
+	if _, err := f.repo.SyncSchedule(ctx, "ns", "schedule-1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.runs.runInputs) != 1 {
+		t.Errorf("runs started = %d, want the retry to start one", len(f.runs.runInputs))
+	}
+}
+
+func TestRetrainingRepository_DeleteSchedule(t *testing.T) {
+	f := newRetrainingFixture()
+	ctx := context.Background()
+	if _, err := f.repo.CreateSchedule(ctx, "ns", models.CreateRetrainingScheduleRequest{
+		DisplayName: "Nightly",
+		SourceRunID: "source",
+		Trigger:     models.RetrainingTrigger{Type: models.RetrainingTriggerCron, Cron: "0 2 * * *"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.repo.DeleteSchedule(ctx, "ns", "schedule-1"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(f.runs.deletedRecurring, ",") != "rr-1" {
+		t.Errorf("deleted recurring runs = %v", f.runs.deletedRecurring)
+	}
+	schedules, err := f.repo.ListSchedules(ctx, "ns")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schedules) != 0 {
+		t.Errorf("schedules = %+v, want none", schedules)
+	}
+	if err := f.repo.DeleteSchedule(ctx, "ns", "schedule-1"); !errors.Is(err, ErrRetrainingScheduleNotFound) {
+		t.Errorf("expected ErrRetrainingScheduleNotFound, got %v", err)
+	}
+}
+
+func TestRetrainingRepository_SyncAllSchedules(t *testing.T) {
+	f := newRetrainingFixture()
+	ctx := context.Background()
+	if _, err := f.repo.CreateSchedule(ctx, "ns", models.CreateRetrainingScheduleRequest{
+		DisplayName: "Nightly",
+		SourceRunID: "source",
+		Trigger:     models.RetrainingTrigger{Type: models.RetrainingTriggerCron, Cron: "0 2 * * *"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	f.store.namespaces = map[string]bool{"ns": true, "openshift-monitoring": false}
+	f.store.readNamespaces = nil
+	f.runs.fakeRunReader["cron-1"] = &models.PipelineRun{RunID: "cron-1", State: "RUNNING", PipelineType: constants.PipelineTypeTabular}
+	f.runs.recurringRuns = []models.PipelineRun{{RunID: "cron-1", CreatedAt: "2024-02-05T02:00:00Z"}}
+
+	result, err := f.repo.SyncAllSchedules(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Synced != 1 || len(result.Failures) != 0 {
+		t.Errorf("result = %+v, want one schedule synced", result)
+	}
+	for _, namespace := range f.store.readNamespaces {
+		if namespace != "ns" {
+			t.Errorf("read schedules of %q, want only dashboard projects", namespace)
+		}
+	}
+	schedule, err := f.repo.GetSchedule(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.LastSyncedAt == "" || strings.Join(schedule.PendingRunIDs, ",") != "cron-1" {
+		t.Errorf("schedule = %+v, want cron-1 collected by the sync", schedule)
+	}
+}
+
+func TestRetrainingRepository_ConcurrentWriteConflicts(t *testing.T) {
+	f := newRetrainingFixture()
+	ctx := context.Background()
+	if _, err := f.repo.CreateSchedule(ctx, "ns", models.CreateRetrainingScheduleRequest{
+		DisplayName: "Nightly",
+		SourceRunID: "source",
+		Trigger:     models.RetrainingTrigger{Type: models.RetrainingTriggerCron, Cron: "0 2 * * *"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	set, schedule, err := f.repo.get(ctx, "ns", "schedule-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.store.version++ // another writer updated the ConfigMap
+	if err := f.repo.save(ctx, "ns", set, schedule); !errors.Is(err, ErrRetrainingScheduleConflict) {
+		t.Errorf("expected ErrRetrainingScheduleConflict, got %v", err)
+	}
+}
+
+func TestRetrainingRepository_CreateValidation(t *testing.T) {
+	valid := func() models.CreateRetrainingScheduleRequest {
+		return models.CreateRetrainingScheduleRequest{
+			DisplayName: "Nightly",
+			SourceRunID: "source",
+			Trigger:     models.RetrainingTrigger{Type: models.RetrainingTriggerCron, Cron: "0 2 * * *"},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(*models.CreateRetrainingScheduleRequest)
+		wantErr error
+	}{
+		{"missing display name", func(r *models.CreateRetrainingScheduleRequest) { r.DisplayName = " " }, ErrValidation},
+		{"unknown trigger", func(r *models.CreateRetrainingScheduleRequest) { r.Trigger.Type = "webhook" }, ErrValidation},
+		{"six-field cron", func(r *models.CreateRetrainingScheduleRequest) { r.Trigger.Cron = "0 0 2 * * *" }, ErrValidation},
+		{"cron with prefix", func(r *models.CreateRetrainingScheduleRequest) { r.Trigger.S3Prefix = "data/" }, ErrValidation},
+		{"prefix traversal", func(r *models.CreateRetrainingScheduleRequest) {
+			r.Trigger = models.RetrainingTrigger{Type: models.RetrainingTriggerS3Prefix, S3Prefix: "../other"}
+		}, ErrValidation},
+		{"registration without model", func(r *models.CreateRetrainingScheduleRequest) {
+			r.Registration = &models.RetrainingRegistration{RegistryID: "registry-uid"}
+		}, ErrValidation},
+		{"unknown registry", func(r *models.CreateRetrainingScheduleRequest) {
+			r.Registration = &models.RetrainingRegistration{RegistryID: "other", ModelName: "churn"}
+		}, ErrModelRegistryNotFound},
+		{"unknown source run", func(r *models.CreateRetrainingScheduleRequest) { r.SourceRunID = "missing" }, ErrPipelineRunNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRetrainingFixture()
+			req := valid()
+			tt.mutate(&req)
+			if _, err := f.repo.CreateSchedule(context.Background(), "ns", req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(f.runs.recurringInputs) != 0 || f.store.exists {
+				t.Error("nothing should be created for an invalid request")
+			}
+		})
+	}
+
+	t.Run("schedule limit", func(t *testing.T) {
+		f := newRetrainingFixture()
+		f.store.exists = true
+		f.store.data = map[string]string{}
+		for i := 0; i < MaxRetrainingSchedules; i++ {
+			f.store.data[fmt.Sprintf("s-%d", i)] = "{}"
+		}
+		if _, err := f.repo.CreateSchedule(context.Background(), "ns", valid()); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected ErrValidation, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+
+	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+// ErrRunArtifactNotFound is returned when a run has no output artifact with the
+// requested task and name.
+var ErrRunArtifactNotFound = errors.New("run artifact not found")
+
+type runDetailsService interface {
+	GetRunDAG(ctx context.Context, namespace, runID string) (*pipelines.RunDAG, error)
+	StreamTaskLogs(ctx context.Context, namespace, runID, taskID string, opts pipelines.TaskLogOptions) (io.ReadCloser, error)
+	ListRunArtifacts(ctx context.Context, namespace, runID string) ([]pipelines.RunArtifact, error)
+}
+
+// RunDetailsRepository exposes the task graph, task logs and output artifacts of AutoML
+// runs. Every call checks that the run belongs to an AutoML pipeline first, so other
+// runs in the namespace are not reachable through it. Artifacts are read through the
+// DSPA object storage of the namespace.
+type RunDetailsRepository struct {
+	runs      managedRunReader
+	core      runDetailsService
+	artifacts artifactReader
+	logger    *slog.Logger
+}
+
+func NewRunDetailsRepository(logger *slog.Logger, runs managedRunReader, core runDetailsService, artifacts artifactReader) *RunDetailsRepository {
+	return &RunDetailsRepository{
+		runs:      runs,
+		core:      core,
+		artifacts: artifacts,
+		logger:    logger,
+	}
+}
+
+func (r *RunDetailsRepository) GetRunDAG(ctx context.Context, namespace, runID string) (*pipelines.RunDAG, error) {
+	if _, err := r.runs.GetManagedRun(ctx, namespace, runID); err != nil {
+		return nil, err
+	}
+	return r.core.GetRunDAG(ctx, namespace, runID)
+}
+
+// StreamTaskLogs returns the executor logs of a run task. The caller must close the reader.
+func (r *RunDetailsRepository) StreamTaskLogs(ctx context.Context, namespace, runID, taskID string, opts pipelines.TaskLogOptions) (io.ReadCloser, error) {
+	if _, err := r.runs.GetManagedRun(ctx, namespace, runID); err != nil {
+		return nil, err
+	}
+	return r.core.StreamTaskLogs(ctx, namespace, runID, taskID, opts)
+}
+
+func (r *RunDetailsRepository) ListRunArtifacts(ctx context.Context, namespace, runID string) ([]pipelines.RunArtifact, error) {
+	if _, err := r.runs.GetManagedRun(ctx, namespace, runID); err != nil {
+		return nil, err
+	}
+	return r.core.ListRunArtifacts(ctx, namespace, runID)
+}
+
+// ListRunArtifactFiles lists one level of a directory artifact such as a model. dir is
+// relative to the artifact; keys in the result are full object keys.
+func (r *RunDetailsRepository) ListRunArtifactFiles(ctx context.Context, namespace, runID, taskName, name, dir, next string) (*s3.ListObjectsResponse, error) {
+	artifact, err := r.downloadableArtifact(ctx, namespace, runID, taskName, name)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := artifactObjectKey(artifact, dir)
+	if err != nil {
+		return nil, err
+	}
+	return r.artifacts.ListObjects(ctx, S3RequestContext{Namespace: namespace}, s3.ListObjectsOptions{Path: prefix + "/", Next: next})
+}
+
+// GetRunArtifact opens an artifact for download. For a directory artifact, file names
+// the object inside it, relative to the artifact. The caller must close the body.
+func (r *RunDetailsRepository) GetRunArtifact(ctx context.Context, namespace, runID, taskName, name, file string) (*GetObjectResult, string, error) {
+	artifact, err := r.downloadableArtifact(ctx, namespace, runID, taskName, name)
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := artifactObjectKey(artifact, file)
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := r.artifacts.GetObject(ctx, S3RequestContext{Namespace: namespace}, key)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, key, nil
+}
+
+func (r *RunDetailsRepository) downloadableArtifact(ctx context.Context, namespace, runID, taskName, name string) (*pipelines.RunArtifact, error) {
+	if taskName == "" || name == "" {
+		return nil, NewValidationError("task and artifact name are required")
+	}
+	artifacts, err := r.ListRunArtifacts(ctx, namespace, runID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range artifacts {
+		if artifacts[i].TaskName != taskName || artifacts[i].Name != name {
+			continue
+		}
+		if !artifacts[i].Downloadable {
+			return nil, NewValidationError(fmt.Sprintf("artifact %q of task %q is not stored in the pipeline server bucket", name, taskName))
+		}
+		return &artifacts[i], nil
+	}
+	return nil, fmt.Errorf("%w: %s/%s", ErrRunArtifactNotFound, taskName, name)
+}
+
+// artifactObjectKey resolves rel inside the artifact, refusing paths that escape it.
+func artifactObjectKey(artifact *pipelines.RunArtifact, rel string) (string, error) {
+	rel = strings.Trim(rel, "/")
+	if rel == "" {
+		return artifact.Key, nil
+	}
+	if cleaned := path.Clean(rel); cleaned != rel || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", NewValidationError(fmt.Sprintf("invalid artifact path %q", rel))
+	}
+	return artifact.Key + "/" + rel, nil
+}
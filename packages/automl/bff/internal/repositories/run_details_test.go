@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/automl-library/bff/internal/constants"
+	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+func newTestRunDetailsRepository(core *mockPipelinesService, store *fakeArtifactStore) *RunDetailsRepository {
+	runs := fakeRunReader{"run-1": succeededRun("run-1", constants.PipelineTypeTabular, nil)}
+	return NewRunDetailsRepository(slog.Default(), runs, core, store)
+}
+
+func runArtifactsService(artifacts ...pipelines.RunArtifact) *mockPipelinesService {
+	return &mockPipelinesService{
+		listRunArtifactsFn: func(ctx context.Context, namespace, runID string) ([]pipelines.RunArtifact, error) {
+			return artifacts, nil
+		},
+	}
+}
+
+func TestRunDetailsRepository_ChecksOwnership(t *testing.T) {
+	called := false
+	core := &mockPipelinesService{
+		getRunDAGFn: func(ctx context.Context, namespace, runID string) (*pipelines.RunDAG, error) {
+			called = true
+			return &pipelines.RunDAG{RunID: runID}, nil
+		},
+		streamTaskLogsFn: func(ctx context.Context, namespace, runID, taskID string, opts pipelines.TaskLogOptions) (io.ReadCloser, error) {
+			called = true
+			return io.NopCloser(strings.NewReader("")), nil
+		},
+	}
+	repo := newTestRunDetailsRepository(core, &fakeArtifactStore{})
+
+	if _, err := repo.GetRunDAG(context.Background(), "ns", "other-run"); !errors.Is(err, ErrPipelineRunNotFound) {
+		t.Errorf("expected ErrPipelineRunNotFound, got %v", err)
+	}
+	if _, err := repo.StreamTaskLogs(context.Background(), "ns", "other-run", "task-1", pipelines.TaskLogOptions{}); !errors.Is(err, ErrPipelineRunNotFound) {
+		t.Errorf("expected ErrPipelineRunNotFound, got %v", err)
+	}
+	if called {
+		t.Error("core service must not be called for runs of other pipelines")
+	}
+
+	dag, err := repo.GetRunDAG(context.Background(), "ns", "run-1")
+	if err != nil || dag.RunID != "run-1" {
+		t.Errorf("expected DAG of run-1, got %v, %v", dag, err)
+	}
+}
+
+func TestRunDetailsRepository_GetRunArtifact(t *testing.T) {
+	model := pipelines.RunArtifact{TaskName: "train", Name: "model", Key: "p/run-1/train/7/model", Bucket: "pipelines", Downloadable: true}
+	external := pipelines.RunArtifact{TaskName: "train", Name: "report", Key: "r/run-1/train/7/report", Bucket: "other"}
+	store := &fakeArtifactStore{objects: map[string]string{
+		"p/run-1/train/7/model":                   "single",
+		"p/run-1/train/7/model/weights/model.pkl": "weights",
+	}}
+	repo := newTestRunDetailsRepository(runArtifactsService(model, external), store)
+
+	for _, tt := range []struct {
+		name, task, artifact, file string
+		wantKey                    string
+		wantErr                    error
+	}{
+		{name: "single file artifact", task: "train", artifact: "model", wantKey: "p/run-1/train/7/model"},
+		{name: "file inside directory artifact", task: "train", artifact: "model", file: "weights/model.pkl", wantKey: "p/run-1/train/7/model/weights/model.pkl"},
+		{name: "path escaping the artifact", task: "train", artifact: "model", file: "../8/model", wantErr: ErrValidation},
+		{name: "unknown artifact", task: "train", artifact: "missing", wantErr: ErrRunArtifactNotFound},
+		{name: "outside DSPA bucket", task: "train", artifact: "report", wantErr: ErrValidation},
+		{name: "missing task", artifact: "model", wantErr: ErrValidation},
+		{name: "object missing", task: "train", artifact: "model", file: "absent.bin", wantErr: s3.ErrObjectNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, key, err := repo.GetRunArtifact(context.Background(), "ns", "run-1", tt.task, tt.artifact, tt.file)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer result.Body.Close()
+			if key != tt.wantKey {
+				t.Errorf("expected key %q, got %q", tt.wantKey, key)
+			}
+		})
+	}
+}
+
+func TestRunDetailsRepository_ListRunArtifactFiles(t *testing.T) {
+	model := pipelines.RunArtifact{TaskName: "train", Name: "model", Key: "p/run-1/train/7/model", Downloadable: true}
+	store := &fakeArtifactStore{objects: map[string]string{
+		"p/run-1/train/7/model/weights/model.pkl": "weights",
+		"p/run-1/train/7/model/config/a.json":     "{}",
+	}}
+	repo := newTestRunDetailsRepository(runArtifactsService(model), store)
+
+	result, err := repo.ListRunArtifactFiles(context.Background(), "ns", "run-1", "train", "model", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.CommonPrefixes) != 2 || result.CommonPrefixes[0].Prefix != "p/run-1/train/7/model/config/" {
+		t.Errorf("unexpected listing: %+v", result.CommonPrefixes)
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"io"
 	"log/slog"
 	"mime"
+	"net/http"
 	"strings"
 	"time"
 
 	helper "github.com/opendatahub-io/automl-library/bff/internal/helpers"
+	"github.com/opendatahub-io/automl-library/bff/internal/models"
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
 	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
 	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
@@ -265,6 +267,40 @@ func (r *S3Repository) GetCSVSchema(ctx context.Context, req S3RequestContext, k
 	return helper.InferCSVSchema(body)
 }
 
+// csvProfileMaxBytes bounds the bytes fetched for profiling. Profiling stops at the
+// requested row count, so smaller files and narrow rows transfer less than this.
+const csvProfileMaxBytes int64 = 16 << 20 // 16 MiB
+
+// csvProfileRange is the HTTP Range header matching csvProfileMaxBytes.
+var csvProfileRange = fmt.Sprintf("bytes=0-%d", csvProfileMaxBytes-1)
+
+// GetCSVProfile resolves credentials from req, streams up to sampleRows data rows of
+// the CSV at key from S3, and returns per-column statistics, warnings and suggested
+// run columns. sampleRows of 0 uses helper.DefaultProfileSampleRows. Column names
+// rejected by ValidateASCIIColumnNames are reported and never suggested.
+func (r *S3Repository) GetCSVProfile(ctx context.Context, req S3RequestContext, key string, sampleRows int) (helper.CSVProfileResult, error) {
+	if !strings.HasSuffix(strings.ToLower(key), ".csv") {
+		return helper.CSVProfileResult{}, fmt.Errorf("%w: only CSV files are supported (must have .csv extension)", ErrCSVUploadValidation)
+	}
+
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return helper.CSVProfileResult{}, err
+	}
+
+	body, _, err := r.s3Service.GetObject(ctx, opts, s3.GetObjectInput{Bucket: bucket, Key: key, Range: csvProfileRange})
+	if err != nil {
+		return helper.CSVProfileResult{}, fmt.Errorf("error retrieving CSV file from S3: %w", err)
+	}
+	defer body.Close()
+
+	return helper.ProfileCSV(body, helper.CSVProfileOptions{
+		MaxRows:         sampleRows,
+		MaxBytes:        csvProfileMaxBytes,
+		ColumnNameError: profileColumnNameError,
+	})
+}
+
 // extractAWSS3ConnectionOptions extracts S3 connection options from a Kubernetes secret's
 // raw data using the AWS_* key convention used by RHOAI/ODH data connection secrets.
 // Also returns the optional default bucket (AWS_S3_BUCKET) separately.
@@ -357,6 +393,188 @@ func (r *S3Repository) UploadCSVFile(ctx context.Context, req S3RequestContext,
 	})
 }
 
+// --- Resumable uploads ---
+// Large CSV files are uploaded with S3 multipart upload: the browser starts an upload,
+// sends parts (in parallel, retrying or resuming as needed) and completes it. Every call
+// after the first carries the resolved key and upload ID returned by CreateCSVUpload.
+
+// CreateCSVUpload validates the upload is a CSV, resolves a non-colliding key and starts
+// a multipart upload for it. maxAttempts of 0 uses the default (10).
+func (r *S3Repository) CreateCSVUpload(ctx context.Context, req S3RequestContext, key, rawContentType string, maxAttempts int) (*s3.MultipartUpload, error) {
+	contentType, err := ValidateCsvUpload(rawContentType, key)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxCollisionAttempts
+	}
+
+	keyCtx, cancel := context.WithTimeout(ctx, s3KeyResolutionTimeout)
+	defer cancel()
+
+	resolvedKey, err := r.s3Service.ResolveNonCollidingKey(keyCtx, opts, s3.ResolveNonCollidingKeyInput{
+		Bucket:      bucket,
+		Key:         key,
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.s3Service.CreateMultipartUpload(keyCtx, opts, s3.CreateMultipartUploadInput{
+		Bucket:      bucket,
+		Key:         resolvedKey,
+		ContentType: contentType,
+	})
+}
+
+// UploadPart resolves credentials from req and uploads one part of a multipart upload.
+// body must hold exactly size bytes.
+func (r *S3Repository) UploadPart(ctx context.Context, req S3RequestContext, key, uploadID string, partNumber int32, body io.ReadSeeker, size int64) (*s3.UploadedPart, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return r.s3Service.UploadPart(ctx, opts, s3.UploadPartInput{
+		Bucket:        bucket,
+		Key:           key,
+		UploadID:      uploadID,
+		PartNumber:    partNumber,
+		Body:          body,
+		ContentLength: size,
+	})
+}
+
+// ListUploadParts resolves credentials from req and lists the parts stored for an upload.
+func (r *S3Repository) ListUploadParts(ctx context.Context, req S3RequestContext, key, uploadID string) ([]s3.UploadedPart, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return r.s3Service.ListParts(ctx, opts, s3.ListPartsInput{Bucket: bucket, Key: key, UploadID: uploadID})
+}
+
+// CompleteUpload resolves credentials from req and completes an upload. An empty parts
+// list completes the upload with every stored part.
+// Returns ErrObjectAlreadyExists if another writer created the key in the meantime.
+func (r *S3Repository) CompleteUpload(ctx context.Context, req S3RequestContext, key, uploadID string, parts []s3.UploadedPart) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.CompleteMultipartUpload(ctx, opts, s3.CompleteMultipartUploadInput{
+		Bucket:   bucket,
+		Key:      key,
+		UploadID: uploadID,
+		Parts:    parts,
+	})
+}
+
+// AbortUpload resolves credentials from req and aborts an upload, discarding its parts.
+func (r *S3Repository) AbortUpload(ctx context.Context, req S3RequestContext, key, uploadID string) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.AbortMultipartUpload(ctx, opts, s3.AbortMultipartUploadInput{Bucket: bucket, Key: key, UploadID: uploadID})
+}
+
+// --- Object management ---
+
+// CopyObject resolves credentials from req and copies sourceKey to destinationKey.
+// Returns ErrObjectAlreadyExists if destinationKey exists.
+func (r *S3Repository) CopyObject(ctx context.Context, req S3RequestContext, sourceKey, destinationKey string) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.CopyObject(ctx, opts, s3.CopyObjectInput{Bucket: bucket, SourceKey: sourceKey, DestinationKey: destinationKey})
+}
+
+// MoveObject resolves credentials from req and renames sourceKey to destinationKey.
+// Returns ErrObjectAlreadyExists if destinationKey exists.
+func (r *S3Repository) MoveObject(ctx context.Context, req S3RequestContext, sourceKey, destinationKey string) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.MoveObject(ctx, opts, s3.MoveObjectInput{Bucket: bucket, SourceKey: sourceKey, DestinationKey: destinationKey})
+}
+
+// DeleteObject resolves credentials from req and deletes key.
+func (r *S3Repository) DeleteObject(ctx context.Context, req S3RequestContext, key string) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.DeleteObject(ctx, opts, s3.DeleteObjectInput{Bucket: bucket, Key: key})
+}
+
+// DeletePrefix resolves credentials from req and deletes every object under prefix.
+// Without a confirmation token it only reports what would be deleted (see s3.DeletePrefixInput).
+func (r *S3Repository) DeletePrefix(ctx context.Context, req S3RequestContext, prefix, confirmationToken string) (*s3.DeletePrefixResult, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return r.s3Service.DeletePrefix(ctx, opts, s3.DeletePrefixInput{Bucket: bucket, Prefix: prefix, ConfirmationToken: confirmationToken})
+}
+
+// PresignDownload resolves credentials from req and returns a presigned GET URL for key.
+func (r *S3Repository) PresignDownload(ctx context.Context, req S3RequestContext, key string, expires time.Duration) (*models.S3PresignedURL, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	presigned, err := r.s3Service.PresignObject(ctx, opts, s3.PresignInput{Bucket: bucket, Key: key, Method: http.MethodGet, Expires: expires})
+	if err != nil {
+		return nil, err
+	}
+	return &models.S3PresignedURL{Key: key, PresignedURL: *presigned}, nil
+}
+
+// PresignCSVUpload validates that key names a CSV file, resolves a non-colliding key like
+// UploadCSVFile and returns a presigned PUT URL for it. The URL cannot replace an existing
+// object. maxAttempts of 0 uses the default (10).
+func (r *S3Repository) PresignCSVUpload(ctx context.Context, req S3RequestContext, key string, expires time.Duration, maxAttempts int) (*models.S3PresignedURL, error) {
+	if _, err := ValidateCsvUpload("", key); err != nil {
+		return nil, err
+	}
+
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxCollisionAttempts
+	}
+
+	keyCtx, cancel := context.WithTimeout(ctx, s3KeyResolutionTimeout)
+	defer cancel()
+
+	resolvedKey, err := r.s3Service.ResolveNonCollidingKey(keyCtx, opts, s3.ResolveNonCollidingKeyInput{
+		Bucket:      bucket,
+		Key:         key,
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	presigned, err := r.s3Service.PresignObject(ctx, opts, s3.PresignInput{Bucket: bucket, Key: resolvedKey, Method: http.MethodPut, Expires: expires})
+	if err != nil {
+		return nil, err
+	}
+	return &models.S3PresignedURL{Key: resolvedKey, PresignedURL: *presigned}, nil
+}
+
 // ValidateCsvUpload validates that a multipart upload is a CSV file and returns "text/csv".
 // contentType is the raw Content-Type header; filename is the part's filename.
 // Accepts: text/csv; application/octet-stream or empty Content-Type when filename ends with .csv.
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	helper "github.com/opendatahub-io/automl-library/bff/internal/helpers"
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
 	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
 	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
@@ -69,6 +72,9 @@ func (m *mockK8sService) PatchResource(context.Context, schema.GroupVersionResou
 func (m *mockK8sService) PatchDeployment(context.Context, string, string, types.PatchType, []byte) error {
 	return nil
 }
+func (m *mockK8sService) GetPodLogs(context.Context, string, string, *v1.PodLogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
 
 type mockS3Service struct {
 	getObjectFn              func(ctx context.Context, opts s3.ConnectionOptions, input s3.GetObjectInput) (io.ReadCloser, string, error)
@@ -77,6 +83,16 @@ type mockS3Service struct {
 	listObjectsFn            func(ctx context.Context, opts s3.ConnectionOptions, query s3.ListObjectsQuery) (*s3.ListObjectsResponse, error)
 	objectExistsFn           func(ctx context.Context, opts s3.ConnectionOptions, input s3.ObjectExistsInput) (bool, error)
 	resolveNonCollidingKeyFn func(ctx context.Context, opts s3.ConnectionOptions, input s3.ResolveNonCollidingKeyInput) (string, error)
+	createMPUFn              func(ctx context.Context, opts s3.ConnectionOptions, input s3.CreateMultipartUploadInput) (*s3.MultipartUpload, error)
+	uploadPartFn             func(ctx context.Context, opts s3.ConnectionOptions, input s3.UploadPartInput) (*s3.UploadedPart, error)
+	listPartsFn              func(ctx context.Context, opts s3.ConnectionOptions, input s3.ListPartsInput) ([]s3.UploadedPart, error)
+	completeMPUFn            func(ctx context.Context, opts s3.ConnectionOptions, input s3.CompleteMultipartUploadInput) error
+	abortMPUFn               func(ctx context.Context, opts s3.ConnectionOptions, input s3.AbortMultipartUploadInput) error
+	copyObjectFn             func(ctx context.Context, opts s3.ConnectionOptions, input s3.CopyObjectInput) error
+	moveObjectFn             func(ctx context.Context, opts s3.ConnectionOptions, input s3.MoveObjectInput) error
+	deleteObjectFn           func(ctx context.Context, opts s3.ConnectionOptions, input s3.DeleteObjectInput) error
+	deletePrefixFn           func(ctx context.Context, opts s3.ConnectionOptions, input s3.DeletePrefixInput) (*s3.DeletePrefixResult, error)
+	presignObjectFn          func(ctx context.Context, opts s3.ConnectionOptions, input s3.PresignInput) (*s3.PresignedURL, error)
 }
 
 func (m *mockS3Service) GetObject(ctx context.Context, opts s3.ConnectionOptions, input s3.GetObjectInput) (io.ReadCloser, string, error) {
@@ -97,6 +113,36 @@ func (m *mockS3Service) ObjectExists(ctx context.Context, opts s3.ConnectionOpti
 func (m *mockS3Service) ResolveNonCollidingKey(ctx context.Context, opts s3.ConnectionOptions, input s3.ResolveNonCollidingKeyInput) (string, error) {
 	return m.resolveNonCollidingKeyFn(ctx, opts, input)
 }
+func (m *mockS3Service) CreateMultipartUpload(ctx context.Context, opts s3.ConnectionOptions, input s3.CreateMultipartUploadInput) (*s3.MultipartUpload, error) {
+	return m.createMPUFn(ctx, opts, input)
+}
+func (m *mockS3Service) UploadPart(ctx context.Context, opts s3.ConnectionOptions, input s3.UploadPartInput) (*s3.UploadedPart, error) {
+	return m.uploadPartFn(ctx, opts, input)
+}
+func (m *mockS3Service) ListParts(ctx context.Context, opts s3.ConnectionOptions, input s3.ListPartsInput) ([]s3.UploadedPart, error) {
+	return m.listPartsFn(ctx, opts, input)
+}
+func (m *mockS3Service) CompleteMultipartUpload(ctx context.Context, opts s3.ConnectionOptions, input s3.CompleteMultipartUploadInput) error {
+	return m.completeMPUFn(ctx, opts, input)
+}
+func (m *mockS3Service) AbortMultipartUpload(ctx context.Context, opts s3.ConnectionOptions, input s3.AbortMultipartUploadInput) error {
+	return m.abortMPUFn(ctx, opts, input)
+}
+func (m *mockS3Service) CopyObject(ctx context.Context, opts s3.ConnectionOptions, input s3.CopyObjectInput) error {
+	return m.copyObjectFn(ctx, opts, input)
+}
+func (m *mockS3Service) MoveObject(ctx context.Context, opts s3.ConnectionOptions, input s3.MoveObjectInput) error {
+	return m.moveObjectFn(ctx, opts, input)
+}
+func (m *mockS3Service) DeleteObject(ctx context.Context, opts s3.ConnectionOptions, input s3.DeleteObjectInput) error {
+	return m.deleteObjectFn(ctx, opts, input)
+}
+func (m *mockS3Service) DeletePrefix(ctx context.Context, opts s3.ConnectionOptions, input s3.DeletePrefixInput) (*s3.DeletePrefixResult, error) {
+	return m.deletePrefixFn(ctx, opts, input)
+}
+func (m *mockS3Service) PresignObject(ctx context.Context, opts s3.ConnectionOptions, input s3.PresignInput) (*s3.PresignedURL, error) {
+	return m.presignObjectFn(ctx, opts, input)
+}
 
 type mockPipelinesServiceForS3 struct {
 	discoverReadyDSPAFn func(ctx context.Context, namespace string) (*pipelines.DiscoveredDSPA, error)
@@ -119,6 +165,15 @@ func (m *mockPipelinesServiceForS3) ListPipelineRuns(context.Context, string, *p
 func (m *mockPipelinesServiceForS3) TerminateRun(context.Context, string, string) error { return nil }
 func (m *mockPipelinesServiceForS3) RetryRun(context.Context, string, string) error     { return nil }
 func (m *mockPipelinesServiceForS3) DeleteRun(context.Context, string, string) error    { return nil }
+func (m *mockPipelinesServiceForS3) CreateRecurringRun(context.Context, string, *pipelines.CreateRecurringRunInput) (*pipelines.RecurringRun, error) {
+	return nil, nil
+}
+func (m *mockPipelinesServiceForS3) GetRecurringRun(context.Context, string, string) (*pipelines.RecurringRun, error) {
+	return nil, nil
+}
+func (m *mockPipelinesServiceForS3) DeleteRecurringRun(context.Context, string, string) error {
+	return nil
+}
 func (m *mockPipelinesServiceForS3) ListPipelines(context.Context, string, string) (*pipelines.PipelinesResponse, error) {
 	return nil, nil
 }
@@ -149,6 +204,15 @@ func (m *mockPipelinesServiceForS3) GetAllPipelineRuns(context.Context, string,
 func (m *mockPipelinesServiceForS3) GetPipelineRunWithSpec(context.Context, string, string) (*pipelines.PipelineRun, error) {
 	return nil, nil
 }
+func (m *mockPipelinesServiceForS3) GetRunDAG(context.Context, string, string) (*pipelines.RunDAG, error) {
+	return nil, nil
+}
+func (m *mockPipelinesServiceForS3) StreamTaskLogs(context.Context, string, string, string, pipelines.TaskLogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
+func (m *mockPipelinesServiceForS3) ListRunArtifacts(context.Context, string, string) ([]pipelines.RunArtifact, error) {
+	return nil, nil
+}
 func (m *mockPipelinesServiceForS3) EnableManagedPipelines(context.Context, string) (*pipelines.EnableManagedPipelinesResult, error) {
 	return nil, nil
 }
@@ -627,6 +691,110 @@ func TestS3Repository_GetObject(t *testing.T) {
 	}
 }
 
+func TestS3Repository_CreateCSVUpload(t *testing.T) {
+	t.Run("resolves key and starts upload", func(t *testing.T) {
+		k8s := &mockK8sService{
+			getSecretFn: func(ctx context.Context, namespace, secretName string) (*v1.Secret, error) {
+				return makeK8sSecret("s", "ns", standardSecretData()), nil
+			},
+		}
+		var gotInput s3.CreateMultipartUploadInput
+		s3svc := &mockS3Service{
+			resolveNonCollidingKeyFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.ResolveNonCollidingKeyInput) (string, error) {
+				return "data/big-1.csv", nil
+			},
+			createMPUFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.CreateMultipartUploadInput) (*s3.MultipartUpload, error) {
+				gotInput = input
+				return &s3.MultipartUpload{Key: input.Key, UploadID: "upload-1"}, nil
+			},
+		}
+		repo := NewS3Repository(slog.Default(), s3svc, k8s, nil)
+
+		upload, err := repo.CreateCSVUpload(context.Background(), S3RequestContext{Namespace: "ns", SecretName: "s"}, "data/big.csv", "", 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if upload.Key != "data/big-1.csv" || upload.UploadID != "upload-1" {
+			t.Errorf("upload = %+v", upload)
+		}
+		if gotInput.Bucket != "my-bucket" || gotInput.ContentType != "text/csv" {
+			t.Errorf("input: %+v", gotInput)
+		}
+	})
+
+	t.Run("rejects non-csv key", func(t *testing.T) {
+		repo := NewS3Repository(slog.Default(), nil, nil, nil)
+		_, err := repo.CreateCSVUpload(context.Background(), S3RequestContext{}, "data.json", "", 5)
+		if !errors.Is(err, ErrCSVUploadValidation) {
+			t.Errorf("error = %v, want ErrCSVUploadValidation", err)
+		}
+	})
+}
+
+func TestS3Repository_CompleteUpload(t *testing.T) {
+	k8s := &mockK8sService{
+		getSecretFn: func(ctx context.Context, namespace, secretName string) (*v1.Secret, error) {
+			return makeK8sSecret("s", "ns", standardSecretData()), nil
+		},
+	}
+	var gotInput s3.CompleteMultipartUploadInput
+	s3svc := &mockS3Service{
+		completeMPUFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.CompleteMultipartUploadInput) error {
+			gotInput = input
+			return nil
+		},
+	}
+	repo := NewS3Repository(slog.Default(), s3svc, k8s, nil)
+
+	parts := []s3.UploadedPart{{PartNumber: 1, ETag: "e1"}}
+	if err := repo.CompleteUpload(context.Background(), S3RequestContext{Namespace: "ns", SecretName: "s"}, "data/big.csv", "upload-1", parts); err != nil {
+		t.Fatal(err)
+	}
+	if gotInput.Bucket != "my-bucket" || gotInput.Key != "data/big.csv" || gotInput.UploadID != "upload-1" || len(gotInput.Parts) != 1 {
+		t.Errorf("input: %+v", gotInput)
+	}
+}
+
+func TestS3Repository_PresignCSVUpload(t *testing.T) {
+	t.Run("presigns the resolved key", func(t *testing.T) {
+		k8s := &mockK8sService{
+			getSecretFn: func(ctx context.Context, namespace, secretName string) (*v1.Secret, error) {
+				return makeK8sSecret("s", "ns", standardSecretData()), nil
+			},
+		}
+		var gotInput s3.PresignInput
+		s3svc := &mockS3Service{
+			resolveNonCollidingKeyFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.ResolveNonCollidingKeyInput) (string, error) {
+				return "data/up-1.csv", nil
+			},
+			presignObjectFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.PresignInput) (*s3.PresignedURL, error) {
+				gotInput = input
+				return &s3.PresignedURL{URL: "https://s3.example.com/signed", Method: input.Method}, nil
+			},
+		}
+		repo := NewS3Repository(slog.Default(), s3svc, k8s, nil)
+
+		presigned, err := repo.PresignCSVUpload(context.Background(), S3RequestContext{Namespace: "ns", SecretName: "s"}, "data/up.csv", time.Minute, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if presigned.Key != "data/up-1.csv" || presigned.URL != "https://s3.example.com/signed" {
+			t.Errorf("presigned = %+v", presigned)
+		}
+		if gotInput.Bucket != "my-bucket" || gotInput.Key != "data/up-1.csv" || gotInput.Method != http.MethodPut || gotInput.Expires != time.Minute {
+			t.Errorf("input: %+v", gotInput)
+		}
+	})
+
+	t.Run("rejects non-csv key", func(t *testing.T) {
+		repo := NewS3Repository(slog.Default(), nil, nil, nil)
+		_, err := repo.PresignCSVUpload(context.Background(), S3RequestContext{}, "data.json", 0, 5)
+		if !errors.Is(err, ErrCSVUploadValidation) {
+			t.Errorf("error = %v, want ErrCSVUploadValidation", err)
+		}
+	})
+}
+
 func TestS3Repository_UploadCSVFile(t *testing.T) {
 	t.Run("resolves key and uploads", func(t *testing.T) {
 		k8s := &mockK8sService{
@@ -753,3 +921,53 @@ func TestS3Repository_GetCSVSchema(t *testing.T) {
 		}
 	})
 }
+
+func TestS3Repository_GetCSVProfile(t *testing.T) {
+	t.Run("rejects non-csv key", func(t *testing.T) {
+		repo := NewS3Repository(slog.Default(), nil, nil, nil)
+		_, err := repo.GetCSVProfile(context.Background(), S3RequestContext{}, "data.parquet", 0)
+		if !errors.Is(err, ErrCSVUploadValidation) {
+			t.Errorf("expected ErrCSVUploadValidation, got %v", err)
+		}
+	})
+
+	t.Run("fetches a bounded range and applies column name rules", func(t *testing.T) {
+		var gotRange string
+		k8s := &mockK8sService{
+			getSecretFn: func(ctx context.Context, namespace, secretName string) (*v1.Secret, error) {
+				return makeK8sSecret("s", "ns", standardSecretData()), nil
+			},
+		}
+		s3svc := &mockS3Service{
+			getObjectFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.GetObjectInput) (io.ReadCloser, string, error) {
+				gotRange = input.Range
+				return io.NopCloser(strings.NewReader("feature,étiquette\n" + strings.Repeat("a,x\nb,y\n", 60))), "text/csv", nil
+			},
+		}
+		repo := NewS3Repository(slog.Default(), s3svc, k8s, nil)
+
+		profile, err := repo.GetCSVProfile(context.Background(),
+			S3RequestContext{Namespace: "ns", SecretName: "s"}, "data.csv", 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotRange != csvProfileRange {
+			t.Errorf("Range = %q, want %q", gotRange, csvProfileRange)
+		}
+		if profile.RowsSampled != 100 || !profile.SampleTruncated {
+			t.Errorf("RowsSampled = %d, SampleTruncated = %v; want 100, true", profile.RowsSampled, profile.SampleTruncated)
+		}
+		if profile.Suggestions.LabelColumn == nil || *profile.Suggestions.LabelColumn != "feature" {
+			t.Errorf("LabelColumn = %v, want feature", profile.Suggestions.LabelColumn)
+		}
+		found := false
+		for _, w := range profile.Warnings {
+			if w.Code == helper.ProfileWarningInvalidColumnName && w.Column == "étiquette" {
+				found = strings.Contains(w.Message, "ASCII")
+			}
+		}
+		if !found {
+			t.Errorf("expected non-ASCII column warning, got %+v", profile.Warnings)
+		}
+	})
+}
@@ -56,6 +56,13 @@ func main() {
 	// TLS configuration flags
 	flag.BoolVar(&cfg.InsecureSkipVerify, "insecure-skip-verify", getEnvAsBool("INSECURE_SKIP_VERIFY", false), "Skip TLS certificate verification (useful for development, default: false)")
 
+	// Inter-BFF: gen-ai playground promotion
+	flag.BoolVar(&cfg.MockBFFClients, "mock-bff-clients", getEnvAsBool("MOCK_BFF_CLIENTS", false), "Use mock BFF clients for inter-BFF communication")
+	flag.StringVar(&cfg.BFFGenAIDevURL, "bff-genai-dev-url", getEnvAsString("BFF_GENAI_DEV_URL", ""), "Developer override URL for gen-ai BFF (e.g., http://localhost:8143/api/v1)")
+	flag.StringVar(&cfg.BFFGenAIServiceName, "bff-genai-service-name", getEnvAsString("BFF_GENAI_SERVICE_NAME", "odh-dashboard-gen-ai-ui"), "Kubernetes service name for gen-ai BFF")
+	flag.IntVar(&cfg.BFFGenAIServicePort, "bff-genai-service-port", getEnvAsInt("BFF_GENAI_SERVICE_PORT", 8143), "Port for gen-ai BFF service")
+	flag.BoolVar(&cfg.BFFGenAITLSEnabled, "bff-genai-tls-enabled", getEnvAsBool("BFF_GENAI_TLS_ENABLED", false), "Enable TLS for gen-ai BFF communication")
+
 	// Deprecated flags - kept for backward compatibility
 	flag.BoolVar(&cfg.StandaloneMode, "standalone-mode", false, "DEPRECATED: Use -deployment-mode=standalone instead")
 	flag.BoolVar(&cfg.FederatedPlatform, "federated-platform", false, "DEPRECATED: Use -deployment-mode=federated instead")
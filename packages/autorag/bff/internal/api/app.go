@@ -10,6 +10,8 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient"
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient/bffmocks"
 	k8s "github.com/opendatahub-io/autorag-library/bff/internal/integrations/kubernetes"
 	ogx "github.com/opendatahub-io/autorag-library/bff/internal/integrations/ogx"
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
@@ -38,9 +40,20 @@ const (
 	SecretPath               = ApiPathPrefix + "/secret/:name"
 	S3FilePath               = ApiPathPrefix + "/s3/files/:key"
 	S3FilesPath              = ApiPathPrefix + "/s3/files"
+	S3FileCopyPath           = S3FilePath + "/copy"
+	S3FileMovePath           = S3FilePath + "/move"
+	S3FilePresignPath        = S3FilePath + "/presign"
+	S3UploadsPath            = ApiPathPrefix + "/s3/uploads"
+	S3UploadPartsPath        = S3UploadsPath + "/parts"
+	S3UploadCompletePath     = S3UploadsPath + "/complete"
 	OGXModelsPath            = ApiPathPrefix + "/ogx/models"
 	OGXVectorStoresPath      = ApiPathPrefix + "/ogx/vector-stores"
+	OGXRetrievalEvalPath     = OGXVectorStoresPath + "/:vectorStoreId/evaluate"
 	PipelineRunsPath         = ApiPathPrefix + "/pipeline-runs"
+	PromoteToPlaygroundPath  = PipelineRunsPath + "/:runId/promote-to-playground"
+	PipelineRunDAGPath       = PipelineRunsPath + "/:runId/dag"
+	PipelineRunTaskLogsPath  = PipelineRunsPath + "/:runId/tasks/:taskId/logs"
+	PipelineRunArtifactsPath = PipelineRunsPath + "/:runId/artifacts"
 	IndexingPipelineRunsPath = ApiPathPrefix + "/indexing-pipeline-runs"
 	ManagedPipelinesListPath = ApiPathPrefix + "/managed-pipelines"
 	ManagedPipelinesPath     = ApiPathPrefix + "/managed-pipelines/enable"
@@ -77,6 +90,8 @@ type App struct {
 	rootCAs *x509.CertPool
 	// portForwardManager manages on-demand port-forwards for local dev (nil in production)
 	portForwardManager *k8s.PortForwardManager
+	// bffClientFactory creates clients for calling other module BFFs (gen-ai)
+	bffClientFactory bffclient.BFFClientFactory
 
 	// Handler-composition middleware (namespace extraction, RBAC)
 	mw *Middleware
@@ -87,6 +102,8 @@ type App struct {
 	s3          *S3Handler
 	pipelines   *PipelinesHandler
 	ogx         *OGXHandler
+	playground  *PlaygroundHandler
+	runDetails  *RunDetailsHandler
 }
 
 func NewApp(cfg config.EnvConfig, logger *slog.Logger) (*App, error) {
@@ -223,12 +240,20 @@ func NewApp(cfg config.EnvConfig, logger *slog.Logger) (*App, error) {
 		ogxClient = ogx.NewDefaultOGXClient(ogxCfg)
 	}
 
+	pipelinesRepo := repositories.NewPipelinesRepository(logger, pipelinesService, repositories.PipelinesRepositoryConfig{
+		AutoRAGPipelineName:    cfg.AutoRAGPipelineNamePrefix,
+		IndexingPipelineName:   cfg.IndexingPipelineNamePrefix,
+		DefaultPipelineVersion: cfg.PipelineVersionSuffix,
+	})
+	s3Repo := repositories.NewS3Repository(logger, s3Service, k8sService, pipelinesService)
+
 	app := &App{
 		config:             cfg,
 		logger:             logger,
 		k8sService:         k8sService,
 		rootCAs:            rootCAs,
 		portForwardManager: pfManager,
+		bffClientFactory:   initBFFClientFactory(cfg, logger, rootCAs),
 		mw: &Middleware{
 			logger:     logger,
 			config:     cfg,
@@ -245,24 +270,62 @@ func NewApp(cfg config.EnvConfig, logger *slog.Logger) (*App, error) {
 		},
 		s3: &S3Handler{
 			logger: logger,
-			repo:   repositories.NewS3Repository(logger, s3Service, k8sService, pipelinesService),
+			repo:   s3Repo,
 		},
 		pipelines: &PipelinesHandler{
 			logger: logger,
-			repo: repositories.NewPipelinesRepository(logger, pipelinesService, repositories.PipelinesRepositoryConfig{
-				AutoRAGPipelineName:    cfg.AutoRAGPipelineNamePrefix,
-				IndexingPipelineName:   cfg.IndexingPipelineNamePrefix,
-				DefaultPipelineVersion: cfg.PipelineVersionSuffix,
-			}),
+			repo:   pipelinesRepo,
 		},
 		ogx: &OGXHandler{
 			logger: logger,
 			repo:   repositories.NewOGXRepository(logger, ogxClient, k8sService),
 		},
+		playground: &PlaygroundHandler{
+			logger: logger,
+			repo:   repositories.NewPlaygroundRepository(logger, pipelinesRepo, s3Repo),
+		},
+		runDetails: &RunDetailsHandler{
+			logger: logger,
+			repo:   repositories.NewRunDetailsRepository(logger, pipelinesRepo, pipelinesService, s3Repo),
+		},
 	}
 	return app, nil
 }
 
+// initBFFClientFactory creates the factory for calling other module BFFs, applying the
+// gen-ai service overrides from cfg.
+func initBFFClientFactory(cfg config.EnvConfig, logger *slog.Logger, rootCAs *x509.CertPool) bffclient.BFFClientFactory {
+	bffConfig := bffclient.NewDefaultBFFClientConfig()
+	bffConfig.MockBFFClients = cfg.MockBFFClients
+	bffConfig.InsecureSkipVerify = cfg.InsecureSkipVerify
+
+	if genAIConfig := bffConfig.GetServiceConfig(bffclient.BFFTargetGenAI); genAIConfig != nil {
+		if cfg.BFFGenAIServiceName != "" {
+			genAIConfig.ServiceName = cfg.BFFGenAIServiceName
+		}
+		if cfg.BFFGenAIServicePort > 0 {
+			genAIConfig.Port = cfg.BFFGenAIServicePort
+		}
+		genAIConfig.TLSEnabled = cfg.BFFGenAITLSEnabled
+		genAIConfig.DevOverrideURL = cfg.BFFGenAIDevURL
+	}
+
+	if cfg.MockBFFClients {
+		logger.Info("Using mock BFF client factory")
+		return bffmocks.NewMockClientFactory(logger)
+	}
+
+	if genAIConfig := bffConfig.GetServiceConfig(bffclient.BFFTargetGenAI); genAIConfig != nil {
+		logger.Info("Using real BFF client factory",
+			"genAIServiceName", genAIConfig.ServiceName,
+			"genAIServicePort", genAIConfig.Port,
+			"genAIDevURL", genAIConfig.DevOverrideURL)
+	} else {
+		logger.Info("Using real BFF client factory")
+	}
+	return bffclient.NewRealClientFactory(bffConfig, rootCAs, cfg.InsecureSkipVerify, logger)
+}
+
 func (app *App) Shutdown() error {
 	app.logger.Info("shutting down app...")
 	if app.portForwardManager != nil {
@@ -298,6 +361,17 @@ func (app *App) Routes() http.Handler {
 	apiRouter.POST(PipelineRunsPath+"/:runId/retry", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.pipelines.RetryPipelineRunHandler)))
 	apiRouter.DELETE(PipelineRunsPath+"/:runId", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.pipelines.DeletePipelineRunHandler)))
 
+	// Run task graph, task logs and output artifacts (artifacts are read from Pipeline Server object storage)
+	apiRouter.GET(PipelineRunDAGPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunDAGHandler)))
+	apiRouter.GET(PipelineRunTaskLogsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunTaskLogsHandler)))
+	apiRouter.GET(PipelineRunArtifactsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunArtifactsHandler)))
+	apiRouter.GET(PipelineRunArtifactsPath+"/files", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunArtifactFilesHandler)))
+	apiRouter.GET(PipelineRunArtifactsPath+"/download", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.runDetails.RunArtifactDownloadHandler)))
+
+	// Promote an AutoRAG pattern to the gen-ai playground (calls the gen-ai BFF with the caller's token)
+	apiRouter.POST(PromoteToPlaygroundPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(
+		bffclient.AttachBFFClient(app.bffClientFactory, bffclient.BFFTargetGenAI)(app.playground.PromoteToPlaygroundHandler))))
+
 	// Indexing pipeline runs
 	apiRouter.POST(IndexingPipelineRunsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.pipelines.CreateIndexingPipelineRunHandler)))
 
@@ -306,10 +380,23 @@ func (app *App) Routes() http.Handler {
 	apiRouter.GET(S3FilesPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.GetS3FilesHandler)))
 	// POST /s3/files/:key: secretName is required; there is no DSPA fallback.
 	apiRouter.POST(S3FilePath, app.mw.AttachNamespace(app.s3.rejectDeclaredOversizedS3Post(app.mw.RequireAccessToService(app.s3.PostS3FileHandler))))
+	// Resumable multipart uploads for files above the single POST limit; secretName is required.
+	apiRouter.POST(S3UploadsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.CreateS3UploadHandler)))
+	apiRouter.DELETE(S3UploadsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.AbortS3UploadHandler)))
+	apiRouter.GET(S3UploadPartsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.ListS3UploadPartsHandler)))
+	apiRouter.PUT(S3UploadPartsPath+"/:partNumber", app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.PutS3UploadPartHandler)))
+	apiRouter.POST(S3UploadCompletePath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.CompleteS3UploadHandler)))
+	// Object browser operations; secretName is required except for presigned downloads.
+	apiRouter.POST(S3FileCopyPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.CopyS3FileHandler)))
+	apiRouter.POST(S3FileMovePath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.MoveS3FileHandler)))
+	apiRouter.POST(S3FilePresignPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.PresignS3FileHandler)))
+	apiRouter.DELETE(S3FilePath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.DeleteS3FileHandler)))
+	apiRouter.DELETE(S3FilesPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.s3.DeleteS3FilesHandler)))
 
 	// Open GenAI Stack — credentials are resolved by the repository from the secretName query param
 	apiRouter.GET(OGXModelsPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.ogx.OGXModelsHandler)))
 	apiRouter.GET(OGXVectorStoresPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.ogx.OGXVectorStoresHandler)))
+	apiRouter.POST(OGXRetrievalEvalPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.ogx.OGXRetrievalEvaluationHandler)))
 
 	// Managed pipelines — list discovered pipelines / enable AutoRAG pipeline definitions on an existing DSPA
 	apiRouter.GET(ManagedPipelinesListPath, app.mw.AttachNamespace(app.mw.RequireAccessToService(app.pipelines.ListManagedPipelinesHandler)))
@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"io"
+	"time"
 
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient"
 	"github.com/opendatahub-io/autorag-library/bff/internal/models"
 	"github.com/opendatahub-io/autorag-library/bff/internal/repositories"
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
@@ -89,6 +91,9 @@ func (m *mockK8sService) PatchResource(_ context.Context, _ schema.GroupVersionR
 func (m *mockK8sService) PatchDeployment(_ context.Context, _, _ string, _ types.PatchType, _ []byte) error {
 	return nil
 }
+func (m *mockK8sService) GetPodLogs(_ context.Context, _, _ string, _ *v1.PodLogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
 func (m *mockK8sService) DiscoverResourceGVR(ctx context.Context, group, resource, namespace string, knownVersions []string) (schema.GroupVersionResource, error) {
 	return schema.GroupVersionResource{}, nil
 }
@@ -156,6 +161,79 @@ func (m *mockS3Repo) ListObjects(ctx context.Context, req repositories.S3Request
 	return args.Get(0).(*s3.ListObjectsResponse), args.Error(1)
 }
 
+func (m *mockS3Repo) CreateUpload(ctx context.Context, req repositories.S3RequestContext, key, rawContentType string, maxAttempts int) (*s3.MultipartUpload, error) {
+	args := m.Called(ctx, req, key, rawContentType, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.MultipartUpload), args.Error(1)
+}
+
+func (m *mockS3Repo) UploadPart(ctx context.Context, req repositories.S3RequestContext, key, uploadID string, partNumber int32, body io.ReadSeeker, size int64) (*s3.UploadedPart, error) {
+	args := m.Called(ctx, req, key, uploadID, partNumber, body, size)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.UploadedPart), args.Error(1)
+}
+
+func (m *mockS3Repo) ListUploadParts(ctx context.Context, req repositories.S3RequestContext, key, uploadID string) ([]s3.UploadedPart, error) {
+	args := m.Called(ctx, req, key, uploadID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]s3.UploadedPart), args.Error(1)
+}
+
+func (m *mockS3Repo) CompleteUpload(ctx context.Context, req repositories.S3RequestContext, key, uploadID string, parts []s3.UploadedPart) error {
+	args := m.Called(ctx, req, key, uploadID, parts)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) AbortUpload(ctx context.Context, req repositories.S3RequestContext, key, uploadID string) error {
+	args := m.Called(ctx, req, key, uploadID)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) CopyObject(ctx context.Context, req repositories.S3RequestContext, sourceKey, destinationKey string) error {
+	args := m.Called(ctx, req, sourceKey, destinationKey)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) MoveObject(ctx context.Context, req repositories.S3RequestContext, sourceKey, destinationKey string) error {
+	args := m.Called(ctx, req, sourceKey, destinationKey)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) DeleteObject(ctx context.Context, req repositories.S3RequestContext, key string) error {
+	args := m.Called(ctx, req, key)
+	return args.Error(0)
+}
+
+func (m *mockS3Repo) DeletePrefix(ctx context.Context, req repositories.S3RequestContext, prefix, confirmationToken string) (*s3.DeletePrefixResult, error) {
+	args := m.Called(ctx, req, prefix, confirmationToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.DeletePrefixResult), args.Error(1)
+}
+
+func (m *mockS3Repo) PresignDownload(ctx context.Context, req repositories.S3RequestContext, key string, expires time.Duration) (*models.S3PresignedURL, error) {
+	args := m.Called(ctx, req, key, expires)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.S3PresignedURL), args.Error(1)
+}
+
+func (m *mockS3Repo) PresignUpload(ctx context.Context, req repositories.S3RequestContext, key string, expires time.Duration, maxAttempts int) (*models.S3PresignedURL, error) {
+	args := m.Called(ctx, req, key, expires, maxAttempts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.S3PresignedURL), args.Error(1)
+}
+
 // --- Mock Pipelines Repository ---
 
 type mockPipelinesRepo struct {
@@ -246,3 +324,71 @@ func (m *mockOGXRepo) GetOGXVectorStoreProviders(ctx context.Context, namespace,
 	}
 	return args.Get(0).(*models.OGXVectorStoreProvidersData), args.Error(1)
 }
+
+func (m *mockOGXRepo) EvaluateRetrieval(ctx context.Context, namespace, secretName, vectorStoreID string, req models.RetrievalEvalRequest) (*models.RetrievalEvaluation, error) {
+	args := m.Called(ctx, namespace, secretName, vectorStoreID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RetrievalEvaluation), args.Error(1)
+}
+
+// --- Mock Playground Repository ---
+
+type mockPlaygroundRepo struct {
+	mock.Mock
+}
+
+func (m *mockPlaygroundRepo) PromoteToPlayground(ctx context.Context, genAI bffclient.BFFClientInterface, namespace, runID string, req models.PromoteToPlaygroundRequest) (*models.PlaygroundPromotion, error) {
+	args := m.Called(ctx, genAI, namespace, runID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PlaygroundPromotion), args.Error(1)
+}
+
+// --- Mock Run Details Repository ---
+
+type mockRunDetailsRepo struct {
+	mock.Mock
+}
+
+func (m *mockRunDetailsRepo) GetRunDAG(ctx context.Context, namespace, runID string) (*pipelines.RunDAG, error) {
+	args := m.Called(ctx, namespace, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pipelines.RunDAG), args.Error(1)
+}
+
+func (m *mockRunDetailsRepo) StreamTaskLogs(ctx context.Context, namespace, runID, taskID string, opts pipelines.TaskLogOptions) (io.ReadCloser, error) {
+	args := m.Called(ctx, namespace, runID, taskID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *mockRunDetailsRepo) ListRunArtifacts(ctx context.Context, namespace, runID string) ([]pipelines.RunArtifact, error) {
+	args := m.Called(ctx, namespace, runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]pipelines.RunArtifact), args.Error(1)
+}
+
+func (m *mockRunDetailsRepo) ListRunArtifactFiles(ctx context.Context, namespace, runID, taskName, name, dir, next string) (*s3.ListObjectsResponse, error) {
+	args := m.Called(ctx, namespace, runID, taskName, name, dir, next)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*s3.ListObjectsResponse), args.Error(1)
+}
+
+func (m *mockRunDetailsRepo) GetRunArtifact(ctx context.Context, namespace, runID, taskName, name, file string) (*repositories.GetObjectResult, string, error) {
+	args := m.Called(ctx, namespace, runID, taskName, name, file)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*repositories.GetObjectResult), args.String(1), args.Error(2)
+}
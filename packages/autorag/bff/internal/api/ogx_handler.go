@@ -2,8 +2,10 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -19,6 +21,7 @@ import (
 type ogxRepository interface {
 	GetOGXModels(ctx context.Context, namespace, secretName string) (*models.OGXModelsData, error)
 	GetOGXVectorStoreProviders(ctx context.Context, namespace, secretName string) (*models.OGXVectorStoreProvidersData, error)
+	EvaluateRetrieval(ctx context.Context, namespace, secretName, vectorStoreID string, req models.RetrievalEvalRequest) (*models.RetrievalEvaluation, error)
 }
 
 type OGXHandler struct {
@@ -28,6 +31,7 @@ type OGXHandler struct {
 
 type OGXModelsEnvelope Envelope[*models.OGXModelsData, None]
 type OGXVectorStoresEnvelope Envelope[*models.OGXVectorStoreProvidersData, None]
+type RetrievalEvaluationEnvelope Envelope[*models.RetrievalEvaluation, None]
 
 // OGXModelsHandler handles GET /api/v1/ogx/models
 // Returns all available models from Open GenAI Stack Distribution.
@@ -104,6 +108,62 @@ func (h *OGXHandler) OGXVectorStoresHandler(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// OGXRetrievalEvaluationHandler handles POST /api/v1/ogx/vector-stores/:vectorStoreId/evaluate
+// Queries an existing vector store with a gold set of questions and returns recall@k, MRR
+// and nDCG@k, plus the retrieved chunks of every question for inspection.
+func (h *OGXHandler) OGXRetrievalEvaluationHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return
+	}
+
+	secretName := r.URL.Query().Get("secretName")
+	if secretName == "" {
+		badRequestResponse(h.logger, w, r, "missing required query parameter: secretName")
+		return
+	}
+	if err := kubernetes.ValidateResourceName("secretName", secretName); err != nil {
+		badRequestResponse(h.logger, w, r, "invalid secretName: must be a valid DNS-1123 subdomain (lowercase alphanumeric, '-', or '.', start/end with alphanumeric, max 253 chars)")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	var req models.RetrievalEvalRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			payloadTooLargeResponse(h.logger, w, r, "request body exceeds maximum size")
+			return
+		}
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+	var extra any
+	if err := decoder.Decode(&extra); err != io.EOF {
+		badRequestResponse(h.logger, w, r, "request body must contain only a single JSON object")
+		return
+	}
+
+	evaluation, err := h.repo.EvaluateRetrieval(ctx, namespace, secretName, params.ByName("vectorStoreId"), req)
+	if err != nil {
+		if errors.Is(err, repositories.ErrValidation) {
+			badRequestResponse(h.logger, w, r, err.Error())
+			return
+		}
+		h.handleOGXOrK8sError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, RetrievalEvaluationEnvelope{Data: evaluation}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
 // --- OGX Error Helpers ---
 
 // handleOGXOrK8sError handles errors that may originate from either the OGX client
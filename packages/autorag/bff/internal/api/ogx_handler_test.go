@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/julienschmidt/httprouter"
@@ -578,3 +579,93 @@ func TestHandleOGXClientError(t *testing.T) {
 		})
 	}
 }
+
+// ---------- OGXRetrievalEvaluationHandler ----------
+
+func TestOGXRetrievalEvaluationHandler(t *testing.T) {
+	ns := "test-ns"
+	body := `{"k":3,"questions":[{"question":"How do I reset?","expected_documents":["faq.md"]}]}`
+	evalReq := models.RetrievalEvalRequest{
+		K:         3,
+		Questions: []models.RetrievalEvalQuestion{{Question: "How do I reset?", ExpectedDocuments: []string{"faq.md"}}},
+	}
+
+	tests := []struct {
+		name           string
+		queryString    string
+		body           string
+		setupMock      func(repo *mockOGXRepo)
+		wantStatusCode int
+		wantBodySubstr string
+	}{
+		{
+			name:        "success",
+			queryString: "?secretName=my-ogx-secret",
+			body:        body,
+			setupMock: func(repo *mockOGXRepo) {
+				repo.On("EvaluateRetrieval", mock.Anything, ns, "my-ogx-secret", "vs_1", evalReq).Return(&models.RetrievalEvaluation{
+					VectorStoreID: "vs_1",
+					K:             3,
+					Metrics:       models.RetrievalMetrics{RecallAtK: 1, MRR: 1, NDCGAtK: 1},
+				}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			wantBodySubstr: `"recall_at_k": 1`,
+		},
+		{
+			name:           "missing secretName returns 400",
+			body:           body,
+			setupMock:      func(repo *mockOGXRepo) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBodySubstr: "secretName",
+		},
+		{
+			name:           "unknown field returns 400",
+			queryString:    "?secretName=my-ogx-secret",
+			body:           `{"questions":[],"top_k":3}`,
+			setupMock:      func(repo *mockOGXRepo) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBodySubstr: "top_k",
+		},
+		{
+			name:        "validation error returns 400",
+			queryString: "?secretName=my-ogx-secret",
+			body:        body,
+			setupMock: func(repo *mockOGXRepo) {
+				repo.On("EvaluateRetrieval", mock.Anything, ns, "my-ogx-secret", "vs_1", evalReq).
+					Return(nil, repositories.NewValidationError("k must be between 1 and 50"))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBodySubstr: "k must be between",
+		},
+		{
+			name:        "unknown vector store returns 404",
+			queryString: "?secretName=my-ogx-secret",
+			body:        body,
+			setupMock: func(repo *mockOGXRepo) {
+				repo.On("EvaluateRetrieval", mock.Anything, ns, "my-ogx-secret", "vs_1", evalReq).
+					Return(nil, fmt.Errorf("search failed: %w", ogx.NewNotFoundError("Open GenAI Stack vector store \"vs_1\" not found")))
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBodySubstr: "not_found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, repo := newTestOGXHandler()
+			tt.setupMock(repo)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/ogx/vector-stores/vs_1/evaluate"+tt.queryString, strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), constants.NamespaceHeaderParameterKey, ns))
+			rr := httptest.NewRecorder()
+			handler.OGXRetrievalEvaluationHandler(rr, req, httprouter.Params{{Key: "vectorStoreId", Value: "vs_1"}})
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			if tt.wantBodySubstr != "" {
+				assert.Contains(t, rr.Body.String(), tt.wantBodySubstr)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/autorag-library/bff/internal/constants"
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient"
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
+	"github.com/opendatahub-io/autorag-library/bff/internal/repositories"
+	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
+	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+)
+
+type playgroundRepository interface {
+	PromoteToPlayground(ctx context.Context, genAI bffclient.BFFClientInterface, namespace, runID string, req models.PromoteToPlaygroundRequest) (*models.PlaygroundPromotion, error)
+}
+
+type PlaygroundHandler struct {
+	logger *slog.Logger
+	repo   playgroundRepository
+}
+
+type PlaygroundPromotionEnvelope Envelope[*models.PlaygroundPromotion, None]
+
+// PromoteToPlaygroundHandler handles POST /api/v1/pipeline-runs/:runId/promote-to-playground
+// Creates a gen-ai playground agent profile and vector store configured from one of the
+// run's RAG patterns (the best-scoring one unless pattern_name is given). Requires the
+// gen-ai BFF client attached by AttachBFFClient.
+func (h *PlaygroundHandler) PromoteToPlaygroundHandler(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		badRequestResponse(h.logger, w, r, "missing namespace in context - ensure AttachNamespace middleware is used first")
+		return
+	}
+
+	genAI := bffclient.GetClient(ctx, bffclient.BFFTargetGenAI)
+	if genAI == nil {
+		serviceUnavailableResponseWithMessage(h.logger, w, r, bffclient.NewNotConfiguredError(bffclient.BFFTargetGenAI),
+			"gen-ai playground is not available")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	var req models.PromoteToPlaygroundRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil && err != io.EOF {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			payloadTooLargeResponse(h.logger, w, r, "request body exceeds maximum size")
+			return
+		}
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+	var extra any
+	if err := decoder.Decode(&extra); err != io.EOF {
+		badRequestResponse(h.logger, w, r, "request body must contain only a single JSON object")
+		return
+	}
+
+	promotion, err := h.repo.PromoteToPlayground(ctx, genAI, namespace, params.ByName("runId"), req)
+	if err != nil {
+		h.mapPlaygroundError(w, r, err)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusCreated, PlaygroundPromotionEnvelope{Data: promotion}, nil); err != nil {
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
+
+// mapPlaygroundError maps pipeline, artifact and gen-ai BFF errors to HTTP responses.
+// Gen-ai errors keep the caller-facing status where it is meaningful (the gen-ai BFF runs
+// the same RBAC checks with the caller's token) and are reported as 502/503 otherwise.
+func (h *PlaygroundHandler) mapPlaygroundError(w http.ResponseWriter, r *http.Request, err error) {
+	var bffErr *bffclient.BFFClientError
+	if errors.As(err, &bffErr) {
+		switch bffErr.Code {
+		case bffclient.ErrCodeBadRequest:
+			badRequestResponse(h.logger, w, r, err.Error())
+		case bffclient.ErrCodeUnauthorized:
+			unauthorizedResponse(h.logger, w, r, err.Error())
+		case bffclient.ErrCodeForbidden:
+			forbiddenResponse(h.logger, w, r, err.Error())
+		case bffclient.ErrCodeNotFound:
+			notFoundResponseWithMessage(h.logger, w, r, err.Error())
+		case bffclient.ErrCodeConnectionFailed, bffclient.ErrCodeTimeout, bffclient.ErrCodeServerUnavailable, bffclient.ErrCodeNotConfigured:
+			serviceUnavailableResponseWithMessage(h.logger, w, r, err, "gen-ai playground is not available")
+		default:
+			badGatewayResponseWithMessage(h.logger, w, r, err, "unexpected response from the gen-ai playground")
+		}
+		return
+	}
+
+	switch {
+	case errors.Is(err, repositories.ErrPipelineRunNotFound):
+		notFoundResponse(h.logger, w, r)
+	case errors.Is(err, repositories.ErrManagedPipelinesNotFound):
+		notFoundResponseWithMessage(h.logger, w, r, err.Error())
+	case errors.Is(err, repositories.ErrValidation):
+		badRequestResponse(h.logger, w, r, err.Error())
+	case errors.Is(err, pipelines.ErrNoDSPAFound):
+		notFoundResponseWithMessage(h.logger, w, r, "no Pipeline Server (DSPipelineApplication) found in namespace")
+	case errors.Is(err, pipelines.ErrDSPANotReady):
+		serviceUnavailableResponseWithMessage(h.logger, w, r, err,
+			"Pipeline Server exists but is not ready - check that the APIServer component is running")
+	case errors.Is(err, kubernetes.ErrForbidden):
+		forbiddenResponse(h.logger, w, r, err.Error())
+	default:
+		serverErrorResponse(h.logger, w, r, err)
+	}
+}
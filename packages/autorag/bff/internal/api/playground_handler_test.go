@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/autorag-library/bff/internal/constants"
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient"
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient/bffmocks"
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
+	"github.com/opendatahub-io/autorag-library/bff/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPromoteToPlaygroundHandler(t *testing.T) {
+	ns := "test-ns"
+	genAI := bffmocks.NewMockBFFClient(bffclient.BFFTargetGenAI)
+
+	tests := []struct {
+		name           string
+		body           string
+		noClient       bool
+		setupMock      func(repo *mockPlaygroundRepo)
+		wantStatusCode int
+		wantBodySubstr string
+	}{
+		{
+			name: "success with empty body",
+			setupMock: func(repo *mockPlaygroundRepo) {
+				repo.On("PromoteToPlayground", mock.Anything, genAI, ns, "run-1", models.PromoteToPlaygroundRequest{}).
+					Return(&models.PlaygroundPromotion{RunID: "run-1", PatternName: "Pattern1", AgentProfileID: "profile-1"}, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+			wantBodySubstr: `"agent_profile_id": "profile-1"`,
+		},
+		{
+			name: "pattern name is passed through",
+			body: `{"pattern_name":"Pattern2"}`,
+			setupMock: func(repo *mockPlaygroundRepo) {
+				repo.On("PromoteToPlayground", mock.Anything, genAI, ns, "run-1", models.PromoteToPlaygroundRequest{PatternName: "Pattern2"}).
+					Return(&models.PlaygroundPromotion{RunID: "run-1", PatternName: "Pattern2"}, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+			wantBodySubstr: `"pattern_name": "Pattern2"`,
+		},
+		{
+			name:           "unknown field returns 400",
+			body:           `{"pattern":"Pattern2"}`,
+			setupMock:      func(repo *mockPlaygroundRepo) {},
+			wantStatusCode: http.StatusBadRequest,
+			wantBodySubstr: "pattern",
+		},
+		{
+			name:           "missing gen-ai client returns 503",
+			noClient:       true,
+			setupMock:      func(repo *mockPlaygroundRepo) {},
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name: "run not found returns 404",
+			setupMock: func(repo *mockPlaygroundRepo) {
+				repo.On("PromoteToPlayground", mock.Anything, genAI, ns, "run-1", models.PromoteToPlaygroundRequest{}).
+					Return(nil, repositories.ErrPipelineRunNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "validation error returns 400",
+			setupMock: func(repo *mockPlaygroundRepo) {
+				repo.On("PromoteToPlayground", mock.Anything, genAI, ns, "run-1", models.PromoteToPlaygroundRequest{}).
+					Return(nil, repositories.NewValidationError("pipeline run must be SUCCEEDED to be promoted"))
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBodySubstr: "SUCCEEDED",
+		},
+		{
+			name: "gen-ai forbidden returns 403",
+			setupMock: func(repo *mockPlaygroundRepo) {
+				repo.On("PromoteToPlayground", mock.Anything, genAI, ns, "run-1", models.PromoteToPlaygroundRequest{}).
+					Return(nil, fmt.Errorf("failed to create playground agent profile: %w",
+						bffclient.NewForbiddenError(bffclient.BFFTargetGenAI, "cannot create agent profiles")))
+			},
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name: "gen-ai connection failure returns 503",
+			setupMock: func(repo *mockPlaygroundRepo) {
+				repo.On("PromoteToPlayground", mock.Anything, genAI, ns, "run-1", models.PromoteToPlaygroundRequest{}).
+					Return(nil, bffclient.NewConnectionError(bffclient.BFFTargetGenAI, "connection refused"))
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name: "gen-ai invalid response returns 502",
+			setupMock: func(repo *mockPlaygroundRepo) {
+				repo.On("PromoteToPlayground", mock.Anything, genAI, ns, "run-1", models.PromoteToPlaygroundRequest{}).
+					Return(nil, bffclient.NewInvalidResponseError(bffclient.BFFTargetGenAI, "vector store response has no id"))
+			},
+			wantStatusCode: http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockPlaygroundRepo)
+			handler := &PlaygroundHandler{logger: slog.New(slog.NewTextHandler(io.Discard, nil)), repo: repo}
+			tt.setupMock(repo)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/pipeline-runs/run-1/promote-to-playground", strings.NewReader(tt.body))
+			ctx := context.WithValue(req.Context(), constants.NamespaceHeaderParameterKey, ns)
+			if !tt.noClient {
+				ctx = context.WithValue(ctx, constants.BFFClientKey(constants.BFFTarget(bffclient.BFFTargetGenAI)), genAI)
+			}
+			rr := httptest.NewRecorder()
+			handler.PromoteToPlaygroundHandler(rr, req.WithContext(ctx), httprouter.Params{{Key: "runId", Value: "run-1"}})
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			if tt.wantBodySubstr != "" {
+				assert.Contains(t, rr.Body.String(), tt.wantBodySubstr)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/opendatahub-io/autorag-library/bff/internal/constants"
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
 	"github.com/opendatahub-io/autorag-library/bff/internal/repositories"
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
 	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
@@ -24,6 +26,17 @@ type s3Repository interface {
 	GetObject(ctx context.Context, req repositories.S3RequestContext, key string) (*repositories.GetObjectResult, error)
 	UploadFile(ctx context.Context, req repositories.S3RequestContext, key string, body io.Reader, rawContentType string, maxAttempts int) (string, error)
 	ListObjects(ctx context.Context, req repositories.S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error)
+	CreateUpload(ctx context.Context, req repositories.S3RequestContext, key, rawContentType string, maxAttempts int) (*s3.MultipartUpload, error)
+	UploadPart(ctx context.Context, req repositories.S3RequestContext, key, uploadID string, partNumber int32, body io.ReadSeeker, size int64) (*s3.UploadedPart, error)
+	ListUploadParts(ctx context.Context, req repositories.S3RequestContext, key, uploadID string) ([]s3.UploadedPart, error)
+	CompleteUpload(ctx context.Context, req repositories.S3RequestContext, key, uploadID string, parts []s3.UploadedPart) error
+	AbortUpload(ctx context.Context, req repositories.S3RequestContext, key, uploadID string) error
+	CopyObject(ctx context.Context, req repositories.S3RequestContext, sourceKey, destinationKey string) error
+	MoveObject(ctx context.Context, req repositories.S3RequestContext, sourceKey, destinationKey string) error
+	DeleteObject(ctx context.Context, req repositories.S3RequestContext, key string) error
+	DeletePrefix(ctx context.Context, req repositories.S3RequestContext, prefix, confirmationToken string) (*s3.DeletePrefixResult, error)
+	PresignDownload(ctx context.Context, req repositories.S3RequestContext, key string, expires time.Duration) (*models.S3PresignedURL, error)
+	PresignUpload(ctx context.Context, req repositories.S3RequestContext, key string, expires time.Duration, maxAttempts int) (*models.S3PresignedURL, error)
 }
 
 type S3Handler struct {
@@ -86,69 +99,87 @@ func (h *S3Handler) buildS3Request(w http.ResponseWriter, r *http.Request, secre
 
 // handleS3RepoError classifies S3 repo errors and writes the appropriate HTTP response.
 func (h *S3Handler) handleS3RepoError(w http.ResponseWriter, r *http.Request, err error, key string) {
+	writeS3RepoError(h.logger, w, r, err, key)
+}
+
+// writeS3RepoError maps credential resolution and S3 errors to HTTP responses. It is
+// shared by handlers that read objects through S3Repository.
+func writeS3RepoError(logger *slog.Logger, w http.ResponseWriter, r *http.Request, err error, key string) {
 	// K8s domain errors from credential resolution
 	switch {
 	case errors.Is(err, kubernetes.ErrNotFound):
-		notFoundResponseWithMessage(h.logger, w, r, err.Error())
+		notFoundResponseWithMessage(logger, w, r, err.Error())
 		return
 	case errors.Is(err, kubernetes.ErrForbidden):
-		forbiddenResponse(h.logger, w, r, err.Error())
+		forbiddenResponse(logger, w, r, err.Error())
 		return
 	case errors.Is(err, kubernetes.ErrUnauthorized):
-		unauthorizedResponse(h.logger, w, r, err.Error())
+		unauthorizedResponse(logger, w, r, err.Error())
 		return
 	}
 
 	// DSPA discovery errors (S3 GET without explicit secretName falls back to DSPA)
 	if errors.Is(err, pipelines.ErrNoDSPAFound) {
-		notFoundResponseWithMessage(h.logger, w, r, "no Pipeline Server (DSPipelineApplication) found in namespace")
+		notFoundResponseWithMessage(logger, w, r, "no Pipeline Server (DSPipelineApplication) found in namespace")
 		return
 	}
 	if errors.Is(err, pipelines.ErrDSPANotReady) {
-		serviceUnavailableResponseWithMessage(h.logger, w, r, err,
+		serviceUnavailableResponseWithMessage(logger, w, r, err,
 			"Pipeline Server exists but is not ready - check that the APIServer component is running")
 		return
 	}
 
 	// S3 domain errors
 	if errors.Is(err, s3.ErrObjectNotFound) {
-		notFoundResponseWithMessage(h.logger, w, r, fmt.Sprintf("object %q not found in S3 storage", key))
+		notFoundResponseWithMessage(logger, w, r, fmt.Sprintf("object %q not found in S3 storage", key))
 		return
 	}
 	if errors.Is(err, s3.ErrBucketNotFound) {
-		notFoundResponseWithMessage(h.logger, w, r, "S3 bucket not found")
+		notFoundResponseWithMessage(logger, w, r, "S3 bucket not found")
 		return
 	}
 	if errors.Is(err, s3.ErrAccessDenied) {
 		if key != "" {
-			forbiddenResponse(h.logger, w, r, fmt.Sprintf("access denied to S3 object %q", key))
+			forbiddenResponse(logger, w, r, fmt.Sprintf("access denied to S3 object %q", key))
 		} else {
-			forbiddenResponse(h.logger, w, r, "access denied to S3 bucket")
+			forbiddenResponse(logger, w, r, "access denied to S3 bucket")
 		}
 		return
 	}
 	if errors.Is(err, s3.ErrObjectAlreadyExists) {
-		conflictResponse(h.logger, w, r, fmt.Sprintf("object key %q already exists in S3 (upload conflict); retry with a different key", key))
+		conflictResponse(logger, w, r, fmt.Sprintf("object key %q already exists in S3 (upload conflict); retry with a different key", key))
+		return
+	}
+	if errors.Is(err, s3.ErrUploadNotFound) {
+		notFoundResponseWithMessage(logger, w, r, fmt.Sprintf("multipart upload for %q not found; it may have been completed or aborted", key))
+		return
+	}
+	if errors.Is(err, s3.ErrInvalidConfirmationToken) {
+		conflictResponse(logger, w, r, fmt.Sprintf("%s; request a new confirmation token for %q", err, key))
 		return
 	}
 
 	// DSPA server-side misconfiguration (missing bucket, secret name, endpoint, credentials)
 	if errors.Is(err, repositories.ErrDSPAConfiguration) {
-		serviceUnavailableResponseWithMessage(h.logger, w, r, err, err.Error())
+		serviceUnavailableResponseWithMessage(logger, w, r, err, err.Error())
 		return
 	}
 	// Credential resolution / validation bad-request errors
 	if errors.Is(err, s3.ErrInvalidKey) ||
+		errors.Is(err, s3.ErrInvalidUploadID) ||
+		errors.Is(err, s3.ErrInvalidPart) ||
+		errors.Is(err, s3.ErrPrefixTooLarge) ||
+		errors.Is(err, s3.ErrInvalidPresignRequest) ||
 		errors.Is(err, kubernetes.ErrAmbiguousSecretKey) ||
 		errors.Is(err, s3.ErrEndpointValidation) ||
 		errors.Is(err, repositories.ErrS3Configuration) {
-		badRequestResponse(h.logger, w, r, err.Error())
+		badRequestResponse(logger, w, r, err.Error())
 		return
 	}
 
 	// Network connectivity
 	if s3.IsConnectivityError(err) {
-		badGatewayResponseWithMessage(h.logger, w, r, err,
+		badGatewayResponseWithMessage(logger, w, r, err,
 			"Unable to connect to the S3 storage endpoint. "+
 				"The endpoint may be unreachable from this cluster. "+
 				"If this is a disconnected or air-gapped environment, "+
@@ -156,7 +187,7 @@ func (h *S3Handler) handleS3RepoError(w http.ResponseWriter, r *http.Request, er
 				"points to a storage service accessible within the cluster network.")
 		return
 	}
-	serverErrorResponse(h.logger, w, r, err)
+	serverErrorResponse(logger, w, r, err)
 }
 
 // GetS3FileHandler retrieves a file from S3 storage.
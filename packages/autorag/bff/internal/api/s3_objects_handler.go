@@ -0,0 +1,238 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
+	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+// Object browser operations: copy, move and delete objects, delete a whole folder (prefix)
+// and hand out presigned links. Everything that changes the bucket requires secretName,
+// like POST /s3/files; presigned downloads fall back to the DSPA like GET /s3/files/:key.
+
+type S3DeletePrefixEnvelope Envelope[*s3.DeletePrefixResult, None]
+type S3PresignedURLEnvelope Envelope[*models.S3PresignedURL, None]
+
+// s3MaxPresignExpiry caps presigned URL lifetime. S3 allows up to 7 days, but links
+// handed to browsers should not outlive a working session.
+const s3MaxPresignExpiry = time.Hour
+
+// s3MinPresignExpiry is the shortest presigned URL lifetime a client may request.
+const s3MinPresignExpiry = time.Minute
+
+// parseS3PathKey reads and unescapes the key path parameter.
+func (h *S3Handler) parseS3PathKey(w http.ResponseWriter, r *http.Request, ps httprouter.Params) (string, bool) {
+	key, err := url.PathUnescape(ps.ByName("key"))
+	if err != nil {
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("invalid URL encoding in path parameter 'key': %s", err))
+		return "", false
+	}
+	if key == "" {
+		badRequestResponse(h.logger, w, r, "path parameter 'key' is required and cannot be empty")
+		return "", false
+	}
+	return key, true
+}
+
+// readS3Destination reads and validates the body of copy and move requests.
+func (h *S3Handler) readS3Destination(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var body models.S3ObjectDestinationRequest
+	if err := readJSON(w, r, &body); err != nil {
+		badRequestResponse(h.logger, w, r, err.Error())
+		return "", false
+	}
+	if body.DestinationKey == "" {
+		badRequestResponse(h.logger, w, r, "field 'destination_key' is required and cannot be empty")
+		return "", false
+	}
+	return body.DestinationKey, true
+}
+
+// CopyS3FileHandler copies an object to a new key in the same bucket.
+// Path parameters: key (source object).
+// Query parameters: namespace, secretName (required); bucket (optional).
+// Request body: {"destination_key": "..."}. Returns 409 if the destination exists.
+func (h *S3Handler) CopyS3FileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	key, ok := h.parseS3PathKey(w, r, ps)
+	if !ok {
+		return
+	}
+	req, ok := h.parseS3SecretRequest(w, r)
+	if !ok {
+		return
+	}
+	destination, ok := h.readS3Destination(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.CopyObject(r.Context(), req, key, destination); err != nil {
+		h.handleS3RepoError(w, r, err, destination)
+		return
+	}
+
+	resp := map[string]any{
+		"copied": true,
+		"key":    destination,
+	}
+	if err := writeJSON(w, http.StatusCreated, resp, nil); err != nil {
+		h.logger.Error("failed to write copy response", "error", err, "key", destination)
+	}
+}
+
+// MoveS3FileHandler renames an object within the same bucket.
+// Path parameters: key (source object).
+// Query parameters: namespace, secretName (required); bucket (optional).
+// Request body: {"destination_key": "..."}. Returns 409 if the destination exists.
+func (h *S3Handler) MoveS3FileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	key, ok := h.parseS3PathKey(w, r, ps)
+	if !ok {
+		return
+	}
+	req, ok := h.parseS3SecretRequest(w, r)
+	if !ok {
+		return
+	}
+	destination, ok := h.readS3Destination(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.MoveObject(r.Context(), req, key, destination); err != nil {
+		h.handleS3RepoError(w, r, err, destination)
+		return
+	}
+
+	resp := map[string]any{
+		"moved": true,
+		"key":   destination,
+	}
+	if err := writeJSON(w, http.StatusOK, resp, nil); err != nil {
+		h.logger.Error("failed to write move response", "error", err, "key", destination)
+	}
+}
+
+// DeleteS3FileHandler deletes a single object.
+// Path parameters: key.
+// Query parameters: namespace, secretName (required); bucket (optional).
+func (h *S3Handler) DeleteS3FileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	key, ok := h.parseS3PathKey(w, r, ps)
+	if !ok {
+		return
+	}
+	req, ok := h.parseS3SecretRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteObject(r.Context(), req, key); err != nil {
+		h.handleS3RepoError(w, r, err, key)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteS3FilesHandler deletes every object under a folder in two steps.
+// Query parameters: namespace, secretName, prefix (required); confirmationToken, bucket (optional).
+// Without confirmationToken nothing is deleted: the response counts the objects under the
+// prefix and carries a token valid for five minutes. Repeating the request with the token
+// deletes the objects, unless they changed in between (409).
+func (h *S3Handler) DeleteS3FilesHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	queryParams := r.URL.Query()
+	prefix := queryParams.Get("prefix")
+	if prefix == "" {
+		badRequestResponse(h.logger, w, r, "query parameter 'prefix' is required and cannot be empty")
+		return
+	}
+	req, ok := h.parseS3SecretRequest(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.repo.DeletePrefix(r.Context(), req, prefix, queryParams.Get("confirmationToken"))
+	if err != nil {
+		h.handleS3RepoError(w, r, err, prefix)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, S3DeletePrefixEnvelope{Data: result}, nil); err != nil {
+		h.logger.Error("failed to write prefix delete response", "error", err, "prefix", prefix)
+	}
+}
+
+// PresignS3FileHandler returns a time-limited URL for downloading (GET) or uploading (PUT)
+// an object directly from the browser.
+// Path parameters: key.
+// Query parameters: namespace; secretName (required for PUT; GET falls back to the DSPA); bucket (optional).
+// Request body: {"method": "GET"|"PUT", "expires_in_seconds": 900}. Expiry defaults to 15
+// minutes and may be 60-3600 seconds. PUT keys are suffixed (-1, -2, …) like
+// POST /s3/files when taken, and the URL never replaces an existing object.
+func (h *S3Handler) PresignS3FileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	key, ok := h.parseS3PathKey(w, r, ps)
+	if !ok {
+		return
+	}
+
+	var body models.S3PresignRequest
+	if err := readJSON(w, r, &body); err != nil {
+		badRequestResponse(h.logger, w, r, err.Error())
+		return
+	}
+	method := strings.ToUpper(body.Method)
+	if method != http.MethodGet && method != http.MethodPut {
+		badRequestResponse(h.logger, w, r, "field 'method' must be GET or PUT")
+		return
+	}
+	expires := time.Duration(body.ExpiresInSeconds) * time.Second
+	if body.ExpiresInSeconds != 0 && (expires < s3MinPresignExpiry || expires > s3MaxPresignExpiry) {
+		badRequestResponse(h.logger, w, r, fmt.Sprintf("field 'expires_in_seconds' must be between %d and %d",
+			int(s3MinPresignExpiry.Seconds()), int(s3MaxPresignExpiry.Seconds())))
+		return
+	}
+
+	var presigned *models.S3PresignedURL
+	var err error
+	if method == http.MethodPut {
+		req, ok := h.parseS3SecretRequest(w, r)
+		if !ok {
+			return
+		}
+		presigned, err = h.repo.PresignUpload(r.Context(), req, key, expires, h.effectivePostS3CollisionAttempts())
+	} else {
+		queryParams := r.URL.Query()
+		secretName := queryParams.Get("secretName")
+		if secretName != "" {
+			if err := kubernetes.ValidateResourceName("secretName", secretName); err != nil {
+				badRequestResponse(h.logger, w, r, fmt.Sprintf("invalid secretName: %s", err))
+				return
+			}
+		}
+		req, ok := h.buildS3Request(w, r, secretName, queryParams.Get("bucket"))
+		if !ok {
+			return
+		}
+		presigned, err = h.repo.PresignDownload(r.Context(), req, key, expires)
+	}
+	if err != nil {
+		if errors.Is(err, s3.ErrMaxCollisionsExceeded) {
+			conflictResponse(h.logger, w, r,
+				fmt.Sprintf("unable to find unique filename (%s); try a different base name", err))
+			return
+		}
+		h.handleS3RepoError(w, r, err, key)
+		return
+	}
+
+	if err := writeJSON(w, http.StatusOK, S3PresignedURLEnvelope{Data: presigned}, nil); err != nil {
+		h.logger.Error("failed to write presign response", "error", err, "key", key)
+	}
+}
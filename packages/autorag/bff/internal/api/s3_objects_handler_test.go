@@ -0,0 +1,329 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
+	"github.com/opendatahub-io/autorag-library/bff/internal/repositories"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newS3ObjectRequest creates a request for the object browser handlers with namespace
+// in context. The query string is set directly on the URL.
+func newS3ObjectRequest(method, queryString, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/s3/files", strings.NewReader(body))
+	req.URL.RawQuery = queryString
+	return req.WithContext(ctxWithNamespace("test-ns"))
+}
+
+func TestCopyAndMoveS3FileHandlers(t *testing.T) {
+	secretReq := repositories.S3RequestContext{Namespace: "test-ns", SecretName: "my-secret"}
+	tests := []struct {
+		name             string
+		move             bool
+		key              string
+		queryString      string
+		body             string
+		setupMock        func(repo *mockS3Repo)
+		wantStatusCode   int
+		wantBodyContains string
+	}{
+		{
+			name:        "copy returns 201",
+			key:         "docs%2Fa.pdf",
+			queryString: "secretName=my-secret",
+			body:        `{"destination_key":"docs/b.pdf"}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("CopyObject", mock.Anything, secretReq, "docs/a.pdf", "docs/b.pdf").Return(nil)
+			},
+			wantStatusCode:   http.StatusCreated,
+			wantBodyContains: `"copied": true`,
+		},
+		{
+			name:        "move returns 200",
+			move:        true,
+			key:         "docs%2Fa.pdf",
+			queryString: "secretName=my-secret",
+			body:        `{"destination_key":"archive/a.pdf"}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("MoveObject", mock.Anything, secretReq, "docs/a.pdf", "archive/a.pdf").Return(nil)
+			},
+			wantStatusCode:   http.StatusOK,
+			wantBodyContains: `"moved": true`,
+		},
+		{
+			name:             "missing secretName returns 400",
+			key:              "a.pdf",
+			body:             `{"destination_key":"b.pdf"}`,
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "secretName",
+		},
+		{
+			name:             "missing destination returns 400",
+			key:              "a.pdf",
+			queryString:      "secretName=my-secret",
+			body:             `{}`,
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "destination_key",
+		},
+		{
+			name:        "existing destination returns 409",
+			key:         "a.pdf",
+			queryString: "secretName=my-secret",
+			body:        `{"destination_key":"b.pdf"}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("CopyObject", mock.Anything, mock.Anything, "a.pdf", "b.pdf").Return(s3.ErrObjectAlreadyExists)
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+		{
+			name:        "missing source returns 404",
+			move:        true,
+			key:         "a.pdf",
+			queryString: "secretName=my-secret",
+			body:        `{"destination_key":"b.pdf"}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("MoveObject", mock.Anything, mock.Anything, "a.pdf", "b.pdf").Return(s3.ErrObjectNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockS3Repo)
+			tt.setupMock(repo)
+			handler := &S3Handler{logger: silentLogger(), repo: repo}
+
+			rr := httptest.NewRecorder()
+			req := newS3ObjectRequest(http.MethodPost, tt.queryString, tt.body)
+			ps := httprouter.Params{{Key: "key", Value: tt.key}}
+			if tt.move {
+				handler.MoveS3FileHandler(rr, req, ps)
+			} else {
+				handler.CopyS3FileHandler(rr, req, ps)
+			}
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			if tt.wantBodyContains != "" {
+				assert.Contains(t, rr.Body.String(), tt.wantBodyContains)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteS3FileHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryString    string
+		setupMock      func(repo *mockS3Repo)
+		wantStatusCode int
+	}{
+		{
+			name:        "success returns 204",
+			queryString: "secretName=my-secret",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("DeleteObject", mock.Anything, mock.Anything, "docs/a.pdf").Return(nil)
+			},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "missing secretName returns 400",
+			setupMock:      func(repo *mockS3Repo) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "missing object returns 404",
+			queryString: "secretName=my-secret",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("DeleteObject", mock.Anything, mock.Anything, "docs/a.pdf").Return(s3.ErrObjectNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockS3Repo)
+			tt.setupMock(repo)
+			handler := &S3Handler{logger: silentLogger(), repo: repo}
+
+			rr := httptest.NewRecorder()
+			handler.DeleteS3FileHandler(rr, newS3ObjectRequest(http.MethodDelete, tt.queryString, ""),
+				httprouter.Params{{Key: "key", Value: "docs%2Fa.pdf"}})
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteS3FilesHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		queryString      string
+		setupMock        func(repo *mockS3Repo)
+		wantStatusCode   int
+		wantBodyContains string
+	}{
+		{
+			name:        "preview returns confirmation token",
+			queryString: "secretName=my-secret&prefix=docs%2F",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("DeletePrefix", mock.Anything, mock.Anything, "docs/", "").
+					Return(&s3.DeletePrefixResult{Prefix: "docs/", ObjectCount: 2, ConfirmationToken: "tok"}, nil)
+			},
+			wantStatusCode:   http.StatusOK,
+			wantBodyContains: `"confirmation_token": "tok"`,
+		},
+		{
+			name:        "confirmed delete",
+			queryString: "secretName=my-secret&prefix=docs%2F&confirmationToken=tok",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("DeletePrefix", mock.Anything, mock.Anything, "docs/", "tok").
+					Return(&s3.DeletePrefixResult{Prefix: "docs/", ObjectCount: 2, Deleted: true, DeletedCount: 2}, nil)
+			},
+			wantStatusCode:   http.StatusOK,
+			wantBodyContains: `"deleted_count": 2`,
+		},
+		{
+			name:             "missing prefix returns 400",
+			queryString:      "secretName=my-secret",
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "prefix",
+		},
+		{
+			name:        "stale token returns 409",
+			queryString: "secretName=my-secret&prefix=docs%2F&confirmationToken=old",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("DeletePrefix", mock.Anything, mock.Anything, "docs/", "old").
+					Return(nil, s3.ErrInvalidConfirmationToken)
+			},
+			wantStatusCode:   http.StatusConflict,
+			wantBodyContains: "request a new confirmation token",
+		},
+		{
+			name:        "too many objects returns 400",
+			queryString: "secretName=my-secret&prefix=docs%2F",
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("DeletePrefix", mock.Anything, mock.Anything, "docs/", "").
+					Return(nil, s3.ErrPrefixTooLarge)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockS3Repo)
+			tt.setupMock(repo)
+			handler := &S3Handler{logger: silentLogger(), repo: repo}
+
+			rr := httptest.NewRecorder()
+			handler.DeleteS3FilesHandler(rr, newS3ObjectRequest(http.MethodDelete, tt.queryString, ""), nil)
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			if tt.wantBodyContains != "" {
+				assert.Contains(t, rr.Body.String(), tt.wantBodyContains)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPresignS3FileHandler(t *testing.T) {
+	presigned := &models.S3PresignedURL{
+		Key:          "docs/a-1.pdf",
+		PresignedURL: s3.PresignedURL{URL: "https://s3.example.com/signed", Method: http.MethodPut},
+	}
+	tests := []struct {
+		name             string
+		queryString      string
+		body             string
+		setupMock        func(repo *mockS3Repo)
+		wantStatusCode   int
+		wantBodyContains string
+	}{
+		{
+			name:        "upload URL uses the resolved key",
+			queryString: "secretName=my-secret",
+			body:        `{"method":"put","expires_in_seconds":600}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("PresignUpload", mock.Anything, mock.Anything, "docs/a.pdf", 10*time.Minute, 0).Return(presigned, nil)
+			},
+			wantStatusCode:   http.StatusOK,
+			wantBodyContains: `"key": "docs/a-1.pdf"`,
+		},
+		{
+			name: "download URL without secretName falls back to DSPA",
+			body: `{"method":"GET"}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("PresignDownload", mock.Anything, repositories.S3RequestContext{Namespace: "test-ns"}, "docs/a.pdf", time.Duration(0)).
+					Return(&models.S3PresignedURL{Key: "docs/a.pdf"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:             "upload URL without secretName returns 400",
+			body:             `{"method":"PUT"}`,
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "secretName",
+		},
+		{
+			name:             "unsupported method returns 400",
+			queryString:      "secretName=my-secret",
+			body:             `{"method":"DELETE"}`,
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "method",
+		},
+		{
+			name:             "expiry above cap returns 400",
+			queryString:      "secretName=my-secret",
+			body:             `{"method":"GET","expires_in_seconds":86400}`,
+			setupMock:        func(repo *mockS3Repo) {},
+			wantStatusCode:   http.StatusBadRequest,
+			wantBodyContains: "expires_in_seconds",
+		},
+		{
+			name:        "collision cap returns 409",
+			queryString: "secretName=my-secret",
+			body:        `{"method":"PUT"}`,
+			setupMock: func(repo *mockS3Repo) {
+				repo.On("PresignUpload", mock.Anything, mock.Anything, "docs/a.pdf", time.Duration(0), 0).
+					Return(nil, s3.ErrMaxCollisionsExceeded)
+			},
+			wantStatusCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mockS3Repo)
+			tt.setupMock(repo)
+			handler := &S3Handler{logger: silentLogger(), repo: repo}
+
+			rr := httptest.NewRecorder()
+			handler.PresignS3FileHandler(rr, newS3ObjectRequest(http.MethodPost, tt.queryString, tt.body),
+				httprouter.Params{{Key: "key", Value: "docs%2Fa.pdf"}})
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+			if tt.wantBodyContains != "" {
+				assert.Contains(t, rr.Body.String(), tt.wantBodyContains)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -155,6 +155,26 @@ type EnvConfig struct {
 	// Default is false (secure) for production environments
 	InsecureSkipVerify bool
 
+	// ─── BFF INTER-COMMUNICATION ─────────────────────────────────
+	// MockBFFClients enables mock mode for BFF inter-communication clients.
+	// When true, BFF clients return mock responses instead of making real HTTP calls.
+	MockBFFClients bool
+
+	// BFFGenAIServiceName is the Kubernetes service name for the gen-ai BFF.
+	// Default: "odh-dashboard-gen-ai-ui" (standalone module Service).
+	BFFGenAIServiceName string
+
+	// BFFGenAIServicePort is the port for the gen-ai BFF service.
+	// Default: 8143
+	BFFGenAIServicePort int
+
+	// BFFGenAITLSEnabled enables HTTPS for gen-ai BFF communication.
+	BFFGenAITLSEnabled bool
+
+	// BFFGenAIDevURL is a developer override URL for the gen-ai BFF (local development).
+	// When set, overrides service discovery. Example: "http://localhost:8143/api/v1"
+	BFFGenAIDevURL string
+
 	// ─── DEPRECATED ─────────────────────────────────────────────
 	// The following fields are deprecated and maintained for backward compatibility
 	// Use DeploymentMode instead
@@ -3,6 +3,8 @@ package fake
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
 	v1 "k8s.io/api/core/v1"
@@ -177,6 +179,15 @@ func (c *K8sClient) GetPods(_ context.Context, _ string) (*v1.PodList, error) {
 	return &v1.PodList{}, nil
 }
 
+// GetPodLogs returns a few canned executor log lines so the run task log view renders
+// without a live cluster.
+func (c *K8sClient) GetPodLogs(_ context.Context, namespace, podName string, _ *v1.PodLogOptions) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(fmt.Sprintf(
+		"[KFP Executor] pod %s/%s started\nloading inputs\nwriting outputs\n[KFP Executor] completed\n",
+		namespace, podName,
+	))), nil
+}
+
 func (c *K8sClient) GetSecrets(_ context.Context, namespace string) ([]v1.Secret, error) {
 	if secrets, ok := fakeSecrets[namespace]; ok {
 		return secrets, nil
@@ -2,6 +2,7 @@ package fake
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/ogx"
 	"github.com/opendatahub-io/autorag-library/bff/internal/models"
@@ -39,3 +40,18 @@ func (c *OGXClient) ListProviders(_ context.Context, _, _ string) ([]models.OGXP
 		{API: "vector_io", ProviderID: "pgvector", ProviderType: "remote::pgvector"},
 	}, nil
 }
+
+// SearchVectorStore returns up to maxResults chunks from a fixed set of three documents.
+func (c *OGXClient) SearchVectorStore(_ context.Context, _, _, _, query string, maxResults int) ([]models.OGXVectorStoreSearchResult, error) {
+	documents := []string{"handbook.pdf", "faq.md", "release-notes.md"}
+	results := make([]models.OGXVectorStoreSearchResult, 0, maxResults)
+	for i := 0; i < maxResults && i < len(documents); i++ {
+		results = append(results, models.OGXVectorStoreSearchResult{
+			FileID:   fmt.Sprintf("file-%d", i+1),
+			Filename: documents[i],
+			Score:    0.9 - 0.1*float64(i),
+			Content:  []models.OGXVectorStoreSearchContent{{Type: "text", Text: fmt.Sprintf("Chunk of %s relevant to %q", documents[i], query)}},
+		})
+	}
+	return results, nil
+}
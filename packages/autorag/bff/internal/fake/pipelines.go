@@ -194,6 +194,20 @@ func (c *PipelinesClient) DeleteRun(_ context.Context, _ string, runID string) e
 	return nil
 }
 
+// Recurring runs are not used by AutoRAG; the fake reports none exist.
+
+func (c *PipelinesClient) CreateRecurringRun(_ context.Context, _ string, _ *plsvc.CreateRecurringRunInput) (*plsvc.RecurringRun, error) {
+	return nil, fmt.Errorf("%w: recurring runs are not supported by the fake pipeline server", plsvc.ErrInvalidInput)
+}
+
+func (c *PipelinesClient) GetRecurringRun(_ context.Context, _ string, recurringRunID string) (*plsvc.RecurringRun, error) {
+	return nil, fmt.Errorf("%w: recurring run %q not found", plsvc.ErrPipelineNotFound, recurringRunID)
+}
+
+func (c *PipelinesClient) DeleteRecurringRun(_ context.Context, _ string, recurringRunID string) error {
+	return fmt.Errorf("%w: recurring run %q not found", plsvc.ErrPipelineNotFound, recurringRunID)
+}
+
 func (c *PipelinesClient) ListPipelines(_ context.Context, _ string, _ string) (*plsvc.PipelinesResponse, error) {
 	return &plsvc.PipelinesResponse{
 		Pipelines: []plsvc.Pipeline{
@@ -258,7 +272,7 @@ func (c *PipelinesClient) progressRun(runID string) {
 
 	for _, name := range taskNames {
 		run.RunDetails.TaskDetails = append(run.RunDetails.TaskDetails, plsvc.TaskDetail{
-			RunID: runID, TaskID: uuid.New().String(), DisplayName: name,
+			RunID: runID, TaskID: uuid.New().String(), DisplayName: name, ChildTasks: fakeTaskPods(runID, name),
 			State: "PENDING", CreateTime: run.CreatedAt, StartTime: now,
 			StateHistory: []plsvc.RuntimeStatus{{UpdateTime: now, State: "PENDING"}},
 		})
@@ -361,6 +375,7 @@ func buildSeedRunDetails(runID string, state plsvc.RunState, createdAt, finished
 			CreateTime:  createdAt,
 			StartTime:   createdAt,
 			EndTime:     finishedAt,
+			ChildTasks:  fakeTaskPods(runID, name),
 			StateHistory: []plsvc.RuntimeStatus{
 				{UpdateTime: createdAt, State: "RUNNING"},
 				{UpdateTime: finishedAt, State: state},
@@ -369,3 +384,10 @@ func buildSeedRunDetails(runID string, state plsvc.RunState, createdAt, finished
 	}
 	return &plsvc.RunDetails{TaskDetails: details}
 }
+
+// fakeTaskPods names the executor pod of a task; the fake K8s client serves canned logs
+// for any pod name.
+func fakeTaskPods(runID, taskName string) []plsvc.ChildTask {
+	prefix, _, _ := strings.Cut(runID, "-")
+	return []plsvc.ChildTask{{PodName: taskName + "-" + prefix + "-system-container-impl"}}
+}
@@ -1,6 +1,7 @@
 package ogx
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -8,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/opendatahub-io/autorag-library/bff/internal/models"
@@ -24,6 +26,7 @@ type httpClientInterface interface {
 type OGXClientInterface interface {
 	ListModels(ctx context.Context, baseURL, apiKey string) ([]models.OGXNativeModel, error)
 	ListProviders(ctx context.Context, baseURL, apiKey string) ([]models.OGXProvider, error)
+	SearchVectorStore(ctx context.Context, baseURL, apiKey, vectorStoreID, query string, maxResults int) ([]models.OGXVectorStoreSearchResult, error)
 }
 
 // OGXClient communicates with an Open GenAI Stack Distribution server.
@@ -164,6 +167,60 @@ func (c *OGXClient) ListProviders(ctx context.Context, baseURL, apiKey string) (
 	return envelope.Data, nil
 }
 
+// SearchVectorStore runs a query against a vector store via the OpenAI-compatible
+// /v1/vector_stores/{id}/search endpoint and returns the chunks in rank order.
+func (c *OGXClient) SearchVectorStore(ctx context.Context, baseURL, apiKey, vectorStoreID, query string, maxResults int) ([]models.OGXVectorStoreSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	payload, err := json.Marshal(map[string]any{"query": query, "max_num_results": maxResults})
+	if err != nil {
+		return nil, NewOGXError(ErrCodeInternalError,
+			fmt.Sprintf("failed to encode Open GenAI Stack vector store search request: %s", err.Error()),
+			http.StatusInternalServerError)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		baseURL+"/v1/vector_stores/"+url.PathEscape(vectorStoreID)+"/search", bytes.NewReader(payload))
+	if err != nil {
+		return nil, NewConnectionError(fmt.Sprintf("failed to create request for Open GenAI Stack vector store search: %s", err.Error()))
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	setAuthHeader(req, apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, wrapClientError(err, "SearchVectorStore")
+	}
+	defer resp.Body.Close()
+
+	const maxSearchResponseBytes = 4 << 20 // 4 MiB
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSearchResponseBytes))
+	if err != nil {
+		return nil, NewOGXError(ErrCodeInternalError,
+			fmt.Sprintf("failed to read Open GenAI Stack vector store search response body: %s", err.Error()),
+			http.StatusInternalServerError)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, NewNotFoundError(fmt.Sprintf("Open GenAI Stack vector store %q not found", vectorStoreID))
+		}
+		return nil, mapHTTPStatusToError(resp.StatusCode, body, "vector_stores")
+	}
+
+	var envelope struct {
+		Data []models.OGXVectorStoreSearchResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, NewOGXError(ErrCodeInternalError,
+			fmt.Sprintf("failed to parse Open GenAI Stack vector store search response: %s", err.Error()),
+			http.StatusInternalServerError)
+	}
+
+	return envelope.Data, nil
+}
+
 // setAuthHeader sets the Authorization header when an API key is provided.
 // The header is omitted over plain HTTP (except localhost) to avoid leaking tokens.
 func setAuthHeader(req *http.Request, apiKey string) {
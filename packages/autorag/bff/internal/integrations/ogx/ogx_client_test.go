@@ -227,6 +227,47 @@ func TestOGXClient_ListProviders(t *testing.T) {
 	})
 }
 
+// --- SearchVectorStore ---
+
+func TestOGXClient_SearchVectorStore(t *testing.T) {
+	t.Run("posts the query and parses ranked chunks", func(t *testing.T) {
+		ts, c := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/v1/vector_stores/vs_1%2Fx/search", r.URL.EscapedPath())
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "how do I reset?", body["query"])
+			assert.Equal(t, float64(3), body["max_num_results"])
+			jsonResponse(t, w, map[string]any{
+				"object": "vector_store.search_results.page",
+				"data": []map[string]any{
+					{"file_id": "file-1", "filename": "faq.md", "score": 0.8, "content": []map[string]any{{"type": "text", "text": "Reset via settings."}}},
+				},
+			})
+		})
+		defer ts.Close()
+
+		got, err := c.SearchVectorStore(context.Background(), ts.URL, "", "vs_1/x", "how do I reset?", 3)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, "faq.md", got[0].Filename)
+		assert.Equal(t, "Reset via settings.", got[0].Content[0].Text)
+	})
+
+	t.Run("unknown vector store returns NOT_FOUND", func(t *testing.T) {
+		ts, c := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		defer ts.Close()
+
+		_, err := c.SearchVectorStore(context.Background(), ts.URL, "", "missing", "q", 5)
+		var ogxErr *OGXError
+		require.ErrorAs(t, err, &ogxErr)
+		assert.Equal(t, ErrCodeNotFound, ogxErr.Code)
+		assert.Contains(t, ogxErr.Message, "missing")
+	})
+}
+
 // --- setAuthHeader ---
 
 func TestSetAuthHeader(t *testing.T) {
@@ -56,3 +56,19 @@ type OGXVectorStoreProvider struct {
 type OGXVectorStoreProvidersData struct {
 	VectorStoreProviders []OGXVectorStoreProvider `json:"vector_store_providers"`
 }
+
+// OGXVectorStoreSearchResult is one chunk returned by the OpenAI-compatible
+// /v1/vector_stores/{id}/search endpoint. Internal format, not exposed to the frontend.
+type OGXVectorStoreSearchResult struct {
+	FileID     string                        `json:"file_id"`
+	Filename   string                        `json:"filename"`
+	Score      float64                       `json:"score"`
+	Attributes map[string]any                `json:"attributes,omitempty"`
+	Content    []OGXVectorStoreSearchContent `json:"content"`
+}
+
+// OGXVectorStoreSearchContent is a content part of a search result chunk.
+type OGXVectorStoreSearchContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
@@ -0,0 +1,134 @@
+package models
+
+// RAGPattern is the subset of an AutoRAG pattern.json artifact needed to rebuild the
+// pattern in the gen-ai playground. Both the legacy layout (top-level final_score) and the
+// current layout (evaluation.metrics) are accepted.
+type RAGPattern struct {
+	Name       string                `json:"name"`
+	Settings   RAGPatternSettings    `json:"settings"`
+	Evaluation *RAGPatternEvaluation `json:"evaluation,omitempty"`
+	// FinalScore is only present in legacy pattern.json files.
+	FinalScore *float64 `json:"final_score,omitempty"`
+	Indexing   *struct {
+		PipelineSpec *struct {
+			Parameters map[string]any `json:"parameters"`
+		} `json:"pipeline_spec,omitempty"`
+	} `json:"indexing,omitempty"`
+}
+
+type RAGPatternSettings struct {
+	VectorStoreBinding *struct {
+		ProviderID    string  `json:"provider_id"`
+		VectorStoreID *string `json:"vector_store_id"`
+	} `json:"vector_store_binding,omitempty"`
+	Chunking struct {
+		Method       string `json:"method"`
+		ChunkSize    int    `json:"chunk_size"`
+		ChunkOverlap int    `json:"chunk_overlap"`
+	} `json:"chunking"`
+	Embedding struct {
+		ModelID string `json:"model_id"`
+	} `json:"embedding"`
+	Retrieval struct {
+		Method         string `json:"method"`
+		NumberOfChunks int    `json:"number_of_chunks"`
+		SearchMode     string `json:"search_mode,omitempty"`
+	} `json:"retrieval"`
+	Generation struct {
+		ModelID             string   `json:"model_id"`
+		Temperature         *float64 `json:"temperature,omitempty"`
+		MaxCompletionTokens *int     `json:"max_completion_tokens,omitempty"`
+	} `json:"generation"`
+}
+
+type RAGPatternEvaluation struct {
+	Metrics []struct {
+		Name   string `json:"name"`
+		Scores struct {
+			Mean *float64 `json:"mean"`
+		} `json:"scores"`
+		OptimizationMetric bool `json:"optimization_metric,omitempty"`
+	} `json:"metrics"`
+}
+
+// PromoteToPlaygroundRequest is the BFF-level input for promoting an AutoRAG pattern to the
+// gen-ai playground. All fields are optional.
+type PromoteToPlaygroundRequest struct {
+	// PatternName selects a pattern by name. Defaults to the pattern with the best
+	// optimization metric score.
+	PatternName string `json:"pattern_name,omitempty"`
+	// DisplayName names the agent profile and vector store. Defaults to
+	// "<run display name> - <pattern name>".
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PlaygroundPromotion describes the gen-ai resources created from an AutoRAG pattern.
+type PlaygroundPromotion struct {
+	RunID             string   `json:"run_id"`
+	PatternName       string   `json:"pattern_name"`
+	Score             *float64 `json:"score,omitempty"`
+	GenerationModelID string   `json:"generation_model_id"`
+	EmbeddingModelID  string   `json:"embedding_model_id"`
+	VectorStoreID     string   `json:"vector_store_id"`
+	VectorStoreName   string   `json:"vector_store_name"`
+	AgentProfileID    string   `json:"agent_profile_id"`
+	AgentProfileName  string   `json:"agent_profile_name"`
+
+	// IndexingParameters are the pattern's documents-indexing-pipeline parameters. The new
+	// vector store starts empty; pass these to POST /api/v1/indexing-pipeline-runs to
+	// index documents with the pattern's chunking and embedding settings.
+	IndexingParameters map[string]any `json:"indexing_parameters,omitempty"`
+}
+
+// GenAIModel is an AI asset model returned by the gen-ai BFF (GET /aaa/models).
+type GenAIModel struct {
+	ModelID         string   `json:"model_id"`
+	ModelName       string   `json:"model_name"`
+	Endpoints       []string `json:"endpoints"`
+	ModelSourceType string   `json:"model_source_type"`
+}
+
+// GenAICreateVectorStoreRequest is the gen-ai BFF request body for POST /lsd/vectorstores.
+type GenAICreateVectorStoreRequest struct {
+	Name     string            `json:"name"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// GenAIVectorStore is the vector store returned by the gen-ai BFF.
+type GenAIVectorStore struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// GenAIAgentProfileSpec is the subset of the gen-ai AgentProfile spec set on promotion.
+type GenAIAgentProfileSpec struct {
+	DisplayName     string                   `json:"displayName"`
+	Description     string                   `json:"description,omitempty"`
+	Model           GenAIModelReference      `json:"model"`
+	Temperature     *float64                 `json:"temperature,omitempty"`
+	MaxOutputTokens *int                     `json:"maxOutputTokens,omitempty"`
+	VectorStores    *GenAIVectorStoresConfig `json:"vectorStores,omitempty"`
+}
+
+type GenAIModelReference struct {
+	ID         string `json:"id"`
+	URI        string `json:"uri"`
+	SourceType string `json:"sourceType,omitempty"`
+}
+
+type GenAIVectorStoresConfig struct {
+	Stores        []GenAIVectorStoreRef `json:"stores"`
+	MaxNumResults *int                  `json:"maxNumResults,omitempty"`
+}
+
+type GenAIVectorStoreRef struct {
+	ID string `json:"id"`
+}
+
+// GenAIAgentProfile is the gen-ai BFF response for POST /agent-profiles.
+type GenAIAgentProfile struct {
+	Name        string `json:"name"`
+	ProfileID   string `json:"profileId"`
+	DisplayName string `json:"displayName"`
+}
@@ -0,0 +1,60 @@
+package models
+
+// RetrievalEvalRequest is a gold set of questions evaluated against an existing vector store.
+type RetrievalEvalRequest struct {
+	// K is the number of chunks retrieved per question. Defaults to 5.
+	K         int                     `json:"k,omitempty"`
+	Questions []RetrievalEvalQuestion `json:"questions"`
+}
+
+// RetrievalEvalQuestion is one gold question and the documents a good retrieval returns.
+// Expected documents are matched against the file ID, filename or base filename of
+// each retrieved chunk.
+type RetrievalEvalQuestion struct {
+	ID                string   `json:"id,omitempty"`
+	Question          string   `json:"question"`
+	ExpectedDocuments []string `json:"expected_documents"`
+}
+
+// RetrievalEvaluation holds aggregate and per-question retrieval metrics. Metrics are
+// computed at document level: each expected document counts once, at the rank of its
+// first retrieved chunk.
+type RetrievalEvaluation struct {
+	VectorStoreID string                        `json:"vector_store_id"`
+	K             int                           `json:"k"`
+	Metrics       RetrievalMetrics              `json:"metrics"`
+	Questions     []RetrievalEvalQuestionResult `json:"questions"`
+}
+
+// RetrievalMetrics are averaged over all questions for the aggregate result.
+type RetrievalMetrics struct {
+	// RecallAtK is the fraction of expected documents found in the top K chunks.
+	RecallAtK float64 `json:"recall_at_k"`
+	// MRR is the reciprocal rank of the first relevant chunk (0 when none is retrieved).
+	MRR float64 `json:"mrr"`
+	// NDCGAtK is the normalized discounted cumulative gain with binary relevance.
+	NDCGAtK float64 `json:"ndcg_at_k"`
+}
+
+// RetrievalEvalQuestionResult is the evaluation of a single question.
+type RetrievalEvalQuestionResult struct {
+	ID                string           `json:"id,omitempty"`
+	Question          string           `json:"question"`
+	ExpectedDocuments []string         `json:"expected_documents"`
+	Metrics           RetrievalMetrics `json:"metrics"`
+	MatchedDocuments  []string         `json:"matched_documents"`
+	MissingDocuments  []string         `json:"missing_documents"`
+	RetrievedChunks   []RetrievedChunk `json:"retrieved_chunks"`
+}
+
+// RetrievedChunk is one retrieved chunk in rank order.
+type RetrievedChunk struct {
+	Rank     int     `json:"rank"`
+	FileID   string  `json:"file_id"`
+	Filename string  `json:"filename,omitempty"`
+	Score    float64 `json:"score"`
+	// Text is truncated for display.
+	Text string `json:"text"`
+	// MatchedDocument is the expected document this chunk matched, if any.
+	MatchedDocument string `json:"matched_document,omitempty"`
+}
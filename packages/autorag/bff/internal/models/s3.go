@@ -7,3 +7,41 @@ import s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/
 type ObjectInfo = s3.ObjectInfo
 type CommonPrefix = s3.CommonPrefix
 type ListObjectsResponse = s3.ListObjectsResponse
+
+// CreateS3UploadRequest is the body of POST /api/v1/s3/uploads.
+type CreateS3UploadRequest struct {
+	Key         string `json:"key"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// CompleteS3UploadRequest is the optional body of POST /api/v1/s3/uploads/complete.
+// Without parts, the upload is completed with every part stored in S3.
+type CompleteS3UploadRequest struct {
+	Parts []s3.UploadedPart `json:"parts,omitempty"`
+}
+
+// S3UploadParts lists the parts stored for a multipart upload.
+type S3UploadParts struct {
+	Key      string            `json:"key"`
+	UploadID string            `json:"upload_id"`
+	Parts    []s3.UploadedPart `json:"parts"`
+}
+
+// S3ObjectDestinationRequest is the body of POST /api/v1/s3/files/:key/copy and /move.
+type S3ObjectDestinationRequest struct {
+	DestinationKey string `json:"destination_key"`
+}
+
+// S3PresignRequest is the body of POST /api/v1/s3/files/:key/presign.
+// ExpiresInSeconds of 0 uses the default expiry.
+type S3PresignRequest struct {
+	Method           string `json:"method"`
+	ExpiresInSeconds int    `json:"expires_in_seconds,omitempty"`
+}
+
+// S3PresignedURL is a presigned URL for Key, which for uploads may differ from the
+// requested key when a collision was resolved.
+type S3PresignedURL struct {
+	Key string `json:"key"`
+	s3.PresignedURL
+}
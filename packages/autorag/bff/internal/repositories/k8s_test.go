@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"testing"
 
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
@@ -74,6 +75,9 @@ func (m *mockK8sService) PatchResource(_ context.Context, _ schema.GroupVersionR
 func (m *mockK8sService) PatchDeployment(_ context.Context, _, _ string, _ types.PatchType, _ []byte) error {
 	return nil
 }
+func (m *mockK8sService) GetPodLogs(_ context.Context, _, _ string, _ *v1.PodLogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
 func (m *mockK8sService) DiscoverResourceGVR(context.Context, string, string, string, []string) (schema.GroupVersionResource, error) {
 	return schema.GroupVersionResource{}, nil
 }
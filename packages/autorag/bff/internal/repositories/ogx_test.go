@@ -18,6 +18,7 @@ import (
 type mockOGXClient struct {
 	listModelsFn    func(ctx context.Context, baseURL, apiKey string) ([]models.OGXNativeModel, error)
 	listProvidersFn func(ctx context.Context, baseURL, apiKey string) ([]models.OGXProvider, error)
+	searchFn        func(ctx context.Context, baseURL, apiKey, vectorStoreID, query string, maxResults int) ([]models.OGXVectorStoreSearchResult, error)
 }
 
 func (m *mockOGXClient) ListModels(ctx context.Context, baseURL, apiKey string) ([]models.OGXNativeModel, error) {
@@ -33,6 +34,13 @@ func (m *mockOGXClient) ListProviders(ctx context.Context, baseURL, apiKey strin
 	return nil, nil
 }
 
+func (m *mockOGXClient) SearchVectorStore(ctx context.Context, baseURL, apiKey, vectorStoreID, query string, maxResults int) ([]models.OGXVectorStoreSearchResult, error) {
+	if m.searchFn != nil {
+		return m.searchFn(ctx, baseURL, apiKey, vectorStoreID, query, maxResults)
+	}
+	return nil, nil
+}
+
 type mockK8sForOGX struct {
 	mockK8sService
 	getSecretFn func(ctx context.Context, namespace, secretName string) (*v1.Secret, error)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"strings"
 	"testing"
@@ -25,6 +26,9 @@ type mockPipelinesService struct {
 	terminateRunFn           func(ctx context.Context, namespace, runID string) error
 	retryRunFn               func(ctx context.Context, namespace, runID string) error
 	deleteRunFn              func(ctx context.Context, namespace, runID string) error
+	getRunDAGFn              func(ctx context.Context, namespace, runID string) (*pipelines.RunDAG, error)
+	streamTaskLogsFn         func(ctx context.Context, namespace, runID, taskID string, opts pipelines.TaskLogOptions) (io.ReadCloser, error)
+	listRunArtifactsFn       func(ctx context.Context, namespace, runID string) ([]pipelines.RunArtifact, error)
 }
 
 func (m *mockPipelinesService) DiscoverNamedPipelines(ctx context.Context, namespace, defaultVersion string, definitions map[string]string) (map[string]*pipelines.DiscoveredPipeline, error) {
@@ -51,6 +55,15 @@ func (m *mockPipelinesService) RetryRun(ctx context.Context, namespace, runID st
 func (m *mockPipelinesService) DeleteRun(ctx context.Context, namespace, runID string) error {
 	return m.deleteRunFn(ctx, namespace, runID)
 }
+func (m *mockPipelinesService) GetRunDAG(ctx context.Context, namespace, runID string) (*pipelines.RunDAG, error) {
+	return m.getRunDAGFn(ctx, namespace, runID)
+}
+func (m *mockPipelinesService) StreamTaskLogs(ctx context.Context, namespace, runID, taskID string, opts pipelines.TaskLogOptions) (io.ReadCloser, error) {
+	return m.streamTaskLogsFn(ctx, namespace, runID, taskID, opts)
+}
+func (m *mockPipelinesService) ListRunArtifacts(ctx context.Context, namespace, runID string) ([]pipelines.RunArtifact, error) {
+	return m.listRunArtifactsFn(ctx, namespace, runID)
+}
 
 // Unused — stub to satisfy pipelines.Service
 func (m *mockPipelinesService) GetPipelineRun(context.Context, string, string) (*pipelines.PipelineRun, error) {
@@ -59,6 +72,13 @@ func (m *mockPipelinesService) GetPipelineRun(context.Context, string, string) (
 func (m *mockPipelinesService) ListPipelineRuns(context.Context, string, *pipelines.ListRunsParams) (*pipelines.PipelineRunResponse, error) {
 	return nil, nil
 }
+func (m *mockPipelinesService) CreateRecurringRun(context.Context, string, *pipelines.CreateRecurringRunInput) (*pipelines.RecurringRun, error) {
+	return nil, nil
+}
+func (m *mockPipelinesService) GetRecurringRun(context.Context, string, string) (*pipelines.RecurringRun, error) {
+	return nil, nil
+}
+func (m *mockPipelinesService) DeleteRecurringRun(context.Context, string, string) error { return nil }
 func (m *mockPipelinesService) ListPipelines(context.Context, string, string) (*pipelines.PipelinesResponse, error) {
 	return nil, nil
 }
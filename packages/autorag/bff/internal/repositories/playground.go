@@ -0,0 +1,422 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/opendatahub-io/autorag-library/bff/internal/constants"
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient"
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
+	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+const (
+	// autoragOutputRoot and autoragPatternGenerationDir mirror the artifact layout the
+	// frontend reads in useAutoragResults.
+	autoragOutputRoot           = "documents-rag-optimization-pipeline"
+	autoragPatternGenerationDir = "rag-templates-optimization"
+	autoragPatternsDir          = "rag_patterns"
+
+	// maxPatternJSONBytes bounds a single pattern.json download.
+	maxPatternJSONBytes = 1 << 20
+	// maxPlaygroundPatterns bounds how many patterns are loaded from a run.
+	maxPlaygroundPatterns = 50
+
+	// Limits enforced by the gen-ai BFF.
+	maxAgentProfileDisplayName  = 100
+	maxAgentProfileDescription  = 1000
+	maxVectorStoreName          = 256
+	maxVectorStoreMetadataValue = 512
+	maxAgentProfileOutputTokens = 32000
+	maxAgentProfileTemperature  = 2.0
+)
+
+type playgroundRunGetter interface {
+	GetManagedRun(ctx context.Context, namespace, runID string) (*models.PipelineRun, error)
+}
+
+type playgroundArtifactStore interface {
+	ListObjects(ctx context.Context, req S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error)
+	GetObject(ctx context.Context, req S3RequestContext, key string) (*GetObjectResult, error)
+}
+
+// PlaygroundRepository promotes AutoRAG patterns to the gen-ai playground. Run ownership is
+// checked through the pipelines repository and pattern artifacts are read from the DSPA
+// bucket; gen-ai resources are created through the caller-scoped BFF client.
+type PlaygroundRepository struct {
+	runs      playgroundRunGetter
+	artifacts playgroundArtifactStore
+	logger    *slog.Logger
+}
+
+func NewPlaygroundRepository(logger *slog.Logger, runs playgroundRunGetter, artifacts playgroundArtifactStore) *PlaygroundRepository {
+	return &PlaygroundRepository{runs: runs, artifacts: artifacts, logger: logger}
+}
+
+// PromoteToPlayground creates a gen-ai vector store and agent profile configured from an
+// AutoRAG pattern. The vector store is deleted again if the agent profile cannot be created,
+// so a failed promotion does not leave an orphaned store behind.
+func (r *PlaygroundRepository) PromoteToPlayground(ctx context.Context, genAI bffclient.BFFClientInterface, namespace, runID string, req models.PromoteToPlaygroundRequest) (*models.PlaygroundPromotion, error) {
+	req, err := validatePromoteToPlaygroundRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := r.runs.GetManagedRun(ctx, namespace, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.PipelineType != constants.PipelineTypeAutoRAG {
+		return nil, NewValidationError("only AutoRAG optimization runs can be promoted to the playground")
+	}
+	if run.State != string(pipelines.RunStateSucceeded) {
+		return nil, NewValidationError(fmt.Sprintf("pipeline run must be SUCCEEDED to be promoted, current state is %s", run.State))
+	}
+
+	patterns, err := r.loadPatterns(ctx, namespace, runID)
+	if err != nil {
+		return nil, err
+	}
+	pattern, score, err := selectPattern(patterns, req.PatternName)
+	if err != nil {
+		return nil, err
+	}
+	if pattern.Settings.Generation.ModelID == "" {
+		return nil, NewValidationError(fmt.Sprintf("pattern %s has no generation model", pattern.Name))
+	}
+
+	model, err := resolveGenAIModel(ctx, genAI, namespace, pattern.Settings.Generation.ModelID)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := req.DisplayName
+	if displayName == "" {
+		displayName = truncateBytes(fmt.Sprintf("%s - %s", run.DisplayName, pattern.Name), maxAgentProfileDisplayName)
+	}
+
+	var storeEnvelope struct {
+		Data models.GenAIVectorStore `json:"data"`
+	}
+	storeReq := models.GenAICreateVectorStoreRequest{
+		Name:     truncateBytes(displayName, maxVectorStoreName),
+		Metadata: patternVectorStoreMetadata(runID, pattern),
+	}
+	if err := genAI.Call(ctx, http.MethodPost, genAIPath("/lsd/vectorstores", namespace, nil), storeReq, &storeEnvelope); err != nil {
+		return nil, fmt.Errorf("failed to create playground vector store: %w", err)
+	}
+	if storeEnvelope.Data.ID == "" {
+		return nil, fmt.Errorf("failed to create playground vector store: %w",
+			bffclient.NewInvalidResponseError(bffclient.BFFTargetGenAI, "vector store response has no id"))
+	}
+	store := storeEnvelope.Data
+
+	spec := buildAgentProfileSpec(pattern, model, store.ID, displayName, req.Description)
+	var profileEnvelope struct {
+		Data models.GenAIAgentProfile `json:"data"`
+	}
+	body := map[string]any{"spec": spec}
+	if err := genAI.Call(ctx, http.MethodPost, genAIPath("/agent-profiles", namespace, nil), body, &profileEnvelope); err != nil {
+		r.deleteVectorStore(ctx, genAI, namespace, store.ID)
+		return nil, fmt.Errorf("failed to create playground agent profile: %w", err)
+	}
+
+	promotion := &models.PlaygroundPromotion{
+		RunID:             runID,
+		PatternName:       pattern.Name,
+		Score:             score,
+		GenerationModelID: model.ModelID,
+		EmbeddingModelID:  pattern.Settings.Embedding.ModelID,
+		VectorStoreID:     store.ID,
+		VectorStoreName:   store.Name,
+		AgentProfileID:    profileEnvelope.Data.ProfileID,
+		AgentProfileName:  profileEnvelope.Data.Name,
+	}
+	if pattern.Indexing != nil && pattern.Indexing.PipelineSpec != nil {
+		promotion.IndexingParameters = pattern.Indexing.PipelineSpec.Parameters
+	}
+	return promotion, nil
+}
+
+func validatePromoteToPlaygroundRequest(req models.PromoteToPlaygroundRequest) (models.PromoteToPlaygroundRequest, error) {
+	req.PatternName = strings.TrimSpace(req.PatternName)
+	req.DisplayName = strings.TrimSpace(req.DisplayName)
+	req.Description = strings.TrimSpace(req.Description)
+	if len(req.DisplayName) > maxAgentProfileDisplayName {
+		return req, NewValidationError(fmt.Sprintf("display_name must be at most %d characters", maxAgentProfileDisplayName))
+	}
+	if len(req.Description) > maxAgentProfileDescription {
+		return req, NewValidationError(fmt.Sprintf("description must be at most %d characters", maxAgentProfileDescription))
+	}
+	if strings.ContainsAny(req.PatternName, "/\\") {
+		return req, NewValidationError("pattern_name must not contain path separators")
+	}
+	return req, nil
+}
+
+// loadPatterns reads every pattern.json of the run's latest pattern-generation output.
+// The output directory name is not deterministic, so the lexicographically last one wins,
+// matching the results page.
+func (r *PlaygroundRepository) loadPatterns(ctx context.Context, namespace, runID string) ([]models.RAGPattern, error) {
+	s3Req := S3RequestContext{Namespace: namespace}
+
+	generationDir := path.Join(autoragOutputRoot, runID, autoragPatternGenerationDir)
+	outputs, err := r.artifacts.ListObjects(ctx, s3Req, s3.ListObjectsOptions{Path: generationDir})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pattern outputs: %w", err)
+	}
+	prefixes := commonPrefixes(outputs)
+	if len(prefixes) == 0 {
+		return nil, NewValidationError("pipeline run has no RAG pattern outputs")
+	}
+	latest := prefixes[len(prefixes)-1]
+
+	patternDirs, err := r.artifacts.ListObjects(ctx, s3Req, s3.ListObjectsOptions{Path: path.Join(latest, autoragPatternsDir)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RAG patterns: %w", err)
+	}
+	dirs := commonPrefixes(patternDirs)
+	if len(dirs) > maxPlaygroundPatterns {
+		dirs = dirs[:maxPlaygroundPatterns]
+	}
+
+	patterns := make([]models.RAGPattern, 0, len(dirs))
+	for _, dir := range dirs {
+		pattern, err := r.readPattern(ctx, s3Req, path.Join(dir, "pattern.json"))
+		if err != nil {
+			r.logger.Warn("skipping unreadable RAG pattern", slog.String("dir", dir), slog.Any("error", err))
+			continue
+		}
+		if pattern.Name == "" {
+			pattern.Name = path.Base(dir)
+		}
+		patterns = append(patterns, *pattern)
+	}
+	if len(patterns) == 0 {
+		return nil, NewValidationError("pipeline run has no readable RAG patterns")
+	}
+	return patterns, nil
+}
+
+func (r *PlaygroundRepository) readPattern(ctx context.Context, req S3RequestContext, key string) (*models.RAGPattern, error) {
+	obj, err := r.artifacts.GetObject(ctx, req, key)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(obj.Body, maxPatternJSONBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxPatternJSONBytes {
+		return nil, fmt.Errorf("pattern.json exceeds %d bytes", maxPatternJSONBytes)
+	}
+	var pattern models.RAGPattern
+	if err := json.Unmarshal(data, &pattern); err != nil {
+		return nil, fmt.Errorf("invalid pattern.json: %w", err)
+	}
+	return &pattern, nil
+}
+
+func commonPrefixes(resp *s3.ListObjectsResponse) []string {
+	if resp == nil {
+		return nil
+	}
+	prefixes := make([]string, 0, len(resp.CommonPrefixes))
+	for _, cp := range resp.CommonPrefixes {
+		if p := strings.TrimSuffix(cp.Prefix, "/"); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// patternScore returns the pattern's optimization metric mean, falling back to the legacy
+// final_score.
+func patternScore(pattern models.RAGPattern) *float64 {
+	if pattern.Evaluation != nil {
+		for _, metric := range pattern.Evaluation.Metrics {
+			if metric.OptimizationMetric && metric.Scores.Mean != nil {
+				return metric.Scores.Mean
+			}
+		}
+	}
+	return pattern.FinalScore
+}
+
+// selectPattern returns the named pattern, or the best-scoring one when name is empty.
+// Ties keep the first pattern in directory order.
+func selectPattern(patterns []models.RAGPattern, name string) (models.RAGPattern, *float64, error) {
+	if name != "" {
+		for _, pattern := range patterns {
+			if pattern.Name == name {
+				return pattern, patternScore(pattern), nil
+			}
+		}
+		return models.RAGPattern{}, nil, NewValidationError(fmt.Sprintf("pattern %s not found in pipeline run", name))
+	}
+
+	best := -1
+	var bestScore *float64
+	for i, pattern := range patterns {
+		score := patternScore(pattern)
+		if score == nil {
+			continue
+		}
+		if bestScore == nil || *score > *bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return models.RAGPattern{}, nil, NewValidationError("no scored RAG pattern found - specify pattern_name")
+	}
+	return patterns[best], bestScore, nil
+}
+
+// resolveGenAIModel finds the pattern's generation model among the models the gen-ai
+// playground can use. Pattern model IDs may be provider-qualified ("provider/model"), so
+// the unqualified ID and the model name are matched too.
+func resolveGenAIModel(ctx context.Context, genAI bffclient.BFFClientInterface, namespace, modelID string) (*models.GenAIModel, error) {
+	var envelope struct {
+		Data []models.GenAIModel `json:"data"`
+	}
+	if err := genAI.Call(ctx, http.MethodGet, genAIPath("/aaa/models", namespace, nil), nil, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to list playground models: %w", err)
+	}
+
+	unqualified := modelID[strings.LastIndex(modelID, "/")+1:]
+	var match *models.GenAIModel
+	for i := range envelope.Data {
+		m := &envelope.Data[i]
+		if m.ModelID == modelID {
+			match = m
+			break
+		}
+		if match == nil && (m.ModelID == unqualified || m.ModelName == unqualified || m.ModelName == modelID) {
+			match = m
+		}
+	}
+	if match == nil {
+		return nil, NewValidationError(fmt.Sprintf("generation model %s is not available in the playground for this namespace", modelID))
+	}
+	if modelEndpoint(*match) == "" {
+		return nil, NewValidationError(fmt.Sprintf("generation model %s has no endpoint", modelID))
+	}
+	return match, nil
+}
+
+// modelEndpoint picks the model URI for the agent profile. The gen-ai BFF reports endpoints
+// as "internal: <url>" or "external: <url>"; the in-cluster one is preferred.
+func modelEndpoint(model models.GenAIModel) string {
+	var external, raw string
+	for _, endpoint := range model.Endpoints {
+		switch {
+		case strings.HasPrefix(endpoint, "internal:"):
+			return strings.TrimSpace(strings.TrimPrefix(endpoint, "internal:"))
+		case strings.HasPrefix(endpoint, "external:") && external == "":
+			external = strings.TrimSpace(strings.TrimPrefix(endpoint, "external:"))
+		case raw == "":
+			raw = strings.TrimSpace(endpoint)
+		}
+	}
+	if external != "" {
+		return external
+	}
+	return raw
+}
+
+func buildAgentProfileSpec(pattern models.RAGPattern, model *models.GenAIModel, vectorStoreID, displayName, description string) models.GenAIAgentProfileSpec {
+	spec := models.GenAIAgentProfileSpec{
+		DisplayName: displayName,
+		Description: description,
+		Model: models.GenAIModelReference{
+			ID:         model.ModelID,
+			URI:        modelEndpoint(*model),
+			SourceType: model.ModelSourceType,
+		},
+		VectorStores: &models.GenAIVectorStoresConfig{
+			Stores: []models.GenAIVectorStoreRef{{ID: vectorStoreID}},
+		},
+	}
+	if spec.Description == "" {
+		spec.Description = fmt.Sprintf("Promoted from AutoRAG pattern %s", pattern.Name)
+	}
+	generation := pattern.Settings.Generation
+	if t := generation.Temperature; t != nil && *t >= 0 && *t <= maxAgentProfileTemperature {
+		spec.Temperature = t
+	}
+	if n := generation.MaxCompletionTokens; n != nil && *n >= 1 && *n <= maxAgentProfileOutputTokens {
+		spec.MaxOutputTokens = n
+	}
+	if n := pattern.Settings.Retrieval.NumberOfChunks; n > 0 {
+		spec.VectorStores.MaxNumResults = &n
+	}
+	return spec
+}
+
+// patternVectorStoreMetadata records where the vector store came from and the settings its
+// documents must be indexed with.
+func patternVectorStoreMetadata(runID string, pattern models.RAGPattern) map[string]string {
+	settings := pattern.Settings
+	metadata := map[string]string{}
+	set := func(key, value string) {
+		if value != "" {
+			metadata[key] = truncateBytes(value, maxVectorStoreMetadataValue)
+		}
+	}
+	set("autorag_run_id", runID)
+	set("autorag_pattern", pattern.Name)
+	set("embedding_model_id", settings.Embedding.ModelID)
+	set("chunking_method", settings.Chunking.Method)
+	if settings.Chunking.ChunkSize > 0 {
+		set("chunk_size", strconv.Itoa(settings.Chunking.ChunkSize))
+		set("chunk_overlap", strconv.Itoa(settings.Chunking.ChunkOverlap))
+	}
+	set("retrieval_method", settings.Retrieval.Method)
+	set("search_mode", settings.Retrieval.SearchMode)
+	if b := settings.VectorStoreBinding; b != nil && b.VectorStoreID != nil {
+		set("source_vector_store_id", *b.VectorStoreID)
+	}
+	return metadata
+}
+
+func (r *PlaygroundRepository) deleteVectorStore(ctx context.Context, genAI bffclient.BFFClientInterface, namespace, vectorStoreID string) {
+	p := genAIPath("/lsd/vectorstores/delete", namespace, url.Values{"vector_store_id": {vectorStoreID}})
+	if err := genAI.Call(ctx, http.MethodDelete, p, nil, nil); err != nil {
+		r.logger.Warn("failed to delete playground vector store after agent profile creation failed",
+			slog.String("vectorStoreId", vectorStoreID), slog.Any("error", err))
+	}
+}
+
+func genAIPath(p, namespace string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("namespace", namespace)
+	return p + "?" + query.Encode()
+}
+
+// truncateBytes shortens s to at most n bytes without splitting a UTF-8 sequence; the
+// gen-ai BFF checks lengths in bytes.
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
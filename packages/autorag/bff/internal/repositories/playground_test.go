@@ -0,0 +1,240 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/autorag-library/bff/internal/constants"
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient"
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/bffclient/bffmocks"
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
+	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
+)
+
+type fakePlaygroundRuns struct {
+	run *models.PipelineRun
+	err error
+}
+
+func (f *fakePlaygroundRuns) GetManagedRun(_ context.Context, _, _ string) (*models.PipelineRun, error) {
+	return f.run, f.err
+}
+
+// fakePatternStore serves pattern.json files keyed by pattern directory name under a
+// single pattern-generation output.
+type fakePatternStore struct {
+	patterns map[string]string
+}
+
+const fakePatternOutput = "documents-rag-optimization-pipeline/run-1/rag-templates-optimization/b-uuid/"
+
+func (f *fakePatternStore) ListObjects(_ context.Context, _ S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error) {
+	switch options.Path {
+	case "documents-rag-optimization-pipeline/run-1/rag-templates-optimization":
+		return &s3.ListObjectsResponse{CommonPrefixes: []s3.CommonPrefix{
+			{Prefix: fakePatternOutput},
+			{Prefix: "documents-rag-optimization-pipeline/run-1/rag-templates-optimization/a-uuid/"},
+		}}, nil
+	case strings.TrimSuffix(fakePatternOutput, "/") + "/rag_patterns":
+		resp := &s3.ListObjectsResponse{}
+		for name := range f.patterns {
+			resp.CommonPrefixes = append(resp.CommonPrefixes, s3.CommonPrefix{Prefix: fakePatternOutput + "rag_patterns/" + name + "/"})
+		}
+		return resp, nil
+	}
+	return &s3.ListObjectsResponse{}, nil
+}
+
+func (f *fakePatternStore) GetObject(_ context.Context, _ S3RequestContext, key string) (*GetObjectResult, error) {
+	for name, body := range f.patterns {
+		if key == fakePatternOutput+"rag_patterns/"+name+"/pattern.json" {
+			return &GetObjectResult{Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+	return nil, s3.ErrObjectNotFound
+}
+
+const pattern1JSON = `{
+	"name": "Pattern1",
+	"settings": {
+		"vector_store_binding": {"provider_id": "milvus", "vector_store_id": "vs_source"},
+		"chunking": {"method": "recursive", "chunk_size": 512, "chunk_overlap": 128},
+		"embedding": {"model_id": "ibm-granite/granite-embedding-278m"},
+		"retrieval": {"method": "window", "number_of_chunks": 5},
+		"generation": {"model_id": "ibm-granite/granite-3.3-8b-instruct", "temperature": 0.2, "max_completion_tokens": 2048}
+	},
+	"evaluation": {"metrics": [
+		{"name": "faithfulness", "scores": {"mean": 0.9}},
+		{"name": "answer_correctness", "scores": {"mean": 0.71}, "optimization_metric": true}
+	]},
+	"indexing": {"pipeline_spec": {"parameters": {"chunk_size": 512}}}
+}`
+
+const pattern2JSON = `{
+	"name": "Pattern2",
+	"settings": {
+		"chunking": {"method": "recursive", "chunk_size": 1024, "chunk_overlap": 256},
+		"embedding": {"model_id": "ibm-granite/granite-embedding-278m"},
+		"retrieval": {"method": "simple", "number_of_chunks": 3},
+		"generation": {"model_id": "granite-8b"}
+	},
+	"evaluation": {"metrics": [
+		{"name": "answer_correctness", "scores": {"mean": 0.64}, "optimization_metric": true}
+	]}
+}`
+
+func newTestPlaygroundRepository(state string) *PlaygroundRepository {
+	runs := &fakePlaygroundRuns{run: &models.PipelineRun{
+		RunID:        "run-1",
+		DisplayName:  "rag-optimization",
+		State:        state,
+		PipelineType: constants.PipelineTypeAutoRAG,
+	}}
+	store := &fakePatternStore{patterns: map[string]string{"Pattern1": pattern1JSON, "Pattern2": pattern2JSON}}
+	return NewPlaygroundRepository(slog.New(slog.NewTextHandler(io.Discard, nil)), runs, store)
+}
+
+// recordingGenAI wraps the mock gen-ai client's default responses and records every call.
+func recordingGenAI(failProfile bool) (*bffmocks.MockBFFClient, *[]string, *map[string]any) {
+	client := bffmocks.NewMockBFFClient(bffclient.BFFTargetGenAI)
+	defaults := bffmocks.NewMockBFFClient(bffclient.BFFTargetGenAI)
+	var calls []string
+	var profileBody map[string]any
+	client.CallHandler = func(ctx context.Context, method, path string, body interface{}, response interface{}) error {
+		calls = append(calls, method+" "+path)
+		if method == http.MethodPost && strings.HasPrefix(path, "/agent-profiles") {
+			raw, _ := json.Marshal(body)
+			_ = json.Unmarshal(raw, &profileBody)
+			if failProfile {
+				return bffclient.NewForbiddenError(bffclient.BFFTargetGenAI, "cannot create agent profiles")
+			}
+		}
+		return defaults.Call(ctx, method, path, body, response)
+	}
+	return client, &calls, &profileBody
+}
+
+func TestPromoteToPlayground(t *testing.T) {
+	repo := newTestPlaygroundRepository("SUCCEEDED")
+	genAI, calls, profileBody := recordingGenAI(false)
+
+	got, err := repo.PromoteToPlayground(context.Background(), genAI, "test-ns", "run-1", models.PromoteToPlaygroundRequest{})
+	if err != nil {
+		t.Fatalf("PromoteToPlayground() error = %v", err)
+	}
+
+	if got.PatternName != "Pattern1" || got.Score == nil || *got.Score != 0.71 {
+		t.Errorf("selected pattern = %s (score %v), want Pattern1 (0.71)", got.PatternName, got.Score)
+	}
+	if got.GenerationModelID != "ibm-granite/granite-3.3-8b-instruct" {
+		t.Errorf("GenerationModelID = %q", got.GenerationModelID)
+	}
+	if got.VectorStoreID != "vs_mock-playground" || got.AgentProfileID != "mock-profile-id" {
+		t.Errorf("created resources = %q / %q", got.VectorStoreID, got.AgentProfileID)
+	}
+	if got.IndexingParameters["chunk_size"] != float64(512) {
+		t.Errorf("IndexingParameters = %v", got.IndexingParameters)
+	}
+	if len(*calls) != 3 {
+		t.Fatalf("gen-ai calls = %v, want models, vector store and agent profile", *calls)
+	}
+
+	spec := (*profileBody)["spec"].(map[string]any)
+	if spec["displayName"] != "rag-optimization - Pattern1" {
+		t.Errorf("displayName = %v", spec["displayName"])
+	}
+	model := spec["model"].(map[string]any)
+	if model["uri"] != "http://granite-instruct.mock-ns.svc.cluster.local:8080/v1" {
+		t.Errorf("model uri = %v", model["uri"])
+	}
+	if spec["temperature"] != 0.2 || spec["maxOutputTokens"] != float64(2048) {
+		t.Errorf("generation settings = %v / %v", spec["temperature"], spec["maxOutputTokens"])
+	}
+	stores := spec["vectorStores"].(map[string]any)
+	if stores["maxNumResults"] != float64(5) {
+		t.Errorf("maxNumResults = %v", stores["maxNumResults"])
+	}
+}
+
+func TestPromoteToPlayground_NamedPatternMatchesModelName(t *testing.T) {
+	repo := newTestPlaygroundRepository("SUCCEEDED")
+	genAI, _, _ := recordingGenAI(false)
+
+	got, err := repo.PromoteToPlayground(context.Background(), genAI, "test-ns", "run-1", models.PromoteToPlaygroundRequest{PatternName: "Pattern2", DisplayName: "My agent"})
+	if err != nil {
+		t.Fatalf("PromoteToPlayground() error = %v", err)
+	}
+	if got.PatternName != "Pattern2" || got.GenerationModelID != "mock-granite-8b" {
+		t.Errorf("got pattern %q with model %q", got.PatternName, got.GenerationModelID)
+	}
+	if got.VectorStoreName != "My agent" {
+		t.Errorf("VectorStoreName = %q", got.VectorStoreName)
+	}
+}
+
+func TestPromoteToPlayground_DeletesVectorStoreWhenProfileFails(t *testing.T) {
+	repo := newTestPlaygroundRepository("SUCCEEDED")
+	genAI, calls, _ := recordingGenAI(true)
+
+	_, err := repo.PromoteToPlayground(context.Background(), genAI, "test-ns", "run-1", models.PromoteToPlaygroundRequest{})
+	var bffErr *bffclient.BFFClientError
+	if !errors.As(err, &bffErr) || bffErr.Code != bffclient.ErrCodeForbidden {
+		t.Fatalf("error = %v, want gen-ai FORBIDDEN", err)
+	}
+	last := (*calls)[len(*calls)-1]
+	if last != "DELETE /lsd/vectorstores/delete?namespace=test-ns&vector_store_id=vs_mock-playground" {
+		t.Errorf("last gen-ai call = %q, want vector store cleanup", last)
+	}
+}
+
+func TestPromoteToPlayground_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		req     models.PromoteToPlaygroundRequest
+		wantErr string
+	}{
+		{name: "run not succeeded", state: "RUNNING", wantErr: "must be SUCCEEDED"},
+		{name: "unknown pattern", state: "SUCCEEDED", req: models.PromoteToPlaygroundRequest{PatternName: "Pattern9"}, wantErr: "not found"},
+		{name: "pattern path traversal", state: "SUCCEEDED", req: models.PromoteToPlaygroundRequest{PatternName: "../x"}, wantErr: "path separators"},
+		{name: "display name too long", state: "SUCCEEDED", req: models.PromoteToPlaygroundRequest{DisplayName: strings.Repeat("a", 101)}, wantErr: "display_name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestPlaygroundRepository(tt.state)
+			genAI, calls, _ := recordingGenAI(false)
+
+			_, err := repo.PromoteToPlayground(context.Background(), genAI, "test-ns", "run-1", tt.req)
+			if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want validation error containing %q", err, tt.wantErr)
+			}
+			if len(*calls) != 0 {
+				t.Errorf("gen-ai calls = %v, want none", *calls)
+			}
+		})
+	}
+}
+
+func TestModelEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoints []string
+		want      string
+	}{
+		{[]string{"external: https://ext.example.com/v1", "internal: http://svc:8080/v1"}, "http://svc:8080/v1"},
+		{[]string{"external: https://ext.example.com/v1"}, "https://ext.example.com/v1"},
+		{[]string{"https://custom.example.com/v1"}, "https://custom.example.com/v1"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := modelEndpoint(models.GenAIModel{Endpoints: tt.endpoints}); got != tt.want {
+			t.Errorf("modelEndpoint(%v) = %q, want %q", tt.endpoints, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultRetrievalEvalK is the number of chunks retrieved per question when k is omitted.
+	DefaultRetrievalEvalK = 5
+	// MaxRetrievalEvalK bounds k.
+	MaxRetrievalEvalK = 50
+	// MaxRetrievalEvalQuestions bounds the gold set so a request finishes within the
+	// per-search timeout budget.
+	MaxRetrievalEvalQuestions = 200
+
+	maxRetrievalEvalExpectedDocs  = 20
+	maxRetrievalEvalQuestionChars = 4000
+	maxRetrievalEvalVectorStoreID = 256
+	// maxRetrievedChunkText truncates chunk text in the response; chunks are returned for
+	// inspection only.
+	maxRetrievedChunkText = 500
+	// retrievalEvalConcurrency bounds parallel searches against the vector store.
+	retrievalEvalConcurrency = 4
+)
+
+// EvaluateRetrieval queries the vector store with every gold question and scores the
+// retrieved chunks against the expected documents. Any failed search fails the evaluation,
+// since partial metrics would not be comparable between runs.
+func (r *OGXRepository) EvaluateRetrieval(ctx context.Context, namespace, secretName, vectorStoreID string, req models.RetrievalEvalRequest) (*models.RetrievalEvaluation, error) {
+	req, err := validateRetrievalEvalRequest(vectorStoreID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, apiKey, err := resolveOGXCredentials(ctx, r.k8sService, namespace, secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.RetrievalEvalQuestionResult, len(req.Questions))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(retrievalEvalConcurrency)
+	for i, question := range req.Questions {
+		g.Go(func() error {
+			chunks, err := r.ogxClient.SearchVectorStore(gctx, baseURL, apiKey, vectorStoreID, question.Question, req.K)
+			if err != nil {
+				return fmt.Errorf("failed to search OGX vector store for question %d: %w", i+1, err)
+			}
+			results[i] = scoreRetrieval(question, chunks, req.K)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	evaluation := &models.RetrievalEvaluation{VectorStoreID: vectorStoreID, K: req.K, Questions: results}
+	for _, result := range results {
+		evaluation.Metrics.RecallAtK += result.Metrics.RecallAtK
+		evaluation.Metrics.MRR += result.Metrics.MRR
+		evaluation.Metrics.NDCGAtK += result.Metrics.NDCGAtK
+	}
+	n := float64(len(results))
+	evaluation.Metrics.RecallAtK /= n
+	evaluation.Metrics.MRR /= n
+	evaluation.Metrics.NDCGAtK /= n
+	return evaluation, nil
+}
+
+func validateRetrievalEvalRequest(vectorStoreID string, req models.RetrievalEvalRequest) (models.RetrievalEvalRequest, error) {
+	if strings.TrimSpace(vectorStoreID) == "" {
+		return req, NewValidationError("vector store ID is required")
+	}
+	if len(vectorStoreID) > maxRetrievalEvalVectorStoreID {
+		return req, NewValidationError(fmt.Sprintf("vector store ID must be at most %d characters", maxRetrievalEvalVectorStoreID))
+	}
+	if req.K == 0 {
+		req.K = DefaultRetrievalEvalK
+	}
+	if req.K < 1 || req.K > MaxRetrievalEvalK {
+		return req, NewValidationError(fmt.Sprintf("k must be between 1 and %d", MaxRetrievalEvalK))
+	}
+	if len(req.Questions) == 0 {
+		return req, NewValidationError("questions must contain at least one question")
+	}
+	if len(req.Questions) > MaxRetrievalEvalQuestions {
+		return req, NewValidationError(fmt.Sprintf("questions must contain at most %d questions", MaxRetrievalEvalQuestions))
+	}
+
+	questions := make([]models.RetrievalEvalQuestion, len(req.Questions))
+	for i, q := range req.Questions {
+		q.Question = strings.TrimSpace(q.Question)
+		if q.Question == "" {
+			return req, NewValidationError(fmt.Sprintf("questions[%d].question is required", i))
+		}
+		if len(q.Question) > maxRetrievalEvalQuestionChars {
+			return req, NewValidationError(fmt.Sprintf("questions[%d].question must be at most %d characters", i, maxRetrievalEvalQuestionChars))
+		}
+		seen := map[string]bool{}
+		var expected []string
+		for _, doc := range q.ExpectedDocuments {
+			doc = strings.TrimSpace(doc)
+			if doc == "" {
+				return req, NewValidationError(fmt.Sprintf("questions[%d].expected_documents must not contain empty values", i))
+			}
+			if !seen[doc] {
+				seen[doc] = true
+				expected = append(expected, doc)
+			}
+		}
+		if len(expected) == 0 {
+			return req, NewValidationError(fmt.Sprintf("questions[%d].expected_documents is required", i))
+		}
+		if len(expected) > maxRetrievalEvalExpectedDocs {
+			return req, NewValidationError(fmt.Sprintf("questions[%d].expected_documents must contain at most %d documents", i, maxRetrievalEvalExpectedDocs))
+		}
+		q.ExpectedDocuments = expected
+		questions[i] = q
+	}
+	req.Questions = questions
+	return req, nil
+}
+
+// scoreRetrieval computes document-level recall@k, reciprocal rank and nDCG@k with binary
+// relevance. Each expected document is credited once, at its first retrieved chunk.
+func scoreRetrieval(question models.RetrievalEvalQuestion, results []models.OGXVectorStoreSearchResult, k int) models.RetrievalEvalQuestionResult {
+	if len(results) > k {
+		results = results[:k]
+	}
+	result := models.RetrievalEvalQuestionResult{
+		ID:                question.ID,
+		Question:          question.Question,
+		ExpectedDocuments: question.ExpectedDocuments,
+		MatchedDocuments:  []string{},
+		MissingDocuments:  []string{},
+		RetrievedChunks:   make([]models.RetrievedChunk, 0, len(results)),
+	}
+
+	found := map[string]bool{}
+	var dcg float64
+	for i, chunk := range results {
+		rank := i + 1
+		doc := matchExpectedDocument(chunk, question.ExpectedDocuments)
+		if doc != "" {
+			if result.Metrics.MRR == 0 {
+				result.Metrics.MRR = 1 / float64(rank)
+			}
+			if !found[doc] {
+				found[doc] = true
+				dcg += 1 / math.Log2(float64(rank+1))
+			}
+		}
+		result.RetrievedChunks = append(result.RetrievedChunks, models.RetrievedChunk{
+			Rank:            rank,
+			FileID:          chunk.FileID,
+			Filename:        chunk.Filename,
+			Score:           chunk.Score,
+			Text:            chunkText(chunk),
+			MatchedDocument: doc,
+		})
+	}
+
+	var idcg float64
+	for i := 1; i <= min(len(question.ExpectedDocuments), k); i++ {
+		idcg += 1 / math.Log2(float64(i+1))
+	}
+	for _, doc := range question.ExpectedDocuments {
+		if found[doc] {
+			result.MatchedDocuments = append(result.MatchedDocuments, doc)
+		} else {
+			result.MissingDocuments = append(result.MissingDocuments, doc)
+		}
+	}
+	result.Metrics.RecallAtK = float64(len(found)) / float64(len(question.ExpectedDocuments))
+	if idcg > 0 {
+		result.Metrics.NDCGAtK = dcg / idcg
+	}
+	return result
+}
+
+// matchExpectedDocument returns the expected document a chunk belongs to. A document
+// matches the chunk's file ID, its filename, or its filename without directories, so
+// gold sets can reference either the uploaded S3 key or the stored file name.
+func matchExpectedDocument(chunk models.OGXVectorStoreSearchResult, expected []string) string {
+	for _, doc := range expected {
+		if doc == chunk.FileID {
+			return doc
+		}
+		if chunk.Filename != "" && (doc == chunk.Filename || path.Base(doc) == path.Base(chunk.Filename)) {
+			return doc
+		}
+	}
+	return ""
+}
+
+func chunkText(chunk models.OGXVectorStoreSearchResult) string {
+	var parts []string
+	for _, content := range chunk.Content {
+		if content.Type == "text" || content.Type == "" {
+			parts = append(parts, content.Text)
+		}
+	}
+	text := strings.Join(parts, "\n")
+	if runes := []rune(text); len(runes) > maxRetrievedChunkText {
+		text = string(runes[:maxRetrievedChunkText]) + "…"
+	}
+	return text
+}
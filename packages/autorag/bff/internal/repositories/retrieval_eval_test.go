@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/opendatahub-io/autorag-library/bff/internal/integrations/ogx"
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
+)
+
+func searchResult(fileID, filename string) models.OGXVectorStoreSearchResult {
+	return models.OGXVectorStoreSearchResult{
+		FileID:   fileID,
+		Filename: filename,
+		Content:  []models.OGXVectorStoreSearchContent{{Type: "text", Text: "chunk of " + filename}},
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestScoreRetrieval(t *testing.T) {
+	question := models.RetrievalEvalQuestion{
+		ID:                "q1",
+		Question:          "How do I reset my password?",
+		ExpectedDocuments: []string{"docs/faq.md", "file-9"},
+	}
+	results := []models.OGXVectorStoreSearchResult{
+		searchResult("file-1", "handbook.pdf"),
+		searchResult("file-2", "faq.md"),
+		searchResult("file-2", "faq.md"), // second chunk of the same document is not credited again
+		searchResult("file-3", "release-notes.md"),
+	}
+
+	got := scoreRetrieval(question, results, 3)
+
+	if len(got.RetrievedChunks) != 3 {
+		t.Fatalf("RetrievedChunks = %d, want top 3", len(got.RetrievedChunks))
+	}
+	if got.RetrievedChunks[1].MatchedDocument != "docs/faq.md" || got.RetrievedChunks[0].MatchedDocument != "" {
+		t.Errorf("chunk matches = %+v", got.RetrievedChunks)
+	}
+	if !approxEqual(got.Metrics.RecallAtK, 0.5) {
+		t.Errorf("RecallAtK = %v, want 0.5", got.Metrics.RecallAtK)
+	}
+	if !approxEqual(got.Metrics.MRR, 0.5) {
+		t.Errorf("MRR = %v, want 0.5", got.Metrics.MRR)
+	}
+	// DCG = 1/log2(3); IDCG = 1/log2(2) + 1/log2(3).
+	wantNDCG := (1 / math.Log2(3)) / (1 + 1/math.Log2(3))
+	if !approxEqual(got.Metrics.NDCGAtK, wantNDCG) {
+		t.Errorf("NDCGAtK = %v, want %v", got.Metrics.NDCGAtK, wantNDCG)
+	}
+	if strings.Join(got.MatchedDocuments, ",") != "docs/faq.md" || strings.Join(got.MissingDocuments, ",") != "file-9" {
+		t.Errorf("matched = %v, missing = %v", got.MatchedDocuments, got.MissingDocuments)
+	}
+}
+
+func TestScoreRetrieval_PerfectAndEmpty(t *testing.T) {
+	question := models.RetrievalEvalQuestion{Question: "q", ExpectedDocuments: []string{"a.md", "b.md"}}
+
+	perfect := scoreRetrieval(question, []models.OGXVectorStoreSearchResult{
+		searchResult("f1", "a.md"), searchResult("f2", "b.md"),
+	}, 5)
+	if perfect.Metrics != (models.RetrievalMetrics{RecallAtK: 1, MRR: 1, NDCGAtK: 1}) {
+		t.Errorf("perfect metrics = %+v", perfect.Metrics)
+	}
+
+	empty := scoreRetrieval(question, nil, 5)
+	if empty.Metrics != (models.RetrievalMetrics{}) || len(empty.MissingDocuments) != 2 {
+		t.Errorf("empty result = %+v", empty)
+	}
+}
+
+func TestEvaluateRetrieval(t *testing.T) {
+	t.Run("averages metrics across questions", func(t *testing.T) {
+		var gotK int
+		ogxClient := &mockOGXClient{
+			searchFn: func(ctx context.Context, baseURL, apiKey, vectorStoreID, query string, maxResults int) ([]models.OGXVectorStoreSearchResult, error) {
+				gotK = maxResults
+				if vectorStoreID != "vs_1" || baseURL != "https://ogx.example.com" {
+					t.Errorf("unexpected search target %s %s", baseURL, vectorStoreID)
+				}
+				if query == "hit" {
+					return []models.OGXVectorStoreSearchResult{searchResult("f1", "a.md")}, nil
+				}
+				return []models.OGXVectorStoreSearchResult{searchResult("f2", "other.md")}, nil
+			},
+		}
+		repo := NewOGXRepository(slog.Default(), ogxClient, defaultK8s())
+
+		eval, err := repo.EvaluateRetrieval(context.Background(), "ns", "ogx-creds", "vs_1", models.RetrievalEvalRequest{
+			Questions: []models.RetrievalEvalQuestion{
+				{Question: "hit", ExpectedDocuments: []string{"a.md"}},
+				{Question: "miss", ExpectedDocuments: []string{"a.md", " a.md "}},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotK != DefaultRetrievalEvalK || eval.K != DefaultRetrievalEvalK {
+			t.Errorf("k = %d/%d, want default %d", gotK, eval.K, DefaultRetrievalEvalK)
+		}
+		if eval.Metrics != (models.RetrievalMetrics{RecallAtK: 0.5, MRR: 0.5, NDCGAtK: 0.5}) {
+			t.Errorf("Metrics = %+v", eval.Metrics)
+		}
+		if len(eval.Questions[1].ExpectedDocuments) != 1 {
+			t.Errorf("expected documents should be trimmed and deduplicated: %v", eval.Questions[1].ExpectedDocuments)
+		}
+	})
+
+	t.Run("search failure fails the evaluation", func(t *testing.T) {
+		ogxClient := &mockOGXClient{
+			searchFn: func(ctx context.Context, baseURL, apiKey, vectorStoreID, query string, maxResults int) ([]models.OGXVectorStoreSearchResult, error) {
+				return nil, ogx.NewNotFoundError("vector store not found")
+			},
+		}
+		repo := NewOGXRepository(slog.Default(), ogxClient, defaultK8s())
+
+		_, err := repo.EvaluateRetrieval(context.Background(), "ns", "ogx-creds", "vs_1", models.RetrievalEvalRequest{
+			Questions: []models.RetrievalEvalQuestion{{Question: "q", ExpectedDocuments: []string{"a.md"}}},
+		})
+		var ogxErr *ogx.OGXError
+		if !errors.As(err, &ogxErr) || ogxErr.Code != ogx.ErrCodeNotFound {
+			t.Errorf("expected NOT_FOUND OGXError, got %v", err)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		valid := []models.RetrievalEvalQuestion{{Question: "q", ExpectedDocuments: []string{"a.md"}}}
+		tests := []struct {
+			name          string
+			vectorStoreID string
+			req           models.RetrievalEvalRequest
+		}{
+			{"missing vector store", " ", models.RetrievalEvalRequest{Questions: valid}},
+			{"k too large", "vs_1", models.RetrievalEvalRequest{K: MaxRetrievalEvalK + 1, Questions: valid}},
+			{"negative k", "vs_1", models.RetrievalEvalRequest{K: -1, Questions: valid}},
+			{"no questions", "vs_1", models.RetrievalEvalRequest{}},
+			{"empty question", "vs_1", models.RetrievalEvalRequest{Questions: []models.RetrievalEvalQuestion{{Question: " ", ExpectedDocuments: []string{"a.md"}}}}},
+			{"no expected documents", "vs_1", models.RetrievalEvalRequest{Questions: []models.RetrievalEvalQuestion{{Question: "q"}}}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				repo := NewOGXRepository(slog.Default(), &mockOGXClient{}, defaultK8s())
+				if _, err := repo.EvaluateRetrieval(context.Background(), "ns", "ogx-creds", tt.vectorStoreID, tt.req); !errors.Is(err, ErrValidation) {
+					t.Errorf("expected ErrValidation, got %v", err)
+				}
+			})
+		}
+	})
+}
@@ -7,9 +7,11 @@ import (
 	"io"
 	"log/slog"
 	"mime"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/opendatahub-io/autorag-library/bff/internal/models"
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
 	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
 	s3 "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/s3"
@@ -218,6 +220,179 @@ func (r *S3Repository) UploadFile(ctx context.Context, req S3RequestContext, key
 	})
 }
 
+// --- Resumable uploads ---
+// Large documents are uploaded with S3 multipart upload: the browser starts an upload,
+// sends parts (in parallel, retrying or resuming as needed) and completes it. Every call
+// after the first carries the resolved key and upload ID returned by CreateUpload.
+
+// CreateUpload resolves a non-colliding key and starts a multipart upload for it, with the
+// raw content type sanitized against the upload allowlist as in UploadFile.
+// maxAttempts of 0 uses the default (10).
+func (r *S3Repository) CreateUpload(ctx context.Context, req S3RequestContext, key, rawContentType string, maxAttempts int) (*s3.MultipartUpload, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxCollisionAttempts
+	}
+
+	keyCtx, cancel := context.WithTimeout(ctx, s3KeyResolutionTimeout)
+	defer cancel()
+
+	resolvedKey, err := r.s3Service.ResolveNonCollidingKey(keyCtx, opts, s3.ResolveNonCollidingKeyInput{
+		Bucket:      bucket,
+		Key:         key,
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.s3Service.CreateMultipartUpload(keyCtx, opts, s3.CreateMultipartUploadInput{
+		Bucket:      bucket,
+		Key:         resolvedKey,
+		ContentType: SanitizeContentType(rawContentType),
+	})
+}
+
+// UploadPart resolves credentials from req and uploads one part of a multipart upload.
+// body must hold exactly size bytes.
+func (r *S3Repository) UploadPart(ctx context.Context, req S3RequestContext, key, uploadID string, partNumber int32, body io.ReadSeeker, size int64) (*s3.UploadedPart, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return r.s3Service.UploadPart(ctx, opts, s3.UploadPartInput{
+		Bucket:        bucket,
+		Key:           key,
+		UploadID:      uploadID,
+		PartNumber:    partNumber,
+		Body:          body,
+		ContentLength: size,
+	})
+}
+
+// ListUploadParts resolves credentials from req and lists the parts stored for an upload.
+func (r *S3Repository) ListUploadParts(ctx context.Context, req S3RequestContext, key, uploadID string) ([]s3.UploadedPart, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return r.s3Service.ListParts(ctx, opts, s3.ListPartsInput{Bucket: bucket, Key: key, UploadID: uploadID})
+}
+
+// CompleteUpload resolves credentials from req and completes an upload. An empty parts
+// list completes the upload with every stored part.
+// Returns ErrObjectAlreadyExists if another writer created the key in the meantime.
+func (r *S3Repository) CompleteUpload(ctx context.Context, req S3RequestContext, key, uploadID string, parts []s3.UploadedPart) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.CompleteMultipartUpload(ctx, opts, s3.CompleteMultipartUploadInput{
+		Bucket:   bucket,
+		Key:      key,
+		UploadID: uploadID,
+		Parts:    parts,
+	})
+}
+
+// AbortUpload resolves credentials from req and aborts an upload, discarding its parts.
+func (r *S3Repository) AbortUpload(ctx context.Context, req S3RequestContext, key, uploadID string) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.AbortMultipartUpload(ctx, opts, s3.AbortMultipartUploadInput{Bucket: bucket, Key: key, UploadID: uploadID})
+}
+
+// --- Object management ---
+
+// CopyObject resolves credentials from req and copies sourceKey to destinationKey.
+// Returns ErrObjectAlreadyExists if destinationKey exists.
+func (r *S3Repository) CopyObject(ctx context.Context, req S3RequestContext, sourceKey, destinationKey string) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.CopyObject(ctx, opts, s3.CopyObjectInput{Bucket: bucket, SourceKey: sourceKey, DestinationKey: destinationKey})
+}
+
+// MoveObject resolves credentials from req and renames sourceKey to destinationKey.
+// Returns ErrObjectAlreadyExists if destinationKey exists.
+func (r *S3Repository) MoveObject(ctx context.Context, req S3RequestContext, sourceKey, destinationKey string) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.MoveObject(ctx, opts, s3.MoveObjectInput{Bucket: bucket, SourceKey: sourceKey, DestinationKey: destinationKey})
+}
+
+// DeleteObject resolves credentials from req and deletes key.
+func (r *S3Repository) DeleteObject(ctx context.Context, req S3RequestContext, key string) error {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return err
+	}
+	return r.s3Service.DeleteObject(ctx, opts, s3.DeleteObjectInput{Bucket: bucket, Key: key})
+}
+
+// DeletePrefix resolves credentials from req and deletes every object under prefix.
+// Without a confirmation token it only reports what would be deleted (see s3.DeletePrefixInput).
+func (r *S3Repository) DeletePrefix(ctx context.Context, req S3RequestContext, prefix, confirmationToken string) (*s3.DeletePrefixResult, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return r.s3Service.DeletePrefix(ctx, opts, s3.DeletePrefixInput{Bucket: bucket, Prefix: prefix, ConfirmationToken: confirmationToken})
+}
+
+// PresignDownload resolves credentials from req and returns a presigned GET URL for key.
+func (r *S3Repository) PresignDownload(ctx context.Context, req S3RequestContext, key string, expires time.Duration) (*models.S3PresignedURL, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	presigned, err := r.s3Service.PresignObject(ctx, opts, s3.PresignInput{Bucket: bucket, Key: key, Method: http.MethodGet, Expires: expires})
+	if err != nil {
+		return nil, err
+	}
+	return &models.S3PresignedURL{Key: key, PresignedURL: *presigned}, nil
+}
+
+// PresignUpload resolves a non-colliding key like UploadFile and returns a presigned PUT
+// URL for it. The URL cannot replace an existing object. maxAttempts of 0 uses the default (10).
+func (r *S3Repository) PresignUpload(ctx context.Context, req S3RequestContext, key string, expires time.Duration, maxAttempts int) (*models.S3PresignedURL, error) {
+	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxCollisionAttempts
+	}
+
+	keyCtx, cancel := context.WithTimeout(ctx, s3KeyResolutionTimeout)
+	defer cancel()
+
+	resolvedKey, err := r.s3Service.ResolveNonCollidingKey(keyCtx, opts, s3.ResolveNonCollidingKeyInput{
+		Bucket:      bucket,
+		Key:         key,
+		MaxAttempts: maxAttempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	presigned, err := r.s3Service.PresignObject(ctx, opts, s3.PresignInput{Bucket: bucket, Key: resolvedKey, Method: http.MethodPut, Expires: expires})
+	if err != nil {
+		return nil, err
+	}
+	return &models.S3PresignedURL{Key: resolvedKey, PresignedURL: *presigned}, nil
+}
+
 // ListObjects resolves credentials from req and lists objects using options.
 func (r *S3Repository) ListObjects(ctx context.Context, req S3RequestContext, options s3.ListObjectsOptions) (*s3.ListObjectsResponse, error) {
 	opts, bucket, err := r.resolveCredsAndBucket(ctx, req)
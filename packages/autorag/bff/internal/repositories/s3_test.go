@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	kubernetes "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
 	pipelines "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/pipelines"
@@ -70,6 +72,9 @@ func (m *mockK8sServiceForS3) PatchResource(_ context.Context, _ schema.GroupVer
 func (m *mockK8sServiceForS3) PatchDeployment(_ context.Context, _, _ string, _ types.PatchType, _ []byte) error {
 	return nil
 }
+func (m *mockK8sServiceForS3) GetPodLogs(_ context.Context, _, _ string, _ *v1.PodLogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
 func (m *mockK8sServiceForS3) DiscoverResourceGVR(context.Context, string, string, string, []string) (schema.GroupVersionResource, error) {
 	return schema.GroupVersionResource{}, nil
 }
@@ -80,6 +85,9 @@ type mockS3ServiceForRepo struct {
 	listObjectsFn            func(ctx context.Context, opts s3.ConnectionOptions, query s3.ListObjectsQuery) (*s3.ListObjectsResponse, error)
 	objectExistsFn           func(ctx context.Context, opts s3.ConnectionOptions, input s3.ObjectExistsInput) (bool, error)
 	resolveNonCollidingKeyFn func(ctx context.Context, opts s3.ConnectionOptions, input s3.ResolveNonCollidingKeyInput) (string, error)
+	createMPUFn              func(ctx context.Context, opts s3.ConnectionOptions, input s3.CreateMultipartUploadInput) (*s3.MultipartUpload, error)
+	completeMPUFn            func(ctx context.Context, opts s3.ConnectionOptions, input s3.CompleteMultipartUploadInput) error
+	presignObjectFn          func(ctx context.Context, opts s3.ConnectionOptions, input s3.PresignInput) (*s3.PresignedURL, error)
 }
 
 func (m *mockS3ServiceForRepo) GetObject(context.Context, s3.ConnectionOptions, s3.GetObjectInput) (io.ReadCloser, string, error) {
@@ -100,6 +108,36 @@ func (m *mockS3ServiceForRepo) ObjectExists(ctx context.Context, opts s3.Connect
 func (m *mockS3ServiceForRepo) ResolveNonCollidingKey(ctx context.Context, opts s3.ConnectionOptions, input s3.ResolveNonCollidingKeyInput) (string, error) {
 	return m.resolveNonCollidingKeyFn(ctx, opts, input)
 }
+func (m *mockS3ServiceForRepo) CreateMultipartUpload(ctx context.Context, opts s3.ConnectionOptions, input s3.CreateMultipartUploadInput) (*s3.MultipartUpload, error) {
+	return m.createMPUFn(ctx, opts, input)
+}
+func (m *mockS3ServiceForRepo) UploadPart(context.Context, s3.ConnectionOptions, s3.UploadPartInput) (*s3.UploadedPart, error) {
+	return nil, nil
+}
+func (m *mockS3ServiceForRepo) ListParts(context.Context, s3.ConnectionOptions, s3.ListPartsInput) ([]s3.UploadedPart, error) {
+	return nil, nil
+}
+func (m *mockS3ServiceForRepo) CompleteMultipartUpload(ctx context.Context, opts s3.ConnectionOptions, input s3.CompleteMultipartUploadInput) error {
+	return m.completeMPUFn(ctx, opts, input)
+}
+func (m *mockS3ServiceForRepo) AbortMultipartUpload(context.Context, s3.ConnectionOptions, s3.AbortMultipartUploadInput) error {
+	return nil
+}
+func (m *mockS3ServiceForRepo) CopyObject(context.Context, s3.ConnectionOptions, s3.CopyObjectInput) error {
+	return nil
+}
+func (m *mockS3ServiceForRepo) MoveObject(context.Context, s3.ConnectionOptions, s3.MoveObjectInput) error {
+	return nil
+}
+func (m *mockS3ServiceForRepo) DeleteObject(context.Context, s3.ConnectionOptions, s3.DeleteObjectInput) error {
+	return nil
+}
+func (m *mockS3ServiceForRepo) DeletePrefix(context.Context, s3.ConnectionOptions, s3.DeletePrefixInput) (*s3.DeletePrefixResult, error) {
+	return nil, nil
+}
+func (m *mockS3ServiceForRepo) PresignObject(ctx context.Context, opts s3.ConnectionOptions, input s3.PresignInput) (*s3.PresignedURL, error) {
+	return m.presignObjectFn(ctx, opts, input)
+}
 
 type mockPipelinesServiceForS3 struct {
 	discoverReadyDSPAFn func(ctx context.Context, namespace string) (*pipelines.DiscoveredDSPA, error)
@@ -122,6 +160,15 @@ func (m *mockPipelinesServiceForS3) ListPipelineRuns(context.Context, string, *p
 func (m *mockPipelinesServiceForS3) TerminateRun(context.Context, string, string) error { return nil }
 func (m *mockPipelinesServiceForS3) RetryRun(context.Context, string, string) error     { return nil }
 func (m *mockPipelinesServiceForS3) DeleteRun(context.Context, string, string) error    { return nil }
+func (m *mockPipelinesServiceForS3) CreateRecurringRun(context.Context, string, *pipelines.CreateRecurringRunInput) (*pipelines.RecurringRun, error) {
+	return nil, nil
+}
+func (m *mockPipelinesServiceForS3) GetRecurringRun(context.Context, string, string) (*pipelines.RecurringRun, error) {
+	return nil, nil
+}
+func (m *mockPipelinesServiceForS3) DeleteRecurringRun(context.Context, string, string) error {
+	return nil
+}
 func (m *mockPipelinesServiceForS3) ListPipelines(context.Context, string, string) (*pipelines.PipelinesResponse, error) {
 	return nil, nil
 }
@@ -152,6 +199,15 @@ func (m *mockPipelinesServiceForS3) GetAllPipelineRuns(context.Context, string,
 func (m *mockPipelinesServiceForS3) GetPipelineRunWithSpec(context.Context, string, string) (*pipelines.PipelineRun, error) {
 	return nil, nil
 }
+func (m *mockPipelinesServiceForS3) GetRunDAG(context.Context, string, string) (*pipelines.RunDAG, error) {
+	return nil, nil
+}
+func (m *mockPipelinesServiceForS3) StreamTaskLogs(context.Context, string, string, string, pipelines.TaskLogOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
+func (m *mockPipelinesServiceForS3) ListRunArtifacts(context.Context, string, string) ([]pipelines.RunArtifact, error) {
+	return nil, nil
+}
 func (m *mockPipelinesServiceForS3) EnableManagedPipelines(_ context.Context, _ string) (*pipelines.EnableManagedPipelinesResult, error) {
 	return nil, nil
 }
@@ -663,6 +719,89 @@ func TestS3Repository_UploadFile(t *testing.T) {
 	})
 }
 
+func TestS3Repository_CreateUpload(t *testing.T) {
+	k8s := &mockK8sServiceForS3{
+		getSecretFn: func(ctx context.Context, namespace, secretName string) (*v1.Secret, error) {
+			return makeK8sSecret("s", "ns", standardSecretData()), nil
+		},
+	}
+	var gotInput s3.CreateMultipartUploadInput
+	s3svc := &mockS3ServiceForRepo{
+		resolveNonCollidingKeyFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.ResolveNonCollidingKeyInput) (string, error) {
+			return "docs/handbook-1.pdf", nil
+		},
+		createMPUFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.CreateMultipartUploadInput) (*s3.MultipartUpload, error) {
+			gotInput = input
+			return &s3.MultipartUpload{Key: input.Key, UploadID: "upload-1"}, nil
+		},
+	}
+	repo := NewS3Repository(slog.Default(), s3svc, k8s, nil)
+
+	upload, err := repo.CreateUpload(context.Background(), S3RequestContext{Namespace: "ns", SecretName: "s"}, "docs/handbook.pdf", "image/svg+xml", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upload.Key != "docs/handbook-1.pdf" || upload.UploadID != "upload-1" {
+		t.Errorf("upload = %+v", upload)
+	}
+	if gotInput.ContentType != "application/octet-stream" {
+		t.Errorf("content type = %q, want disallowed type sanitized", gotInput.ContentType)
+	}
+}
+
+func TestS3Repository_PresignUpload(t *testing.T) {
+	k8s := &mockK8sServiceForS3{
+		getSecretFn: func(ctx context.Context, namespace, secretName string) (*v1.Secret, error) {
+			return makeK8sSecret("s", "ns", standardSecretData()), nil
+		},
+	}
+	var gotInput s3.PresignInput
+	s3svc := &mockS3ServiceForRepo{
+		resolveNonCollidingKeyFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.ResolveNonCollidingKeyInput) (string, error) {
+			return "docs/handbook-1.pdf", nil
+		},
+		presignObjectFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.PresignInput) (*s3.PresignedURL, error) {
+			gotInput = input
+			return &s3.PresignedURL{URL: "https://s3.example.com/signed", Method: input.Method}, nil
+		},
+	}
+	repo := NewS3Repository(slog.Default(), s3svc, k8s, nil)
+
+	presigned, err := repo.PresignUpload(context.Background(), S3RequestContext{Namespace: "ns", SecretName: "s"}, "docs/handbook.pdf", time.Minute, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if presigned.Key != "docs/handbook-1.pdf" || presigned.URL != "https://s3.example.com/signed" {
+		t.Errorf("presigned = %+v", presigned)
+	}
+	if gotInput.Bucket != "my-bucket" || gotInput.Key != "docs/handbook-1.pdf" || gotInput.Method != http.MethodPut || gotInput.Expires != time.Minute {
+		t.Errorf("input: %+v", gotInput)
+	}
+}
+
+func TestS3Repository_CompleteUpload(t *testing.T) {
+	k8s := &mockK8sServiceForS3{
+		getSecretFn: func(ctx context.Context, namespace, secretName string) (*v1.Secret, error) {
+			return makeK8sSecret("s", "ns", standardSecretData()), nil
+		},
+	}
+	var gotInput s3.CompleteMultipartUploadInput
+	s3svc := &mockS3ServiceForRepo{
+		completeMPUFn: func(ctx context.Context, opts s3.ConnectionOptions, input s3.CompleteMultipartUploadInput) error {
+			gotInput = input
+			return nil
+		},
+	}
+	repo := NewS3Repository(slog.Default(), s3svc, k8s, nil)
+
+	if err := repo.CompleteUpload(context.Background(), S3RequestContext{Namespace: "ns", SecretName: "s"}, "docs/handbook.pdf", "upload-1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if gotInput.Key != "docs/handbook.pdf" || gotInput.UploadID != "upload-1" || gotInput.Parts != nil {
+		t.Errorf("input: %+v", gotInput)
+	}
+}
+
 func TestS3Repository_ListObjects(t *testing.T) {
 	k8s := &mockK8sServiceForS3{
 		getSecretFn: func(ctx context.Context, namespace, secretName string) (*v1.Secret, error) {
@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"context"
+	"io"
 	"strings"
 	"time"
 
@@ -90,6 +91,11 @@ func (c *baseClient) GetPods(ctx context.Context, namespace string) (*v1.PodList
 	return c.Clientset.CoreV1().Pods(namespace).List(timeoutCtx, metav1.ListOptions{})
 }
 
+// GetPodLogs is not bounded by a timeout: followed logs stream until ctx is done.
+func (c *baseClient) GetPodLogs(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+	return c.Clientset.CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+}
+
 func (c *baseClient) GetSecrets(ctx context.Context, namespace string) ([]v1.Secret, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"io"
 	"log/slog"
 
 	v1 "k8s.io/api/core/v1"
@@ -36,6 +37,8 @@ type Client interface {
 	// Common resource operations
 	GetNamespaces(ctx context.Context) ([]v1.Namespace, error)
 	GetPods(ctx context.Context, namespace string) (*v1.PodList, error)
+	// GetPodLogs streams the logs of a pod container. The caller must close the stream.
+	GetPodLogs(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error)
 	GetSecrets(ctx context.Context, namespace string) ([]v1.Secret, error)
 	GetSecret(ctx context.Context, namespace, secretName string) (*v1.Secret, error)
 
@@ -58,6 +61,7 @@ type Service interface {
 	GetAccessibleNamespaces(ctx context.Context) ([]v1.Namespace, error)
 	GetAccessibleNamespaceInfos(ctx context.Context) ([]NamespaceInfo, error)
 	GetPods(ctx context.Context, namespace string) (*v1.PodList, error)
+	GetPodLogs(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error)
 	GetSecrets(ctx context.Context, namespace string) ([]v1.Secret, error)
 	GetSecretInfos(ctx context.Context, namespace string) ([]SecretInfo, error)
 	GetSecret(ctx context.Context, namespace, secretName string) (*v1.Secret, error)
@@ -191,6 +195,33 @@ func (s *service) GetPods(ctx context.Context, namespace string) (*v1.PodList, e
 	return pods, nil
 }
 
+// GetPodLogs streams the logs of a pod container. opts may be nil. The stream stays open
+// while opts.Follow is set and the container runs, so callers bound it with ctx.
+func (s *service) GetPodLogs(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+	logger := s.loggerWithIdentity(ctx)
+	logger.Info("streaming pod logs", "namespace", namespace, "pod", podName)
+
+	if err := ValidateNamespaceName(namespace); err != nil {
+		s.Logger.Error("invalid namespace name", "error", err)
+		return nil, err
+	}
+	if err := ValidateResourceName("pod", podName); err != nil {
+		s.Logger.Error("invalid pod name", "error", err)
+		return nil, err
+	}
+	if opts == nil {
+		opts = &v1.PodLogOptions{}
+	}
+
+	stream, err := s.Client.GetPodLogs(ctx, namespace, podName, opts)
+	if err != nil {
+		s.Logger.Error("failed to get pod logs", "namespace", namespace, "pod", podName, "error", err)
+		return nil, TranslateK8sError(err, "pod logs", "get")
+	}
+
+	return stream, nil
+}
+
 // --- Secrets ---
 
 func (s *service) GetSecrets(ctx context.Context, namespace string) ([]v1.Secret, error) {
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"strings"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -20,6 +23,7 @@ type mockClient struct {
 	createResourceFn      func(ctx context.Context, gvr schema.GroupVersionResource, namespace string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	getNamespacesFn       func(ctx context.Context) ([]v1.Namespace, error)
 	getPodsFn             func(ctx context.Context, namespace string) (*v1.PodList, error)
+	getPodLogsFn          func(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error)
 	getSecretsFn          func(ctx context.Context, namespace string) ([]v1.Secret, error)
 	getSecretFn           func(ctx context.Context, namespace, secretName string) (*v1.Secret, error)
 	getUserFn             func(ctx context.Context) (string, error)
@@ -45,6 +49,9 @@ func (m *mockClient) GetNamespaces(ctx context.Context) ([]v1.Namespace, error)
 func (m *mockClient) GetPods(ctx context.Context, namespace string) (*v1.PodList, error) {
 	return m.getPodsFn(ctx, namespace)
 }
+func (m *mockClient) GetPodLogs(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+	return m.getPodLogsFn(ctx, namespace, podName, opts)
+}
 func (m *mockClient) GetSecrets(ctx context.Context, namespace string) ([]v1.Secret, error) {
 	return m.getSecretsFn(ctx, namespace)
 }
@@ -330,6 +337,54 @@ func TestService_GetPods(t *testing.T) {
 	})
 }
 
+func TestService_GetPodLogs(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var gotOpts *v1.PodLogOptions
+		client := &mockClient{
+			getPodLogsFn: func(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+				gotOpts = opts
+				return io.NopCloser(strings.NewReader("line 1\n")), nil
+			},
+		}
+		svc := newTestService(client)
+
+		stream, err := svc.GetPodLogs(ctxWithUser("alice"), "my-ns", "pod-1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stream.Close()
+		data, _ := io.ReadAll(stream)
+		if string(data) != "line 1\n" {
+			t.Errorf("logs = %q", data)
+		}
+		if gotOpts == nil {
+			t.Error("nil options should be replaced with defaults")
+		}
+	})
+
+	t.Run("invalid pod name", func(t *testing.T) {
+		svc := newTestService(&mockClient{})
+		_, err := svc.GetPodLogs(ctxWithUser("alice"), "my-ns", "Bad_Pod", nil)
+		var ve *ValidationError
+		if !errors.As(err, &ve) {
+			t.Errorf("expected *ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("pod not found", func(t *testing.T) {
+		client := &mockClient{
+			getPodLogsFn: func(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+				return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, podName)
+			},
+		}
+		svc := newTestService(client)
+		_, err := svc.GetPodLogs(ctxWithUser("alice"), "my-ns", "pod-1", nil)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
 // --- Secrets Tests ---
 
 func TestService_GetSecrets(t *testing.T) {
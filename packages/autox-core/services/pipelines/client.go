@@ -293,6 +293,102 @@ func (c *client) DeleteRun(ctx context.Context, baseURL string, runID string) er
 	return nil
 }
 
+// CreateRecurringRun creates a new recurring run
+func (c *client) CreateRecurringRun(ctx context.Context, baseURL string, input *CreateRecurringRunInput) (*RecurringRun, error) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/apis/v2beta1/recurringruns", baseURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, readhttpError(resp)
+	}
+
+	var recurringRun RecurringRun
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSuccessBodySize)).Decode(&recurringRun); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &recurringRun, nil
+}
+
+// GetRecurringRun retrieves a single recurring run by ID
+func (c *client) GetRecurringRun(ctx context.Context, baseURL string, recurringRunID string) (*RecurringRun, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if recurringRunID == "" {
+		return nil, fmt.Errorf("%w: recurringRunID is required", ErrInvalidInput)
+	}
+
+	apiURL := fmt.Sprintf("%s/apis/v2beta1/recurringruns/%s", baseURL, url.PathEscape(recurringRunID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, readhttpError(resp)
+	}
+
+	var recurringRun RecurringRun
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSuccessBodySize)).Decode(&recurringRun); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &recurringRun, nil
+}
+
+// DeleteRecurringRun deletes a recurring run. Runs it already started are kept.
+func (c *client) DeleteRecurringRun(ctx context.Context, baseURL string, recurringRunID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if recurringRunID == "" {
+		return fmt.Errorf("%w: recurringRunID is required", ErrInvalidInput)
+	}
+
+	apiURL := fmt.Sprintf("%s/apis/v2beta1/recurringruns/%s", baseURL, url.PathEscape(recurringRunID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return readhttpError(resp)
+	}
+
+	return nil
+}
+
 // ListPipelines retrieves all pipelines, paging through results.
 // Capped at maxPaginationPages to prevent unbounded iteration from a malicious server.
 func (c *client) ListPipelines(ctx context.Context, baseURL string, filter string) (*PipelinesResponse, error) {
@@ -187,6 +187,95 @@ func TestClient_RunActions(t *testing.T) {
 	}
 }
 
+// --- Recurring Runs ---
+
+func TestClient_CreateRecurringRun(t *testing.T) {
+	ts, c := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/apis/v2beta1/recurringruns") {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		// KFP encodes int64 fields as JSON strings.
+		if body["max_concurrency"] != "1" {
+			t.Errorf("max_concurrency = %#v, want \"1\"", body["max_concurrency"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"recurring_run_id":"rr-1","display_name":"nightly","max_concurrency":"1","status":"ENABLED",
+			"trigger":{"cron_schedule":{"cron":"0 0 2 * * *"}}}`)
+	})
+	defer ts.Close()
+
+	rr, err := c.CreateRecurringRun(context.Background(), ts.URL, &CreateRecurringRunInput{
+		DisplayName:    "nightly",
+		Trigger:        &Trigger{CronSchedule: &CronSchedule{Cron: "0 0 2 * * *"}},
+		Mode:           RecurringRunModeEnable,
+		MaxConcurrency: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.RecurringRunID != "rr-1" || rr.MaxConcurrency != 1 || rr.Trigger.CronSchedule.Cron != "0 0 2 * * *" {
+		t.Errorf("unexpected recurring run %+v", rr)
+	}
+}
+
+func TestClient_RecurringRunActions(t *testing.T) {
+	t.Run("get", func(t *testing.T) {
+		ts, c := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/recurringruns/rr-1") {
+				t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			}
+			jsonResponse(w, RecurringRun{RecurringRunID: "rr-1"})
+		})
+		defer ts.Close()
+
+		rr, err := c.GetRecurringRun(context.Background(), ts.URL, "rr-1")
+		if err != nil || rr.RecurringRunID != "rr-1" {
+			t.Errorf("GetRecurringRun = %+v, %v", rr, err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		ts, c := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodDelete || !strings.HasSuffix(r.URL.Path, "/recurringruns/rr-1") {
+				t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+		defer ts.Close()
+
+		if err := c.DeleteRecurringRun(context.Background(), ts.URL, "rr-1"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ts, c := newTestServer(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "not found", http.StatusNotFound)
+		})
+		defer ts.Close()
+
+		if _, err := c.GetRecurringRun(context.Background(), ts.URL, "rr-1"); !errors.Is(err, ErrPipelineNotFound) {
+			t.Errorf("expected ErrPipelineNotFound, got %v", err)
+		}
+	})
+
+	t.Run("empty ID", func(t *testing.T) {
+		ts, c := newTestServer(func(w http.ResponseWriter, r *http.Request) { t.Fatal("should not call") })
+		defer ts.Close()
+
+		if _, err := c.GetRecurringRun(context.Background(), ts.URL, ""); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("GetRecurringRun: expected ErrInvalidInput, got %v", err)
+		}
+		if err := c.DeleteRecurringRun(context.Background(), ts.URL, ""); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("DeleteRecurringRun: expected ErrInvalidInput, got %v", err)
+		}
+	})
+}
+
 // --- ListPipelines (pagination) ---
 
 func TestClient_ListPipelines(t *testing.T) {
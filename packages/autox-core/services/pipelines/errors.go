@@ -5,12 +5,15 @@ import "errors"
 // Sentinel errors for pipeline operations.
 // ErrConflict is shared with the kubernetes package — use k8s.ErrConflict.
 var (
-	ErrPipelineRunNotFound = errors.New("pipeline run not found")
-	ErrPipelineNotFound    = errors.New("pipeline not found")
-	ErrInvalidInput        = errors.New("invalid input")
-	ErrInvalidRunState     = errors.New("invalid run state for operation")
-	ErrNoDSPAFound         = errors.New("no pipeline server found in namespace")
-	ErrDSPANotReady        = errors.New("pipeline server exists but is not ready")
+	ErrPipelineRunNotFound  = errors.New("pipeline run not found")
+	ErrRecurringRunNotFound = errors.New("recurring run not found")
+	ErrPipelineNotFound     = errors.New("pipeline not found")
+	ErrInvalidInput         = errors.New("invalid input")
+	ErrInvalidRunState      = errors.New("invalid run state for operation")
+	ErrNoDSPAFound          = errors.New("no pipeline server found in namespace")
+	ErrDSPANotReady         = errors.New("pipeline server exists but is not ready")
+	ErrTaskNotFound         = errors.New("pipeline task not found")
+	ErrTaskLogsUnavailable  = errors.New("pipeline task has no pods to read logs from")
 
 	// ErrPipelineServerBadRequest indicates the pipeline server itself rejected a request
 	// as malformed (HTTP 400) — distinct from ErrInvalidInput, which is raised for local
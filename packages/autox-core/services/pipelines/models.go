@@ -28,6 +28,7 @@ type PipelineRun struct {
 	ExperimentID             string                    `json:"experiment_id,omitempty"`
 	PipelineVersionReference *PipelineVersionReference `json:"pipeline_version_reference,omitempty"`
 	RuntimeConfig            *RuntimeConfig            `json:"runtime_config,omitempty"`
+	RecurringRunID           string                    `json:"recurring_run_id,omitempty"`
 	State                    RunState                  `json:"state,omitempty"`
 	StorageState             string                    `json:"storage_state,omitempty"`
 	ServiceAccount           string                    `json:"service_account,omitempty"`
@@ -98,6 +99,60 @@ type CreatePipelineRunInput struct {
 	RuntimeConfig            *RuntimeConfig            `json:"runtime_config,omitempty"`
 }
 
+// RecurringRunMode is the requested mode of a KFP v2beta1 recurring run.
+type RecurringRunMode string
+
+// KFP v2beta1 recurring run modes.
+const (
+	RecurringRunModeEnable  RecurringRunMode = "ENABLE"
+	RecurringRunModeDisable RecurringRunMode = "DISABLE"
+)
+
+// CronSchedule triggers a recurring run on a cron expression. KFP uses the
+// six-field robfig/cron format with a leading seconds field.
+type CronSchedule struct {
+	Cron      string `json:"cron"`
+	StartTime string `json:"start_time,omitempty"`
+	EndTime   string `json:"end_time,omitempty"`
+}
+
+// Trigger defines when a recurring run starts new runs.
+type Trigger struct {
+	CronSchedule *CronSchedule `json:"cron_schedule,omitempty"`
+}
+
+// RecurringRun represents a Kubeflow Pipelines v2beta1 recurring run.
+type RecurringRun struct {
+	RecurringRunID           string                    `json:"recurring_run_id"`
+	DisplayName              string                    `json:"display_name"`
+	Description              string                    `json:"description,omitempty"`
+	PipelineVersionReference *PipelineVersionReference `json:"pipeline_version_reference,omitempty"`
+	RuntimeConfig            *RuntimeConfig            `json:"runtime_config,omitempty"`
+	Trigger                  *Trigger                  `json:"trigger,omitempty"`
+	Mode                     RecurringRunMode          `json:"mode,omitempty"`
+	// MaxConcurrency and the other int64 fields are JSON strings in the KFP API.
+	MaxConcurrency int64      `json:"max_concurrency,omitempty,string"`
+	NoCatchup      bool       `json:"no_catchup,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	CreatedAt      string     `json:"created_at,omitempty"`
+	UpdatedAt      string     `json:"updated_at,omitempty"`
+	Namespace      string     `json:"namespace,omitempty"`
+	ExperimentID   string     `json:"experiment_id,omitempty"`
+	Error          *ErrorInfo `json:"error,omitempty"`
+}
+
+// CreateRecurringRunInput is the input payload for creating a recurring run.
+type CreateRecurringRunInput struct {
+	DisplayName              string                    `json:"display_name"`
+	Description              string                    `json:"description,omitempty"`
+	PipelineVersionReference *PipelineVersionReference `json:"pipeline_version_reference,omitempty"`
+	RuntimeConfig            *RuntimeConfig            `json:"runtime_config,omitempty"`
+	Trigger                  *Trigger                  `json:"trigger"`
+	Mode                     RecurringRunMode          `json:"mode,omitempty"`
+	MaxConcurrency           int64                     `json:"max_concurrency,omitempty,string"`
+	NoCatchup                bool                      `json:"no_catchup,omitempty"`
+}
+
 // PipelineRunResponse wraps a list of pipeline runs with pagination.
 type PipelineRunResponse struct {
 	Runs          []PipelineRun `json:"runs,omitempty"`
@@ -199,3 +254,53 @@ type DSPAObjectStorageSpec struct {
 	Bucket         string // default bucket from the DSPA spec
 	Region         string // S3-compatible region (defaults to "us-east-1" if empty)
 }
+
+// RunDAG is the task graph of a pipeline run: the tasks declared in the pipeline spec,
+// each merged with the execution state reported by the pipeline server.
+type RunDAG struct {
+	RunID string    `json:"run_id"`
+	State RunState  `json:"state,omitempty"`
+	Tasks []DAGTask `json:"tasks"`
+}
+
+// DAGTask is a node of a RunDAG. Tasks that have not started yet carry only the fields
+// taken from the pipeline spec. Parent names the enclosing sub-DAG task, if any.
+type DAGTask struct {
+	Name          string     `json:"name"`
+	DisplayName   string     `json:"display_name,omitempty"`
+	ComponentName string     `json:"component_name,omitempty"`
+	Parent        string     `json:"parent,omitempty"`
+	DependsOn     []string   `json:"depends_on,omitempty"`
+	TaskID        string     `json:"task_id,omitempty"`
+	ExecutionID   string     `json:"execution_id,omitempty"`
+	State         RunState   `json:"state,omitempty"`
+	StartTime     string     `json:"start_time,omitempty"`
+	EndTime       string     `json:"end_time,omitempty"`
+	Error         *ErrorInfo `json:"error,omitempty"`
+	PodNames      []string   `json:"pod_names,omitempty"`
+}
+
+// RunArtifact is a typed output artifact of a pipeline run task. Type is the KFP artifact
+// schema title (e.g. "system.Model"). Bucket and Key locate the artifact in object
+// storage; Downloadable is true when it lives in the DSPA's own bucket, so it can be
+// read with the DSPA object storage credentials.
+type RunArtifact struct {
+	TaskName     string `json:"task_name"`
+	TaskID       string `json:"task_id,omitempty"`
+	ExecutionID  string `json:"execution_id"`
+	Name         string `json:"name"`
+	Type         string `json:"type,omitempty"`
+	URI          string `json:"uri"`
+	Bucket       string `json:"bucket,omitempty"`
+	Key          string `json:"key,omitempty"`
+	Downloadable bool   `json:"downloadable"`
+}
+
+// TaskLogOptions selects which pod of a task to read logs from and how.
+// PodName defaults to the task's last pod (the executor). Zero values mean unlimited.
+type TaskLogOptions struct {
+	PodName    string
+	TailLines  int64
+	LimitBytes int64
+	Follow     bool
+}
@@ -0,0 +1,399 @@
+package pipelines
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// executorContainer is the container of a KFP v2 task pod that runs the component code.
+// The other containers (launcher init, driver) only log KFP internals.
+const executorContainer = "main"
+
+// pipelineSpecDoc is the subset of a compiled KFP v2 PipelineSpec read for run details.
+type pipelineSpecDoc struct {
+	PipelineInfo struct {
+		Name string `json:"name"`
+	} `json:"pipelineInfo"`
+	Root                componentSpecDoc            `json:"root"`
+	Components          map[string]componentSpecDoc `json:"components"`
+	DefaultPipelineRoot string                      `json:"defaultPipelineRoot"`
+}
+
+type componentSpecDoc struct {
+	DAG *struct {
+		Tasks map[string]taskSpecDoc `json:"tasks"`
+	} `json:"dag"`
+	OutputDefinitions struct {
+		Artifacts map[string]struct {
+			ArtifactType struct {
+				SchemaTitle string `json:"schemaTitle"`
+			} `json:"artifactType"`
+		} `json:"artifacts"`
+	} `json:"outputDefinitions"`
+}
+
+type taskSpecDoc struct {
+	TaskInfo struct {
+		Name string `json:"name"`
+	} `json:"taskInfo"`
+	DependentTasks []string `json:"dependentTasks"`
+	ComponentRef   struct {
+		Name string `json:"name"`
+	} `json:"componentRef"`
+}
+
+// specTask is a task of the pipeline spec flattened out of its (sub-)DAG.
+type specTask struct {
+	name      string
+	display   string
+	component string
+	parent    string
+	dependsOn []string
+}
+
+// parsePipelineSpec decodes a pipeline spec as stored on a pipeline version. Versions
+// uploaded with a platform spec wrap both documents in {"pipeline_spec": ..., "platform_spec": ...}.
+func parsePipelineSpec(raw json.RawMessage) (*pipelineSpecDoc, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("%w: run has no pipeline spec", ErrInvalidInput)
+	}
+	var wrapper struct {
+		PipelineSpec json.RawMessage `json:"pipeline_spec"`
+	}
+	if err := json.Unmarshal(raw, &wrapper); err == nil && len(wrapper.PipelineSpec) > 0 {
+		raw = wrapper.PipelineSpec
+	}
+	var spec pipelineSpecDoc
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse pipeline spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// flattenTasks lists the tasks of dag and, after each sub-DAG task, the tasks it contains.
+// Tasks of one DAG are in dependency order, with ties broken by name.
+func (spec *pipelineSpecDoc) flattenTasks(dag componentSpecDoc, parent string, seen map[string]bool) []specTask {
+	if dag.DAG == nil {
+		return nil
+	}
+	var out []specTask
+	for _, name := range topoSort(dag.DAG.Tasks) {
+		t := dag.DAG.Tasks[name]
+		task := specTask{
+			name:      name,
+			display:   t.TaskInfo.Name,
+			component: t.ComponentRef.Name,
+			parent:    parent,
+			dependsOn: slices.Sorted(slices.Values(t.DependentTasks)),
+		}
+		if task.display == "" {
+			task.display = name
+		}
+		out = append(out, task)
+
+		// A component referenced twice would otherwise recurse forever on a malformed spec.
+		if comp, ok := spec.Components[t.ComponentRef.Name]; ok && comp.DAG != nil && !seen[t.ComponentRef.Name] {
+			seen[t.ComponentRef.Name] = true
+			out = append(out, spec.flattenTasks(comp, name, seen)...)
+			delete(seen, t.ComponentRef.Name)
+		}
+	}
+	return out
+}
+
+// topoSort orders task names so that every task comes after the tasks it depends on.
+// Dependencies outside the DAG are ignored; cycles fall back to name order.
+func topoSort(tasks map[string]taskSpecDoc) []string {
+	remaining := make(map[string]int, len(tasks))
+	dependents := make(map[string][]string, len(tasks))
+	for name, t := range tasks {
+		remaining[name] += 0
+		for _, dep := range t.DependentTasks {
+			if _, ok := tasks[dep]; ok {
+				remaining[name]++
+				dependents[dep] = append(dependents[dep], name)
+			}
+		}
+	}
+
+	order := make([]string, 0, len(tasks))
+	for len(remaining) > 0 {
+		var ready []string
+		for name, n := range remaining {
+			if n == 0 {
+				ready = append(ready, name)
+			}
+		}
+		if len(ready) == 0 {
+			for name := range remaining {
+				ready = append(ready, name)
+			}
+		}
+		sort.Strings(ready)
+		for _, name := range ready {
+			delete(remaining, name)
+			for _, d := range dependents[name] {
+				if _, ok := remaining[d]; ok {
+					remaining[d]--
+				}
+			}
+		}
+		order = append(order, ready...)
+	}
+	return order
+}
+
+// taskDetailsByName groups the run's task details by display name. A task can be
+// reported several times (retries, driver and executor entries); the entry with an
+// execution wins, and the pods of all entries are kept in order.
+func taskDetailsByName(run *PipelineRun) map[string]*TaskDetail {
+	byName := map[string]*TaskDetail{}
+	if run.RunDetails == nil {
+		return byName
+	}
+	for i := range run.RunDetails.TaskDetails {
+		detail := run.RunDetails.TaskDetails[i]
+		existing, ok := byName[detail.DisplayName]
+		if !ok {
+			byName[detail.DisplayName] = &detail
+			continue
+		}
+		pods := append(slices.Clone(existing.ChildTasks), detail.ChildTasks...)
+		if existing.ExecutionID == "" || (detail.ExecutionID != "" && detail.StartTime > existing.StartTime) {
+			*existing = detail
+		}
+		existing.ChildTasks = pods
+	}
+	return byName
+}
+
+func podNames(detail *TaskDetail) []string {
+	var names []string
+	for _, child := range detail.ChildTasks {
+		if child.PodName != "" && !slices.Contains(names, child.PodName) {
+			names = append(names, child.PodName)
+		}
+	}
+	return names
+}
+
+// GetRunDAG returns the task graph of a run with the state of each task. The graph comes
+// from the pipeline spec; task details without a matching spec task are appended so that
+// nothing the pipeline server reports is hidden.
+func (s *service) GetRunDAG(ctx context.Context, namespace, runID string) (*RunDAG, error) {
+	logger := s.loggerWithIdentity(ctx)
+	logger.Info("getting run DAG", "namespace", namespace, "run_id", runID)
+
+	run, err := s.GetPipelineRunWithSpec(ctx, namespace, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := taskDetailsByName(run)
+	dag := &RunDAG{RunID: run.RunID, State: run.State, Tasks: []DAGTask{}}
+	matched := map[string]bool{}
+
+	spec, err := parsePipelineSpec(run.PipelineSpec)
+	if err != nil {
+		logger.Warn("building run DAG from task details only", "run_id", runID, "error", err)
+	} else {
+		for _, t := range spec.flattenTasks(spec.Root, "", map[string]bool{}) {
+			task := DAGTask{
+				Name:          t.name,
+				DisplayName:   t.display,
+				ComponentName: t.component,
+				Parent:        t.parent,
+				DependsOn:     t.dependsOn,
+			}
+			detail, ok := details[t.display]
+			if !ok {
+				detail, ok = details[t.name]
+			}
+			if ok {
+				matched[detail.DisplayName] = true
+				applyTaskDetail(&task, detail)
+			}
+			dag.Tasks = append(dag.Tasks, task)
+		}
+	}
+
+	names := make([]string, 0, len(details))
+	for name := range details {
+		if !matched[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		task := DAGTask{Name: name, DisplayName: name}
+		applyTaskDetail(&task, details[name])
+		dag.Tasks = append(dag.Tasks, task)
+	}
+
+	return dag, nil
+}
+
+func applyTaskDetail(task *DAGTask, detail *TaskDetail) {
+	task.TaskID = detail.TaskID
+	task.ExecutionID = detail.ExecutionID
+	task.State = detail.State
+	task.StartTime = detail.StartTime
+	task.EndTime = detail.EndTime
+	task.Error = detail.Error
+	task.PodNames = podNames(detail)
+}
+
+// StreamTaskLogs streams the executor logs of a run task. The caller must close the
+// returned reader. Follow is ignored once the task has finished.
+func (s *service) StreamTaskLogs(ctx context.Context, namespace, runID, taskID string, opts TaskLogOptions) (io.ReadCloser, error) {
+	logger := s.loggerWithIdentity(ctx)
+	logger.Info("streaming task logs", "namespace", namespace, "run_id", runID, "task_id", taskID)
+
+	if taskID == "" {
+		return nil, fmt.Errorf("%w: task ID is required", ErrInvalidInput)
+	}
+	if opts.TailLines < 0 || opts.LimitBytes < 0 {
+		return nil, fmt.Errorf("%w: tail lines and limit bytes must not be negative", ErrInvalidInput)
+	}
+
+	run, err := s.GetPipelineRun(ctx, namespace, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var task *TaskDetail
+	if run.RunDetails != nil {
+		for i := range run.RunDetails.TaskDetails {
+			if run.RunDetails.TaskDetails[i].TaskID == taskID {
+				task = &run.RunDetails.TaskDetails[i]
+				break
+			}
+		}
+	}
+	if task == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+
+	pods := podNames(task)
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTaskLogsUnavailable, taskID)
+	}
+	// Only pods of this task are readable through it; otherwise any pod in the
+	// namespace could be read by naming it.
+	pod := pods[len(pods)-1]
+	if opts.PodName != "" {
+		if !slices.Contains(pods, opts.PodName) {
+			return nil, fmt.Errorf("%w: pod %s does not belong to task %s", ErrInvalidInput, opts.PodName, taskID)
+		}
+		pod = opts.PodName
+	}
+
+	logOpts := &v1.PodLogOptions{
+		Container: executorContainer,
+		Follow:    opts.Follow && (task.State == RunStatePending || task.State == RunStateRunning),
+	}
+	if opts.TailLines > 0 {
+		logOpts.TailLines = &opts.TailLines
+	}
+	if opts.LimitBytes > 0 {
+		logOpts.LimitBytes = &opts.LimitBytes
+	}
+
+	return s.K8sService.GetPodLogs(ctx, namespace, pod, logOpts)
+}
+
+// ListRunArtifacts lists the typed output artifacts of the run's finished tasks.
+// Locations follow the KFP v2 launcher layout:
+// <pipelineRoot>/<pipelineName>/<runID>/<taskName>/<executionID>/<outputName>.
+// The pipeline root is the run's, else the spec default, else the DSPA bucket.
+func (s *service) ListRunArtifacts(ctx context.Context, namespace, runID string) ([]RunArtifact, error) {
+	logger := s.loggerWithIdentity(ctx)
+	logger.Info("listing run artifacts", "namespace", namespace, "run_id", runID)
+
+	dspa, err := s.DiscoverReadyDSPA(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.GetPipelineRunWithSpec(ctx, namespace, runID)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := parsePipelineSpec(run.PipelineSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	root := spec.DefaultPipelineRoot
+	if run.RuntimeConfig != nil && run.RuntimeConfig.PipelineRoot != "" {
+		root = run.RuntimeConfig.PipelineRoot
+	}
+	var dspaBucket string
+	if dspa.ObjectStorage != nil {
+		dspaBucket = dspa.ObjectStorage.Bucket
+	}
+	if root == "" {
+		if dspaBucket == "" {
+			return nil, fmt.Errorf("%w: run has no pipeline root and the pipeline server has no bucket", ErrInvalidInput)
+		}
+		root = "s3://" + dspaBucket
+	}
+	runRoot := strings.TrimRight(root, "/") + "/" + spec.PipelineInfo.Name + "/" + run.RunID
+
+	details := taskDetailsByName(run)
+	artifacts := []RunArtifact{}
+	for _, t := range spec.flattenTasks(spec.Root, "", map[string]bool{}) {
+		comp, ok := spec.Components[t.component]
+		if !ok || comp.DAG != nil || len(comp.OutputDefinitions.Artifacts) == 0 {
+			continue
+		}
+		detail, ok := details[t.display]
+		if !ok {
+			detail, ok = details[t.name]
+		}
+		if !ok || detail.State != RunStateSucceeded || detail.ExecutionID == "" {
+			continue
+		}
+
+		outputs := make([]string, 0, len(comp.OutputDefinitions.Artifacts))
+		for name := range comp.OutputDefinitions.Artifacts {
+			outputs = append(outputs, name)
+		}
+		sort.Strings(outputs)
+		for _, name := range outputs {
+			uri := runRoot + "/" + t.name + "/" + detail.ExecutionID + "/" + name
+			artifact := RunArtifact{
+				TaskName:    t.name,
+				TaskID:      detail.TaskID,
+				ExecutionID: detail.ExecutionID,
+				Name:        name,
+				Type:        comp.OutputDefinitions.Artifacts[name].ArtifactType.SchemaTitle,
+				URI:         uri,
+			}
+			if bucket, key, ok := parseS3URI(uri); ok {
+				artifact.Bucket = bucket
+				artifact.Key = key
+				artifact.Downloadable = dspaBucket != "" && bucket == dspaBucket
+			}
+			artifacts = append(artifacts, artifact)
+		}
+	}
+
+	return artifacts, nil
+}
+
+// parseS3URI splits s3://bucket/key into its bucket and key.
+func parseS3URI(uri string) (bucket, key string, ok bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return "", "", false
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), true
+}
@@ -0,0 +1,292 @@
+package pipelines
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	k8s "github.com/opendatahub-io/odh-dashboard/packages/autox-core/services/kubernetes"
+	v1 "k8s.io/api/core/v1"
+)
+
+// testRunSpec is a two-step pipeline whose training step sits in a condition sub-DAG,
+// where the compiler suffixes the task name.
+const testRunSpec = `{
+	"pipelineInfo": {"name": "my-pipeline"},
+	"root": {"dag": {"tasks": {
+		"train-branch": {"taskInfo": {"name": "train-branch"}, "componentRef": {"name": "comp-condition-1"}, "dependentTasks": ["load-data"]},
+		"load-data": {"taskInfo": {"name": "load-data"}, "componentRef": {"name": "comp-load-data"}}
+	}}},
+	"components": {
+		"comp-condition-1": {"dag": {"tasks": {
+			"train-2": {"taskInfo": {"name": "train-2"}, "componentRef": {"name": "comp-train"}}
+		}}},
+		"comp-load-data": {"outputDefinitions": {"artifacts": {
+			"dataset": {"artifactType": {"schemaTitle": "system.Dataset"}}
+		}}},
+		"comp-train": {"outputDefinitions": {"artifacts": {
+			"model": {"artifactType": {"schemaTitle": "system.Model"}},
+			"metrics": {"artifactType": {"schemaTitle": "system.Metrics"}}
+		}}}
+	}
+}`
+
+func testRunWithDetails(details ...TaskDetail) *PipelineRun {
+	return &PipelineRun{
+		RunID:                    "run-1",
+		State:                    RunStateRunning,
+		PipelineVersionReference: &PipelineVersionReference{PipelineID: "p1", PipelineVersionID: "v1"},
+		RunDetails:               &RunDetails{TaskDetails: details},
+	}
+}
+
+func newRunDetailsTestService(run *PipelineRun, spec string, k8sClient k8s.Client) *service {
+	client := &mockPipelineClient{
+		getPipelineRunFn: func(ctx context.Context, baseURL string, runID string) (*PipelineRun, error) {
+			if runID != run.RunID {
+				return nil, ErrPipelineNotFound
+			}
+			copied := *run
+			return &copied, nil
+		},
+		getPipelineVersionFn: func(ctx context.Context, baseURL string, pipelineID, versionID string) (*PipelineVersion, error) {
+			return &PipelineVersion{PipelineSpec: json.RawMessage(spec)}, nil
+		},
+	}
+	svc := newTestServiceWithMock(client)
+	if k8sClient != nil {
+		svc.K8sService = k8s.NewService(k8s.ServiceConfig{Logger: slog.Default()}, k8sClient)
+	}
+	return svc
+}
+
+// podLogsK8sClient implements the pod log call of k8s.Client.
+type podLogsK8sClient struct {
+	k8s.Client
+	getPodLogsFn func(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error)
+}
+
+func (m *podLogsK8sClient) GetPodLogs(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+	return m.getPodLogsFn(ctx, namespace, podName, opts)
+}
+
+func TestParsePipelineSpec_PlatformSpecWrapper(t *testing.T) {
+	wrapped := `{"pipeline_spec": ` + testRunSpec + `, "platform_spec": {}}`
+	spec, err := parsePipelineSpec(json.RawMessage(wrapped))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.PipelineInfo.Name != "my-pipeline" {
+		t.Errorf("expected pipeline name my-pipeline, got %q", spec.PipelineInfo.Name)
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	tasks := map[string]taskSpecDoc{
+		"c": {DependentTasks: []string{"b"}},
+		"b": {DependentTasks: []string{"a", "outside"}},
+		"a": {},
+		"d": {},
+	}
+	got := topoSort(tasks)
+	want := []string{"a", "d", "b", "c"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestService_GetRunDAG(t *testing.T) {
+	run := testRunWithDetails(
+		TaskDetail{TaskID: "t1", DisplayName: "load-data", State: RunStateSucceeded, ExecutionID: "11",
+			ChildTasks: []ChildTask{{PodName: "load-driver"}, {PodName: "load-exec"}}},
+		TaskDetail{TaskID: "t2", DisplayName: "train-2", State: RunStateRunning, ExecutionID: "12"},
+		TaskDetail{TaskID: "t3", DisplayName: "train-2", State: RunStateFailed, ExecutionID: "",
+			ChildTasks: []ChildTask{{PodName: "train-exec"}}},
+		TaskDetail{TaskID: "t9", DisplayName: "exit-handler", State: RunStateSucceeded},
+	)
+
+	t.Run("merges spec and task details", func(t *testing.T) {
+		svc := newRunDetailsTestService(run, testRunSpec, nil)
+		dag, err := svc.GetRunDAG(testCtx(), "test-ns", "run-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var names []string
+		for _, task := range dag.Tasks {
+			names = append(names, task.Name)
+		}
+		want := []string{"load-data", "train-branch", "train-2", "exit-handler"}
+		if !slices.Equal(names, want) {
+			t.Fatalf("expected tasks %v, got %v", want, names)
+		}
+
+		load := dag.Tasks[0]
+		if load.State != RunStateSucceeded || load.TaskID != "t1" || !slices.Equal(load.PodNames, []string{"load-driver", "load-exec"}) {
+			t.Errorf("unexpected load-data task: %+v", load)
+		}
+		branch := dag.Tasks[1]
+		if !slices.Equal(branch.DependsOn, []string{"load-data"}) || branch.State != "" {
+			t.Errorf("unexpected train-branch task: %+v", branch)
+		}
+		train := dag.Tasks[2]
+		if train.Parent != "train-branch" || train.ComponentName != "comp-train" {
+			t.Errorf("unexpected train-2 placement: %+v", train)
+		}
+		// The entry with an execution wins; pods of both entries are kept.
+		if train.TaskID != "t2" || train.ExecutionID != "12" || !slices.Equal(train.PodNames, []string{"train-exec"}) {
+			t.Errorf("unexpected train-2 detail: %+v", train)
+		}
+	})
+
+	t.Run("falls back to task details without spec", func(t *testing.T) {
+		svc := newRunDetailsTestService(run, "", nil)
+		dag, err := svc.GetRunDAG(testCtx(), "test-ns", "run-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(dag.Tasks) != 3 {
+			t.Errorf("expected 3 tasks, got %d", len(dag.Tasks))
+		}
+	})
+
+	t.Run("run not found", func(t *testing.T) {
+		svc := newRunDetailsTestService(run, testRunSpec, nil)
+		_, err := svc.GetRunDAG(testCtx(), "test-ns", "missing")
+		if !errors.Is(err, ErrPipelineRunNotFound) {
+			t.Errorf("expected ErrPipelineRunNotFound, got %v", err)
+		}
+	})
+}
+
+func TestService_StreamTaskLogs(t *testing.T) {
+	run := testRunWithDetails(
+		TaskDetail{TaskID: "t1", DisplayName: "load-data", State: RunStateSucceeded,
+			ChildTasks: []ChildTask{{PodName: "load-driver"}, {PodName: "load-exec"}}},
+		TaskDetail{TaskID: "t2", DisplayName: "train-2", State: RunStateRunning,
+			ChildTasks: []ChildTask{{PodName: "train-exec"}}},
+		TaskDetail{TaskID: "t3", DisplayName: "pending", State: RunStatePending},
+	)
+
+	for _, tt := range []struct {
+		name       string
+		taskID     string
+		opts       TaskLogOptions
+		wantPod    string
+		wantFollow bool
+		wantErr    error
+	}{
+		{name: "defaults to last pod", taskID: "t1", wantPod: "load-exec"},
+		{name: "requested pod", taskID: "t1", opts: TaskLogOptions{PodName: "load-driver"}, wantPod: "load-driver"},
+		{name: "follow ignored for finished task", taskID: "t1", opts: TaskLogOptions{Follow: true}, wantPod: "load-exec"},
+		{name: "follow running task", taskID: "t2", opts: TaskLogOptions{Follow: true, TailLines: 100}, wantPod: "train-exec", wantFollow: true},
+		{name: "pod of another task", taskID: "t1", opts: TaskLogOptions{PodName: "train-exec"}, wantErr: ErrInvalidInput},
+		{name: "task not found", taskID: "t404", wantErr: ErrTaskNotFound},
+		{name: "task without pods", taskID: "t3", wantErr: ErrTaskLogsUnavailable},
+		{name: "negative tail lines", taskID: "t1", opts: TaskLogOptions{TailLines: -1}, wantErr: ErrInvalidInput},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPod string
+			var gotOpts *v1.PodLogOptions
+			k8sClient := &podLogsK8sClient{
+				getPodLogsFn: func(ctx context.Context, namespace, podName string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+					gotPod, gotOpts = podName, opts
+					return io.NopCloser(strings.NewReader("log line\n")), nil
+				},
+			}
+			svc := newRunDetailsTestService(run, testRunSpec, k8sClient)
+
+			rc, err := svc.StreamTaskLogs(testCtx(), "test-ns", "run-1", tt.taskID, tt.opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer rc.Close()
+
+			if gotPod != tt.wantPod {
+				t.Errorf("expected pod %q, got %q", tt.wantPod, gotPod)
+			}
+			if gotOpts.Container != executorContainer || gotOpts.Follow != tt.wantFollow {
+				t.Errorf("unexpected log options: %+v", gotOpts)
+			}
+			if tt.opts.TailLines > 0 && (gotOpts.TailLines == nil || *gotOpts.TailLines != tt.opts.TailLines) {
+				t.Errorf("expected tail lines %d, got %v", tt.opts.TailLines, gotOpts.TailLines)
+			}
+		})
+	}
+}
+
+func TestService_ListRunArtifacts(t *testing.T) {
+	run := testRunWithDetails(
+		TaskDetail{TaskID: "t1", DisplayName: "load-data", State: RunStateSucceeded, ExecutionID: "11"},
+		TaskDetail{TaskID: "t2", DisplayName: "train-2", State: RunStateSucceeded, ExecutionID: "12"},
+	)
+
+	newSvc := func(r *PipelineRun) *service {
+		svc := newRunDetailsTestService(r, testRunSpec, nil)
+		svc.dspaCache.set("test-ns", &DiscoveredDSPA{
+			Name:          "dspa1",
+			Namespace:     "test-ns",
+			APIServerURL:  "https://ds-pipeline.test-ns.svc:8443",
+			ObjectStorage: &DSPAObjectStorageSpec{Bucket: "pipelines"},
+		})
+		return svc
+	}
+
+	t.Run("defaults to DSPA bucket", func(t *testing.T) {
+		artifacts, err := newSvc(run).ListRunArtifacts(testCtx(), "test-ns", "run-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(artifacts) != 3 {
+			t.Fatalf("expected 3 artifacts, got %d: %+v", len(artifacts), artifacts)
+		}
+		dataset := artifacts[0]
+		if dataset.Name != "dataset" || dataset.Type != "system.Dataset" || dataset.TaskID != "t1" {
+			t.Errorf("unexpected dataset artifact: %+v", dataset)
+		}
+		if dataset.URI != "s3://pipelines/my-pipeline/run-1/load-data/11/dataset" ||
+			dataset.Bucket != "pipelines" || dataset.Key != "my-pipeline/run-1/load-data/11/dataset" || !dataset.Downloadable {
+			t.Errorf("unexpected dataset location: %+v", dataset)
+		}
+		if artifacts[1].Name != "metrics" || artifacts[2].Name != "model" || artifacts[2].TaskName != "train-2" {
+			t.Errorf("unexpected train artifacts: %+v", artifacts[1:])
+		}
+	})
+
+	t.Run("run pipeline root outside DSPA bucket", func(t *testing.T) {
+		r := *run
+		r.RuntimeConfig = &RuntimeConfig{PipelineRoot: "s3://other/prefix/"}
+		artifacts, err := newSvc(&r).ListRunArtifacts(testCtx(), "test-ns", "run-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if artifacts[0].Key != "prefix/my-pipeline/run-1/load-data/11/dataset" || artifacts[0].Downloadable {
+			t.Errorf("unexpected artifact: %+v", artifacts[0])
+		}
+	})
+
+	t.Run("skips unfinished tasks", func(t *testing.T) {
+		r := testRunWithDetails(
+			TaskDetail{TaskID: "t1", DisplayName: "load-data", State: RunStateSucceeded, ExecutionID: "11"},
+			TaskDetail{TaskID: "t2", DisplayName: "train-2", State: RunStateRunning, ExecutionID: "12"},
+		)
+		artifacts, err := newSvc(r).ListRunArtifacts(testCtx(), "test-ns", "run-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(artifacts) != 1 || artifacts[0].Name != "dataset" {
+			t.Errorf("expected only the dataset artifact, got %+v", artifacts)
+		}
+	})
+}
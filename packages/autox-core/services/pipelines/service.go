@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"strings"
 	"sync"
@@ -25,6 +26,11 @@ type Service interface {
 	RetryRun(ctx context.Context, namespace, runID string) error
 	DeleteRun(ctx context.Context, namespace, runID string) error
 
+	// Recurring Runs
+	CreateRecurringRun(ctx context.Context, namespace string, input *CreateRecurringRunInput) (*RecurringRun, error)
+	GetRecurringRun(ctx context.Context, namespace, recurringRunID string) (*RecurringRun, error)
+	DeleteRecurringRun(ctx context.Context, namespace, recurringRunID string) error
+
 	// Pipeline CRUD
 	ListPipelines(ctx context.Context, namespace, filter string) (*PipelinesResponse, error)
 	GetPipelineVersion(ctx context.Context, namespace, pipelineID, versionID string) (*PipelineVersion, error)
@@ -44,6 +50,11 @@ type Service interface {
 	GetAllPipelineRuns(ctx context.Context, namespace, pipelineID string) ([]PipelineRun, error)
 	GetPipelineRunWithSpec(ctx context.Context, namespace, runID string) (*PipelineRun, error)
 
+	// Run details
+	GetRunDAG(ctx context.Context, namespace, runID string) (*RunDAG, error)
+	StreamTaskLogs(ctx context.Context, namespace, runID, taskID string, opts TaskLogOptions) (io.ReadCloser, error)
+	ListRunArtifacts(ctx context.Context, namespace, runID string) ([]RunArtifact, error)
+
 	// DSPA
 	DiscoverReadyDSPA(ctx context.Context, namespace string) (*DiscoveredDSPA, error)
 
@@ -61,6 +72,11 @@ type Client interface {
 	RetryRun(ctx context.Context, baseURL string, runID string) error
 	DeleteRun(ctx context.Context, baseURL string, runID string) error
 
+	// Recurring Run operations
+	CreateRecurringRun(ctx context.Context, baseURL string, input *CreateRecurringRunInput) (*RecurringRun, error)
+	GetRecurringRun(ctx context.Context, baseURL string, recurringRunID string) (*RecurringRun, error)
+	DeleteRecurringRun(ctx context.Context, baseURL string, recurringRunID string) error
+
 	// Pipeline operations
 	ListPipelines(ctx context.Context, baseURL string, filter string) (*PipelinesResponse, error)
 	GetPipelineVersion(ctx context.Context, baseURL string, pipelineID, versionID string) (*PipelineVersion, error)
@@ -243,6 +259,71 @@ func (s *service) DeleteRun(ctx context.Context, namespace, runID string) error
 	return nil
 }
 
+// --- Recurring Runs ---
+
+func (s *service) CreateRecurringRun(ctx context.Context, namespace string, input *CreateRecurringRunInput) (*RecurringRun, error) {
+	logger := s.loggerWithIdentity(ctx)
+	logger.Info("creating recurring run", "namespace", namespace, "display_name", input.DisplayName)
+
+	if input.Trigger == nil || input.Trigger.CronSchedule == nil || input.Trigger.CronSchedule.Cron == "" {
+		return nil, fmt.Errorf("%w: recurring run requires a cron trigger", ErrInvalidInput)
+	}
+
+	baseURL, err := s.discoverDSPAURL(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	recurringRun, err := s.Client.CreateRecurringRun(ctx, baseURL, input)
+	if err != nil {
+		s.Logger.Error("failed to create recurring run", "error", err)
+		return nil, err
+	}
+
+	return recurringRun, nil
+}
+
+func (s *service) GetRecurringRun(ctx context.Context, namespace, recurringRunID string) (*RecurringRun, error) {
+	logger := s.loggerWithIdentity(ctx)
+	logger.Info("getting recurring run", "namespace", namespace, "recurring_run_id", recurringRunID)
+
+	baseURL, err := s.discoverDSPAURL(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	recurringRun, err := s.Client.GetRecurringRun(ctx, baseURL, recurringRunID)
+	if err != nil {
+		if errors.Is(err, ErrPipelineNotFound) {
+			return nil, ErrRecurringRunNotFound
+		}
+		s.Logger.Error("failed to get recurring run", "recurring_run_id", recurringRunID, "error", err)
+		return nil, err
+	}
+
+	return recurringRun, nil
+}
+
+func (s *service) DeleteRecurringRun(ctx context.Context, namespace, recurringRunID string) error {
+	logger := s.loggerWithIdentity(ctx)
+	logger.Info("deleting recurring run", "namespace", namespace, "recurring_run_id", recurringRunID)
+
+	baseURL, err := s.discoverDSPAURL(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Client.DeleteRecurringRun(ctx, baseURL, recurringRunID); err != nil {
+		if errors.Is(err, ErrPipelineNotFound) {
+			return ErrRecurringRunNotFound
+		}
+		s.Logger.Error("failed to delete recurring run", "recurring_run_id", recurringRunID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
 // --- Pipeline CRUD ---
 
 func (s *service) ListPipelines(ctx context.Context, namespace, filter string) (*PipelinesResponse, error) {
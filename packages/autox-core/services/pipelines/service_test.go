@@ -18,6 +18,9 @@ type mockPipelineClient struct {
 	terminateRunFn          func(ctx context.Context, baseURL string, runID string) error
 	retryRunFn              func(ctx context.Context, baseURL string, runID string) error
 	deleteRunFn             func(ctx context.Context, baseURL string, runID string) error
+	createRecurringRunFn    func(ctx context.Context, baseURL string, input *CreateRecurringRunInput) (*RecurringRun, error)
+	getRecurringRunFn       func(ctx context.Context, baseURL string, recurringRunID string) (*RecurringRun, error)
+	deleteRecurringRunFn    func(ctx context.Context, baseURL string, recurringRunID string) error
 	listPipelinesFn         func(ctx context.Context, baseURL string, filter string) (*PipelinesResponse, error)
 	getPipelineVersionFn    func(ctx context.Context, baseURL string, pipelineID, versionID string) (*PipelineVersion, error)
 	listPipelineVersionsFn  func(ctx context.Context, baseURL string, pipelineID string) (*PipelineVersionsResponse, error)
@@ -43,6 +46,15 @@ func (m *mockPipelineClient) RetryRun(ctx context.Context, baseURL string, runID
 func (m *mockPipelineClient) DeleteRun(ctx context.Context, baseURL string, runID string) error {
 	return m.deleteRunFn(ctx, baseURL, runID)
 }
+func (m *mockPipelineClient) CreateRecurringRun(ctx context.Context, baseURL string, input *CreateRecurringRunInput) (*RecurringRun, error) {
+	return m.createRecurringRunFn(ctx, baseURL, input)
+}
+func (m *mockPipelineClient) GetRecurringRun(ctx context.Context, baseURL string, recurringRunID string) (*RecurringRun, error) {
+	return m.getRecurringRunFn(ctx, baseURL, recurringRunID)
+}
+func (m *mockPipelineClient) DeleteRecurringRun(ctx context.Context, baseURL string, recurringRunID string) error {
+	return m.deleteRecurringRunFn(ctx, baseURL, recurringRunID)
+}
 func (m *mockPipelineClient) ListPipelines(ctx context.Context, baseURL string, filter string) (*PipelinesResponse, error) {
 	return m.listPipelinesFn(ctx, baseURL, filter)
 }
@@ -1097,3 +1109,62 @@ func TestService_ListPipelineRuns_Error(t *testing.T) {
 		t.Error("expected error")
 	}
 }
+
+// --- Recurring Runs ---
+
+func TestService_CreateRecurringRun_RequiresCronTrigger(t *testing.T) {
+	client := &mockPipelineClient{
+		createRecurringRunFn: func(ctx context.Context, baseURL string, input *CreateRecurringRunInput) (*RecurringRun, error) {
+			t.Fatal("client should not be called without a trigger")
+			return nil, nil
+		},
+	}
+	svc := newTestServiceWithMock(client)
+
+	_, err := svc.CreateRecurringRun(testCtx(), "test-ns", &CreateRecurringRunInput{DisplayName: "nightly"})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestService_CreateRecurringRun(t *testing.T) {
+	client := &mockPipelineClient{
+		createRecurringRunFn: func(ctx context.Context, baseURL string, input *CreateRecurringRunInput) (*RecurringRun, error) {
+			if baseURL != "https://ds-pipeline.test-ns.svc:8443" {
+				t.Errorf("baseURL = %q", baseURL)
+			}
+			return &RecurringRun{RecurringRunID: "rr-1", DisplayName: input.DisplayName, Trigger: input.Trigger}, nil
+		},
+	}
+	svc := newTestServiceWithMock(client)
+
+	rr, err := svc.CreateRecurringRun(testCtx(), "test-ns", &CreateRecurringRunInput{
+		DisplayName: "nightly",
+		Trigger:     &Trigger{CronSchedule: &CronSchedule{Cron: "0 0 2 * * *"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.RecurringRunID != "rr-1" {
+		t.Errorf("RecurringRunID = %q", rr.RecurringRunID)
+	}
+}
+
+func TestService_RecurringRun_NotFound(t *testing.T) {
+	client := &mockPipelineClient{
+		getRecurringRunFn: func(ctx context.Context, baseURL string, recurringRunID string) (*RecurringRun, error) {
+			return nil, fmt.Errorf("%w: gone", ErrPipelineNotFound)
+		},
+		deleteRecurringRunFn: func(ctx context.Context, baseURL string, recurringRunID string) error {
+			return fmt.Errorf("%w: gone", ErrPipelineNotFound)
+		},
+	}
+	svc := newTestServiceWithMock(client)
+
+	if _, err := svc.GetRecurringRun(testCtx(), "test-ns", "rr-1"); !errors.Is(err, ErrRecurringRunNotFound) {
+		t.Errorf("GetRecurringRun: expected ErrRecurringRunNotFound, got %v", err)
+	}
+	if err := svc.DeleteRecurringRun(testCtx(), "test-ns", "rr-1"); !errors.Is(err, ErrRecurringRunNotFound) {
+		t.Errorf("DeleteRecurringRun: expected ErrRecurringRunNotFound, got %v", err)
+	}
+}
@@ -7,11 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
@@ -31,6 +36,10 @@ const s3ConnectTimeout = 10 * time.Second
 // timeout.
 const s3MetadataTimeout = 15 * time.Second
 
+// copyPartSize is the part size for copies above MaxCopyObjectSize. It grows for objects
+// that would otherwise need more than MaxMultipartParts parts.
+const copyPartSize int64 = 512 << 20
+
 const (
 	defaultTransferConcurrency      = 3
 	defaultTransferPartSizeBytes    = 8 * 1024 * 1024 // 8 MB
@@ -88,6 +97,7 @@ type ClientConfig struct {
 type ClientProvider interface {
 	CreateAPIClient(opts ConnectionOptions) (APIClient, error)
 	CreateTransferClient(opts ConnectionOptions) (TransferClient, error)
+	CreatePresignClient(opts ConnectionOptions) (PresignClient, error)
 }
 
 // --- Low-level SDK interfaces (for provider-level testing) ---
@@ -97,6 +107,15 @@ type APIClient interface {
 	HeadObject(ctx context.Context, params *awss3.HeadObjectInput, optFns ...func(*awss3.Options)) (*awss3.HeadObjectOutput, error)
 	ListObjectsV2(ctx context.Context, params *awss3.ListObjectsV2Input, optFns ...func(*awss3.Options)) (*awss3.ListObjectsV2Output, error)
 	GetObject(ctx context.Context, params *awss3.GetObjectInput, optFns ...func(*awss3.Options)) (*awss3.GetObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *awss3.CreateMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *awss3.UploadPartInput, optFns ...func(*awss3.Options)) (*awss3.UploadPartOutput, error)
+	ListParts(ctx context.Context, params *awss3.ListPartsInput, optFns ...func(*awss3.Options)) (*awss3.ListPartsOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *awss3.CompleteMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *awss3.AbortMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.AbortMultipartUploadOutput, error)
+	CopyObject(ctx context.Context, params *awss3.CopyObjectInput, optFns ...func(*awss3.Options)) (*awss3.CopyObjectOutput, error)
+	UploadPartCopy(ctx context.Context, params *awss3.UploadPartCopyInput, optFns ...func(*awss3.Options)) (*awss3.UploadPartCopyOutput, error)
+	DeleteObject(ctx context.Context, params *awss3.DeleteObjectInput, optFns ...func(*awss3.Options)) (*awss3.DeleteObjectOutput, error)
+	DeleteObjects(ctx context.Context, params *awss3.DeleteObjectsInput, optFns ...func(*awss3.Options)) (*awss3.DeleteObjectsOutput, error)
 }
 
 // TransferClient wraps the transfer manager methods used by this package.
@@ -106,6 +125,13 @@ type TransferClient interface {
 	GetObject(ctx context.Context, params *transfermanager.GetObjectInput, optFns ...func(*transfermanager.Options)) (*transfermanager.GetObjectOutput, error)
 }
 
+// PresignClient wraps the presigning methods used by this package.
+// The real implementation is *awss3.PresignClient (returned by awss3.NewPresignClient).
+type PresignClient interface {
+	PresignGetObject(ctx context.Context, params *awss3.GetObjectInput, optFns ...func(*awss3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignPutObject(ctx context.Context, params *awss3.PutObjectInput, optFns ...func(*awss3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
 // NewClient creates a client with an injectable provider (for testing).
 func NewClient(provider ClientProvider) Client {
 	return &client{Provider: provider}
@@ -221,10 +247,13 @@ func (c *client) ListObjects(ctx context.Context, opts ConnectionOptions, input
 	}
 
 	apiInput := &awss3.ListObjectsV2Input{
-		Bucket:    aws.String(input.Bucket),
-		Delimiter: aws.String(input.Delimiter),
-		Prefix:    aws.String(input.Prefix),
-		MaxKeys:   aws.Int32(input.Limit),
+		Bucket:  aws.String(input.Bucket),
+		Prefix:  aws.String(input.Prefix),
+		MaxKeys: aws.Int32(input.Limit),
+	}
+	// An empty delimiter lists every key under the prefix rather than one folder level.
+	if input.Delimiter != "" {
+		apiInput.Delimiter = aws.String(input.Delimiter)
 	}
 	if input.ContinuationToken != "" {
 		apiInput.ContinuationToken = aws.String(input.ContinuationToken)
@@ -268,6 +297,430 @@ func (c *client) ObjectExists(ctx context.Context, opts ConnectionOptions, input
 	return false, fmt.Errorf("error checking object existence in S3: %w", err)
 }
 
+// --- Multipart upload ---
+// Parts are uploaded with CRC32 checksums, matching the transfer manager, so that uploads
+// completed from ListParts (after a client lost track of its parts) carry the same
+// checksums as uploads completed from the client's own part list.
+
+func (c *client) CreateMultipartUpload(ctx context.Context, opts ConnectionOptions, input CreateMultipartUploadInput) (*MultipartUpload, error) {
+	ctx, cancel := context.WithTimeout(ctx, s3MetadataTimeout)
+	defer cancel()
+
+	apiClient, err := c.Provider.CreateAPIClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	apiInput := &awss3.CreateMultipartUploadInput{
+		Bucket:            aws.String(input.Bucket),
+		Key:               aws.String(input.Key),
+		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+	}
+	if input.ContentType != "" {
+		apiInput.ContentType = aws.String(input.ContentType)
+	}
+
+	output, err := apiClient.CreateMultipartUpload(ctx, apiInput)
+	if err != nil {
+		if translated := translateS3Error(err); translated != nil {
+			return nil, translated
+		}
+		return nil, fmt.Errorf("error creating S3 multipart upload: %w", err)
+	}
+
+	return &MultipartUpload{Key: input.Key, UploadID: aws.ToString(output.UploadId)}, nil
+}
+
+// UploadPart is not bounded by s3MetadataTimeout since parts carry payload.
+func (c *client) UploadPart(ctx context.Context, opts ConnectionOptions, input UploadPartInput) (*UploadedPart, error) {
+	apiClient, err := c.Provider.CreateAPIClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := apiClient.UploadPart(ctx, &awss3.UploadPartInput{
+		Bucket:            aws.String(input.Bucket),
+		Key:               aws.String(input.Key),
+		UploadId:          aws.String(input.UploadID),
+		PartNumber:        aws.Int32(input.PartNumber),
+		Body:              input.Body,
+		ContentLength:     aws.Int64(input.ContentLength),
+		ChecksumAlgorithm: types.ChecksumAlgorithmCrc32,
+	})
+	if err != nil {
+		if translated := translateS3Error(err); translated != nil {
+			return nil, translated
+		}
+		return nil, fmt.Errorf("error uploading S3 multipart upload part: %w", err)
+	}
+
+	return &UploadedPart{
+		PartNumber:    input.PartNumber,
+		ETag:          aws.ToString(output.ETag),
+		Size:          input.ContentLength,
+		ChecksumCRC32: aws.ToString(output.ChecksumCRC32),
+	}, nil
+}
+
+// ListParts returns every stored part in part-number order, following pagination.
+func (c *client) ListParts(ctx context.Context, opts ConnectionOptions, input ListPartsInput) ([]UploadedPart, error) {
+	ctx, cancel := context.WithTimeout(ctx, s3MetadataTimeout)
+	defer cancel()
+
+	apiClient, err := c.Provider.CreateAPIClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []UploadedPart{}
+	var marker *string
+	for {
+		output, err := apiClient.ListParts(ctx, &awss3.ListPartsInput{
+			Bucket:           aws.String(input.Bucket),
+			Key:              aws.String(input.Key),
+			UploadId:         aws.String(input.UploadID),
+			PartNumberMarker: marker,
+		})
+		if err != nil {
+			if translated := translateS3Error(err); translated != nil {
+				return nil, translated
+			}
+			return nil, fmt.Errorf("error listing S3 multipart upload parts: %w", err)
+		}
+		for _, part := range output.Parts {
+			parts = append(parts, UploadedPart{
+				PartNumber:    aws.ToInt32(part.PartNumber),
+				ETag:          aws.ToString(part.ETag),
+				Size:          aws.ToInt64(part.Size),
+				ChecksumCRC32: aws.ToString(part.ChecksumCRC32),
+			})
+		}
+		// Guard against stores that report truncation without advancing the marker.
+		next := output.NextPartNumberMarker
+		if !aws.ToBool(output.IsTruncated) || next == nil || aws.ToString(next) == aws.ToString(marker) {
+			return parts, nil
+		}
+		marker = next
+	}
+}
+
+// CompleteMultipartUpload uses If-None-Match: * like UploadObject, so completing an
+// upload never replaces an object written at the same key since the upload started.
+// It is not bounded by s3MetadataTimeout: S3 may take minutes to assemble large objects.
+func (c *client) CompleteMultipartUpload(ctx context.Context, opts ConnectionOptions, input CompleteMultipartUploadInput) error {
+	apiClient, err := c.Provider.CreateAPIClient(opts)
+	if err != nil {
+		return err
+	}
+
+	completed := make([]types.CompletedPart, 0, len(input.Parts))
+	for _, part := range input.Parts {
+		cp := types.CompletedPart{
+			PartNumber: aws.Int32(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		}
+		if part.ChecksumCRC32 != "" {
+			cp.ChecksumCRC32 = aws.String(part.ChecksumCRC32)
+		}
+		completed = append(completed, cp)
+	}
+
+	_, err = apiClient.CompleteMultipartUpload(ctx, &awss3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(input.Bucket),
+		Key:             aws.String(input.Key),
+		UploadId:        aws.String(input.UploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+		IfNoneMatch:     aws.String("*"),
+	})
+	if err != nil {
+		if isS3ConditionalCreateConflict(err) {
+			return ErrObjectAlreadyExists
+		}
+		if translated := translateS3Error(err); translated != nil {
+			return translated
+		}
+		return fmt.Errorf("error completing S3 multipart upload: %w", err)
+	}
+	return nil
+}
+
+func (c *client) AbortMultipartUpload(ctx context.Context, opts ConnectionOptions, input AbortMultipartUploadInput) error {
+	ctx, cancel := context.WithTimeout(ctx, s3MetadataTimeout)
+	defer cancel()
+
+	apiClient, err := c.Provider.CreateAPIClient(opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.AbortMultipartUpload(ctx, &awss3.AbortMultipartUploadInput{
+		Bucket:   aws.String(input.Bucket),
+		Key:      aws.String(input.Key),
+		UploadId: aws.String(input.UploadID),
+	})
+	if err != nil {
+		if translated := translateS3Error(err); translated != nil {
+			return translated
+		}
+		return fmt.Errorf("error aborting S3 multipart upload: %w", err)
+	}
+	return nil
+}
+
+// --- Object management ---
+
+// CopyObject copies an object within a bucket using If-None-Match: * so that an existing
+// object at the destination is never replaced. Objects larger than MaxCopyObjectSize, the
+// S3 limit for a single CopyObject request, are copied part by part. Copies run inside S3
+// but take time proportional to the object size, so they are not bounded by
+// s3MetadataTimeout.
+func (c *client) CopyObject(ctx context.Context, opts ConnectionOptions, input CopyObjectInput) error {
+	apiClient, err := c.Provider.CreateAPIClient(opts)
+	if err != nil {
+		return err
+	}
+
+	head, err := apiClient.HeadObject(ctx, &awss3.HeadObjectInput{
+		Bucket: aws.String(input.Bucket),
+		Key:    aws.String(input.SourceKey),
+	})
+	if err != nil {
+		if translated := translateS3Error(err); translated != nil {
+			return translated
+		}
+		return fmt.Errorf("error reading S3 copy source: %w", err)
+	}
+
+	source := copySource(input.Bucket, input.SourceKey)
+	if aws.ToInt64(head.ContentLength) > MaxCopyObjectSize {
+		return copyObjectInParts(ctx, apiClient, input, source, head)
+	}
+
+	_, err = apiClient.CopyObject(ctx, &awss3.CopyObjectInput{
+		Bucket:      aws.String(input.Bucket),
+		Key:         aws.String(input.DestinationKey),
+		CopySource:  aws.String(source),
+		IfNoneMatch: aws.String("*"),
+	})
+	if err != nil {
+		return copyObjectError(err)
+	}
+	return nil
+}
+
+// copyObjectInParts copies a large object with UploadPartCopy. Every part is pinned to the
+// source ETag read before the copy, so a source replaced mid-copy fails the copy instead of
+// producing a mix of both versions. The upload is aborted on any failure.
+func copyObjectInParts(ctx context.Context, apiClient APIClient, input CopyObjectInput, source string, head *awss3.HeadObjectOutput) error {
+	size := aws.ToInt64(head.ContentLength)
+	partSize := max(copyPartSize, (size+MaxMultipartParts-1)/MaxMultipartParts)
+
+	created, err := apiClient.CreateMultipartUpload(ctx, &awss3.CreateMultipartUploadInput{
+		Bucket:      aws.String(input.Bucket),
+		Key:         aws.String(input.DestinationKey),
+		ContentType: head.ContentType,
+		Metadata:    head.Metadata,
+	})
+	if err != nil {
+		return copyObjectError(err)
+	}
+	abort := func() {
+		_, _ = apiClient.AbortMultipartUpload(context.WithoutCancel(ctx), &awss3.AbortMultipartUploadInput{
+			Bucket:   aws.String(input.Bucket),
+			Key:      aws.String(input.DestinationKey),
+			UploadId: created.UploadId,
+		})
+	}
+
+	var parts []types.CompletedPart
+	for partNumber, start := int32(1), int64(0); start < size; partNumber, start = partNumber+1, start+partSize {
+		end := min(start+partSize, size) - 1
+		output, err := apiClient.UploadPartCopy(ctx, &awss3.UploadPartCopyInput{
+			Bucket:            aws.String(input.Bucket),
+			Key:               aws.String(input.DestinationKey),
+			UploadId:          created.UploadId,
+			PartNumber:        aws.Int32(partNumber),
+			CopySource:        aws.String(source),
+			CopySourceRange:   aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+			CopySourceIfMatch: head.ETag,
+		})
+		if err != nil {
+			abort()
+			if isS3ConditionalCreateConflict(err) {
+				return fmt.Errorf("error copying S3 object: source changed during copy: %w", err)
+			}
+			return copyObjectError(err)
+		}
+		if output.CopyPartResult == nil {
+			abort()
+			return fmt.Errorf("error copying S3 object: part %d returned no result", partNumber)
+		}
+		parts = append(parts, types.CompletedPart{PartNumber: aws.Int32(partNumber), ETag: output.CopyPartResult.ETag})
+	}
+
+	_, err = apiClient.CompleteMultipartUpload(ctx, &awss3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(input.Bucket),
+		Key:             aws.String(input.DestinationKey),
+		UploadId:        created.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		IfNoneMatch:     aws.String("*"),
+	})
+	if err != nil {
+		abort()
+		return copyObjectError(err)
+	}
+	return nil
+}
+
+func copyObjectError(err error) error {
+	if isS3ConditionalCreateConflict(err) {
+		return ErrObjectAlreadyExists
+	}
+	if translated := translateS3Error(err); translated != nil {
+		return translated
+	}
+	return fmt.Errorf("error copying S3 object: %w", err)
+}
+
+// copySource builds the x-amz-copy-source value. The SDK sends it verbatim, so the key is
+// URL-encoded here with its "/" separators kept.
+func copySource(bucket, key string) string {
+	return bucket + "/" + strings.ReplaceAll(url.PathEscape(key), "%2F", "/")
+}
+
+// DeleteObject deletes a single object. S3 reports success for keys that do not exist.
+func (c *client) DeleteObject(ctx context.Context, opts ConnectionOptions, input DeleteObjectInput) error {
+	ctx, cancel := context.WithTimeout(ctx, s3MetadataTimeout)
+	defer cancel()
+
+	apiClient, err := c.Provider.CreateAPIClient(opts)
+	if err != nil {
+		return err
+	}
+
+	_, err = apiClient.DeleteObject(ctx, &awss3.DeleteObjectInput{
+		Bucket: aws.String(input.Bucket),
+		Key:    aws.String(input.Key),
+	})
+	if err != nil {
+		if translated := translateS3Error(err); translated != nil {
+			return translated
+		}
+		return fmt.Errorf("error deleting S3 object: %w", err)
+	}
+	return nil
+}
+
+// DeleteObjects deletes up to MaxDeleteObjectsBatch keys in one request. Keys that S3
+// could not delete are reported in the result rather than as an error.
+func (c *client) DeleteObjects(ctx context.Context, opts ConnectionOptions, input DeleteObjectsInput) (*DeleteObjectsResult, error) {
+	if len(input.Keys) > MaxDeleteObjectsBatch {
+		return nil, fmt.Errorf("error deleting S3 objects: %d keys exceeds the batch limit of %d", len(input.Keys), MaxDeleteObjectsBatch)
+	}
+	if len(input.Keys) == 0 {
+		return &DeleteObjectsResult{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s3MetadataTimeout)
+	defer cancel()
+
+	apiClient, err := c.Provider.CreateAPIClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]types.ObjectIdentifier, 0, len(input.Keys))
+	for _, key := range input.Keys {
+		objects = append(objects, types.ObjectIdentifier{Key: aws.String(key)})
+	}
+
+	// Quiet mode: S3 only reports the keys it failed to delete.
+	output, err := apiClient.DeleteObjects(ctx, &awss3.DeleteObjectsInput{
+		Bucket: aws.String(input.Bucket),
+		Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+	})
+	if err != nil {
+		if translated := translateS3Error(err); translated != nil {
+			return nil, translated
+		}
+		return nil, fmt.Errorf("error deleting S3 objects: %w", err)
+	}
+
+	result := &DeleteObjectsResult{}
+	for _, e := range output.Errors {
+		result.Errors = append(result.Errors, DeleteObjectError{
+			Key:     aws.ToString(e.Key),
+			Code:    aws.ToString(e.Code),
+			Message: aws.ToString(e.Message),
+		})
+	}
+	result.Deleted = len(input.Keys) - len(result.Errors)
+	return result, nil
+}
+
+// PresignObject signs a GET or PUT request for one object. Signing happens locally, but
+// the presign client comes from the same provider as every other client, so the endpoint
+// passes SSRF validation before a URL for it is handed out.
+//
+// Presigned GETs ask S3 to serve the object as an attachment so that browsers download it
+// instead of rendering it. Presigned PUTs sign If-None-Match: * so they never replace an
+// existing object.
+func (c *client) PresignObject(ctx context.Context, opts ConnectionOptions, input PresignInput) (*PresignedURL, error) {
+	presigner, err := c.Provider.CreatePresignClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	signedAt := time.Now()
+	withExpiry := func(o *awss3.PresignOptions) { o.Expires = input.Expires }
+
+	var req *v4.PresignedHTTPRequest
+	switch input.Method {
+	case http.MethodGet:
+		req, err = presigner.PresignGetObject(ctx, &awss3.GetObjectInput{
+			Bucket:                     aws.String(input.Bucket),
+			Key:                        aws.String(input.Key),
+			ResponseContentDisposition: aws.String(attachmentDisposition(input.Key)),
+		}, withExpiry)
+	case http.MethodPut:
+		req, err = presigner.PresignPutObject(ctx, &awss3.PutObjectInput{
+			Bucket:      aws.String(input.Bucket),
+			Key:         aws.String(input.Key),
+			IfNoneMatch: aws.String("*"),
+		}, withExpiry)
+	default:
+		return nil, fmt.Errorf("%w: unsupported method %q", ErrInvalidPresignRequest, input.Method)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error presigning S3 %s request: %w", input.Method, err)
+	}
+
+	headers := map[string]string{}
+	for name, values := range req.SignedHeader {
+		// Clients derive Host from the URL.
+		if strings.EqualFold(name, "Host") {
+			continue
+		}
+		headers[name] = strings.Join(values, ",")
+	}
+
+	return &PresignedURL{
+		URL:       req.URL,
+		Method:    req.Method,
+		ExpiresAt: signedAt.Add(input.Expires).UTC(),
+		Headers:   headers,
+	}, nil
+}
+
+// attachmentDisposition builds a Content-Disposition that downloads the object under its
+// base name.
+func attachmentDisposition(key string) string {
+	if disposition := mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(key)}); disposition != "" {
+		return disposition
+	}
+	return "attachment"
+}
+
 // --- awsClientProvider ---
 
 // awsClientProvider is the real implementation of ClientProvider.
@@ -296,6 +749,14 @@ func (p *awsClientProvider) CreateTransferClient(opts ConnectionOptions) (Transf
 	}), nil
 }
 
+func (p *awsClientProvider) CreatePresignClient(opts ConnectionOptions) (PresignClient, error) {
+	awsClient, err := p.buildAWSClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	return awss3.NewPresignClient(awsClient), nil
+}
+
 // buildAWSClient creates a real *awss3.Client with validated endpoint, credentials, and TLS.
 // Static credentials and single-attempt retries are used so that unreachable S3
 // endpoints fail fast rather than blocking under the OpenShift route timeout.
@@ -384,6 +845,11 @@ func translateS3Error(err error) error {
 		return ErrBucketNotFound
 	}
 
+	var noSuchUpload *types.NoSuchUpload
+	if errors.As(err, &noSuchUpload) {
+		return ErrUploadNotFound
+	}
+
 	var codedErr interface{ ErrorCode() string }
 	if errors.As(err, &codedErr) {
 		switch codedErr.ErrorCode() {
@@ -393,6 +859,10 @@ func translateS3Error(err error) error {
 			return ErrBucketNotFound
 		case "AccessDenied":
 			return ErrAccessDenied
+		case "NoSuchUpload":
+			return ErrUploadNotFound
+		case "InvalidPart", "InvalidPartOrder", "EntityTooSmall":
+			return fmt.Errorf("%w: %w", ErrInvalidPart, err)
 		}
 	}
 
@@ -414,4 +884,5 @@ func isS3ConditionalCreateConflict(err error) bool {
 var _ Client = (*client)(nil)
 var _ APIClient = (*awss3.Client)(nil)
 var _ TransferClient = (*transfermanager.Client)(nil)
+var _ PresignClient = (*awss3.PresignClient)(nil)
 var _ ClientProvider = (*awsClientProvider)(nil)
@@ -8,6 +8,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager"
@@ -18,9 +19,18 @@ import (
 // --- Mock implementations ---
 
 type mockAPIClient struct {
-	headObjectFn    func(ctx context.Context, params *awss3.HeadObjectInput, optFns ...func(*awss3.Options)) (*awss3.HeadObjectOutput, error)
-	listObjectsV2Fn func(ctx context.Context, params *awss3.ListObjectsV2Input, optFns ...func(*awss3.Options)) (*awss3.ListObjectsV2Output, error)
-	getObjectFn     func(ctx context.Context, params *awss3.GetObjectInput, optFns ...func(*awss3.Options)) (*awss3.GetObjectOutput, error)
+	headObjectFn     func(ctx context.Context, params *awss3.HeadObjectInput, optFns ...func(*awss3.Options)) (*awss3.HeadObjectOutput, error)
+	listObjectsV2Fn  func(ctx context.Context, params *awss3.ListObjectsV2Input, optFns ...func(*awss3.Options)) (*awss3.ListObjectsV2Output, error)
+	getObjectFn      func(ctx context.Context, params *awss3.GetObjectInput, optFns ...func(*awss3.Options)) (*awss3.GetObjectOutput, error)
+	createMPUFn      func(ctx context.Context, params *awss3.CreateMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.CreateMultipartUploadOutput, error)
+	uploadPartFn     func(ctx context.Context, params *awss3.UploadPartInput, optFns ...func(*awss3.Options)) (*awss3.UploadPartOutput, error)
+	listPartsFn      func(ctx context.Context, params *awss3.ListPartsInput, optFns ...func(*awss3.Options)) (*awss3.ListPartsOutput, error)
+	completeMPUFn    func(ctx context.Context, params *awss3.CompleteMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.CompleteMultipartUploadOutput, error)
+	abortMPUFn       func(ctx context.Context, params *awss3.AbortMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.AbortMultipartUploadOutput, error)
+	copyObjectFn     func(ctx context.Context, params *awss3.CopyObjectInput, optFns ...func(*awss3.Options)) (*awss3.CopyObjectOutput, error)
+	uploadPartCopyFn func(ctx context.Context, params *awss3.UploadPartCopyInput, optFns ...func(*awss3.Options)) (*awss3.UploadPartCopyOutput, error)
+	deleteObjectFn   func(ctx context.Context, params *awss3.DeleteObjectInput, optFns ...func(*awss3.Options)) (*awss3.DeleteObjectOutput, error)
+	deleteObjectsFn  func(ctx context.Context, params *awss3.DeleteObjectsInput, optFns ...func(*awss3.Options)) (*awss3.DeleteObjectsOutput, error)
 }
 
 func (m *mockAPIClient) HeadObject(ctx context.Context, params *awss3.HeadObjectInput, optFns ...func(*awss3.Options)) (*awss3.HeadObjectOutput, error) {
@@ -32,6 +42,33 @@ func (m *mockAPIClient) ListObjectsV2(ctx context.Context, params *awss3.ListObj
 func (m *mockAPIClient) GetObject(ctx context.Context, params *awss3.GetObjectInput, optFns ...func(*awss3.Options)) (*awss3.GetObjectOutput, error) {
 	return m.getObjectFn(ctx, params, optFns...)
 }
+func (m *mockAPIClient) CreateMultipartUpload(ctx context.Context, params *awss3.CreateMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.CreateMultipartUploadOutput, error) {
+	return m.createMPUFn(ctx, params, optFns...)
+}
+func (m *mockAPIClient) UploadPart(ctx context.Context, params *awss3.UploadPartInput, optFns ...func(*awss3.Options)) (*awss3.UploadPartOutput, error) {
+	return m.uploadPartFn(ctx, params, optFns...)
+}
+func (m *mockAPIClient) ListParts(ctx context.Context, params *awss3.ListPartsInput, optFns ...func(*awss3.Options)) (*awss3.ListPartsOutput, error) {
+	return m.listPartsFn(ctx, params, optFns...)
+}
+func (m *mockAPIClient) CompleteMultipartUpload(ctx context.Context, params *awss3.CompleteMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.CompleteMultipartUploadOutput, error) {
+	return m.completeMPUFn(ctx, params, optFns...)
+}
+func (m *mockAPIClient) AbortMultipartUpload(ctx context.Context, params *awss3.AbortMultipartUploadInput, optFns ...func(*awss3.Options)) (*awss3.AbortMultipartUploadOutput, error) {
+	return m.abortMPUFn(ctx, params, optFns...)
+}
+func (m *mockAPIClient) CopyObject(ctx context.Context, params *awss3.CopyObjectInput, optFns ...func(*awss3.Options)) (*awss3.CopyObjectOutput, error) {
+	return m.copyObjectFn(ctx, params, optFns...)
+}
+func (m *mockAPIClient) UploadPartCopy(ctx context.Context, params *awss3.UploadPartCopyInput, optFns ...func(*awss3.Options)) (*awss3.UploadPartCopyOutput, error) {
+	return m.uploadPartCopyFn(ctx, params, optFns...)
+}
+func (m *mockAPIClient) DeleteObject(ctx context.Context, params *awss3.DeleteObjectInput, optFns ...func(*awss3.Options)) (*awss3.DeleteObjectOutput, error) {
+	return m.deleteObjectFn(ctx, params, optFns...)
+}
+func (m *mockAPIClient) DeleteObjects(ctx context.Context, params *awss3.DeleteObjectsInput, optFns ...func(*awss3.Options)) (*awss3.DeleteObjectsOutput, error) {
+	return m.deleteObjectsFn(ctx, params, optFns...)
+}
 
 type mockTransferClient struct {
 	uploadObjectFn func(ctx context.Context, params *transfermanager.UploadObjectInput, optFns ...func(*transfermanager.Options)) (*transfermanager.UploadObjectOutput, error)
@@ -48,8 +85,10 @@ func (m *mockTransferClient) GetObject(ctx context.Context, params *transfermana
 type mockProvider struct {
 	apiClient      APIClient
 	transferClient TransferClient
+	presignClient  PresignClient
 	apiErr         error
 	transferErr    error
+	presignErr     error
 }
 
 func (m *mockProvider) CreateAPIClient(opts ConnectionOptions) (APIClient, error) {
@@ -58,6 +97,9 @@ func (m *mockProvider) CreateAPIClient(opts ConnectionOptions) (APIClient, error
 func (m *mockProvider) CreateTransferClient(opts ConnectionOptions) (TransferClient, error) {
 	return m.transferClient, m.transferErr
 }
+func (m *mockProvider) CreatePresignClient(opts ConnectionOptions) (PresignClient, error) {
+	return m.presignClient, m.presignErr
+}
 
 func testOpts() ConnectionOptions {
 	return ConnectionOptions{AccessKeyID: "AK", SecretAccessKey: "SK", Region: "us-east-1", BaseEndpoint: "https://s3.example.com"}
@@ -539,3 +581,280 @@ type s3CodedError struct {
 
 func (e *s3CodedError) Error() string     { return "s3 error: " + e.code }
 func (e *s3CodedError) ErrorCode() string { return e.code }
+
+// --- Multipart upload ---
+
+func TestClient_CreateMultipartUpload(t *testing.T) {
+	var got *awss3.CreateMultipartUploadInput
+	api := &mockAPIClient{
+		createMPUFn: func(ctx context.Context, params *awss3.CreateMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.CreateMultipartUploadOutput, error) {
+			got = params
+			return &awss3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil
+		},
+	}
+	c := &client{Provider: &mockProvider{apiClient: api}}
+
+	upload, err := c.CreateMultipartUpload(context.Background(), testOpts(), CreateMultipartUploadInput{Bucket: "b", Key: "k.csv", ContentType: "text/csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upload.Key != "k.csv" || upload.UploadID != "upload-1" {
+		t.Errorf("upload = %+v", upload)
+	}
+	if aws.ToString(got.ContentType) != "text/csv" || got.ChecksumAlgorithm != types.ChecksumAlgorithmCrc32 {
+		t.Errorf("content type = %q, checksum algorithm = %q", aws.ToString(got.ContentType), got.ChecksumAlgorithm)
+	}
+}
+
+func TestClient_UploadPart(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &mockAPIClient{
+			uploadPartFn: func(ctx context.Context, params *awss3.UploadPartInput, _ ...func(*awss3.Options)) (*awss3.UploadPartOutput, error) {
+				if aws.ToString(params.UploadId) != "upload-1" || aws.ToInt32(params.PartNumber) != 2 || aws.ToInt64(params.ContentLength) != 4 {
+					t.Errorf("unexpected params: upload=%q part=%d length=%d", aws.ToString(params.UploadId), aws.ToInt32(params.PartNumber), aws.ToInt64(params.ContentLength))
+				}
+				return &awss3.UploadPartOutput{ETag: aws.String(`"etag-2"`), ChecksumCRC32: aws.String("crc")}, nil
+			},
+		}
+		c := &client{Provider: &mockProvider{apiClient: api}}
+
+		part, err := c.UploadPart(context.Background(), testOpts(), UploadPartInput{
+			Bucket: "b", Key: "k", UploadID: "upload-1", PartNumber: 2, Body: bytes.NewReader([]byte("data")), ContentLength: 4,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := UploadedPart{PartNumber: 2, ETag: `"etag-2"`, Size: 4, ChecksumCRC32: "crc"}
+		if *part != want {
+			t.Errorf("part = %+v, want %+v", *part, want)
+		}
+	})
+
+	t.Run("unknown upload translated", func(t *testing.T) {
+		api := &mockAPIClient{
+			uploadPartFn: func(ctx context.Context, params *awss3.UploadPartInput, _ ...func(*awss3.Options)) (*awss3.UploadPartOutput, error) {
+				return nil, &types.NoSuchUpload{}
+			},
+		}
+		c := &client{Provider: &mockProvider{apiClient: api}}
+
+		_, err := c.UploadPart(context.Background(), testOpts(), UploadPartInput{Bucket: "b", Key: "k", UploadID: "gone", PartNumber: 1, Body: bytes.NewReader([]byte("x")), ContentLength: 1})
+		if !errors.Is(err, ErrUploadNotFound) {
+			t.Errorf("expected ErrUploadNotFound, got %v", err)
+		}
+	})
+}
+
+func TestClient_ListParts_Paginates(t *testing.T) {
+	var markers []string
+	api := &mockAPIClient{
+		listPartsFn: func(ctx context.Context, params *awss3.ListPartsInput, _ ...func(*awss3.Options)) (*awss3.ListPartsOutput, error) {
+			markers = append(markers, aws.ToString(params.PartNumberMarker))
+			if params.PartNumberMarker == nil {
+				return &awss3.ListPartsOutput{
+					Parts:                []types.Part{{PartNumber: aws.Int32(1), ETag: aws.String("e1"), Size: aws.Int64(5)}},
+					IsTruncated:          aws.Bool(true),
+					NextPartNumberMarker: aws.String("1"),
+				}, nil
+			}
+			return &awss3.ListPartsOutput{
+				Parts: []types.Part{{PartNumber: aws.Int32(2), ETag: aws.String("e2"), Size: aws.Int64(3), ChecksumCRC32: aws.String("crc")}},
+			}, nil
+		},
+	}
+	c := &client{Provider: &mockProvider{apiClient: api}}
+
+	parts, err := c.ListParts(context.Background(), testOpts(), ListPartsInput{Bucket: "b", Key: "k", UploadID: "upload-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 2 || parts[0].ETag != "e1" || parts[1].ChecksumCRC32 != "crc" {
+		t.Errorf("parts = %+v", parts)
+	}
+	if len(markers) != 2 || markers[1] != "1" {
+		t.Errorf("markers = %v", markers)
+	}
+}
+
+func TestClient_CompleteMultipartUpload(t *testing.T) {
+	t.Run("sends parts with conditional create", func(t *testing.T) {
+		var got *awss3.CompleteMultipartUploadInput
+		api := &mockAPIClient{
+			completeMPUFn: func(ctx context.Context, params *awss3.CompleteMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.CompleteMultipartUploadOutput, error) {
+				got = params
+				return &awss3.CompleteMultipartUploadOutput{}, nil
+			},
+		}
+		c := &client{Provider: &mockProvider{apiClient: api}}
+
+		err := c.CompleteMultipartUpload(context.Background(), testOpts(), CompleteMultipartUploadInput{
+			Bucket: "b", Key: "k", UploadID: "upload-1",
+			Parts: []UploadedPart{{PartNumber: 1, ETag: "e1", ChecksumCRC32: "crc"}, {PartNumber: 2, ETag: "e2"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if aws.ToString(got.IfNoneMatch) != "*" {
+			t.Errorf("IfNoneMatch = %q", aws.ToString(got.IfNoneMatch))
+		}
+		parts := got.MultipartUpload.Parts
+		if len(parts) != 2 || aws.ToString(parts[0].ChecksumCRC32) != "crc" || parts[1].ChecksumCRC32 != nil {
+			t.Errorf("completed parts = %+v", parts)
+		}
+	})
+
+	t.Run("conflict returns ErrObjectAlreadyExists", func(t *testing.T) {
+		api := &mockAPIClient{
+			completeMPUFn: func(ctx context.Context, params *awss3.CompleteMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.CompleteMultipartUploadOutput, error) {
+				return nil, &s3CodedError{code: "PreconditionFailed"}
+			},
+		}
+		c := &client{Provider: &mockProvider{apiClient: api}}
+
+		err := c.CompleteMultipartUpload(context.Background(), testOpts(), CompleteMultipartUploadInput{Bucket: "b", Key: "k", UploadID: "u", Parts: []UploadedPart{{PartNumber: 1, ETag: "e"}}})
+		if !errors.Is(err, ErrObjectAlreadyExists) {
+			t.Errorf("expected ErrObjectAlreadyExists, got %v", err)
+		}
+	})
+
+	t.Run("rejected part translated", func(t *testing.T) {
+		api := &mockAPIClient{
+			completeMPUFn: func(ctx context.Context, params *awss3.CompleteMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.CompleteMultipartUploadOutput, error) {
+				return nil, &s3CodedError{code: "EntityTooSmall"}
+			},
+		}
+		c := &client{Provider: &mockProvider{apiClient: api}}
+
+		err := c.CompleteMultipartUpload(context.Background(), testOpts(), CompleteMultipartUploadInput{Bucket: "b", Key: "k", UploadID: "u", Parts: []UploadedPart{{PartNumber: 1, ETag: "e"}}})
+		if !errors.Is(err, ErrInvalidPart) {
+			t.Errorf("expected ErrInvalidPart, got %v", err)
+		}
+	})
+}
+
+func TestClient_AbortMultipartUpload_NotFound(t *testing.T) {
+	api := &mockAPIClient{
+		abortMPUFn: func(ctx context.Context, params *awss3.AbortMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.AbortMultipartUploadOutput, error) {
+			return nil, &s3CodedError{code: "NoSuchUpload"}
+		},
+	}
+	c := &client{Provider: &mockProvider{apiClient: api}}
+
+	err := c.AbortMultipartUpload(context.Background(), testOpts(), AbortMultipartUploadInput{Bucket: "b", Key: "k", UploadID: "u"})
+	if !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("expected ErrUploadNotFound, got %v", err)
+	}
+}
+
+// --- Object management ---
+
+func TestClient_CopyObject_LargeObjectCopiedInParts(t *testing.T) {
+	size := MaxCopyObjectSize + 1
+	var ranges []string
+	var completed *awss3.CompleteMultipartUploadInput
+	api := &mockAPIClient{
+		headObjectFn: func(ctx context.Context, params *awss3.HeadObjectInput, _ ...func(*awss3.Options)) (*awss3.HeadObjectOutput, error) {
+			return &awss3.HeadObjectOutput{ContentLength: aws.Int64(size), ETag: aws.String(`"src"`), ContentType: aws.String("text/csv")}, nil
+		},
+		createMPUFn: func(ctx context.Context, params *awss3.CreateMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.CreateMultipartUploadOutput, error) {
+			if aws.ToString(params.ContentType) != "text/csv" {
+				t.Errorf("ContentType = %q", aws.ToString(params.ContentType))
+			}
+			return &awss3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil
+		},
+		uploadPartCopyFn: func(ctx context.Context, params *awss3.UploadPartCopyInput, _ ...func(*awss3.Options)) (*awss3.UploadPartCopyOutput, error) {
+			if aws.ToString(params.CopySource) != "b/data/my%20file.csv" || aws.ToString(params.CopySourceIfMatch) != `"src"` {
+				t.Errorf("copy source = %q if-match %q", aws.ToString(params.CopySource), aws.ToString(params.CopySourceIfMatch))
+			}
+			ranges = append(ranges, aws.ToString(params.CopySourceRange))
+			return &awss3.UploadPartCopyOutput{CopyPartResult: &types.CopyPartResult{ETag: aws.String(fmt.Sprintf(`"p%d"`, aws.ToInt32(params.PartNumber)))}}, nil
+		},
+		completeMPUFn: func(ctx context.Context, params *awss3.CompleteMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.CompleteMultipartUploadOutput, error) {
+			completed = params
+			return &awss3.CompleteMultipartUploadOutput{}, nil
+		},
+	}
+	c := &client{Provider: &mockProvider{apiClient: api}}
+
+	if err := c.CopyObject(context.Background(), testOpts(), CopyObjectInput{Bucket: "b", SourceKey: "data/my file.csv", DestinationKey: "copy.csv"}); err != nil {
+		t.Fatal(err)
+	}
+
+	wantParts := int((size + copyPartSize - 1) / copyPartSize)
+	if len(ranges) != wantParts {
+		t.Fatalf("copied %d parts, want %d", len(ranges), wantParts)
+	}
+	if ranges[0] != fmt.Sprintf("bytes=0-%d", copyPartSize-1) || ranges[len(ranges)-1] != fmt.Sprintf("bytes=%d-%d", size-1, size-1) {
+		t.Errorf("ranges = %s ... %s", ranges[0], ranges[len(ranges)-1])
+	}
+	if len(completed.MultipartUpload.Parts) != wantParts || aws.ToString(completed.IfNoneMatch) != "*" {
+		t.Errorf("complete = %d parts, If-None-Match %q", len(completed.MultipartUpload.Parts), aws.ToString(completed.IfNoneMatch))
+	}
+}
+
+func TestClient_CopyObject_AbortsWhenPartFails(t *testing.T) {
+	aborted := false
+	api := &mockAPIClient{
+		headObjectFn: func(ctx context.Context, params *awss3.HeadObjectInput, _ ...func(*awss3.Options)) (*awss3.HeadObjectOutput, error) {
+			return &awss3.HeadObjectOutput{ContentLength: aws.Int64(MaxCopyObjectSize + 1), ETag: aws.String(`"src"`)}, nil
+		},
+		createMPUFn: func(ctx context.Context, params *awss3.CreateMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.CreateMultipartUploadOutput, error) {
+			return &awss3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil
+		},
+		uploadPartCopyFn: func(ctx context.Context, params *awss3.UploadPartCopyInput, _ ...func(*awss3.Options)) (*awss3.UploadPartCopyOutput, error) {
+			return nil, &s3CodedError{code: "PreconditionFailed"}
+		},
+		abortMPUFn: func(ctx context.Context, params *awss3.AbortMultipartUploadInput, _ ...func(*awss3.Options)) (*awss3.AbortMultipartUploadOutput, error) {
+			aborted = aws.ToString(params.UploadId) == "upload-1"
+			return &awss3.AbortMultipartUploadOutput{}, nil
+		},
+	}
+	c := &client{Provider: &mockProvider{apiClient: api}}
+
+	err := c.CopyObject(context.Background(), testOpts(), CopyObjectInput{Bucket: "b", SourceKey: "a", DestinationKey: "b"})
+	if err == nil || !strings.Contains(err.Error(), "source changed") || errors.Is(err, ErrObjectAlreadyExists) {
+		t.Errorf("error = %v, want source changed", err)
+	}
+	if !aborted {
+		t.Error("multipart copy was not aborted")
+	}
+}
+
+func TestClient_DeleteObjects(t *testing.T) {
+	api := &mockAPIClient{
+		deleteObjectsFn: func(ctx context.Context, params *awss3.DeleteObjectsInput, _ ...func(*awss3.Options)) (*awss3.DeleteObjectsOutput, error) {
+			if len(params.Delete.Objects) != 3 || !aws.ToBool(params.Delete.Quiet) {
+				t.Errorf("delete = %d objects, quiet %v", len(params.Delete.Objects), aws.ToBool(params.Delete.Quiet))
+			}
+			return &awss3.DeleteObjectsOutput{Errors: []types.Error{{Key: aws.String("b"), Code: aws.String("AccessDenied")}}}, nil
+		},
+	}
+	c := &client{Provider: &mockProvider{apiClient: api}}
+
+	result, err := c.DeleteObjects(context.Background(), testOpts(), DeleteObjectsInput{Bucket: "b", Keys: []string{"a", "b", "c"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Deleted != 2 || len(result.Errors) != 1 || result.Errors[0].Key != "b" {
+		t.Errorf("result = %+v", result)
+	}
+
+	if _, err := c.DeleteObjects(context.Background(), testOpts(), DeleteObjectsInput{Bucket: "b", Keys: make([]string, MaxDeleteObjectsBatch+1)}); err == nil {
+		t.Error("expected error above the batch limit")
+	}
+}
+
+func TestClient_PresignObject_ProviderError(t *testing.T) {
+	c := &client{Provider: &mockProvider{presignErr: ErrEndpointValidation}}
+
+	_, err := c.PresignObject(context.Background(), testOpts(), PresignInput{Bucket: "b", Key: "k", Method: "GET", Expires: time.Minute})
+	if !errors.Is(err, ErrEndpointValidation) {
+		t.Errorf("error = %v, want ErrEndpointValidation", err)
+	}
+}
+
+func TestAttachmentDisposition(t *testing.T) {
+	if got := attachmentDisposition("data/report 1.csv"); got != `attachment; filename="report 1.csv"` {
+		t.Errorf("attachmentDisposition() = %q", got)
+	}
+}
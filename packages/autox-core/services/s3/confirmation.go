@@ -0,0 +1,45 @@
+package s3
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Prefix delete confirmation tokens have the form "<expiry unix seconds>.<digest>", where
+// the digest is a SHA-256 over the expiry, bucket, prefix and the key, size and ETag of
+// every object under the prefix. Tokens are not secrets: they confirm that the caller saw
+// the objects being deleted. They need no server-side state, so any BFF replica can
+// confirm a token, and a token stops matching as soon as an object under the prefix is
+// added, removed or replaced.
+
+func prefixDeleteToken(bucket, prefix string, objects []ObjectInfo, expiresAt time.Time) string {
+	h := sha256.New()
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	fmt.Fprintf(h, "%s\x00%s\x00%s\n", expiry, bucket, prefix)
+	for _, object := range objects {
+		fmt.Fprintf(h, "%s\x00%d\x00%s\n", object.Key, object.Size, object.ETag)
+	}
+	return expiry + "." + base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// parseConfirmationExpiry returns the expiry encoded in a confirmation token.
+func parseConfirmationExpiry(token string) (time.Time, error) {
+	expiry, digest, ok := strings.Cut(token, ".")
+	if !ok || digest == "" {
+		return time.Time{}, fmt.Errorf("%w: malformed token", ErrInvalidConfirmationToken)
+	}
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: malformed token", ErrInvalidConfirmationToken)
+	}
+	return time.Unix(unix, 0).UTC(), nil
+}
+
+func confirmationTokenMatches(got, want string) bool {
+	return subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
@@ -32,6 +32,30 @@ var (
 	// ErrInvalidKey is returned when an S3 object key contains prohibited sequences
 	// (null bytes, path traversal patterns, or control characters).
 	ErrInvalidKey = errors.New("invalid S3 object key")
+
+	// ErrUploadNotFound is returned when a multipart upload ID does not exist for the key,
+	// typically because the upload was already completed or aborted.
+	ErrUploadNotFound = errors.New("s3 multipart upload not found")
+
+	// ErrInvalidPart is returned when multipart upload parts are rejected: a part number
+	// outside 1-10000, duplicate or missing parts, an ETag that does not match, or a
+	// non-final part smaller than the S3 minimum part size.
+	ErrInvalidPart = errors.New("invalid s3 multipart upload part")
+
+	// ErrInvalidUploadID is returned when a multipart upload ID is empty or malformed.
+	ErrInvalidUploadID = errors.New("invalid S3 multipart upload ID")
+
+	// ErrInvalidConfirmationToken is returned by DeletePrefix when the confirmation token
+	// is malformed, expired, or no longer matches the objects under the prefix.
+	ErrInvalidConfirmationToken = errors.New("invalid or expired prefix delete confirmation token")
+
+	// ErrPrefixTooLarge is returned by DeletePrefix when more than MaxPrefixDeleteObjects
+	// objects exist under the prefix.
+	ErrPrefixTooLarge = errors.New("too many objects under s3 prefix")
+
+	// ErrInvalidPresignRequest is returned when a presigned URL is requested for an
+	// unsupported method or with an expiry outside 1s-7d.
+	ErrInvalidPresignRequest = errors.New("invalid S3 presign request")
 )
 
 // IsConnectivityError reports whether err is a pre-request network failure reaching the S3
@@ -1,6 +1,9 @@
 package s3
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // ObjectInfo represents a single S3 object in the listing response.
 type ObjectInfo struct {
@@ -100,3 +103,174 @@ type ResolveNonCollidingKeyInput struct {
 	Key         string
 	MaxAttempts int
 }
+
+// --- Multipart upload types ---
+
+const (
+	// MaxMultipartParts is the S3 limit on the number of parts in one multipart upload.
+	MaxMultipartParts = 10000
+	// MinMultipartPartSize is the S3 minimum size of every part except the last.
+	MinMultipartPartSize int64 = 5 << 20
+)
+
+// CreateMultipartUploadInput holds the parameters for starting a multipart upload.
+type CreateMultipartUploadInput struct {
+	Bucket      string
+	Key         string
+	ContentType string
+}
+
+// MultipartUpload identifies an in-progress multipart upload. The upload ID is only
+// valid together with the key it was created for.
+type MultipartUpload struct {
+	Key      string `json:"key"`
+	UploadID string `json:"upload_id"`
+}
+
+// UploadPartInput holds the parameters for uploading one part of a multipart upload.
+// Uploading the same part number again replaces the earlier part, so clients can retry
+// a part after a network failure.
+type UploadPartInput struct {
+	Bucket     string
+	Key        string
+	UploadID   string
+	PartNumber int32
+	// Body should be an io.ReadSeeker so the SDK can compute the part checksum and
+	// sign the payload on plain-HTTP endpoints.
+	Body          io.Reader
+	ContentLength int64
+}
+
+// UploadedPart describes a part stored for a multipart upload.
+type UploadedPart struct {
+	PartNumber    int32  `json:"part_number"`
+	ETag          string `json:"etag"`
+	Size          int64  `json:"size"`
+	ChecksumCRC32 string `json:"checksum_crc32,omitempty"`
+}
+
+// ListPartsInput holds the parameters for listing the parts of a multipart upload.
+type ListPartsInput struct {
+	Bucket   string
+	Key      string
+	UploadID string
+}
+
+// CompleteMultipartUploadInput holds the parameters for completing a multipart upload.
+// When Parts is empty, Service completes the upload with every part stored in S3.
+type CompleteMultipartUploadInput struct {
+	Bucket   string
+	Key      string
+	UploadID string
+	Parts    []UploadedPart
+}
+
+// AbortMultipartUploadInput holds the parameters for aborting a multipart upload.
+type AbortMultipartUploadInput struct {
+	Bucket   string
+	Key      string
+	UploadID string
+}
+
+// --- Object management types ---
+
+const (
+	// MaxCopyObjectSize is the largest object S3 copies in a single CopyObject request.
+	// Larger objects are copied part by part.
+	MaxCopyObjectSize int64 = 5 << 30
+	// MaxDeleteObjectsBatch is the S3 limit on keys per DeleteObjects request.
+	MaxDeleteObjectsBatch = 1000
+	// MaxPrefixDeleteObjects bounds how many objects one prefix delete may remove.
+	MaxPrefixDeleteObjects = 10000
+	// PrefixDeleteConfirmationTTL is how long a prefix delete confirmation token is valid.
+	PrefixDeleteConfirmationTTL = 5 * time.Minute
+	// DefaultPresignExpiry is used when a presign request does not set an expiry.
+	DefaultPresignExpiry = 15 * time.Minute
+	// MaxPresignExpiry is the SigV4 limit on presigned URL lifetime.
+	MaxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// CopyObjectInput holds the parameters for copying an object within a bucket.
+// Copies never replace an existing object at DestinationKey.
+type CopyObjectInput struct {
+	Bucket         string
+	SourceKey      string
+	DestinationKey string
+}
+
+// MoveObjectInput holds the parameters for renaming an object within a bucket.
+type MoveObjectInput struct {
+	Bucket         string
+	SourceKey      string
+	DestinationKey string
+}
+
+// DeleteObjectInput holds the parameters for deleting a single object.
+type DeleteObjectInput struct {
+	Bucket string
+	Key    string
+}
+
+// DeleteObjectsInput holds the parameters for a batch delete of at most
+// MaxDeleteObjectsBatch keys.
+type DeleteObjectsInput struct {
+	Bucket string
+	Keys   []string
+}
+
+// DeleteObjectError describes a key that S3 failed to delete in a batch delete.
+type DeleteObjectError struct {
+	Key     string `json:"key"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// DeleteObjectsResult is the outcome of a batch delete. S3 reports failures per key,
+// so a batch can partially succeed.
+type DeleteObjectsResult struct {
+	Deleted int                 `json:"deleted"`
+	Errors  []DeleteObjectError `json:"errors,omitempty"`
+}
+
+// DeletePrefixInput holds the parameters for deleting every object under a prefix.
+// Without a ConfirmationToken nothing is deleted: Service returns what would be deleted
+// together with a token that must be sent back to perform the delete.
+type DeletePrefixInput struct {
+	Bucket            string
+	Prefix            string
+	ConfirmationToken string
+}
+
+// DeletePrefixResult describes a prefix delete. When Deleted is false it is a preview:
+// ObjectCount and TotalSize describe the objects under the prefix and ConfirmationToken
+// confirms their deletion until ExpiresAt. The token only matches while the objects under
+// the prefix are unchanged.
+type DeletePrefixResult struct {
+	Prefix            string              `json:"prefix"`
+	ObjectCount       int                 `json:"object_count"`
+	TotalSize         int64               `json:"total_size"`
+	ConfirmationToken string              `json:"confirmation_token,omitempty"`
+	ExpiresAt         *time.Time          `json:"expires_at,omitempty"`
+	Deleted           bool                `json:"deleted"`
+	DeletedCount      int                 `json:"deleted_count"`
+	Errors            []DeleteObjectError `json:"errors,omitempty"`
+}
+
+// PresignInput holds the parameters for a presigned URL. Method is GET or PUT.
+// Presigned PUTs do not pin the Content-Type: the uploader chooses the one stored.
+type PresignInput struct {
+	Bucket  string
+	Key     string
+	Method  string
+	Expires time.Duration
+}
+
+// PresignedURL is a time-limited URL that grants Method on a single object without
+// further credentials. Headers lists the signed headers the caller must send with
+// the request.
+type PresignedURL struct {
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
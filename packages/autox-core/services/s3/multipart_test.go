@@ -0,0 +1,447 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // ETags only, matching S3
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// fakeS3Server is a local S3 stand-in implementing the multipart upload API and the
+// object operations used by this package (get, head, put, copy, delete, batch delete and
+// ListObjectsV2) for path-style requests (/<bucket>/<key>). It stores everything in memory,
+// keyed by "<bucket>/<key>".
+type fakeS3Server struct {
+	mu       sync.Mutex
+	nextID   int
+	uploads  map[string]*fakeUpload
+	objects  map[string][]byte
+	pageSize int
+}
+
+type fakeUpload struct {
+	key   string
+	parts map[int32][]byte
+}
+
+type fakeS3Part struct {
+	PartNumber int32
+	ETag       string
+	Size       int64
+}
+
+type fakeListPartsResult struct {
+	XMLName              xml.Name     `xml:"ListPartsResult"`
+	UploadId             string       //nolint:revive // S3 XML element name
+	IsTruncated          bool         `xml:"IsTruncated"`
+	NextPartNumberMarker int32        `xml:"NextPartNumberMarker,omitempty"`
+	Parts                []fakeS3Part `xml:"Part"`
+}
+
+type fakeCompleteRequest struct {
+	Parts []struct {
+		PartNumber int32
+		ETag       string
+	} `xml:"Part"`
+}
+
+func newFakeS3Server(t *testing.T) (*fakeS3Server, *httptest.Server) {
+	t.Helper()
+	f := &fakeS3Server{uploads: map[string]*fakeUpload{}, objects: map[string][]byte{}, pageSize: 1000}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func partETag(data []byte) string {
+	sum := md5.Sum(data) //nolint:gosec // ETags only, matching S3
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func writeS3XMLError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func (f *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	objectKey := strings.TrimPrefix(r.URL.Path, "/")
+	q := r.URL.Query()
+	uploadID := q.Get("uploadId")
+
+	switch {
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		f.nextID++
+		id := "upload-" + strconv.Itoa(f.nextID)
+		f.uploads[id] = &fakeUpload{key: objectKey, parts: map[int32][]byte{}}
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", id)
+
+	case uploadID != "":
+		upload, ok := f.uploads[uploadID]
+		if !ok || upload.key != objectKey {
+			writeS3XMLError(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		f.serveUpload(w, r, uploadID, upload)
+
+	case r.Method == http.MethodPost && q.Has("delete"):
+		f.serveDeleteObjects(w, r, objectKey)
+
+	case r.Method == http.MethodGet && q.Get("list-type") == "2":
+		f.serveListObjects(w, r, objectKey)
+
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		data, ok := f.objects[objectKey]
+		if !ok {
+			writeS3XMLError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("ETag", partETag(data))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+
+	case r.Method == http.MethodPut:
+		if r.Header.Get("If-None-Match") == "*" {
+			if _, exists := f.objects[objectKey]; exists {
+				writeS3XMLError(w, http.StatusPreconditionFailed, "PreconditionFailed")
+				return
+			}
+		}
+		if source := r.Header.Get("X-Amz-Copy-Source"); source != "" {
+			sourceKey, err := url.PathUnescape(source)
+			data, ok := f.objects[sourceKey]
+			if err != nil || !ok {
+				writeS3XMLError(w, http.StatusNotFound, "NoSuchKey")
+				return
+			}
+			f.objects[objectKey] = bytes.Clone(data)
+			w.Header().Set("Content-Type", "application/xml")
+			fmt.Fprintf(w, "<CopyObjectResult><ETag>%s</ETag></CopyObjectResult>", partETag(data))
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeS3XMLError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		f.objects[objectKey] = data
+		w.Header().Set("ETag", partETag(data))
+
+	case r.Method == http.MethodDelete:
+		delete(f.objects, objectKey)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeS3XMLError(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3Server) serveUpload(w http.ResponseWriter, r *http.Request, uploadID string, upload *fakeUpload) {
+	q := r.URL.Query()
+	switch r.Method {
+	case http.MethodPut:
+		n, err := strconv.ParseInt(q.Get("partNumber"), 10, 32)
+		if err != nil {
+			writeS3XMLError(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeS3XMLError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		upload.parts[int32(n)] = data
+		w.Header().Set("ETag", partETag(data))
+
+	case http.MethodGet:
+		marker, _ := strconv.ParseInt(q.Get("part-number-marker"), 10, 32)
+		numbers := make([]int32, 0, len(upload.parts))
+		for n := range upload.parts {
+			if n > int32(marker) {
+				numbers = append(numbers, n)
+			}
+		}
+		sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+		result := fakeListPartsResult{UploadId: uploadID}
+		if len(numbers) > f.pageSize {
+			numbers = numbers[:f.pageSize]
+			result.IsTruncated = true
+			result.NextPartNumberMarker = numbers[len(numbers)-1]
+		}
+		for _, n := range numbers {
+			result.Parts = append(result.Parts, fakeS3Part{PartNumber: n, ETag: partETag(upload.parts[n]), Size: int64(len(upload.parts[n]))})
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(result)
+
+	case http.MethodPost:
+		var req fakeCompleteRequest
+		if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeS3XMLError(w, http.StatusBadRequest, "MalformedXML")
+			return
+		}
+		if r.Header.Get("If-None-Match") == "*" {
+			if _, exists := f.objects[upload.key]; exists {
+				writeS3XMLError(w, http.StatusPreconditionFailed, "PreconditionFailed")
+				return
+			}
+		}
+		var object []byte
+		for i, part := range req.Parts {
+			data, ok := upload.parts[part.PartNumber]
+			if !ok || partETag(data) != part.ETag {
+				writeS3XMLError(w, http.StatusBadRequest, "InvalidPart")
+				return
+			}
+			if i > 0 && req.Parts[i-1].PartNumber >= part.PartNumber {
+				writeS3XMLError(w, http.StatusBadRequest, "InvalidPartOrder")
+				return
+			}
+			object = append(object, data...)
+		}
+		f.objects[upload.key] = object
+		delete(f.uploads, uploadID)
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+
+	case http.MethodDelete:
+		delete(f.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeS3XMLError(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+type fakeDeleteRequest struct {
+	Objects []struct {
+		Key string
+	} `xml:"Object"`
+}
+
+func (f *fakeS3Server) serveDeleteObjects(w http.ResponseWriter, r *http.Request, bucket string) {
+	var req fakeDeleteRequest
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeS3XMLError(w, http.StatusBadRequest, "MalformedXML")
+		return
+	}
+	for _, object := range req.Objects {
+		delete(f.objects, bucket+"/"+object.Key)
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprint(w, "<DeleteResult></DeleteResult>")
+}
+
+type fakeS3Object struct {
+	Key  string
+	Size int64
+	ETag string
+}
+
+type fakeListObjectsResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	KeyCount              int            `xml:"KeyCount"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	Contents              []fakeS3Object `xml:"Contents"`
+}
+
+// serveListObjects lists keys in order without delimiter support. Continuation tokens
+// are the last key of the previous page.
+func (f *fakeS3Server) serveListObjects(w http.ResponseWriter, r *http.Request, bucket string) {
+	q := r.URL.Query()
+	prefix := q.Get("prefix")
+	after := q.Get("continuation-token")
+	var keys []string
+	for stored := range f.objects {
+		key, ok := strings.CutPrefix(stored, bucket+"/")
+		if ok && strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	result := fakeListObjectsResult{Name: bucket, Prefix: prefix}
+	if len(keys) > f.pageSize {
+		keys = keys[:f.pageSize]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, key := range keys {
+		data := f.objects[bucket+"/"+key]
+		result.Contents = append(result.Contents, fakeS3Object{Key: key, Size: int64(len(data)), ETag: partETag(data)})
+	}
+	result.KeyCount = len(result.Contents)
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+// staticAPIProvider hands out an SDK client pointed at the stand-in, bypassing the
+// SSRF-validating provider which rejects loopback endpoints.
+type staticAPIProvider struct {
+	api     APIClient
+	presign PresignClient
+}
+
+func (p *staticAPIProvider) CreateAPIClient(ConnectionOptions) (APIClient, error) {
+	return p.api, nil
+}
+func (p *staticAPIProvider) CreateTransferClient(ConnectionOptions) (TransferClient, error) {
+	return nil, errors.New("transfer client not supported by the S3 stand-in")
+}
+func (p *staticAPIProvider) CreatePresignClient(ConnectionOptions) (PresignClient, error) {
+	return p.presign, nil
+}
+
+func newStandInService(t *testing.T) (*fakeS3Server, Service) {
+	t.Helper()
+	fake, srv := newFakeS3Server(t)
+	api := awss3.NewFromConfig(aws.Config{
+		Region:           "us-east-1",
+		Credentials:      credentials.NewStaticCredentialsProvider("AK", "SK", ""),
+		RetryMaxAttempts: 1,
+	}, func(o *awss3.Options) {
+		o.BaseEndpoint = aws.String(srv.URL)
+		o.UsePathStyle = true
+	})
+	c := &client{Provider: &staticAPIProvider{api: api, presign: awss3.NewPresignClient(api)}}
+	return fake, NewService(ServiceConfig{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}, c)
+}
+
+func uploadTestPart(t *testing.T, svc Service, upload *MultipartUpload, n int32, data []byte) *UploadedPart {
+	t.Helper()
+	part, err := svc.UploadPart(context.Background(), testOpts(), UploadPartInput{
+		Bucket: "bucket", Key: upload.Key, UploadID: upload.UploadID,
+		PartNumber: n, Body: bytes.NewReader(data), ContentLength: int64(len(data)),
+	})
+	if err != nil {
+		t.Fatalf("UploadPart(%d) error = %v", n, err)
+	}
+	return part
+}
+
+func TestMultipartUpload_StandIn_ParallelParts(t *testing.T) {
+	fake, svc := newStandInService(t)
+	ctx := context.Background()
+
+	upload, err := svc.CreateMultipartUpload(ctx, testOpts(), CreateMultipartUploadInput{Bucket: "bucket", Key: "data/train.csv", ContentType: "text/csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunks := [][]byte{[]byte("a,b\n"), []byte("1,2\n"), []byte("3,4\n")}
+	parts := make([]UploadedPart, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parts[i] = *uploadTestPart(t, svc, upload, int32(i+1), chunk)
+		}()
+	}
+	wg.Wait()
+
+	// Parts may be reported in any order; the service sorts them for S3.
+	parts[0], parts[2] = parts[2], parts[0]
+	if err := svc.CompleteMultipartUpload(ctx, testOpts(), CompleteMultipartUploadInput{Bucket: "bucket", Key: upload.Key, UploadID: upload.UploadID, Parts: parts}); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(fake.objects["bucket/data/train.csv"]); got != "a,b\n1,2\n3,4\n" {
+		t.Errorf("object = %q", got)
+	}
+}
+
+func TestMultipartUpload_StandIn_ResumeFromStoredParts(t *testing.T) {
+	fake, svc := newStandInService(t)
+	fake.pageSize = 1 // exercise ListParts pagination
+	ctx := context.Background()
+
+	upload, err := svc.CreateMultipartUpload(ctx, testOpts(), CreateMultipartUploadInput{Bucket: "bucket", Key: "big.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadTestPart(t, svc, upload, 2, []byte("second"))
+
+	// After a network drop the client asks which parts are stored and uploads the rest.
+	stored, err := svc.ListParts(ctx, testOpts(), ListPartsInput{Bucket: "bucket", Key: upload.Key, UploadID: upload.UploadID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || stored[0].PartNumber != 2 || stored[0].Size != 6 {
+		t.Fatalf("stored parts = %+v", stored)
+	}
+	uploadTestPart(t, svc, upload, 1, []byte("first-"))
+
+	if err := svc.CompleteMultipartUpload(ctx, testOpts(), CompleteMultipartUploadInput{Bucket: "bucket", Key: upload.Key, UploadID: upload.UploadID}); err != nil {
+		t.Fatal(err)
+	}
+	body, _, err := svc.GetObject(ctx, testOpts(), GetObjectInput{Bucket: "bucket", Key: "big.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	if data, _ := io.ReadAll(body); string(data) != "first-second" {
+		t.Errorf("object = %q", data)
+	}
+}
+
+func TestMultipartUpload_StandIn_ExistingObjectConflict(t *testing.T) {
+	fake, svc := newStandInService(t)
+	ctx := context.Background()
+
+	upload, err := svc.CreateMultipartUpload(ctx, testOpts(), CreateMultipartUploadInput{Bucket: "bucket", Key: "k.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadTestPart(t, svc, upload, 1, []byte("new"))
+	fake.objects["bucket/k.csv"] = []byte("written meanwhile")
+
+	err = svc.CompleteMultipartUpload(ctx, testOpts(), CompleteMultipartUploadInput{Bucket: "bucket", Key: upload.Key, UploadID: upload.UploadID})
+	if !errors.Is(err, ErrObjectAlreadyExists) {
+		t.Errorf("error = %v, want ErrObjectAlreadyExists", err)
+	}
+}
+
+func TestMultipartUpload_StandIn_Abort(t *testing.T) {
+	_, svc := newStandInService(t)
+	ctx := context.Background()
+
+	upload, err := svc.CreateMultipartUpload(ctx, testOpts(), CreateMultipartUploadInput{Bucket: "bucket", Key: "k.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadTestPart(t, svc, upload, 1, []byte("data"))
+
+	if err := svc.AbortMultipartUpload(ctx, testOpts(), AbortMultipartUploadInput{Bucket: "bucket", Key: upload.Key, UploadID: upload.UploadID}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.ListParts(ctx, testOpts(), ListPartsInput{Bucket: "bucket", Key: upload.Key, UploadID: upload.UploadID})
+	if !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("error = %v, want ErrUploadNotFound", err)
+	}
+}
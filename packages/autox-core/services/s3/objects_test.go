@@ -0,0 +1,167 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObjectManagement_StandIn_CopyAndMove(t *testing.T) {
+	fake, svc := newStandInService(t)
+	ctx := context.Background()
+	fake.objects["bucket/data/my file.csv"] = []byte("a,b\n")
+
+	if err := svc.CopyObject(ctx, testOpts(), CopyObjectInput{Bucket: "bucket", SourceKey: "data/my file.csv", DestinationKey: "data/copy.csv"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(fake.objects["bucket/data/copy.csv"]); got != "a,b\n" {
+		t.Errorf("copy = %q", got)
+	}
+
+	err := svc.CopyObject(ctx, testOpts(), CopyObjectInput{Bucket: "bucket", SourceKey: "data/my file.csv", DestinationKey: "data/copy.csv"})
+	if !errors.Is(err, ErrObjectAlreadyExists) {
+		t.Errorf("second copy error = %v, want ErrObjectAlreadyExists", err)
+	}
+
+	if err := svc.MoveObject(ctx, testOpts(), MoveObjectInput{Bucket: "bucket", SourceKey: "data/my file.csv", DestinationKey: "archive/my file.csv"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fake.objects["bucket/data/my file.csv"]; ok {
+		t.Error("source still exists after move")
+	}
+	if got := string(fake.objects["bucket/archive/my file.csv"]); got != "a,b\n" {
+		t.Errorf("moved object = %q", got)
+	}
+
+	err = svc.CopyObject(ctx, testOpts(), CopyObjectInput{Bucket: "bucket", SourceKey: "missing.csv", DestinationKey: "other.csv"})
+	if !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("missing source error = %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestObjectManagement_StandIn_DeleteObject(t *testing.T) {
+	fake, svc := newStandInService(t)
+	ctx := context.Background()
+	fake.objects["bucket/k.csv"] = []byte("x")
+
+	if err := svc.DeleteObject(ctx, testOpts(), DeleteObjectInput{Bucket: "bucket", Key: "k.csv"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fake.objects["bucket/k.csv"]; ok {
+		t.Error("object still exists after delete")
+	}
+	if err := svc.DeleteObject(ctx, testOpts(), DeleteObjectInput{Bucket: "bucket", Key: "k.csv"}); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("second delete error = %v, want ErrObjectNotFound", err)
+	}
+}
+
+func TestObjectManagement_StandIn_DeletePrefix(t *testing.T) {
+	fake, svc := newStandInService(t)
+	fake.pageSize = 2 // exercise ListObjectsV2 pagination
+	ctx := context.Background()
+	for _, key := range []string{"data/train/a.csv", "data/train/b.csv", "data/train/nested/c.csv"} {
+		fake.objects["bucket/"+key] = []byte("12345")
+	}
+	fake.objects["bucket/data/train-2.csv"] = []byte("keep")
+
+	preview, err := svc.DeletePrefix(ctx, testOpts(), DeletePrefixInput{Bucket: "bucket", Prefix: "data/train/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Deleted || preview.ObjectCount != 3 || preview.TotalSize != 15 || preview.ConfirmationToken == "" {
+		t.Fatalf("preview = %+v", preview)
+	}
+	if len(fake.objects) != 4 {
+		t.Fatal("preview deleted objects")
+	}
+
+	result, err := svc.DeletePrefix(ctx, testOpts(), DeletePrefixInput{Bucket: "bucket", Prefix: "data/train/", ConfirmationToken: preview.ConfirmationToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Deleted || result.DeletedCount != 3 {
+		t.Errorf("result = %+v", result)
+	}
+	if len(fake.objects) != 1 || fake.objects["bucket/data/train-2.csv"] == nil {
+		t.Errorf("remaining objects = %v", fake.objects)
+	}
+}
+
+func TestObjectManagement_StandIn_DeletePrefixStaleToken(t *testing.T) {
+	fake, svc := newStandInService(t)
+	ctx := context.Background()
+	fake.objects["bucket/data/a.csv"] = []byte("a")
+
+	preview, err := svc.DeletePrefix(ctx, testOpts(), DeletePrefixInput{Bucket: "bucket", Prefix: "data/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.objects["bucket/data/added-after-preview.csv"] = []byte("b")
+
+	_, err = svc.DeletePrefix(ctx, testOpts(), DeletePrefixInput{Bucket: "bucket", Prefix: "data/", ConfirmationToken: preview.ConfirmationToken})
+	if !errors.Is(err, ErrInvalidConfirmationToken) {
+		t.Fatalf("error = %v, want ErrInvalidConfirmationToken", err)
+	}
+	if len(fake.objects) != 2 {
+		t.Errorf("objects deleted with a stale token: %v", fake.objects)
+	}
+}
+
+func TestObjectManagement_StandIn_PresignedURLs(t *testing.T) {
+	fake, svc := newStandInService(t)
+	ctx := context.Background()
+
+	put, err := svc.PresignObject(ctx, testOpts(), PresignInput{Bucket: "bucket", Key: "data/up.csv", Method: http.MethodPut})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if put.Headers["If-None-Match"] != "*" {
+		t.Errorf("signed headers = %v", put.Headers)
+	}
+	if until := time.Until(put.ExpiresAt); until <= 14*time.Minute || until > DefaultPresignExpiry {
+		t.Errorf("ExpiresAt in %s, want the default expiry", until)
+	}
+
+	doPresigned := func(p *PresignedURL, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(p.Method, p.URL, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, value := range p.Headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	if resp := doPresigned(put, "a,b\n"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("presigned PUT status = %d", resp.StatusCode)
+	}
+	if resp := doPresigned(put, "replaced"); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("second presigned PUT status = %d, want 412", resp.StatusCode)
+	}
+	if got := string(fake.objects["bucket/data/up.csv"]); got != "a,b\n" {
+		t.Errorf("object = %q", got)
+	}
+
+	get, err := svc.PresignObject(ctx, testOpts(), PresignInput{Bucket: "bucket", Key: "data/up.csv", Method: http.MethodGet, Expires: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(get.URL, "response-content-disposition=attachment") {
+		t.Errorf("GET URL %q does not force a download", get.URL)
+	}
+	resp := doPresigned(get, "")
+	if data, _ := io.ReadAll(resp.Body); string(data) != "a,b\n" {
+		t.Errorf("presigned GET body = %q", data)
+	}
+}
@@ -1,14 +1,18 @@
 package s3
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"path"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var trailingNumberSuffixPattern = regexp.MustCompile(`^(.*)-(\d+)$`)
@@ -22,6 +26,16 @@ type Service interface {
 	ListObjects(ctx context.Context, opts ConnectionOptions, query ListObjectsQuery) (*ListObjectsResponse, error)
 	ObjectExists(ctx context.Context, opts ConnectionOptions, input ObjectExistsInput) (bool, error)
 	ResolveNonCollidingKey(ctx context.Context, opts ConnectionOptions, input ResolveNonCollidingKeyInput) (string, error)
+	CreateMultipartUpload(ctx context.Context, opts ConnectionOptions, input CreateMultipartUploadInput) (*MultipartUpload, error)
+	UploadPart(ctx context.Context, opts ConnectionOptions, input UploadPartInput) (*UploadedPart, error)
+	ListParts(ctx context.Context, opts ConnectionOptions, input ListPartsInput) ([]UploadedPart, error)
+	CompleteMultipartUpload(ctx context.Context, opts ConnectionOptions, input CompleteMultipartUploadInput) error
+	AbortMultipartUpload(ctx context.Context, opts ConnectionOptions, input AbortMultipartUploadInput) error
+	CopyObject(ctx context.Context, opts ConnectionOptions, input CopyObjectInput) error
+	MoveObject(ctx context.Context, opts ConnectionOptions, input MoveObjectInput) error
+	DeleteObject(ctx context.Context, opts ConnectionOptions, input DeleteObjectInput) error
+	DeletePrefix(ctx context.Context, opts ConnectionOptions, input DeletePrefixInput) (*DeletePrefixResult, error)
+	PresignObject(ctx context.Context, opts ConnectionOptions, input PresignInput) (*PresignedURL, error)
 }
 
 // Client defines the contract for S3 operations.
@@ -36,6 +50,21 @@ type Client interface {
 	UploadObject(ctx context.Context, opts ConnectionOptions, input UploadObjectInput) error
 	ListObjects(ctx context.Context, opts ConnectionOptions, input ListObjectsInput) (*ListObjectsResponse, error)
 	ObjectExists(ctx context.Context, opts ConnectionOptions, input ObjectExistsInput) (bool, error)
+	// Multipart upload operations use the raw S3 SDK client so that uploads can span
+	// several requests and be resumed: parts are uploaded independently and the upload
+	// is completed once every part is stored.
+	CreateMultipartUpload(ctx context.Context, opts ConnectionOptions, input CreateMultipartUploadInput) (*MultipartUpload, error)
+	UploadPart(ctx context.Context, opts ConnectionOptions, input UploadPartInput) (*UploadedPart, error)
+	ListParts(ctx context.Context, opts ConnectionOptions, input ListPartsInput) ([]UploadedPart, error)
+	CompleteMultipartUpload(ctx context.Context, opts ConnectionOptions, input CompleteMultipartUploadInput) error
+	AbortMultipartUpload(ctx context.Context, opts ConnectionOptions, input AbortMultipartUploadInput) error
+	// Object management operations use the raw S3 SDK client. Copies and deletes run
+	// inside S3 without streaming object data through the caller.
+	CopyObject(ctx context.Context, opts ConnectionOptions, input CopyObjectInput) error
+	DeleteObject(ctx context.Context, opts ConnectionOptions, input DeleteObjectInput) error
+	DeleteObjects(ctx context.Context, opts ConnectionOptions, input DeleteObjectsInput) (*DeleteObjectsResult, error)
+	// PresignObject signs a time-limited GET or PUT URL for one object.
+	PresignObject(ctx context.Context, opts ConnectionOptions, input PresignInput) (*PresignedURL, error)
 }
 
 // ServiceConfig holds configuration for creating a Service.
@@ -46,6 +75,7 @@ type ServiceConfig struct {
 type service struct {
 	Client Client
 	Logger *slog.Logger
+	now    func() time.Time
 }
 
 // Compile-time interface check.
@@ -55,9 +85,17 @@ func NewService(cfg ServiceConfig, client Client) Service {
 	return &service{
 		Client: client,
 		Logger: cfg.Logger,
+		now:    time.Now,
 	}
 }
 
+func (s *service) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
 // GetObject uses the raw S3 SDK client. Supports the optional Range field in
 // GetObjectInput for efficient partial reads (e.g. CSV schema inspection).
 // The caller is responsible for closing the returned body.
@@ -193,6 +231,327 @@ func (s *service) ResolveNonCollidingKey(ctx context.Context, opts ConnectionOpt
 	return "", fmt.Errorf("%w: %d attempts", ErrMaxCollisionsExceeded, input.MaxAttempts)
 }
 
+// CreateMultipartUpload starts a multipart upload for the given key. The returned upload
+// ID must be passed to every later call for the same upload.
+func (s *service) CreateMultipartUpload(ctx context.Context, opts ConnectionOptions, input CreateMultipartUploadInput) (*MultipartUpload, error) {
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+
+	s.Logger.Info("creating S3 multipart upload", "bucket", input.Bucket, "key", input.Key)
+
+	upload, err := s.Client.CreateMultipartUpload(ctx, opts, input)
+	if err != nil {
+		s.Logger.Error("failed to create S3 multipart upload", "bucket", input.Bucket, "key", input.Key, "error", err)
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+// UploadPart uploads one part of a multipart upload. Returns ErrInvalidPart when the
+// part number is out of range or the part is empty.
+func (s *service) UploadPart(ctx context.Context, opts ConnectionOptions, input UploadPartInput) (*UploadedPart, error) {
+	if err := validateMultipartTarget(input.Key, input.UploadID); err != nil {
+		return nil, err
+	}
+	if err := validatePartNumber(input.PartNumber); err != nil {
+		return nil, err
+	}
+	if input.ContentLength <= 0 {
+		return nil, fmt.Errorf("%w: part %d is empty", ErrInvalidPart, input.PartNumber)
+	}
+
+	s.Logger.Info("uploading S3 multipart upload part", "bucket", input.Bucket, "key", input.Key, "partNumber", input.PartNumber)
+
+	part, err := s.Client.UploadPart(ctx, opts, input)
+	if err != nil {
+		s.Logger.Error("failed to upload S3 multipart upload part", "bucket", input.Bucket, "key", input.Key, "partNumber", input.PartNumber, "error", err)
+		return nil, err
+	}
+
+	return part, nil
+}
+
+// ListParts returns the parts stored for a multipart upload in part-number order.
+// Clients use it to find which parts still need uploading after an interruption.
+func (s *service) ListParts(ctx context.Context, opts ConnectionOptions, input ListPartsInput) ([]UploadedPart, error) {
+	if err := validateMultipartTarget(input.Key, input.UploadID); err != nil {
+		return nil, err
+	}
+
+	s.Logger.Info("listing S3 multipart upload parts", "bucket", input.Bucket, "key", input.Key)
+
+	parts, err := s.Client.ListParts(ctx, opts, input)
+	if err != nil {
+		s.Logger.Error("failed to list S3 multipart upload parts", "bucket", input.Bucket, "key", input.Key, "error", err)
+		return nil, err
+	}
+
+	return parts, nil
+}
+
+// CompleteMultipartUpload assembles the object from the given parts. When input.Parts is
+// empty, every part stored in S3 is used, so clients that lost their part list can still
+// complete a resumed upload. Returns ErrObjectAlreadyExists if an object was written at the
+// key while the upload was in progress.
+func (s *service) CompleteMultipartUpload(ctx context.Context, opts ConnectionOptions, input CompleteMultipartUploadInput) error {
+	if err := validateMultipartTarget(input.Key, input.UploadID); err != nil {
+		return err
+	}
+
+	parts := input.Parts
+	if len(parts) == 0 {
+		stored, err := s.ListParts(ctx, opts, ListPartsInput{Bucket: input.Bucket, Key: input.Key, UploadID: input.UploadID})
+		if err != nil {
+			return err
+		}
+		parts = stored
+	}
+	parts, err := sortCompletedParts(parts)
+	if err != nil {
+		return err
+	}
+	input.Parts = parts
+
+	s.Logger.Info("completing S3 multipart upload", "bucket", input.Bucket, "key", input.Key, "parts", len(parts))
+
+	if err := s.Client.CompleteMultipartUpload(ctx, opts, input); err != nil {
+		s.Logger.Error("failed to complete S3 multipart upload", "bucket", input.Bucket, "key", input.Key, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// AbortMultipartUpload discards a multipart upload and every part stored for it.
+func (s *service) AbortMultipartUpload(ctx context.Context, opts ConnectionOptions, input AbortMultipartUploadInput) error {
+	if err := validateMultipartTarget(input.Key, input.UploadID); err != nil {
+		return err
+	}
+
+	s.Logger.Info("aborting S3 multipart upload", "bucket", input.Bucket, "key", input.Key)
+
+	if err := s.Client.AbortMultipartUpload(ctx, opts, input); err != nil {
+		s.Logger.Error("failed to abort S3 multipart upload", "bucket", input.Bucket, "key", input.Key, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// CopyObject copies an object to a new key in the same bucket.
+// Returns ErrObjectAlreadyExists if an object already exists at the destination.
+func (s *service) CopyObject(ctx context.Context, opts ConnectionOptions, input CopyObjectInput) error {
+	if err := validateCopyTarget(input.SourceKey, input.DestinationKey); err != nil {
+		return err
+	}
+
+	s.Logger.Info("copying S3 object", "bucket", input.Bucket, "sourceKey", input.SourceKey, "destinationKey", input.DestinationKey)
+
+	if err := s.Client.CopyObject(ctx, opts, input); err != nil {
+		s.Logger.Error("failed to copy S3 object", "bucket", input.Bucket, "sourceKey", input.SourceKey, "destinationKey", input.DestinationKey, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// MoveObject renames an object by copying it to the destination and deleting the source.
+// S3 has no atomic rename: if the source cannot be deleted after the copy, the object
+// exists under both keys and the returned error says so.
+func (s *service) MoveObject(ctx context.Context, opts ConnectionOptions, input MoveObjectInput) error {
+	if err := validateCopyTarget(input.SourceKey, input.DestinationKey); err != nil {
+		return err
+	}
+
+	s.Logger.Info("moving S3 object", "bucket", input.Bucket, "sourceKey", input.SourceKey, "destinationKey", input.DestinationKey)
+
+	if err := s.Client.CopyObject(ctx, opts, CopyObjectInput(input)); err != nil {
+		s.Logger.Error("failed to move S3 object", "bucket", input.Bucket, "sourceKey", input.SourceKey, "destinationKey", input.DestinationKey, "error", err)
+		return err
+	}
+	if err := s.Client.DeleteObject(ctx, opts, DeleteObjectInput{Bucket: input.Bucket, Key: input.SourceKey}); err != nil {
+		s.Logger.Error("failed to delete S3 object after copying it", "bucket", input.Bucket, "sourceKey", input.SourceKey, "destinationKey", input.DestinationKey, "error", err)
+		return fmt.Errorf("object copied to %q but source not deleted: %w", input.DestinationKey, err)
+	}
+
+	return nil
+}
+
+// DeleteObject deletes a single object. Returns ErrObjectNotFound if the key does not
+// exist, which S3 itself does not report.
+func (s *service) DeleteObject(ctx context.Context, opts ConnectionOptions, input DeleteObjectInput) error {
+	if err := validateKey(input.Key); err != nil {
+		return err
+	}
+
+	exists, err := s.Client.ObjectExists(ctx, opts, ObjectExistsInput(input))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrObjectNotFound
+	}
+
+	s.Logger.Info("deleting S3 object", "bucket", input.Bucket, "key", input.Key)
+
+	if err := s.Client.DeleteObject(ctx, opts, input); err != nil {
+		s.Logger.Error("failed to delete S3 object", "bucket", input.Bucket, "key", input.Key, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeletePrefix deletes every object under a prefix in two steps. Called without a
+// confirmation token it deletes nothing and returns the number and total size of the
+// objects together with a token. Called again with that token before it expires, it
+// deletes the objects, provided they are unchanged. Returns ErrInvalidConfirmationToken
+// for expired or stale tokens and ErrPrefixTooLarge above MaxPrefixDeleteObjects objects.
+func (s *service) DeletePrefix(ctx context.Context, opts ConnectionOptions, input DeletePrefixInput) (*DeletePrefixResult, error) {
+	if err := validatePrefix(input.Prefix); err != nil {
+		return nil, err
+	}
+
+	confirming := input.ConfirmationToken != ""
+	var expiresAt time.Time
+	if confirming {
+		var err error
+		expiresAt, err = parseConfirmationExpiry(input.ConfirmationToken)
+		if err != nil {
+			return nil, err
+		}
+		if !s.clock().Before(expiresAt) {
+			return nil, fmt.Errorf("%w: token expired", ErrInvalidConfirmationToken)
+		}
+	}
+
+	objects, err := s.listAllObjects(ctx, opts, input.Bucket, input.Prefix)
+	if err != nil {
+		s.Logger.Error("failed to list S3 objects for prefix delete", "bucket", input.Bucket, "prefix", input.Prefix, "error", err)
+		return nil, err
+	}
+
+	result := &DeletePrefixResult{Prefix: input.Prefix, ObjectCount: len(objects)}
+	for _, object := range objects {
+		result.TotalSize += object.Size
+	}
+
+	if !confirming {
+		expiresAt = s.clock().Add(PrefixDeleteConfirmationTTL).UTC().Truncate(time.Second)
+		result.ConfirmationToken = prefixDeleteToken(input.Bucket, input.Prefix, objects, expiresAt)
+		result.ExpiresAt = &expiresAt
+		return result, nil
+	}
+
+	if !confirmationTokenMatches(input.ConfirmationToken, prefixDeleteToken(input.Bucket, input.Prefix, objects, expiresAt)) {
+		return nil, fmt.Errorf("%w: objects under the prefix changed", ErrInvalidConfirmationToken)
+	}
+
+	s.Logger.Info("deleting S3 prefix", "bucket", input.Bucket, "prefix", input.Prefix, "objects", len(objects))
+
+	keys := make([]string, 0, len(objects))
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	for batch := range slices.Chunk(keys, MaxDeleteObjectsBatch) {
+		deleted, err := s.Client.DeleteObjects(ctx, opts, DeleteObjectsInput{Bucket: input.Bucket, Keys: batch})
+		if err != nil {
+			s.Logger.Error("failed to delete S3 prefix", "bucket", input.Bucket, "prefix", input.Prefix, "deleted", result.DeletedCount, "error", err)
+			return nil, err
+		}
+		result.DeletedCount += deleted.Deleted
+		result.Errors = append(result.Errors, deleted.Errors...)
+	}
+	result.Deleted = true
+
+	return result, nil
+}
+
+// listAllObjects lists every object under prefix in key order, following pagination.
+func (s *service) listAllObjects(ctx context.Context, opts ConnectionOptions, bucket, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	next := ""
+	for {
+		page, err := s.Client.ListObjects(ctx, opts, ListObjectsInput{
+			Bucket:            bucket,
+			Prefix:            prefix,
+			Limit:             MaxDeleteObjectsBatch,
+			ContinuationToken: next,
+		})
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Contents...)
+		if len(objects) > MaxPrefixDeleteObjects {
+			return nil, fmt.Errorf("%w: more than %d objects under %q", ErrPrefixTooLarge, MaxPrefixDeleteObjects, prefix)
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" || page.NextContinuationToken == next {
+			break
+		}
+		next = page.NextContinuationToken
+	}
+	slices.SortFunc(objects, func(a, b ObjectInfo) int { return cmp.Compare(a.Key, b.Key) })
+	return objects, nil
+}
+
+// PresignObject returns a time-limited GET or PUT URL for one object. Expires defaults
+// to DefaultPresignExpiry and may not exceed MaxPresignExpiry.
+func (s *service) PresignObject(ctx context.Context, opts ConnectionOptions, input PresignInput) (*PresignedURL, error) {
+	if err := validateKey(input.Key); err != nil {
+		return nil, err
+	}
+	if input.Method != http.MethodGet && input.Method != http.MethodPut {
+		return nil, fmt.Errorf("%w: method must be GET or PUT", ErrInvalidPresignRequest)
+	}
+	if input.Expires == 0 {
+		input.Expires = DefaultPresignExpiry
+	}
+	if input.Expires < time.Second || input.Expires > MaxPresignExpiry {
+		return nil, fmt.Errorf("%w: expiry must be between 1s and %s", ErrInvalidPresignRequest, MaxPresignExpiry)
+	}
+
+	s.Logger.Info("presigning S3 object URL", "bucket", input.Bucket, "key", input.Key, "method", input.Method, "expires", input.Expires)
+
+	presigned, err := s.Client.PresignObject(ctx, opts, input)
+	if err != nil {
+		s.Logger.Error("failed to presign S3 object URL", "bucket", input.Bucket, "key", input.Key, "method", input.Method, "error", err)
+		return nil, err
+	}
+
+	return presigned, nil
+}
+
+func validateMultipartTarget(key, uploadID string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return validateUploadID(uploadID)
+}
+
+// sortCompletedParts returns a copy of parts in ascending part-number order, as S3
+// requires, rejecting empty lists, out-of-range or duplicate part numbers and missing ETags.
+func sortCompletedParts(parts []UploadedPart) ([]UploadedPart, error) {
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("%w: upload has no parts", ErrInvalidPart)
+	}
+	sorted := slices.Clone(parts)
+	slices.SortFunc(sorted, func(a, b UploadedPart) int { return cmp.Compare(a.PartNumber, b.PartNumber) })
+	for i, part := range sorted {
+		if err := validatePartNumber(part.PartNumber); err != nil {
+			return nil, err
+		}
+		if part.ETag == "" {
+			return nil, fmt.Errorf("%w: part %d has no etag", ErrInvalidPart, part.PartNumber)
+		}
+		if i > 0 && sorted[i-1].PartNumber == part.PartNumber {
+			return nil, fmt.Errorf("%w: duplicate part number %d", ErrInvalidPart, part.PartNumber)
+		}
+	}
+	return sorted, nil
+}
+
 func splitS3ObjectPath(key string) (dir, name string) {
 	i := strings.LastIndex(key, "/")
 	if i == -1 {
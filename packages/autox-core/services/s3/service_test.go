@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockS3Client implements Client for service tests.
@@ -17,6 +18,15 @@ type mockS3Client struct {
 	uploadObjectFn   func(ctx context.Context, opts ConnectionOptions, input UploadObjectInput) error
 	listObjectsFn    func(ctx context.Context, opts ConnectionOptions, input ListObjectsInput) (*ListObjectsResponse, error)
 	objectExistsFn   func(ctx context.Context, opts ConnectionOptions, input ObjectExistsInput) (bool, error)
+	createMPUFn      func(ctx context.Context, opts ConnectionOptions, input CreateMultipartUploadInput) (*MultipartUpload, error)
+	uploadPartFn     func(ctx context.Context, opts ConnectionOptions, input UploadPartInput) (*UploadedPart, error)
+	listPartsFn      func(ctx context.Context, opts ConnectionOptions, input ListPartsInput) ([]UploadedPart, error)
+	completeMPUFn    func(ctx context.Context, opts ConnectionOptions, input CompleteMultipartUploadInput) error
+	abortMPUFn       func(ctx context.Context, opts ConnectionOptions, input AbortMultipartUploadInput) error
+	copyObjectFn     func(ctx context.Context, opts ConnectionOptions, input CopyObjectInput) error
+	deleteObjectFn   func(ctx context.Context, opts ConnectionOptions, input DeleteObjectInput) error
+	deleteObjectsFn  func(ctx context.Context, opts ConnectionOptions, input DeleteObjectsInput) (*DeleteObjectsResult, error)
+	presignObjectFn  func(ctx context.Context, opts ConnectionOptions, input PresignInput) (*PresignedURL, error)
 }
 
 func (m *mockS3Client) GetObject(ctx context.Context, opts ConnectionOptions, input GetObjectInput) (io.ReadCloser, string, error) {
@@ -34,6 +44,33 @@ func (m *mockS3Client) ListObjects(ctx context.Context, opts ConnectionOptions,
 func (m *mockS3Client) ObjectExists(ctx context.Context, opts ConnectionOptions, input ObjectExistsInput) (bool, error) {
 	return m.objectExistsFn(ctx, opts, input)
 }
+func (m *mockS3Client) CreateMultipartUpload(ctx context.Context, opts ConnectionOptions, input CreateMultipartUploadInput) (*MultipartUpload, error) {
+	return m.createMPUFn(ctx, opts, input)
+}
+func (m *mockS3Client) UploadPart(ctx context.Context, opts ConnectionOptions, input UploadPartInput) (*UploadedPart, error) {
+	return m.uploadPartFn(ctx, opts, input)
+}
+func (m *mockS3Client) ListParts(ctx context.Context, opts ConnectionOptions, input ListPartsInput) ([]UploadedPart, error) {
+	return m.listPartsFn(ctx, opts, input)
+}
+func (m *mockS3Client) CompleteMultipartUpload(ctx context.Context, opts ConnectionOptions, input CompleteMultipartUploadInput) error {
+	return m.completeMPUFn(ctx, opts, input)
+}
+func (m *mockS3Client) AbortMultipartUpload(ctx context.Context, opts ConnectionOptions, input AbortMultipartUploadInput) error {
+	return m.abortMPUFn(ctx, opts, input)
+}
+func (m *mockS3Client) CopyObject(ctx context.Context, opts ConnectionOptions, input CopyObjectInput) error {
+	return m.copyObjectFn(ctx, opts, input)
+}
+func (m *mockS3Client) DeleteObject(ctx context.Context, opts ConnectionOptions, input DeleteObjectInput) error {
+	return m.deleteObjectFn(ctx, opts, input)
+}
+func (m *mockS3Client) DeleteObjects(ctx context.Context, opts ConnectionOptions, input DeleteObjectsInput) (*DeleteObjectsResult, error) {
+	return m.deleteObjectsFn(ctx, opts, input)
+}
+func (m *mockS3Client) PresignObject(ctx context.Context, opts ConnectionOptions, input PresignInput) (*PresignedURL, error) {
+	return m.presignObjectFn(ctx, opts, input)
+}
 
 func newTestS3Service(client *mockS3Client) *service {
 	return &service{Client: client, Logger: slog.Default()}
@@ -447,3 +484,228 @@ func TestService_ResolveNonCollidingKey_ErrorDuringRetry(t *testing.T) {
 		t.Error("expected error")
 	}
 }
+
+// --- Multipart upload ---
+
+func TestService_UploadPart_Validation(t *testing.T) {
+	svc := newTestS3Service(&mockS3Client{})
+	tests := []struct {
+		name    string
+		input   UploadPartInput
+		wantErr error
+	}{
+		{"missing upload id", UploadPartInput{Key: "k", PartNumber: 1, ContentLength: 1}, ErrInvalidUploadID},
+		{"invalid key", UploadPartInput{Key: "../k", UploadID: "u", PartNumber: 1, ContentLength: 1}, ErrInvalidKey},
+		{"part number zero", UploadPartInput{Key: "k", UploadID: "u", ContentLength: 1}, ErrInvalidPart},
+		{"part number too large", UploadPartInput{Key: "k", UploadID: "u", PartNumber: MaxMultipartParts + 1, ContentLength: 1}, ErrInvalidPart},
+		{"empty part", UploadPartInput{Key: "k", UploadID: "u", PartNumber: 1}, ErrInvalidPart},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.UploadPart(context.Background(), testOpts(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_CompleteMultipartUpload_UsesStoredParts(t *testing.T) {
+	var completed []UploadedPart
+	client := &mockS3Client{
+		listPartsFn: func(ctx context.Context, opts ConnectionOptions, input ListPartsInput) ([]UploadedPart, error) {
+			return []UploadedPart{{PartNumber: 2, ETag: "e2"}, {PartNumber: 1, ETag: "e1"}}, nil
+		},
+		completeMPUFn: func(ctx context.Context, opts ConnectionOptions, input CompleteMultipartUploadInput) error {
+			completed = input.Parts
+			return nil
+		},
+	}
+	svc := newTestS3Service(client)
+
+	err := svc.CompleteMultipartUpload(context.Background(), testOpts(), CompleteMultipartUploadInput{Bucket: "b", Key: "k", UploadID: "u"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(completed) != 2 || completed[0].PartNumber != 1 || completed[1].PartNumber != 2 {
+		t.Errorf("completed parts = %+v, want parts 1 and 2 in order", completed)
+	}
+}
+
+func TestService_CompleteMultipartUpload_RejectsInvalidParts(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts []UploadedPart
+	}{
+		{"duplicate part number", []UploadedPart{{PartNumber: 1, ETag: "a"}, {PartNumber: 1, ETag: "b"}}},
+		{"missing etag", []UploadedPart{{PartNumber: 1}}},
+		{"part number out of range", []UploadedPart{{PartNumber: 0, ETag: "a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockS3Client{
+				completeMPUFn: func(ctx context.Context, opts ConnectionOptions, input CompleteMultipartUploadInput) error {
+					t.Fatal("client should not be called")
+					return nil
+				},
+			}
+			svc := newTestS3Service(client)
+
+			err := svc.CompleteMultipartUpload(context.Background(), testOpts(), CompleteMultipartUploadInput{Bucket: "b", Key: "k", UploadID: "u", Parts: tt.parts})
+			if !errors.Is(err, ErrInvalidPart) {
+				t.Errorf("error = %v, want ErrInvalidPart", err)
+			}
+		})
+	}
+}
+
+func TestService_CompleteMultipartUpload_NoStoredParts(t *testing.T) {
+	client := &mockS3Client{
+		listPartsFn: func(ctx context.Context, opts ConnectionOptions, input ListPartsInput) ([]UploadedPart, error) {
+			return []UploadedPart{}, nil
+		},
+	}
+	svc := newTestS3Service(client)
+
+	err := svc.CompleteMultipartUpload(context.Background(), testOpts(), CompleteMultipartUploadInput{Bucket: "b", Key: "k", UploadID: "u"})
+	if !errors.Is(err, ErrInvalidPart) {
+		t.Errorf("error = %v, want ErrInvalidPart", err)
+	}
+}
+
+// --- Object management ---
+
+func TestService_CopyObject_Validation(t *testing.T) {
+	client := &mockS3Client{
+		copyObjectFn: func(ctx context.Context, opts ConnectionOptions, input CopyObjectInput) error {
+			t.Fatal("client should not be called")
+			return nil
+		},
+	}
+	svc := newTestS3Service(client)
+
+	err := svc.CopyObject(context.Background(), testOpts(), CopyObjectInput{Bucket: "b", SourceKey: "a.csv", DestinationKey: "a.csv"})
+	if !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestService_MoveObject_SourceDeleteFails(t *testing.T) {
+	client := &mockS3Client{
+		copyObjectFn: func(ctx context.Context, opts ConnectionOptions, input CopyObjectInput) error {
+			return nil
+		},
+		deleteObjectFn: func(ctx context.Context, opts ConnectionOptions, input DeleteObjectInput) error {
+			return ErrAccessDenied
+		},
+	}
+	svc := newTestS3Service(client)
+
+	err := svc.MoveObject(context.Background(), testOpts(), MoveObjectInput{Bucket: "b", SourceKey: "a.csv", DestinationKey: "b.csv"})
+	if !errors.Is(err, ErrAccessDenied) || !strings.Contains(err.Error(), `copied to "b.csv"`) {
+		t.Errorf("error = %v, want ErrAccessDenied mentioning the copy", err)
+	}
+}
+
+func TestService_DeletePrefix_Tokens(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	deleted := 0
+	client := &mockS3Client{
+		listObjectsFn: func(ctx context.Context, opts ConnectionOptions, input ListObjectsInput) (*ListObjectsResponse, error) {
+			if input.Delimiter != "" {
+				t.Errorf("Delimiter = %q, want recursive listing", input.Delimiter)
+			}
+			return &ListObjectsResponse{Contents: []ObjectInfo{{Key: "p/a", Size: 1, ETag: "e"}}}, nil
+		},
+		deleteObjectsFn: func(ctx context.Context, opts ConnectionOptions, input DeleteObjectsInput) (*DeleteObjectsResult, error) {
+			deleted += len(input.Keys)
+			return &DeleteObjectsResult{Deleted: len(input.Keys)}, nil
+		},
+	}
+	svc := newTestS3Service(client)
+	svc.now = func() time.Time { return now }
+
+	preview, err := svc.DeletePrefix(context.Background(), testOpts(), DeletePrefixInput{Bucket: "b", Prefix: "p/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !preview.ExpiresAt.Equal(now.Add(PrefixDeleteConfirmationTTL)) {
+		t.Errorf("ExpiresAt = %v", preview.ExpiresAt)
+	}
+
+	tests := []struct {
+		name   string
+		token  string
+		prefix string
+		at     time.Time
+	}{
+		{"malformed", "not-a-token", "p/", now},
+		{"expired", preview.ConfirmationToken, "p/", now.Add(PrefixDeleteConfirmationTTL)},
+		{"other prefix", preview.ConfirmationToken, "q/", now},
+		{"tampered expiry", strings.Replace(preview.ConfirmationToken, ".", "0.", 1), "p/", now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc.now = func() time.Time { return tt.at }
+			_, err := svc.DeletePrefix(context.Background(), testOpts(), DeletePrefixInput{Bucket: "b", Prefix: tt.prefix, ConfirmationToken: tt.token})
+			if !errors.Is(err, ErrInvalidConfirmationToken) {
+				t.Errorf("error = %v, want ErrInvalidConfirmationToken", err)
+			}
+		})
+	}
+	if deleted != 0 {
+		t.Fatalf("deleted %d objects with invalid tokens", deleted)
+	}
+
+	svc.now = func() time.Time { return now.Add(time.Minute) }
+	result, err := svc.DeletePrefix(context.Background(), testOpts(), DeletePrefixInput{Bucket: "b", Prefix: "p/", ConfirmationToken: preview.ConfirmationToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Deleted || result.DeletedCount != 1 || deleted != 1 {
+		t.Errorf("result = %+v, deleted = %d", result, deleted)
+	}
+}
+
+func TestService_DeletePrefix_TooManyObjects(t *testing.T) {
+	client := &mockS3Client{
+		listObjectsFn: func(ctx context.Context, opts ConnectionOptions, input ListObjectsInput) (*ListObjectsResponse, error) {
+			page := make([]ObjectInfo, MaxDeleteObjectsBatch)
+			return &ListObjectsResponse{Contents: page, IsTruncated: true, NextContinuationToken: input.ContinuationToken + "x"}, nil
+		},
+	}
+	svc := newTestS3Service(client)
+
+	_, err := svc.DeletePrefix(context.Background(), testOpts(), DeletePrefixInput{Bucket: "b", Prefix: "p/"})
+	if !errors.Is(err, ErrPrefixTooLarge) {
+		t.Errorf("error = %v, want ErrPrefixTooLarge", err)
+	}
+}
+
+func TestService_PresignObject_Validation(t *testing.T) {
+	var got PresignInput
+	client := &mockS3Client{
+		presignObjectFn: func(ctx context.Context, opts ConnectionOptions, input PresignInput) (*PresignedURL, error) {
+			got = input
+			return &PresignedURL{URL: "https://s3.example.com/b/k"}, nil
+		},
+	}
+	svc := newTestS3Service(client)
+
+	if _, err := svc.PresignObject(context.Background(), testOpts(), PresignInput{Bucket: "b", Key: "k", Method: "GET"}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Expires != DefaultPresignExpiry {
+		t.Errorf("Expires = %s, want default", got.Expires)
+	}
+
+	for _, input := range []PresignInput{
+		{Bucket: "b", Key: "k", Method: "DELETE"},
+		{Bucket: "b", Key: "k", Method: "GET", Expires: MaxPresignExpiry + time.Second},
+		{Bucket: "b", Key: "k", Method: "PUT", Expires: time.Millisecond},
+	} {
+		if _, err := svc.PresignObject(context.Background(), testOpts(), input); !errors.Is(err, ErrInvalidPresignRequest) {
+			t.Errorf("PresignObject(%+v) error = %v, want ErrInvalidPresignRequest", input, err)
+		}
+	}
+}
@@ -31,6 +31,65 @@ func validateKey(key string) error {
 	return nil
 }
 
+// validateCopyTarget validates both keys of a copy or move and rejects copying an object
+// onto itself or to a folder-style key ending in "/".
+func validateCopyTarget(sourceKey, destinationKey string) error {
+	if err := validateKey(sourceKey); err != nil {
+		return err
+	}
+	if err := validateKey(destinationKey); err != nil {
+		return err
+	}
+	if sourceKey == destinationKey {
+		return fmt.Errorf("%w: source and destination keys are the same", ErrInvalidKey)
+	}
+	if strings.HasSuffix(destinationKey, "/") {
+		return fmt.Errorf("%w: destination key must not end with /", ErrInvalidKey)
+	}
+	return nil
+}
+
+// validatePrefix validates a prefix for DeletePrefix. Prefixes must name a folder (end in
+// "/") so that deleting "data/train" cannot also remove "data/train-2.csv", and the empty
+// prefix, which would empty the bucket, is rejected.
+func validatePrefix(prefix string) error {
+	if err := validateKey(prefix); err != nil {
+		return err
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		return fmt.Errorf("%w: prefix must end with /", ErrInvalidKey)
+	}
+	return nil
+}
+
+// maxUploadIDLength bounds multipart upload IDs. AWS IDs are around 100 characters; other
+// S3-compatible stores use shorter ones.
+const maxUploadIDLength = 1024
+
+// validateUploadID rejects empty multipart upload IDs and IDs with control characters.
+func validateUploadID(uploadID string) error {
+	if uploadID == "" {
+		return fmt.Errorf("%w: upload ID must not be empty", ErrInvalidUploadID)
+	}
+	if len(uploadID) > maxUploadIDLength {
+		return fmt.Errorf("%w: upload ID exceeds %d characters", ErrInvalidUploadID, maxUploadIDLength)
+	}
+	for _, r := range uploadID {
+		if r < 0x20 || r == 0x7f {
+			return fmt.Errorf("%w: upload ID contains control character", ErrInvalidUploadID)
+		}
+	}
+	return nil
+}
+
+// validatePartNumber checks a part number against the S3 range 1-10000.
+func validatePartNumber(partNumber int32) error {
+	if partNumber < 1 || partNumber > MaxMultipartParts {
+		return fmt.Errorf("%w: part number must be between 1 and %d", ErrInvalidPart, MaxMultipartParts)
+	}
+	return nil
+}
+
 // validateAndNormalizeEndpoint validates the S3 endpoint URL to prevent SSRF attacks.
 //
 // HTTPS is required for external endpoints — plain HTTP is rejected because S3
@@ -46,6 +46,76 @@ func TestValidateKey(t *testing.T) {
 	}
 }
 
+func TestValidateUploadID(t *testing.T) {
+	tests := []struct {
+		name     string
+		uploadID string
+		wantErr  bool
+	}{
+		{"valid", "VXBsb2FkIElEIGZvciBlbHZpbmcncyBteS1tb3ZpZS5tMnRz", false},
+		{"empty", "", true},
+		{"too long", strings.Repeat("a", maxUploadIDLength+1), true},
+		{"newline", "abc\ndef", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateUploadID(tt.uploadID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUploadID(%q) error = %v, wantErr %v", tt.uploadID, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidUploadID) {
+				t.Errorf("validateUploadID(%q) error should wrap ErrInvalidUploadID, got %v", tt.uploadID, err)
+			}
+		})
+	}
+}
+
+func TestValidateCopyTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		wantErr     bool
+	}{
+		{"valid", "data/a.csv", "archive/a.csv", false},
+		{"same key", "data/a.csv", "data/a.csv", true},
+		{"destination folder", "data/a.csv", "archive/", true},
+		{"invalid source", "../a.csv", "b.csv", true},
+		{"empty destination", "data/a.csv", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCopyTarget(tt.source, tt.destination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCopyTarget(%q, %q) error = %v, wantErr %v", tt.source, tt.destination, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("error should wrap ErrInvalidKey, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidatePrefix(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		wantErr bool
+	}{
+		{"data/train/", false},
+		{"data/train", true},
+		{"", true},
+		{"data/../", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			err := validatePrefix(tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePrefix(%q) error = %v, wantErr %v", tt.prefix, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestIsInternalHost(t *testing.T) {
 	tests := []struct {
 		hostname string
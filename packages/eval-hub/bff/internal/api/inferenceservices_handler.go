@@ -13,6 +13,7 @@ import (
 	"github.com/opendatahub-io/eval-hub/bff/internal/models"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
@@ -48,7 +49,15 @@ func (app *App) InferenceServicesHandler(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	result, err := listInferenceServices(ctx, dynClient, namespace)
+	labelSelector := r.URL.Query().Get("label_selector")
+	if labelSelector != "" {
+		if _, err := labels.Parse(labelSelector); err != nil {
+			app.badRequestResponse(w, r, fmt.Errorf("invalid label_selector: %w", err))
+			return
+		}
+	}
+
+	result, err := listInferenceServices(ctx, dynClient, namespace, labelSelector)
 	if err != nil {
 		logger.Warn("InferenceService listing failed, returning empty list", "namespace", namespace, "error", err)
 
@@ -94,11 +103,11 @@ func newUserDynamicClient(token string) (dynamic.Interface, error) {
 	return dynamic.NewForConfig(cfg)
 }
 
-func listInferenceServices(ctx context.Context, client dynamic.Interface, namespace string) (*models.InferenceServicesResponse, error) {
+func listInferenceServices(ctx context.Context, client dynamic.Interface, namespace string, labelSelector string) (*models.InferenceServicesResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	list, err := client.Resource(inferenceServiceGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	list, err := client.Resource(inferenceServiceGVR).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, err
 	}
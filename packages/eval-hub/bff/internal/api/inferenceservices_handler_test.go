@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func newTestInferenceService(name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.kserve.io/v1beta1",
+		"kind":       "InferenceService",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "test-ns",
+		},
+		"status": map[string]interface{}{
+			"url": "http://" + name + ".test-ns.svc.cluster.local",
+		},
+	}}
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestListInferenceServices_LabelSelector(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{inferenceServiceGVR: "InferenceServiceList"},
+		newTestInferenceService("granite", map[string]string{"modelregistry.opendatahub.io/model-version-id": "7"}),
+		newTestInferenceService("llama", map[string]string{"modelregistry.opendatahub.io/model-version-id": "8"}),
+	)
+
+	all, err := listInferenceServices(context.Background(), client, "test-ns", "")
+	require.NoError(t, err)
+	assert.Len(t, all.Items, 2)
+
+	filtered, err := listInferenceServices(context.Background(), client, "test-ns", "modelregistry.opendatahub.io/model-version-id=7")
+	require.NoError(t, err)
+	require.Len(t, filtered.Items, 1)
+	assert.Equal(t, "granite", filtered.Items[0].Name)
+	assert.Equal(t, "http://granite.test-ns.svc.cluster.local", filtered.Items[0].URL)
+}
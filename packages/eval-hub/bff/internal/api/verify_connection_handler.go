@@ -132,6 +132,7 @@ func (app *App) VerifyConnectionHandler(w http.ResponseWriter, r *http.Request,
 		slog.String("base_url", sanitizeURL(req.BaseURL)),
 		slog.Bool("has_secret", secretValue != ""),
 		slog.String("model_id", req.ModelID),
+		slog.String("protocol", req.Protocol),
 	)
 
 	client, err := connectionprobe.NewConnectionProbeClient(
@@ -186,6 +187,7 @@ func (app *App) VerifyConnectionHandler(w http.ResponseWriter, r *http.Request,
 	app.logger.Info("verify-connection: probe succeeded",
 		slog.String("base_url", sanitizeURL(req.BaseURL)),
 		slog.Int("response_time_ms", response.ResponseTime),
+		slog.String("detected_protocol", response.Protocol),
 	)
 
 	envelope := VerifyConnectionEnvelope{
@@ -39,6 +39,9 @@ type ClientOptions struct {
 	AllowHTTP           bool
 	SkipTLSVerification bool
 	RootCAs             *x509.CertPool
+	// Detectors overrides the protocol detectors run for model and agent
+	// sources. When nil, DefaultDetectors is used.
+	Detectors []ProtocolDetector
 }
 
 // ConnectionProbeClient handles connection verification for evaluation sources.
@@ -49,6 +52,7 @@ type ConnectionProbeClient struct {
 	secretValue        string
 	sourceType         string
 	skipSSRFValidation bool
+	detectors          []ProtocolDetector
 }
 
 // isPrivateIP checks if an IP is a loopback, RFC1918, or unique-local address.
@@ -184,6 +188,11 @@ func NewConnectionProbeClient(
 		},
 	}
 
+	detectors := opts.Detectors
+	if detectors == nil {
+		detectors = DefaultDetectors()
+	}
+
 	return &ConnectionProbeClient{
 		logger:             logger,
 		httpClient:         httpClient,
@@ -191,6 +200,7 @@ func NewConnectionProbeClient(
 		secretValue:        secretValue,
 		sourceType:         sourceType,
 		skipSSRFValidation: opts.SkipSSRFValidation || internal,
+		detectors:          detectors,
 	}, nil
 }
 
@@ -203,11 +213,11 @@ func (c *ConnectionProbeClient) Probe(ctx context.Context, req models.VerifyConn
 
 	switch c.sourceType {
 	case "model", "agent":
-		return c.probeModelEndpoint(ctx, req, startTime)
+		return c.probeWithDetection(ctx, req, startTime)
 	case "prerecorded":
 		return c.probePrerecordedEndpoint(ctx, req, startTime)
 	default:
-		return c.probeModelEndpoint(ctx, req, startTime)
+		return c.probeWithDetection(ctx, req, startTime)
 	}
 }
 
@@ -249,9 +259,7 @@ func (c *ConnectionProbeClient) probeModelEndpoint(ctx context.Context, req mode
 		return nil, NewConnectionError(c.baseURL, fmt.Sprintf("Failed to create request: %v", err))
 	}
 
-	if c.secretValue != "" && (httpReq.URL.Scheme == "https" || isInternalHost(fullURL)) {
-		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.secretValue))
-	}
+	c.setAuthorization(httpReq)
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	return c.executeAndMap(ctx, httpReq, fullURL, startTime, true)
@@ -282,40 +290,53 @@ func (c *ConnectionProbeClient) probePrerecordedEndpoint(ctx context.Context, _
 		return nil, NewConnectionError(c.baseURL, fmt.Sprintf("Failed to create request: %v", err))
 	}
 
-	if c.secretValue != "" && (httpReq.URL.Scheme == "https" || isInternalHost(fullURL)) {
-		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.secretValue))
-	}
+	c.setAuthorization(httpReq)
 
 	return c.executeAndMap(ctx, httpReq, fullURL, startTime, false)
 }
 
-func (c *ConnectionProbeClient) executeAndMap(ctx context.Context, httpReq *http.Request, fullURL string, startTime time.Time, checkOpenAI bool) (*models.VerifyConnectionResponse, error) {
+// setAuthorization attaches the bearer credential, but only over HTTPS or to
+// cluster-internal hosts so the secret never travels in clear text.
+func (c *ConnectionProbeClient) setAuthorization(httpReq *http.Request) {
+	if c.secretValue != "" && (httpReq.URL.Scheme == "https" || isInternalHost(httpReq.URL.String())) {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.secretValue))
+	}
+}
+
+// send performs the SSRF check, executes the request and returns the status code
+// and a size-limited response body. Transport failures are returned as typed
+// ConnectionProbeErrors; HTTP error statuses are left to the caller.
+func (c *ConnectionProbeClient) send(ctx context.Context, httpReq *http.Request) (int, []byte, error) {
 	if !c.skipSSRFValidation {
-		parsedURL, err := url.Parse(fullURL)
-		if err != nil {
-			return nil, NewConnectionError(c.baseURL, fmt.Sprintf("Failed to parse request URL: %v", err))
-		}
-		if err := validateRequestSSRF(parsedURL.Hostname(), c.logger); err != nil {
-			return nil, NewConnectionError(c.baseURL, err.Error())
+		if err := validateRequestSSRF(httpReq.URL.Hostname(), c.logger); err != nil {
+			return 0, nil, NewConnectionError(c.baseURL, err.Error())
 		}
 	}
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, NewTimeoutError(c.baseURL)
+			return 0, nil, NewTimeoutError(c.baseURL)
 		}
-		return nil, NewConnectionError(c.baseURL, err.Error())
+		return 0, nil, NewConnectionError(c.baseURL, err.Error())
 	}
 	defer resp.Body.Close()
 
 	limitedReader := io.LimitReader(resp.Body, maxResponseBodySize)
 	responseBody, err := io.ReadAll(limitedReader)
 	if err != nil {
-		return nil, NewConnectionError(c.baseURL, fmt.Sprintf("Failed to read response: %v", err))
+		return 0, nil, NewConnectionError(c.baseURL, fmt.Sprintf("Failed to read response: %v", err))
+	}
+	return resp.StatusCode, responseBody, nil
+}
+
+func (c *ConnectionProbeClient) executeAndMap(ctx context.Context, httpReq *http.Request, fullURL string, startTime time.Time, checkOpenAI bool) (*models.VerifyConnectionResponse, error) {
+	statusCode, responseBody, err := c.send(ctx, httpReq)
+	if err != nil {
+		return nil, err
 	}
 
-	switch resp.StatusCode {
+	switch statusCode {
 	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
 		elapsed := int(time.Since(startTime).Milliseconds())
 		result := &models.VerifyConnectionResponse{
@@ -333,11 +354,11 @@ func (c *ConnectionProbeClient) executeAndMap(ctx context.Context, httpReq *http
 	case http.StatusForbidden:
 		return nil, NewForbiddenError(c.baseURL)
 	default:
-		errorMsg := fmt.Sprintf("Request to %s returned HTTP %d", fullURL, resp.StatusCode)
+		errorMsg := fmt.Sprintf("Request to %s returned HTTP %d", fullURL, statusCode)
 		responseStr := strings.TrimSpace(string(responseBody))
 		if responseStr != "" {
 			errorMsg += fmt.Sprintf(": %s", responseStr)
-		} else if resp.StatusCode == http.StatusNotFound {
+		} else if statusCode == http.StatusNotFound {
 			errorMsg += ". The endpoint may not exist or the base URL may be incorrect."
 		}
 		return nil, NewConnectionError(c.baseURL, errorMsg)
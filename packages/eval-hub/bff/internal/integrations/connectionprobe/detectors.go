@@ -0,0 +1,481 @@
+package connectionprobe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opendatahub-io/eval-hub/bff/internal/models"
+)
+
+// ProtocolDetector identifies whether an endpoint speaks a given serving protocol.
+// Detectors must not return errors: transport and auth failures are reported
+// through the detection status so the caller can tell them apart.
+type ProtocolDetector interface {
+	Protocol() string
+	Detect(ctx context.Context, c *ConnectionProbeClient, req models.VerifyConnectionRequest) models.ProtocolDetection
+}
+
+// DefaultDetectors returns the built-in detectors in priority order. The most
+// specific protocols come first so that, for example, a vLLM embedding model is
+// reported as "embeddings" rather than as a generic OpenAI endpoint.
+func DefaultDetectors() []ProtocolDetector {
+	return []ProtocolDetector{
+		&embeddingsDetector{},
+		&rerankDetector{},
+		&kserveV2Detector{},
+		&tgiDetector{},
+		&vllmDetector{},
+		&openAIDetector{},
+	}
+}
+
+// probeWithDetection verifies a model or agent endpoint. With an explicit
+// protocol only the matching detector runs. Otherwise the chat completions probe
+// runs first and every detector enriches the result; when the chat probe fails
+// for a non-auth reason, a successful detection still verifies the endpoint.
+func (c *ConnectionProbeClient) probeWithDetection(ctx context.Context, req models.VerifyConnectionRequest, startTime time.Time) (*models.VerifyConnectionResponse, error) {
+	if req.Protocol != "" && req.Protocol != models.ProtocolAuto {
+		return c.probeExplicitProtocol(ctx, req, startTime)
+	}
+
+	result, probeErr := c.probeModelEndpoint(ctx, req, startTime)
+	if probeErr != nil {
+		var typed *ConnectionProbeError
+		if !errors.As(probeErr, &typed) || typed.Code != ErrCodeConnectionFailed {
+			return nil, probeErr
+		}
+	}
+
+	detections := c.runDetectors(ctx, c.detectors, req)
+
+	if probeErr != nil {
+		primary := firstDetected(detections)
+		if primary == nil {
+			if hasStatus(detections, models.DetectionStatusAuthFailed) {
+				return nil, NewUnauthorizedError(c.baseURL)
+			}
+			return nil, probeErr
+		}
+		compat := false
+		result = &models.VerifyConnectionResponse{
+			Success:      true,
+			Message:      fmt.Sprintf("Connection verified successfully using the %s protocol", primary.Protocol),
+			ResponseTime: int(time.Since(startTime).Milliseconds()),
+			OpenAICompat: &compat,
+		}
+	}
+
+	applyDetections(result, detections)
+	return result, nil
+}
+
+func (c *ConnectionProbeClient) probeExplicitProtocol(ctx context.Context, req models.VerifyConnectionRequest, startTime time.Time) (*models.VerifyConnectionResponse, error) {
+	var detector ProtocolDetector
+	for _, d := range c.detectors {
+		if d.Protocol() == req.Protocol {
+			detector = d
+			break
+		}
+	}
+	if detector == nil {
+		return nil, NewUnsupportedProtocolError(c.baseURL, req.Protocol)
+	}
+
+	detection := detector.Detect(ctx, c, req)
+	detection.Protocol = detector.Protocol()
+	switch detection.Status {
+	case models.DetectionStatusDetected:
+		result := &models.VerifyConnectionResponse{
+			Success:      true,
+			Message:      fmt.Sprintf("Connection verified successfully using the %s protocol", detection.Protocol),
+			ResponseTime: int(time.Since(startTime).Milliseconds()),
+		}
+		applyDetections(result, []models.ProtocolDetection{detection})
+		return result, nil
+	case models.DetectionStatusAuthFailed:
+		return nil, NewUnauthorizedError(c.baseURL)
+	case models.DetectionStatusNetworkError:
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, NewTimeoutError(c.baseURL)
+		}
+		return nil, NewConnectionError(c.baseURL, detection.Message)
+	default:
+		return nil, NewProtocolMismatchError(c.baseURL, detection.Protocol, detection.Message)
+	}
+}
+
+// runDetectors runs all detectors concurrently and returns their results in
+// detector order.
+func (c *ConnectionProbeClient) runDetectors(ctx context.Context, detectors []ProtocolDetector, req models.VerifyConnectionRequest) []models.ProtocolDetection {
+	results := make([]models.ProtocolDetection, len(detectors))
+	var wg sync.WaitGroup
+	for i, d := range detectors {
+		wg.Add(1)
+		go func(i int, d ProtocolDetector) {
+			defer wg.Done()
+			results[i] = d.Detect(ctx, c, req)
+			results[i].Protocol = d.Protocol()
+		}(i, d)
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		c.logger.Debug("protocol detection", "protocol", r.Protocol, "status", r.Status, "message", r.Message)
+	}
+	return results
+}
+
+// applyDetections copies the primary detection's protocol and the union of
+// served models onto the response. The context length comes from the first
+// detected protocol that advertises one.
+func applyDetections(result *models.VerifyConnectionResponse, detections []models.ProtocolDetection) {
+	result.Detections = detections
+	if primary := firstDetected(detections); primary != nil {
+		result.Protocol = primary.Protocol
+	}
+
+	seen := map[string]bool{}
+	for _, d := range detections {
+		if d.Status != models.DetectionStatusDetected {
+			continue
+		}
+		for _, m := range d.Models {
+			if !seen[m] {
+				seen[m] = true
+				result.Models = append(result.Models, m)
+			}
+		}
+		if result.ContextLength == nil && d.ContextLength != nil {
+			result.ContextLength = d.ContextLength
+		}
+	}
+}
+
+func firstDetected(detections []models.ProtocolDetection) *models.ProtocolDetection {
+	for i := range detections {
+		if detections[i].Status == models.DetectionStatusDetected {
+			return &detections[i]
+		}
+	}
+	return nil
+}
+
+func hasStatus(detections []models.ProtocolDetection, status string) bool {
+	for _, d := range detections {
+		if d.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// openAIBaseURL returns the OpenAI-style API prefix (normally ending in /v1),
+// stripping any operation path the user pasted into the base URL.
+func openAIBaseURL(baseURL string) string {
+	trimmed := strings.TrimSuffix(baseURL, "/")
+	for _, suffix := range []string{"/chat/completions", "/completions", "/embeddings", "/rerank", "/models"} {
+		if strings.HasSuffix(trimmed, suffix) {
+			return strings.TrimSuffix(trimmed, suffix)
+		}
+	}
+	return trimmed
+}
+
+// serverRootURL returns the scheme and host of the endpoint, used by protocols
+// whose routes are not nested under the OpenAI /v1 prefix (TGI /info, KServe /v2).
+func serverRootURL(baseURL string) string {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return strings.TrimSuffix(baseURL, "/")
+	}
+	return parsedURL.Scheme + "://" + parsedURL.Host
+}
+
+// probeJSON issues a request for a detector and decodes a 2xx JSON response into
+// out. A non-nil detection is returned when the request could not identify the
+// protocol (transport error, auth failure, unexpected status or body).
+func (c *ConnectionProbeClient) probeJSON(ctx context.Context, method, fullURL string, body any, out any) *models.ProtocolDetection {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return &models.ProtocolDetection{Status: models.DetectionStatusNotDetected, Message: fmt.Sprintf("Failed to marshal request: %v", err)}
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, fullURL, reader)
+	if err != nil {
+		return &models.ProtocolDetection{Status: models.DetectionStatusNetworkError, Message: fmt.Sprintf("Failed to create request: %v", err)}
+	}
+	c.setAuthorization(httpReq)
+	httpReq.Header.Set("Accept", "application/json")
+	if reader != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	statusCode, responseBody, err := c.send(ctx, httpReq)
+	if err != nil {
+		return &models.ProtocolDetection{Status: models.DetectionStatusNetworkError, Message: err.Error()}
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return &models.ProtocolDetection{Status: models.DetectionStatusAuthFailed, Message: fmt.Sprintf("%s returned HTTP %d", fullURL, statusCode)}
+	case statusCode < 200 || statusCode > 299:
+		return &models.ProtocolDetection{Status: models.DetectionStatusNotDetected, Message: fmt.Sprintf("%s returned HTTP %d", fullURL, statusCode)}
+	}
+
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return &models.ProtocolDetection{Status: models.DetectionStatusNotDetected, Message: fmt.Sprintf("%s returned a non-JSON response", fullURL)}
+	}
+	return nil
+}
+
+func notDetected(message string) models.ProtocolDetection {
+	return models.ProtocolDetection{Status: models.DetectionStatusNotDetected, Message: message}
+}
+
+func detected(modelIDs []string, contextLength int) models.ProtocolDetection {
+	d := models.ProtocolDetection{Status: models.DetectionStatusDetected, Models: modelIDs}
+	if contextLength > 0 {
+		d.ContextLength = &contextLength
+	}
+	return d
+}
+
+// modelListResponse is the OpenAI /models response. vLLM adds owned_by "vllm"
+// and max_model_len to each entry.
+type modelListResponse struct {
+	Object string `json:"object"`
+	Data   []struct {
+		ID          string `json:"id"`
+		OwnedBy     string `json:"owned_by"`
+		MaxModelLen int    `json:"max_model_len"`
+	} `json:"data"`
+}
+
+type openAIDetector struct{}
+
+func (d *openAIDetector) Protocol() string { return models.ProtocolOpenAI }
+
+func (d *openAIDetector) Detect(ctx context.Context, c *ConnectionProbeClient, _ models.VerifyConnectionRequest) models.ProtocolDetection {
+	var parsed modelListResponse
+	if failed := c.probeJSON(ctx, http.MethodGet, openAIBaseURL(c.baseURL)+"/models", nil, &parsed); failed != nil {
+		return *failed
+	}
+	if parsed.Object != "list" && parsed.Data == nil {
+		return notDetected("/models response is not an OpenAI model list")
+	}
+	ids := make([]string, 0, len(parsed.Data))
+	for _, m := range parsed.Data {
+		ids = append(ids, m.ID)
+	}
+	return detected(ids, 0)
+}
+
+type vllmDetector struct{}
+
+func (d *vllmDetector) Protocol() string { return models.ProtocolVLLM }
+
+func (d *vllmDetector) Detect(ctx context.Context, c *ConnectionProbeClient, req models.VerifyConnectionRequest) models.ProtocolDetection {
+	var parsed modelListResponse
+	if failed := c.probeJSON(ctx, http.MethodGet, openAIBaseURL(c.baseURL)+"/models", nil, &parsed); failed != nil {
+		return *failed
+	}
+
+	isVLLM := false
+	contextLength := 0
+	ids := make([]string, 0, len(parsed.Data))
+	for _, m := range parsed.Data {
+		ids = append(ids, m.ID)
+		if m.OwnedBy == "vllm" || m.MaxModelLen > 0 {
+			isVLLM = true
+		}
+		if m.MaxModelLen > 0 && (contextLength == 0 || m.ID == req.ModelID) {
+			contextLength = m.MaxModelLen
+		}
+	}
+	if !isVLLM {
+		return notDetected("/models entries are not owned by vllm")
+	}
+	return detected(ids, contextLength)
+}
+
+// infoResponse covers the /info routes of TGI and of Text Embeddings Inference,
+// which share the same path. TEI sets model_type to embedding or reranker.
+type infoResponse struct {
+	ModelID        string          `json:"model_id"`
+	ModelType      json.RawMessage `json:"model_type"`
+	MaxTotalTokens int             `json:"max_total_tokens"`
+	MaxInputTokens int             `json:"max_input_tokens"`
+	MaxInputLength int             `json:"max_input_length"`
+	Router         string          `json:"router"`
+}
+
+type tgiDetector struct{}
+
+func (d *tgiDetector) Protocol() string { return models.ProtocolTGI }
+
+func (d *tgiDetector) Detect(ctx context.Context, c *ConnectionProbeClient, _ models.VerifyConnectionRequest) models.ProtocolDetection {
+	var parsed infoResponse
+	if failed := c.probeJSON(ctx, http.MethodGet, serverRootURL(c.baseURL)+"/info", nil, &parsed); failed != nil {
+		return *failed
+	}
+	if parsed.ModelID == "" || len(parsed.ModelType) > 0 {
+		return notDetected("/info response is not from text-generation-inference")
+	}
+	if parsed.MaxTotalTokens == 0 && !strings.Contains(parsed.Router, "text-generation") {
+		return notDetected("/info response is not from text-generation-inference")
+	}
+	return detected([]string{parsed.ModelID}, parsed.MaxTotalTokens)
+}
+
+// serverMetadataResponse is the Open Inference Protocol GET /v2 response.
+type serverMetadataResponse struct {
+	Name       string   `json:"name"`
+	Version    string   `json:"version"`
+	Extensions []string `json:"extensions"`
+}
+
+// modelMetadataResponse is the Open Inference Protocol GET /v2/models/{name} response.
+type modelMetadataResponse struct {
+	Name     string `json:"name"`
+	Platform string `json:"platform"`
+}
+
+type kserveV2Detector struct{}
+
+func (d *kserveV2Detector) Protocol() string { return models.ProtocolKServeV2 }
+
+func (d *kserveV2Detector) Detect(ctx context.Context, c *ConnectionProbeClient, req models.VerifyConnectionRequest) models.ProtocolDetection {
+	root := serverRootURL(c.baseURL)
+
+	var server serverMetadataResponse
+	if failed := c.probeJSON(ctx, http.MethodGet, root+"/v2", nil, &server); failed != nil {
+		return *failed
+	}
+	if server.Name == "" || server.Version == "" {
+		return notDetected("/v2 response is not Open Inference Protocol server metadata")
+	}
+
+	if req.ModelID == "" {
+		return detected(nil, 0)
+	}
+
+	// The protocol has no model listing route, so only the requested model can
+	// be confirmed.
+	var model modelMetadataResponse
+	if failed := c.probeJSON(ctx, http.MethodGet, root+"/v2/models/"+url.PathEscape(req.ModelID), nil, &model); failed != nil {
+		if failed.Status == models.DetectionStatusNotDetected {
+			failed.Message = fmt.Sprintf("server speaks the Open Inference Protocol but model %q was not found", req.ModelID)
+		}
+		return *failed
+	}
+	name := model.Name
+	if name == "" {
+		name = req.ModelID
+	}
+	return detected([]string{name}, 0)
+}
+
+type embeddingsRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Model string `json:"model"`
+	Data  []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+type embeddingsDetector struct{}
+
+func (d *embeddingsDetector) Protocol() string { return models.ProtocolEmbeddings }
+
+func (d *embeddingsDetector) Detect(ctx context.Context, c *ConnectionProbeClient, req models.VerifyConnectionRequest) models.ProtocolDetection {
+	body := embeddingsRequest{Model: "test", Input: "test"}
+	if req.ModelID != "" {
+		body.Model = req.ModelID
+	}
+
+	var parsed embeddingsResponse
+	if failed := c.probeJSON(ctx, http.MethodPost, openAIBaseURL(c.baseURL)+"/embeddings", body, &parsed); failed != nil {
+		return *failed
+	}
+	if len(parsed.Data) == 0 || len(parsed.Data[0].Embedding) == 0 {
+		return notDetected("/embeddings response contains no embedding vectors")
+	}
+
+	var ids []string
+	if parsed.Model != "" {
+		ids = []string{parsed.Model}
+	}
+	return detected(ids, 0)
+}
+
+// rerankRequest carries both the Cohere/Jina/vLLM "documents" field and the
+// Text Embeddings Inference "texts" field so one request covers both servers.
+type rerankRequest struct {
+	Model     string   `json:"model,omitempty"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	Texts     []string `json:"texts"`
+}
+
+type rerankResult struct {
+	Index          *int     `json:"index"`
+	RelevanceScore *float64 `json:"relevance_score"`
+	Score          *float64 `json:"score"`
+}
+
+type rerankDetector struct{}
+
+func (d *rerankDetector) Protocol() string { return models.ProtocolRerank }
+
+func (d *rerankDetector) Detect(ctx context.Context, c *ConnectionProbeClient, req models.VerifyConnectionRequest) models.ProtocolDetection {
+	body := rerankRequest{Model: req.ModelID, Query: "test", Documents: []string{"test"}, Texts: []string{"test"}}
+
+	// Cohere-style servers nest results under "results"; TEI returns a bare array.
+	var raw json.RawMessage
+	apiBase, root := openAIBaseURL(c.baseURL), serverRootURL(c.baseURL)
+	failed := c.probeJSON(ctx, http.MethodPost, apiBase+"/rerank", body, &raw)
+	if failed != nil && failed.Status == models.DetectionStatusNotDetected && root != apiBase {
+		failed = c.probeJSON(ctx, http.MethodPost, root+"/rerank", body, &raw)
+	}
+	if failed != nil {
+		return *failed
+	}
+
+	var wrapped struct {
+		Model   string         `json:"model"`
+		Results []rerankResult `json:"results"`
+	}
+	var results []rerankResult
+	if err := json.Unmarshal(raw, &wrapped); err == nil && len(wrapped.Results) > 0 {
+		results = wrapped.Results
+	} else if err := json.Unmarshal(raw, &results); err != nil {
+		return notDetected("/rerank response is not a list of scored results")
+	}
+
+	if len(results) == 0 || results[0].Index == nil || (results[0].RelevanceScore == nil && results[0].Score == nil) {
+		return notDetected("/rerank response is not a list of scored results")
+	}
+
+	var ids []string
+	if wrapped.Model != "" {
+		ids = []string{wrapped.Model}
+	}
+	return detected(ids, 0)
+}
@@ -0,0 +1,284 @@
+package connectionprobe
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/opendatahub-io/eval-hub/bff/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProbeClient(t *testing.T, baseURL, secret string, opts *ClientOptions) *ConnectionProbeClient {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if opts == nil {
+		opts = &ClientOptions{}
+	}
+	opts.SkipSSRFValidation = true
+	client, err := NewConnectionProbeClient(logger, baseURL, secret, "model", opts)
+	require.NoError(t, err)
+	return client
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// newVLLMServer mimics a vLLM OpenAI-compatible chat server.
+func newVLLMServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"object": "list",
+			"data": []map[string]any{
+				{"id": "granite-3b", "object": "model", "owned_by": "vllm", "max_model_len": 8192},
+			},
+		})
+	})
+	mux.HandleFunc("POST /v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"id":      "chatcmpl-1",
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": "hi"}}},
+		})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProbe_AutoDetectsVLLM(t *testing.T) {
+	server := newVLLMServer(t)
+	client := newTestProbeClient(t, server.URL+"/v1", "", nil)
+
+	resp, err := client.Probe(context.Background(), models.VerifyConnectionRequest{ModelID: "granite-3b"})
+
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	require.NotNil(t, resp.OpenAICompat)
+	assert.True(t, *resp.OpenAICompat)
+	assert.Equal(t, models.ProtocolVLLM, resp.Protocol)
+	assert.Equal(t, []string{"granite-3b"}, resp.Models)
+	require.NotNil(t, resp.ContextLength)
+	assert.Equal(t, 8192, *resp.ContextLength)
+	assert.Len(t, resp.Detections, len(DefaultDetectors()))
+}
+
+func TestProbe_AutoDetectsTGI(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /info", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"model_id":         "mistralai/Mistral-7B-Instruct-v0.3",
+			"max_input_tokens": 4095,
+			"max_total_tokens": 4096,
+			"router":           "text-generation-router",
+		})
+	})
+	mux.HandleFunc("POST /v1/chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"id":      "",
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": "hi"}}},
+		})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newTestProbeClient(t, server.URL+"/v1", "", nil)
+	resp, err := client.Probe(context.Background(), models.VerifyConnectionRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ProtocolTGI, resp.Protocol)
+	assert.Equal(t, []string{"mistralai/Mistral-7B-Instruct-v0.3"}, resp.Models)
+	require.NotNil(t, resp.ContextLength)
+	assert.Equal(t, 4096, *resp.ContextLength)
+}
+
+func TestProbe_FallsBackToKServeV2WhenChatFails(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"name": "kserve", "version": "2.0", "extensions": []string{}})
+	})
+	mux.HandleFunc("GET /v2/models/sklearn-iris", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"name": "sklearn-iris", "platform": "sklearn"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newTestProbeClient(t, server.URL, "", nil)
+	resp, err := client.Probe(context.Background(), models.VerifyConnectionRequest{ModelID: "sklearn-iris"})
+
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	require.NotNil(t, resp.OpenAICompat)
+	assert.False(t, *resp.OpenAICompat)
+	assert.Equal(t, models.ProtocolKServeV2, resp.Protocol)
+	assert.Equal(t, []string{"sklearn-iris"}, resp.Models)
+	assert.Nil(t, resp.ContextLength)
+}
+
+func TestProbe_DetectsEmbeddingsAndRerank(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		body     any
+		protocol string
+	}{
+		{
+			"OpenAI embeddings",
+			"POST /v1/embeddings",
+			map[string]any{"object": "list", "model": "bge-small", "data": []map[string]any{{"embedding": []float64{0.1, 0.2}}}},
+			models.ProtocolEmbeddings,
+		},
+		{
+			"Cohere-style rerank",
+			"POST /v1/rerank",
+			map[string]any{"model": "bge-reranker", "results": []map[string]any{{"index": 0, "relevance_score": 0.9}}},
+			models.ProtocolRerank,
+		},
+		{
+			"TEI rerank at server root",
+			"POST /rerank",
+			[]map[string]any{{"index": 0, "score": 0.9}},
+			models.ProtocolRerank,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc(tc.path, func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, tc.body)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client := newTestProbeClient(t, server.URL+"/v1", "", nil)
+			resp, err := client.Probe(context.Background(), models.VerifyConnectionRequest{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.protocol, resp.Protocol)
+		})
+	}
+}
+
+func TestProbe_ExplicitProtocol(t *testing.T) {
+	server := newVLLMServer(t)
+
+	t.Run("matching protocol", func(t *testing.T) {
+		client := newTestProbeClient(t, server.URL+"/v1", "", nil)
+		resp, err := client.Probe(context.Background(), models.VerifyConnectionRequest{Protocol: models.ProtocolOpenAI})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.ProtocolOpenAI, resp.Protocol)
+		require.Len(t, resp.Detections, 1)
+		assert.Nil(t, resp.OpenAICompat)
+	})
+
+	t.Run("mismatched protocol", func(t *testing.T) {
+		client := newTestProbeClient(t, server.URL+"/v1", "", nil)
+		_, err := client.Probe(context.Background(), models.VerifyConnectionRequest{Protocol: models.ProtocolKServeV2})
+
+		var probeErr *ConnectionProbeError
+		require.ErrorAs(t, err, &probeErr)
+		assert.Equal(t, ErrCodeProtocolMismatch, probeErr.Code)
+	})
+
+	t.Run("unknown protocol", func(t *testing.T) {
+		client := newTestProbeClient(t, server.URL+"/v1", "", nil)
+		_, err := client.Probe(context.Background(), models.VerifyConnectionRequest{Protocol: "grpc"})
+
+		var probeErr *ConnectionProbeError
+		require.ErrorAs(t, err, &probeErr)
+		assert.Equal(t, ErrCodeUnsupported, probeErr.Code)
+		assert.Equal(t, http.StatusBadRequest, probeErr.StatusCode)
+	})
+}
+
+func TestProbe_AuthFailureIsDistinguishedFromNetworkFailure(t *testing.T) {
+	t.Run("auth failure on detector", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/chat/completions" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client := newTestProbeClient(t, server.URL+"/v1", "", nil)
+		_, err := client.Probe(context.Background(), models.VerifyConnectionRequest{})
+
+		var probeErr *ConnectionProbeError
+		require.ErrorAs(t, err, &probeErr)
+		assert.Equal(t, ErrCodeUnauthorized, probeErr.Code)
+	})
+
+	t.Run("network failure", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		baseURL := server.URL
+		server.Close()
+
+		client := newTestProbeClient(t, baseURL, "", nil)
+		_, err := client.Probe(context.Background(), models.VerifyConnectionRequest{Protocol: models.ProtocolTGI})
+
+		var probeErr *ConnectionProbeError
+		require.ErrorAs(t, err, &probeErr)
+		assert.Equal(t, ErrCodeConnectionFailed, probeErr.Code)
+	})
+
+	t.Run("detection statuses", func(t *testing.T) {
+		unauthorized := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer unauthorized.Close()
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closedURL := closed.URL
+		closed.Close()
+
+		detector := &openAIDetector{}
+		authResult := detector.Detect(context.Background(), newTestProbeClient(t, unauthorized.URL, "", nil), models.VerifyConnectionRequest{})
+		networkResult := detector.Detect(context.Background(), newTestProbeClient(t, closedURL, "", nil), models.VerifyConnectionRequest{})
+
+		assert.Equal(t, models.DetectionStatusAuthFailed, authResult.Status)
+		assert.Equal(t, models.DetectionStatusNetworkError, networkResult.Status)
+	})
+}
+
+type staticDetector struct {
+	protocol string
+}
+
+func (d *staticDetector) Protocol() string { return d.protocol }
+
+func (d *staticDetector) Detect(_ context.Context, _ *ConnectionProbeClient, _ models.VerifyConnectionRequest) models.ProtocolDetection {
+	return detected([]string{"custom-model"}, 2048)
+}
+
+func TestProbe_CustomDetectors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client := newTestProbeClient(t, server.URL, "", &ClientOptions{
+		Detectors: []ProtocolDetector{&staticDetector{protocol: "custom"}},
+	})
+	resp, err := client.Probe(context.Background(), models.VerifyConnectionRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "custom", resp.Protocol)
+	assert.Equal(t, []string{"custom-model"}, resp.Models)
+	require.NotNil(t, resp.ContextLength)
+	assert.Equal(t, 2048, *resp.ContextLength)
+}
+
+func TestURLHelpers(t *testing.T) {
+	assert.Equal(t, "https://host/v1", openAIBaseURL("https://host/v1/"))
+	assert.Equal(t, "https://host/v1", openAIBaseURL("https://host/v1/chat/completions"))
+	assert.Equal(t, "https://host/openai/v1", openAIBaseURL("https://host/openai/v1/embeddings"))
+	assert.Equal(t, "https://host:8443", serverRootURL("https://host:8443/v1"))
+}
@@ -7,6 +7,8 @@ const (
 	ErrCodeTimeout          = "TIMEOUT"
 	ErrCodeUnauthorized     = "UNAUTHORIZED"
 	ErrCodeForbidden        = "FORBIDDEN"
+	ErrCodeUnsupported      = "UNSUPPORTED_PROTOCOL"
+	ErrCodeProtocolMismatch = "PROTOCOL_MISMATCH"
 )
 
 // ConnectionProbeError represents connection verification errors with typed error codes.
@@ -60,3 +62,21 @@ func NewForbiddenError(baseURL string) *ConnectionProbeError {
 		StatusCode: 403,
 	}
 }
+
+func NewUnsupportedProtocolError(baseURL, protocol string) *ConnectionProbeError {
+	return &ConnectionProbeError{
+		Code:       ErrCodeUnsupported,
+		Message:    fmt.Sprintf("Unsupported protocol: %s", protocol),
+		BaseURL:    baseURL,
+		StatusCode: 400,
+	}
+}
+
+func NewProtocolMismatchError(baseURL, protocol, message string) *ConnectionProbeError {
+	return &ConnectionProbeError{
+		Code:       ErrCodeProtocolMismatch,
+		Message:    fmt.Sprintf("Endpoint did not respond as %s: %s", protocol, message),
+		BaseURL:    baseURL,
+		StatusCode: 422,
+	}
+}
@@ -1,16 +1,51 @@
 package models
 
+// Serving protocols recognised by the connection probe detectors.
+const (
+	ProtocolAuto       = "auto"
+	ProtocolOpenAI     = "openai"
+	ProtocolVLLM       = "vllm"
+	ProtocolTGI        = "tgi"
+	ProtocolKServeV2   = "kserve_v2"
+	ProtocolEmbeddings = "embeddings"
+	ProtocolRerank     = "rerank"
+)
+
+// Outcomes reported by a single protocol detector.
+const (
+	DetectionStatusDetected     = "detected"
+	DetectionStatusNotDetected  = "not_detected"
+	DetectionStatusAuthFailed   = "auth_failed"
+	DetectionStatusNetworkError = "network_error"
+)
+
 type VerifyConnectionRequest struct {
 	SourceType  string `json:"source_type"`
 	BaseURL     string `json:"base_url"`
 	SecretName  string `json:"secret_name,omitempty"`
 	SecretValue string `json:"secret_value,omitempty"`
 	ModelID     string `json:"model_id,omitempty"`
+	// Protocol restricts detection to a single serving protocol. Empty or "auto"
+	// probes chat completions first and runs every registered detector.
+	Protocol string `json:"protocol,omitempty"`
 }
 
 type VerifyConnectionResponse struct {
-	Success      bool   `json:"success"`
-	Message      string `json:"message"`
-	ResponseTime int    `json:"response_time_ms,omitempty"`
-	OpenAICompat *bool  `json:"openai_compatible,omitempty"`
+	Success       bool                `json:"success"`
+	Message       string              `json:"message"`
+	ResponseTime  int                 `json:"response_time_ms,omitempty"`
+	OpenAICompat  *bool               `json:"openai_compatible,omitempty"`
+	Protocol      string              `json:"protocol,omitempty"`
+	Models        []string            `json:"models,omitempty"`
+	ContextLength *int                `json:"context_length,omitempty"`
+	Detections    []ProtocolDetection `json:"detections,omitempty"`
+}
+
+// ProtocolDetection is the outcome of running one protocol detector against an endpoint.
+type ProtocolDetection struct {
+	Protocol      string   `json:"protocol"`
+	Status        string   `json:"status"`
+	Message       string   `json:"message,omitempty"`
+	Models        []string `json:"models,omitempty"`
+	ContextLength *int     `json:"context_length,omitempty"`
 }
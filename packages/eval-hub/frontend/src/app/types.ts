@@ -482,6 +482,24 @@ export type VerifyConnectionRequest = {
   secret_name?: string;
   secret_value?: string;
   model_id?: string;
+  protocol?: ServingProtocol;
+};
+
+export type ServingProtocol =
+  | 'auto'
+  | 'openai'
+  | 'vllm'
+  | 'tgi'
+  | 'kserve_v2'
+  | 'embeddings'
+  | 'rerank';
+
+export type ProtocolDetection = {
+  protocol: string;
+  status: 'detected' | 'not_detected' | 'auth_failed' | 'network_error';
+  message?: string;
+  models?: string[];
+  context_length?: number;
 };
 
 export type VerifyConnectionResponse = {
@@ -489,6 +507,10 @@ export type VerifyConnectionResponse = {
   message: string;
   response_time_ms?: number;
   openai_compatible?: boolean;
+  protocol?: string;
+  models?: string[];
+  context_length?: number;
+  detections?: ProtocolDetection[];
 };
 
 export type ConnectionValidationStatus = 'idle' | 'validating' | 'success' | 'error';
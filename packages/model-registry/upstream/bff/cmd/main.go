@@ -77,6 +77,13 @@ func main() {
 	flag.StringVar(&cfg.BFFMLflowAuthMethod, "bff-mlflow-auth-method", getEnvAsString("BFF_MLFLOW_AUTH_METHOD", "user_token"), "Auth method for the MLflow BFF: 'user_token' (default) or 'internal' (Kubeflow)")
 	flag.StringVar(&cfg.BFFMLflowAuthTokenHeader, "bff-mlflow-auth-token-header", getEnvAsString("BFF_MLFLOW_AUTH_TOKEN_HEADER", "x-forwarded-access-token"), "Header to send the auth token to the MLflow BFF")
 	flag.StringVar(&cfg.BFFMLflowAuthTokenPrefix, "bff-mlflow-auth-token-prefix", getEnvAsString("BFF_MLFLOW_AUTH_TOKEN_PREFIX", ""), "Prefix for the auth token header (e.g., 'Bearer ')")
+	flag.StringVar(&cfg.BFFEvalHubServiceName, "bff-eval-hub-service-name", getEnvAsString("BFF_EVAL_HUB_SERVICE_NAME", "odh-dashboard-eval-hub-ui"), "Kubernetes service name for the eval-hub BFF")
+	flag.IntVar(&cfg.BFFEvalHubServicePort, "bff-eval-hub-service-port", getEnvAsInt("BFF_EVAL_HUB_SERVICE_PORT", 8543), "Port for the eval-hub BFF service")
+	flag.BoolVar(&cfg.BFFEvalHubTLSEnabled, "bff-eval-hub-tls-enabled", getEnvAsBool("BFF_EVAL_HUB_TLS_ENABLED", true), "Enable TLS for eval-hub BFF communication")
+	flag.StringVar(&cfg.BFFEvalHubDevURL, "bff-eval-hub-dev-url", getEnvAsString("BFF_EVAL_HUB_DEV_URL", ""), "Developer override URL for the eval-hub BFF (e.g., http://localhost:4000/api/v1)")
+	flag.StringVar(&cfg.BFFEvalHubAuthMethod, "bff-eval-hub-auth-method", getEnvAsString("BFF_EVAL_HUB_AUTH_METHOD", "user_token"), "Auth method for the eval-hub BFF: 'user_token' (default) or 'internal' (Kubeflow)")
+	flag.StringVar(&cfg.BFFEvalHubAuthTokenHeader, "bff-eval-hub-auth-token-header", getEnvAsString("BFF_EVAL_HUB_AUTH_TOKEN_HEADER", "x-forwarded-access-token"), "Header to send the auth token to the eval-hub BFF")
+	flag.StringVar(&cfg.BFFEvalHubAuthTokenPrefix, "bff-eval-hub-auth-token-prefix", getEnvAsString("BFF_EVAL_HUB_AUTH_TOKEN_PREFIX", ""), "Prefix for the eval-hub auth token header (e.g., 'Bearer ')")
 
 	flag.Parse()
 
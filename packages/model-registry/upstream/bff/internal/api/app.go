@@ -57,6 +57,7 @@ const (
 	ModelVersionListPath         = ModelRegistryPath + "/model_versions"
 	ModelVersionPath             = ModelVersionListPath + "/:" + ModelVersionId
 	ModelVersionArtifactListPath = ModelVersionPath + "/artifacts"
+	ModelVersionLineagePath      = ModelVersionPath + "/lineage"
 	ModelArtifactListPath        = ModelRegistryPath + "/model_artifacts"
 	ModelArtifactPath            = ModelArtifactListPath + "/:" + ModelArtifactId
 	ArtifactListPath             = ModelRegistryPath + "/artifacts"
@@ -79,12 +80,25 @@ const (
 	ModelCatalogSettingsSourceConfigListPath = ModelCatalogSettingsPathPrefix + "/source_configs"
 	ModelCatalogSettingsSourceConfigPath     = ModelCatalogSettingsSourceConfigListPath + "/:" + CatalogSourceId
 	CatalogSourcePreviewPath                 = ModelCatalogSettingsPathPrefix + "/source_preview"
+	CatalogSourceSyncPath                    = ModelCatalogSettingsSourceConfigPath + "/sync"
+	CatalogSourceSyncDiffPath                = CatalogSourceSyncPath + "/diff"
+	CatalogSourceSyncHistoryPath             = CatalogSourceSyncPath + "/history"
+	CatalogScheduledSyncsPath                = ModelCatalogSettingsPathPrefix + "/scheduled_syncs"
 
 	// Model Transfer Jobs
-	ModelTransferJobName       = "job_name"
-	ModelTransferJobListPath   = ModelRegistryPath + "/model_transfer_jobs"
-	ModelTransferJobPath       = ModelTransferJobListPath + "/:" + ModelTransferJobName
-	ModelTransferJobEventsPath = ModelTransferJobPath + "/events"
+	ModelTransferJobName             = "job_name"
+	ModelTransferJobListPath         = ModelRegistryPath + "/model_transfer_jobs"
+	ModelTransferJobPath             = ModelTransferJobListPath + "/:" + ModelTransferJobName
+	ModelTransferJobEventsPath       = ModelTransferJobPath + "/events"
+	ModelTransferJobStatusStreamPath = ModelTransferJobPath + "/status_stream"
+
+	// Model version promotions
+	PromotionRequestId            = "promotion_request_id"
+	PromotionPolicyPath           = ModelRegistryPath + "/promotion_policy"
+	PromotionRequestListPath      = ModelRegistryPath + "/promotion_requests"
+	PromotionRequestPath          = PromotionRequestListPath + "/:" + PromotionRequestId
+	PromotionRequestDecisionsPath = PromotionRequestPath + "/decisions"
+	PromotionAuditLogPath         = ModelRegistryPath + "/promotion_audit_log"
 
 	// Agent catalog
 	AgentId                   = "agent_id"
@@ -120,6 +134,9 @@ const (
 	McpDeploymentName     = "mcp_deployment_name"
 	McpDeploymentListPath = ApiPathPrefix + "/mcp_deployments"
 	McpDeploymentPath     = McpDeploymentListPath + "/:" + McpDeploymentName
+
+	// Deploy from catalog endpoint (downstream-only implementation)
+	CatalogDeploymentListPath = ApiPathPrefix + "/catalog_deployments"
 )
 
 const (
@@ -140,6 +157,9 @@ const (
 	handlerMcpDeploymentUpdateID   HandlerID = "mcpDeployment:update"
 	handlerMcpDeploymentDeleteID   HandlerID = "mcpDeployment:delete"
 	handlerMCPServerConverterGetID HandlerID = "mcpServer:converter:get"
+
+	// Deploy from catalog handler - downstream-only
+	handlerCatalogDeploymentCreateID HandlerID = "catalogDeployment:create"
 )
 
 type App struct {
@@ -157,6 +177,11 @@ type App struct {
 	// BFFClientFactory() in bff_client_factory.go, so NewApp doesn't carry its setup.
 	bffClientFactory     bffclient.BFFClientFactory
 	bffClientFactoryOnce sync.Once
+	// serviceAccountClientFactory builds clients that act as the BFF's own service account
+	// (see ServiceAccountClient in service_account_client.go), also built lazily.
+	serviceAccountClientFactory     k8s.KubernetesClientFactory
+	serviceAccountClientFactoryErr  error
+	serviceAccountClientFactoryOnce sync.Once
 	// backgroundWg tracks work started via TrackBackgroundWork, so Shutdown can give it a
 	// bounded chance to finish instead of the process exiting out from under it.
 	backgroundWg sync.WaitGroup
@@ -330,6 +355,7 @@ func (app *App) Routes() http.Handler {
 	apiRouter.GET(ArtifactPath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.GetArtifactHandler))))
 	apiRouter.POST(ArtifactListPath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.CreateArtifactHandler))))
 	apiRouter.GET(ModelVersionArtifactListPath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.GetAllModelArtifactsByModelVersionHandler))))
+	apiRouter.GET(ModelVersionLineagePath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.handlerWithOverride(HandlerIDModelVersionLineage, func() httprouter.Handle { return app.GetModelVersionLineageHandler })))))
 	apiRouter.POST(ModelVersionArtifactListPath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.CreateModelArtifactByModelVersionHandler))))
 	apiRouter.PATCH(ModelRegistryPath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.UpdateModelVersionHandler))))
 	apiRouter.PATCH(ModelArtifactPath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.UpdateModelArtifactHandler))))
@@ -338,10 +364,20 @@ func (app *App) Routes() http.Handler {
 	apiRouter.GET(ModelTransferJobListPath, app.AttachNamespace(app.RequireAccessToMRService(app.handlerWithOverride(HandlerIDModelTransferJobList, func() httprouter.Handle { return app.GetAllModelTransferJobsHandler }))))
 	apiRouter.GET(ModelTransferJobPath, app.AttachNamespace(app.RequireAccessToMRService(app.GetModelTransferJobHandler)))
 	apiRouter.GET(ModelTransferJobEventsPath, app.AttachNamespace(app.RequireAccessToMRService(app.GetModelTransferJobEventsHandler)))
+	apiRouter.GET(ModelTransferJobStatusStreamPath, app.AttachNamespace(app.RequireAccessToMRService(app.GetModelTransferJobStatusStreamHandler)))
 	apiRouter.POST(ModelTransferJobListPath, app.AttachNamespace(app.RequireAccessToMRService(app.CreateModelTransferJobHandler)))
 	apiRouter.PATCH(ModelTransferJobPath, app.AttachNamespace(app.RequireAccessToMRService(app.UpdateModelTransferJobHandler)))
 	apiRouter.DELETE(ModelTransferJobPath, app.AttachNamespace(app.RequireAccessToMRService(app.DeleteModelTransferJobHandler)))
 
+	// Model version promotions
+	apiRouter.GET(PromotionPolicyPath, app.AttachNamespace(app.RequireAccessToMRService(app.GetPromotionPolicyHandler)))
+	apiRouter.PUT(PromotionPolicyPath, app.AttachNamespace(app.RequireAccessToMRService(app.UpdatePromotionPolicyHandler)))
+	apiRouter.GET(PromotionRequestListPath, app.AttachNamespace(app.RequireAccessToMRService(app.GetAllPromotionRequestsHandler)))
+	apiRouter.POST(PromotionRequestListPath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.CreatePromotionRequestHandler))))
+	apiRouter.GET(PromotionRequestPath, app.AttachNamespace(app.RequireAccessToMRService(app.GetPromotionRequestHandler)))
+	apiRouter.POST(PromotionRequestDecisionsPath, app.AttachNamespace(app.RequireAccessToMRService(app.AttachModelRegistryRESTClient(app.DecidePromotionRequestHandler))))
+	apiRouter.GET(PromotionAuditLogPath, app.AttachNamespace(app.RequireAccessToMRService(app.GetPromotionAuditLogHandler)))
+
 	// Model catalog HTTP client routes (requests that we forward to Model Catalog API)
 	apiRouter.GET(CatalogModelListPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.AttachModelCatalogRESTClient(app.GetAllCatalogModelsAcrossSourcesHandler))))
 	apiRouter.GET(CatalogSourceListPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.AttachModelCatalogRESTClient(app.GetAllCatalogSourcesHandler))))
@@ -452,6 +488,14 @@ func (app *App) Routes() http.Handler {
 			}),
 		)
 
+		// Deploy from catalog endpoint - downstream-only implementation
+		apiRouter.POST(
+			CatalogDeploymentListPath,
+			app.handlerWithOverride(handlerCatalogDeploymentCreateID, func() httprouter.Handle {
+				return app.AttachNamespace(app.EndpointNotImplementedHandler("Catalog deployment"))
+			}),
+		)
+
 		//SettingsPath: Certificate endpoints
 		apiRouter.GET(CertificatesPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.GetCertificatesHandler)))
 
@@ -475,6 +519,12 @@ func (app *App) Routes() http.Handler {
 		apiRouter.PATCH(ModelCatalogSettingsSourceConfigPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.UpdateCatalogSourceConfigHandler)))
 		apiRouter.DELETE(ModelCatalogSettingsSourceConfigPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.DeleteCatalogSourceConfigHandler)))
 		apiRouter.POST(CatalogSourcePreviewPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.AttachModelCatalogRESTClient(app.CreateCatalogSourcePreviewHandler))))
+		apiRouter.GET(CatalogSourceSyncPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.GetCatalogSourceSyncStatusHandler)))
+		apiRouter.PUT(CatalogSourceSyncPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.UpdateCatalogSourceSyncScheduleHandler)))
+		apiRouter.POST(CatalogSourceSyncPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.AttachModelCatalogRESTClient(app.SyncCatalogSourceHandler))))
+		apiRouter.GET(CatalogSourceSyncDiffPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.GetCatalogSourceSyncDiffHandler)))
+		apiRouter.GET(CatalogSourceSyncHistoryPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.GetCatalogSourceSyncHistoryHandler)))
+		apiRouter.POST(CatalogScheduledSyncsPath, app.AttachNamespace(app.RequireListServiceAccessInNamespace(app.AttachModelCatalogRESTClient(app.RunScheduledCatalogSourceSyncsHandler))))
 
 		// Agent catalog endpoints
 		apiRouter.GET(AgentListPath, app.AttachNamespace(app.AttachModelCatalogRESTClient(app.GetAllAgentsHandler)))
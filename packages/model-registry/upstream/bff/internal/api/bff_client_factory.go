@@ -11,7 +11,8 @@ import (
 
 // BFFClientFactory exposes the inter-BFF client factory for extensions (see
 // docs/inter-bff-communication.md), used to resolve MCP Registry server details
-// from the MLflow BFF. Built lazily on first use, rather than in NewApp, so that
+// from the MLflow BFF and to find a model version's deployments and evaluations
+// from the eval-hub BFF. Built lazily on first use, rather than in NewApp, so that
 // function -- shared with upstream -- doesn't need to carry this setup logic.
 func (app *App) BFFClientFactory() bffclient.BFFClientFactory {
 	app.bffClientFactoryOnce.Do(func() {
@@ -45,6 +46,24 @@ func newBFFClientFactory(cfg config.EnvConfig, podNamespace string, rootCAs *x50
 		mlflowConfig.AuthTokenPrefix = cfg.BFFMLflowAuthTokenPrefix
 	}
 
+	if evalHubConfig := bffConfig.GetServiceConfig(bffclient.BFFTargetEvalHub); evalHubConfig != nil {
+		if cfg.BFFEvalHubServiceName != "" {
+			evalHubConfig.ServiceName = cfg.BFFEvalHubServiceName
+		}
+		if cfg.BFFEvalHubServicePort > 0 {
+			evalHubConfig.Port = cfg.BFFEvalHubServicePort
+		}
+		evalHubConfig.TLSEnabled = cfg.BFFEvalHubTLSEnabled
+		evalHubConfig.DevOverrideURL = cfg.BFFEvalHubDevURL
+		if cfg.BFFEvalHubAuthMethod != "" {
+			evalHubConfig.AuthMethod = cfg.BFFEvalHubAuthMethod
+		}
+		if cfg.BFFEvalHubAuthTokenHeader != "" {
+			evalHubConfig.AuthTokenHeader = cfg.BFFEvalHubAuthTokenHeader
+		}
+		evalHubConfig.AuthTokenPrefix = cfg.BFFEvalHubAuthTokenPrefix
+	}
+
 	if cfg.MockBFFClients {
 		if logger != nil {
 			logger.Info("Using mock BFF client factory")
@@ -15,6 +15,9 @@ type HandlerID string
 const (
 	// HandlerIDModelTransferJobList identifies the handler for listing model transfer jobs.
 	HandlerIDModelTransferJobList HandlerID = "modelTransferJobs:list"
+
+	// HandlerIDModelVersionLineage identifies the handler for building a model version's lineage graph.
+	HandlerIDModelVersionLineage HandlerID = "modelVersions:lineage"
 )
 
 // HandlerFactory builds a router handler that has access to the App instance.
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -98,17 +99,6 @@ func (app *App) AttachModelCatalogRESTClient(next func(http.ResponseWriter, *htt
 			return
 		}
 
-		client, err := app.kubernetesClientFactory.GetClient(r.Context())
-		if err != nil {
-			app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
-			return
-		}
-
-		modelCatalog, err := app.repositories.ModelCatalog.GetModelCatalogWithMode(r.Context(), client, namespace, app.config.DeploymentMode.IsFederatedMode())
-		if err != nil {
-			app.notFoundResponse(w, r)
-			return
-		}
 		apiPath := repositories.ModelCatalogAPIPath
 		if strings.HasPrefix(r.URL.Path, McpServerCatalogPathPrefix) {
 			apiPath = repositories.McpCatalogAPIPath
@@ -116,52 +106,94 @@ func (app *App) AttachModelCatalogRESTClient(next func(http.ResponseWriter, *htt
 			apiPath = repositories.AgentCatalogAPIPath
 		}
 
-		modelCatalogBaseURL := modelCatalog.ServerAddress
-		if apiPath != repositories.ModelCatalogAPIPath {
-			modelCatalogBaseURL = strings.Replace(modelCatalogBaseURL, repositories.ModelCatalogAPIPath, apiPath, 1)
+		restHttpClient, err := app.newModelCatalogRESTClient(r, namespace, apiPath)
+		if err != nil {
+			if errors.Is(err, errModelCatalogNotFound) {
+				app.notFoundResponse(w, r)
+				return
+			}
+			app.serverErrorResponse(w, r, err)
+			return
 		}
+		ctx := context.WithValue(r.Context(), constants.ModelCatalogHttpClientKey, restHttpClient)
+		next(w, r.WithContext(ctx), ps)
+	}
+}
 
-		// If we are in dev mode, we need to resolve the server address to the local host
-		// to allow the client to connect to the model registry via port forwarded from the cluster to the local machine.
-		// If you are in federated mode, we do not want to override the server address.
-		if app.config.DevMode && !app.config.DeploymentMode.IsFederatedMode() {
-			modelCatalogBaseURL = app.repositories.ModelCatalog.ResolveServerAddress("localhost", int32(app.config.DevModeCatalogPort), modelCatalog.IsHTTPS, "", app.config.DeploymentMode.IsFederatedMode(), apiPath)
-		}
+// errModelCatalogNotFound is returned by newModelCatalogRESTClient when no model catalog
+// serves the namespace.
+var errModelCatalogNotFound = errors.New("model catalog not found")
+
+// newModelCatalogRESTClient builds a REST client for the model catalog serving namespace,
+// rooted at apiPath. Handlers that only optionally need the catalog use it directly rather
+// than AttachModelCatalogRESTClient, which fails the request without a catalog.
+func (app *App) newModelCatalogRESTClient(r *http.Request, namespace string, apiPath string) (httpclient.HTTPClientInterface, error) {
+	client, err := app.kubernetesClientFactory.GetClient(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
 
-		// Set up a child logger for the rest client that automatically adds the request id to all statements for
-		// tracing.
-		restClientLogger := app.logger
-		traceId, ok := r.Context().Value(constants.TraceIdKey).(string)
-		if app.logger != nil {
-			if ok {
-				restClientLogger = app.logger.With(slog.String("trace_id", traceId))
-			} else {
-				app.logger.Warn("Failed to set trace_id for tracing")
-			}
+	modelCatalog, err := app.repositories.ModelCatalog.GetModelCatalogWithMode(r.Context(), client, namespace, app.config.DeploymentMode.IsFederatedMode())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errModelCatalogNotFound, err)
+	}
+
+	modelCatalogBaseURL := modelCatalog.ServerAddress
+	if apiPath != repositories.ModelCatalogAPIPath {
+		modelCatalogBaseURL = strings.Replace(modelCatalogBaseURL, repositories.ModelCatalogAPIPath, apiPath, 1)
+	}
+
+	// If we are in dev mode, we need to resolve the server address to the local host
+	// to allow the client to connect to the model registry via port forwarded from the cluster to the local machine.
+	// If you are in federated mode, we do not want to override the server address.
+	if app.config.DevMode && !app.config.DeploymentMode.IsFederatedMode() {
+		modelCatalogBaseURL = app.repositories.ModelCatalog.ResolveServerAddress("localhost", int32(app.config.DevModeCatalogPort), modelCatalog.IsHTTPS, "", app.config.DeploymentMode.IsFederatedMode(), apiPath)
+	}
+
+	// Set up a child logger for the rest client that automatically adds the request id to all statements for
+	// tracing.
+	restClientLogger := app.logger
+	traceId, ok := r.Context().Value(constants.TraceIdKey).(string)
+	if app.logger != nil {
+		if ok {
+			restClientLogger = app.logger.With(slog.String("trace_id", traceId))
+		} else {
+			app.logger.Warn("Failed to set trace_id for tracing")
 		}
+	}
 
-		// Prepare headers for the REST client
-		headers := http.Header{}
-
-		// If using user token authentication, extract and forward the authorization header
-		if app.config.AuthMethod == config.AuthMethodUser {
-			identity, ok := r.Context().Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
-			if ok && identity != nil && identity.Token != "" {
-				// Always send as "Authorization: Bearer <token>" regardless of incoming header format
-				// The identity.Token already has any prefix removed by ExtractRequestIdentity
-				authHeaderValue := "Bearer " + identity.Token
-				headers.Set("Authorization", authHeaderValue)
-			}
+	// Prepare headers for the REST client
+	headers := http.Header{}
+
+	// If using user token authentication, extract and forward the authorization header
+	if app.config.AuthMethod == config.AuthMethodUser {
+		identity, ok := r.Context().Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+		if ok && identity != nil && identity.Token != "" {
+			// Always send as "Authorization: Bearer <token>" regardless of incoming header format
+			// The identity.Token already has any prefix removed by ExtractRequestIdentity
+			authHeaderValue := "Bearer " + identity.Token
+			headers.Set("Authorization", authHeaderValue)
 		}
+	}
 
-		restHttpClient, err := httpclient.NewHTTPClient(restClientLogger, modelCatalogBaseURL, headers, app.config.InsecureSkipVerify, app.rootCAs)
-		if err != nil {
-			app.serverErrorResponse(w, r, fmt.Errorf("failed to create HTTP client: %v", err))
-			return
+	restHttpClient, err := httpclient.NewHTTPClient(restClientLogger, modelCatalogBaseURL, headers, app.config.InsecureSkipVerify, app.rootCAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %v", err)
+	}
+	return restHttpClient, nil
+}
+
+// optionalModelCatalogRESTClient returns a model catalog client for handlers that work
+// without one, or nil when the namespace has no model catalog.
+func (app *App) optionalModelCatalogRESTClient(r *http.Request, namespace string) httpclient.HTTPClientInterface {
+	catalogClient, err := app.newModelCatalogRESTClient(r, namespace, repositories.ModelCatalogAPIPath)
+	if err != nil {
+		if app.logger != nil {
+			app.logger.Debug("model catalog unavailable", "namespace", namespace, "error", err)
 		}
-		ctx := context.WithValue(r.Context(), constants.ModelCatalogHttpClientKey, restHttpClient)
-		next(w, r.WithContext(ctx), ps)
+		return nil
 	}
+	return catalogClient
 }
 
 func (app *App) AttachModelRegistryRESTClient(next func(http.ResponseWriter, *http.Request, httprouter.Params)) httprouter.Handle {
@@ -174,60 +206,76 @@ func (app *App) AttachModelRegistryRESTClient(next func(http.ResponseWriter, *ht
 			app.badRequestResponse(w, r, fmt.Errorf("missing namespace in the context"))
 		}
 
-		client, err := app.kubernetesClientFactory.GetClient(r.Context())
+		restHttpClient, err := app.newModelRegistryRESTClient(r, namespace, modelRegistryID)
 		if err != nil {
-			app.serverErrorResponse(w, r, fmt.Errorf("failed to get Kubernetes client: %w", err))
+			if errors.Is(err, errModelRegistryNotFound) {
+				app.notFoundResponse(w, r)
+				return
+			}
+			app.serverErrorResponse(w, r, err)
 			return
 		}
+		ctx := context.WithValue(r.Context(), constants.ModelRegistryHttpClientKey, restHttpClient)
+		next(w, r.WithContext(ctx), ps)
+	}
+}
 
-		modelRegistry, err := app.repositories.ModelRegistry.GetModelRegistryWithMode(r.Context(), client, namespace, modelRegistryID, app.config.DeploymentMode.IsFederatedMode())
-		if err != nil {
-			app.notFoundResponse(w, r)
-			return
-		}
-		modelRegistryBaseURL := modelRegistry.ServerAddress
+// errModelRegistryNotFound is returned by newModelRegistryRESTClient when the model registry
+// does not exist or cannot be read.
+var errModelRegistryNotFound = errors.New("model registry not found")
 
-		// If we are in dev mode, we need to resolve the server address to the local host
-		// to allow the client to connect to the model registry via port forwarded from the cluster to the local machine.
-		// If you are in federated mode, we do not want to override the server address.
-		if app.config.DevMode && !app.config.DeploymentMode.IsFederatedMode() {
-			modelRegistryBaseURL = app.repositories.ModelRegistry.ResolveServerAddress("localhost", int32(app.config.DevModeModelRegistryPort), modelRegistry.IsHTTPS, "", app.config.DeploymentMode.IsFederatedMode())
-		}
+// newModelRegistryRESTClient builds a REST client for a model registry. Handlers that take the
+// registry from the request body rather than the path use it directly.
+func (app *App) newModelRegistryRESTClient(r *http.Request, namespace string, modelRegistryID string) (httpclient.HTTPClientInterface, error) {
+	client, err := app.kubernetesClientFactory.GetClient(r.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes client: %w", err)
+	}
 
-		// Set up a child logger for the rest client that automatically adds the request id to all statements for
-		// tracing.
-		restClientLogger := app.logger
-		traceId, ok := r.Context().Value(constants.TraceIdKey).(string)
-		if app.logger != nil {
-			if ok {
-				restClientLogger = app.logger.With(slog.String("trace_id", traceId))
-			} else {
-				app.logger.Warn("Failed to set trace_id for tracing")
-			}
-		}
+	modelRegistry, err := app.repositories.ModelRegistry.GetModelRegistryWithMode(r.Context(), client, namespace, modelRegistryID, app.config.DeploymentMode.IsFederatedMode())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errModelRegistryNotFound, err)
+	}
+	modelRegistryBaseURL := modelRegistry.ServerAddress
 
-		// Prepare headers for the REST client
-		headers := http.Header{}
-
-		// If using user token authentication, extract and forward the authorization header
-		if app.config.AuthMethod == config.AuthMethodUser {
-			identity, ok := r.Context().Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
-			if ok && identity != nil && identity.Token != "" {
-				// Always send as "Authorization: Bearer <token>" regardless of incoming header format
-				// The identity.Token already has any prefix removed by ExtractRequestIdentity
-				authHeaderValue := "Bearer " + identity.Token
-				headers.Set("Authorization", authHeaderValue)
-			}
+	// If we are in dev mode, we need to resolve the server address to the local host
+	// to allow the client to connect to the model registry via port forwarded from the cluster to the local machine.
+	// If you are in federated mode, we do not want to override the server address.
+	if app.config.DevMode && !app.config.DeploymentMode.IsFederatedMode() {
+		modelRegistryBaseURL = app.repositories.ModelRegistry.ResolveServerAddress("localhost", int32(app.config.DevModeModelRegistryPort), modelRegistry.IsHTTPS, "", app.config.DeploymentMode.IsFederatedMode())
+	}
+
+	// Set up a child logger for the rest client that automatically adds the request id to all statements for
+	// tracing.
+	restClientLogger := app.logger
+	traceId, ok := r.Context().Value(constants.TraceIdKey).(string)
+	if app.logger != nil {
+		if ok {
+			restClientLogger = app.logger.With(slog.String("trace_id", traceId))
+		} else {
+			app.logger.Warn("Failed to set trace_id for tracing")
 		}
+	}
 
-		restHttpClient, err := httpclient.NewHTTPClient(restClientLogger, modelRegistryBaseURL, headers, app.config.InsecureSkipVerify, app.rootCAs)
-		if err != nil {
-			app.serverErrorResponse(w, r, fmt.Errorf("failed to create HTTP client: %v", err))
-			return
+	// Prepare headers for the REST client
+	headers := http.Header{}
+
+	// If using user token authentication, extract and forward the authorization header
+	if app.config.AuthMethod == config.AuthMethodUser {
+		identity, ok := r.Context().Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+		if ok && identity != nil && identity.Token != "" {
+			// Always send as "Authorization: Bearer <token>" regardless of incoming header format
+			// The identity.Token already has any prefix removed by ExtractRequestIdentity
+			authHeaderValue := "Bearer " + identity.Token
+			headers.Set("Authorization", authHeaderValue)
 		}
-		ctx := context.WithValue(r.Context(), constants.ModelRegistryHttpClientKey, restHttpClient)
-		next(w, r.WithContext(ctx), ps)
 	}
+
+	restHttpClient, err := httpclient.NewHTTPClient(restClientLogger, modelRegistryBaseURL, headers, app.config.InsecureSkipVerify, app.rootCAs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %v", err)
+	}
+	return restHttpClient, nil
 }
 
 func (app *App) AttachNamespace(next func(http.ResponseWriter, *http.Request, httprouter.Params)) httprouter.Handle {
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/kubeflow/hub/ui/bff/internal/constants"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
+)
+
+type CatalogSourceSyncStatusEnvelope Envelope[*models.CatalogSourceSyncStatus, None]
+type CatalogSourceSyncScheduleEnvelope Envelope[*models.CatalogSourceSyncSchedule, None]
+type CatalogSourceSyncRecordEnvelope Envelope[*models.CatalogSourceSyncRecord, None]
+type CatalogSourceSyncHistoryEnvelope Envelope[*models.CatalogSourceSyncHistory, None]
+type CatalogSourceSyncRunListEnvelope Envelope[*models.CatalogSourceSyncRunList, None]
+
+// GetCatalogSourceSyncStatusHandler returns the sync schedule and last sync of a catalog
+// source.
+func (app *App) GetCatalogSourceSyncStatusHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("catalog client not found"))
+		return
+	}
+
+	status, err := app.repositories.ModelCatalogSourceSync.GetSyncStatus(ctx, client, namespace, ps.ByName(CatalogSourceId))
+	if err != nil {
+		app.catalogSourceSyncErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, CatalogSourceSyncStatusEnvelope{Data: status}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *App) UpdateCatalogSourceSyncScheduleHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("catalog client not found"))
+		return
+	}
+
+	var envelope CatalogSourceSyncScheduleEnvelope
+	if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("error decoding JSON: %w", err))
+		return
+	}
+	if envelope.Data == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("data is required"))
+		return
+	}
+
+	status, err := app.repositories.ModelCatalogSourceSync.UpdateSyncSchedule(ctx, client, namespace, ps.ByName(CatalogSourceId), *envelope.Data)
+	if err != nil {
+		app.catalogSourceSyncErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, CatalogSourceSyncStatusEnvelope{Data: status}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// SyncCatalogSourceHandler syncs a catalog source now and returns the outcome.
+func (app *App) SyncCatalogSourceHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return
+	}
+
+	catalogClient, ok := ctx.Value(constants.ModelCatalogHttpClientKey).(httpclient.HTTPClientInterface)
+	if !ok {
+		app.serverErrorResponse(w, r, errors.New("REST client not found"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("catalog client not found"))
+		return
+	}
+
+	record, err := app.repositories.ModelCatalogSourceSync.SyncCatalogSource(ctx, client, catalogClient, namespace, ps.ByName(CatalogSourceId))
+	if err != nil {
+		app.catalogSourceSyncErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, CatalogSourceSyncRecordEnvelope{Data: record}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// RunScheduledCatalogSourceSyncsHandler runs the scheduled syncs that are due in the
+// namespace. It is called by the model-catalog-sync CronJob.
+func (app *App) RunScheduledCatalogSourceSyncsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return
+	}
+
+	catalogClient, ok := ctx.Value(constants.ModelCatalogHttpClientKey).(httpclient.HTTPClientInterface)
+	if !ok {
+		app.serverErrorResponse(w, r, errors.New("REST client not found"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("catalog client not found"))
+		return
+	}
+
+	runs, err := app.repositories.ModelCatalogSourceSync.RunScheduledSyncs(ctx, client, catalogClient, namespace)
+	if err != nil {
+		app.catalogSourceSyncErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, CatalogSourceSyncRunListEnvelope{Data: runs}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetCatalogSourceSyncDiffHandler returns the models added, removed and updated by the last
+// sync of a catalog source.
+func (app *App) GetCatalogSourceSyncDiffHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("catalog client not found"))
+		return
+	}
+
+	record, err := app.repositories.ModelCatalogSourceSync.GetLastSyncDiff(ctx, client, namespace, ps.ByName(CatalogSourceId))
+	if err != nil {
+		app.catalogSourceSyncErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, CatalogSourceSyncRecordEnvelope{Data: record}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *App) GetCatalogSourceSyncHistoryHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("catalog client not found"))
+		return
+	}
+
+	history, err := app.repositories.ModelCatalogSourceSync.GetSyncHistory(ctx, client, namespace, ps.ByName(CatalogSourceId))
+	if err != nil {
+		app.catalogSourceSyncErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, CatalogSourceSyncHistoryEnvelope{Data: history}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *App) catalogSourceSyncErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrCatalogSourceNotFound),
+		errors.Is(err, repositories.ErrCatalogSourceSyncNotRun):
+		app.notFoundResponse(w, r)
+	case errors.Is(err, repositories.ErrCatalogSourceSyncInvalidSchedule),
+		errors.Is(err, repositories.ErrCatalogSourceSyncSourceDisabled):
+		app.badRequestResponse(w, r, err)
+	case errors.Is(err, repositories.ErrCatalogSourceSyncConflict):
+		app.conflictResponse(w, r, err.Error())
+	default:
+		app.serverErrorResponse(w, r, err)
+	}
+}
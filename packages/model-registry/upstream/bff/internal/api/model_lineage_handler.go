@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/kubeflow/hub/ui/bff/internal/constants"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
+)
+
+type ModelLineageEnvelope Envelope[*models.ModelLineage, None]
+
+// GetModelVersionLineageHandler returns the lineage graph of a model version. Transfer jobs
+// are looked up in the optional jobNamespace query parameter and in the namespaces recorded
+// on the version's artifacts.
+func (app *App) GetModelVersionLineageHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return
+	}
+
+	restClient, ok := ctx.Value(constants.ModelRegistryHttpClientKey).(httpclient.HTTPClientInterface)
+	if !ok {
+		app.serverErrorResponse(w, r, errors.New("REST client not found"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("kubernetes client not found"))
+		return
+	}
+
+	lineage, err := app.repositories.ModelLineage.GetModelVersionLineage(ctx, restClient, client, namespace, ps.ByName(ModelRegistryId), r.URL.Query().Get("jobNamespace"), ps.ByName(ModelVersionId))
+	if err != nil {
+		if errors.Is(err, repositories.ErrModelVersionNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, ModelLineageEnvelope{Data: lineage}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,299 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/kubeflow/hub/ui/bff/internal/constants"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
+)
+
+type PromotionPolicyEnvelope Envelope[*models.ModelPromotionPolicy, None]
+type PromotionRequestEnvelope Envelope[*models.ModelPromotionRequest, None]
+type PromotionRequestListEnvelope Envelope[*models.ModelPromotionRequestList, None]
+type PromotionRequestCreateEnvelope Envelope[*models.ModelPromotionRequestCreate, None]
+type PromotionDecisionEnvelope Envelope[*models.ModelPromotionDecision, None]
+type PromotionAuditLogEnvelope Envelope[*models.ModelPromotionAuditLog, None]
+
+func (app *App) GetPromotionPolicyHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, _, store, ok := app.promotionRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	policy, err := app.repositories.ModelPromotion.GetPromotionPolicy(ctx, store, namespace, ps.ByName(ModelRegistryId))
+	if err != nil {
+		app.promotionErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, PromotionPolicyEnvelope{Data: policy}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// UpdatePromotionPolicyHandler replaces the promotion policy of a model registry. Only
+// cluster admins may change it.
+func (app *App) UpdatePromotionPolicyHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, client, store, ok := app.promotionRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return
+	}
+	isAdmin, err := client.IsClusterAdmin(identity)
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to check cluster admin: %w", err))
+		return
+	}
+	if !isAdmin {
+		app.forbiddenResponse(w, r, "only cluster admins can change the promotion policy")
+		return
+	}
+
+	var envelope PromotionPolicyEnvelope
+	if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("error decoding JSON: %w", err))
+		return
+	}
+	if envelope.Data == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("data is required"))
+		return
+	}
+
+	policy, err := app.repositories.ModelPromotion.UpdatePromotionPolicy(ctx, store, namespace, ps.ByName(ModelRegistryId), *envelope.Data)
+	if err != nil {
+		app.promotionErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, PromotionPolicyEnvelope{Data: policy}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetAllPromotionRequestsHandler lists promotion requests, optionally filtered by the
+// modelVersionId and status query parameters.
+func (app *App) GetAllPromotionRequestsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, _, store, ok := app.promotionRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	requests, err := app.repositories.ModelPromotion.GetAllPromotionRequests(ctx, store, namespace, ps.ByName(ModelRegistryId), r.URL.Query().Get("modelVersionId"), r.URL.Query().Get("status"))
+	if err != nil {
+		app.promotionErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, PromotionRequestListEnvelope{Data: requests}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *App) GetPromotionRequestHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, _, store, ok := app.promotionRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	request, err := app.repositories.ModelPromotion.GetPromotionRequest(ctx, store, namespace, ps.ByName(ModelRegistryId), ps.ByName(PromotionRequestId))
+	if err != nil {
+		app.promotionErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, PromotionRequestEnvelope{Data: request}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+func (app *App) CreatePromotionRequestHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, client, store, ok := app.promotionRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	restClient, ok := ctx.Value(constants.ModelRegistryHttpClientKey).(httpclient.HTTPClientInterface)
+	if !ok {
+		app.serverErrorResponse(w, r, errors.New("REST client not found"))
+		return
+	}
+
+	var envelope PromotionRequestCreateEnvelope
+	if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("error decoding JSON: %w", err))
+		return
+	}
+	if envelope.Data == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("data is required"))
+		return
+	}
+
+	requester, _, err := promotionActor(ctx, client)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	request, err := app.repositories.ModelPromotion.CreatePromotionRequest(ctx, store, restClient, app.optionalModelCatalogRESTClient(r, namespace), namespace, ps.ByName(ModelRegistryId), requester, *envelope.Data)
+	if err != nil {
+		app.promotionErrorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.JoinPath(request.Id).String())
+	if err := app.WriteJSON(w, http.StatusCreated, PromotionRequestEnvelope{Data: request}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// DecidePromotionRequestHandler records an approval or rejection of a promotion request by
+// the calling user.
+func (app *App) DecidePromotionRequestHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, client, store, ok := app.promotionRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	restClient, ok := ctx.Value(constants.ModelRegistryHttpClientKey).(httpclient.HTTPClientInterface)
+	if !ok {
+		app.serverErrorResponse(w, r, errors.New("REST client not found"))
+		return
+	}
+
+	var envelope PromotionDecisionEnvelope
+	if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		app.badRequestResponse(w, r, fmt.Errorf("error decoding JSON: %w", err))
+		return
+	}
+	if envelope.Data == nil {
+		app.badRequestResponse(w, r, fmt.Errorf("data is required"))
+		return
+	}
+
+	actor, groups, err := promotionActor(ctx, client)
+	if err != nil {
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	request, err := app.repositories.ModelPromotion.DecidePromotionRequest(ctx, store, restClient, app.optionalModelCatalogRESTClient(r, namespace), namespace, ps.ByName(ModelRegistryId), ps.ByName(PromotionRequestId), actor, groups, *envelope.Data)
+	if err != nil {
+		app.promotionErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, PromotionRequestEnvelope{Data: request}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// GetPromotionAuditLogHandler returns the promotion audit log, optionally for the model
+// version of the modelVersionId query parameter.
+func (app *App) GetPromotionAuditLogHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, _, store, ok := app.promotionRequestContext(w, r)
+	if !ok {
+		return
+	}
+
+	auditLog, err := app.repositories.ModelPromotion.GetPromotionAuditLog(ctx, store, namespace, ps.ByName(ModelRegistryId), r.URL.Query().Get("modelVersionId"))
+	if err != nil {
+		app.promotionErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.WriteJSON(w, http.StatusOK, PromotionAuditLogEnvelope{Data: auditLog}, nil); err != nil {
+		app.serverErrorResponse(w, r, err)
+	}
+}
+
+// promotionRequestContext reads the namespace and Kubernetes clients every promotion handler
+// needs, writing the error response when one is missing. client acts as the caller and is
+// used to identify them; store acts as the BFF service account and is the only client that
+// reads or writes the promotion ConfigMaps, so callers cannot edit records or the audit log
+// with their own token.
+func (app *App) promotionRequestContext(w http.ResponseWriter, r *http.Request) (string, kubernetes.KubernetesClientInterface, kubernetes.KubernetesClientInterface, bool) {
+	namespace, ok := r.Context().Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return "", nil, nil, false
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(r.Context())
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("kubernetes client not found"))
+		return "", nil, nil, false
+	}
+	store, err := app.ServiceAccountClient(r.Context())
+	if err != nil {
+		app.serverErrorResponse(w, r, fmt.Errorf("failed to get service account client: %w", err))
+		return "", nil, nil, false
+	}
+	return namespace, client, store, true
+}
+
+// promotionActor returns the calling user and their groups.
+func promotionActor(ctx context.Context, client kubernetes.KubernetesClientInterface) (string, []string, error) {
+	identity, ok := ctx.Value(constants.RequestIdentityKey).(*kubernetes.RequestIdentity)
+	if !ok || identity == nil {
+		return "", nil, fmt.Errorf("missing RequestIdentity in context")
+	}
+	user, err := client.GetUser(identity)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	groups, err := client.GetUserGroups(identity)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+	return user, groups, nil
+}
+
+func (app *App) promotionErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrPromotionRequestNotFound),
+		errors.Is(err, repositories.ErrModelVersionNotFound):
+		app.notFoundResponse(w, r)
+	case errors.Is(err, repositories.ErrPromotionPolicyInvalid),
+		errors.Is(err, repositories.ErrPromotionStageNotFound),
+		errors.Is(err, repositories.ErrPromotionRequestInvalid),
+		errors.Is(err, repositories.ErrPromotionInvalidDecision):
+		app.badRequestResponse(w, r, err)
+	case errors.Is(err, repositories.ErrPromotionNotAllowed):
+		app.forbiddenResponse(w, r, err.Error())
+	case errors.Is(err, repositories.ErrPromotionRequestExists),
+		errors.Is(err, repositories.ErrPromotionRequestClosed),
+		errors.Is(err, repositories.ErrPromotionRequestConflict),
+		errors.Is(err, repositories.ErrPromotionAlreadyApproved),
+		errors.Is(err, repositories.ErrPromotionPolicyConflict):
+		app.conflictResponse(w, r, err.Error())
+	default:
+		app.serverErrorResponse(w, r, err)
+	}
+}
@@ -1,11 +1,13 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/kubeflow/hub/ui/bff/internal/constants"
@@ -18,6 +20,14 @@ type ModelTransferJobListEnvelope Envelope[*models.ModelTransferJobList, None]
 type ModelTransferJobEnvelope Envelope[*models.ModelTransferJob, None]
 type ModelTransferJobEventsEnvelope Envelope[models.ModelTransferJobEventsResponse, None]
 
+const (
+	// modelTransferJobStatusPollInterval is how often the status stream re-reads the job.
+	modelTransferJobStatusPollInterval = 2 * time.Second
+	// modelTransferJobStatusKeepAlive bounds the silence on the status stream so proxies
+	// do not close it while a large transfer makes no visible progress.
+	modelTransferJobStatusKeepAlive = 15 * time.Second
+)
+
 func (app *App) GetAllModelTransferJobsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	ctx := r.Context()
 
@@ -376,6 +386,134 @@ func (app *App) GetModelTransferJobEventsHandler(w http.ResponseWriter, r *http.
 	}
 }
 
+// GetModelTransferJobStatusStreamHandler streams the status and progress of a transfer job
+// as server-sent events. A "status" event carrying the job is sent whenever it changes; the
+// stream ends after the job reaches a terminal status, or with a "deleted" event when the
+// job goes away.
+func (app *App) GetModelTransferJobStatusStreamHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := r.Context()
+
+	namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+	if !ok || namespace == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("missing namespace in context"))
+		return
+	}
+
+	client, err := app.kubernetesClientFactory.GetClient(ctx)
+	if err != nil {
+		app.serverErrorResponse(w, r, errors.New("kubernetes client not found"))
+		return
+	}
+
+	jobName := ps.ByName(ModelTransferJobName)
+	if jobName == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("job name is required"))
+		return
+	}
+
+	jobNamespace, err := getRequiredJobNamespace(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+	if err := app.authorizeJobNamespace(ctx, client, jobNamespace); err != nil {
+		app.forbiddenResponse(w, r, err.Error())
+		return
+	}
+	modelRegistryID := ps.ByName(ModelRegistryId)
+	if modelRegistryID == "" {
+		app.badRequestResponse(w, r, fmt.Errorf("model registry name is required"))
+		return
+	}
+
+	// The first read decides the response status, so a missing job is a plain 404.
+	job, err := app.repositories.ModelRegistry.GetModelTransferJob(ctx, client, jobNamespace, jobName, modelRegistryID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrJobNotFound) {
+			app.notFoundResponse(w, r)
+			return
+		}
+		app.serverErrorResponse(w, r, err)
+		return
+	}
+
+	// The stream outlives the server write timeout for as long as the transfer runs.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		app.logger.Debug("could not clear write deadline for transfer job status stream", "error", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	var last []byte
+	lastWrite := time.Now()
+	ticker := time.NewTicker(modelTransferJobStatusPollInterval)
+	defer ticker.Stop()
+
+	for {
+		payload, err := json.Marshal(ModelTransferJobEnvelope{Data: job})
+		if err != nil {
+			app.LogError(r, fmt.Errorf("error encoding transfer job status: %w", err))
+			return
+		}
+		if !bytes.Equal(payload, last) {
+			if err := writeServerSentEvent(rc, w, "status", payload); err != nil {
+				return
+			}
+			last = payload
+			lastWrite = time.Now()
+		} else if time.Since(lastWrite) >= modelTransferJobStatusKeepAlive {
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			_ = rc.Flush()
+			lastWrite = time.Now()
+		}
+
+		if isTerminalTransferJobStatus(job.Status) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		job, err = app.repositories.ModelRegistry.GetModelTransferJob(ctx, client, jobNamespace, jobName, modelRegistryID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrJobNotFound) {
+				_ = writeServerSentEvent(rc, w, "deleted", []byte(`{}`))
+				return
+			}
+			if ctx.Err() == nil {
+				app.LogError(r, fmt.Errorf("error reading transfer job status: %w", err))
+				_ = writeServerSentEvent(rc, w, "error", []byte(`{"message":"failed to read transfer job status"}`))
+			}
+			return
+		}
+	}
+}
+
+func writeServerSentEvent(rc *http.ResponseController, w http.ResponseWriter, event string, data []byte) error {
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+func isTerminalTransferJobStatus(status models.ModelTransferJobStatus) bool {
+	switch status {
+	case models.ModelTransferJobStatusCompleted, models.ModelTransferJobStatusFailed, models.ModelTransferJobStatusCancelled:
+		return true
+	}
+	return false
+}
+
 func getRequiredJobNamespace(r *http.Request) (string, error) {
 	jobNamespace := r.URL.Query().Get("jobNamespace")
 	if jobNamespace == "" {
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
 	"github.com/kubeflow/hub/ui/bff/internal/models"
@@ -1458,6 +1460,68 @@ var _ = Describe("TestModelTransferJob", func() {
 	})
 })
 
+var _ = Describe("TestModelTransferJob status stream", func() {
+	var requestIdentity kubernetes.RequestIdentity
+
+	BeforeEach(func() {
+		requestIdentity = kubernetes.RequestIdentity{
+			UserID: "user@example.com",
+		}
+	})
+
+	Context("streaming model transfer job status", func() {
+		It("GET status stream sends the final status and closes for a completed job", func() {
+			rs, body, err := serveApiTest(
+				http.MethodGet,
+				"/api/v1/model_registry/model-registry/model_transfer_jobs/transfer-job-002/status_stream?namespace=kubeflow&jobNamespace=kubeflow",
+				nil,
+				kubernetesMockedStaticClientFactory,
+				requestIdentity,
+				"kubeflow",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rs.StatusCode).To(Equal(http.StatusOK))
+			Expect(rs.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+			events := strings.Split(strings.TrimSpace(string(body)), "\n\n")
+			Expect(events).To(HaveLen(1))
+			Expect(events[0]).To(HavePrefix("event: status\ndata: "))
+
+			var envelope ModelTransferJobEnvelope
+			Expect(json.Unmarshal([]byte(strings.TrimPrefix(events[0], "event: status\ndata: ")), &envelope)).To(Succeed())
+			Expect(envelope.Data.Status).To(Equal(models.ModelTransferJobStatusCompleted))
+			Expect(envelope.Data.Progress).NotTo(BeNil())
+			Expect(envelope.Data.Progress.Percent).To(Equal(float64(100)))
+		})
+
+		It("GET status stream returns 404 for non-existent job", func() {
+			rs, _, err := serveApiTest(
+				http.MethodGet,
+				"/api/v1/model_registry/model-registry/model_transfer_jobs/does-not-exist/status_stream?namespace=kubeflow&jobNamespace=kubeflow",
+				nil,
+				kubernetesMockedStaticClientFactory,
+				requestIdentity,
+				"kubeflow",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound))
+		})
+
+		It("GET status stream returns 400 when jobNamespace is missing", func() {
+			rs, _, err := serveApiTest(
+				http.MethodGet,
+				"/api/v1/model_registry/model-registry/model_transfer_jobs/transfer-job-002/status_stream?namespace=kubeflow",
+				nil,
+				kubernetesMockedStaticClientFactory,
+				requestIdentity,
+				"kubeflow",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rs.StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
+
 var _ = Describe("TestModelTransferJob registry filtering", func() {
 	var requestIdentity kubernetes.RequestIdentity
 
@@ -10,6 +10,7 @@ import (
 	"github.com/kubeflow/hub/pkg/openapi"
 	"github.com/kubeflow/hub/ui/bff/internal/constants"
 	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
 	"github.com/kubeflow/hub/ui/bff/internal/validation"
 )
 
@@ -143,6 +144,19 @@ func (app *App) UpdateModelVersionHandler(w http.ResponseWriter, r *http.Request
 
 	//TODO add validation - note updating requires different rules to create as fields are optional.
 
+	if err := app.repositories.ModelPromotion.CheckModelVersionUpdate(client, ps.ByName(ModelVersionId), data); err != nil {
+		var httpErr *httpclient.HTTPError
+		switch {
+		case errors.Is(err, repositories.ErrPromotionPropertyManaged):
+			app.badRequestResponse(w, r, err)
+		case errors.As(err, &httpErr):
+			app.errorResponse(w, r, httpErr)
+		default:
+			app.serverErrorResponse(w, r, err)
+		}
+		return
+	}
+
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		app.serverErrorResponse(w, r, fmt.Errorf("error marshaling ModelVersion to JSON: %w", err))
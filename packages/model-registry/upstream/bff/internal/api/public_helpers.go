@@ -8,6 +8,7 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/kubeflow/hub/ui/bff/internal/config"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
 	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
 	"github.com/kubeflow/hub/ui/bff/internal/repositories"
 )
@@ -108,6 +109,26 @@ func (app *App) Repositories() *repositories.Repositories {
 	return app.repositories
 }
 
+// ErrModelCatalogNotFound and ErrModelRegistryNotFound are wrapped by the errors of
+// NewModelCatalogRESTClient and NewModelRegistryRESTClient when the service does not exist.
+var (
+	ErrModelCatalogNotFound  = errModelCatalogNotFound
+	ErrModelRegistryNotFound = errModelRegistryNotFound
+)
+
+// NewModelCatalogRESTClient exposes the model catalog client construction for extensions
+// whose routes are not under the catalog path prefixes. apiPath selects the catalog API,
+// e.g. repositories.ModelCatalogAPIPath or repositories.McpCatalogAPIPath.
+func (app *App) NewModelCatalogRESTClient(r *http.Request, namespace string, apiPath string) (httpclient.HTTPClientInterface, error) {
+	return app.newModelCatalogRESTClient(r, namespace, apiPath)
+}
+
+// NewModelRegistryRESTClient exposes the model registry client construction for extensions
+// that take the registry from the request body rather than the path.
+func (app *App) NewModelRegistryRESTClient(r *http.Request, namespace string, modelRegistryID string) (httpclient.HTTPClientInterface, error) {
+	return app.newModelRegistryRESTClient(r, namespace, modelRegistryID)
+}
+
 // PodNamespace exposes the namespace this pod is running in, for extensions
 // that need it to build inter-BFF service URLs.
 func (app *App) PodNamespace() string {
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes/k8mocks"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// ServiceAccountClient returns a Kubernetes client acting as the BFF's own service
+// account, whatever the auth method. It is for state the BFF keeps on behalf of its
+// users, such as promotion records and the audit log, which callers must not be able
+// to write directly with their own token. Handlers using it are expected to have
+// authorized the caller first. Built lazily on first use, like BFFClientFactory.
+func (app *App) ServiceAccountClient(ctx context.Context) (k8s.KubernetesClientInterface, error) {
+	app.serviceAccountClientFactoryOnce.Do(func() {
+		app.serviceAccountClientFactory, app.serviceAccountClientFactoryErr = newServiceAccountClientFactory(app.testEnv, app.logger)
+	})
+	if app.serviceAccountClientFactoryErr != nil {
+		return nil, app.serviceAccountClientFactoryErr
+	}
+	return app.serviceAccountClientFactory.GetClient(ctx)
+}
+
+// SetServiceAccountClientFactoryForTest lets tests inject the factory behind
+// ServiceAccountClient into an App built via NewTestApp.
+func (app *App) SetServiceAccountClientFactoryForTest(factory k8s.KubernetesClientFactory) {
+	app.serviceAccountClientFactoryOnce.Do(func() {
+		app.serviceAccountClientFactory = factory
+	})
+}
+
+// newServiceAccountClientFactory uses the envtest admin config when the Kubernetes
+// client is mocked, and the pod's service account (or local kubeconfig) otherwise.
+func newServiceAccountClientFactory(testEnv *envtest.Environment, logger *slog.Logger) (k8s.KubernetesClientFactory, error) {
+	if testEnv != nil {
+		clientset, err := kubernetes.NewForConfig(testEnv.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create envtest service account client: %w", err)
+		}
+		return k8mocks.NewStaticClientFactory(clientset, logger)
+	}
+	return k8s.NewStaticClientFactory(logger)
+}
@@ -126,4 +126,15 @@ type EnvConfig struct {
 	BFFMLflowAuthMethod      string
 	BFFMLflowAuthTokenHeader string
 	BFFMLflowAuthTokenPrefix string
+
+	// ─── INTER-BFF (eval-hub) ───────────────────────────────────
+	// Used to find the InferenceServices and evaluation jobs of a model
+	// version for its lineage graph.
+	BFFEvalHubServiceName     string
+	BFFEvalHubServicePort     int
+	BFFEvalHubTLSEnabled      bool
+	BFFEvalHubDevURL          string
+	BFFEvalHubAuthMethod      string
+	BFFEvalHubAuthTokenHeader string
+	BFFEvalHubAuthTokenPrefix string
 }
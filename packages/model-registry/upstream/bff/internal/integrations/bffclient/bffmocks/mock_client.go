@@ -42,6 +42,8 @@ func (m *MockBFFClient) Call(ctx context.Context, method, path string, body inte
 	switch m.target {
 	case bffclient.BFFTargetMLflow:
 		return m.handleMLflowCall(ctx, method, path, body, response)
+	case bffclient.BFFTargetEvalHub:
+		return m.handleEvalHubCall(ctx, method, path, body, response)
 	default:
 		return bffclient.NewBFFClientErrorWithTarget(bffclient.ErrCodeNotFound, fmt.Sprintf("mock not implemented for target %s", m.target), m.target, 404)
 	}
@@ -63,6 +65,49 @@ func (m *MockBFFClient) handleMLflowCall(ctx context.Context, method, path strin
 	}
 }
 
+// handleEvalHubCall handles mock calls to the eval-hub BFF. Every namespace has one
+// ready InferenceService, evaluated by one completed evaluation job.
+func (m *MockBFFClient) handleEvalHubCall(ctx context.Context, method, path string, body interface{}, response interface{}) error {
+	namespace := mockQueryParam(path, "namespace")
+	isvcURL := fmt.Sprintf("https://mock-model-predictor.%s.svc.cluster.local", namespace)
+
+	switch {
+	case strings.HasPrefix(path, "/inferenceservices") && method == "GET":
+		isvc := map[string]interface{}{
+			"name":              "mock-model",
+			"url":               isvcURL,
+			"ready":             true,
+			"model_format_name": "vLLM",
+		}
+		return marshalToResponse(map[string]interface{}{"data": map[string]interface{}{"items": []interface{}{isvc}}}, response)
+
+	case strings.HasPrefix(path, "/evaluations/jobs") && method == "GET":
+		job := map[string]interface{}{
+			"resource": map[string]interface{}{"id": "mock-eval-job-1", "created_at": "2026-01-02T15:04:05Z"},
+			"status":   map[string]interface{}{"state": "completed"},
+			"name":     "mock-model-eval",
+			"model":    map[string]interface{}{"name": "mock-model", "url": isvcURL + "/v1"},
+		}
+		return marshalToResponse(map[string]interface{}{"data": []interface{}{job}}, response)
+
+	default:
+		return bffclient.NewNotFoundError(m.target, fmt.Sprintf("mock not implemented for %s %s", method, path))
+	}
+}
+
+// mockQueryParam returns a query parameter of a mock call path.
+func mockQueryParam(path string, key string) string {
+	idx := strings.Index(path, "?")
+	if idx == -1 {
+		return ""
+	}
+	values, err := url.ParseQuery(path[idx+1:])
+	if err != nil {
+		return ""
+	}
+	return values.Get(key)
+}
+
 // extractMockMCPServerName pulls the server name back out of a
 // "/mcp-registry/servers/<name>?workspace=<ns>" path. Names may contain "/"
 // (the upstream <namespace>/<slug> convention) so it's not URL-escaped.
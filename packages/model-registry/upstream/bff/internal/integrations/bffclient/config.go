@@ -7,14 +7,16 @@ import (
 
 // BFFTarget represents a target BFF service.
 //
-// Only the MLflow target is defined today because the model-registry BFF only
-// needs inter-BFF communication to resolve MCP Registry server details (see
-// docs/inter-bff-communication.md). Add new BFFTarget* consts here following
-// the same pattern if additional targets are needed later.
+// The model-registry BFF uses inter-BFF communication to resolve MCP Registry
+// server details from the MLflow BFF, and to find the InferenceServices and
+// evaluation jobs of a model version from the eval-hub BFF for its lineage
+// graph (see docs/inter-bff-communication.md). Add new BFFTarget* consts here
+// following the same pattern if additional targets are needed later.
 type BFFTarget string
 
 const (
-	BFFTargetMLflow BFFTarget = "mlflow"
+	BFFTargetMLflow  BFFTarget = "mlflow"
+	BFFTargetEvalHub BFFTarget = "eval-hub"
 )
 
 // Supported values for BFFServiceConfig.AuthMethod.
@@ -78,7 +80,8 @@ type BFFClientConfig struct {
 }
 
 // NewDefaultBFFClientConfig creates a default BFF client configuration
-// with the MLflow BFF configured for the standalone (per-module) deployment mode.
+// with the MLflow and eval-hub BFFs configured for the standalone (per-module)
+// deployment mode.
 func NewDefaultBFFClientConfig() *BFFClientConfig {
 	return &BFFClientConfig{
 		MockBFFClients: false,
@@ -93,6 +96,16 @@ func NewDefaultBFFClientConfig() *BFFClientConfig {
 				AuthTokenHeader: "x-forwarded-access-token",
 				AuthTokenPrefix: "",
 			},
+			BFFTargetEvalHub: {
+				Target:          BFFTargetEvalHub,
+				ServiceName:     "odh-dashboard-eval-hub-ui",
+				Port:            8543,
+				PathPrefix:      "/api/v1",
+				TLSEnabled:      true,
+				AuthMethod:      "user_token",
+				AuthTokenHeader: "x-forwarded-access-token",
+				AuthTokenPrefix: "",
+			},
 		},
 		PodNamespace:       "",
 		InsecureSkipVerify: false,
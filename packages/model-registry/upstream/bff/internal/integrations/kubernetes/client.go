@@ -18,6 +18,9 @@ const CatalogSourceUserConfigMapName = "model-catalog-sources"
 const McpServerAPIGroup = "mcp.x-k8s.io"
 const McpServerResource = "mcpservers"
 
+const InferenceServiceAPIGroup = "serving.kserve.io"
+const InferenceServiceResource = "inferenceservices"
+
 const McpCatalogSourceKey = "sources.yaml"
 const McpCatalogSourceDefaultConfigMapName = CatalogSourceDefaultConfigMapName
 const McpCatalogSourceUserConfigMapName = "mcp-catalog-sources"
@@ -39,12 +42,14 @@ type KubernetesClientInterface interface {
 	CanAccessServiceInNamespace(ctx context.Context, identity *RequestIdentity, namespace, serviceName string) (bool, error)
 	CanNamespaceAccessRegistry(ctx context.Context, identity *RequestIdentity, jobNamespace, registryName, registryNamespace string) (bool, error)
 	CanVerbMcpServersInNamespace(ctx context.Context, identity *RequestIdentity, namespace, verb string) (bool, error)
+	CanVerbInferenceServicesInNamespace(ctx context.Context, identity *RequestIdentity, namespace, verb string) (bool, error)
 	GetSelfSubjectRulesReview(ctx context.Context, identity *RequestIdentity, namespace string) ([]string, error)
 
 	// Meta
 	IsClusterAdmin(identity *RequestIdentity) (bool, error)
 	BearerToken() (string, error)
 	GetUser(identity *RequestIdentity) (string, error)
+	GetUserGroups(identity *RequestIdentity) ([]string, error)
 
 	// Model Registry Settings
 	GetGroups(ctx context.Context) ([]string, error)
@@ -66,6 +71,7 @@ type KubernetesClientInterface interface {
 	CreateModelTransferJob(ctx context.Context, namespace string, job *batchv1.Job) (*batchv1.Job, error)
 	GetTransferJobPods(ctx context.Context, namespace string, jobNames []string) (*corev1.PodList, error)
 	GetEventsForPods(ctx context.Context, namespace string, podNames []string) (*corev1.EventList, error)
+	GetTransferJobPodLogs(ctx context.Context, namespace string, podName string, tailLines int64) (string, error)
 	DeleteModelTransferJob(ctx context.Context, namespace string, jobName string) error
 	CreateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error)
 	DeleteConfigMap(ctx context.Context, namespace string, name string) error
@@ -74,4 +80,8 @@ type KubernetesClientInterface interface {
 	GetSecret(ctx context.Context, namespace string, name string) (*corev1.Secret, error)
 	PatchSecretOwnerReference(ctx context.Context, namespace string, name string, ownerRef metav1.OwnerReference) error
 	PatchConfigMapOwnerReference(ctx context.Context, namespace string, name string, ownerRef metav1.OwnerReference) error
+
+	// Model promotions
+	ListConfigMaps(ctx context.Context, namespace string, labelSelector string) (*corev1.ConfigMapList, error)
+	UpdateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error)
 }
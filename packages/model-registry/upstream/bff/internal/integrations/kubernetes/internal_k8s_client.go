@@ -180,6 +180,37 @@ func (kc *InternalKubernetesClient) CanVerbMcpServersInNamespace(ctx context.Con
 	return true, nil
 }
 
+func (kc *InternalKubernetesClient) CanVerbInferenceServicesInNamespace(ctx context.Context, identity *RequestIdentity, namespace, verb string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   identity.UserID,
+			Groups: identity.Groups,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Verb:      verb,
+				Group:     InferenceServiceAPIGroup,
+				Resource:  InferenceServiceResource,
+				Namespace: namespace,
+			},
+		},
+	}
+
+	resp, err := kc.Client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		kc.Logger.Error("SAR failed for InferenceService access", "user", identity.UserID, "verb", verb, "namespace", namespace, "error", err)
+		return false, err
+	}
+
+	if !resp.Status.Allowed {
+		kc.Logger.Warn("InferenceService access denied", "user", identity.UserID, "verb", verb, "namespace", namespace)
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // GetSelfSubjectRulesReview gets the rules for what a user can access in a namespace
 func (kc *InternalKubernetesClient) GetSelfSubjectRulesReview(ctx context.Context, identity *RequestIdentity, namespace string) ([]string, error) {
 	kc.Logger.Warn("GetSelfSubjectRulesReview not fully implemented for internal client",
@@ -356,3 +387,8 @@ func (kc *InternalKubernetesClient) GetUser(identity *RequestIdentity) (string,
 	// On internal client, we can use the identity from request directly
 	return identity.UserID, nil
 }
+
+func (kc *InternalKubernetesClient) GetUserGroups(identity *RequestIdentity) ([]string, error) {
+	// On internal client, groups come from the request headers
+	return identity.Groups, nil
+}
@@ -1055,6 +1055,7 @@ func createModelTransferJob(k8sClient kubernetes.Interface, ctx context.Context,
 				"modelregistry.kubeflow.org/upload-intent":       "create_model",
 				"modelregistry.kubeflow.org/author":              "John Watson",
 				"modelregistry.kubeflow.org/description":         "Create new model - completed successfully",
+				"modelregistry.kubeflow.org/job-capabilities":    "progress,resume",
 			},
 		},
 		Spec: batchv1.JobSpec{
@@ -606,6 +606,45 @@ func (kc *SharedClientLogic) DeleteConfigMap(ctx context.Context, namespace stri
 
 }
 
+// ListConfigMaps returns the ConfigMaps in namespace matching labelSelector.
+func (kc *SharedClientLogic) ListConfigMaps(ctx context.Context, namespace string, labelSelector string) (*corev1.ConfigMapList, error) {
+	sessionLogger := ctx.Value(constants.TraceLoggerKey).(*slog.Logger)
+
+	configMaps, err := kc.Client.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		sessionLogger.Error("failed to list configmaps",
+			"namespace", namespace,
+			"labelSelector", labelSelector,
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to list configmaps: %w", err)
+	}
+
+	return configMaps, nil
+}
+
+// UpdateConfigMap replaces a ConfigMap. The update is rejected with a conflict when
+// configMap.ResourceVersion is set and no longer current.
+func (kc *SharedClientLogic) UpdateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	sessionLogger := ctx.Value(constants.TraceLoggerKey).(*slog.Logger)
+
+	updated, err := kc.Client.CoreV1().ConfigMaps(namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+	if err != nil {
+		if apierrors.IsConflict(err) {
+			sessionLogger.Debug("configmap was modified concurrently", "namespace", namespace, "name", configMap.Name)
+		} else {
+			sessionLogger.Error("failed to update configmap",
+				"namespace", namespace,
+				"name", configMap.Name,
+				"error", err,
+			)
+		}
+		return nil, fmt.Errorf("failed to update configmap %s: %w", configMap.Name, err)
+	}
+
+	return updated, nil
+}
+
 func (kc *SharedClientLogic) PatchConfigMapOwnerReference(ctx context.Context, namespace string, name string, ownerRef metav1.OwnerReference) error {
 	sessionLogger := ctx.Value(constants.TraceLoggerKey).(*slog.Logger)
 
@@ -686,6 +725,27 @@ func (kc *SharedClientLogic) GetEventsForPods(ctx context.Context, namespace str
 	return &corev1.EventList{Items: allEvents}, nil
 }
 
+// GetTransferJobPodLogs returns the last tailLines lines of the async-upload container
+// of a transfer job pod. The job reports its progress through these logs.
+func (kc *SharedClientLogic) GetTransferJobPodLogs(ctx context.Context, namespace string, podName string, tailLines int64) (string, error) {
+	if namespace == "" || podName == "" {
+		return "", fmt.Errorf("namespace and pod name cannot be empty")
+	}
+
+	sessionLogger := ctx.Value(constants.TraceLoggerKey).(*slog.Logger)
+
+	raw, err := kc.Client.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: "async-upload",
+		TailLines: &tailLines,
+	}).Do(ctx).Raw()
+	if err != nil {
+		sessionLogger.Debug("failed to get logs for transfer job pod", "namespace", namespace, "pod", podName, "error", err)
+		return "", fmt.Errorf("failed to get logs for pod %s: %w", podName, err)
+	}
+
+	return string(raw), nil
+}
+
 func (kc *SharedClientLogic) PatchSecretOwnerReference(ctx context.Context, namespace string, name string, ownerRef metav1.OwnerReference) error {
 	sessionLogger := ctx.Value(constants.TraceLoggerKey).(*slog.Logger)
 
@@ -188,3 +188,30 @@ func TestGetEventsForPods_FiltersByPodNamesAndHandlesEmptyInputs(t *testing.T) {
 		t.Fatalf("expected no events for empty podNames, got %d", len(eventList.Items))
 	}
 }
+
+func TestGetTransferJobPodLogs_RejectsEmptyInputs(t *testing.T) {
+	//nolint:staticcheck // fake.NewSimpleClientset is sufficient for unit tests; field management is not required here.
+	clientset := fake.NewSimpleClientset()
+	logic := &SharedClientLogic{
+		Client: clientset,
+		Logger: slog.New(slog.Default().Handler()),
+	}
+
+	ctx := withLogger(context.Background())
+
+	if _, err := logic.GetTransferJobPodLogs(ctx, "", "pod-a", 10); err == nil {
+		t.Fatalf("expected error for empty namespace")
+	}
+	if _, err := logic.GetTransferJobPodLogs(ctx, "kubeflow", "", 10); err == nil {
+		t.Fatalf("expected error for empty pod name")
+	}
+
+	// The fake clientset serves a fixed body for any pod log request.
+	logs, err := logic.GetTransferJobPodLogs(ctx, "kubeflow", "pod-a", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logs == "" {
+		t.Fatalf("expected fake logs, got empty string")
+	}
+}
@@ -263,6 +263,35 @@ func (kc *TokenKubernetesClient) CanVerbMcpServersInNamespace(ctx context.Contex
 	return true, nil
 }
 
+func (kc *TokenKubernetesClient) CanVerbInferenceServicesInNamespace(ctx context.Context, _ *RequestIdentity, namespace, verb string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	sar := &authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Verb:      verb,
+				Group:     InferenceServiceAPIGroup,
+				Resource:  InferenceServiceResource,
+				Namespace: namespace,
+			},
+		},
+	}
+
+	resp, err := kc.Client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		kc.Logger.Error("self-SAR failed for InferenceService access", "verb", verb, "namespace", namespace, "error", err)
+		return false, err
+	}
+
+	if !resp.Status.Allowed {
+		kc.Logger.Warn("InferenceService access denied", "verb", verb, "namespace", namespace)
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // RequestIdentity is unused because the token already represents the user identity.
 // This endpoint is used only on dev mode that is why is safe to ignore permissions errors
 func (kc *TokenKubernetesClient) GetNamespaces(ctx context.Context, _ *RequestIdentity) ([]corev1.Namespace, error) {
@@ -314,6 +343,27 @@ func (kc *TokenKubernetesClient) GetUser(_ *RequestIdentity) (string, error) {
 	return username, nil
 }
 
+// GetUserGroups returns the groups of the token's user, as reported by a SelfSubjectReview.
+func (kc *TokenKubernetesClient) GetUserGroups(_ *RequestIdentity) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ssr := &authnv1.SelfSubjectReview{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "SelfSubjectReview",
+			APIVersion: "authentication.k8s.io/v1",
+		},
+	}
+
+	resp, err := kc.Client.AuthenticationV1().SelfSubjectReviews().Create(ctx, ssr, metav1.CreateOptions{})
+	if err != nil {
+		kc.Logger.Error("failed to get user groups from token", "error", err)
+		return nil, fmt.Errorf("failed to get user groups: %w", err)
+	}
+
+	return resp.Status.UserInfo.Groups, nil
+}
+
 func (kc *TokenKubernetesClient) RESTConfig() *rest.Config {
 	return kc.restConfig
 }
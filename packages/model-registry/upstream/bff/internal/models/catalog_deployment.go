@@ -0,0 +1,80 @@
+package models
+
+// CatalogDeploymentKind is what kind of catalog entry a deployment is created from
+type CatalogDeploymentKind string
+
+const (
+	CatalogDeploymentKindModel     CatalogDeploymentKind = "model"
+	CatalogDeploymentKindMcpServer CatalogDeploymentKind = "mcp_server"
+)
+
+// CatalogDeploymentStepStatus is the outcome of one step of a deploy from catalog
+type CatalogDeploymentStepStatus string
+
+const (
+	CatalogDeploymentStepCompleted  CatalogDeploymentStepStatus = "completed"
+	CatalogDeploymentStepSkipped    CatalogDeploymentStepStatus = "skipped"
+	CatalogDeploymentStepRolledBack CatalogDeploymentStepStatus = "rolled_back"
+)
+
+// CatalogDeploymentResources are the resources requested for a deployed model
+type CatalogDeploymentResources struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+	GPU    int    `json:"gpu"`
+}
+
+// CatalogDeploymentRegistration registers the deployed catalog model in a model registry.
+// Either RegisteredModelId, to add a version to an existing model, or RegisteredModelName,
+// to register a new model, is set.
+type CatalogDeploymentRegistration struct {
+	ModelRegistryName   string `json:"modelRegistryName"`
+	RegisteredModelId   string `json:"registeredModelId,omitempty"`
+	RegisteredModelName string `json:"registeredModelName,omitempty"`
+	VersionName         string `json:"versionName"`
+}
+
+type CatalogDeploymentCreateRequest struct {
+	Kind     CatalogDeploymentKind `json:"kind"`
+	SourceId string                `json:"sourceId,omitempty"`
+	// ModelName is the catalog model to deploy when Kind is model.
+	ModelName string `json:"modelName,omitempty"`
+	// McpServerId is the catalog MCP server to deploy when Kind is mcp_server.
+	McpServerId string `json:"mcpServerId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// Namespace is the project to deploy to. It defaults to the namespace query parameter,
+	// which also selects the model catalog and model registry.
+	Namespace string `json:"namespace,omitempty"`
+	// ServingRuntime names the ServingRuntime, or dashboard template, to serve the model with.
+	// A compatible one is picked when it is empty.
+	ServingRuntime string `json:"servingRuntime,omitempty"`
+	// Resources overrides the resources sized from the catalog metadata.
+	Resources    *CatalogDeploymentResources    `json:"resources,omitempty"`
+	Registration *CatalogDeploymentRegistration `json:"registration,omitempty"`
+	// Env sets the environment variables of an MCP server, and must include its required ones.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// CatalogDeploymentStep is one step of a deploy from catalog
+type CatalogDeploymentStep struct {
+	Name     string                      `json:"name"`
+	Status   CatalogDeploymentStepStatus `json:"status"`
+	Resource string                      `json:"resource,omitempty"`
+	Message  string                      `json:"message,omitempty"`
+}
+
+// CatalogDeployment is the result of a deploy from catalog
+type CatalogDeployment struct {
+	Kind                 CatalogDeploymentKind       `json:"kind"`
+	Name                 string                      `json:"name"`
+	Namespace            string                      `json:"namespace"`
+	ServingRuntime       string                      `json:"servingRuntime,omitempty"`
+	Resources            *CatalogDeploymentResources `json:"resources,omitempty"`
+	InferenceServiceName string                      `json:"inferenceServiceName,omitempty"`
+	McpDeployment        *McpDeployment              `json:"mcpDeployment,omitempty"`
+	ModelRegistryName    string                      `json:"modelRegistryName,omitempty"`
+	RegisteredModelId    string                      `json:"registeredModelId,omitempty"`
+	ModelVersionId       string                      `json:"modelVersionId,omitempty"`
+	Steps                []CatalogDeploymentStep     `json:"steps"`
+}
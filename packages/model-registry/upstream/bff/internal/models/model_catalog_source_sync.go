@@ -0,0 +1,88 @@
+package models
+
+// CatalogSourceSyncTrigger is what started a catalog source sync
+type CatalogSourceSyncTrigger string
+
+const (
+	CatalogSourceSyncTriggerManual    CatalogSourceSyncTrigger = "manual"
+	CatalogSourceSyncTriggerScheduled CatalogSourceSyncTrigger = "scheduled"
+)
+
+// CatalogSourceSyncResult is the outcome of a catalog source sync
+type CatalogSourceSyncResult string
+
+const (
+	CatalogSourceSyncResultSucceeded CatalogSourceSyncResult = "SUCCEEDED"
+	CatalogSourceSyncResultFailed    CatalogSourceSyncResult = "FAILED"
+)
+
+// CatalogSourceModelChangeType is how a model changed between two syncs
+type CatalogSourceModelChangeType string
+
+const (
+	CatalogSourceModelChangeAdded   CatalogSourceModelChangeType = "added"
+	CatalogSourceModelChangeRemoved CatalogSourceModelChangeType = "removed"
+	CatalogSourceModelChangeUpdated CatalogSourceModelChangeType = "updated"
+)
+
+// CatalogSourceSyncSchedule configures the periodic sync of a catalog source
+type CatalogSourceSyncSchedule struct {
+	Enabled bool `json:"enabled"`
+	// IntervalMinutes is the time between two scheduled syncs.
+	IntervalMinutes int `json:"intervalMinutes"`
+	// TrackedModels are the model names that raise a notification when they get a new version.
+	TrackedModels []string `json:"trackedModels"`
+}
+
+// CatalogSourceModelChange is one model added, removed or updated between two syncs
+type CatalogSourceModelChange struct {
+	Name            string                       `json:"name"`
+	ChangeType      CatalogSourceModelChangeType `json:"changeType"`
+	PreviousVersion string                       `json:"previousVersion,omitempty"`
+	Version         string                       `json:"version,omitempty"`
+}
+
+// CatalogSourceModelNotification reports a new version of a tracked model
+type CatalogSourceModelNotification struct {
+	ModelName       string `json:"modelName"`
+	PreviousVersion string `json:"previousVersion,omitempty"`
+	Version         string `json:"version"`
+	Message         string `json:"message"`
+}
+
+// CatalogSourceSyncRecord is the outcome of one sync of a catalog source. Changes is only
+// returned for the last sync.
+type CatalogSourceSyncRecord struct {
+	SourceId      string                           `json:"sourceId"`
+	Trigger       CatalogSourceSyncTrigger         `json:"trigger"`
+	Result        CatalogSourceSyncResult          `json:"result"`
+	Error         string                           `json:"error,omitempty"`
+	StartedAt     string                           `json:"startedAt"`
+	CompletedAt   string                           `json:"completedAt"`
+	ModelCount    int                              `json:"modelCount"`
+	Added         int                              `json:"added"`
+	Removed       int                              `json:"removed"`
+	Updated       int                              `json:"updated"`
+	Changes       []CatalogSourceModelChange       `json:"changes,omitempty"`
+	Notifications []CatalogSourceModelNotification `json:"notifications"`
+}
+
+// CatalogSourceSyncStatus is the sync schedule and latest sync of a catalog source
+type CatalogSourceSyncStatus struct {
+	SourceId   string                    `json:"sourceId"`
+	Schedule   CatalogSourceSyncSchedule `json:"schedule"`
+	LastSync   *CatalogSourceSyncRecord  `json:"lastSync,omitempty"`
+	NextSyncAt string                    `json:"nextSyncAt,omitempty"`
+}
+
+// CatalogSourceSyncHistory lists the past syncs of a catalog source, newest first
+type CatalogSourceSyncHistory struct {
+	Items []CatalogSourceSyncRecord `json:"items"`
+	Size  int                       `json:"size"`
+}
+
+// CatalogSourceSyncRunList lists the scheduled syncs run by one pass of the scheduler
+type CatalogSourceSyncRunList struct {
+	Items []CatalogSourceSyncRecord `json:"items"`
+	Size  int                       `json:"size"`
+}
@@ -0,0 +1,102 @@
+package models
+
+// ModelLineageNodeType represents the kind of resource a lineage node stands for
+type ModelLineageNodeType string
+
+const (
+	ModelLineageNodeTypeDataset          ModelLineageNodeType = "dataset"
+	ModelLineageNodeTypePipelineRun      ModelLineageNodeType = "pipeline_run"
+	ModelLineageNodeTypeRegisteredModel  ModelLineageNodeType = "registered_model"
+	ModelLineageNodeTypeModelVersion     ModelLineageNodeType = "model_version"
+	ModelLineageNodeTypeModelArtifact    ModelLineageNodeType = "model_artifact"
+	ModelLineageNodeTypeTransferJob      ModelLineageNodeType = "transfer_job"
+	ModelLineageNodeTypeInferenceService ModelLineageNodeType = "inference_service"
+	ModelLineageNodeTypeEvaluationJob    ModelLineageNodeType = "evaluation_job"
+)
+
+// ModelLineageRelation represents how the source node of an edge led to its target node
+type ModelLineageRelation string
+
+const (
+	ModelLineageRelationInputTo     ModelLineageRelation = "input_to"
+	ModelLineageRelationProduced    ModelLineageRelation = "produced"
+	ModelLineageRelationArtifactOf  ModelLineageRelation = "artifact_of"
+	ModelLineageRelationHasVersion  ModelLineageRelation = "has_version"
+	ModelLineageRelationDeployedAs  ModelLineageRelation = "deployed_as"
+	ModelLineageRelationEvaluatedBy ModelLineageRelation = "evaluated_by"
+)
+
+// ModelLineageNode represents a single resource in a model lineage graph
+type ModelLineageNode struct {
+	Id         string               `json:"id"`
+	Type       ModelLineageNodeType `json:"type"`
+	Name       string               `json:"name"`
+	Namespace  string               `json:"namespace,omitempty"`
+	Status     string               `json:"status,omitempty"`
+	Uri        string               `json:"uri,omitempty"`
+	Properties map[string]string    `json:"properties,omitempty"`
+}
+
+// ModelLineageEdge represents a directed relation between two lineage nodes, pointing
+// from the upstream resource to the one derived from it
+type ModelLineageEdge struct {
+	Source   string               `json:"source"`
+	Target   string               `json:"target"`
+	Relation ModelLineageRelation `json:"relation"`
+}
+
+// ModelLineage represents the lineage graph of a model version, from the data and runs
+// that produced it to the deployments and evaluations that use it
+type ModelLineage struct {
+	RootNodeId string             `json:"rootNodeId"`
+	Nodes      []ModelLineageNode `json:"nodes"`
+	Edges      []ModelLineageEdge `json:"edges"`
+	Warnings   []string           `json:"warnings,omitempty"`
+}
+
+// NewModelLineage creates an empty lineage graph rooted at the given node id
+func NewModelLineage(rootNodeId string) *ModelLineage {
+	return &ModelLineage{
+		RootNodeId: rootNodeId,
+		Nodes:      []ModelLineageNode{},
+		Edges:      []ModelLineageEdge{},
+	}
+}
+
+// HasNode reports whether a node with the given id is already in the graph
+func (l *ModelLineage) HasNode(id string) bool {
+	for _, node := range l.Nodes {
+		if node.Id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// AddNode adds a node to the graph unless a node with the same id is already present
+func (l *ModelLineage) AddNode(node ModelLineageNode) {
+	if l.HasNode(node.Id) {
+		return
+	}
+	l.Nodes = append(l.Nodes, node)
+}
+
+// AddEdge adds an edge to the graph unless the same edge is already present
+func (l *ModelLineage) AddEdge(source, target string, relation ModelLineageRelation) {
+	for _, edge := range l.Edges {
+		if edge.Source == source && edge.Target == target && edge.Relation == relation {
+			return
+		}
+	}
+	l.Edges = append(l.Edges, ModelLineageEdge{Source: source, Target: target, Relation: relation})
+}
+
+// AddWarning records a part of the graph that could not be resolved
+func (l *ModelLineage) AddWarning(warning string) {
+	l.Warnings = append(l.Warnings, warning)
+}
+
+// ModelLineageNodeId builds the graph-unique id of a node from its type and resource key
+func ModelLineageNodeId(nodeType ModelLineageNodeType, key string) string {
+	return string(nodeType) + ":" + key
+}
@@ -0,0 +1,127 @@
+package models
+
+// ModelPromotionStatus represents the status of a promotion request
+type ModelPromotionStatus string
+
+const (
+	// ModelPromotionStatusPending is a request waiting for approvals.
+	ModelPromotionStatusPending ModelPromotionStatus = "PENDING"
+	// ModelPromotionStatusPromoted is a request whose model version was moved to the target stage.
+	ModelPromotionStatusPromoted ModelPromotionStatus = "PROMOTED"
+	// ModelPromotionStatusRejected is a request an approver rejected.
+	ModelPromotionStatusRejected ModelPromotionStatus = "REJECTED"
+	// ModelPromotionStatusFailed is an approved request whose requirements were not met, or
+	// whose model version could not be updated.
+	ModelPromotionStatusFailed ModelPromotionStatus = "FAILED"
+)
+
+// ModelPromotionDecisionType is an approver's decision on a promotion request
+type ModelPromotionDecisionType string
+
+const (
+	ModelPromotionDecisionApprove ModelPromotionDecisionType = "approve"
+	ModelPromotionDecisionReject  ModelPromotionDecisionType = "reject"
+)
+
+// ModelPromotionAuditAction is the kind of event recorded in the promotion audit log
+type ModelPromotionAuditAction string
+
+const (
+	ModelPromotionAuditActionRequested ModelPromotionAuditAction = "requested"
+	ModelPromotionAuditActionApproved  ModelPromotionAuditAction = "approved"
+	ModelPromotionAuditActionRejected  ModelPromotionAuditAction = "rejected"
+	ModelPromotionAuditActionPromoted  ModelPromotionAuditAction = "promoted"
+	ModelPromotionAuditActionFailed    ModelPromotionAuditAction = "failed"
+)
+
+// ModelPromotionStagePolicy holds the requirements a model version must meet to be promoted
+// to a stage.
+type ModelPromotionStagePolicy struct {
+	Stage string `json:"stage" yaml:"stage"`
+	// RequiredApprovals is the number of approvals from ApproverGroup needed. Zero promotes
+	// the version as soon as the request is created and its requirements are met.
+	RequiredApprovals int    `json:"requiredApprovals" yaml:"requiredApprovals"`
+	ApproverGroup     string `json:"approverGroup,omitempty" yaml:"approverGroup,omitempty"`
+	// MinEvalScore is the lowest accepted value of the model version's EvalScoreProperty.
+	MinEvalScore *float64 `json:"minEvalScore,omitempty" yaml:"minEvalScore,omitempty"`
+	// EvalScoreProperty is the model version custom property holding its evaluation score.
+	// Defaults to "eval_score".
+	EvalScoreProperty string `json:"evalScoreProperty,omitempty" yaml:"evalScoreProperty,omitempty"`
+	// RequiredSecurityBenchmarks lists the model catalog security benchmarks the version's
+	// catalog model must have passed.
+	RequiredSecurityBenchmarks []string `json:"requiredSecurityBenchmarks,omitempty" yaml:"requiredSecurityBenchmarks,omitempty"`
+}
+
+// ModelPromotionPolicy is the promotion policy of a model registry
+type ModelPromotionPolicy struct {
+	Stages []ModelPromotionStagePolicy `json:"stages" yaml:"stages"`
+}
+
+// ModelPromotionRequirement is the result of checking one requirement of a stage policy
+type ModelPromotionRequirement struct {
+	Name    string `json:"name"`
+	Met     bool   `json:"met"`
+	Message string `json:"message,omitempty"`
+}
+
+// ModelPromotionApproval is one approval of a promotion request
+type ModelPromotionApproval struct {
+	User      string `json:"user"`
+	Comment   string `json:"comment,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// ModelPromotionRequest is a request to promote a model version to a stage
+type ModelPromotionRequest struct {
+	Id                string                      `json:"id"`
+	ModelVersionId    string                      `json:"modelVersionId"`
+	ModelVersionName  string                      `json:"modelVersionName,omitempty"`
+	RegisteredModelId string                      `json:"registeredModelId,omitempty"`
+	TargetStage       string                      `json:"targetStage"`
+	Requester         string                      `json:"requester"`
+	Comment           string                      `json:"comment,omitempty"`
+	Status            ModelPromotionStatus        `json:"status"`
+	StatusMessage     string                      `json:"statusMessage,omitempty"`
+	RequiredApprovals int                         `json:"requiredApprovals"`
+	ApproverGroup     string                      `json:"approverGroup,omitempty"`
+	Approvals         []ModelPromotionApproval    `json:"approvals"`
+	Requirements      []ModelPromotionRequirement `json:"requirements"`
+	CreatedAt         string                      `json:"createdAt"`
+	UpdatedAt         string                      `json:"updatedAt"`
+}
+
+// ModelPromotionRequestList is a list of promotion requests
+type ModelPromotionRequestList struct {
+	Items []ModelPromotionRequest `json:"items"`
+	Size  int                     `json:"size"`
+}
+
+// ModelPromotionRequestCreate is the payload to request a promotion
+type ModelPromotionRequestCreate struct {
+	ModelVersionId string `json:"modelVersionId"`
+	TargetStage    string `json:"targetStage"`
+	Comment        string `json:"comment,omitempty"`
+}
+
+// ModelPromotionDecision is the payload to approve or reject a promotion request
+type ModelPromotionDecision struct {
+	Decision ModelPromotionDecisionType `json:"decision"`
+	Comment  string                     `json:"comment,omitempty"`
+}
+
+// ModelPromotionAuditEntry is one event in the promotion audit log
+type ModelPromotionAuditEntry struct {
+	Timestamp          string                    `json:"timestamp"`
+	PromotionRequestId string                    `json:"promotionRequestId"`
+	ModelVersionId     string                    `json:"modelVersionId"`
+	TargetStage        string                    `json:"targetStage"`
+	Actor              string                    `json:"actor"`
+	Action             ModelPromotionAuditAction `json:"action"`
+	Comment            string                    `json:"comment,omitempty"`
+}
+
+// ModelPromotionAuditLog is the promotion audit log of a model registry, oldest entry first
+type ModelPromotionAuditLog struct {
+	Items []ModelPromotionAuditEntry `json:"items"`
+	Size  int                        `json:"size"`
+}
@@ -65,35 +65,55 @@ type ModelTransferJobEvent struct {
 	Message   string `json:"message"`
 }
 
+// ModelTransferJobProgress represents the byte-level progress of a transfer job, as last
+// reported by the job pod
+type ModelTransferJobProgress struct {
+	BytesTransferred int64   `json:"bytesTransferred"`
+	TotalBytes       int64   `json:"totalBytes,omitempty"`
+	FilesCompleted   int     `json:"filesCompleted"`
+	FilesTotal       int     `json:"filesTotal,omitempty"`
+	FilesResumed     int     `json:"filesResumed,omitempty"`
+	CurrentFile      string  `json:"currentFile,omitempty"`
+	BytesPerSecond   int64   `json:"bytesPerSecond,omitempty"`
+	Percent          float64 `json:"percent"`
+	EtaSeconds       *int64  `json:"etaSeconds,omitempty"`
+	Attempt          int     `json:"attempt"`
+	MaxAttempts      int     `json:"maxAttempts"`
+	UpdatedAt        string  `json:"updatedAt,omitempty"`
+}
+
 // ModelTransferJob represents a model transfer job
 type ModelTransferJob struct {
-	Id                       string                       `json:"id"`
-	Name                     string                       `json:"name"`
-	JobDisplayName           string                       `json:"jobDisplayName"`
-	Description              string                       `json:"description,omitempty"`
-	Source                   ModelTransferJobSource       `json:"source"`
-	Destination              ModelTransferJobDestination  `json:"destination"`
-	UploadIntent             ModelTransferJobUploadIntent `json:"uploadIntent"`
-	RegisteredModelId        string                       `json:"registeredModelId,omitempty"`
-	RegisteredModelName      string                       `json:"registeredModelName,omitempty"`
-	ModelVersionId           string                       `json:"modelVersionId,omitempty"`
-	ModelVersionName         string                       `json:"modelVersionName,omitempty"`
-	ModelArtifactId          string                       `json:"modelArtifactId,omitempty"`
-	ModelArtifactName        string                       `json:"modelArtifactName,omitempty"`
-	Namespace                string                       `json:"namespace,omitempty"`
-	Author                   string                       `json:"author,omitempty"`
-	Status                   ModelTransferJobStatus       `json:"status"`
-	CreateTimeSinceEpoch     string                       `json:"createTimeSinceEpoch"`
-	LastUpdateTimeSinceEpoch string                       `json:"lastUpdateTimeSinceEpoch"`
-	ErrorMessage             string                       `json:"errorMessage,omitempty"`
-	VersionDescription       string                       `json:"versionDescription,omitempty"`
-	SourceModelFormat        string                       `json:"sourceModelFormat,omitempty"`
-	SourceModelFormatVersion string                       `json:"sourceModelFormatVersion,omitempty"`
-	ModelCustomProperties    map[string]interface{}       `json:"modelCustomProperties,omitempty"`
-	VersionCustomProperties  map[string]interface{}       `json:"versionCustomProperties,omitempty"`
-	SourceSecretName         string                       `json:"sourceSecretName,omitempty"`
-	DestSecretName           string                       `json:"destSecretName,omitempty"`
-	Events                   []ModelTransferJobEvent      `json:"events"`
+	Id                           string                       `json:"id"`
+	Name                         string                       `json:"name"`
+	JobDisplayName               string                       `json:"jobDisplayName"`
+	Description                  string                       `json:"description,omitempty"`
+	Source                       ModelTransferJobSource       `json:"source"`
+	Destination                  ModelTransferJobDestination  `json:"destination"`
+	UploadIntent                 ModelTransferJobUploadIntent `json:"uploadIntent"`
+	RegisteredModelId            string                       `json:"registeredModelId,omitempty"`
+	RegisteredModelName          string                       `json:"registeredModelName,omitempty"`
+	ModelVersionId               string                       `json:"modelVersionId,omitempty"`
+	ModelVersionName             string                       `json:"modelVersionName,omitempty"`
+	ModelArtifactId              string                       `json:"modelArtifactId,omitempty"`
+	ModelArtifactName            string                       `json:"modelArtifactName,omitempty"`
+	Namespace                    string                       `json:"namespace,omitempty"`
+	Author                       string                       `json:"author,omitempty"`
+	Status                       ModelTransferJobStatus       `json:"status"`
+	CreateTimeSinceEpoch         string                       `json:"createTimeSinceEpoch"`
+	LastUpdateTimeSinceEpoch     string                       `json:"lastUpdateTimeSinceEpoch"`
+	ErrorMessage                 string                       `json:"errorMessage,omitempty"`
+	VersionDescription           string                       `json:"versionDescription,omitempty"`
+	SourceModelFormat            string                       `json:"sourceModelFormat,omitempty"`
+	SourceModelFormatVersion     string                       `json:"sourceModelFormatVersion,omitempty"`
+	ModelCustomProperties        map[string]interface{}       `json:"modelCustomProperties,omitempty"`
+	VersionCustomProperties      map[string]interface{}       `json:"versionCustomProperties,omitempty"`
+	SourceSecretName             string                       `json:"sourceSecretName,omitempty"`
+	DestSecretName               string                       `json:"destSecretName,omitempty"`
+	BandwidthLimitBytesPerSecond int64                        `json:"bandwidthLimitBytesPerSecond,omitempty"`
+	ResumedFromJob               string                       `json:"resumedFromJob,omitempty"`
+	Progress                     *ModelTransferJobProgress    `json:"progress,omitempty"`
+	Events                       []ModelTransferJobEvent      `json:"events"`
 }
 
 // ModelTransferJobEventsResponse represents the response for transfer job events
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kubeflow/hub/ui/bff/internal/api"
+	"github.com/kubeflow/hub/ui/bff/internal/config"
+	"github.com/kubeflow/hub/ui/bff/internal/constants"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	redhatrepos "github.com/kubeflow/hub/ui/bff/internal/redhat/repositories"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
+)
+
+type CatalogDeploymentEnvelope api.Envelope[models.CatalogDeployment, api.None]
+type CatalogDeploymentCreateEnvelope api.Envelope[models.CatalogDeploymentCreateRequest, api.None]
+
+// catalogDeploymentCreateHandlerID must match api.handlerCatalogDeploymentCreateID.
+const catalogDeploymentCreateHandlerID = api.HandlerID("catalogDeployment:create")
+
+type catalogDeploymentRepository interface {
+	Deploy(
+		ctx context.Context,
+		client k8s.KubernetesClientInterface,
+		catalogClient httpclient.HTTPClientInterface,
+		registryClient httpclient.HTTPClientInterface,
+		namespace string,
+		req models.CatalogDeploymentCreateRequest,
+	) (models.CatalogDeployment, error)
+}
+
+var newCatalogDeploymentRepository = func(app *api.App) catalogDeploymentRepository {
+	repos := app.Repositories()
+	return redhatrepos.NewCatalogDeploymentRepository(app.Logger(), repos.ModelCatalogClient, repos.ModelRegistryClient, app.PodNamespace())
+}
+
+// newCatalogDeploymentCatalogClient and newCatalogDeploymentRegistryClient build the clients
+// for the catalog and the registry named in the request; tests replace them.
+var newCatalogDeploymentCatalogClient = func(app *api.App, r *http.Request, namespace string, apiPath string) (httpclient.HTTPClientInterface, error) {
+	return app.NewModelCatalogRESTClient(r, namespace, apiPath)
+}
+
+var newCatalogDeploymentRegistryClient = func(app *api.App, r *http.Request, namespace string, modelRegistryName string) (httpclient.HTTPClientInterface, error) {
+	return app.NewModelRegistryRESTClient(r, namespace, modelRegistryName)
+}
+
+func init() {
+	api.RegisterHandlerOverride(catalogDeploymentCreateHandlerID, overrideCatalogDeploymentCreate)
+}
+
+// overrideCatalogDeploymentCreate deploys a catalog model to KServe, or a catalog MCP server
+// to the MCP lifecycle operator, in one request (see docs/catalog-deployments.md).
+func overrideCatalogDeploymentCreate(app *api.App, buildDefault func() httprouter.Handle) httprouter.Handle {
+	if !shouldUseRedHatOverrides(app) {
+		return buildDefault()
+	}
+
+	repo := newCatalogDeploymentRepository(app)
+
+	return app.AttachNamespace(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		namespace, ok := namespaceFromContext(app, w, r)
+		if !ok {
+			return
+		}
+
+		var envelope CatalogDeploymentCreateEnvelope
+		if err := app.ReadJSON(w, r, &envelope); err != nil {
+			app.BadRequest(w, r, err)
+			return
+		}
+		req := envelope.Data
+
+		targetNamespace := strings.TrimSpace(req.Namespace)
+		if targetNamespace == "" {
+			targetNamespace = namespace
+		}
+
+		if req.Kind == models.CatalogDeploymentKindMcpServer {
+			if !requireMcpDeploymentAccess(app, w, r, targetNamespace, "create") {
+				return
+			}
+		} else if !requireInferenceServiceAccess(app, w, r, targetNamespace, "create") {
+			return
+		}
+
+		client, ok := getKubernetesClient(app, w, r)
+		if !ok {
+			return
+		}
+
+		apiPath := repositories.ModelCatalogAPIPath
+		if req.Kind == models.CatalogDeploymentKindMcpServer {
+			apiPath = repositories.McpCatalogAPIPath
+		}
+		catalogClient, err := newCatalogDeploymentCatalogClient(app, r, namespace, apiPath)
+		if err != nil {
+			handleCatalogDeploymentError(app, w, r, err)
+			return
+		}
+
+		var registryClient httpclient.HTTPClientInterface
+		if req.Registration != nil && req.Kind == models.CatalogDeploymentKindModel {
+			registryName := strings.TrimSpace(req.Registration.ModelRegistryName)
+			if registryName == "" {
+				app.BadRequest(w, r, fmt.Errorf("registration.modelRegistryName is required"))
+				return
+			}
+			if !requireModelRegistryAccess(app, w, r, client, namespace, registryName) {
+				return
+			}
+			registryClient, err = newCatalogDeploymentRegistryClient(app, r, namespace, registryName)
+			if err != nil {
+				handleCatalogDeploymentError(app, w, r, err)
+				return
+			}
+		}
+
+		result, err := repo.Deploy(r.Context(), client, catalogClient, registryClient, targetNamespace, req)
+		if err != nil {
+			handleCatalogDeploymentError(app, w, r, err)
+			return
+		}
+
+		resp := CatalogDeploymentEnvelope{Data: result}
+		if err := app.WriteJSON(w, http.StatusCreated, resp, nil); err != nil {
+			app.ServerError(w, r, err)
+		}
+	})
+}
+
+func handleCatalogDeploymentError(app *api.App, w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, redhatrepos.ErrCatalogDeploymentValidation),
+		errors.Is(err, redhatrepos.ErrCatalogDeploymentNoCompatibleRuntime):
+		app.BadRequest(w, r, err)
+	case errors.Is(err, redhatrepos.ErrCatalogDeploymentNotFound),
+		errors.Is(err, api.ErrModelCatalogNotFound),
+		errors.Is(err, api.ErrModelRegistryNotFound):
+		app.NotFound(w, r)
+	case errors.Is(err, redhatrepos.ErrCatalogDeploymentConflict):
+		app.Conflict(w, r, err.Error())
+	case apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err):
+		app.Forbidden(w, r, err.Error())
+	default:
+		app.ServerError(w, r, err)
+	}
+}
+
+// requireInferenceServiceAccess is the InferenceService counterpart of
+// requireMcpDeploymentAccess: it only runs a SAR for the "internal" auth method.
+func requireInferenceServiceAccess(app *api.App, w http.ResponseWriter, r *http.Request, namespace, verb string) bool {
+	if app.Config().AuthMethod != config.AuthMethodInternal {
+		return true
+	}
+
+	client, ok := getKubernetesClient(app, w, r)
+	if !ok {
+		return false
+	}
+
+	identity, ok := r.Context().Value(constants.RequestIdentityKey).(*k8s.RequestIdentity)
+	if !ok || identity == nil {
+		app.BadRequest(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return false
+	}
+
+	allowed, err := client.CanVerbInferenceServicesInNamespace(r.Context(), identity, namespace, verb)
+	if err != nil {
+		app.Logger().Error("InferenceService access check failed",
+			slog.String("user", identity.UserID),
+			slog.String("verb", verb),
+			slog.String("namespace", namespace),
+			slog.Any("error", err),
+		)
+		app.Forbidden(w, r, fmt.Sprintf("access check failed for %s inferenceservices in %s: %v", verb, namespace, err))
+		return false
+	}
+
+	if !allowed {
+		app.Forbidden(w, r, fmt.Sprintf("user %s cannot %s inferenceservices in namespace %s", identity.UserID, verb, namespace))
+		return false
+	}
+
+	return true
+}
+
+// requireModelRegistryAccess applies the access check of the model registry routes to a
+// registry named in the request body rather than the path.
+func requireModelRegistryAccess(app *api.App, w http.ResponseWriter, r *http.Request, client k8s.KubernetesClientInterface, namespace, registryName string) bool {
+	identity, ok := r.Context().Value(constants.RequestIdentityKey).(*k8s.RequestIdentity)
+	if !ok || identity == nil {
+		app.BadRequest(w, r, fmt.Errorf("missing RequestIdentity in context"))
+		return false
+	}
+
+	allowed, err := client.CanAccessServiceInNamespace(r.Context(), identity, namespace, registryName)
+	if err != nil {
+		app.Forbidden(w, r, fmt.Sprintf("SAR or SelfSAR AccessReview failed for service %s in namespace %s: %v", registryName, namespace, err))
+		return false
+	}
+	if !allowed {
+		app.Forbidden(w, r, fmt.Sprintf("SAR or SelfSAR AccessReview denied access to service %s in namespace %s", registryName, namespace))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/hub/ui/bff/internal/api"
+	"github.com/kubeflow/hub/ui/bff/internal/constants"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	redhatrepos "github.com/kubeflow/hub/ui/bff/internal/redhat/repositories"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
+)
+
+type mockCatalogDeploymentRepo struct {
+	deployFn func(client k8s.KubernetesClientInterface, catalogClient httpclient.HTTPClientInterface, registryClient httpclient.HTTPClientInterface, namespace string, req models.CatalogDeploymentCreateRequest) (models.CatalogDeployment, error)
+}
+
+func (m *mockCatalogDeploymentRepo) Deploy(_ context.Context, client k8s.KubernetesClientInterface, catalogClient httpclient.HTTPClientInterface, registryClient httpclient.HTTPClientInterface, namespace string, req models.CatalogDeploymentCreateRequest) (models.CatalogDeployment, error) {
+	return m.deployFn(client, catalogClient, registryClient, namespace, req)
+}
+
+// fakeCatalogDeploymentKubeClient answers the registry access check.
+type fakeCatalogDeploymentKubeClient struct {
+	k8s.KubernetesClientInterface
+	allowedServices map[string]bool
+}
+
+func (f *fakeCatalogDeploymentKubeClient) CanAccessServiceInNamespace(_ context.Context, _ *k8s.RequestIdentity, namespace, serviceName string) (bool, error) {
+	return f.allowedServices[namespace+"/"+serviceName], nil
+}
+
+// fakeCatalogDeploymentHTTPClient records which client the handler built.
+type fakeCatalogDeploymentHTTPClient struct {
+	httpclient.HTTPClientInterface
+	name string
+}
+
+func withCatalogDeploymentRepo(t *testing.T, repo catalogDeploymentRepository) {
+	t.Helper()
+	originalRepo := newCatalogDeploymentRepository
+	originalCatalog := newCatalogDeploymentCatalogClient
+	originalRegistry := newCatalogDeploymentRegistryClient
+	newCatalogDeploymentRepository = func(*api.App) catalogDeploymentRepository {
+		return repo
+	}
+	newCatalogDeploymentCatalogClient = func(_ *api.App, _ *http.Request, namespace string, apiPath string) (httpclient.HTTPClientInterface, error) {
+		return &fakeCatalogDeploymentHTTPClient{name: namespace + apiPath}, nil
+	}
+	newCatalogDeploymentRegistryClient = func(_ *api.App, _ *http.Request, namespace string, modelRegistryName string) (httpclient.HTTPClientInterface, error) {
+		return &fakeCatalogDeploymentHTTPClient{name: namespace + "/" + modelRegistryName}, nil
+	}
+	t.Cleanup(func() {
+		newCatalogDeploymentRepository = originalRepo
+		newCatalogDeploymentCatalogClient = originalCatalog
+		newCatalogDeploymentRegistryClient = originalRegistry
+	})
+}
+
+func catalogDeploymentRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, api.CatalogDeploymentListPath+"?namespace=model-registries", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	ctx := context.WithValue(req.Context(), constants.RequestIdentityKey, &k8s.RequestIdentity{UserID: "alice"})
+	return req.WithContext(ctx)
+}
+
+func TestCatalogDeploymentCreateDeploysModel(t *testing.T) {
+	app := newRedHatTestApp(&fakeTransferJobsKubeFactory{client: &fakeCatalogDeploymentKubeClient{}})
+
+	withCatalogDeploymentRepo(t, &mockCatalogDeploymentRepo{
+		deployFn: func(_ k8s.KubernetesClientInterface, catalogClient httpclient.HTTPClientInterface, registryClient httpclient.HTTPClientInterface, namespace string, req models.CatalogDeploymentCreateRequest) (models.CatalogDeployment, error) {
+			if namespace != "model-registries" {
+				t.Fatalf("expected the query namespace as target, got %q", namespace)
+			}
+			if got := catalogClient.(*fakeCatalogDeploymentHTTPClient).name; got != "model-registries"+repositories.ModelCatalogAPIPath {
+				t.Fatalf("unexpected catalog client %q", got)
+			}
+			if registryClient != nil {
+				t.Fatalf("expected no registry client without registration")
+			}
+			return models.CatalogDeployment{
+				Kind:                 req.Kind,
+				Name:                 "granite-8b",
+				Namespace:            namespace,
+				ServingRuntime:       "vllm-runtime",
+				InferenceServiceName: "granite-8b",
+				Steps: []models.CatalogDeploymentStep{
+					{Name: "createInferenceService", Status: models.CatalogDeploymentStepCompleted, Resource: "granite-8b"},
+				},
+			}, nil
+		},
+	})
+
+	handler := overrideCatalogDeploymentCreate(app, failDefault(t))
+	rr := httptest.NewRecorder()
+	handler(rr, catalogDeploymentRequest(`{"data":{"kind":"model","sourceId":"rh","modelName":"ibm/granite-8b"}}`), nil)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp CatalogDeploymentEnvelope
+	decodeResponse(t, rr, &resp)
+	if resp.Data.InferenceServiceName != "granite-8b" || len(resp.Data.Steps) != 1 {
+		t.Fatalf("unexpected deployment %+v", resp.Data)
+	}
+}
+
+func TestCatalogDeploymentCreateRegistersInRequestedRegistry(t *testing.T) {
+	client := &fakeCatalogDeploymentKubeClient{allowedServices: map[string]bool{"model-registries/prod": true}}
+	app := newRedHatTestApp(&fakeTransferJobsKubeFactory{client: client})
+
+	withCatalogDeploymentRepo(t, &mockCatalogDeploymentRepo{
+		deployFn: func(_ k8s.KubernetesClientInterface, _ httpclient.HTTPClientInterface, registryClient httpclient.HTTPClientInterface, namespace string, _ models.CatalogDeploymentCreateRequest) (models.CatalogDeployment, error) {
+			if namespace != "team-a" {
+				t.Fatalf("expected target namespace team-a, got %q", namespace)
+			}
+			if registryClient == nil || registryClient.(*fakeCatalogDeploymentHTTPClient).name != "model-registries/prod" {
+				t.Fatalf("unexpected registry client %+v", registryClient)
+			}
+			return models.CatalogDeployment{Name: "granite-8b", Namespace: namespace, ModelRegistryName: "prod"}, nil
+		},
+	})
+
+	handler := overrideCatalogDeploymentCreate(app, failDefault(t))
+	rr := httptest.NewRecorder()
+	body := `{"data":{"kind":"model","sourceId":"rh","modelName":"ibm/granite-8b","namespace":"team-a","registration":{"modelRegistryName":"prod","registeredModelName":"granite","versionName":"v1"}}}`
+	handler(rr, catalogDeploymentRequest(body), nil)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCatalogDeploymentCreateForbiddenWithoutRegistryAccess(t *testing.T) {
+	app := newRedHatTestApp(&fakeTransferJobsKubeFactory{client: &fakeCatalogDeploymentKubeClient{}})
+
+	withCatalogDeploymentRepo(t, &mockCatalogDeploymentRepo{
+		deployFn: func(k8s.KubernetesClientInterface, httpclient.HTTPClientInterface, httpclient.HTTPClientInterface, string, models.CatalogDeploymentCreateRequest) (models.CatalogDeployment, error) {
+			t.Fatal("repository should not be called without registry access")
+			return models.CatalogDeployment{}, nil
+		},
+	})
+
+	handler := overrideCatalogDeploymentCreate(app, failDefault(t))
+	rr := httptest.NewRecorder()
+	body := `{"data":{"kind":"model","sourceId":"rh","modelName":"ibm/granite-8b","registration":{"modelRegistryName":"prod","registeredModelName":"granite","versionName":"v1"}}}`
+	handler(rr, catalogDeploymentRequest(body), nil)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", rr.Code)
+	}
+}
+
+func TestCatalogDeploymentCreateUsesMcpCatalogForMcpServers(t *testing.T) {
+	app := newRedHatTestApp(&fakeTransferJobsKubeFactory{client: &fakeCatalogDeploymentKubeClient{}})
+
+	withCatalogDeploymentRepo(t, &mockCatalogDeploymentRepo{
+		deployFn: func(_ k8s.KubernetesClientInterface, catalogClient httpclient.HTTPClientInterface, _ httpclient.HTTPClientInterface, namespace string, req models.CatalogDeploymentCreateRequest) (models.CatalogDeployment, error) {
+			if got := catalogClient.(*fakeCatalogDeploymentHTTPClient).name; got != "model-registries"+repositories.McpCatalogAPIPath {
+				t.Fatalf("unexpected catalog client %q", got)
+			}
+			return models.CatalogDeployment{Kind: req.Kind, Name: "github", Namespace: namespace}, nil
+		},
+	})
+
+	handler := overrideCatalogDeploymentCreate(app, failDefault(t))
+	rr := httptest.NewRecorder()
+	handler(rr, catalogDeploymentRequest(`{"data":{"kind":"mcp_server","mcpServerId":"github","env":{"GITHUB_TOKEN":"x"}}}`), nil)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCatalogDeploymentCreateMapsRepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"validation", fmt.Errorf("%w: modelName is required", redhatrepos.ErrCatalogDeploymentValidation), http.StatusBadRequest},
+		{"no runtime", fmt.Errorf("%w for model format %q", redhatrepos.ErrCatalogDeploymentNoCompatibleRuntime, "onnx"), http.StatusBadRequest},
+		{"not found", fmt.Errorf("%w: model", redhatrepos.ErrCatalogDeploymentNotFound), http.StatusNotFound},
+		{"conflict", fmt.Errorf("%w: granite-8b", redhatrepos.ErrCatalogDeploymentConflict), http.StatusConflict},
+		{"other", fmt.Errorf("boom (rolled back: createServingRuntime)"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := newRedHatTestApp(&fakeTransferJobsKubeFactory{client: &fakeCatalogDeploymentKubeClient{}})
+			withCatalogDeploymentRepo(t, &mockCatalogDeploymentRepo{
+				deployFn: func(k8s.KubernetesClientInterface, httpclient.HTTPClientInterface, httpclient.HTTPClientInterface, string, models.CatalogDeploymentCreateRequest) (models.CatalogDeployment, error) {
+					return models.CatalogDeployment{}, tc.err
+				},
+			})
+
+			handler := overrideCatalogDeploymentCreate(app, failDefault(t))
+			rr := httptest.NewRecorder()
+			handler(rr, catalogDeploymentRequest(`{"data":{"kind":"model","modelName":"ibm/granite-8b"}}`), nil)
+
+			if rr.Code != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, rr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/kubeflow/hub/ui/bff/internal/api"
+	"github.com/kubeflow/hub/ui/bff/internal/constants"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/bffclient"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
+)
+
+const (
+	// modelVersionLineageHandlerID must match api.HandlerIDModelVersionLineage.
+	modelVersionLineageHandlerID = api.HandlerID("modelVersions:lineage")
+
+	// Labels the dashboard sets on InferenceServices deployed from a model registry.
+	modelVersionIDLabel         = "modelregistry.opendatahub.io/model-version-id"
+	modelRegistryNameLabel      = "modelregistry.opendatahub.io/name"
+	maxLineageServingNamespaces = 10
+)
+
+// evalHubInferenceServicesEnvelope mirrors the eval-hub BFF's GET /inferenceservices
+// response (see packages/eval-hub/bff/internal/models/inferenceservice.go). Only the
+// fields the lineage graph needs are declared.
+type evalHubInferenceServicesEnvelope struct {
+	Data struct {
+		Items []struct {
+			Name            string `json:"name"`
+			URL             string `json:"url"`
+			Ready           bool   `json:"ready"`
+			ModelFormatName string `json:"model_format_name"`
+		} `json:"items"`
+		Warning string `json:"warning"`
+	} `json:"data"`
+}
+
+// evalHubEvaluationJobsEnvelope mirrors the eval-hub BFF's GET /evaluations/jobs response.
+type evalHubEvaluationJobsEnvelope struct {
+	Data []struct {
+		Resource struct {
+			ID        string `json:"id"`
+			CreatedAt string `json:"created_at"`
+		} `json:"resource"`
+		Status struct {
+			State string `json:"state"`
+		} `json:"status"`
+		Name  string `json:"name"`
+		Model struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"model"`
+	} `json:"data"`
+}
+
+func init() {
+	api.RegisterHandlerOverride(modelVersionLineageHandlerID, overrideModelVersionLineage)
+}
+
+// overrideModelVersionLineage extends the upstream lineage graph with the InferenceServices
+// serving the model version and the evaluation jobs run against them, both resolved via
+// inter-BFF calls to the eval-hub BFF (see docs/inter-bff-communication.md).
+//
+// InferenceServices are searched in the namespaces of the servingNamespace query parameter
+// (comma-separated), or in jobNamespace when it is not set.
+func overrideModelVersionLineage(app *api.App, buildDefault func() httprouter.Handle) httprouter.Handle {
+	if !shouldUseRedHatOverrides(app) {
+		return buildDefault()
+	}
+
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := r.Context()
+
+		namespace, ok := ctx.Value(constants.NamespaceHeaderParameterKey).(string)
+		if !ok || namespace == "" {
+			app.BadRequest(w, r, fmt.Errorf("missing namespace in the context"))
+			return
+		}
+
+		restClient, ok := ctx.Value(constants.ModelRegistryHttpClientKey).(httpclient.HTTPClientInterface)
+		if !ok {
+			app.ServerError(w, r, errors.New("REST client not found"))
+			return
+		}
+
+		client, ok := getKubernetesClient(app, w, r)
+		if !ok {
+			return
+		}
+
+		servingNamespaces, err := lineageServingNamespaces(r)
+		if err != nil {
+			app.BadRequest(w, r, err)
+			return
+		}
+
+		modelRegistryID := ps.ByName(api.ModelRegistryId)
+		modelVersionID := ps.ByName(api.ModelVersionId)
+
+		lineage, err := app.Repositories().ModelLineage.GetModelVersionLineage(ctx, restClient, client, namespace, modelRegistryID, r.URL.Query().Get("jobNamespace"), modelVersionID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrModelVersionNotFound) {
+				app.NotFound(w, r)
+				return
+			}
+			app.ServerError(w, r, err)
+			return
+		}
+
+		if len(servingNamespaces) == 0 {
+			lineage.AddWarning("set servingNamespace to include InferenceServices and evaluations")
+		} else if evalHubClient := bffclient.ClientForRequest(ctx, app.BFFClientFactory(), bffclient.BFFTargetEvalHub); evalHubClient == nil {
+			lineage.AddWarning("the eval-hub BFF is not configured; InferenceServices and evaluations are not included")
+		} else {
+			addServingLineage(ctx, app.Logger(), evalHubClient, lineage, modelRegistryID, modelVersionID, servingNamespaces)
+		}
+
+		if err := app.WriteJSON(w, http.StatusOK, api.ModelLineageEnvelope{Data: lineage}, nil); err != nil {
+			app.ServerError(w, r, err)
+		}
+	}
+}
+
+// lineageServingNamespaces reads the namespaces to search for InferenceServices.
+func lineageServingNamespaces(r *http.Request) ([]string, error) {
+	raw := r.URL.Query().Get("servingNamespace")
+	if raw == "" {
+		raw = r.URL.Query().Get("jobNamespace")
+	}
+
+	seen := make(map[string]struct{})
+	var namespaces []string
+	for _, ns := range strings.Split(raw, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" {
+			continue
+		}
+		if _, ok := seen[ns]; ok {
+			continue
+		}
+		seen[ns] = struct{}{}
+		namespaces = append(namespaces, ns)
+	}
+	if len(namespaces) > maxLineageServingNamespaces {
+		return nil, fmt.Errorf("servingNamespace accepts at most %d namespaces", maxLineageServingNamespaces)
+	}
+	return namespaces, nil
+}
+
+// servingLineage holds what the eval-hub BFF returned for one namespace.
+type servingLineage struct {
+	namespace         string
+	inferenceServices evalHubInferenceServicesEnvelope
+	evaluationJobs    evalHubEvaluationJobsEnvelope
+	warnings          []string
+}
+
+// addServingLineage adds the InferenceServices labelled with the model version, and the
+// evaluation jobs whose model endpoint is one of them. It's best-effort: namespaces the
+// eval-hub BFF cannot answer for are reported in the lineage warnings.
+//
+// Namespaces are resolved concurrently; the context carries the request timeout.
+func addServingLineage(ctx context.Context, logger *slog.Logger, client bffclient.BFFClientInterface, lineage *models.ModelLineage, modelRegistryName string, modelVersionID string, namespaces []string) {
+	selector := modelVersionIDLabel + "=" + modelVersionID
+	if modelRegistryName != "" {
+		selector += "," + modelRegistryNameLabel + "=" + modelRegistryName
+	}
+
+	results := make([]servingLineage, len(namespaces))
+	var wg sync.WaitGroup
+	for i, ns := range namespaces {
+		wg.Add(1)
+		go func(i int, ns string) {
+			defer wg.Done()
+			results[i] = fetchServingLineage(ctx, logger, client, ns, selector)
+		}(i, ns)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].namespace < results[j].namespace })
+	for _, result := range results {
+		for _, warning := range result.warnings {
+			lineage.AddWarning(warning)
+		}
+
+		isvcNodeIDs := make(map[string]string)
+		isvcURLs := make(map[string]string)
+		for _, isvc := range result.inferenceServices.Data.Items {
+			node := models.ModelLineageNode{
+				Id:        models.ModelLineageNodeId(models.ModelLineageNodeTypeInferenceService, result.namespace+"/"+isvc.Name),
+				Type:      models.ModelLineageNodeTypeInferenceService,
+				Name:      isvc.Name,
+				Namespace: result.namespace,
+				Status:    "NotReady",
+				Uri:       isvc.URL,
+			}
+			if isvc.Ready {
+				node.Status = "Ready"
+			}
+			if isvc.ModelFormatName != "" {
+				node.Properties = map[string]string{"modelFormatName": isvc.ModelFormatName}
+			}
+			lineage.AddNode(node)
+			lineage.AddEdge(lineage.RootNodeId, node.Id, models.ModelLineageRelationDeployedAs)
+			isvcNodeIDs[isvc.Name] = node.Id
+			if isvc.URL != "" {
+				isvcURLs[node.Id] = isvc.URL
+			}
+		}
+
+		for _, job := range result.evaluationJobs.Data {
+			isvcNodeID := matchEvaluatedInferenceService(job.Model.Name, job.Model.URL, isvcNodeIDs, isvcURLs)
+			if isvcNodeID == "" || job.Resource.ID == "" {
+				continue
+			}
+			name := job.Name
+			if name == "" {
+				name = job.Resource.ID
+			}
+			node := models.ModelLineageNode{
+				Id:        models.ModelLineageNodeId(models.ModelLineageNodeTypeEvaluationJob, job.Resource.ID),
+				Type:      models.ModelLineageNodeTypeEvaluationJob,
+				Name:      name,
+				Namespace: result.namespace,
+				Status:    job.Status.State,
+			}
+			if job.Resource.CreatedAt != "" {
+				node.Properties = map[string]string{"createdAt": job.Resource.CreatedAt}
+			}
+			lineage.AddNode(node)
+			lineage.AddEdge(isvcNodeID, node.Id, models.ModelLineageRelationEvaluatedBy)
+		}
+	}
+}
+
+// fetchServingLineage calls GET /inferenceservices and, when the model version is served
+// in the namespace, GET /evaluations/jobs on the eval-hub BFF.
+func fetchServingLineage(ctx context.Context, logger *slog.Logger, client bffclient.BFFClientInterface, namespace string, selector string) servingLineage {
+	result := servingLineage{namespace: namespace}
+
+	isvcPath := fmt.Sprintf("/inferenceservices?namespace=%s&label_selector=%s", url.QueryEscape(namespace), url.QueryEscape(selector))
+	if err := client.Call(ctx, http.MethodGet, isvcPath, nil, &result.inferenceServices); err != nil {
+		if logger != nil {
+			logger.Debug("failed to list InferenceServices from eval-hub BFF", slog.String("namespace", namespace), slog.Any("error", err))
+		}
+		result.warnings = append(result.warnings, fmt.Sprintf("unable to list InferenceServices in namespace %q", namespace))
+		return result
+	}
+	if warning := result.inferenceServices.Data.Warning; warning != "" {
+		result.warnings = append(result.warnings, fmt.Sprintf("namespace %q: %s", namespace, warning))
+	}
+	if len(result.inferenceServices.Data.Items) == 0 {
+		return result
+	}
+
+	jobsPath := fmt.Sprintf("/evaluations/jobs?namespace=%s", url.QueryEscape(namespace))
+	if err := client.Call(ctx, http.MethodGet, jobsPath, nil, &result.evaluationJobs); err != nil {
+		if logger != nil {
+			logger.Debug("failed to list evaluation jobs from eval-hub BFF", slog.String("namespace", namespace), slog.Any("error", err))
+		}
+		result.warnings = append(result.warnings, fmt.Sprintf("unable to list evaluation jobs in namespace %q", namespace))
+	}
+	return result
+}
+
+// matchEvaluatedInferenceService returns the node id of the InferenceService an evaluation
+// job ran against. Jobs point at the model endpoint, usually the InferenceService URL plus
+// an API path such as "/v1", so URLs are matched by prefix before falling back to the name.
+func matchEvaluatedInferenceService(modelName string, modelURL string, nodeIDsByName map[string]string, urlsByNodeID map[string]string) string {
+	if modelURL != "" {
+		for nodeID, isvcURL := range urlsByNodeID {
+			trimmed := strings.TrimRight(isvcURL, "/")
+			if modelURL == trimmed || strings.HasPrefix(modelURL, trimmed+"/") {
+				return nodeID
+			}
+		}
+	}
+	return nodeIDsByName[modelName]
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/bffclient"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/bffclient/bffmocks"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+)
+
+func TestAddServingLineageLinksInferenceServicesAndEvaluations(t *testing.T) {
+	client := bffmocks.NewMockBFFClient(bffclient.BFFTargetEvalHub)
+	var isvcPath string
+	client.CallHandler = func(ctx context.Context, method, path string, body interface{}, response interface{}) error {
+		if strings.HasPrefix(path, "/inferenceservices") {
+			isvcPath = path
+		}
+		return bffmocks.NewMockBFFClient(bffclient.BFFTargetEvalHub).Call(ctx, method, path, body, response)
+	}
+
+	lineage := models.NewModelLineage("model_version:7")
+	lineage.AddNode(models.ModelLineageNode{Id: "model_version:7", Type: models.ModelLineageNodeTypeModelVersion, Name: "v1"})
+
+	addServingLineage(context.Background(), noopLogger(), client, lineage, "model-registry", "7", []string{"team-a"})
+
+	parsed, err := url.Parse(isvcPath)
+	if err != nil {
+		t.Fatalf("failed to parse InferenceService path %q: %v", isvcPath, err)
+	}
+	if got, want := parsed.Query().Get("label_selector"), "modelregistry.opendatahub.io/model-version-id=7,modelregistry.opendatahub.io/name=model-registry"; got != want {
+		t.Fatalf("expected label_selector %q, got %q", want, got)
+	}
+
+	if !lineage.HasNode("inference_service:team-a/mock-model") {
+		t.Fatalf("expected InferenceService node, got %+v", lineage.Nodes)
+	}
+	if !lineage.HasNode("evaluation_job:mock-eval-job-1") {
+		t.Fatalf("expected evaluation job node, got %+v", lineage.Nodes)
+	}
+	expectedEdges := []models.ModelLineageEdge{
+		{Source: "model_version:7", Target: "inference_service:team-a/mock-model", Relation: models.ModelLineageRelationDeployedAs},
+		{Source: "inference_service:team-a/mock-model", Target: "evaluation_job:mock-eval-job-1", Relation: models.ModelLineageRelationEvaluatedBy},
+	}
+	for _, expected := range expectedEdges {
+		found := false
+		for _, edge := range lineage.Edges {
+			if edge == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected edge %+v, got %+v", expected, lineage.Edges)
+		}
+	}
+	if len(lineage.Warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", lineage.Warnings)
+	}
+}
+
+func TestAddServingLineageReportsUnreachableNamespaces(t *testing.T) {
+	client := bffmocks.NewMockBFFClient(bffclient.BFFTargetEvalHub)
+	client.CallHandler = func(ctx context.Context, method, path string, body interface{}, response interface{}) error {
+		return bffclient.NewServerUnavailableError(bffclient.BFFTargetEvalHub)
+	}
+
+	lineage := models.NewModelLineage("model_version:7")
+	addServingLineage(context.Background(), noopLogger(), client, lineage, "model-registry", "7", []string{"team-b", "team-a"})
+
+	expected := []string{
+		`unable to list InferenceServices in namespace "team-a"`,
+		`unable to list InferenceServices in namespace "team-b"`,
+	}
+	if strings.Join(lineage.Warnings, "|") != strings.Join(expected, "|") {
+		t.Fatalf("expected warnings %v, got %v", expected, lineage.Warnings)
+	}
+}
+
+func TestMatchEvaluatedInferenceService(t *testing.T) {
+	nodeIDsByName := map[string]string{"granite": "inference_service:team-a/granite"}
+	urlsByNodeID := map[string]string{"inference_service:team-a/granite": "https://granite-predictor.team-a.svc.cluster.local/"}
+
+	tests := []struct {
+		name      string
+		modelName string
+		modelURL  string
+		want      string
+	}{
+		{name: "URL with API path", modelURL: "https://granite-predictor.team-a.svc.cluster.local/v1", want: "inference_service:team-a/granite"},
+		{name: "exact URL", modelURL: "https://granite-predictor.team-a.svc.cluster.local", want: "inference_service:team-a/granite"},
+		{name: "URL sharing a prefix", modelURL: "https://granite-predictor.team-a.svc.cluster.local.example.com/v1", want: ""},
+		{name: "name fallback", modelName: "granite", modelURL: "https://external.example.com/v1", want: "inference_service:team-a/granite"},
+		{name: "unrelated", modelName: "llama", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchEvaluatedInferenceService(tt.modelName, tt.modelURL, nodeIDsByName, urlsByNodeID); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLineageServingNamespaces(t *testing.T) {
+	req := httptest.NewRequest("GET", "/lineage?servingNamespace=team-a,%20team-b,team-a&jobNamespace=team-c", nil)
+	namespaces, err := lineageServingNamespaces(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(namespaces, ",") != "team-a,team-b" {
+		t.Fatalf("expected team-a,team-b, got %v", namespaces)
+	}
+
+	req = httptest.NewRequest("GET", "/lineage?jobNamespace=team-c", nil)
+	namespaces, err = lineageServingNamespaces(req)
+	if err != nil || strings.Join(namespaces, ",") != "team-c" {
+		t.Fatalf("expected jobNamespace fallback, got %v (%v)", namespaces, err)
+	}
+
+	req = httptest.NewRequest("GET", "/lineage?servingNamespace=a,b,c,d,e,f,g,h,i,j,k", nil)
+	if _, err := lineageServingNamespaces(req); err == nil {
+		t.Fatalf("expected an error for too many namespaces")
+	}
+}
@@ -0,0 +1,801 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/hub/pkg/openapi"
+	helper "github.com/kubeflow/hub/ui/bff/internal/helpers"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	ErrCatalogDeploymentValidation          = errors.New("catalog deployment validation failed")
+	ErrCatalogDeploymentNotFound            = errors.New("catalog entry not found")
+	ErrCatalogDeploymentConflict            = errors.New("catalog deployment already exists")
+	ErrCatalogDeploymentNoCompatibleRuntime = errors.New("no compatible serving runtime")
+)
+
+var (
+	inferenceServiceGVR = schema.GroupVersionResource{
+		Group:    "serving.kserve.io",
+		Version:  "v1beta1",
+		Resource: "inferenceservices",
+	}
+	servingRuntimeGVR = schema.GroupVersionResource{
+		Group:    "serving.kserve.io",
+		Version:  "v1alpha1",
+		Resource: "servingruntimes",
+	}
+	templateGVR = schema.GroupVersionResource{
+		Group:    "template.openshift.io",
+		Version:  "v1",
+		Resource: "templates",
+	}
+)
+
+const (
+	inferenceServiceAPIVersion = "serving.kserve.io/v1beta1"
+	inferenceServiceKind       = "InferenceService"
+
+	dashboardLabel                   = "opendatahub.io/dashboard"
+	displayNameAnnotation            = "openshift.io/display-name"
+	templateNameAnnotation           = "opendatahub.io/template-name"
+	templateDisplayNameAnnotation    = "opendatahub.io/template-display-name"
+	recommendedAcceleratorAnnotation = "opendatahub.io/recommended-accelerators"
+	catalogSourceIDAnnotation        = "modelcatalog.opendatahub.io/source-id"
+	catalogModelNameAnnotation       = "modelcatalog.opendatahub.io/model-name"
+	registryNameLabel                = "modelregistry.opendatahub.io/name"
+	registeredModelIDLabel           = "modelregistry.opendatahub.io/registered-model-id"
+	modelVersionIDLabel              = "modelregistry.opendatahub.io/model-version-id"
+
+	gpuResourceName = "nvidia.com/gpu"
+
+	// catalogDeploymentRollbackTimeout bounds undoing the steps of a failed deployment.
+	catalogDeploymentRollbackTimeout = 30 * time.Second
+
+	// catalogModelFormatProperty is the catalog custom property naming the model format. Catalog
+	// models without it are LLMs served by vLLM.
+	catalogModelFormatProperty = "model_format"
+	defaultCatalogModelFormat  = "vLLM"
+	catalogModelSourceKind     = "catalog"
+)
+
+// CatalogDeploymentRepository deploys catalog models as InferenceServices and catalog MCP
+// servers as MCPServers, optionally registering the model in a model registry. Each step that
+// completes is undone when a later one fails.
+type CatalogDeploymentRepository struct {
+	logger            *slog.Logger
+	catalog           repositories.ModelCatalogClientInterface
+	registry          repositories.ModelRegistryClientInterface
+	templateNamespace string
+	dynamicClient     func(client k8s.KubernetesClientInterface) (dynamic.Interface, error)
+}
+
+// NewCatalogDeploymentRepository returns a repository that looks up dashboard serving runtime
+// templates in templateNamespace.
+func NewCatalogDeploymentRepository(logger *slog.Logger, catalog repositories.ModelCatalogClientInterface, registry repositories.ModelRegistryClientInterface, templateNamespace string) *CatalogDeploymentRepository {
+	return &CatalogDeploymentRepository{
+		logger:            logger,
+		catalog:           catalog,
+		registry:          registry,
+		templateNamespace: templateNamespace,
+		dynamicClient: func(client k8s.KubernetesClientInterface) (dynamic.Interface, error) {
+			cfg, err := restConfigForClient(client)
+			if err != nil {
+				return nil, err
+			}
+			dyn, err := dynamic.NewForConfig(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create dynamic Kubernetes client: %w", err)
+			}
+			return dyn, nil
+		},
+	}
+}
+
+// Deploy creates the deployment. catalogClient must target the model catalog API for models
+// and the MCP catalog API for MCP servers. registryClient is only used, and only required,
+// when the request asks for registration.
+func (r *CatalogDeploymentRepository) Deploy(
+	ctx context.Context,
+	client k8s.KubernetesClientInterface,
+	catalogClient httpclient.HTTPClientInterface,
+	registryClient httpclient.HTTPClientInterface,
+	namespace string,
+	req models.CatalogDeploymentCreateRequest,
+) (models.CatalogDeployment, error) {
+	if namespace == "" {
+		return models.CatalogDeployment{}, errors.New("namespace is required")
+	}
+	if err := validateCatalogDeploymentRequest(req); err != nil {
+		return models.CatalogDeployment{}, err
+	}
+	if req.Registration != nil && registryClient == nil {
+		return models.CatalogDeployment{}, errors.New("model registry client is required for registration")
+	}
+
+	dyn, err := r.dynamicClient(client)
+	if err != nil {
+		return models.CatalogDeployment{}, err
+	}
+
+	if req.Kind == models.CatalogDeploymentKindMcpServer {
+		return r.deployMcpServer(ctx, dyn, catalogClient, namespace, req)
+	}
+	return r.deployModel(ctx, dyn, catalogClient, registryClient, namespace, req)
+}
+
+// catalogDeploymentTransaction records the steps of a deployment and how to undo them.
+type catalogDeploymentTransaction struct {
+	steps     []models.CatalogDeploymentStep
+	rollbacks []func(ctx context.Context) error
+}
+
+func (t *catalogDeploymentTransaction) completed(name string, resource string, rollback func(ctx context.Context) error) {
+	t.steps = append(t.steps, models.CatalogDeploymentStep{Name: name, Status: models.CatalogDeploymentStepCompleted, Resource: resource})
+	t.rollbacks = append(t.rollbacks, rollback)
+}
+
+func (t *catalogDeploymentTransaction) skipped(name string, resource string, message string) {
+	t.steps = append(t.steps, models.CatalogDeploymentStep{Name: name, Status: models.CatalogDeploymentStepSkipped, Resource: resource, Message: message})
+	t.rollbacks = append(t.rollbacks, nil)
+}
+
+// rollback undoes the completed steps in reverse order and wraps err with what was undone.
+// Steps that cannot be undone are logged and reported, and the remaining ones still run.
+// The steps are undone even if ctx was cancelled (e.g. the client went away mid-deployment),
+// under their own catalogDeploymentRollbackTimeout.
+func (t *catalogDeploymentTransaction) rollback(ctx context.Context, logger *slog.Logger, err error) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), catalogDeploymentRollbackTimeout)
+	defer cancel()
+
+	var undone, failed []string
+	for i := len(t.steps) - 1; i >= 0; i-- {
+		if t.rollbacks[i] == nil {
+			continue
+		}
+		step := &t.steps[i]
+		if rollbackErr := t.rollbacks[i](ctx); rollbackErr != nil {
+			if logger != nil {
+				logger.Warn("failed to roll back catalog deployment step", "step", step.Name, "resource", step.Resource, "error", rollbackErr)
+			}
+			failed = append(failed, step.Name+" "+step.Resource)
+			continue
+		}
+		step.Status = models.CatalogDeploymentStepRolledBack
+		undone = append(undone, step.Name+" "+step.Resource)
+	}
+	if len(undone) > 0 {
+		err = fmt.Errorf("%w (rolled back: %s)", err, strings.Join(undone, ", "))
+	}
+	if len(failed) > 0 {
+		err = fmt.Errorf("%w (rollback incomplete: %s)", err, strings.Join(failed, ", "))
+	}
+	return err
+}
+
+func (r *CatalogDeploymentRepository) deployModel(
+	ctx context.Context,
+	dyn dynamic.Interface,
+	catalogClient httpclient.HTTPClientInterface,
+	registryClient httpclient.HTTPClientInterface,
+	namespace string,
+	req models.CatalogDeploymentCreateRequest,
+) (models.CatalogDeployment, error) {
+	model, err := r.catalog.GetCatalogSourceModel(catalogClient, req.SourceId, req.ModelName)
+	if err != nil {
+		return models.CatalogDeployment{}, catalogLookupError(err, fmt.Sprintf("model %q in source %q", req.ModelName, req.SourceId))
+	}
+	artifacts, err := r.catalog.GetCatalogSourceModelArtifacts(catalogClient, req.SourceId, req.ModelName, nil)
+	if err != nil {
+		return models.CatalogDeployment{}, catalogLookupError(err, fmt.Sprintf("artifacts of model %q", req.ModelName))
+	}
+	storageURI := catalogModelStorageURI(artifacts)
+	if storageURI == "" {
+		return models.CatalogDeployment{}, fmt.Errorf("%w: catalog model %q has no artifact URI to serve", ErrCatalogDeploymentValidation, req.ModelName)
+	}
+
+	resources := sizeCatalogModel(*model)
+	if req.Resources != nil {
+		resources = *req.Resources
+	}
+	modelFormat := catalogModelFormat(*model)
+
+	runtime, err := r.selectServingRuntime(ctx, dyn, namespace, modelFormat, resources.GPU > 0, req.ServingRuntime)
+	if err != nil {
+		return models.CatalogDeployment{}, err
+	}
+
+	name := req.Name
+	if name == "" {
+		name = catalogDeploymentName(req.ModelName)
+	}
+	displayName := req.DisplayName
+	if displayName == "" {
+		displayName = req.ModelName
+	}
+
+	deployment := models.CatalogDeployment{
+		Kind:                 models.CatalogDeploymentKindModel,
+		Name:                 name,
+		Namespace:            namespace,
+		ServingRuntime:       runtime.name,
+		Resources:            &resources,
+		InferenceServiceName: name,
+	}
+	tx := &catalogDeploymentTransaction{}
+
+	if runtime.template == nil {
+		tx.skipped("servingRuntime", runtime.name, "using the existing ServingRuntime")
+	} else {
+		if _, err := dyn.Resource(servingRuntimeGVR).Namespace(namespace).Create(ctx, runtime.template, metav1.CreateOptions{}); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return models.CatalogDeployment{}, fmt.Errorf("failed to create ServingRuntime %q: %w", runtime.name, err)
+			}
+			tx.skipped("servingRuntime", runtime.name, "the ServingRuntime was created concurrently")
+		} else {
+			tx.completed("servingRuntime", runtime.name, func(ctx context.Context) error {
+				return dyn.Resource(servingRuntimeGVR).Namespace(namespace).Delete(ctx, runtime.name, metav1.DeleteOptions{})
+			})
+		}
+	}
+
+	isvc := buildCatalogInferenceService(namespace, name, displayName, req, runtime.name, modelFormat, storageURI, resources)
+
+	// A dry run catches invalid specs and missing permissions before anything is registered.
+	if _, err := dyn.Resource(inferenceServiceGVR).Namespace(namespace).Create(ctx, isvc, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}); err != nil {
+		return models.CatalogDeployment{}, tx.rollback(ctx, r.logger, inferenceServiceCreateError(err, name, namespace))
+	}
+
+	if req.Registration != nil {
+		registration := *req.Registration
+		registeredModelID, modelVersionID, err := r.registerCatalogModel(ctx, tx, registryClient, req, *model, modelFormat, storageURI)
+		if err != nil {
+			return models.CatalogDeployment{}, tx.rollback(ctx, r.logger, err)
+		}
+		deployment.ModelRegistryName = registration.ModelRegistryName
+		deployment.RegisteredModelId = registeredModelID
+		deployment.ModelVersionId = modelVersionID
+
+		labels := isvc.GetLabels()
+		labels[registryNameLabel] = registration.ModelRegistryName
+		labels[registeredModelIDLabel] = registeredModelID
+		labels[modelVersionIDLabel] = modelVersionID
+		isvc.SetLabels(labels)
+	}
+
+	if _, err := dyn.Resource(inferenceServiceGVR).Namespace(namespace).Create(ctx, isvc, metav1.CreateOptions{}); err != nil {
+		return models.CatalogDeployment{}, tx.rollback(ctx, r.logger, inferenceServiceCreateError(err, name, namespace))
+	}
+	tx.completed("inferenceService", name, nil)
+
+	deployment.Steps = tx.steps
+	return deployment, nil
+}
+
+// registerCatalogModel registers the catalog model as a new version, with a model artifact
+// pointing at the catalog, and records how to archive what it created.
+func (r *CatalogDeploymentRepository) registerCatalogModel(
+	ctx context.Context,
+	tx *catalogDeploymentTransaction,
+	registryClient httpclient.HTTPClientInterface,
+	req models.CatalogDeploymentCreateRequest,
+	model models.CatalogModel,
+	modelFormat string,
+	storageURI string,
+) (string, string, error) {
+	registration := req.Registration
+	archived, err := json.Marshal(map[string]string{"state": "ARCHIVED"})
+	if err != nil {
+		return "", "", err
+	}
+
+	registeredModelID := registration.RegisteredModelId
+	if registeredModelID == "" {
+		create := openapi.RegisteredModelCreate{Name: registration.RegisteredModelName, Description: model.Description}
+		data, err := json.Marshal(create)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to encode registered model: %w", err)
+		}
+		registeredModel, err := r.registry.CreateRegisteredModel(registryClient, data)
+		if err != nil {
+			return "", "", registryError(err, "failed to register model")
+		}
+		registeredModelID = registeredModel.GetId()
+		tx.completed("registeredModel", registeredModelID, func(context.Context) error {
+			_, err := r.registry.UpdateRegisteredModel(registryClient, registeredModelID, archived)
+			return err
+		})
+	} else if _, err := r.registry.GetRegisteredModel(registryClient, registeredModelID); err != nil {
+		return "", "", registryError(err, fmt.Sprintf("failed to read registered model %q", registeredModelID))
+	}
+
+	versionCreate := openapi.ModelVersionCreate{Name: registration.VersionName, RegisteredModelId: registeredModelID}
+	data, err := json.Marshal(versionCreate)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode model version: %w", err)
+	}
+	version, err := r.registry.CreateModelVersionForRegisteredModel(registryClient, registeredModelID, data)
+	if err != nil {
+		return "", "", registryError(err, "failed to register model version")
+	}
+	modelVersionID := version.GetId()
+	tx.completed("modelVersion", modelVersionID, func(context.Context) error {
+		_, err := r.registry.UpdateModelVersion(registryClient, modelVersionID, archived)
+		return err
+	})
+
+	artifactType := "model-artifact"
+	artifact := openapi.ModelArtifactCreate{
+		ArtifactType:     &artifactType,
+		Name:             &registration.VersionName,
+		Uri:              &storageURI,
+		ModelFormatName:  &modelFormat,
+		ModelSourceKind:  stringPtr(catalogModelSourceKind),
+		ModelSourceClass: &req.SourceId,
+		ModelSourceName:  &req.ModelName,
+	}
+	data, err = json.Marshal(artifact)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode model artifact: %w", err)
+	}
+	if _, err := r.registry.CreateModelArtifactByModelVersion(registryClient, modelVersionID, data); err != nil {
+		return "", "", registryError(err, "failed to register model artifact")
+	}
+
+	return registeredModelID, modelVersionID, nil
+}
+
+func (r *CatalogDeploymentRepository) deployMcpServer(
+	ctx context.Context,
+	dyn dynamic.Interface,
+	catalogClient httpclient.HTTPClientInterface,
+	namespace string,
+	req models.CatalogDeploymentCreateRequest,
+) (models.CatalogDeployment, error) {
+	server, err := r.catalog.GetMcpServer(catalogClient, req.McpServerId, nil)
+	if err != nil {
+		return models.CatalogDeployment{}, catalogLookupError(err, fmt.Sprintf("MCP server %q", req.McpServerId))
+	}
+	image := helper.ExtractContainerImage(server.Artifacts)
+	if image == "" {
+		return models.CatalogDeployment{}, fmt.Errorf("%w: MCP server %q has no usable container image artifact", ErrCatalogDeploymentValidation, req.McpServerId)
+	}
+
+	name := req.Name
+	if name == "" {
+		name = catalogDeploymentName(server.Name)
+	}
+	mcpServer := helper.ConvertToMCPServer(server.RuntimeMetadata, helper.ConversionOptions{Name: name, ContainerImage: image}).MCPServer
+	mcpServer.Metadata.Namespace = namespace
+
+	env, err := catalogMcpServerEnv(server.RuntimeMetadata, req.Env)
+	if err != nil {
+		return models.CatalogDeployment{}, err
+	}
+	mcpServer.Spec.Config.Env = env
+
+	displayName := req.DisplayName
+	if displayName == "" && server.DisplayName != nil {
+		displayName = *server.DisplayName
+	}
+	mcpServer.Metadata.Annotations = map[string]string{mcpCatalogServerAnnotation: server.Name}
+	if displayName != "" {
+		mcpServer.Metadata.Annotations[mcpDisplayNameAnnotation] = displayName
+	}
+
+	obj, err := convertMcpServerToUnstructured(*mcpServer)
+	if err != nil {
+		return models.CatalogDeployment{}, err
+	}
+	created, err := dyn.Resource(mcpServerGVR).Namespace(namespace).Create(ctx, &obj, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return models.CatalogDeployment{}, fmt.Errorf("%w: MCPServer %q in namespace %q", ErrCatalogDeploymentConflict, name, namespace)
+		}
+		return models.CatalogDeployment{}, fmt.Errorf("failed to create MCPServer: %w", err)
+	}
+	mcpDeployment, err := convertUnstructuredToMcpDeployment(*created)
+	if err != nil {
+		return models.CatalogDeployment{}, err
+	}
+
+	return models.CatalogDeployment{
+		Kind:          models.CatalogDeploymentKindMcpServer,
+		Name:          name,
+		Namespace:     namespace,
+		McpDeployment: &mcpDeployment,
+		Steps: []models.CatalogDeploymentStep{
+			{Name: "mcpServer", Status: models.CatalogDeploymentStepCompleted, Resource: name},
+		},
+	}, nil
+}
+
+// selectedServingRuntime is a ServingRuntime in the namespace, or one to create from a
+// dashboard template when template is set.
+type selectedServingRuntime struct {
+	name     string
+	priority int
+	template *unstructured.Unstructured
+}
+
+// selectServingRuntime picks the ServingRuntime to serve modelFormat with. The runtimes of the
+// namespace are preferred over dashboard templates. Without an explicit runtime, only runtimes
+// that auto-select the format, and recommend a GPU when one is needed, are considered, and the
+// highest priority wins.
+func (r *CatalogDeploymentRepository) selectServingRuntime(ctx context.Context, dyn dynamic.Interface, namespace string, modelFormat string, needsGPU bool, requested string) (selectedServingRuntime, error) {
+	var candidates []selectedServingRuntime
+
+	runtimes, err := dyn.Resource(servingRuntimeGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return selectedServingRuntime{}, fmt.Errorf("failed to list ServingRuntimes: %w", err)
+	}
+	for i := range runtimes.Items {
+		runtime := runtimes.Items[i]
+		if requested != "" && runtime.GetName() != requested {
+			continue
+		}
+		if priority, ok := servingRuntimeSupports(runtime, modelFormat, needsGPU, requested != ""); ok {
+			candidates = append(candidates, selectedServingRuntime{name: runtime.GetName(), priority: priority})
+		}
+	}
+
+	if len(candidates) == 0 && r.templateNamespace != "" {
+		templates, err := dyn.Resource(templateGVR).Namespace(r.templateNamespace).List(ctx, metav1.ListOptions{LabelSelector: dashboardLabel + "=true"})
+		if err != nil {
+			return selectedServingRuntime{}, fmt.Errorf("failed to list serving runtime templates: %w", err)
+		}
+		for _, template := range templates.Items {
+			runtime := servingRuntimeFromTemplate(template, namespace)
+			if runtime == nil || (requested != "" && template.GetName() != requested && runtime.GetName() != requested) {
+				continue
+			}
+			if priority, ok := servingRuntimeSupports(*runtime, modelFormat, needsGPU, requested != ""); ok {
+				candidates = append(candidates, selectedServingRuntime{name: runtime.GetName(), priority: priority, template: runtime})
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		if requested != "" {
+			return selectedServingRuntime{}, fmt.Errorf("%w: serving runtime %q does not exist or does not support model format %q", ErrCatalogDeploymentNoCompatibleRuntime, requested, modelFormat)
+		}
+		return selectedServingRuntime{}, fmt.Errorf("%w: no serving runtime supports model format %q", ErrCatalogDeploymentNoCompatibleRuntime, modelFormat)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].priority != candidates[j].priority {
+			return candidates[i].priority > candidates[j].priority
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	return candidates[0], nil
+}
+
+// servingRuntimeSupports reports whether a single-model ServingRuntime serves modelFormat, and
+// with which priority.
+func servingRuntimeSupports(runtime unstructured.Unstructured, modelFormat string, needsGPU bool, explicit bool) (int, bool) {
+	if disabled, _, _ := unstructured.NestedBool(runtime.Object, "spec", "disabled"); disabled {
+		return 0, false
+	}
+	if multiModel, _, _ := unstructured.NestedBool(runtime.Object, "spec", "multiModel"); multiModel {
+		return 0, false
+	}
+	if needsGPU && !explicit {
+		if raw := runtime.GetAnnotations()[recommendedAcceleratorAnnotation]; raw != "" {
+			var accelerators []string
+			if err := json.Unmarshal([]byte(raw), &accelerators); err == nil && !slices.Contains(accelerators, gpuResourceName) {
+				return 0, false
+			}
+		}
+	}
+
+	formats, _, _ := unstructured.NestedSlice(runtime.Object, "spec", "supportedModelFormats")
+	for _, item := range formats {
+		format, ok := item.(map[string]interface{})
+		if !ok || !strings.EqualFold(getString(format, "name"), modelFormat) {
+			continue
+		}
+		if autoSelect, ok := format["autoSelect"].(bool); !explicit && (!ok || !autoSelect) {
+			continue
+		}
+		priority, _, _ := unstructured.NestedInt64(format, "priority")
+		return int(priority), true
+	}
+	return 0, false
+}
+
+// servingRuntimeFromTemplate returns the ServingRuntime of a dashboard template, annotated the
+// way the dashboard annotates the runtimes it creates from templates.
+func servingRuntimeFromTemplate(template unstructured.Unstructured, namespace string) *unstructured.Unstructured {
+	objects, _, _ := unstructured.NestedSlice(template.Object, "objects")
+	for _, item := range objects {
+		obj, ok := item.(map[string]interface{})
+		if !ok || getString(obj, "kind") != "ServingRuntime" {
+			continue
+		}
+		runtime := &unstructured.Unstructured{Object: obj}
+		runtime = runtime.DeepCopy()
+		runtime.SetNamespace(namespace)
+		runtime.SetResourceVersion("")
+		runtime.SetUID("")
+
+		labels := runtime.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[dashboardLabel] = "true"
+		runtime.SetLabels(labels)
+
+		annotations := runtime.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[templateNameAnnotation] = template.GetName()
+		displayName := annotations[displayNameAnnotation]
+		if displayName == "" {
+			displayName = template.GetAnnotations()[displayNameAnnotation]
+		}
+		if displayName != "" {
+			annotations[templateDisplayNameAnnotation] = displayName
+			annotations[displayNameAnnotation] = displayName
+		}
+		runtime.SetAnnotations(annotations)
+		return runtime
+	}
+	return nil
+}
+
+func buildCatalogInferenceService(namespace string, name string, displayName string, req models.CatalogDeploymentCreateRequest, runtime string, modelFormat string, storageURI string, resources models.CatalogDeploymentResources) *unstructured.Unstructured {
+	quantities := map[string]interface{}{}
+	if resources.CPU != "" {
+		quantities["cpu"] = resources.CPU
+	}
+	if resources.Memory != "" {
+		quantities["memory"] = resources.Memory
+	}
+	if resources.GPU > 0 {
+		quantities[gpuResourceName] = strconv.Itoa(resources.GPU)
+	}
+	limits := map[string]interface{}{}
+	for key, value := range quantities {
+		limits[key] = value
+	}
+
+	isvc := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": inferenceServiceAPIVersion,
+		"kind":       inferenceServiceKind,
+		"spec": map[string]interface{}{
+			"predictor": map[string]interface{}{
+				"model": map[string]interface{}{
+					"modelFormat": map[string]interface{}{"name": modelFormat},
+					"runtime":     runtime,
+					"storageUri":  storageURI,
+					"resources": map[string]interface{}{
+						"requests": quantities,
+						"limits":   limits,
+					},
+				},
+			},
+		},
+	}}
+	isvc.SetName(name)
+	isvc.SetNamespace(namespace)
+	isvc.SetLabels(map[string]string{dashboardLabel: "true"})
+	isvc.SetAnnotations(map[string]string{
+		displayNameAnnotation:      displayName,
+		catalogSourceIDAnnotation:  req.SourceId,
+		catalogModelNameAnnotation: req.ModelName,
+	})
+	return isvc
+}
+
+var (
+	catalogModelSizeRegexp    = regexp.MustCompile(`(?i)([0-9]+(?:\.[0-9]+)?)\s*([MB])`)
+	invalidDeploymentNameChar = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// catalogModelSizeTiers size a model by its parameter count in billions, after quantization.
+var catalogModelSizeTiers = []struct {
+	maxBillions float64
+	resources   models.CatalogDeploymentResources
+}{
+	{3, models.CatalogDeploymentResources{CPU: "4", Memory: "16Gi", GPU: 1}},
+	{14, models.CatalogDeploymentResources{CPU: "8", Memory: "32Gi", GPU: 1}},
+	{40, models.CatalogDeploymentResources{CPU: "16", Memory: "64Gi", GPU: 2}},
+	{math.Inf(1), models.CatalogDeploymentResources{CPU: "32", Memory: "128Gi", GPU: 4}},
+}
+
+// sizeCatalogModel sizes a deployment from the "size" custom property of a catalog model, such
+// as "8B params", scaled down for FP8, INT8 and 4-bit "tensor_type"s. Models without a size get
+// the smallest tier.
+func sizeCatalogModel(model models.CatalogModel) models.CatalogDeploymentResources {
+	match := catalogModelSizeRegexp.FindStringSubmatch(catalogModelStringProperty(model, "size"))
+	if match == nil {
+		return catalogModelSizeTiers[0].resources
+	}
+	billions, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return catalogModelSizeTiers[0].resources
+	}
+	if strings.EqualFold(match[2], "M") {
+		billions /= 1000
+	}
+
+	tensorType := strings.ToUpper(catalogModelStringProperty(model, "tensor_type"))
+	switch {
+	case strings.Contains(tensorType, "4"):
+		billions /= 4
+	case strings.Contains(tensorType, "8"):
+		billions /= 2
+	}
+
+	for _, tier := range catalogModelSizeTiers {
+		if billions <= tier.maxBillions {
+			return tier.resources
+		}
+	}
+	return catalogModelSizeTiers[len(catalogModelSizeTiers)-1].resources
+}
+
+func catalogModelFormat(model models.CatalogModel) string {
+	if format := catalogModelStringProperty(model, catalogModelFormatProperty); format != "" {
+		return format
+	}
+	return defaultCatalogModelFormat
+}
+
+func catalogModelStringProperty(model models.CatalogModel, key string) string {
+	if model.CustomProperties == nil {
+		return ""
+	}
+	value, ok := (*model.CustomProperties)[key]
+	if !ok || value.MetadataStringValue == nil {
+		return ""
+	}
+	return strings.TrimSpace(value.MetadataStringValue.StringValue)
+}
+
+func catalogModelStorageURI(artifacts *models.CatalogModelArtifactList) string {
+	if artifacts == nil {
+		return ""
+	}
+	for _, artifact := range artifacts.Items {
+		if artifact.ArtifactType == "model-artifact" && artifact.Uri != nil && *artifact.Uri != "" {
+			return *artifact.Uri
+		}
+	}
+	return ""
+}
+
+// catalogMcpServerEnv builds the environment of an MCP server from the request, failing when a
+// required variable of the catalog entry is missing.
+func catalogMcpServerEnv(metadata *models.McpRuntimeMetadata, values map[string]string) ([]models.MCPEnvVar, error) {
+	var env []models.MCPEnvVar
+	seen := map[string]bool{}
+	if metadata != nil {
+		var missing []string
+		for _, variable := range metadata.RequiredEnvironmentVariables {
+			value, ok := values[variable.Name]
+			if !ok || value == "" {
+				missing = append(missing, variable.Name)
+				continue
+			}
+			env = append(env, models.MCPEnvVar{Name: variable.Name, Value: value})
+			seen[variable.Name] = true
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("%w: missing required environment variables: %s", ErrCatalogDeploymentValidation, strings.Join(missing, ", "))
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, models.MCPEnvVar{Name: name, Value: values[name]})
+	}
+	return env, nil
+}
+
+// catalogDeploymentName derives a Kubernetes name from a catalog entry name, such as
+// "ibm-granite/granite-3.1-8b-instruct".
+func catalogDeploymentName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Trim(invalidDeploymentNameChar.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(name) > 53 {
+		name = strings.TrimRight(name[:53], "-")
+	}
+	if name == "" {
+		return "catalog-deployment"
+	}
+	return name
+}
+
+func validateCatalogDeploymentRequest(req models.CatalogDeploymentCreateRequest) error {
+	switch req.Kind {
+	case models.CatalogDeploymentKindModel:
+		if strings.TrimSpace(req.SourceId) == "" || strings.TrimSpace(req.ModelName) == "" {
+			return fmt.Errorf("%w: sourceId and modelName are required", ErrCatalogDeploymentValidation)
+		}
+		if req.Resources != nil && req.Resources.GPU < 0 {
+			return fmt.Errorf("%w: gpu must not be negative", ErrCatalogDeploymentValidation)
+		}
+		if reg := req.Registration; reg != nil {
+			if reg.ModelRegistryName == "" || reg.VersionName == "" {
+				return fmt.Errorf("%w: registration requires modelRegistryName and versionName", ErrCatalogDeploymentValidation)
+			}
+			if (reg.RegisteredModelId == "") == (reg.RegisteredModelName == "") {
+				return fmt.Errorf("%w: registration requires exactly one of registeredModelId and registeredModelName", ErrCatalogDeploymentValidation)
+			}
+		}
+	case models.CatalogDeploymentKindMcpServer:
+		if strings.TrimSpace(req.McpServerId) == "" {
+			return fmt.Errorf("%w: mcpServerId is required", ErrCatalogDeploymentValidation)
+		}
+		if req.ServingRuntime != "" || req.Resources != nil || req.Registration != nil {
+			return fmt.Errorf("%w: servingRuntime, resources and registration only apply to models", ErrCatalogDeploymentValidation)
+		}
+	default:
+		return fmt.Errorf("%w: kind must be %q or %q", ErrCatalogDeploymentValidation, models.CatalogDeploymentKindModel, models.CatalogDeploymentKindMcpServer)
+	}
+
+	if req.Name != "" {
+		if err := validateMcpDeploymentName(req.Name); err != nil {
+			return fmt.Errorf("%w: invalid name: %v", ErrCatalogDeploymentValidation, err)
+		}
+	}
+	return nil
+}
+
+func catalogLookupError(err error, what string) error {
+	var httpErr *httpclient.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 404 {
+		return fmt.Errorf("%w: %s", ErrCatalogDeploymentNotFound, what)
+	}
+	return fmt.Errorf("failed to read %s from the catalog: %w", what, err)
+}
+
+func registryError(err error, message string) error {
+	var httpErr *httpclient.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 409 {
+		return fmt.Errorf("%w: %s: %s", ErrCatalogDeploymentConflict, message, httpErr.Message)
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+func inferenceServiceCreateError(err error, name string, namespace string) error {
+	if apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("%w: InferenceService %q in namespace %q", ErrCatalogDeploymentConflict, name, namespace)
+	}
+	return fmt.Errorf("failed to create InferenceService %q: %w", name, err)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,432 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/hub/pkg/openapi"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/kubeflow/hub/ui/bff/internal/repositories"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const catalogDeploymentTestNamespace = "team-a"
+
+type fakeDeploymentCatalog struct {
+	repositories.ModelCatalogClientInterface
+	model     *models.CatalogModel
+	artifacts *models.CatalogModelArtifactList
+	mcpServer *models.McpServer
+	err       error
+}
+
+func (f *fakeDeploymentCatalog) GetCatalogSourceModel(httpclient.HTTPClientInterface, string, string) (*models.CatalogModel, error) {
+	return f.model, f.err
+}
+
+func (f *fakeDeploymentCatalog) GetCatalogSourceModelArtifacts(httpclient.HTTPClientInterface, string, string, url.Values) (*models.CatalogModelArtifactList, error) {
+	return f.artifacts, nil
+}
+
+func (f *fakeDeploymentCatalog) GetMcpServer(httpclient.HTTPClientInterface, string, url.Values) (*models.McpServer, error) {
+	return f.mcpServer, f.err
+}
+
+// fakeDeploymentRegistry records what is registered and archived.
+type fakeDeploymentRegistry struct {
+	repositories.ModelRegistryClientInterface
+	archivedModels   []string
+	archivedVersions []string
+	artifact         openapi.ModelArtifactCreate
+}
+
+func (f *fakeDeploymentRegistry) CreateRegisteredModel(_ httpclient.HTTPClientInterface, _ []byte) (*openapi.RegisteredModel, error) {
+	id := "rm-1"
+	return &openapi.RegisteredModel{Id: &id}, nil
+}
+
+func (f *fakeDeploymentRegistry) UpdateRegisteredModel(_ httpclient.HTTPClientInterface, id string, _ []byte) (*openapi.RegisteredModel, error) {
+	f.archivedModels = append(f.archivedModels, id)
+	return &openapi.RegisteredModel{Id: &id}, nil
+}
+
+func (f *fakeDeploymentRegistry) CreateModelVersionForRegisteredModel(_ httpclient.HTTPClientInterface, _ string, _ []byte) (*openapi.ModelVersion, error) {
+	id := "mv-1"
+	return &openapi.ModelVersion{Id: &id}, nil
+}
+
+func (f *fakeDeploymentRegistry) UpdateModelVersion(_ httpclient.HTTPClientInterface, id string, _ []byte) (*openapi.ModelVersion, error) {
+	f.archivedVersions = append(f.archivedVersions, id)
+	return &openapi.ModelVersion{Id: &id}, nil
+}
+
+func (f *fakeDeploymentRegistry) CreateModelArtifactByModelVersion(_ httpclient.HTTPClientInterface, _ string, data []byte) (*openapi.ModelArtifact, error) {
+	if err := json.Unmarshal(data, &f.artifact); err != nil {
+		return nil, err
+	}
+	return &openapi.ModelArtifact{}, nil
+}
+
+func stringMetadata(value string) openapi.MetadataValue {
+	return openapi.MetadataValue{MetadataStringValue: &openapi.MetadataStringValue{StringValue: value, MetadataType: "MetadataStringValue"}}
+}
+
+func catalogModelWithProperties(properties map[string]string) *models.CatalogModel {
+	custom := map[string]openapi.MetadataValue{}
+	for key, value := range properties {
+		custom[key] = stringMetadata(value)
+	}
+	return &models.CatalogModel{Name: "ibm-granite/granite-3.1-8b-instruct", CustomProperties: &custom}
+}
+
+func catalogModelArtifacts(uri string) *models.CatalogModelArtifactList {
+	return &models.CatalogModelArtifactList{Items: []models.CatalogArtifact{
+		{ArtifactType: "metrics-artifact"},
+		{ArtifactType: "model-artifact", Uri: &uri},
+	}}
+}
+
+func servingRuntime(name string, format string, priority int64, accelerators string) *unstructured.Unstructured {
+	runtime := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.kserve.io/v1alpha1",
+		"kind":       "ServingRuntime",
+		"spec": map[string]interface{}{
+			"supportedModelFormats": []interface{}{
+				map[string]interface{}{"name": format, "autoSelect": true, "priority": priority},
+			},
+		},
+	}}
+	runtime.SetName(name)
+	if accelerators != "" {
+		runtime.SetAnnotations(map[string]string{recommendedAcceleratorAnnotation: accelerators})
+	}
+	return runtime
+}
+
+func servingRuntimeTemplate(name string, runtime *unstructured.Unstructured) *unstructured.Unstructured {
+	template := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "template.openshift.io/v1",
+		"kind":       "Template",
+		"objects":    []interface{}{runtime.Object},
+	}}
+	template.SetName(name)
+	template.SetNamespace("opendatahub")
+	template.SetLabels(map[string]string{dashboardLabel: "true"})
+	template.SetAnnotations(map[string]string{displayNameAnnotation: "vLLM NVIDIA GPU ServingRuntime"})
+	return template
+}
+
+// newCatalogDeploymentDynamicClient returns a fake dynamic client that, like the API server,
+// does not persist dry-run creates.
+func newCatalogDeploymentDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		inferenceServiceGVR: "InferenceServiceList",
+		servingRuntimeGVR:   "ServingRuntimeList",
+		templateGVR:         "TemplateList",
+		mcpServerGVR:        "MCPServerList",
+	}, objects...)
+	dyn.PrependReactor("create", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		create, ok := action.(k8stesting.CreateActionImpl)
+		if ok && len(create.GetCreateOptions().DryRun) > 0 {
+			return true, create.GetObject(), nil
+		}
+		return false, nil, nil
+	})
+	return dyn
+}
+
+func newTestCatalogDeploymentRepository(catalog repositories.ModelCatalogClientInterface, registry repositories.ModelRegistryClientInterface, dyn dynamic.Interface) *CatalogDeploymentRepository {
+	repo := NewCatalogDeploymentRepository(nil, catalog, registry, "opendatahub")
+	repo.dynamicClient = func(k8s.KubernetesClientInterface) (dynamic.Interface, error) {
+		return dyn, nil
+	}
+	return repo
+}
+
+func modelDeploymentRequest() models.CatalogDeploymentCreateRequest {
+	return models.CatalogDeploymentCreateRequest{
+		Kind:      models.CatalogDeploymentKindModel,
+		SourceId:  "redhat_ai_models",
+		ModelName: "ibm-granite/granite-3.1-8b-instruct",
+	}
+}
+
+func TestDeployModelUsesHighestPriorityRuntimeInNamespace(t *testing.T) {
+	low := servingRuntime("vllm-low", "vLLM", 1, "")
+	low.SetNamespace(catalogDeploymentTestNamespace)
+	high := servingRuntime("vllm-high", "vLLM", 5, `["nvidia.com/gpu"]`)
+	high.SetNamespace(catalogDeploymentTestNamespace)
+	cpuOnly := servingRuntime("vllm-cpu", "vLLM", 10, `["intel.com/gaudi"]`)
+	cpuOnly.SetNamespace(catalogDeploymentTestNamespace)
+	dyn := newCatalogDeploymentDynamicClient(low, high, cpuOnly)
+
+	catalog := &fakeDeploymentCatalog{
+		model:     catalogModelWithProperties(map[string]string{"size": "8B params"}),
+		artifacts: catalogModelArtifacts("oci://registry.redhat.io/rhelai1/granite-3-1-8b-instruct:1.5"),
+	}
+	repo := newTestCatalogDeploymentRepository(catalog, nil, dyn)
+
+	result, err := repo.Deploy(context.Background(), nil, nil, nil, catalogDeploymentTestNamespace, modelDeploymentRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ServingRuntime != "vllm-high" {
+		t.Fatalf("expected vllm-high, got %q", result.ServingRuntime)
+	}
+	if result.Name != "granite-3-1-8b-instruct" {
+		t.Fatalf("unexpected name %q", result.Name)
+	}
+	if result.Resources == nil || result.Resources.GPU != 1 || result.Resources.Memory != "32Gi" {
+		t.Fatalf("unexpected resources %+v", result.Resources)
+	}
+	if len(result.Steps) != 2 || result.Steps[0].Status != models.CatalogDeploymentStepSkipped {
+		t.Fatalf("unexpected steps %+v", result.Steps)
+	}
+
+	isvc, err := dyn.Resource(inferenceServiceGVR).Namespace(catalogDeploymentTestNamespace).Get(context.Background(), result.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected InferenceService to be created: %v", err)
+	}
+	storageURI, _, _ := unstructured.NestedString(isvc.Object, "spec", "predictor", "model", "storageUri")
+	if storageURI != "oci://registry.redhat.io/rhelai1/granite-3-1-8b-instruct:1.5" {
+		t.Fatalf("unexpected storageUri %q", storageURI)
+	}
+	gpu, _, _ := unstructured.NestedString(isvc.Object, "spec", "predictor", "model", "resources", "limits", gpuResourceName)
+	if gpu != "1" {
+		t.Fatalf("expected 1 GPU limit, got %q", gpu)
+	}
+}
+
+func TestDeployModelCreatesRuntimeFromTemplate(t *testing.T) {
+	template := servingRuntimeTemplate("vllm-cuda-runtime-template", servingRuntime("vllm-cuda-runtime", "vLLM", 2, `["nvidia.com/gpu"]`))
+	dyn := newCatalogDeploymentDynamicClient(template)
+
+	catalog := &fakeDeploymentCatalog{
+		model:     catalogModelWithProperties(map[string]string{"size": "70B params", "tensor_type": "FP8"}),
+		artifacts: catalogModelArtifacts("oci://quay.io/models/llama-70b:1.0"),
+	}
+	repo := newTestCatalogDeploymentRepository(catalog, nil, dyn)
+
+	result, err := repo.Deploy(context.Background(), nil, nil, nil, catalogDeploymentTestNamespace, modelDeploymentRequest())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Steps[0].Name != "servingRuntime" || result.Steps[0].Status != models.CatalogDeploymentStepCompleted {
+		t.Fatalf("expected the runtime to be created, got %+v", result.Steps)
+	}
+	if result.Resources.GPU != 2 {
+		t.Fatalf("expected a 70B FP8 model to get 2 GPUs, got %+v", result.Resources)
+	}
+
+	runtime, err := dyn.Resource(servingRuntimeGVR).Namespace(catalogDeploymentTestNamespace).Get(context.Background(), "vllm-cuda-runtime", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected ServingRuntime to be created: %v", err)
+	}
+	if runtime.GetAnnotations()[templateNameAnnotation] != "vllm-cuda-runtime-template" {
+		t.Fatalf("unexpected annotations %v", runtime.GetAnnotations())
+	}
+}
+
+func TestDeployModelFailsWithoutCompatibleRuntime(t *testing.T) {
+	runtime := servingRuntime("ovms", "onnx", 1, "")
+	runtime.SetNamespace(catalogDeploymentTestNamespace)
+	dyn := newCatalogDeploymentDynamicClient(runtime)
+
+	catalog := &fakeDeploymentCatalog{
+		model:     catalogModelWithProperties(nil),
+		artifacts: catalogModelArtifacts("oci://quay.io/models/small:1.0"),
+	}
+	repo := newTestCatalogDeploymentRepository(catalog, nil, dyn)
+
+	_, err := repo.Deploy(context.Background(), nil, nil, nil, catalogDeploymentTestNamespace, modelDeploymentRequest())
+	if !errors.Is(err, ErrCatalogDeploymentNoCompatibleRuntime) {
+		t.Fatalf("expected ErrCatalogDeploymentNoCompatibleRuntime, got %v", err)
+	}
+}
+
+func TestDeployModelRegistersAndRollsBackWhenInferenceServiceFails(t *testing.T) {
+	template := servingRuntimeTemplate("vllm-template", servingRuntime("vllm-runtime", "vLLM", 1, ""))
+	dyn := newCatalogDeploymentDynamicClient(template)
+	dyn.PrependReactor("create", "inferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		create := action.(k8stesting.CreateActionImpl)
+		if len(create.GetCreateOptions().DryRun) > 0 {
+			return true, create.GetObject(), nil
+		}
+		return true, nil, apierrors.NewInternalError(errors.New("webhook unavailable"))
+	})
+
+	catalog := &fakeDeploymentCatalog{
+		model:     catalogModelWithProperties(nil),
+		artifacts: catalogModelArtifacts("oci://quay.io/models/small:1.0"),
+	}
+	registry := &fakeDeploymentRegistry{}
+	repo := newTestCatalogDeploymentRepository(catalog, registry, dyn)
+
+	req := modelDeploymentRequest()
+	req.Registration = &models.CatalogDeploymentRegistration{
+		ModelRegistryName:   "prod",
+		RegisteredModelName: "granite",
+		VersionName:         "v1",
+	}
+	_, err := repo.Deploy(context.Background(), nil, nil, &httpclient.HTTPClient{}, catalogDeploymentTestNamespace, req)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("expected the error to report the rollback, got %v", err)
+	}
+
+	if registry.artifact.ModelSourceKind == nil || *registry.artifact.ModelSourceKind != "catalog" {
+		t.Fatalf("expected the artifact to point at the catalog, got %+v", registry.artifact)
+	}
+	if len(registry.archivedVersions) != 1 || registry.archivedVersions[0] != "mv-1" {
+		t.Fatalf("expected the version to be archived, got %v", registry.archivedVersions)
+	}
+	if len(registry.archivedModels) != 1 || registry.archivedModels[0] != "rm-1" {
+		t.Fatalf("expected the registered model to be archived, got %v", registry.archivedModels)
+	}
+	if _, err := dyn.Resource(servingRuntimeGVR).Namespace(catalogDeploymentTestNamespace).Get(context.Background(), "vllm-runtime", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the ServingRuntime to be deleted, got %v", err)
+	}
+}
+
+// cancelAwareDynamicClient fails deletes with a cancelled context, like a real client, which the
+// fake dynamic client does not.
+type cancelAwareDynamicClient struct {
+	dynamic.Interface
+}
+
+func (c cancelAwareDynamicClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return cancelAwareResource{NamespaceableResourceInterface: c.Interface.Resource(gvr)}
+}
+
+type cancelAwareResource struct {
+	dynamic.NamespaceableResourceInterface
+}
+
+func (r cancelAwareResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return cancelAwareNamespacedResource{ResourceInterface: r.NamespaceableResourceInterface.Namespace(namespace)}
+}
+
+type cancelAwareNamespacedResource struct {
+	dynamic.ResourceInterface
+}
+
+func (r cancelAwareNamespacedResource) Delete(ctx context.Context, name string, options metav1.DeleteOptions, subresources ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.ResourceInterface.Delete(ctx, name, options, subresources...)
+}
+
+func TestDeployModelRollsBackWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	template := servingRuntimeTemplate("vllm-template", servingRuntime("vllm-runtime", "vLLM", 1, ""))
+	dyn := newCatalogDeploymentDynamicClient(template)
+	dyn.PrependReactor("create", "inferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		create := action.(k8stesting.CreateActionImpl)
+		if len(create.GetCreateOptions().DryRun) > 0 {
+			return true, create.GetObject(), nil
+		}
+		// the client goes away while the InferenceService is being created
+		cancel()
+		return true, nil, context.Canceled
+	})
+
+	catalog := &fakeDeploymentCatalog{
+		model:     catalogModelWithProperties(nil),
+		artifacts: catalogModelArtifacts("oci://quay.io/models/small:1.0"),
+	}
+	repo := newTestCatalogDeploymentRepository(catalog, nil, cancelAwareDynamicClient{Interface: dyn})
+
+	_, err := repo.Deploy(ctx, nil, nil, nil, catalogDeploymentTestNamespace, modelDeploymentRequest())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if strings.Contains(err.Error(), "rollback incomplete") {
+		t.Fatalf("expected the rollback to complete, got %v", err)
+	}
+	if _, err := dyn.Resource(servingRuntimeGVR).Namespace(catalogDeploymentTestNamespace).Get(context.Background(), "vllm-runtime", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the ServingRuntime to be deleted, got %v", err)
+	}
+}
+
+func TestDeployMcpServerRequiresEnvironment(t *testing.T) {
+	server := &models.McpServer{
+		Name:      "github",
+		Artifacts: []models.McpArtifact{{URI: "oci://quay.io/mcp/github:1.0"}},
+		RuntimeMetadata: &models.McpRuntimeMetadata{
+			RequiredEnvironmentVariables: []models.McpEnvVarMetadata{{Name: "GITHUB_TOKEN"}},
+		},
+	}
+	dyn := newCatalogDeploymentDynamicClient()
+	repo := newTestCatalogDeploymentRepository(&fakeDeploymentCatalog{mcpServer: server}, nil, dyn)
+
+	req := models.CatalogDeploymentCreateRequest{Kind: models.CatalogDeploymentKindMcpServer, McpServerId: "github"}
+	if _, err := repo.Deploy(context.Background(), nil, nil, nil, catalogDeploymentTestNamespace, req); !errors.Is(err, ErrCatalogDeploymentValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	req.Env = map[string]string{"GITHUB_TOKEN": "secret", "LOG_LEVEL": "debug"}
+	result, err := repo.Deploy(context.Background(), nil, nil, nil, catalogDeploymentTestNamespace, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.McpDeployment == nil || result.McpDeployment.Image != "quay.io/mcp/github:1.0" {
+		t.Fatalf("unexpected MCP deployment %+v", result.McpDeployment)
+	}
+}
+
+func TestSizeCatalogModel(t *testing.T) {
+	tests := []struct {
+		size       string
+		tensorType string
+		gpu        int
+		memory     string
+	}{
+		{"", "", 1, "16Gi"},
+		{"500M params", "", 1, "16Gi"},
+		{"8B params", "", 1, "32Gi"},
+		{"8B params", "INT4", 1, "16Gi"},
+		{"34B params", "", 2, "64Gi"},
+		{"70B params", "", 4, "128Gi"},
+		{"70B params", "FP8", 2, "64Gi"},
+	}
+
+	for _, tc := range tests {
+		model := catalogModelWithProperties(map[string]string{"size": tc.size, "tensor_type": tc.tensorType})
+		resources := sizeCatalogModel(*model)
+		if resources.GPU != tc.gpu || resources.Memory != tc.memory {
+			t.Errorf("size %q %q: expected %d GPU and %s, got %+v", tc.size, tc.tensorType, tc.gpu, tc.memory, resources)
+		}
+	}
+}
+
+func TestCatalogDeploymentName(t *testing.T) {
+	tests := map[string]string{
+		"ibm-granite/granite-3.1-8b-instruct": "granite-3-1-8b-instruct",
+		"Mistral_7B":                          "mistral-7b",
+		"///":                                 "catalog-deployment",
+	}
+	for input, expected := range tests {
+		if got := catalogDeploymentName(input); got != expected {
+			t.Errorf("catalogDeploymentName(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/kubeflow/hub/ui/bff/internal/constants"
+	helper "github.com/kubeflow/hub/ui/bff/internal/helpers"
 	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
 	"github.com/kubeflow/hub/ui/bff/internal/models"
 	corev1 "k8s.io/api/core/v1"
@@ -406,6 +407,12 @@ func (r *ModelCatalogSettingsRepository) DeleteCatalogSourceConfig(
 		return nil, fmt.Errorf("failed to update configmap after deletion: %w", err)
 	}
 
+	// The sync state is only useful while the source exists. A failure to remove it is not
+	// worth failing the deletion for.
+	if err := client.DeleteConfigMap(ctx, namespace, CatalogSourceSyncConfigMapName(catalogSourceId)); err != nil && !apierrors.IsNotFound(err) {
+		helper.GetContextLogger(ctx).Warn("failed to delete catalog source sync state", "catalogId", catalogSourceId, "error", err)
+	}
+
 	return catalogSourceToDelete, nil
 }
 
@@ -0,0 +1,486 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	helper "github.com/kubeflow/hub/ui/bff/internal/helpers"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Each catalog source keeps its sync schedule, last snapshot and history in a ConfigMap
+	// named after the source, next to the catalog source ConfigMaps.
+	catalogSourceSyncConfigMapPrefix  = "model-catalog-sync-"
+	catalogSourceSyncStateKey         = "sync.json"
+	catalogSourceSyncLabel            = "modelregistry.kubeflow.org/catalog-source-sync"
+	catalogSourceSyncSourceAnnotation = "modelregistry.kubeflow.org/catalog-source-id"
+
+	defaultCatalogSourceSyncIntervalMinutes = 24 * 60
+	minCatalogSourceSyncIntervalMinutes     = 15
+	maxCatalogSourceSyncIntervalMinutes     = 30 * 24 * 60
+	maxCatalogSourceSyncTrackedModels       = 100
+	maxCatalogSourceSyncHistory             = 50
+	// maxCatalogSourceSyncChanges bounds the changes kept for the last sync; the counts on
+	// the record stay exact.
+	maxCatalogSourceSyncChanges = 1000
+	// maxCatalogSourceSyncModels keeps the snapshot of a source well within the 1MiB
+	// ConfigMap limit.
+	maxCatalogSourceSyncModels  = 5000
+	catalogSourceSyncPageSize   = 100
+	catalogModelVersionProperty = "version"
+)
+
+var (
+	ErrCatalogSourceSyncInvalidSchedule = errors.New("invalid catalog source sync schedule")
+	ErrCatalogSourceSyncSourceDisabled  = errors.New("catalog source is disabled")
+	ErrCatalogSourceSyncConflict        = errors.New("catalog source sync state was modified by another request")
+	ErrCatalogSourceSyncNotRun          = errors.New("catalog source has not been synced yet")
+)
+
+var invalidConfigMapNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// catalogSourceSyncState is the content of a source's sync ConfigMap. Snapshot is nil until
+// the first successful sync, which only records a baseline.
+type catalogSourceSyncState struct {
+	Schedule models.CatalogSourceSyncSchedule `json:"schedule"`
+	Snapshot map[string]catalogModelSnapshot  `json:"snapshot"`
+	LastSync *models.CatalogSourceSyncRecord  `json:"lastSync,omitempty"`
+	History  []models.CatalogSourceSyncRecord `json:"history,omitempty"`
+}
+
+type catalogModelSnapshot struct {
+	Version     string `json:"v,omitempty"`
+	Fingerprint string `json:"f"`
+}
+
+// ModelCatalogSourceSyncRepository re-reads the models of catalog sources, on demand or on a
+// schedule, and records what changed between two syncs.
+type ModelCatalogSourceSyncRepository struct {
+	modelCatalogClient ModelCatalogClientInterface
+	settings           *ModelCatalogSettingsRepository
+	now                func() time.Time
+}
+
+func NewModelCatalogSourceSyncRepository(modelCatalogClient ModelCatalogClientInterface, settings *ModelCatalogSettingsRepository) *ModelCatalogSourceSyncRepository {
+	return &ModelCatalogSourceSyncRepository{
+		modelCatalogClient: modelCatalogClient,
+		settings:           settings,
+		now:                time.Now,
+	}
+}
+
+// GetSyncStatus returns the schedule and last sync of a catalog source.
+func (r *ModelCatalogSourceSyncRepository) GetSyncStatus(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, sourceId string) (*models.CatalogSourceSyncStatus, error) {
+	if _, err := r.settings.GetCatalogSourceConfig(ctx, client, namespace, sourceId); err != nil {
+		return nil, err
+	}
+
+	state, _, err := r.getSyncState(ctx, client, namespace, sourceId)
+	if err != nil {
+		return nil, err
+	}
+	return r.syncStatus(sourceId, state), nil
+}
+
+// UpdateSyncSchedule replaces the sync schedule of a catalog source.
+func (r *ModelCatalogSourceSyncRepository) UpdateSyncSchedule(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, sourceId string, schedule models.CatalogSourceSyncSchedule) (*models.CatalogSourceSyncStatus, error) {
+	if err := validateCatalogSourceSyncSchedule(schedule); err != nil {
+		return nil, err
+	}
+	if _, err := r.settings.GetCatalogSourceConfig(ctx, client, namespace, sourceId); err != nil {
+		return nil, err
+	}
+
+	state, configMap, err := r.getSyncState(ctx, client, namespace, sourceId)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.TrackedModels == nil {
+		schedule.TrackedModels = []string{}
+	}
+	state.Schedule = schedule
+
+	if err := r.saveSyncState(ctx, client, namespace, sourceId, configMap, state); err != nil {
+		return nil, err
+	}
+	return r.syncStatus(sourceId, state), nil
+}
+
+// SyncCatalogSource syncs a catalog source now. A sync that cannot read the source is
+// recorded as FAILED and returned without an error.
+func (r *ModelCatalogSourceSyncRepository) SyncCatalogSource(ctx context.Context, client k8s.KubernetesClientInterface, catalogClient httpclient.HTTPClientInterface, namespace string, sourceId string) (*models.CatalogSourceSyncRecord, error) {
+	source, err := r.settings.GetCatalogSourceConfig(ctx, client, namespace, sourceId)
+	if err != nil {
+		return nil, err
+	}
+	if !catalogSourceEnabled(source) {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogSourceSyncSourceDisabled, sourceId)
+	}
+
+	state, configMap, err := r.getSyncState(ctx, client, namespace, sourceId)
+	if err != nil {
+		return nil, err
+	}
+	return r.runSync(ctx, client, catalogClient, namespace, sourceId, state, configMap, models.CatalogSourceSyncTriggerManual)
+}
+
+// RunScheduledSyncs runs the scheduled sync of every enabled catalog source in namespace
+// whose sync is due, and returns them in source id order. A source whose state changed
+// while it was being synced was synced by another run, and is left out.
+func (r *ModelCatalogSourceSyncRepository) RunScheduledSyncs(ctx context.Context, client k8s.KubernetesClientInterface, catalogClient httpclient.HTTPClientInterface, namespace string) (*models.CatalogSourceSyncRunList, error) {
+	sources, err := r.settings.GetAllCatalogSourceConfigs(ctx, client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sources.Catalogs, func(i, j int) bool { return sources.Catalogs[i].Id < sources.Catalogs[j].Id })
+
+	result := &models.CatalogSourceSyncRunList{Items: []models.CatalogSourceSyncRecord{}}
+	for i := range sources.Catalogs {
+		source := &sources.Catalogs[i]
+		if !catalogSourceEnabled(source) {
+			continue
+		}
+		state, configMap, err := r.getSyncState(ctx, client, namespace, source.Id)
+		if err != nil {
+			return nil, err
+		}
+		if !r.syncDue(state) {
+			continue
+		}
+		record, err := r.runSync(ctx, client, catalogClient, namespace, source.Id, state, configMap, models.CatalogSourceSyncTriggerScheduled)
+		switch {
+		case errors.Is(err, ErrCatalogSourceSyncConflict):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		result.Items = append(result.Items, *record)
+	}
+	result.Size = len(result.Items)
+	return result, nil
+}
+
+// GetLastSyncDiff returns the last sync of a catalog source with the models it found added,
+// removed or updated.
+func (r *ModelCatalogSourceSyncRepository) GetLastSyncDiff(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, sourceId string) (*models.CatalogSourceSyncRecord, error) {
+	if _, err := r.settings.GetCatalogSourceConfig(ctx, client, namespace, sourceId); err != nil {
+		return nil, err
+	}
+
+	state, _, err := r.getSyncState(ctx, client, namespace, sourceId)
+	if err != nil {
+		return nil, err
+	}
+	if state.LastSync == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogSourceSyncNotRun, sourceId)
+	}
+	return state.LastSync, nil
+}
+
+// GetSyncHistory returns the past syncs of a catalog source, newest first, without their
+// lists of changes.
+func (r *ModelCatalogSourceSyncRepository) GetSyncHistory(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, sourceId string) (*models.CatalogSourceSyncHistory, error) {
+	if _, err := r.settings.GetCatalogSourceConfig(ctx, client, namespace, sourceId); err != nil {
+		return nil, err
+	}
+
+	state, _, err := r.getSyncState(ctx, client, namespace, sourceId)
+	if err != nil {
+		return nil, err
+	}
+	history := &models.CatalogSourceSyncHistory{Items: []models.CatalogSourceSyncRecord{}}
+	history.Items = append(history.Items, state.History...)
+	history.Size = len(history.Items)
+	return history, nil
+}
+
+func (r *ModelCatalogSourceSyncRepository) runSync(ctx context.Context, client k8s.KubernetesClientInterface, catalogClient httpclient.HTTPClientInterface, namespace string, sourceId string, state *catalogSourceSyncState, configMap *corev1.ConfigMap, trigger models.CatalogSourceSyncTrigger) (*models.CatalogSourceSyncRecord, error) {
+	record := models.CatalogSourceSyncRecord{
+		SourceId:      sourceId,
+		Trigger:       trigger,
+		StartedAt:     r.timestamp(),
+		Notifications: []models.CatalogSourceModelNotification{},
+	}
+
+	catalogModels, err := r.fetchSourceModels(catalogClient, sourceId)
+	if err != nil {
+		helper.GetContextLogger(ctx).Warn("catalog source sync failed", "sourceId", sourceId, "error", err)
+		record.Result = models.CatalogSourceSyncResultFailed
+		record.Error = err.Error()
+	} else {
+		snapshot := make(map[string]catalogModelSnapshot, len(catalogModels))
+		for _, model := range catalogModels {
+			snapshot[model.Name] = newCatalogModelSnapshot(model)
+		}
+		if state.Snapshot != nil {
+			diffCatalogSourceSnapshots(&record, state.Snapshot, snapshot, state.Schedule.TrackedModels)
+		}
+		state.Snapshot = snapshot
+		record.Result = models.CatalogSourceSyncResultSucceeded
+		record.ModelCount = len(snapshot)
+	}
+	record.CompletedAt = r.timestamp()
+
+	summary := record
+	summary.Changes = nil
+	state.LastSync = &record
+	state.History = append([]models.CatalogSourceSyncRecord{summary}, state.History...)
+	if len(state.History) > maxCatalogSourceSyncHistory {
+		state.History = state.History[:maxCatalogSourceSyncHistory]
+	}
+
+	if err := r.saveSyncState(ctx, client, namespace, sourceId, configMap, state); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// fetchSourceModels reads every model of a catalog source, page by page.
+func (r *ModelCatalogSourceSyncRepository) fetchSourceModels(catalogClient httpclient.HTTPClientInterface, sourceId string) ([]models.CatalogModel, error) {
+	var result []models.CatalogModel
+	pageToken := ""
+	for {
+		pageValues := url.Values{
+			"source":   {sourceId},
+			"pageSize": {strconv.Itoa(catalogSourceSyncPageSize)},
+		}
+		if pageToken != "" {
+			pageValues.Set("nextPageToken", pageToken)
+		}
+		page, err := r.modelCatalogClient.GetAllCatalogModelsAcrossSources(catalogClient, pageValues)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list models of catalog source: %w", err)
+		}
+		result = append(result, page.Items...)
+		if len(result) > maxCatalogSourceSyncModels {
+			return nil, fmt.Errorf("catalog source has more than %d models", maxCatalogSourceSyncModels)
+		}
+		if page.NextPageToken == "" || page.NextPageToken == pageToken || len(page.Items) == 0 {
+			return result, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (r *ModelCatalogSourceSyncRepository) syncDue(state *catalogSourceSyncState) bool {
+	if !state.Schedule.Enabled {
+		return false
+	}
+	if state.LastSync == nil {
+		return true
+	}
+	next, ok := nextCatalogSourceSync(state)
+	return ok && !r.now().Before(next)
+}
+
+func (r *ModelCatalogSourceSyncRepository) syncStatus(sourceId string, state *catalogSourceSyncState) *models.CatalogSourceSyncStatus {
+	status := &models.CatalogSourceSyncStatus{
+		SourceId: sourceId,
+		Schedule: state.Schedule,
+		LastSync: state.LastSync,
+	}
+	if state.Schedule.Enabled {
+		if next, ok := nextCatalogSourceSync(state); ok {
+			status.NextSyncAt = next.UTC().Format(time.RFC3339)
+		}
+	}
+	return status
+}
+
+func (r *ModelCatalogSourceSyncRepository) getSyncState(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, sourceId string) (*catalogSourceSyncState, *corev1.ConfigMap, error) {
+	state := &catalogSourceSyncState{
+		Schedule: models.CatalogSourceSyncSchedule{
+			IntervalMinutes: defaultCatalogSourceSyncIntervalMinutes,
+			TrackedModels:   []string{},
+		},
+	}
+
+	configMap, err := client.GetConfigMap(ctx, namespace, CatalogSourceSyncConfigMapName(sourceId))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return state, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to fetch catalog source sync state: %w", err)
+	}
+	if configMap == nil {
+		return state, nil, nil
+	}
+
+	if raw := configMap.Data[catalogSourceSyncStateKey]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), state); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse catalog source sync state: %w", err)
+		}
+	}
+	return state, configMap, nil
+}
+
+// saveSyncState creates the sync ConfigMap of a source, or updates the one that was read,
+// failing with ErrCatalogSourceSyncConflict when it changed in the meantime.
+func (r *ModelCatalogSourceSyncRepository) saveSyncState(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, sourceId string, configMap *corev1.ConfigMap, state *catalogSourceSyncState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode catalog source sync state: %w", err)
+	}
+
+	if configMap == nil {
+		newConfigMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      CatalogSourceSyncConfigMapName(sourceId),
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/component": k8s.ComponentLabelValue,
+					catalogSourceSyncLabel:        "true",
+				},
+				Annotations: map[string]string{catalogSourceSyncSourceAnnotation: sourceId},
+			},
+			Data: map[string]string{catalogSourceSyncStateKey: string(data)},
+		}
+		if _, err := client.CreateConfigMap(ctx, namespace, newConfigMap); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return ErrCatalogSourceSyncConflict
+			}
+			return fmt.Errorf("failed to store catalog source sync state: %w", err)
+		}
+		return nil
+	}
+
+	updated := configMap.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = map[string]string{}
+	}
+	updated.Data[catalogSourceSyncStateKey] = string(data)
+	if _, err := client.UpdateConfigMap(ctx, namespace, updated); err != nil {
+		if apierrors.IsConflict(err) {
+			return ErrCatalogSourceSyncConflict
+		}
+		return fmt.Errorf("failed to store catalog source sync state: %w", err)
+	}
+	return nil
+}
+
+func (r *ModelCatalogSourceSyncRepository) timestamp() string {
+	return r.now().UTC().Format(time.RFC3339)
+}
+
+// CatalogSourceSyncConfigMapName returns the name of the ConfigMap holding the sync state of
+// a catalog source. Source ids may contain characters ConfigMap names cannot, so the name
+// ends with a hash of the id to keep it unique.
+func CatalogSourceSyncConfigMapName(sourceId string) string {
+	name := strings.Trim(invalidConfigMapNameChars.ReplaceAllString(strings.ToLower(sourceId), "-"), "-")
+	if len(name) > 40 {
+		name = strings.TrimRight(name[:40], "-")
+	}
+	sum := sha256.Sum256([]byte(sourceId))
+	if name == "" {
+		return catalogSourceSyncConfigMapPrefix + hex.EncodeToString(sum[:4])
+	}
+	return catalogSourceSyncConfigMapPrefix + name + "-" + hex.EncodeToString(sum[:4])
+}
+
+func nextCatalogSourceSync(state *catalogSourceSyncState) (time.Time, bool) {
+	if state.LastSync == nil {
+		return time.Time{}, false
+	}
+	last, err := time.Parse(time.RFC3339, state.LastSync.StartedAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return last.Add(time.Duration(state.Schedule.IntervalMinutes) * time.Minute), true
+}
+
+// diffCatalogSourceSnapshots records on the sync record the models added, removed and
+// updated between two snapshots, and a notification for each tracked model whose version
+// changed.
+func diffCatalogSourceSnapshots(record *models.CatalogSourceSyncRecord, previous, current map[string]catalogModelSnapshot, trackedModels []string) {
+	var changes []models.CatalogSourceModelChange
+	for name, model := range current {
+		old, ok := previous[name]
+		switch {
+		case !ok:
+			record.Added++
+			changes = append(changes, models.CatalogSourceModelChange{Name: name, ChangeType: models.CatalogSourceModelChangeAdded, Version: model.Version})
+		case old.Fingerprint != model.Fingerprint:
+			record.Updated++
+			changes = append(changes, models.CatalogSourceModelChange{Name: name, ChangeType: models.CatalogSourceModelChangeUpdated, PreviousVersion: old.Version, Version: model.Version})
+			if model.Version != "" && model.Version != old.Version && slices.Contains(trackedModels, name) {
+				record.Notifications = append(record.Notifications, models.CatalogSourceModelNotification{
+					ModelName:       name,
+					PreviousVersion: old.Version,
+					Version:         model.Version,
+					Message:         fmt.Sprintf("%s has a new version %s", name, model.Version),
+				})
+			}
+		}
+	}
+	for name, old := range previous {
+		if _, ok := current[name]; !ok {
+			record.Removed++
+			changes = append(changes, models.CatalogSourceModelChange{Name: name, ChangeType: models.CatalogSourceModelChangeRemoved, PreviousVersion: old.Version})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	sort.Slice(record.Notifications, func(i, j int) bool { return record.Notifications[i].ModelName < record.Notifications[j].ModelName })
+	if len(changes) > maxCatalogSourceSyncChanges {
+		changes = changes[:maxCatalogSourceSyncChanges]
+	}
+	record.Changes = changes
+}
+
+// newCatalogModelSnapshot records the version of a catalog model, its "version" custom
+// property or else its last update time, and a fingerprint of all its fields.
+func newCatalogModelSnapshot(model models.CatalogModel) catalogModelSnapshot {
+	snapshot := catalogModelSnapshot{}
+	if model.CustomProperties != nil {
+		if value, ok := (*model.CustomProperties)[catalogModelVersionProperty]; ok && value.MetadataStringValue != nil {
+			snapshot.Version = value.MetadataStringValue.StringValue
+		}
+	}
+	if snapshot.Version == "" && model.LastUpdateTimeSinceEpoch != nil {
+		snapshot.Version = *model.LastUpdateTimeSinceEpoch
+	}
+
+	data, err := json.Marshal(model)
+	if err != nil {
+		data = []byte(model.Name)
+	}
+	sum := sha256.Sum256(data)
+	snapshot.Fingerprint = hex.EncodeToString(sum[:8])
+	return snapshot
+}
+
+func validateCatalogSourceSyncSchedule(schedule models.CatalogSourceSyncSchedule) error {
+	if schedule.IntervalMinutes < minCatalogSourceSyncIntervalMinutes || schedule.IntervalMinutes > maxCatalogSourceSyncIntervalMinutes {
+		return fmt.Errorf("%w: intervalMinutes must be between %d and %d", ErrCatalogSourceSyncInvalidSchedule, minCatalogSourceSyncIntervalMinutes, maxCatalogSourceSyncIntervalMinutes)
+	}
+	if len(schedule.TrackedModels) > maxCatalogSourceSyncTrackedModels {
+		return fmt.Errorf("%w: at most %d tracked models are allowed", ErrCatalogSourceSyncInvalidSchedule, maxCatalogSourceSyncTrackedModels)
+	}
+	for _, name := range schedule.TrackedModels {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: tracked model names must not be empty", ErrCatalogSourceSyncInvalidSchedule)
+		}
+	}
+	return nil
+}
+
+func catalogSourceEnabled(source *models.CatalogSourceConfig) bool {
+	return source.Enabled == nil || *source.Enabled
+}
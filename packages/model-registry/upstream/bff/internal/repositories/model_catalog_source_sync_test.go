@@ -0,0 +1,242 @@
+package repositories
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/hub/pkg/openapi"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/mocks"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const syncTestSources = `
+catalogs:
+  - name: Team models
+    id: team_models
+    type: yaml
+    enabled: true
+    properties:
+      yamlCatalogPath: team_models.yaml
+  - name: Disabled models
+    id: disabled_models
+    type: yaml
+    enabled: false
+    properties:
+      yamlCatalogPath: disabled_models.yaml
+`
+
+// fakeSourceCatalogClient serves the models of one catalog source, two per page.
+type fakeSourceCatalogClient struct {
+	ModelCatalogClientInterface
+	models []models.CatalogModel
+	err    error
+}
+
+func (f *fakeSourceCatalogClient) GetAllCatalogModelsAcrossSources(client httpclient.HTTPClientInterface, pageValues url.Values) (*models.CatalogModelList, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	start, _ := strconv.Atoi(pageValues.Get("nextPageToken"))
+	end := min(start+2, len(f.models))
+	page := &models.CatalogModelList{Items: f.models[start:end], Size: int32(end - start)}
+	if end < len(f.models) {
+		page.NextPageToken = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func syncTestModel(name string, version string) models.CatalogModel {
+	return models.CatalogModel{
+		Name: name,
+		CustomProperties: &map[string]openapi.MetadataValue{
+			"version": {MetadataStringValue: &openapi.MetadataStringValue{StringValue: version, MetadataType: "MetadataStringValue"}},
+		},
+	}
+}
+
+func newSyncTestRepository(catalog *fakeSourceCatalogClient, now *time.Time) *ModelCatalogSourceSyncRepository {
+	repo := NewModelCatalogSourceSyncRepository(catalog, NewModelCatalogSettingsRepository())
+	repo.now = func() time.Time { return *now }
+	return repo
+}
+
+func newSyncTestKubernetesClient() *fakeKubernetesClient {
+	return &fakeKubernetesClient{
+		configMapsByNamespace: map[string]map[string]*corev1.ConfigMap{},
+		catalogSources:        corev1.ConfigMap{Data: map[string]string{k8s.CatalogSourceKey: syncTestSources}},
+	}
+}
+
+func TestSyncCatalogSource_ReportsChangesAfterBaseline(t *testing.T) {
+	ctx := mocks.NewMockSessionContextNoParent()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	catalog := &fakeSourceCatalogClient{models: []models.CatalogModel{
+		syncTestModel("granite", "1.0"),
+		syncTestModel("llama", "3.0"),
+		syncTestModel("mistral", "0.1"),
+	}}
+	repo := newSyncTestRepository(catalog, &now)
+	k8sClient := newSyncTestKubernetesClient()
+
+	_, err := repo.UpdateSyncSchedule(ctx, k8sClient, "kubeflow", "team_models", models.CatalogSourceSyncSchedule{
+		Enabled: true, IntervalMinutes: 60, TrackedModels: []string{"granite", "mistral"},
+	})
+	require.NoError(t, err)
+
+	baseline, err := repo.SyncCatalogSource(ctx, k8sClient, new(mocks.MockHTTPClient), "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.Equal(t, models.CatalogSourceSyncResultSucceeded, baseline.Result)
+	assert.Equal(t, 3, baseline.ModelCount)
+	assert.Empty(t, baseline.Changes)
+
+	catalog.models = []models.CatalogModel{
+		syncTestModel("granite", "1.1"),
+		syncTestModel("llama", "3.0"),
+		syncTestModel("phi", "4.0"),
+	}
+	record, err := repo.SyncCatalogSource(ctx, k8sClient, new(mocks.MockHTTPClient), "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.Equal(t, 1, record.Added)
+	assert.Equal(t, 1, record.Removed)
+	assert.Equal(t, 1, record.Updated)
+	assert.Equal(t, []models.CatalogSourceModelChange{
+		{Name: "granite", ChangeType: models.CatalogSourceModelChangeUpdated, PreviousVersion: "1.0", Version: "1.1"},
+		{Name: "mistral", ChangeType: models.CatalogSourceModelChangeRemoved, PreviousVersion: "0.1"},
+		{Name: "phi", ChangeType: models.CatalogSourceModelChangeAdded, Version: "4.0"},
+	}, record.Changes)
+	require.Len(t, record.Notifications, 1)
+	assert.Equal(t, "granite", record.Notifications[0].ModelName)
+	assert.Equal(t, "1.1", record.Notifications[0].Version)
+
+	diff, err := repo.GetLastSyncDiff(ctx, k8sClient, "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.Equal(t, record.Changes, diff.Changes)
+
+	history, err := repo.GetSyncHistory(ctx, k8sClient, "kubeflow", "team_models")
+	require.NoError(t, err)
+	require.Equal(t, 2, history.Size)
+	assert.Equal(t, 1, history.Items[0].Added)
+	assert.Nil(t, history.Items[0].Changes)
+}
+
+func TestGetSyncStatus_DoesNotSync(t *testing.T) {
+	ctx := mocks.NewMockSessionContextNoParent()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	catalog := &fakeSourceCatalogClient{models: []models.CatalogModel{syncTestModel("granite", "1.0")}}
+	repo := newSyncTestRepository(catalog, &now)
+	k8sClient := newSyncTestKubernetesClient()
+
+	status, err := repo.GetSyncStatus(ctx, k8sClient, "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.False(t, status.Schedule.Enabled)
+	assert.Nil(t, status.LastSync)
+
+	_, err = repo.UpdateSyncSchedule(ctx, k8sClient, "kubeflow", "team_models", models.CatalogSourceSyncSchedule{Enabled: true, IntervalMinutes: 60})
+	require.NoError(t, err)
+
+	status, err = repo.GetSyncStatus(ctx, k8sClient, "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.Nil(t, status.LastSync)
+	assert.Empty(t, status.NextSyncAt)
+
+	_, err = repo.GetSyncStatus(ctx, k8sClient, "kubeflow", "missing")
+	assert.True(t, errors.Is(err, ErrCatalogSourceNotFound))
+}
+
+func TestRunScheduledSyncs_RunsDueSyncs(t *testing.T) {
+	ctx := mocks.NewMockSessionContextNoParent()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	catalog := &fakeSourceCatalogClient{models: []models.CatalogModel{syncTestModel("granite", "1.0")}}
+	repo := newSyncTestRepository(catalog, &now)
+	k8sClient := newSyncTestKubernetesClient()
+	catalogClient := new(mocks.MockHTTPClient)
+
+	// No schedule is enabled yet.
+	runs, err := repo.RunScheduledSyncs(ctx, k8sClient, catalogClient, "kubeflow")
+	require.NoError(t, err)
+	assert.Equal(t, 0, runs.Size)
+
+	_, err = repo.UpdateSyncSchedule(ctx, k8sClient, "kubeflow", "team_models", models.CatalogSourceSyncSchedule{Enabled: true, IntervalMinutes: 60})
+	require.NoError(t, err)
+	// Disabled sources are skipped even with a schedule.
+	_, err = repo.UpdateSyncSchedule(ctx, k8sClient, "kubeflow", "disabled_models", models.CatalogSourceSyncSchedule{Enabled: true, IntervalMinutes: 60})
+	require.NoError(t, err)
+
+	runs, err = repo.RunScheduledSyncs(ctx, k8sClient, catalogClient, "kubeflow")
+	require.NoError(t, err)
+	require.Equal(t, 1, runs.Size)
+	assert.Equal(t, "team_models", runs.Items[0].SourceId)
+	assert.Equal(t, models.CatalogSourceSyncTriggerScheduled, runs.Items[0].Trigger)
+
+	status, err := repo.GetSyncStatus(ctx, k8sClient, "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-01T13:00:00Z", status.NextSyncAt)
+
+	// Not due yet.
+	now = now.Add(30 * time.Minute)
+	runs, err = repo.RunScheduledSyncs(ctx, k8sClient, catalogClient, "kubeflow")
+	require.NoError(t, err)
+	assert.Equal(t, 0, runs.Size)
+
+	now = now.Add(30 * time.Minute)
+	runs, err = repo.RunScheduledSyncs(ctx, k8sClient, catalogClient, "kubeflow")
+	require.NoError(t, err)
+	assert.Equal(t, 1, runs.Size)
+	history, err := repo.GetSyncHistory(ctx, k8sClient, "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.Equal(t, 2, history.Size)
+}
+
+func TestSyncCatalogSource_FailureKeepsSnapshot(t *testing.T) {
+	ctx := mocks.NewMockSessionContextNoParent()
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	catalog := &fakeSourceCatalogClient{models: []models.CatalogModel{syncTestModel("granite", "1.0")}}
+	repo := newSyncTestRepository(catalog, &now)
+	k8sClient := newSyncTestKubernetesClient()
+
+	_, err := repo.SyncCatalogSource(ctx, k8sClient, new(mocks.MockHTTPClient), "kubeflow", "team_models")
+	require.NoError(t, err)
+
+	catalog.err = errors.New("catalog unavailable")
+	record, err := repo.SyncCatalogSource(ctx, k8sClient, new(mocks.MockHTTPClient), "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.Equal(t, models.CatalogSourceSyncResultFailed, record.Result)
+	assert.Contains(t, record.Error, "catalog unavailable")
+
+	catalog.err = nil
+	record, err = repo.SyncCatalogSource(ctx, k8sClient, new(mocks.MockHTTPClient), "kubeflow", "team_models")
+	require.NoError(t, err)
+	assert.Empty(t, record.Changes)
+}
+
+func TestSyncCatalogSource_Errors(t *testing.T) {
+	ctx := mocks.NewMockSessionContextNoParent()
+	now := time.Now()
+	repo := newSyncTestRepository(&fakeSourceCatalogClient{}, &now)
+	k8sClient := newSyncTestKubernetesClient()
+
+	_, err := repo.SyncCatalogSource(ctx, k8sClient, new(mocks.MockHTTPClient), "kubeflow", "disabled_models")
+	assert.True(t, errors.Is(err, ErrCatalogSourceSyncSourceDisabled))
+
+	_, err = repo.SyncCatalogSource(ctx, k8sClient, new(mocks.MockHTTPClient), "kubeflow", "missing")
+	assert.True(t, errors.Is(err, ErrCatalogSourceNotFound))
+
+	_, err = repo.GetLastSyncDiff(ctx, k8sClient, "kubeflow", "team_models")
+	assert.True(t, errors.Is(err, ErrCatalogSourceSyncNotRun))
+
+	_, err = repo.UpdateSyncSchedule(ctx, k8sClient, "kubeflow", "team_models", models.CatalogSourceSyncSchedule{Enabled: true, IntervalMinutes: 1})
+	assert.True(t, errors.Is(err, ErrCatalogSourceSyncInvalidSchedule))
+}
+
+func TestCatalogSourceSyncConfigMapName(t *testing.T) {
+	assert.Regexp(t, `^model-catalog-sync-team-models-[0-9a-f]{8}$`, CatalogSourceSyncConfigMapName("team_models"))
+	assert.NotEqual(t, CatalogSourceSyncConfigMapName("team_models"), CatalogSourceSyncConfigMapName("team-models"))
+}
@@ -0,0 +1,283 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubeflow/hub/pkg/openapi"
+	"github.com/kubeflow/hub/ui/bff/internal/constants"
+	helper "github.com/kubeflow/hub/ui/bff/internal/helpers"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+)
+
+const (
+	// datasetArtifactType is the artifactType the Model Registry API gives DataSet artifacts
+	datasetArtifactType = "dataset-artifact"
+	// pipelineRunModelSourceKind is the model_source_kind of artifacts registered from a pipeline run
+	pipelineRunModelSourceKind = "kfp"
+	// transferJobModelSourceKind is the model_source_kind of artifacts registered by a transfer job
+	transferJobModelSourceKind = "transfer_job"
+)
+
+var ErrModelVersionNotFound = errors.New("model version not found")
+
+// ModelLineageRepository builds lineage graphs from the Model Registry API and the
+// Kubernetes Jobs that transferred models into it.
+type ModelLineageRepository struct {
+	modelRegistryClient ModelRegistryClientInterface
+	modelRegistry       *ModelRegistryRepository
+}
+
+func NewModelLineageRepository(modelRegistryClient ModelRegistryClientInterface, modelRegistry *ModelRegistryRepository) *ModelLineageRepository {
+	return &ModelLineageRepository{
+		modelRegistryClient: modelRegistryClient,
+		modelRegistry:       modelRegistry,
+	}
+}
+
+// GetModelVersionLineage builds the lineage graph of a model version: its registered model,
+// its artifacts, the datasets and pipeline runs that produced them and the transfer jobs
+// that imported them. Only a missing or unreadable model version fails the request; any
+// other part of the graph that cannot be read is reported in Warnings.
+//
+// Transfer jobs are looked up in jobNamespace, if set, and in the namespaces recorded on
+// artifacts registered by a transfer job.
+func (r *ModelLineageRepository) GetModelVersionLineage(ctx context.Context, restClient httpclient.HTTPClientInterface, k8sClient k8s.KubernetesClientInterface, namespace string, modelRegistryID string, jobNamespace string, modelVersionID string) (*models.ModelLineage, error) {
+	logger := helper.GetContextLogger(ctx)
+
+	version, err := r.modelRegistryClient.GetModelVersion(restClient, modelVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch model version: %w", err)
+	}
+	if _, ok := version.GetIdOk(); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrModelVersionNotFound, modelVersionID)
+	}
+
+	versionNodeID := models.ModelLineageNodeId(models.ModelLineageNodeTypeModelVersion, version.GetId())
+	lineage := models.NewModelLineage(versionNodeID)
+	lineage.AddNode(models.ModelLineageNode{
+		Id:     versionNodeID,
+		Type:   models.ModelLineageNodeTypeModelVersion,
+		Name:   version.GetName(),
+		Status: string(version.GetState()),
+	})
+
+	if registeredModelID := version.GetRegisteredModelId(); registeredModelID != "" {
+		registeredModelNode := models.ModelLineageNode{
+			Id:   models.ModelLineageNodeId(models.ModelLineageNodeTypeRegisteredModel, registeredModelID),
+			Type: models.ModelLineageNodeTypeRegisteredModel,
+			Name: registeredModelID,
+		}
+		registeredModel, err := r.modelRegistryClient.GetRegisteredModel(restClient, registeredModelID)
+		if err != nil {
+			logger.Warn("failed to fetch registered model for lineage", "registeredModelId", registeredModelID, "error", err)
+		} else {
+			registeredModelNode.Name = registeredModel.GetName()
+			registeredModelNode.Status = string(registeredModel.GetState())
+		}
+		lineage.AddNode(registeredModelNode)
+		lineage.AddEdge(registeredModelNode.Id, versionNodeID, models.ModelLineageRelationHasVersion)
+	}
+
+	artifacts, err := r.modelRegistryClient.GetModelArtifactsByModelVersion(restClient, modelVersionID, nil)
+	if err != nil {
+		logger.Warn("failed to fetch artifacts for lineage", "modelVersionId", modelVersionID, "error", err)
+		lineage.AddWarning("unable to read the artifacts of the model version")
+		artifacts = &openapi.ModelArtifactList{}
+	}
+
+	transferJobArtifacts := addArtifactLineage(lineage, versionNodeID, artifacts.GetItems())
+
+	if k8sClient != nil && modelRegistryID != "" {
+		r.addTransferJobLineage(ctx, lineage, k8sClient, namespace, modelRegistryID, jobNamespace, modelVersionID, transferJobArtifacts)
+	}
+
+	return lineage, nil
+}
+
+// addArtifactLineage adds the artifacts of a model version and the datasets and pipeline runs
+// they came from. It returns the ids of the artifact nodes registered by a transfer job,
+// keyed by the job's "<namespace>/<name>", for addTransferJobLineage to link.
+func addArtifactLineage(lineage *models.ModelLineage, versionNodeID string, artifacts []openapi.ModelArtifact) map[string]string {
+	var datasetNodeIDs []string
+	var pipelineRunNodeIDs []string
+	transferJobArtifacts := make(map[string]string)
+
+	for _, artifact := range artifacts {
+		if artifact.GetArtifactType() == datasetArtifactType {
+			node := models.ModelLineageNode{
+				Id:   models.ModelLineageNodeId(models.ModelLineageNodeTypeDataset, artifact.GetId()),
+				Type: models.ModelLineageNodeTypeDataset,
+				Name: artifact.GetName(),
+				Uri:  artifact.GetUri(),
+			}
+			lineage.AddNode(node)
+			datasetNodeIDs = append(datasetNodeIDs, node.Id)
+			continue
+		}
+
+		artifactNode := models.ModelLineageNode{
+			Id:     models.ModelLineageNodeId(models.ModelLineageNodeTypeModelArtifact, artifact.GetId()),
+			Type:   models.ModelLineageNodeTypeModelArtifact,
+			Name:   artifact.GetName(),
+			Status: string(artifact.GetState()),
+			Uri:    artifact.GetUri(),
+		}
+		if format := artifact.GetModelFormatName(); format != "" {
+			artifactNode.Properties = map[string]string{"modelFormatName": format}
+		}
+		lineage.AddNode(artifactNode)
+		lineage.AddEdge(artifactNode.Id, versionNodeID, models.ModelLineageRelationArtifactOf)
+
+		switch artifact.GetModelSourceKind() {
+		case pipelineRunModelSourceKind:
+			runKey := artifact.GetModelSourceId()
+			if runKey == "" {
+				runKey = artifact.GetModelSourceName()
+			}
+			if runKey == "" {
+				continue
+			}
+			runName := artifact.GetModelSourceName()
+			if runName == "" {
+				runName = runKey
+			}
+			runNode := models.ModelLineageNode{
+				Id:        models.ModelLineageNodeId(models.ModelLineageNodeTypePipelineRun, runKey),
+				Type:      models.ModelLineageNodeTypePipelineRun,
+				Name:      runName,
+				Namespace: artifact.GetModelSourceGroup(),
+			}
+			if class := artifact.GetModelSourceClass(); class != "" {
+				runNode.Properties = map[string]string{"class": class}
+			}
+			lineage.AddNode(runNode)
+			lineage.AddEdge(runNode.Id, artifactNode.Id, models.ModelLineageRelationProduced)
+			pipelineRunNodeIDs = append(pipelineRunNodeIDs, runNode.Id)
+		case transferJobModelSourceKind:
+			if artifact.GetModelSourceName() == "" {
+				continue
+			}
+			transferJobArtifacts[artifact.GetModelSourceGroup()+"/"+artifact.GetModelSourceName()] = artifactNode.Id
+		}
+	}
+
+	// Model Registry does not record which run consumed a dataset, so datasets registered
+	// on a version are linked to every pipeline run that produced it, or to the version
+	// itself when none did.
+	for _, datasetNodeID := range datasetNodeIDs {
+		if len(pipelineRunNodeIDs) == 0 {
+			lineage.AddEdge(datasetNodeID, versionNodeID, models.ModelLineageRelationInputTo)
+			continue
+		}
+		for _, runNodeID := range pipelineRunNodeIDs {
+			lineage.AddEdge(datasetNodeID, runNodeID, models.ModelLineageRelationInputTo)
+		}
+	}
+
+	return transferJobArtifacts
+}
+
+// addTransferJobLineage adds the transfer jobs that imported the model version, either by
+// targeting it or by producing one of its artifacts. Jobs referenced by an artifact but not
+// readable from the cluster, because they were deleted or the user cannot list jobs in their
+// namespace, are still added from the artifact, without a status.
+func (r *ModelLineageRepository) addTransferJobLineage(ctx context.Context, lineage *models.ModelLineage, k8sClient k8s.KubernetesClientInterface, namespace string, modelRegistryID string, jobNamespace string, modelVersionID string, transferJobArtifacts map[string]string) {
+	logger := helper.GetContextLogger(ctx)
+
+	jobNamespaces := make(map[string]struct{})
+	if jobNamespace != "" {
+		jobNamespaces[jobNamespace] = struct{}{}
+	}
+	for key := range transferJobArtifacts {
+		ns, _ := splitNamespacedName(key)
+		if ns != "" {
+			jobNamespaces[ns] = struct{}{}
+		}
+	}
+
+	found := make(map[string]bool)
+	identity, _ := ctx.Value(constants.RequestIdentityKey).(*k8s.RequestIdentity)
+	for _, ns := range sortedKeys(jobNamespaces) {
+		// Jobs run in the user's project rather than the registry namespace, so access to
+		// each namespace is checked the same way the transfer job handlers check it.
+		if identity == nil {
+			lineage.AddWarning(fmt.Sprintf("unable to list model transfer jobs in namespace %q", ns))
+			continue
+		}
+		allowed, err := k8sClient.CanListServicesInNamespace(ctx, identity, ns)
+		if err != nil || !allowed {
+			logger.Debug("skipping transfer jobs for lineage", "jobNamespace", ns, "allowed", allowed, "error", err)
+			lineage.AddWarning(fmt.Sprintf("no access to model transfer jobs in namespace %q", ns))
+			continue
+		}
+
+		jobs, err := r.modelRegistry.GetAllModelTransferJobs(ctx, k8sClient, namespace, modelRegistryID, ns)
+		if err != nil {
+			logger.Warn("failed to list transfer jobs for lineage", "jobNamespace", ns, "error", err)
+			lineage.AddWarning(fmt.Sprintf("unable to list model transfer jobs in namespace %q", ns))
+			continue
+		}
+		for _, job := range jobs.Items {
+			key := job.Namespace + "/" + job.Name
+			artifactNodeID, fromArtifact := transferJobArtifacts[key]
+			if !fromArtifact && job.ModelVersionId != modelVersionID {
+				continue
+			}
+			node := transferJobLineageNode(job.Name, job.Namespace)
+			node.Status = string(job.Status)
+			node.Properties = map[string]string{"uploadIntent": string(job.UploadIntent)}
+			if job.Destination.URI != "" {
+				node.Uri = job.Destination.URI
+			}
+			lineage.AddNode(node)
+			if fromArtifact {
+				lineage.AddEdge(node.Id, artifactNodeID, models.ModelLineageRelationProduced)
+			} else {
+				lineage.AddEdge(node.Id, lineage.RootNodeId, models.ModelLineageRelationProduced)
+			}
+			found[key] = true
+		}
+	}
+
+	for _, key := range sortedKeys(transferJobArtifacts) {
+		if found[key] {
+			continue
+		}
+		ns, name := splitNamespacedName(key)
+		node := transferJobLineageNode(name, ns)
+		lineage.AddNode(node)
+		lineage.AddEdge(node.Id, transferJobArtifacts[key], models.ModelLineageRelationProduced)
+	}
+}
+
+func transferJobLineageNode(name string, namespace string) models.ModelLineageNode {
+	return models.ModelLineageNode{
+		Id:        models.ModelLineageNodeId(models.ModelLineageNodeTypeTransferJob, namespace+"/"+name),
+		Type:      models.ModelLineageNodeTypeTransferJob,
+		Name:      name,
+		Namespace: namespace,
+	}
+}
+
+func splitNamespacedName(key string) (string, string) {
+	namespace, name, found := strings.Cut(key, "/")
+	if !found {
+		return "", key
+	}
+	return namespace, name
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
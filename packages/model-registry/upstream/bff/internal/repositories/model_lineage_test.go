@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/kubeflow/hub/pkg/openapi"
+	"github.com/kubeflow/hub/ui/bff/internal/mocks"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func mockLineageRESTClient(t *testing.T, version openapi.ModelVersion, artifacts []openapi.ModelArtifact) *mocks.MockHTTPClient {
+	t.Helper()
+
+	versionData, err := json.Marshal(version)
+	require.NoError(t, err)
+	artifactData, err := json.Marshal(openapi.ModelArtifactList{Items: artifacts, Size: int32(len(artifacts))})
+	require.NoError(t, err)
+	registeredModelData, err := json.Marshal(openapi.RegisteredModel{Id: stringPtr("1"), Name: "granite"})
+	require.NoError(t, err)
+
+	versionPath, err := url.JoinPath(modelVersionPath, version.GetId())
+	require.NoError(t, err)
+	artifactsPath, err := url.JoinPath(modelVersionPath, version.GetId(), artifactsByModelVersionPath)
+	require.NoError(t, err)
+	registeredModelPathForID, err := url.JoinPath(registeredModelPath, "1")
+	require.NoError(t, err)
+
+	client := new(mocks.MockHTTPClient)
+	client.On("GET", versionPath).Return(versionData, nil)
+	client.On("GET", artifactsPath).Return(artifactData, nil)
+	client.On("GET", registeredModelPathForID).Return(registeredModelData, nil)
+	return client
+}
+
+func lineageEdge(source, target string, relation models.ModelLineageRelation) models.ModelLineageEdge {
+	return models.ModelLineageEdge{Source: source, Target: target, Relation: relation}
+}
+
+func TestGetModelVersionLineage_PipelineRunAndDataset(t *testing.T) {
+	version := openapi.ModelVersion{Id: stringPtr("7"), Name: "v1", RegisteredModelId: "1"}
+	artifacts := []openapi.ModelArtifact{
+		{
+			Id:               stringPtr("20"),
+			Name:             stringPtr("model"),
+			ArtifactType:     stringPtr("model-artifact"),
+			Uri:              stringPtr("s3://models/granite"),
+			ModelSourceKind:  stringPtr("kfp"),
+			ModelSourceClass: stringPtr("pipelinerun"),
+			ModelSourceGroup: stringPtr("team-a"),
+			ModelSourceId:    stringPtr("run-123"),
+			ModelSourceName:  stringPtr("train-granite"),
+		},
+		{
+			Id:           stringPtr("21"),
+			Name:         stringPtr("training-data"),
+			ArtifactType: stringPtr("dataset-artifact"),
+			Uri:          stringPtr("s3://datasets/train"),
+		},
+	}
+
+	repo := NewModelLineageRepository(&ModelRegistryClient{}, NewModelRegistryRepository())
+	lineage, err := repo.GetModelVersionLineage(testContext(), mockLineageRESTClient(t, version, artifacts), &fakeKubernetesClient{}, "kubeflow", "model-registry", "", "7")
+	require.NoError(t, err)
+
+	assert.Equal(t, "model_version:7", lineage.RootNodeId)
+	assert.Len(t, lineage.Nodes, 5)
+	assert.Empty(t, lineage.Warnings)
+	assert.ElementsMatch(t, []models.ModelLineageEdge{
+		lineageEdge("registered_model:1", "model_version:7", models.ModelLineageRelationHasVersion),
+		lineageEdge("model_artifact:20", "model_version:7", models.ModelLineageRelationArtifactOf),
+		lineageEdge("pipeline_run:run-123", "model_artifact:20", models.ModelLineageRelationProduced),
+		lineageEdge("dataset:21", "pipeline_run:run-123", models.ModelLineageRelationInputTo),
+	}, lineage.Edges)
+
+	for _, node := range lineage.Nodes {
+		switch node.Id {
+		case "registered_model:1":
+			assert.Equal(t, "granite", node.Name)
+		case "pipeline_run:run-123":
+			assert.Equal(t, "train-granite", node.Name)
+			assert.Equal(t, "team-a", node.Namespace)
+		}
+	}
+}
+
+func TestGetModelVersionLineage_TransferJobs(t *testing.T) {
+	version := openapi.ModelVersion{Id: stringPtr("7"), Name: "v1"}
+	artifacts := []openapi.ModelArtifact{
+		{
+			Id:               stringPtr("20"),
+			Name:             stringPtr("model"),
+			ModelSourceKind:  stringPtr("transfer_job"),
+			ModelSourceGroup: stringPtr("team-a"),
+			ModelSourceName:  stringPtr("import-granite"),
+		},
+	}
+	newJob := func(name, versionID string) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "team-a",
+				Annotations: map[string]string{"modelregistry.kubeflow.org/model-version-id": versionID},
+			},
+			Status: batchv1.JobStatus{Succeeded: 1},
+		}
+	}
+	k8sClient := &fakeKubernetesClient{
+		jobs: &batchv1.JobList{Items: []batchv1.Job{
+			newJob("import-granite", ""),
+			newJob("update-granite", "7"),
+			newJob("other-model", "8"),
+		}},
+		allowedNamespaces: map[string]bool{"team-a": true},
+	}
+
+	repo := NewModelLineageRepository(&ModelRegistryClient{}, NewModelRegistryRepository())
+	lineage, err := repo.GetModelVersionLineage(testContext(), mockLineageRESTClient(t, version, artifacts), k8sClient, "kubeflow", "model-registry", "", "7")
+	require.NoError(t, err)
+
+	assert.Empty(t, lineage.Warnings)
+	assert.True(t, lineage.HasNode("transfer_job:team-a/import-granite"))
+	assert.True(t, lineage.HasNode("transfer_job:team-a/update-granite"))
+	assert.False(t, lineage.HasNode("transfer_job:team-a/other-model"))
+	assert.Contains(t, lineage.Edges, lineageEdge("transfer_job:team-a/import-granite", "model_artifact:20", models.ModelLineageRelationProduced))
+	assert.Contains(t, lineage.Edges, lineageEdge("transfer_job:team-a/update-granite", "model_version:7", models.ModelLineageRelationProduced))
+	for _, node := range lineage.Nodes {
+		if node.Type == models.ModelLineageNodeTypeTransferJob {
+			assert.Equal(t, string(models.ModelTransferJobStatusCompleted), node.Status)
+		}
+	}
+}
+
+func TestGetModelVersionLineage_TransferJobNamespaceWithoutAccess(t *testing.T) {
+	version := openapi.ModelVersion{Id: stringPtr("7"), Name: "v1"}
+	artifacts := []openapi.ModelArtifact{
+		{
+			Id:               stringPtr("20"),
+			Name:             stringPtr("model"),
+			ModelSourceKind:  stringPtr("transfer_job"),
+			ModelSourceGroup: stringPtr("team-a"),
+			ModelSourceName:  stringPtr("import-granite"),
+		},
+	}
+
+	repo := NewModelLineageRepository(&ModelRegistryClient{}, NewModelRegistryRepository())
+	lineage, err := repo.GetModelVersionLineage(testContext(), mockLineageRESTClient(t, version, artifacts), &fakeKubernetesClient{}, "kubeflow", "model-registry", "", "7")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`no access to model transfer jobs in namespace "team-a"`}, lineage.Warnings)
+	require.True(t, lineage.HasNode("transfer_job:team-a/import-granite"))
+	assert.Contains(t, lineage.Edges, lineageEdge("transfer_job:team-a/import-granite", "model_artifact:20", models.ModelLineageRelationProduced))
+}
+
+func TestGetModelVersionLineage_VersionNotFound(t *testing.T) {
+	versionData, err := json.Marshal(openapi.ModelVersion{})
+	require.NoError(t, err)
+	path, err := url.JoinPath(modelVersionPath, "404")
+	require.NoError(t, err)
+
+	client := new(mocks.MockHTTPClient)
+	client.On("GET", path).Return(versionData, nil)
+
+	repo := NewModelLineageRepository(&ModelRegistryClient{}, NewModelRegistryRepository())
+	_, err = repo.GetModelVersionLineage(testContext(), client, &fakeKubernetesClient{}, "kubeflow", "model-registry", "", "404")
+	assert.True(t, errors.Is(err, ErrModelVersionNotFound))
+}
@@ -0,0 +1,705 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/hub/pkg/openapi"
+	helper "github.com/kubeflow/hub/ui/bff/internal/helpers"
+	"github.com/kubeflow/hub/ui/bff/internal/integrations/httpclient"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	promotionPolicyConfigMapSuffix = "-promotion-policy"
+	promotionPolicyKey             = "policy.yaml"
+	promotionRecordKey             = "promotion.json"
+
+	promotionRequestLabel      = "modelregistry.kubeflow.org/promotion-request"
+	promotionRegistryNameLabel = "modelregistry.kubeflow.org/model-registry-name"
+	promotionModelVersionLabel = "modelregistry.kubeflow.org/model-version-id"
+
+	defaultEvalScoreProperty = "eval_score"
+	// catalogModelSourceKind is the model_source_kind of artifacts registered from the model
+	// catalog; model_source_class is the catalog source id and model_source_name the model.
+	catalogModelSourceKind = "catalog"
+
+	// Model version custom properties set when a promotion completes.
+	promotionStageProperty   = "stage"
+	promotionRequestProperty = "promotion_request_id"
+
+	// promotionTimeLayout has a fixed width so that timestamps sort as strings.
+	promotionTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+)
+
+var (
+	ErrPromotionPolicyInvalid   = errors.New("invalid promotion policy")
+	ErrPromotionPolicyConflict  = errors.New("promotion policy was modified by another request")
+	ErrPromotionStageNotFound   = errors.New("stage is not defined in the promotion policy")
+	ErrPromotionRequestInvalid  = errors.New("modelVersionId and targetStage are required")
+	ErrPromotionRequestNotFound = errors.New("promotion request not found")
+	ErrPromotionRequestExists   = errors.New("a pending promotion request already exists for this model version and stage")
+	ErrPromotionRequestClosed   = errors.New("promotion request is no longer pending")
+	ErrPromotionRequestConflict = errors.New("promotion request was modified by another request")
+	ErrPromotionNotAllowed      = errors.New("user is not allowed to decide on this promotion request")
+	ErrPromotionAlreadyApproved = errors.New("user already approved this promotion request")
+	ErrPromotionInvalidDecision = errors.New("decision must be approve or reject")
+	ErrPromotionPropertyManaged = errors.New("custom property is managed by the promotion workflow and cannot be changed directly")
+)
+
+// promotionRecord is what a promotion request ConfigMap stores: the request and its part of
+// the audit log, so that both are written in the same update.
+type promotionRecord struct {
+	Request models.ModelPromotionRequest      `json:"request"`
+	Audit   []models.ModelPromotionAuditEntry `json:"audit"`
+}
+
+// ModelPromotionRepository manages model version promotions. The policy and every promotion
+// request live in ConfigMaps in the model registry's namespace; updates use the ConfigMap
+// resourceVersion so that concurrent decisions cannot overwrite each other.
+type ModelPromotionRepository struct {
+	modelRegistryClient ModelRegistryClientInterface
+	modelCatalogClient  ModelCatalogClientInterface
+}
+
+func NewModelPromotionRepository(modelRegistryClient ModelRegistryClientInterface, modelCatalogClient ModelCatalogClientInterface) *ModelPromotionRepository {
+	return &ModelPromotionRepository{
+		modelRegistryClient: modelRegistryClient,
+		modelCatalogClient:  modelCatalogClient,
+	}
+}
+
+// GetPromotionPolicy returns the promotion policy of a model registry. A registry without a
+// policy has no stages.
+func (r *ModelPromotionRepository) GetPromotionPolicy(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, modelRegistryID string) (*models.ModelPromotionPolicy, error) {
+	policy, _, err := r.getPromotionPolicy(ctx, client, namespace, modelRegistryID)
+	return policy, err
+}
+
+// UpdatePromotionPolicy validates and stores the promotion policy of a model registry.
+func (r *ModelPromotionRepository) UpdatePromotionPolicy(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, modelRegistryID string, policy models.ModelPromotionPolicy) (*models.ModelPromotionPolicy, error) {
+	if err := validatePromotionPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	raw, err := yaml.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode promotion policy: %w", err)
+	}
+
+	_, configMap, err := r.getPromotionPolicy(ctx, client, namespace, modelRegistryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if configMap == nil {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelRegistryID + promotionPolicyConfigMapSuffix,
+				Namespace: namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/component": k8s.ComponentLabelValue,
+					promotionRegistryNameLabel:    modelRegistryID,
+				},
+			},
+			Data: map[string]string{promotionPolicyKey: string(raw)},
+		}
+		if _, err := client.CreateConfigMap(ctx, namespace, configMap); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return nil, ErrPromotionPolicyConflict
+			}
+			return nil, fmt.Errorf("failed to create promotion policy: %w", err)
+		}
+		return &policy, nil
+	}
+
+	configMap = configMap.DeepCopy()
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[promotionPolicyKey] = string(raw)
+	if _, err := client.UpdateConfigMap(ctx, namespace, configMap); err != nil {
+		if apierrors.IsConflict(err) {
+			return nil, ErrPromotionPolicyConflict
+		}
+		return nil, fmt.Errorf("failed to update promotion policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// GetAllPromotionRequests lists the promotion requests of a model registry, newest first,
+// optionally filtered by model version and status.
+func (r *ModelPromotionRepository) GetAllPromotionRequests(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, modelRegistryID string, modelVersionID string, status string) (*models.ModelPromotionRequestList, error) {
+	records, err := r.listPromotionRecords(ctx, client, namespace, modelRegistryID, modelVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &models.ModelPromotionRequestList{Items: []models.ModelPromotionRequest{}}
+	for _, record := range records {
+		if status != "" && !strings.EqualFold(string(record.Request.Status), status) {
+			continue
+		}
+		list.Items = append(list.Items, record.Request)
+	}
+	sort.SliceStable(list.Items, func(i, j int) bool { return list.Items[i].CreatedAt > list.Items[j].CreatedAt })
+	list.Size = len(list.Items)
+	return list, nil
+}
+
+// GetPromotionRequest returns a promotion request of a model registry.
+func (r *ModelPromotionRepository) GetPromotionRequest(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, modelRegistryID string, requestID string) (*models.ModelPromotionRequest, error) {
+	record, _, err := r.getPromotionRecord(ctx, client, namespace, modelRegistryID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return &record.Request, nil
+}
+
+// CreatePromotionRequest requests the promotion of a model version to a stage of the policy.
+// The stage requirements are checked straight away so that approvers can see them, and again
+// once the last approval is given. A stage that needs no approvals is promoted immediately.
+//
+// catalogClient may be nil when the model catalog is unavailable; security benchmark
+// requirements are then unmet.
+func (r *ModelPromotionRepository) CreatePromotionRequest(ctx context.Context, client k8s.KubernetesClientInterface, restClient httpclient.HTTPClientInterface, catalogClient httpclient.HTTPClientInterface, namespace string, modelRegistryID string, requester string, payload models.ModelPromotionRequestCreate) (*models.ModelPromotionRequest, error) {
+	if payload.ModelVersionId == "" || payload.TargetStage == "" {
+		return nil, ErrPromotionRequestInvalid
+	}
+
+	policy, err := r.GetPromotionPolicy(ctx, client, namespace, modelRegistryID)
+	if err != nil {
+		return nil, err
+	}
+	stage, ok := findPromotionStage(policy, payload.TargetStage)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPromotionStageNotFound, payload.TargetStage)
+	}
+
+	version, err := r.modelRegistryClient.GetModelVersion(restClient, payload.ModelVersionId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch model version: %w", err)
+	}
+	if _, ok := version.GetIdOk(); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrModelVersionNotFound, payload.ModelVersionId)
+	}
+
+	existing, err := r.listPromotionRecords(ctx, client, namespace, modelRegistryID, payload.ModelVersionId)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range existing {
+		if record.Request.TargetStage == stage.Stage && record.Request.Status == models.ModelPromotionStatusPending {
+			return nil, ErrPromotionRequestExists
+		}
+	}
+
+	now := promotionTimestamp()
+	record := &promotionRecord{
+		Request: models.ModelPromotionRequest{
+			Id:                fmt.Sprintf("%s-promotion-%s", modelRegistryID, utilrand.String(5)),
+			ModelVersionId:    version.GetId(),
+			ModelVersionName:  version.GetName(),
+			RegisteredModelId: version.GetRegisteredModelId(),
+			TargetStage:       stage.Stage,
+			Requester:         requester,
+			Comment:           payload.Comment,
+			Status:            models.ModelPromotionStatusPending,
+			RequiredApprovals: stage.RequiredApprovals,
+			ApproverGroup:     stage.ApproverGroup,
+			Approvals:         []models.ModelPromotionApproval{},
+			Requirements:      r.checkPromotionRequirements(ctx, restClient, catalogClient, stage, version),
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		},
+	}
+	record.audit(requester, models.ModelPromotionAuditActionRequested, payload.Comment, now)
+
+	if stage.RequiredApprovals == 0 {
+		r.completePromotion(ctx, restClient, catalogClient, stage, version, record, requester)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode promotion request: %w", err)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      record.Request.Id,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/component": k8s.ComponentLabelValue,
+				promotionRequestLabel:         "true",
+				promotionRegistryNameLabel:    modelRegistryID,
+				promotionModelVersionLabel:    record.Request.ModelVersionId,
+			},
+		},
+		Data: map[string]string{promotionRecordKey: string(data)},
+	}
+	if _, err := client.CreateConfigMap(ctx, namespace, configMap); err != nil {
+		return nil, fmt.Errorf("failed to store promotion request: %w", err)
+	}
+
+	return &record.Request, nil
+}
+
+// DecidePromotionRequest records an approval or a rejection. Only members of the stage's
+// approver group other than the requester may approve; the requester may also reject, to
+// withdraw the request. The last required approval checks the stage requirements again and
+// either promotes the model version or fails the request.
+func (r *ModelPromotionRepository) DecidePromotionRequest(ctx context.Context, client k8s.KubernetesClientInterface, restClient httpclient.HTTPClientInterface, catalogClient httpclient.HTTPClientInterface, namespace string, modelRegistryID string, requestID string, actor string, actorGroups []string, decision models.ModelPromotionDecision) (*models.ModelPromotionRequest, error) {
+	if decision.Decision != models.ModelPromotionDecisionApprove && decision.Decision != models.ModelPromotionDecisionReject {
+		return nil, ErrPromotionInvalidDecision
+	}
+
+	record, configMap, err := r.getPromotionRecord(ctx, client, namespace, modelRegistryID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	request := &record.Request
+	if request.Status != models.ModelPromotionStatusPending {
+		return nil, ErrPromotionRequestClosed
+	}
+
+	isApprover := request.ApproverGroup != "" && slices.Contains(actorGroups, request.ApproverGroup)
+	isRequester := actor != "" && actor == request.Requester
+	now := promotionTimestamp()
+
+	switch decision.Decision {
+	case models.ModelPromotionDecisionReject:
+		if !isApprover && !isRequester {
+			return nil, ErrPromotionNotAllowed
+		}
+		request.Status = models.ModelPromotionStatusRejected
+		request.StatusMessage = fmt.Sprintf("rejected by %s", actor)
+		record.audit(actor, models.ModelPromotionAuditActionRejected, decision.Comment, now)
+
+	case models.ModelPromotionDecisionApprove:
+		if !isApprover || isRequester {
+			return nil, ErrPromotionNotAllowed
+		}
+		for _, approval := range request.Approvals {
+			if approval.User == actor {
+				return nil, ErrPromotionAlreadyApproved
+			}
+		}
+		request.Approvals = append(request.Approvals, models.ModelPromotionApproval{User: actor, Comment: decision.Comment, Timestamp: now})
+		record.audit(actor, models.ModelPromotionAuditActionApproved, decision.Comment, now)
+
+		if len(request.Approvals) >= request.RequiredApprovals {
+			r.promoteApprovedRequest(ctx, client, restClient, catalogClient, namespace, modelRegistryID, record, actor)
+		}
+	}
+	request.UpdatedAt = now
+
+	if err := r.savePromotionRecord(ctx, client, namespace, configMap, record); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// GetPromotionAuditLog returns every recorded promotion event of a model registry, oldest
+// first, optionally for one model version.
+func (r *ModelPromotionRepository) GetPromotionAuditLog(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, modelRegistryID string, modelVersionID string) (*models.ModelPromotionAuditLog, error) {
+	records, err := r.listPromotionRecords(ctx, client, namespace, modelRegistryID, modelVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Request.CreatedAt < records[j].Request.CreatedAt })
+	log := &models.ModelPromotionAuditLog{Items: []models.ModelPromotionAuditEntry{}}
+	for _, record := range records {
+		log.Items = append(log.Items, record.Audit...)
+	}
+	sort.SliceStable(log.Items, func(i, j int) bool { return log.Items[i].Timestamp < log.Items[j].Timestamp })
+	log.Size = len(log.Items)
+	return log, nil
+}
+
+// CheckModelVersionUpdate rejects a model version update that sets, changes or removes the
+// custom properties written when a promotion completes, so that a stage can only be reached
+// through an approved promotion request. Updates without customProperties leave them alone.
+func (r *ModelPromotionRepository) CheckModelVersionUpdate(restClient httpclient.HTTPClientInterface, modelVersionID string, update openapi.ModelVersionUpdate) error {
+	updated := update.GetCustomProperties()
+	if updated == nil {
+		return nil
+	}
+	version, err := r.modelRegistryClient.GetModelVersion(restClient, modelVersionID)
+	if err != nil {
+		return err
+	}
+	current := version.GetCustomProperties()
+	for _, property := range []string{promotionStageProperty, promotionRequestProperty} {
+		currentValue, hasCurrent := current[property]
+		updatedValue, hasUpdated := updated[property]
+		if hasCurrent != hasUpdated || (hasCurrent && !sameMetadataValue(currentValue, updatedValue)) {
+			return fmt.Errorf("%w: %s", ErrPromotionPropertyManaged, property)
+		}
+	}
+	return nil
+}
+
+// promoteApprovedRequest completes a request that has all its approvals, using the current
+// state of the model version.
+func (r *ModelPromotionRepository) promoteApprovedRequest(ctx context.Context, client k8s.KubernetesClientInterface, restClient httpclient.HTTPClientInterface, catalogClient httpclient.HTTPClientInterface, namespace string, modelRegistryID string, record *promotionRecord, actor string) {
+	request := &record.Request
+
+	policy, err := r.GetPromotionPolicy(ctx, client, namespace, modelRegistryID)
+	if err != nil {
+		record.fail(actor, fmt.Sprintf("unable to read the promotion policy: %v", err))
+		return
+	}
+	stage, ok := findPromotionStage(policy, request.TargetStage)
+	if !ok {
+		record.fail(actor, fmt.Sprintf("stage %q was removed from the promotion policy", request.TargetStage))
+		return
+	}
+
+	version, err := r.modelRegistryClient.GetModelVersion(restClient, request.ModelVersionId)
+	if err != nil {
+		record.fail(actor, fmt.Sprintf("unable to read the model version: %v", err))
+		return
+	}
+	if _, ok := version.GetIdOk(); !ok {
+		record.fail(actor, "the model version no longer exists")
+		return
+	}
+
+	r.completePromotion(ctx, restClient, catalogClient, stage, version, record, actor)
+}
+
+// completePromotion checks the stage requirements and, when they are all met, sets the stage
+// on the model version. The outcome is recorded on the request and in its audit log.
+func (r *ModelPromotionRepository) completePromotion(ctx context.Context, restClient httpclient.HTTPClientInterface, catalogClient httpclient.HTTPClientInterface, stage models.ModelPromotionStagePolicy, version *openapi.ModelVersion, record *promotionRecord, actor string) {
+	request := &record.Request
+	request.Requirements = r.checkPromotionRequirements(ctx, restClient, catalogClient, stage, version)
+
+	var unmet []string
+	for _, requirement := range request.Requirements {
+		if !requirement.Met {
+			unmet = append(unmet, requirement.Name)
+		}
+	}
+	if len(unmet) > 0 {
+		record.fail(actor, "requirements not met: "+strings.Join(unmet, ", "))
+		return
+	}
+
+	customProperties := version.GetCustomProperties()
+	if customProperties == nil {
+		customProperties = map[string]openapi.MetadataValue{}
+	}
+	customProperties[promotionStageProperty] = stringMetadataValue(stage.Stage)
+	customProperties[promotionRequestProperty] = stringMetadataValue(request.Id)
+
+	jsonData, err := json.Marshal(openapi.ModelVersionUpdate{CustomProperties: customProperties})
+	if err != nil {
+		record.fail(actor, fmt.Sprintf("unable to encode the model version update: %v", err))
+		return
+	}
+	if _, err := r.modelRegistryClient.UpdateModelVersion(restClient, request.ModelVersionId, jsonData); err != nil {
+		helper.GetContextLogger(ctx).Error("failed to promote model version", "modelVersionId", request.ModelVersionId, "error", err)
+		record.fail(actor, fmt.Sprintf("unable to update the model version: %v", err))
+		return
+	}
+
+	request.Status = models.ModelPromotionStatusPromoted
+	request.StatusMessage = fmt.Sprintf("promoted to %s", stage.Stage)
+	record.audit(actor, models.ModelPromotionAuditActionPromoted, "", promotionTimestamp())
+}
+
+// checkPromotionRequirements evaluates the evaluation score and security benchmark
+// requirements of a stage against a model version.
+func (r *ModelPromotionRepository) checkPromotionRequirements(ctx context.Context, restClient httpclient.HTTPClientInterface, catalogClient httpclient.HTTPClientInterface, stage models.ModelPromotionStagePolicy, version *openapi.ModelVersion) []models.ModelPromotionRequirement {
+	requirements := []models.ModelPromotionRequirement{}
+
+	if stage.MinEvalScore != nil {
+		property := stage.EvalScoreProperty
+		if property == "" {
+			property = defaultEvalScoreProperty
+		}
+		requirement := models.ModelPromotionRequirement{Name: "minEvalScore"}
+		score, ok := numericMetadataValue(version.GetCustomProperties()[property])
+		switch {
+		case !ok:
+			requirement.Message = fmt.Sprintf("model version has no numeric %q property", property)
+		case score < *stage.MinEvalScore:
+			requirement.Message = fmt.Sprintf("%s %g is below %g", property, score, *stage.MinEvalScore)
+		default:
+			requirement.Met = true
+			requirement.Message = fmt.Sprintf("%s %g", property, score)
+		}
+		requirements = append(requirements, requirement)
+	}
+
+	if len(stage.RequiredSecurityBenchmarks) > 0 {
+		requirements = append(requirements, r.checkSecurityBenchmarks(ctx, restClient, catalogClient, stage.RequiredSecurityBenchmarks, version)...)
+	}
+
+	return requirements
+}
+
+// checkSecurityBenchmarks checks that the catalog model a version was registered from passed
+// the given security benchmarks, using the latest result of each.
+func (r *ModelPromotionRepository) checkSecurityBenchmarks(ctx context.Context, restClient httpclient.HTTPClientInterface, catalogClient httpclient.HTTPClientInterface, benchmarks []string, version *openapi.ModelVersion) []models.ModelPromotionRequirement {
+	unmet := func(message string) []models.ModelPromotionRequirement {
+		requirements := make([]models.ModelPromotionRequirement, 0, len(benchmarks))
+		for _, benchmark := range benchmarks {
+			requirements = append(requirements, models.ModelPromotionRequirement{Name: "securityBenchmark:" + benchmark, Message: message})
+		}
+		return requirements
+	}
+
+	if catalogClient == nil {
+		return unmet("the model catalog is not available")
+	}
+
+	artifacts, err := r.modelRegistryClient.GetModelArtifactsByModelVersion(restClient, version.GetId(), nil)
+	if err != nil {
+		helper.GetContextLogger(ctx).Warn("failed to fetch artifacts for promotion", "modelVersionId", version.GetId(), "error", err)
+		return unmet("unable to read the artifacts of the model version")
+	}
+	var sourceID, modelName string
+	for _, artifact := range artifacts.GetItems() {
+		if artifact.GetModelSourceKind() == catalogModelSourceKind && artifact.GetModelSourceClass() != "" && artifact.GetModelSourceName() != "" {
+			sourceID, modelName = artifact.GetModelSourceClass(), artifact.GetModelSourceName()
+			break
+		}
+	}
+	if sourceID == "" {
+		return unmet("the model version was not registered from the model catalog")
+	}
+
+	securityArtifacts, err := r.modelCatalogClient.GetCatalogModelSecurityArtifacts(catalogClient, sourceID, url.PathEscape(modelName), url.Values{"pageSize": {"100"}})
+	if err != nil {
+		helper.GetContextLogger(ctx).Warn("failed to fetch security artifacts for promotion", "sourceId", sourceID, "model", modelName, "error", err)
+		return unmet("unable to read the security results of the catalog model")
+	}
+
+	type result struct {
+		pass    bool
+		updated int64
+	}
+	latest := make(map[string]result)
+	for _, artifact := range securityArtifacts.Items {
+		if artifact.CustomProperties == nil {
+			continue
+		}
+		properties := *artifact.CustomProperties
+		benchmark := properties["benchmark"]
+		if benchmark.MetadataStringValue == nil {
+			continue
+		}
+		var updated int64
+		if artifact.LastUpdateTimeSinceEpoch != nil {
+			updated, _ = strconv.ParseInt(*artifact.LastUpdateTimeSinceEpoch, 10, 64)
+		}
+		name := benchmark.MetadataStringValue.StringValue
+		if previous, ok := latest[name]; ok && previous.updated >= updated {
+			continue
+		}
+		pass := properties["pass"]
+		latest[name] = result{pass: pass.MetadataBoolValue != nil && pass.MetadataBoolValue.BoolValue, updated: updated}
+	}
+
+	requirements := make([]models.ModelPromotionRequirement, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		requirement := models.ModelPromotionRequirement{Name: "securityBenchmark:" + benchmark}
+		switch res, ok := latest[benchmark]; {
+		case !ok:
+			requirement.Message = fmt.Sprintf("no %s result for %s", benchmark, modelName)
+		case !res.pass:
+			requirement.Message = fmt.Sprintf("%s did not pass %s", modelName, benchmark)
+		default:
+			requirement.Met = true
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements
+}
+
+func (r *ModelPromotionRepository) getPromotionPolicy(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, modelRegistryID string) (*models.ModelPromotionPolicy, *corev1.ConfigMap, error) {
+	policy := &models.ModelPromotionPolicy{Stages: []models.ModelPromotionStagePolicy{}}
+
+	configMap, err := client.GetConfigMap(ctx, namespace, modelRegistryID+promotionPolicyConfigMapSuffix)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return policy, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to fetch promotion policy: %w", err)
+	}
+	if configMap == nil {
+		return policy, nil, nil
+	}
+
+	if raw := configMap.Data[promotionPolicyKey]; raw != "" {
+		if err := yaml.Unmarshal([]byte(raw), policy); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse promotion policy: %w", err)
+		}
+	}
+	return policy, configMap, nil
+}
+
+func (r *ModelPromotionRepository) listPromotionRecords(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, modelRegistryID string, modelVersionID string) ([]*promotionRecord, error) {
+	selector := fmt.Sprintf("%s=true,%s=%s", promotionRequestLabel, promotionRegistryNameLabel, modelRegistryID)
+	if modelVersionID != "" {
+		selector += fmt.Sprintf(",%s=%s", promotionModelVersionLabel, modelVersionID)
+	}
+
+	configMaps, err := client.ListConfigMaps(ctx, namespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotion requests: %w", err)
+	}
+
+	records := make([]*promotionRecord, 0, len(configMaps.Items))
+	for i := range configMaps.Items {
+		record, err := decodePromotionRecord(&configMaps.Items[i])
+		if err != nil {
+			helper.GetContextLogger(ctx).Warn("skipping unreadable promotion request", "name", configMaps.Items[i].Name, "error", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (r *ModelPromotionRepository) getPromotionRecord(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, modelRegistryID string, requestID string) (*promotionRecord, *corev1.ConfigMap, error) {
+	configMap, err := client.GetConfigMap(ctx, namespace, requestID)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, ErrPromotionRequestNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to fetch promotion request: %w", err)
+	}
+	if configMap == nil || configMap.Labels[promotionRequestLabel] != "true" || configMap.Labels[promotionRegistryNameLabel] != modelRegistryID {
+		return nil, nil, ErrPromotionRequestNotFound
+	}
+
+	record, err := decodePromotionRecord(configMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, configMap, nil
+}
+
+func (r *ModelPromotionRepository) savePromotionRecord(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, configMap *corev1.ConfigMap, record *promotionRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode promotion request: %w", err)
+	}
+
+	updated := configMap.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = map[string]string{}
+	}
+	updated.Data[promotionRecordKey] = string(data)
+	if _, err := client.UpdateConfigMap(ctx, namespace, updated); err != nil {
+		if apierrors.IsConflict(err) {
+			return ErrPromotionRequestConflict
+		}
+		return fmt.Errorf("failed to store promotion request: %w", err)
+	}
+	return nil
+}
+
+func decodePromotionRecord(configMap *corev1.ConfigMap) (*promotionRecord, error) {
+	var record promotionRecord
+	if err := json.Unmarshal([]byte(configMap.Data[promotionRecordKey]), &record); err != nil {
+		return nil, fmt.Errorf("failed to parse promotion request %s: %w", configMap.Name, err)
+	}
+	record.Request.Id = configMap.Name
+	return &record, nil
+}
+
+func (p *promotionRecord) audit(actor string, action models.ModelPromotionAuditAction, comment string, timestamp string) {
+	p.Audit = append(p.Audit, models.ModelPromotionAuditEntry{
+		Timestamp:          timestamp,
+		PromotionRequestId: p.Request.Id,
+		ModelVersionId:     p.Request.ModelVersionId,
+		TargetStage:        p.Request.TargetStage,
+		Actor:              actor,
+		Action:             action,
+		Comment:            comment,
+	})
+}
+
+func (p *promotionRecord) fail(actor string, message string) {
+	p.Request.Status = models.ModelPromotionStatusFailed
+	p.Request.StatusMessage = message
+	p.audit(actor, models.ModelPromotionAuditActionFailed, message, promotionTimestamp())
+}
+
+func validatePromotionPolicy(policy models.ModelPromotionPolicy) error {
+	seen := make(map[string]bool)
+	for _, stage := range policy.Stages {
+		if strings.TrimSpace(stage.Stage) == "" {
+			return fmt.Errorf("%w: stage name is required", ErrPromotionPolicyInvalid)
+		}
+		if seen[stage.Stage] {
+			return fmt.Errorf("%w: duplicate stage %q", ErrPromotionPolicyInvalid, stage.Stage)
+		}
+		seen[stage.Stage] = true
+		if stage.RequiredApprovals < 0 {
+			return fmt.Errorf("%w: stage %q: requiredApprovals cannot be negative", ErrPromotionPolicyInvalid, stage.Stage)
+		}
+		if stage.RequiredApprovals > 0 && stage.ApproverGroup == "" {
+			return fmt.Errorf("%w: stage %q: approverGroup is required when approvals are required", ErrPromotionPolicyInvalid, stage.Stage)
+		}
+	}
+	return nil
+}
+
+func findPromotionStage(policy *models.ModelPromotionPolicy, name string) (models.ModelPromotionStagePolicy, bool) {
+	for _, stage := range policy.Stages {
+		if stage.Stage == name {
+			return stage, true
+		}
+	}
+	return models.ModelPromotionStagePolicy{}, false
+}
+
+// numericMetadataValue reads a double, int or numeric string custom property.
+func numericMetadataValue(value openapi.MetadataValue) (float64, bool) {
+	switch {
+	case value.MetadataDoubleValue != nil:
+		return value.MetadataDoubleValue.DoubleValue, true
+	case value.MetadataIntValue != nil:
+		parsed, err := strconv.ParseFloat(value.MetadataIntValue.IntValue, 64)
+		return parsed, err == nil
+	case value.MetadataStringValue != nil:
+		parsed, err := strconv.ParseFloat(value.MetadataStringValue.StringValue, 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+func sameMetadataValue(a openapi.MetadataValue, b openapi.MetadataValue) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(rawA) == string(rawB)
+}
+
+func stringMetadataValue(value string) openapi.MetadataValue {
+	return openapi.MetadataStringValueAsMetadataValue(&openapi.MetadataStringValue{
+		StringValue:  value,
+		MetadataType: "MetadataStringValue",
+	})
+}
+
+func promotionTimestamp() string {
+	return time.Now().UTC().Format(promotionTimeLayout)
+}
@@ -0,0 +1,354 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/kubeflow/hub/pkg/openapi"
+	"github.com/kubeflow/hub/ui/bff/internal/mocks"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const promotionTestNamespace = "kubeflow"
+const promotionTestRegistry = "model-registry"
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func newPromotionTestRepository(t *testing.T) *ModelPromotionRepository {
+	t.Helper()
+	catalogClient, err := mocks.NewModelCatalogClientMock(nil)
+	require.NoError(t, err)
+	return NewModelPromotionRepository(&ModelRegistryClient{}, catalogClient)
+}
+
+// newPromotionTestKubernetesClient returns a fake client with the given policy stored in
+// the registry's policy ConfigMap.
+func newPromotionTestKubernetesClient(t *testing.T, stages ...models.ModelPromotionStagePolicy) *fakeKubernetesClient {
+	t.Helper()
+	k8sClient := &fakeKubernetesClient{user: "alice", configMapsByNamespace: map[string]map[string]*corev1.ConfigMap{}}
+	if len(stages) > 0 {
+		repo := newPromotionTestRepository(t)
+		_, err := repo.UpdatePromotionPolicy(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, models.ModelPromotionPolicy{Stages: stages})
+		require.NoError(t, err)
+	}
+	return k8sClient
+}
+
+// mockPromotionRESTClient serves a model version and its artifacts, and accepts updates of
+// the version.
+func mockPromotionRESTClient(t *testing.T, version openapi.ModelVersion, artifacts []openapi.ModelArtifact) *mocks.MockHTTPClient {
+	t.Helper()
+
+	versionData, err := json.Marshal(version)
+	require.NoError(t, err)
+	artifactData, err := json.Marshal(openapi.ModelArtifactList{Items: artifacts, Size: int32(len(artifacts))})
+	require.NoError(t, err)
+
+	versionPath, err := url.JoinPath(modelVersionPath, version.GetId())
+	require.NoError(t, err)
+	artifactsPath, err := url.JoinPath(modelVersionPath, version.GetId(), artifactsByModelVersionPath)
+	require.NoError(t, err)
+
+	client := new(mocks.MockHTTPClient)
+	client.On("GET", versionPath).Return(versionData, nil)
+	client.On("GET", artifactsPath).Return(artifactData, nil)
+	client.On("PATCH", versionPath, mock.Anything).Return(versionData, nil)
+	return client
+}
+
+func promotionTestVersion(evalScore float64) openapi.ModelVersion {
+	return openapi.ModelVersion{
+		Id:                stringPtr("7"),
+		Name:              "v1",
+		RegisteredModelId: "1",
+		CustomProperties: map[string]openapi.MetadataValue{
+			"eval_score": {MetadataDoubleValue: &openapi.MetadataDoubleValue{DoubleValue: evalScore, MetadataType: "MetadataDoubleValue"}},
+		},
+	}
+}
+
+func TestUpdatePromotionPolicy_Validation(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t)
+
+	tests := []struct {
+		name   string
+		policy models.ModelPromotionPolicy
+	}{
+		{"missing stage name", models.ModelPromotionPolicy{Stages: []models.ModelPromotionStagePolicy{{RequiredApprovals: 0}}}},
+		{"duplicate stage", models.ModelPromotionPolicy{Stages: []models.ModelPromotionStagePolicy{{Stage: "production"}, {Stage: "production"}}}},
+		{"negative approvals", models.ModelPromotionPolicy{Stages: []models.ModelPromotionStagePolicy{{Stage: "production", RequiredApprovals: -1}}}},
+		{"approvals without group", models.ModelPromotionPolicy{Stages: []models.ModelPromotionStagePolicy{{Stage: "production", RequiredApprovals: 1}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.UpdatePromotionPolicy(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, tt.policy)
+			assert.True(t, errors.Is(err, ErrPromotionPolicyInvalid))
+		})
+	}
+}
+
+func TestUpdatePromotionPolicy_RoundTrip(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t)
+
+	policy, err := repo.GetPromotionPolicy(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry)
+	require.NoError(t, err)
+	assert.Empty(t, policy.Stages)
+
+	stages := []models.ModelPromotionStagePolicy{
+		{Stage: "staging"},
+		{Stage: "production", RequiredApprovals: 2, ApproverGroup: "ml-approvers", MinEvalScore: float64Ptr(0.8), RequiredSecurityBenchmarks: []string{"toxicity"}},
+	}
+	_, err = repo.UpdatePromotionPolicy(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, models.ModelPromotionPolicy{Stages: stages})
+	require.NoError(t, err)
+	// The second update replaces the stored policy rather than creating another ConfigMap.
+	_, err = repo.UpdatePromotionPolicy(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, models.ModelPromotionPolicy{Stages: stages})
+	require.NoError(t, err)
+
+	policy, err = repo.GetPromotionPolicy(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry)
+	require.NoError(t, err)
+	assert.Equal(t, stages, policy.Stages)
+	assert.Equal(t, 1, k8sClient.createConfigMapCalls)
+}
+
+func TestCreatePromotionRequest_ApprovalPromotesVersion(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t, models.ModelPromotionStagePolicy{
+		Stage: "production", RequiredApprovals: 2, ApproverGroup: "ml-approvers", MinEvalScore: float64Ptr(0.8),
+	})
+	restClient := mockPromotionRESTClient(t, promotionTestVersion(0.9), nil)
+
+	request, err := repo.CreatePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "production", Comment: "ready",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ModelPromotionStatusPending, request.Status)
+	assert.Equal(t, "v1", request.ModelVersionName)
+	require.Len(t, request.Requirements, 1)
+	assert.True(t, request.Requirements[0].Met)
+
+	_, err = repo.CreatePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "production",
+	})
+	assert.True(t, errors.Is(err, ErrPromotionRequestExists))
+
+	approve := models.ModelPromotionDecision{Decision: models.ModelPromotionDecisionApprove}
+
+	// The requester cannot approve their own request, even as an approver.
+	_, err = repo.DecidePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, request.Id, "alice", []string{"ml-approvers"}, approve)
+	assert.True(t, errors.Is(err, ErrPromotionNotAllowed))
+	// Users outside the approver group cannot approve.
+	_, err = repo.DecidePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, request.Id, "mallory", []string{"developers"}, approve)
+	assert.True(t, errors.Is(err, ErrPromotionNotAllowed))
+
+	request, err = repo.DecidePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, request.Id, "bob", []string{"ml-approvers"}, approve)
+	require.NoError(t, err)
+	assert.Equal(t, models.ModelPromotionStatusPending, request.Status)
+	restClient.AssertNotCalled(t, "PATCH", mock.Anything, mock.Anything)
+
+	_, err = repo.DecidePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, request.Id, "bob", []string{"ml-approvers"}, approve)
+	assert.True(t, errors.Is(err, ErrPromotionAlreadyApproved))
+
+	request, err = repo.DecidePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, request.Id, "carol", []string{"ml-approvers"}, approve)
+	require.NoError(t, err)
+	assert.Equal(t, models.ModelPromotionStatusPromoted, request.Status)
+	assert.Len(t, request.Approvals, 2)
+	restClient.AssertNumberOfCalls(t, "PATCH", 1)
+
+	_, err = repo.DecidePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, request.Id, "dave", []string{"ml-approvers"}, approve)
+	assert.True(t, errors.Is(err, ErrPromotionRequestClosed))
+
+	stored, err := repo.GetPromotionRequest(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, request.Id)
+	require.NoError(t, err)
+	assert.Equal(t, models.ModelPromotionStatusPromoted, stored.Status)
+
+	auditLog, err := repo.GetPromotionAuditLog(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, "7")
+	require.NoError(t, err)
+	var actions []models.ModelPromotionAuditAction
+	for _, entry := range auditLog.Items {
+		actions = append(actions, entry.Action)
+	}
+	assert.Equal(t, []models.ModelPromotionAuditAction{
+		models.ModelPromotionAuditActionRequested,
+		models.ModelPromotionAuditActionApproved,
+		models.ModelPromotionAuditActionApproved,
+		models.ModelPromotionAuditActionPromoted,
+	}, actions)
+}
+
+func TestCreatePromotionRequest_EvalScoreBelowMinimumFails(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t, models.ModelPromotionStagePolicy{Stage: "staging", MinEvalScore: float64Ptr(0.8)})
+	restClient := mockPromotionRESTClient(t, promotionTestVersion(0.5), nil)
+
+	request, err := repo.CreatePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "staging",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ModelPromotionStatusFailed, request.Status)
+	assert.Contains(t, request.StatusMessage, "minEvalScore")
+	restClient.AssertNotCalled(t, "PATCH", mock.Anything, mock.Anything)
+}
+
+func TestCreatePromotionRequest_SecurityBenchmarks(t *testing.T) {
+	artifacts := []openapi.ModelArtifact{
+		{
+			Id:               stringPtr("20"),
+			Name:             stringPtr("model"),
+			ModelSourceKind:  stringPtr("catalog"),
+			ModelSourceClass: stringPtr("sample-source"),
+			ModelSourceName:  stringPtr("granite"),
+		},
+	}
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t, models.ModelPromotionStagePolicy{
+		Stage: "production", RequiredSecurityBenchmarks: []string{"toxicity", "hallucination"},
+	})
+	restClient := mockPromotionRESTClient(t, promotionTestVersion(0.9), artifacts)
+
+	request, err := repo.CreatePromotionRequest(testContext(), k8sClient, restClient, new(mocks.MockHTTPClient), promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "production",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ModelPromotionStatusFailed, request.Status)
+	assert.Equal(t, []models.ModelPromotionRequirement{
+		{Name: "securityBenchmark:toxicity", Met: true},
+		{Name: "securityBenchmark:hallucination", Message: "granite did not pass hallucination"},
+	}, request.Requirements)
+}
+
+func TestCreatePromotionRequest_SecurityBenchmarksWithoutCatalog(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t, models.ModelPromotionStagePolicy{
+		Stage: "production", RequiredApprovals: 1, ApproverGroup: "ml-approvers", RequiredSecurityBenchmarks: []string{"toxicity"},
+	})
+	restClient := mockPromotionRESTClient(t, promotionTestVersion(0.9), nil)
+
+	request, err := repo.CreatePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "production",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ModelPromotionStatusPending, request.Status)
+	require.Len(t, request.Requirements, 1)
+	assert.False(t, request.Requirements[0].Met)
+	assert.Equal(t, "the model catalog is not available", request.Requirements[0].Message)
+}
+
+func TestCreatePromotionRequest_UnknownStage(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t, models.ModelPromotionStagePolicy{Stage: "staging"})
+
+	_, err := repo.CreatePromotionRequest(testContext(), k8sClient, new(mocks.MockHTTPClient), nil, promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "production",
+	})
+	assert.True(t, errors.Is(err, ErrPromotionStageNotFound))
+}
+
+func TestDecidePromotionRequest_RequesterCanWithdraw(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t, models.ModelPromotionStagePolicy{Stage: "production", RequiredApprovals: 1, ApproverGroup: "ml-approvers"})
+	restClient := mockPromotionRESTClient(t, promotionTestVersion(0.9), nil)
+
+	request, err := repo.CreatePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "production",
+	})
+	require.NoError(t, err)
+
+	reject := models.ModelPromotionDecision{Decision: models.ModelPromotionDecisionReject, Comment: "not yet"}
+	_, err = repo.DecidePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, request.Id, "mallory", nil, reject)
+	assert.True(t, errors.Is(err, ErrPromotionNotAllowed))
+
+	request, err = repo.DecidePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, request.Id, "alice", nil, reject)
+	require.NoError(t, err)
+	assert.Equal(t, models.ModelPromotionStatusRejected, request.Status)
+
+	// A closed request no longer blocks a new one for the same stage.
+	_, err = repo.CreatePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "production",
+	})
+	require.NoError(t, err)
+
+	list, err := repo.GetAllPromotionRequests(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, "7", string(models.ModelPromotionStatusPending))
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Size)
+}
+
+func TestDecidePromotionRequest_StaleRecordConflicts(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t, models.ModelPromotionStagePolicy{Stage: "production", RequiredApprovals: 2, ApproverGroup: "ml-approvers"})
+	restClient := mockPromotionRESTClient(t, promotionTestVersion(0.9), nil)
+
+	request, err := repo.CreatePromotionRequest(testContext(), k8sClient, restClient, nil, promotionTestNamespace, promotionTestRegistry, "alice", models.ModelPromotionRequestCreate{
+		ModelVersionId: "7", TargetStage: "production",
+	})
+	require.NoError(t, err)
+
+	configMap := k8sClient.configMapsByNamespace[promotionTestNamespace][request.Id]
+	configMap.ResourceVersion = "1"
+	stale := configMap.DeepCopy()
+	_, err = k8sClient.UpdateConfigMap(testContext(), promotionTestNamespace, configMap)
+	require.NoError(t, err)
+
+	record, err := decodePromotionRecord(stale)
+	require.NoError(t, err)
+	err = repo.savePromotionRecord(testContext(), k8sClient, promotionTestNamespace, stale, record)
+	assert.True(t, errors.Is(err, ErrPromotionRequestConflict))
+}
+
+func TestGetPromotionRequest_NotFound(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	k8sClient := newPromotionTestKubernetesClient(t)
+	k8sClient.configMapsByNamespace[promotionTestNamespace] = map[string]*corev1.ConfigMap{
+		"unrelated": {ObjectMeta: metav1.ObjectMeta{Name: "unrelated"}},
+	}
+
+	_, err := repo.GetPromotionRequest(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, "unrelated")
+	assert.True(t, errors.Is(err, ErrPromotionRequestNotFound))
+	_, err = repo.GetPromotionRequest(testContext(), k8sClient, promotionTestNamespace, promotionTestRegistry, "missing")
+	assert.True(t, errors.Is(err, ErrPromotionRequestNotFound))
+}
+
+func TestCheckModelVersionUpdate_PromotionPropertiesAreManaged(t *testing.T) {
+	repo := newPromotionTestRepository(t)
+	promoted := promotionTestVersion(0.9)
+	promoted.CustomProperties[promotionStageProperty] = stringMetadataValue("staging")
+	promoted.CustomProperties[promotionRequestProperty] = stringMetadataValue("promotion-1")
+	restClient := mockPromotionRESTClient(t, promoted, nil)
+
+	withProperties := func(properties map[string]openapi.MetadataValue) openapi.ModelVersionUpdate {
+		return openapi.ModelVersionUpdate{CustomProperties: properties}
+	}
+	unchanged := map[string]openapi.MetadataValue{
+		"owner":                  stringMetadataValue("team-a"),
+		promotionStageProperty:   stringMetadataValue("staging"),
+		promotionRequestProperty: stringMetadataValue("promotion-1"),
+	}
+	changedStage := map[string]openapi.MetadataValue{
+		promotionStageProperty:   stringMetadataValue("production"),
+		promotionRequestProperty: stringMetadataValue("promotion-1"),
+	}
+	removedStage := map[string]openapi.MetadataValue{
+		promotionRequestProperty: stringMetadataValue("promotion-1"),
+	}
+
+	require.NoError(t, repo.CheckModelVersionUpdate(restClient, "7", openapi.ModelVersionUpdate{Description: stringPtr("new")}))
+	require.NoError(t, repo.CheckModelVersionUpdate(restClient, "7", withProperties(unchanged)))
+	assert.ErrorIs(t, repo.CheckModelVersionUpdate(restClient, "7", withProperties(changedStage)), ErrPromotionPropertyManaged)
+	assert.ErrorIs(t, repo.CheckModelVersionUpdate(restClient, "7", withProperties(removedStage)), ErrPromotionPropertyManaged)
+
+	unpromotedClient := mockPromotionRESTClient(t, promotionTestVersion(0.9), nil)
+	err := repo.CheckModelVersionUpdate(unpromotedClient, "7", withProperties(map[string]openapi.MetadataValue{
+		promotionStageProperty: stringMetadataValue("production"),
+	}))
+	assert.ErrorIs(t, err, ErrPromotionPropertyManaged)
+}
@@ -0,0 +1,288 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	helper "github.com/kubeflow/hub/ui/bff/internal/helpers"
+	k8s "github.com/kubeflow/hub/ui/bff/internal/integrations/kubernetes"
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// transferProgressLogTailLines is how much of the job log is scanned for the
+	// latest progress line. The job writes one every few seconds, so the tail
+	// always holds one unless the container is printing errors in a loop.
+	transferProgressLogTailLines = int64(200)
+
+	// transferCompletedFilesKey is the metadata ConfigMap key holding the files a
+	// previous job already uploaded, one per line. The job skips them on start.
+	transferCompletedFilesKey = "Transfer.completedFiles"
+
+	bandwidthLimitAnnotation = "modelregistry.kubeflow.org/bandwidth-limit"
+	resumedFromAnnotation    = "modelregistry.kubeflow.org/resumed-from"
+	capabilitiesAnnotation   = "modelregistry.kubeflow.org/job-capabilities"
+
+	asyncUploadCapabilityProgress       = "progress"
+	asyncUploadCapabilityResume         = "resume"
+	asyncUploadCapabilityBandwidthLimit = "bandwidth-limit"
+
+	// minBandwidthLimitBytesPerSecond keeps a cap from stalling large uploads past
+	// the registry's upload session timeout.
+	minBandwidthLimitBytesPerSecond = int64(64 * 1024)
+
+	// defaultJobBackoffLimit is what Kubernetes applies when a Job has none set.
+	defaultJobBackoffLimit = int32(6)
+)
+
+// asyncUploadImage is the image an async-upload job runs and the transfer controls it
+// implements.
+type asyncUploadImage struct {
+	URI          string
+	Capabilities asyncUploadCapabilities
+}
+
+// asyncUploadCapabilities are the parts of the transfer contract an async-upload image
+// implements. They are declared next to the image in the UI ConfigMap, as a comma
+// separated list, because the BFF cannot tell from an image reference what it supports:
+//
+//   - progress: with MODEL_SYNC_PROGRESS_FORMAT=json, writes transferProgressLine records
+//     to stdout.
+//   - resume: with MODEL_SYNC_RESUME=true, skips the files listed under
+//     transferCompletedFilesKey and ends a failed attempt with a transferCheckpoint as
+//     its termination message.
+//   - bandwidth-limit: honors MODEL_SYNC_BANDWIDTH_LIMIT_BYTES_PER_SECOND.
+//
+// Jobs get only the environment of the declared capabilities. A bandwidth limit for an
+// image without bandwidth-limit is rejected, and jobs without progress or resume are
+// reported without progress and retried from scratch.
+type asyncUploadCapabilities struct {
+	Progress       bool
+	Resume         bool
+	BandwidthLimit bool
+}
+
+func parseAsyncUploadCapabilities(value string) asyncUploadCapabilities {
+	var capabilities asyncUploadCapabilities
+	for _, name := range strings.Split(value, ",") {
+		switch strings.TrimSpace(name) {
+		case asyncUploadCapabilityProgress:
+			capabilities.Progress = true
+		case asyncUploadCapabilityResume:
+			capabilities.Resume = true
+		case asyncUploadCapabilityBandwidthLimit:
+			capabilities.BandwidthLimit = true
+		}
+	}
+	return capabilities
+}
+
+// String returns the capabilities in the form parseAsyncUploadCapabilities reads.
+func (c asyncUploadCapabilities) String() string {
+	var names []string
+	if c.Progress {
+		names = append(names, asyncUploadCapabilityProgress)
+	}
+	if c.Resume {
+		names = append(names, asyncUploadCapabilityResume)
+	}
+	if c.BandwidthLimit {
+		names = append(names, asyncUploadCapabilityBandwidthLimit)
+	}
+	return strings.Join(names, ",")
+}
+
+// transferProgressLine is a progress record written by the async-upload job to
+// stdout as a single JSON line, for example:
+//
+//	{"type":"progress","bytesTransferred":1048576,"totalBytes":4194304,"filesCompleted":1,"filesTotal":4,"currentFile":"model-00002.safetensors","bytesPerSecond":524288,"timestamp":"2026-01-02T15:04:05Z"}
+type transferProgressLine struct {
+	Type             string `json:"type"`
+	BytesTransferred int64  `json:"bytesTransferred"`
+	TotalBytes       int64  `json:"totalBytes"`
+	FilesCompleted   int    `json:"filesCompleted"`
+	FilesTotal       int    `json:"filesTotal"`
+	FilesResumed     int    `json:"filesResumed"`
+	CurrentFile      string `json:"currentFile"`
+	BytesPerSecond   int64  `json:"bytesPerSecond"`
+	ElapsedSeconds   int64  `json:"elapsedSeconds"`
+	Timestamp        string `json:"timestamp"`
+}
+
+// transferCheckpoint is the part of a failed pod's termination message that lists
+// the files already uploaded, so a retry can skip them.
+type transferCheckpoint struct {
+	CompletedFiles []string `json:"completedFiles"`
+}
+
+// lastTransferProgress returns the most recent progress line in logs, or nil when
+// the job has not reported any yet.
+func lastTransferProgress(logs string) *transferProgressLine {
+	lines := strings.Split(logs, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var progress transferProgressLine
+		if err := json.Unmarshal([]byte(line), &progress); err != nil || progress.Type != "progress" {
+			continue
+		}
+		return &progress
+	}
+	return nil
+}
+
+// buildTransferProgress turns the latest progress line into the API model, filling in
+// percent and ETA. line may be nil; attempts come from the job's pods.
+func buildTransferProgress(line *transferProgressLine, status models.ModelTransferJobStatus, attempt, maxAttempts int) *models.ModelTransferJobProgress {
+	progress := &models.ModelTransferJobProgress{
+		Attempt:     attempt,
+		MaxAttempts: maxAttempts,
+	}
+	if line != nil {
+		progress.BytesTransferred = line.BytesTransferred
+		progress.TotalBytes = line.TotalBytes
+		progress.FilesCompleted = line.FilesCompleted
+		progress.FilesTotal = line.FilesTotal
+		progress.FilesResumed = line.FilesResumed
+		progress.CurrentFile = line.CurrentFile
+		progress.BytesPerSecond = line.BytesPerSecond
+		progress.UpdatedAt = line.Timestamp
+		if progress.BytesPerSecond == 0 && line.ElapsedSeconds > 0 {
+			progress.BytesPerSecond = line.BytesTransferred / line.ElapsedSeconds
+		}
+	}
+
+	if status == models.ModelTransferJobStatusCompleted {
+		if progress.TotalBytes > 0 {
+			progress.BytesTransferred = progress.TotalBytes
+		}
+		if progress.FilesTotal > 0 {
+			progress.FilesCompleted = progress.FilesTotal
+		}
+		progress.CurrentFile = ""
+		progress.Percent = 100
+		eta := int64(0)
+		progress.EtaSeconds = &eta
+		return progress
+	}
+
+	if progress.TotalBytes > 0 {
+		percent := float64(progress.BytesTransferred) * 100 / float64(progress.TotalBytes)
+		// Leave the last step to the COMPLETED status so a finished upload that is
+		// still registering the model does not read as done.
+		progress.Percent = min(float64(int64(percent*10))/10, 99.9)
+	}
+	if status == models.ModelTransferJobStatusRunning && progress.TotalBytes > 0 && progress.BytesPerSecond > 0 {
+		remaining := max(progress.TotalBytes-progress.BytesTransferred, 0)
+		eta := remaining / progress.BytesPerSecond
+		progress.EtaSeconds = &eta
+	}
+	return progress
+}
+
+// latestTransferPod returns the most recently created pod, which is the current or
+// last attempt of the job.
+func latestTransferPod(pods []corev1.Pod) *corev1.Pod {
+	var latest *corev1.Pod
+	for i := range pods {
+		if latest == nil || latest.CreationTimestamp.Before(&pods[i].CreationTimestamp) {
+			latest = &pods[i]
+		}
+	}
+	return latest
+}
+
+func jobMaxAttempts(job *batchv1.Job) int {
+	backoffLimit := defaultJobBackoffLimit
+	if job.Spec.BackoffLimit != nil {
+		backoffLimit = *job.Spec.BackoffLimit
+	}
+	return int(backoffLimit) + 1
+}
+
+// attachTransferProgress sets result.Progress from the job's latest pod. Progress is
+// best effort: a job whose pods or logs cannot be read is returned without it, and so is
+// a job whose image does not report progress.
+func attachTransferProgress(ctx context.Context, client k8s.KubernetesClientInterface, job *batchv1.Job, result *models.ModelTransferJob) {
+	if !parseAsyncUploadCapabilities(job.Annotations[capabilitiesAnnotation]).Progress {
+		return
+	}
+	logger := helper.GetContextLogger(ctx)
+
+	podList, err := client.GetTransferJobPods(ctx, job.Namespace, []string{job.Name})
+	if err != nil {
+		logger.Warn("failed to fetch pods for transfer job progress", "job", job.Name, "error", err)
+		return
+	}
+	pod := latestTransferPod(podList.Items)
+	if pod == nil {
+		return
+	}
+
+	logs, err := client.GetTransferJobPodLogs(ctx, job.Namespace, pod.Name, transferProgressLogTailLines)
+	if err != nil {
+		// Logs are gone once the node reclaims the pod, and are not served for pods
+		// that have not been scheduled yet.
+		logger.Debug("no logs for transfer job progress", "job", job.Name, "pod", pod.Name, "error", err)
+	}
+
+	result.Progress = buildTransferProgress(lastTransferProgress(logs), result.Status, len(podList.Items), jobMaxAttempts(job))
+}
+
+// transferCheckpointFromPods reads the completed files from the termination message of
+// the latest failed attempt. It returns nil when there is nothing to resume from.
+func transferCheckpointFromPods(pods []corev1.Pod) *transferCheckpoint {
+	pod := latestTransferPod(pods)
+	if pod == nil {
+		return nil
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Terminated == nil || cs.State.Terminated.Message == "" {
+			continue
+		}
+		var checkpoint transferCheckpoint
+		if err := json.Unmarshal([]byte(cs.State.Terminated.Message), &checkpoint); err != nil || len(checkpoint.CompletedFiles) == 0 {
+			continue
+		}
+		return &checkpoint
+	}
+	return nil
+}
+
+// sameTransferSource reports whether a retry reads from the same place as the failed
+// job. Files uploaded from a different source must not be skipped.
+func sameTransferSource(annotations map[string]string, payload models.ModelTransferJob) bool {
+	return annotations["modelregistry.kubeflow.org/source-type"] == string(payload.Source.Type) &&
+		annotations["modelregistry.kubeflow.org/source-bucket"] == payload.Source.Bucket &&
+		annotations["modelregistry.kubeflow.org/source-key"] == payload.Source.Key &&
+		annotations["modelregistry.kubeflow.org/source-uri"] == payload.Source.URI &&
+		annotations["modelregistry.kubeflow.org/dest-uri"] == payload.Destination.URI
+}
+
+func parseBandwidthLimit(value string) int64 {
+	if value == "" {
+		return 0
+	}
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+func validateBandwidthLimit(limit int64) error {
+	if limit < 0 {
+		return fmt.Errorf("%w: bandwidth limit must not be negative", ErrJobValidationFailed)
+	}
+	if limit > 0 && limit < minBandwidthLimitBytesPerSecond {
+		return fmt.Errorf("%w: bandwidth limit must be at least %d bytes per second", ErrJobValidationFailed, minBandwidthLimitBytesPerSecond)
+	}
+	return nil
+}
@@ -0,0 +1,389 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/hub/ui/bff/internal/models"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLastTransferProgress(t *testing.T) {
+	logs := strings.Join([]string{
+		`{"type":"progress","bytesTransferred":100,"totalBytes":1000,"filesCompleted":0,"filesTotal":2}`,
+		`downloading model-00002.safetensors`,
+		`{"type":"progress","bytesTransferred":600,"totalBytes":1000,"filesCompleted":1,"filesTotal":2,"currentFile":"model-00002.safetensors"}`,
+		`{"type":"log","message":"retrying chunk"}`,
+		`{"type":"progress", truncated`,
+		``,
+	}, "\n")
+
+	progress := lastTransferProgress(logs)
+	if progress == nil {
+		t.Fatalf("expected a progress line")
+	}
+	if progress.BytesTransferred != 600 || progress.CurrentFile != "model-00002.safetensors" {
+		t.Errorf("expected the latest complete progress line, got %+v", progress)
+	}
+
+	if got := lastTransferProgress("starting upload\nno progress yet\n"); got != nil {
+		t.Errorf("expected nil for logs without progress, got %+v", got)
+	}
+}
+
+func TestBuildTransferProgress(t *testing.T) {
+	testCases := []struct {
+		name        string
+		line        *transferProgressLine
+		status      models.ModelTransferJobStatus
+		wantPercent float64
+		wantEta     *int64
+		wantBytes   int64
+	}{
+		{
+			name:        "running with reported rate",
+			line:        &transferProgressLine{BytesTransferred: 250, TotalBytes: 1000, BytesPerSecond: 50},
+			status:      models.ModelTransferJobStatusRunning,
+			wantPercent: 25,
+			wantEta:     int64Ptr(15),
+			wantBytes:   250,
+		},
+		{
+			name:        "running with rate from elapsed time",
+			line:        &transferProgressLine{BytesTransferred: 300, TotalBytes: 900, ElapsedSeconds: 10},
+			status:      models.ModelTransferJobStatusRunning,
+			wantPercent: 33.3,
+			wantEta:     int64Ptr(20),
+			wantBytes:   300,
+		},
+		{
+			name:        "all bytes sent but still registering",
+			line:        &transferProgressLine{BytesTransferred: 1000, TotalBytes: 1000, BytesPerSecond: 50},
+			status:      models.ModelTransferJobStatusRunning,
+			wantPercent: 99.9,
+			wantEta:     int64Ptr(0),
+			wantBytes:   1000,
+		},
+		{
+			name:        "failed keeps last position without ETA",
+			line:        &transferProgressLine{BytesTransferred: 500, TotalBytes: 1000, BytesPerSecond: 50},
+			status:      models.ModelTransferJobStatusFailed,
+			wantPercent: 50,
+			wantBytes:   500,
+		},
+		{
+			name:        "completed without progress lines",
+			status:      models.ModelTransferJobStatusCompleted,
+			wantPercent: 100,
+			wantEta:     int64Ptr(0),
+		},
+		{
+			name:        "completed fills in totals",
+			line:        &transferProgressLine{BytesTransferred: 800, TotalBytes: 1000, FilesCompleted: 3, FilesTotal: 4, CurrentFile: "b"},
+			status:      models.ModelTransferJobStatusCompleted,
+			wantPercent: 100,
+			wantEta:     int64Ptr(0),
+			wantBytes:   1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			progress := buildTransferProgress(tc.line, tc.status, 2, 4)
+			if progress.Percent != tc.wantPercent {
+				t.Errorf("percent = %v, want %v", progress.Percent, tc.wantPercent)
+			}
+			if progress.BytesTransferred != tc.wantBytes {
+				t.Errorf("bytesTransferred = %d, want %d", progress.BytesTransferred, tc.wantBytes)
+			}
+			switch {
+			case tc.wantEta == nil && progress.EtaSeconds != nil:
+				t.Errorf("expected no ETA, got %d", *progress.EtaSeconds)
+			case tc.wantEta != nil && (progress.EtaSeconds == nil || *progress.EtaSeconds != *tc.wantEta):
+				t.Errorf("eta = %v, want %d", progress.EtaSeconds, *tc.wantEta)
+			}
+			if progress.Attempt != 2 || progress.MaxAttempts != 4 {
+				t.Errorf("attempts = %d/%d, want 2/4", progress.Attempt, progress.MaxAttempts)
+			}
+			if tc.status == models.ModelTransferJobStatusCompleted && progress.CurrentFile != "" {
+				t.Errorf("expected no current file on a completed job, got %q", progress.CurrentFile)
+			}
+		})
+	}
+}
+
+func TestGetModelTransferJob_AttachesProgressFromLatestPod(t *testing.T) {
+	repo := NewModelRegistryRepository()
+	backoffLimit := int32(3)
+	created := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-progress",
+			Namespace: "kubeflow",
+			Labels: map[string]string{
+				"modelregistry.kubeflow.org/model-registry-name": "mr-1",
+			},
+			Annotations: map[string]string{
+				bandwidthLimitAnnotation: "1048576",
+				capabilitiesAnnotation:   "progress,bandwidth-limit",
+			},
+		},
+		Spec:   batchv1.JobSpec{BackoffLimit: &backoffLimit},
+		Status: batchv1.JobStatus{Active: 1, Failed: 1},
+	}
+
+	pod := func(name string, createdAt time.Time) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "kubeflow",
+			CreationTimestamp: metav1.NewTime(createdAt),
+			Labels:            map[string]string{"job-name": job.Name},
+		}}
+	}
+
+	client := &fakeKubernetesClient{
+		jobsByNamespace: map[string]map[string]*batchv1.Job{
+			"kubeflow": {job.Name: job},
+		},
+		podsByNamespace: map[string]*corev1.PodList{
+			"kubeflow": {Items: []corev1.Pod{
+				pod("job-progress-second", created.Add(time.Minute)),
+				pod("job-progress-first", created),
+			}},
+		},
+		logsByPod: map[string]string{
+			"job-progress-first":  `{"type":"progress","bytesTransferred":10,"totalBytes":100}`,
+			"job-progress-second": `{"type":"progress","bytesTransferred":40,"totalBytes":100,"filesResumed":2,"bytesPerSecond":10}`,
+		},
+	}
+
+	result, err := repo.GetModelTransferJob(context.Background(), client, "kubeflow", job.Name, "mr-1")
+	if err != nil {
+		t.Fatalf("GetModelTransferJob returned error: %v", err)
+	}
+	if result.BandwidthLimitBytesPerSecond != 1048576 {
+		t.Errorf("expected bandwidth limit from annotation, got %d", result.BandwidthLimitBytesPerSecond)
+	}
+	progress := result.Progress
+	if progress == nil {
+		t.Fatalf("expected progress to be attached")
+	}
+	if progress.BytesTransferred != 40 || progress.FilesResumed != 2 {
+		t.Errorf("expected progress from the latest pod, got %+v", progress)
+	}
+	if progress.Attempt != 2 || progress.MaxAttempts != 4 {
+		t.Errorf("attempts = %d/%d, want 2/4", progress.Attempt, progress.MaxAttempts)
+	}
+	if progress.EtaSeconds == nil || *progress.EtaSeconds != 6 {
+		t.Errorf("expected ETA of 6s, got %v", progress.EtaSeconds)
+	}
+
+	// Without readable logs the job is still returned, with attempts but no bytes.
+	client.logsByPod = nil
+	result, err = repo.GetModelTransferJob(context.Background(), client, "kubeflow", job.Name, "mr-1")
+	if err != nil {
+		t.Fatalf("GetModelTransferJob returned error: %v", err)
+	}
+	if result.Progress == nil || result.Progress.BytesTransferred != 0 || result.Progress.Attempt != 2 {
+		t.Errorf("expected attempt-only progress, got %+v", result.Progress)
+	}
+}
+
+func TestTransferCheckpointFromPods(t *testing.T) {
+	created := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	terminated := func(name string, createdAt time.Time, message string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(createdAt)},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: message}},
+			}}},
+		}
+	}
+
+	checkpoint := transferCheckpointFromPods([]corev1.Pod{
+		terminated("first", created, `{"completedFiles":["a"]}`),
+		terminated("second", created.Add(time.Minute), `{"completedFiles":["a","b"]}`),
+	})
+	if checkpoint == nil || len(checkpoint.CompletedFiles) != 2 {
+		t.Fatalf("expected the checkpoint of the latest attempt, got %+v", checkpoint)
+	}
+
+	for _, message := range []string{"", "connection reset", `{"completedFiles":[]}`} {
+		if got := transferCheckpointFromPods([]corev1.Pod{terminated("only", created, message)}); got != nil {
+			t.Errorf("expected no checkpoint for message %q, got %+v", message, got)
+		}
+	}
+}
+
+func TestSameTransferSource(t *testing.T) {
+	annotations := map[string]string{
+		"modelregistry.kubeflow.org/source-type":   "s3",
+		"modelregistry.kubeflow.org/source-bucket": "models",
+		"modelregistry.kubeflow.org/source-key":    "llama/",
+		"modelregistry.kubeflow.org/dest-uri":      "quay.io/acme/llama:1",
+	}
+	payload := models.ModelTransferJob{
+		Source:      models.ModelTransferJobSource{Type: models.ModelTransferJobSourceTypeS3, Bucket: "models", Key: "llama/"},
+		Destination: models.ModelTransferJobDestination{URI: "quay.io/acme/llama:1"},
+	}
+	if !sameTransferSource(annotations, payload) {
+		t.Errorf("expected identical source and destination to match")
+	}
+
+	payload.Source.Key = "mistral/"
+	if sameTransferSource(annotations, payload) {
+		t.Errorf("expected a different source key not to match")
+	}
+}
+
+func TestBuildK8sJobSetsBandwidthLimitAndResumeSource(t *testing.T) {
+	job := buildK8sJob(
+		"retry-job",
+		"job-id",
+		models.ModelTransferJob{
+			Namespace:                    "kubeflow",
+			JobDisplayName:               "retry",
+			UploadIntent:                 models.ModelTransferJobUploadIntentCreateModel,
+			BandwidthLimitBytesPerSecond: 2097152,
+			ResumedFromJob:               "failed-job",
+			Source:                       models.ModelTransferJobSource{Type: models.ModelTransferJobSourceTypeURI, URI: "https://example.com/model"},
+			Destination:                  models.ModelTransferJobDestination{Type: models.ModelTransferJobDestinationTypeOCI, URI: "quay.io/acme/model:1", Registry: "quay.io"},
+		},
+		"metadata-config",
+		asyncUploadResolvedTrust{},
+		"",
+		"destination-secret",
+		"http://registry.kubeflow.svc:8080",
+		"registry-id",
+		asyncUploadImage{
+			URI:          "example.com/async-upload:latest",
+			Capabilities: asyncUploadCapabilities{Progress: true, Resume: true, BandwidthLimit: true},
+		},
+	)
+
+	env := map[string]string{}
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["MODEL_SYNC_BANDWIDTH_LIMIT_BYTES_PER_SECOND"] != "2097152" {
+		t.Errorf("expected bandwidth limit env, got %q", env["MODEL_SYNC_BANDWIDTH_LIMIT_BYTES_PER_SECOND"])
+	}
+	if env["MODEL_SYNC_PROGRESS_FORMAT"] != "json" || env["MODEL_SYNC_RESUME"] != "true" {
+		t.Errorf("expected progress and resume env, got %v", env)
+	}
+	if job.Annotations[capabilitiesAnnotation] != "progress,resume,bandwidth-limit" {
+		t.Errorf("expected the image capabilities on the job, got %q", job.Annotations[capabilitiesAnnotation])
+	}
+
+	converted := convertK8sJobToModel(job)
+	if converted.BandwidthLimitBytesPerSecond != 2097152 || converted.ResumedFromJob != "failed-job" {
+		t.Errorf("expected bandwidth limit and resume source to round-trip, got %d %q",
+			converted.BandwidthLimitBytesPerSecond, converted.ResumedFromJob)
+	}
+}
+
+func TestGetModelTransferJob_SkipsProgressForImagesWithoutProgress(t *testing.T) {
+	repo := NewModelRegistryRepository()
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-no-progress",
+			Namespace: "kubeflow",
+			Labels: map[string]string{
+				"modelregistry.kubeflow.org/model-registry-name": "mr-1",
+			},
+		},
+		Status: batchv1.JobStatus{Active: 1},
+	}
+	client := &fakeKubernetesClient{
+		jobsByNamespace: map[string]map[string]*batchv1.Job{
+			"kubeflow": {job.Name: job},
+		},
+		podsByNamespace: map[string]*corev1.PodList{
+			"kubeflow": {Items: []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{
+				Name:      "job-no-progress-pod",
+				Namespace: "kubeflow",
+				Labels:    map[string]string{"job-name": job.Name},
+			}}}},
+		},
+		logsByPod: map[string]string{
+			"job-no-progress-pod": `{"type":"progress","bytesTransferred":10,"totalBytes":100}`,
+		},
+	}
+
+	result, err := repo.GetModelTransferJob(context.Background(), client, "kubeflow", job.Name, "mr-1")
+	if err != nil {
+		t.Fatalf("GetModelTransferJob returned error: %v", err)
+	}
+	if result.Progress != nil {
+		t.Errorf("expected no progress for a job image without progress, got %+v", result.Progress)
+	}
+}
+
+func TestBuildK8sJobOmitsTransferControlsTheImageDoesNotSupport(t *testing.T) {
+	job := buildK8sJob(
+		"plain-job",
+		"job-id",
+		models.ModelTransferJob{
+			Namespace:      "kubeflow",
+			JobDisplayName: "plain",
+			UploadIntent:   models.ModelTransferJobUploadIntentCreateModel,
+			Source:         models.ModelTransferJobSource{Type: models.ModelTransferJobSourceTypeURI, URI: "https://example.com/model"},
+			Destination:    models.ModelTransferJobDestination{Type: models.ModelTransferJobDestinationTypeOCI, URI: "quay.io/acme/model:1", Registry: "quay.io"},
+		},
+		"metadata-config",
+		asyncUploadResolvedTrust{},
+		"",
+		"destination-secret",
+		"http://registry.kubeflow.svc:8080",
+		"registry-id",
+		asyncUploadImage{URI: DefaultAsyncUploadImage},
+	)
+
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		switch e.Name {
+		case "MODEL_SYNC_PROGRESS_FORMAT", "MODEL_SYNC_RESUME", "MODEL_SYNC_BANDWIDTH_LIMIT_BYTES_PER_SECOND":
+			t.Errorf("did not expect %s for an image without capabilities", e.Name)
+		}
+	}
+	if _, ok := job.Annotations[capabilitiesAnnotation]; ok {
+		t.Errorf("did not expect a capabilities annotation, got %q", job.Annotations[capabilitiesAnnotation])
+	}
+}
+
+func TestAsyncUploadCapabilitiesRoundTrip(t *testing.T) {
+	capabilities := parseAsyncUploadCapabilities(" resume , unknown,progress")
+	if !capabilities.Progress || !capabilities.Resume || capabilities.BandwidthLimit {
+		t.Fatalf("unexpected capabilities %+v", capabilities)
+	}
+	if got := capabilities.String(); got != "progress,resume" {
+		t.Errorf("expected progress,resume, got %q", got)
+	}
+	if got := parseAsyncUploadCapabilities(capabilities.String()); got != capabilities {
+		t.Errorf("expected %+v to round-trip, got %+v", capabilities, got)
+	}
+	if parseAsyncUploadCapabilities("") != (asyncUploadCapabilities{}) {
+		t.Errorf("expected no capabilities for an empty value")
+	}
+}
+
+func TestValidateBandwidthLimit(t *testing.T) {
+	for _, limit := range []int64{0, minBandwidthLimitBytesPerSecond, 100 * 1024 * 1024} {
+		if err := validateBandwidthLimit(limit); err != nil {
+			t.Errorf("expected %d to be valid, got %v", limit, err)
+		}
+	}
+	for _, limit := range []int64{-1, 1024} {
+		if err := validateBandwidthLimit(limit); err == nil {
+			t.Errorf("expected %d to be rejected", limit)
+		}
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
@@ -27,6 +27,9 @@ const (
 	DefaultAsyncUploadImage  = "ghcr.io/kubeflow/hub/job/async-upload:latest"
 	asyncUploadConfigMapName = "model-registry-ui-config"
 	asyncUploadConfigMapKey  = "images-jobs-async-upload"
+	// asyncUploadCapabilitiesConfigMapKey declares which transfer controls the configured
+	// image implements; see asyncUploadCapabilities.
+	asyncUploadCapabilitiesConfigMapKey = "images-jobs-async-upload-capabilities"
 )
 
 var (
@@ -51,19 +54,18 @@ func recoverEnumFromAnnotation[T ~string](target *T, annotations map[string]stri
 	}
 }
 
+// isK8sJobFailed reports whether the job has given up. A job whose earlier pods failed
+// but which is running another attempt within its backoff limit has not failed yet.
 func isK8sJobFailed(job *batchv1.Job) bool {
 	if job == nil {
 		return false
 	}
-	if job.Status.Failed > 0 {
-		return true
-	}
 	for _, c := range job.Status.Conditions {
 		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
 			return true
 		}
 	}
-	return false
+	return job.Status.Failed > 0 && job.Status.Active == 0
 }
 
 // ErrForbidden indicates the user does not have permission for the requested operation.
@@ -216,6 +218,7 @@ func (m *ModelRegistryRepository) GetModelTransferJob(ctx context.Context, clien
 	}
 
 	result := convertK8sJobToModel(job)
+	attachTransferProgress(ctx, client, job, &result)
 	return &result, nil
 }
 
@@ -276,7 +279,9 @@ func convertK8sEventsToModelEvents(eventList *corev1.EventList) []models.ModelTr
 }
 
 func (m *ModelRegistryRepository) CreateModelTransferJob(ctx context.Context, client k8s.KubernetesClientInterface, namespace string, payload models.ModelTransferJob, modelRegistryID string, isFederatedMode bool, podNamespace string, bundlePaths []string) (*models.ModelTransferJob, error) {
-	return m.createModelTransferJobResources(ctx, client, namespace, payload, modelRegistryID, "", isFederatedMode, podNamespace, bundlePaths)
+	// Only a retry of a failed job resumes; a new job always starts from scratch.
+	payload.ResumedFromJob = ""
+	return m.createModelTransferJobResources(ctx, client, namespace, payload, modelRegistryID, "", isFederatedMode, podNamespace, bundlePaths, nil)
 }
 
 func (m *ModelRegistryRepository) createModelTransferJobResources(
@@ -289,6 +294,7 @@ func (m *ModelRegistryRepository) createModelTransferJobResources(
 	isFederatedMode bool,
 	podNamespace string,
 	bundlePaths []string,
+	resumeFiles []string,
 ) (*models.ModelTransferJob, error) {
 	payload.Source.Bucket = strings.TrimSpace(payload.Source.Bucket)
 	payload.Source.Key = strings.TrimSpace(payload.Source.Key)
@@ -307,6 +313,15 @@ func (m *ModelRegistryRepository) createModelTransferJobResources(
 
 	logger := helper.GetContextLogger(ctx)
 
+	image := resolveAsyncUploadImage(ctx, client, isFederatedMode, podNamespace)
+	if payload.BandwidthLimitBytesPerSecond > 0 && !image.Capabilities.BandwidthLimit {
+		return nil, fmt.Errorf("%w: the async-upload job image %s does not support bandwidthLimitBytesPerSecond", ErrJobValidationFailed, image.URI)
+	}
+	if !image.Capabilities.Resume {
+		payload.ResumedFromJob = ""
+		resumeFiles = nil
+	}
+
 	modelRegistryAddress, err := m.getModelRegistryAddress(ctx, client, namespace, modelRegistryID, isFederatedMode)
 	if err != nil {
 		return nil, err
@@ -318,6 +333,9 @@ func (m *ModelRegistryRepository) createModelTransferJobResources(
 	destSecretName = existingDestSecretName
 
 	configMap := buildModelMetadataConfigMap(jobName+"-metadata-configmap-", payload, jobID, jobName)
+	if len(resumeFiles) > 0 {
+		configMap.Data[transferCompletedFilesKey] = strings.Join(resumeFiles, "\n")
+	}
 	configMapCreated, err := client.CreateConfigMap(ctx, payload.Namespace, configMap)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create metadata configmap: %w", err)
@@ -363,7 +381,6 @@ func (m *ModelRegistryRepository) createModelTransferJobResources(
 		return nil, err
 	}
 
-	imageURI := resolveAsyncUploadImage(ctx, client, isFederatedMode, podNamespace)
 	job := buildK8sJob(
 		jobName,
 		jobID,
@@ -374,7 +391,7 @@ func (m *ModelRegistryRepository) createModelTransferJobResources(
 		destSecretName,
 		modelRegistryAddress,
 		modelRegistryID,
-		imageURI,
+		image,
 	)
 	jobCreated, err := client.CreateModelTransferJob(ctx, payload.Namespace, job)
 	if err != nil {
@@ -509,6 +526,23 @@ func (m *ModelRegistryRepository) UpdateModelTransferJob(
 	if newPayload.JobDisplayName == "" {
 		newPayload.JobDisplayName = oldJobName
 	}
+	if newPayload.BandwidthLimitBytesPerSecond == 0 {
+		newPayload.BandwidthLimitBytesPerSecond = parseBandwidthLimit(oldAnnotations[bandwidthLimitAnnotation])
+	}
+
+	// Resume from the files the failed job already uploaded, as long as the retry
+	// still reads the same source and writes the same destination.
+	newPayload.ResumedFromJob = ""
+	var resumeFiles []string
+	if parseAsyncUploadCapabilities(oldAnnotations[capabilitiesAnnotation]).Resume && sameTransferSource(oldAnnotations, newPayload) {
+		oldPods, err := client.GetTransferJobPods(ctx, newPayload.Namespace, []string{oldJobName})
+		if err != nil {
+			logger.Warn("failed to get pods of old job, retry starts from scratch", "name", oldJobName, "error", err)
+		} else if checkpoint := transferCheckpointFromPods(oldPods.Items); checkpoint != nil {
+			resumeFiles = checkpoint.CompletedFiles
+			newPayload.ResumedFromJob = oldJobName
+		}
+	}
 
 	oldConfigMap, err := client.GetConfigMap(ctx, newPayload.Namespace, oldConfigMapName)
 	if err != nil {
@@ -616,7 +650,7 @@ func (m *ModelRegistryRepository) UpdateModelTransferJob(
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
-	result, err := m.createModelTransferJobResources(ctx, client, namespace, newPayload, modelRegistryID, existingDestSecretName, isFederatedMode, podNamespace, bundlePaths)
+	result, err := m.createModelTransferJobResources(ctx, client, namespace, newPayload, modelRegistryID, existingDestSecretName, isFederatedMode, podNamespace, bundlePaths, resumeFiles)
 	if err != nil {
 		if reuseDestCreds && existingDestSecretName != "" {
 			if delErr := client.DeleteSecret(ctx, newPayload.Namespace, existingDestSecretName); delErr != nil {
@@ -676,27 +710,33 @@ func (m *ModelRegistryRepository) getModelRegistryAddress(ctx context.Context, c
 	return modelRegistry.ServerAddress, nil
 }
 
-func resolveAsyncUploadImage(ctx context.Context, client k8s.KubernetesClientInterface, isFederatedMode bool, podNamespace string) string {
+// resolveAsyncUploadImage returns the async-upload image and the transfer controls it
+// supports. Only the configured image can declare capabilities; the default image has none.
+func resolveAsyncUploadImage(ctx context.Context, client k8s.KubernetesClientInterface, isFederatedMode bool, podNamespace string) asyncUploadImage {
+	defaultImage := asyncUploadImage{URI: DefaultAsyncUploadImage}
 	if !isFederatedMode || podNamespace == "" {
-		return DefaultAsyncUploadImage
+		return defaultImage
 	}
 	logger := helper.GetContextLogger(ctx)
 	cm, err := client.GetConfigMap(ctx, podNamespace, asyncUploadConfigMapName)
 	if err != nil {
 		logger.Info("ConfigMap not found, using default async-upload image",
 			"configmap", asyncUploadConfigMapName, "error", err)
-		return DefaultAsyncUploadImage
+		return defaultImage
 	}
 	if img, ok := cm.Data[asyncUploadConfigMapKey]; ok && strings.TrimSpace(img) != "" {
-		return strings.TrimSpace(img)
+		return asyncUploadImage{
+			URI:          strings.TrimSpace(img),
+			Capabilities: parseAsyncUploadCapabilities(cm.Data[asyncUploadCapabilitiesConfigMapKey]),
+		}
 	}
 	logger.Warn("ConfigMap key not found or empty, using default async-upload image",
 		"configmap", asyncUploadConfigMapName, "key", asyncUploadConfigMapKey)
-	return DefaultAsyncUploadImage
+	return defaultImage
 }
 
 func buildK8sJob(jobName, jobID string, payload models.ModelTransferJob,
-	configMapName string, trustConfig asyncUploadResolvedTrust, sourceSecretName, destSecretName, modelRegistryAddress, modelRegistryID, imageURI string) *batchv1.Job {
+	configMapName string, trustConfig asyncUploadResolvedTrust, sourceSecretName, destSecretName, modelRegistryAddress, modelRegistryID string, image asyncUploadImage) *batchv1.Job {
 
 	backoffLimit := int32(3)
 
@@ -742,6 +782,18 @@ func buildK8sJob(jobName, jobID string, payload models.ModelTransferJob,
 		{Name: "MODEL_SYNC_MODEL_UPLOAD_INTENT", Value: string(payload.UploadIntent)},
 	}
 
+	// Report progress as JSON lines on stdout and skip files already present in the
+	// destination, so a pod retried by the Job controller picks up where the last stopped.
+	if image.Capabilities.Progress {
+		envVars = append(envVars, corev1.EnvVar{Name: "MODEL_SYNC_PROGRESS_FORMAT", Value: "json"})
+	}
+	if image.Capabilities.Resume {
+		envVars = append(envVars, corev1.EnvVar{Name: "MODEL_SYNC_RESUME", Value: "true"})
+	}
+	if payload.BandwidthLimitBytesPerSecond > 0 && image.Capabilities.BandwidthLimit {
+		envVars = append(envVars, corev1.EnvVar{Name: "MODEL_SYNC_BANDWIDTH_LIMIT_BYTES_PER_SECOND", Value: strconv.FormatInt(payload.BandwidthLimitBytesPerSecond, 10)})
+	}
+
 	if payload.UploadIntent == models.ModelTransferJobUploadIntentCreateVersion && payload.RegisteredModelId != "" {
 		envVars = append(envVars, corev1.EnvVar{Name: "MODEL_SYNC_MODEL_ID", Value: payload.RegisteredModelId})
 	}
@@ -769,6 +821,15 @@ func buildK8sJob(jobName, jobID string, payload models.ModelTransferJob,
 		"modelregistry.kubeflow.org/model-version-id":    payload.ModelVersionId,
 		"modelregistry.kubeflow.org/model-artifact-id":   payload.ModelArtifactId,
 	}
+	if capabilities := image.Capabilities.String(); capabilities != "" {
+		annotations[capabilitiesAnnotation] = capabilities
+	}
+	if payload.BandwidthLimitBytesPerSecond > 0 {
+		annotations[bandwidthLimitAnnotation] = strconv.FormatInt(payload.BandwidthLimitBytesPerSecond, 10)
+	}
+	if payload.ResumedFromJob != "" {
+		annotations[resumedFromAnnotation] = payload.ResumedFromJob
+	}
 
 	if trustConfig.modelRegistryCAMount != nil {
 		modelRegistryCAMount := trustConfig.modelRegistryCAMount
@@ -874,7 +935,7 @@ func buildK8sJob(jobName, jobID string, payload models.ModelTransferJob,
 					Containers: []corev1.Container{
 						{
 							Name:            "async-upload",
-							Image:           imageURI,
+							Image:           image.URI,
 							ImagePullPolicy: corev1.PullIfNotPresent,
 							VolumeMounts:    volumeMounts,
 							Env:             envVars,
@@ -912,7 +973,7 @@ func convertK8sJobToModel(job *batchv1.Job) models.ModelTransferJob {
 	status := models.ModelTransferJobStatusPending
 	if job.Status.Succeeded > 0 {
 		status = models.ModelTransferJobStatusCompleted
-	} else if job.Status.Failed > 0 {
+	} else if isK8sJobFailed(job) {
 		status = models.ModelTransferJobStatusFailed
 	} else if job.Status.Active > 0 {
 		status = models.ModelTransferJobStatusRunning
@@ -947,21 +1008,23 @@ func convertK8sJobToModel(job *batchv1.Job) models.ModelTransferJob {
 			URI:      annotations["modelregistry.kubeflow.org/dest-uri"],
 			Registry: annotations["modelregistry.kubeflow.org/dest-registry"],
 		},
-		UploadIntent:             models.ModelTransferJobUploadIntent(annotations["modelregistry.kubeflow.org/upload-intent"]),
-		RegisteredModelName:      annotations["modelregistry.kubeflow.org/model-name"],
-		ModelVersionName:         annotations["modelregistry.kubeflow.org/version-name"],
-		ModelArtifactName:        annotations["modelregistry.kubeflow.org/version-name"],
-		RegisteredModelId:        annotations["modelregistry.kubeflow.org/registered-model-id"],
-		ModelVersionId:           annotations["modelregistry.kubeflow.org/model-version-id"],
-		ModelArtifactId:          annotations["modelregistry.kubeflow.org/model-artifact-id"],
-		Author:                   annotations["modelregistry.kubeflow.org/author"],
-		Status:                   status,
-		ErrorMessage:             errorMessage,
-		CreateTimeSinceEpoch:     fmt.Sprintf("%d", job.CreationTimestamp.UnixMilli()),
-		LastUpdateTimeSinceEpoch: lastUpdateTime,
-		Namespace:                job.Namespace,
-		SourceSecretName:         annotations["modelregistry.kubeflow.org/source-secret"],
-		DestSecretName:           annotations["modelregistry.kubeflow.org/dest-secret"],
+		UploadIntent:                 models.ModelTransferJobUploadIntent(annotations["modelregistry.kubeflow.org/upload-intent"]),
+		RegisteredModelName:          annotations["modelregistry.kubeflow.org/model-name"],
+		ModelVersionName:             annotations["modelregistry.kubeflow.org/version-name"],
+		ModelArtifactName:            annotations["modelregistry.kubeflow.org/version-name"],
+		RegisteredModelId:            annotations["modelregistry.kubeflow.org/registered-model-id"],
+		ModelVersionId:               annotations["modelregistry.kubeflow.org/model-version-id"],
+		ModelArtifactId:              annotations["modelregistry.kubeflow.org/model-artifact-id"],
+		Author:                       annotations["modelregistry.kubeflow.org/author"],
+		Status:                       status,
+		ErrorMessage:                 errorMessage,
+		CreateTimeSinceEpoch:         fmt.Sprintf("%d", job.CreationTimestamp.UnixMilli()),
+		LastUpdateTimeSinceEpoch:     lastUpdateTime,
+		Namespace:                    job.Namespace,
+		SourceSecretName:             annotations["modelregistry.kubeflow.org/source-secret"],
+		DestSecretName:               annotations["modelregistry.kubeflow.org/dest-secret"],
+		BandwidthLimitBytesPerSecond: parseBandwidthLimit(annotations[bandwidthLimitAnnotation]),
+		ResumedFromJob:               annotations[resumedFromAnnotation],
 	}
 }
 
@@ -1151,6 +1214,10 @@ func validateCreatePayload(payload models.ModelTransferJob, skipDestCredsValidat
 		return fmt.Errorf("%w: invalid destination type: %s", ErrJobValidationFailed, payload.Destination.Type)
 	}
 
+	if err := validateBandwidthLimit(payload.BandwidthLimitBytesPerSecond); err != nil {
+		return err
+	}
+
 	if payload.UploadIntent == "" {
 		return fmt.Errorf("%w: upload intent is required", ErrJobValidationFailed)
 	}
@@ -18,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -31,11 +32,16 @@ type fakeKubernetesClient struct {
 	podsByNamespace       map[string]*corev1.PodList
 	jobsByNamespace       map[string]map[string]*batchv1.Job
 	eventsByNamespace     map[string]*corev1.EventList
+	logsByPod             map[string]string
 	configMapsByNamespace map[string]map[string]*corev1.ConfigMap
 	secretsByNamespace    map[string]map[string]*corev1.Secret
 	createdConfigMaps     []*corev1.ConfigMap
 	createConfigMapCalls  int
 	failCreateConfigMapAt int
+	allowedNamespaces     map[string]bool
+	user                  string
+	userGroups            []string
+	catalogSources        corev1.ConfigMap
 }
 
 // testContext returns a context with a RequestIdentity set, as required by GetAllModelTransferJobs.
@@ -139,7 +145,7 @@ func (f *fakeKubernetesClient) GetNamespaces(ctx context.Context, identity *k8s.
 }
 
 func (f *fakeKubernetesClient) CanListServicesInNamespace(ctx context.Context, identity *k8s.RequestIdentity, namespace string) (bool, error) {
-	return false, nil
+	return f.allowedNamespaces[namespace], nil
 }
 
 func (f *fakeKubernetesClient) CanAccessServiceInNamespace(ctx context.Context, identity *k8s.RequestIdentity, namespace, serviceName string) (bool, error) {
@@ -154,6 +160,10 @@ func (f *fakeKubernetesClient) CanVerbMcpServersInNamespace(ctx context.Context,
 	return false, nil
 }
 
+func (f *fakeKubernetesClient) CanVerbInferenceServicesInNamespace(ctx context.Context, identity *k8s.RequestIdentity, namespace, verb string) (bool, error) {
+	return false, nil
+}
+
 func (f *fakeKubernetesClient) GetSelfSubjectRulesReview(ctx context.Context, identity *k8s.RequestIdentity, namespace string) ([]string, error) {
 	return nil, nil
 }
@@ -167,7 +177,11 @@ func (f *fakeKubernetesClient) BearerToken() (string, error) {
 }
 
 func (f *fakeKubernetesClient) GetUser(identity *k8s.RequestIdentity) (string, error) {
-	return "", nil
+	return f.user, nil
+}
+
+func (f *fakeKubernetesClient) GetUserGroups(identity *k8s.RequestIdentity) ([]string, error) {
+	return f.userGroups, nil
 }
 
 func (f *fakeKubernetesClient) GetGroups(ctx context.Context) ([]string, error) {
@@ -175,7 +189,7 @@ func (f *fakeKubernetesClient) GetGroups(ctx context.Context) ([]string, error)
 }
 
 func (f *fakeKubernetesClient) GetAllCatalogSourceConfigs(ctx context.Context, namespace string) (corev1.ConfigMap, corev1.ConfigMap, error) {
-	return corev1.ConfigMap{}, corev1.ConfigMap{}, nil
+	return corev1.ConfigMap{}, f.catalogSources, nil
 }
 
 func (f *fakeKubernetesClient) UpdateCatalogSourceConfig(ctx context.Context, namespace string, configMap *corev1.ConfigMap) error {
@@ -214,6 +228,14 @@ func (f *fakeKubernetesClient) CreateModelTransferJob(ctx context.Context, names
 	return job, nil
 }
 
+func (f *fakeKubernetesClient) GetTransferJobPodLogs(ctx context.Context, namespace string, podName string, tailLines int64) (string, error) {
+	logs, ok := f.logsByPod[podName]
+	if !ok {
+		return "", fmt.Errorf("pod %s has no logs", podName)
+	}
+	return logs, nil
+}
+
 func (f *fakeKubernetesClient) GetEventsForPods(ctx context.Context, namespace string, podNames []string) (*corev1.EventList, error) {
 	if f.eventsByNamespace == nil {
 		return &corev1.EventList{}, nil
@@ -301,6 +323,34 @@ func (f *fakeKubernetesClient) CanListJobsClusterWide(ctx context.Context, ident
 	return true, nil
 }
 
+func (f *fakeKubernetesClient) ListConfigMaps(ctx context.Context, namespace string, labelSelector string) (*corev1.ConfigMapList, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	list := &corev1.ConfigMapList{}
+	for _, configMap := range f.configMapsByNamespace[namespace] {
+		if selector.Matches(labels.Set(configMap.Labels)) {
+			list.Items = append(list.Items, *configMap.DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeKubernetesClient) UpdateConfigMap(ctx context.Context, namespace string, configMap *corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	existing, ok := f.configMapsByNamespace[namespace][configMap.Name]
+	if !ok {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, configMap.Name)
+	}
+	if configMap.ResourceVersion != "" && configMap.ResourceVersion != existing.ResourceVersion {
+		return nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, configMap.Name, fmt.Errorf("resource version changed"))
+	}
+	updated := configMap.DeepCopy()
+	updated.ResourceVersion = existing.ResourceVersion + "1"
+	f.configMapsByNamespace[namespace][configMap.Name] = updated
+	return updated, nil
+}
+
 func TestGetAllModelTransferJobs_PodWaitingFailuresOverrideStatusToFailed(t *testing.T) {
 	repo := NewModelRegistryRepository()
 
@@ -806,7 +856,7 @@ func TestBuildK8sJobMountsTrustedCAForRegistry(t *testing.T) {
 		"destination-secret",
 		"https://my-registry-rest.apps.example.com/api/model_registry/v1alpha3",
 		"registry-id",
-		"example.com/async-upload:latest",
+		asyncUploadImage{URI: "example.com/async-upload:latest"},
 	)
 
 	container := job.Spec.Template.Spec.Containers[0]
@@ -928,7 +978,7 @@ func TestBuildK8sJobSkipsTrustedCAForInsecureRegistry(t *testing.T) {
 		"destination-secret",
 		"http://my-registry-rest.apps.example.com/api/model_registry/v1alpha3",
 		"registry-id",
-		"example.com/async-upload:latest",
+		asyncUploadImage{URI: "example.com/async-upload:latest"},
 	)
 
 	container := job.Spec.Template.Spec.Containers[0]
@@ -1152,21 +1202,21 @@ var _ = Describe("resolveAsyncUploadImage", func() {
 	Context("when not in federated mode", func() {
 		It("should return the default image", func() {
 			img := resolveAsyncUploadImage(mockCtx, client, false, "")
-			Expect(img).To(Equal(DefaultAsyncUploadImage))
+			Expect(img).To(Equal(asyncUploadImage{URI: DefaultAsyncUploadImage}))
 		})
 	})
 
 	Context("when in federated mode with empty namespace", func() {
 		It("should return the default image", func() {
 			img := resolveAsyncUploadImage(mockCtx, client, true, "")
-			Expect(img).To(Equal(DefaultAsyncUploadImage))
+			Expect(img).To(Equal(asyncUploadImage{URI: DefaultAsyncUploadImage}))
 		})
 	})
 
 	Context("when in federated mode with ConfigMap missing", func() {
 		It("should fall back to the default image", func() {
 			img := resolveAsyncUploadImage(mockCtx, client, true, "bento-namespace")
-			Expect(img).To(Equal(DefaultAsyncUploadImage))
+			Expect(img).To(Equal(asyncUploadImage{URI: DefaultAsyncUploadImage}))
 		})
 	})
 
@@ -1191,7 +1241,45 @@ var _ = Describe("resolveAsyncUploadImage", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			img := resolveAsyncUploadImage(mockCtx, client, true, testNamespace)
-			Expect(img).To(Equal("registry.example.com/custom-image:v1"))
+			Expect(img).To(Equal(asyncUploadImage{URI: "registry.example.com/custom-image:v1"}))
+		})
+
+		It("should return the capabilities declared for the configured image", func() {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      asyncUploadConfigMapName,
+					Namespace: testNamespace,
+				},
+				Data: map[string]string{
+					asyncUploadConfigMapKey:             "registry.example.com/custom-image:v2",
+					asyncUploadCapabilitiesConfigMapKey: "progress, resume,bandwidth-limit",
+				},
+			}
+			_, err := client.CreateConfigMap(mockCtx, testNamespace, cm)
+			Expect(err).NotTo(HaveOccurred())
+
+			img := resolveAsyncUploadImage(mockCtx, client, true, testNamespace)
+			Expect(img).To(Equal(asyncUploadImage{
+				URI:          "registry.example.com/custom-image:v2",
+				Capabilities: asyncUploadCapabilities{Progress: true, Resume: true, BandwidthLimit: true},
+			}))
+		})
+
+		It("should ignore declared capabilities when no image is configured", func() {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      asyncUploadConfigMapName,
+					Namespace: testNamespace,
+				},
+				Data: map[string]string{
+					asyncUploadCapabilitiesConfigMapKey: "progress,resume,bandwidth-limit",
+				},
+			}
+			_, err := client.CreateConfigMap(mockCtx, testNamespace, cm)
+			Expect(err).NotTo(HaveOccurred())
+
+			img := resolveAsyncUploadImage(mockCtx, client, true, testNamespace)
+			Expect(img).To(Equal(asyncUploadImage{URI: DefaultAsyncUploadImage}))
 		})
 
 		It("should fall back to the default image when the key is missing", func() {
@@ -1208,7 +1296,7 @@ var _ = Describe("resolveAsyncUploadImage", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			img := resolveAsyncUploadImage(mockCtx, client, true, testNamespace)
-			Expect(img).To(Equal(DefaultAsyncUploadImage))
+			Expect(img).To(Equal(asyncUploadImage{URI: DefaultAsyncUploadImage}))
 		})
 
 		It("should fall back to the default image when the key is empty", func() {
@@ -1225,7 +1313,7 @@ var _ = Describe("resolveAsyncUploadImage", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			img := resolveAsyncUploadImage(mockCtx, client, true, testNamespace)
-			Expect(img).To(Equal(DefaultAsyncUploadImage))
+			Expect(img).To(Equal(asyncUploadImage{URI: DefaultAsyncUploadImage}))
 		})
 
 		It("should fall back to the default image when the key is whitespace-only", func() {
@@ -1242,7 +1330,7 @@ var _ = Describe("resolveAsyncUploadImage", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			img := resolveAsyncUploadImage(mockCtx, client, true, testNamespace)
-			Expect(img).To(Equal(DefaultAsyncUploadImage))
+			Expect(img).To(Equal(asyncUploadImage{URI: DefaultAsyncUploadImage}))
 		})
 	})
 })
@@ -4,25 +4,33 @@ package repositories
 type Repositories struct {
 	HealthCheck                    *HealthCheckRepository
 	ModelRegistry                  *ModelRegistryRepository
+	ModelLineage                   *ModelLineageRepository
+	ModelPromotion                 *ModelPromotionRepository
 	ModelCatalog                   *ModelCatalogRepository
 	ModelRegistrySettings          *ModelRegistrySettingsRepository
 	ModelRegistryClient            ModelRegistryClientInterface
 	ModelCatalogClient             ModelCatalogClientInterface
 	ModelCatalogSettingsRepository *ModelCatalogSettingsRepository
+	ModelCatalogSourceSync         *ModelCatalogSourceSyncRepository
 	McpCatalogSettingsRepository   *McpCatalogSettingsRepository
 	User                           *UserRepository
 	Namespace                      *NamespaceRepository
 }
 
 func NewRepositories(modelRegistryClient ModelRegistryClientInterface, modelCatalogClient ModelCatalogClientInterface) *Repositories {
+	modelRegistry := NewModelRegistryRepository()
+	modelCatalogSettings := NewModelCatalogSettingsRepository()
 	return &Repositories{
 		HealthCheck:                    NewHealthCheckRepository(),
-		ModelRegistry:                  NewModelRegistryRepository(),
+		ModelRegistry:                  modelRegistry,
+		ModelLineage:                   NewModelLineageRepository(modelRegistryClient, modelRegistry),
+		ModelPromotion:                 NewModelPromotionRepository(modelRegistryClient, modelCatalogClient),
 		ModelCatalog:                   NewCatalogRepository(),
 		ModelCatalogClient:             modelCatalogClient,
 		ModelRegistrySettings:          NewModelRegistrySettingsRepository(),
 		ModelRegistryClient:            modelRegistryClient,
-		ModelCatalogSettingsRepository: NewModelCatalogSettingsRepository(),
+		ModelCatalogSettingsRepository: modelCatalogSettings,
+		ModelCatalogSourceSync:         NewModelCatalogSourceSyncRepository(modelCatalogClient, modelCatalogSettings),
 		McpCatalogSettingsRepository:   NewMcpCatalogSettingsRepository(),
 		User:                           NewUserRepository(),
 		Namespace:                      NewNamespaceRepository(),
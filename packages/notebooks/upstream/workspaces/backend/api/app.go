@@ -100,6 +100,18 @@ func (a *App) Routes() http.Handler {
 	router.PUT(constants.WorkspacesByNamePath, a.UpdateWorkspaceHandler)
 	router.DELETE(constants.WorkspacesByNamePath, a.DeleteWorkspaceHandler)
 	router.POST(constants.PauseWorkspacePath, a.PauseActionWorkspaceHandler)
+	router.POST(constants.SnapshotWorkspacePath, a.SnapshotActionWorkspaceHandler)
+	router.POST(constants.DeferUpgradeWorkspacePath, a.DeferUpgradeActionWorkspaceHandler)
+	router.GET(constants.WorkspaceMetricsPath, a.GetWorkspaceMetricsHandler)
+	router.GET(constants.WorkspaceSharesPath, a.GetWorkspaceSharesHandler)
+	router.POST(constants.WorkspaceSharesPath, a.CreateWorkspaceShareHandler)
+	router.DELETE(constants.WorkspaceSharesByNamePath, a.DeleteWorkspaceShareHandler)
+
+	// workspacesnapshots
+	router.GET(constants.WorkspaceSnapshotsByNamespacePath, a.GetWorkspaceSnapshotsHandler)
+	router.GET(constants.WorkspaceSnapshotsByNamePath, a.GetWorkspaceSnapshotHandler)
+	router.DELETE(constants.WorkspaceSnapshotsByNamePath, a.DeleteWorkspaceSnapshotHandler)
+	router.POST(constants.CloneWorkspaceSnapshotPath, a.CloneWorkspaceSnapshotHandler)
 
 	// workspacekinds
 	router.GET(constants.AllWorkspaceKindsPath, a.GetWorkspaceKindsHandler)
@@ -110,6 +122,13 @@ func (a *App) Routes() http.Handler {
 	router.POST(constants.PodTemplateOptionsListValuesPath, a.PodTemplateOptionsListValuesHandler)
 	router.GET(constants.WorkspaceKindIconPath, a.GetWorkspaceKindIconHandler)
 	router.GET(constants.WorkspaceKindLogoPath, a.GetWorkspaceKindLogoHandler)
+	router.GET(constants.WorkspaceKindPendingUpgradesPath, a.GetPendingUpgradesHandler)
+
+	// upgradecampaigns
+	router.GET(constants.AllUpgradeCampaignsPath, a.GetUpgradeCampaignsHandler)
+	router.GET(constants.UpgradeCampaignsByNamePath, a.GetUpgradeCampaignHandler)
+	router.POST(constants.AllUpgradeCampaignsPath, a.CreateUpgradeCampaignHandler)
+	router.DELETE(constants.UpgradeCampaignsByNamePath, a.DeleteUpgradeCampaignHandler)
 
 	// storageclasses
 	router.GET(constants.AllStorageClassesPath, a.GetStorageClassesHandler)
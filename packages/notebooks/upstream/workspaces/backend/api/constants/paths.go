@@ -19,6 +19,7 @@ const (
 
 	NamespacePathParam    = "namespace"
 	ResourceNamePathParam = "name"
+	SharePathParam        = "share"
 
 	// healthcheck
 	HealthCheckPath = PathPrefix + "/healthcheck"
@@ -32,6 +33,16 @@ const (
 	WorkspacesByNamePath      = AllWorkspacesPath + "/:" + NamespacePathParam + "/:" + ResourceNamePathParam
 	WorkspaceActionsPath      = WorkspacesByNamePath + "/actions"
 	PauseWorkspacePath        = WorkspaceActionsPath + "/pause"
+	SnapshotWorkspacePath     = WorkspaceActionsPath + "/snapshot"
+	DeferUpgradeWorkspacePath = WorkspaceActionsPath + "/deferupgrade"
+	WorkspaceMetricsPath      = WorkspacesByNamePath + "/metrics"
+	WorkspaceSharesPath       = WorkspacesByNamePath + "/shares"
+	WorkspaceSharesByNamePath = WorkspaceSharesPath + "/:" + SharePathParam
+
+	// workspacesnapshots
+	WorkspaceSnapshotsByNamespacePath = PathPrefix + "/workspacesnapshots/:" + NamespacePathParam
+	WorkspaceSnapshotsByNamePath      = WorkspaceSnapshotsByNamespacePath + "/:" + ResourceNamePathParam
+	CloneWorkspaceSnapshotPath        = WorkspaceSnapshotsByNamePath + "/actions/clone"
 
 	// workspacekinds
 	AllWorkspaceKindsPath            = PathPrefix + "/workspacekinds"
@@ -40,6 +51,11 @@ const (
 	WorkspaceKindsAssetsPath         = WorkspaceKindsByNamePath + "/assets"
 	WorkspaceKindIconPath            = WorkspaceKindsAssetsPath + "/icon"
 	WorkspaceKindLogoPath            = WorkspaceKindsAssetsPath + "/logo"
+	WorkspaceKindPendingUpgradesPath = WorkspaceKindsByNamePath + "/pendingupgrades"
+
+	// upgradecampaigns
+	AllUpgradeCampaignsPath    = PathPrefix + "/upgradecampaigns"
+	UpgradeCampaignsByNamePath = AllUpgradeCampaignsPath + "/:" + ResourceNamePathParam
 
 	// namespaces
 	AllNamespacesPath = PathPrefix + "/namespaces"
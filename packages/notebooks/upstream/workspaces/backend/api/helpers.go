@@ -138,12 +138,32 @@ func (a *App) LocationGetWorkspace(namespace, name string) string {
 	return path
 }
 
+// LocationGetWorkspaceShares returns the GET location (HTTP path) for the shares of a workspace.
+func (a *App) LocationGetWorkspaceShares(namespace, name string) string {
+	path := strings.Replace(constants.WorkspaceSharesPath, ":"+constants.NamespacePathParam, namespace, 1)
+	path = strings.Replace(path, ":"+constants.ResourceNamePathParam, name, 1)
+	return path
+}
+
+// LocationGetWorkspaceSnapshot returns the GET location (HTTP path) for a workspace snapshot resource.
+func (a *App) LocationGetWorkspaceSnapshot(namespace, name string) string {
+	path := strings.Replace(constants.WorkspaceSnapshotsByNamePath, ":"+constants.NamespacePathParam, namespace, 1)
+	path = strings.Replace(path, ":"+constants.ResourceNamePathParam, name, 1)
+	return path
+}
+
 // LocationGetWorkspaceKind returns the GET location (HTTP path) for a workspace kind resource.
 func (a *App) LocationGetWorkspaceKind(name string) string {
 	path := strings.Replace(constants.WorkspaceKindsByNamePath, ":"+constants.ResourceNamePathParam, name, 1)
 	return path
 }
 
+// LocationGetUpgradeCampaign returns the GET location (HTTP path) for an upgrade campaign resource.
+func (a *App) LocationGetUpgradeCampaign(name string) string {
+	path := strings.Replace(constants.UpgradeCampaignsByNamePath, ":"+constants.ResourceNamePathParam, name, 1)
+	return path
+}
+
 // LocationGetSecret returns the GET location (HTTP path) for a secret resource.
 func (a *App) LocationGetSecret(namespace, name string) string {
 	path := strings.Replace(constants.SecretsByNamePath, ":"+constants.NamespacePathParam, namespace, 1)
@@ -87,6 +87,7 @@ var _ = BeforeSuite(func() {
 	testEnv = &envtest.Environment{
 		CRDDirectoryPaths: []string{
 			filepath.Join("..", "..", "controller", "manifests", "kustomize", "base", "crd"),
+			filepath.Join("testdata", "crd"),
 		},
 		ErrorIfCRDPathMissing: true,
 
@@ -0,0 +1,366 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/api/constants"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/auth"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/helper"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/upgradecampaigns"
+	repository "github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/upgradecampaigns"
+)
+
+type UpgradeCampaignEnvelope Envelope[*models.UpgradeCampaign]
+type UpgradeCampaignListEnvelope Envelope[[]models.UpgradeCampaign]
+type UpgradeCampaignCreateEnvelope Envelope[*models.UpgradeCampaignCreate]
+type PendingUpgradeListEnvelope Envelope[[]models.PendingUpgrade]
+type UpgradeDeferralEnvelope Envelope[*models.UpgradeDeferral]
+
+// GetUpgradeCampaignsHandler returns a list of all upgrade campaigns in the cluster.
+//
+//	@Summary		List upgrade campaigns
+//	@Description	Returns a list of all upgrade campaigns in the cluster, including the upgrade state of each affected workspace.
+//	@Tags			upgradecampaigns
+//	@ID				listUpgradeCampaigns
+//	@Produce		application/json
+//	@Success		200	{object}	UpgradeCampaignListEnvelope	"Successful operation. Returns a list of all upgrade campaigns."
+//	@Failure		401	{object}	ErrorEnvelope				"Unauthorized. Authentication is required."
+//	@Failure		403	{object}	ErrorEnvelope				"Forbidden. User does not have permission to list upgrade campaigns."
+//	@Failure		500	{object}	ErrorEnvelope				"Internal server error. An unexpected error occurred on the server."
+//	@Router			/upgradecampaigns [get]
+func (a *App) GetUpgradeCampaignsHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbList, auth.WorkspaceUpgradeCampaigns, auth.ResourcePolicyResourceMeta{}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	campaigns, err := a.repositories.UpgradeCampaign.GetUpgradeCampaigns(r.Context())
+	if err != nil {
+		a.serverErrorResponse(w, r, err)
+		return
+	}
+
+	responseEnvelope := &UpgradeCampaignListEnvelope{Data: campaigns}
+	a.dataResponse(w, r, responseEnvelope)
+}
+
+// GetUpgradeCampaignHandler retrieves a specific upgrade campaign by name.
+//
+//	@Summary		Get upgrade campaign
+//	@Description	Returns details of a specific upgrade campaign identified by its name, including the upgrade state of each affected workspace.
+//	@Tags			upgradecampaigns
+//	@ID				getUpgradeCampaign
+//	@Produce		application/json
+//	@Param			name	path		string					true	"Name of the upgrade campaign"	extensions(x-example=jupyterlab-upgrade)
+//	@Success		200		{object}	UpgradeCampaignEnvelope	"Successful operation. Returns the requested upgrade campaign."
+//	@Failure		401		{object}	ErrorEnvelope			"Unauthorized. Authentication is required."
+//	@Failure		403		{object}	ErrorEnvelope			"Forbidden. User does not have permission to access the upgrade campaign."
+//	@Failure		404		{object}	ErrorEnvelope			"Not Found. Upgrade campaign does not exist."
+//	@Failure		422		{object}	ErrorEnvelope			"Unprocessable Entity. Validation error."
+//	@Failure		500		{object}	ErrorEnvelope			"Internal server error. An unexpected error occurred on the server."
+//	@Router			/upgradecampaigns/{name} [get]
+func (a *App) GetUpgradeCampaignHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	name := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateFieldIsDNS1123Subdomain(field.NewPath(constants.ResourceNamePathParam), name)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbGet, auth.WorkspaceUpgradeCampaigns, auth.ResourcePolicyResourceMeta{Name: name}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	campaign, err := a.repositories.UpgradeCampaign.GetUpgradeCampaign(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, repository.ErrUpgradeCampaignNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		a.serverErrorResponse(w, r, err)
+		return
+	}
+
+	responseEnvelope := &UpgradeCampaignEnvelope{Data: campaign}
+	a.dataResponse(w, r, responseEnvelope)
+}
+
+// CreateUpgradeCampaignHandler starts a new upgrade campaign.
+//
+//	@Summary		Create upgrade campaign
+//	@Description	Starts a campaign which restarts the workspaces of a workspace kind that have a pending redirect, in batches, while they are idle. Each workspace kind can only have one campaign.
+//	@Tags			upgradecampaigns
+//	@ID				createUpgradeCampaign
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		UpgradeCampaignCreateEnvelope	true	"Upgrade campaign configuration"
+//	@Success		201		{object}	UpgradeCampaignEnvelope			"Upgrade campaign created successfully"
+//	@Failure		400		{object}	ErrorEnvelope					"Bad Request."
+//	@Failure		401		{object}	ErrorEnvelope					"Unauthorized. Authentication is required."
+//	@Failure		403		{object}	ErrorEnvelope					"Forbidden. User does not have permission to create upgrade campaigns."
+//	@Failure		409		{object}	ErrorEnvelope					"Conflict. Upgrade campaign with the same name, or for the same workspace kind, already exists."
+//	@Failure		413		{object}	ErrorEnvelope					"Request Entity Too Large. The request body is too large."
+//	@Failure		415		{object}	ErrorEnvelope					"Unsupported Media Type. Content-Type header is not correct."
+//	@Failure		422		{object}	ErrorEnvelope					"Unprocessable Entity. Validation error."
+//	@Failure		500		{object}	ErrorEnvelope					"Internal server error. An unexpected error occurred on the server."
+//	@Router			/upgradecampaigns [post]
+func (a *App) CreateUpgradeCampaignHandler(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	// validate the Content-Type header
+	if success := a.ValidateContentType(w, r, constants.MediaTypeJson); !success {
+		return
+	}
+
+	// decode the request body
+	bodyEnvelope := &UpgradeCampaignCreateEnvelope{}
+	err := a.DecodeJSON(r, bodyEnvelope)
+	if err != nil {
+		if a.IsMaxBytesError(err) {
+			a.requestEntityTooLargeResponse(w, r, err)
+			return
+		}
+		a.badRequestResponse(w, r, fmt.Errorf("error decoding request body: %w", err))
+		return
+	}
+
+	// validate the request body
+	dataPath := field.NewPath("data")
+	if bodyEnvelope.Data == nil {
+		valErrs := field.ErrorList{field.Required(dataPath, "data is required")}
+		a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+		return
+	}
+	valErrs := bodyEnvelope.Data.Validate(dataPath)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+		return
+	}
+
+	// give the request data a clear name
+	campaignCreate := bodyEnvelope.Data
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbCreate, auth.WorkspaceUpgradeCampaigns, auth.ResourcePolicyResourceMeta{Name: campaignCreate.Name}),
+	}
+	actor, ok := a.requireAuth(w, r, authPolicies)
+	if !ok {
+		return
+	}
+	// ============================================================
+
+	createdCampaign, err := a.repositories.UpgradeCampaign.CreateUpgradeCampaign(r.Context(), actor, campaignCreate)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceKindNotFound) {
+			valErrs = field.ErrorList{field.NotFound(dataPath.Child("workspaceKind"), campaignCreate.WorkspaceKind)}
+			a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+			return
+		}
+		if errors.Is(err, repository.ErrUpgradeCampaignAlreadyExists) || errors.Is(err, repository.ErrWorkspaceKindHasCampaign) {
+			causes := helper.StatusCausesFromAPIStatus(err)
+			a.conflictResponse(w, r, err, causes)
+			return
+		}
+		if apierrors.IsInvalid(err) {
+			causes := helper.StatusCausesFromAPIStatus(err)
+			a.failedValidationResponse(w, r, errMsgKubernetesValidation, nil, causes)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error creating upgrade campaign: %w", err))
+		return
+	}
+
+	// calculate the GET location for the created campaign (for the Location header)
+	location := a.LocationGetUpgradeCampaign(createdCampaign.Name)
+
+	responseEnvelope := &UpgradeCampaignEnvelope{Data: createdCampaign}
+	a.createdResponse(w, r, responseEnvelope, location)
+}
+
+// DeleteUpgradeCampaignHandler deletes a specific upgrade campaign by name.
+//
+//	@Summary		Delete upgrade campaign
+//	@Description	Stops and deletes a specific upgrade campaign identified by its name. Workspaces which are already restarting are not affected.
+//	@Tags			upgradecampaigns
+//	@ID				deleteUpgradeCampaign
+//	@Param			name	path		string			true	"Name of the upgrade campaign"	extensions(x-example=jupyterlab-upgrade)
+//	@Success		204		{object}	nil				"Upgrade campaign deleted successfully"
+//	@Failure		401		{object}	ErrorEnvelope	"Unauthorized. Authentication is required."
+//	@Failure		403		{object}	ErrorEnvelope	"Forbidden. User does not have permission to delete the upgrade campaign."
+//	@Failure		404		{object}	ErrorEnvelope	"Not Found. Upgrade campaign does not exist."
+//	@Failure		422		{object}	ErrorEnvelope	"Unprocessable Entity. Validation error."
+//	@Failure		500		{object}	ErrorEnvelope	"Internal server error. An unexpected error occurred on the server."
+//	@Router			/upgradecampaigns/{name} [delete]
+func (a *App) DeleteUpgradeCampaignHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	name := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateFieldIsDNS1123Subdomain(field.NewPath(constants.ResourceNamePathParam), name)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbDelete, auth.WorkspaceUpgradeCampaigns, auth.ResourcePolicyResourceMeta{Name: name}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	err := a.repositories.UpgradeCampaign.DeleteUpgradeCampaign(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, repository.ErrUpgradeCampaignNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error deleting upgrade campaign: %w", err))
+		return
+	}
+
+	a.deletedResponse(w, r)
+}
+
+// GetPendingUpgradesHandler returns the workspaces of a workspace kind which have a pending redirect.
+//
+//	@Summary		List pending upgrades of a workspace kind
+//	@Description	Returns the workspaces of a workspace kind which would be restarted by an upgrade campaign, because one of their podTemplate options has a redirect.
+//	@Tags			workspacekinds
+//	@ID				listWorkspaceKindPendingUpgrades
+//	@Produce		application/json
+//	@Param			name	path		string						true	"Name of the workspace kind"	extensions(x-example=jupyterlab)
+//	@Success		200		{object}	PendingUpgradeListEnvelope	"Successful operation. Returns the workspaces with a pending upgrade."
+//	@Failure		401		{object}	ErrorEnvelope				"Unauthorized. Authentication is required."
+//	@Failure		403		{object}	ErrorEnvelope				"Forbidden. User does not have permission to list workspaces."
+//	@Failure		404		{object}	ErrorEnvelope				"Not Found. Workspace kind does not exist."
+//	@Failure		422		{object}	ErrorEnvelope				"Unprocessable Entity. Validation error."
+//	@Failure		500		{object}	ErrorEnvelope				"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspacekinds/{name}/pendingupgrades [get]
+func (a *App) GetPendingUpgradesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	name := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateWorkspaceKindName(field.NewPath(constants.ResourceNamePathParam), name)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbGet, auth.WorkspaceKinds, auth.ResourcePolicyResourceMeta{Name: name}),
+		auth.NewResourcePolicy(auth.VerbList, auth.Workspaces, auth.ResourcePolicyResourceMeta{}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	pendingUpgrades, err := a.repositories.UpgradeCampaign.GetPendingUpgrades(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceKindNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		a.serverErrorResponse(w, r, err)
+		return
+	}
+
+	responseEnvelope := &PendingUpgradeListEnvelope{Data: pendingUpgrades}
+	a.dataResponse(w, r, responseEnvelope)
+}
+
+// DeferUpgradeActionWorkspaceHandler defers the upgrade of a workspace by the campaign of its workspace kind.
+//
+//	@Summary		Defer the upgrade of a workspace
+//	@Description	Postpones the restart of a workspace by the upgrade campaign of its workspace kind. Each workspace can only be deferred once per campaign.
+//	@Tags			workspaces
+//	@ID				deferWorkspaceUpgrade
+//	@Produce		json
+//	@Param			namespace	path		string					true	"Namespace of the workspace"	extensions(x-example=default)
+//	@Param			name		path		string					true	"Name of the workspace"			extensions(x-example=my-workspace)
+//	@Success		200			{object}	UpgradeDeferralEnvelope	"Successful action. Returns the deferred campaign."
+//	@Failure		401			{object}	ErrorEnvelope			"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope			"Forbidden. User does not have permission to update the workspace."
+//	@Failure		404			{object}	ErrorEnvelope			"Not Found. Workspace does not exist, or has no pending upgrade."
+//	@Failure		409			{object}	ErrorEnvelope			"Conflict. Workspace upgrade was already deferred."
+//	@Failure		422			{object}	ErrorEnvelope			"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope			"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspaces/{namespace}/{name}/actions/deferupgrade [post]
+func (a *App) DeferUpgradeActionWorkspaceHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	workspaceName := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateWorkspaceName(field.NewPath(constants.ResourceNamePathParam), workspaceName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbUpdate, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: workspaceName}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	deferral, err := a.repositories.UpgradeCampaign.DeferWorkspaceUpgrade(r.Context(), namespace, workspaceName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) || errors.Is(err, repository.ErrWorkspaceUpgradeNotPending) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		if errors.Is(err, repository.ErrWorkspaceUpgradeDeferred) {
+			causes := helper.StatusCausesFromAPIStatus(err)
+			a.conflictResponse(w, r, err, causes)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error deferring workspace upgrade: %w", err))
+		return
+	}
+
+	responseEnvelope := &UpgradeDeferralEnvelope{Data: deferral}
+	a.dataResponse(w, r, responseEnvelope)
+}
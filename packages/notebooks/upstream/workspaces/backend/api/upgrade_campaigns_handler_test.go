@@ -0,0 +1,502 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/api/constants"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/upgradecampaigns"
+)
+
+var _ = Describe("Upgrade Campaigns Handler", func() {
+
+	// NOTE: the tests in this context work on the same resources, they must be run in order.
+	//       also, they assume a specific state of the cluster, so cannot be run in parallel with other tests.
+	//       therefore, we run them using the `Ordered` and `Serial` Ginkgo decorators.
+	Context("with an existing WorkspaceKind", Serial, Ordered, func() {
+
+		const (
+			namespaceName1 = "wsuc-test-ns1"
+			nonAdminUser   = "non-admin-user"
+		)
+
+		var (
+			workspaceKindName string
+			campaignName1     string
+			campaignName2     string
+
+			// workspacePendingName is a Workspace with a pending redirect
+			workspacePendingName string
+			workspacePendingKey  types.NamespacedName
+
+			// workspaceCurrentName is a Workspace without a pending redirect
+			workspaceCurrentName string
+		)
+
+		// doCreateCampaignRequest calls CreateUpgradeCampaignHandler.
+		doCreateCampaignRequest := func(user string, campaignCreate *models.UpgradeCampaignCreate) *httptest.ResponseRecorder {
+			requestBody := &UpgradeCampaignCreateEnvelope{
+				Data: campaignCreate,
+			}
+			bodyBytes, err := json.Marshal(requestBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest(http.MethodPost, constants.AllUpgradeCampaignsPath, strings.NewReader(string(bodyBytes)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			a.CreateUpgradeCampaignHandler(rr, req, httprouter.Params{})
+			return rr
+		}
+
+		// doCampaignByNameRequest calls a handler which takes the name of an upgrade campaign.
+		doCampaignByNameRequest := func(handler httprouter.Handle, method string, user string, campaignName string) *httptest.ResponseRecorder {
+			path := strings.Replace(constants.UpgradeCampaignsByNamePath, ":"+constants.ResourceNamePathParam, campaignName, 1)
+			req, err := http.NewRequest(method, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: campaignName},
+			}
+			rr := httptest.NewRecorder()
+			handler(rr, req, ps)
+			return rr
+		}
+
+		// doDeferUpgradeRequest calls DeferUpgradeActionWorkspaceHandler for a workspace.
+		doDeferUpgradeRequest := func(user string, workspaceName string) *httptest.ResponseRecorder {
+			path := strings.Replace(constants.DeferUpgradeWorkspacePath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			path = strings.Replace(path, ":"+constants.ResourceNamePathParam, workspaceName, 1)
+			req, err := http.NewRequest(http.MethodPost, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: workspaceName},
+			}
+			rr := httptest.NewRecorder()
+			a.DeferUpgradeActionWorkspaceHandler(rr, req, ps)
+			return rr
+		}
+
+		// doPendingUpgradesRequest calls GetPendingUpgradesHandler for a workspace kind.
+		doPendingUpgradesRequest := func(user string, workspaceKindName string) *httptest.ResponseRecorder {
+			path := strings.Replace(constants.WorkspaceKindPendingUpgradesPath, ":"+constants.ResourceNamePathParam, workspaceKindName, 1)
+			req, err := http.NewRequest(http.MethodGet, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: workspaceKindName},
+			}
+			rr := httptest.NewRecorder()
+			a.GetPendingUpgradesHandler(rr, req, ps)
+			return rr
+		}
+
+		BeforeAll(func() {
+			uniqueName := "wsuc-test"
+			workspaceKindName = fmt.Sprintf("workspacekind-%s", uniqueName)
+			campaignName1 = fmt.Sprintf("campaign-1-%s", uniqueName)
+			campaignName2 = fmt.Sprintf("campaign-2-%s", uniqueName)
+			workspacePendingName = fmt.Sprintf("workspace-pending-%s", uniqueName)
+			workspacePendingKey = types.NamespacedName{Name: workspacePendingName, Namespace: namespaceName1}
+			workspaceCurrentName = fmt.Sprintf("workspace-current-%s", uniqueName)
+
+			By("creating Namespace 1")
+			namespace1 := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Create(ctx, namespace1)).To(Succeed())
+
+			By("creating a WorkspaceKind")
+			workspaceKind := NewExampleWorkspaceKind(workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspaceKind)).To(Succeed())
+
+			By("creating the Workspaces in Namespace 1")
+			workspacePending := NewExampleWorkspace(workspacePendingName, namespaceName1, workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspacePending)).To(Succeed())
+			workspaceCurrent := NewExampleWorkspace(workspaceCurrentName, namespaceName1, workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspaceCurrent)).To(Succeed())
+
+			By("setting a pending redirect on the status of the pending Workspace")
+			// NOTE: there is no controller in envtest, so we set the status ourselves
+			Expect(k8sClient.Get(ctx, workspacePendingKey, workspacePending)).To(Succeed())
+			workspacePending.Status.PodTemplateOptions.ImageConfig.Desired = "jupyterlab_scipy_190"
+			workspacePending.Status.PodTemplateOptions.ImageConfig.RedirectChain = []kubefloworgv1beta1.WorkspacePodOptionRedirectStep{
+				{Source: "jupyterlab_scipy_180", Target: "jupyterlab_scipy_190"},
+			}
+			Expect(k8sClient.Status().Update(ctx, workspacePending)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			By("deleting the upgrade campaigns")
+			for _, campaignName := range []string{campaignName1, campaignName2} {
+				campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: campaignName,
+					},
+				}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, campaign))).To(Succeed())
+			}
+
+			By("deleting the Workspaces from Namespace 1")
+			for _, workspaceName := range []string{workspacePendingName, workspaceCurrentName} {
+				workspace := &kubefloworgv1beta1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      workspaceName,
+						Namespace: namespaceName1,
+					},
+				}
+				Expect(k8sClient.Delete(ctx, workspace)).To(Succeed())
+			}
+
+			By("deleting WorkspaceKind")
+			workspaceKind := &kubefloworgv1beta1.WorkspaceKind{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: workspaceKindName,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, workspaceKind)).To(Succeed())
+
+			By("deleting Namespace 1")
+			namespace1 := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, namespace1)).To(Succeed())
+		})
+
+		It("should return the workspaces with a pending upgrade", func() {
+			By("executing GetPendingUpgradesHandler")
+			rr := doPendingUpgradesRequest(adminUser, workspaceKindName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusOK), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response only contains the pending Workspace")
+			var response PendingUpgradeListEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).To(HaveLen(1))
+			Expect(response.Data[0].Namespace).To(Equal(namespaceName1))
+			Expect(response.Data[0].Name).To(Equal(workspacePendingName))
+			Expect(response.Data[0].ImageConfig.Current).To(Equal("jupyterlab_scipy_180"))
+		})
+
+		It("should return 404 for the pending upgrades of a non-existent workspace kind", func() {
+			By("executing GetPendingUpgradesHandler")
+			rr := doPendingUpgradesRequest(adminUser, "non-existent-workspacekind")
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 404 when deferring an upgrade without a campaign", func() {
+			By("executing DeferUpgradeActionWorkspaceHandler")
+			rr := doDeferUpgradeRequest(adminUser, workspacePendingName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 403 when creating an upgrade campaign without permission", func() {
+			By("executing CreateUpgradeCampaignHandler as a non-admin user")
+			rr := doCreateCampaignRequest(nonAdminUser, &models.UpgradeCampaignCreate{
+				Name:          campaignName1,
+				WorkspaceKind: workspaceKindName,
+			})
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 422 when creating an upgrade campaign for a non-existent workspace kind", func() {
+			By("executing CreateUpgradeCampaignHandler")
+			rr := doCreateCampaignRequest(adminUser, &models.UpgradeCampaignCreate{
+				Name:          campaignName1,
+				WorkspaceKind: "non-existent-workspacekind",
+			})
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusUnprocessableEntity), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should create an upgrade campaign successfully", func() {
+			By("executing CreateUpgradeCampaignHandler")
+			rr := doCreateCampaignRequest(adminUser, &models.UpgradeCampaignCreate{
+				Name:            campaignName1,
+				WorkspaceKind:   workspaceKindName,
+				Paused:          true,
+				DeferralSeconds: ptr.To[int32](3600),
+			})
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusCreated), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the campaign")
+			var response UpgradeCampaignEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).NotTo(BeNil())
+			Expect(response.Data.Name).To(Equal(campaignName1))
+			Expect(response.Data.WorkspaceKind).To(Equal(workspaceKindName))
+			Expect(response.Data.Paused).To(BeTrue())
+			Expect(response.Data.DeferralSeconds).To(Equal(int32(3600)))
+
+			By("getting the WorkspaceUpgradeCampaign from the Kubernetes API")
+			campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: campaignName1}, campaign)).To(Succeed())
+			Expect(campaign.Spec.WorkspaceKind).To(Equal(workspaceKindName))
+		})
+
+		It("should return 409 when the upgrade campaign already exists", func() {
+			By("executing CreateUpgradeCampaignHandler")
+			rr := doCreateCampaignRequest(adminUser, &models.UpgradeCampaignCreate{
+				Name:          campaignName1,
+				WorkspaceKind: workspaceKindName,
+			})
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusConflict), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 409 when the workspace kind already has an upgrade campaign", func() {
+			By("executing CreateUpgradeCampaignHandler")
+			rr := doCreateCampaignRequest(adminUser, &models.UpgradeCampaignCreate{
+				Name:          campaignName2,
+				WorkspaceKind: workspaceKindName,
+			})
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusConflict), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the second campaign was not created")
+			campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: campaignName2}, campaign)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should list upgrade campaigns successfully", func() {
+			By("creating the HTTP request")
+			req, err := http.NewRequest(http.MethodGet, constants.AllUpgradeCampaignsPath, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("setting the auth headers")
+			req.Header.Set(userIdHeader, adminUser)
+
+			By("executing GetUpgradeCampaignsHandler")
+			rr := httptest.NewRecorder()
+			a.GetUpgradeCampaignsHandler(rr, req, httprouter.Params{})
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusOK), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the campaign")
+			var response UpgradeCampaignListEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			campaignNames := make([]string, len(response.Data))
+			for i, campaign := range response.Data {
+				campaignNames[i] = campaign.Name
+			}
+			Expect(campaignNames).To(ContainElement(campaignName1))
+		})
+
+		It("should get an upgrade campaign successfully", func() {
+			By("executing GetUpgradeCampaignHandler")
+			rr := doCampaignByNameRequest(a.GetUpgradeCampaignHandler, http.MethodGet, adminUser, campaignName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusOK), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the campaign")
+			var response UpgradeCampaignEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).NotTo(BeNil())
+			Expect(response.Data.Name).To(Equal(campaignName1))
+			Expect(response.Data.WorkspaceKind).To(Equal(workspaceKindName))
+		})
+
+		It("should return 404 for a non-existent upgrade campaign", func() {
+			By("executing GetUpgradeCampaignHandler")
+			rr := doCampaignByNameRequest(a.GetUpgradeCampaignHandler, http.MethodGet, adminUser, "non-existent-campaign")
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 403 when deferring an upgrade without permission", func() {
+			By("executing DeferUpgradeActionWorkspaceHandler as a non-admin user")
+			rr := doDeferUpgradeRequest(nonAdminUser, workspacePendingName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 404 when deferring a workspace without a pending upgrade", func() {
+			By("executing DeferUpgradeActionWorkspaceHandler")
+			rr := doDeferUpgradeRequest(adminUser, workspaceCurrentName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should defer the upgrade of a workspace successfully", func() {
+			By("executing DeferUpgradeActionWorkspaceHandler")
+			rr := doDeferUpgradeRequest(adminUser, workspacePendingName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusOK), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the deferral")
+			var response UpgradeDeferralEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).NotTo(BeNil())
+			Expect(response.Data.Campaign).To(Equal(campaignName1))
+			Expect(response.Data.DeferralSeconds).To(Equal(int32(3600)))
+
+			By("ensuring the deferral annotation was set on the Workspace")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Expect(k8sClient.Get(ctx, workspacePendingKey, workspace)).To(Succeed())
+			Expect(workspace.Annotations).To(HaveKeyWithValue(models.AnnotationUpgradeCampaignDeferral, campaignName1))
+		})
+
+		It("should return 409 when the upgrade of a workspace was already deferred", func() {
+			By("executing DeferUpgradeActionWorkspaceHandler")
+			rr := doDeferUpgradeRequest(adminUser, workspacePendingName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusConflict), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 403 when deleting an upgrade campaign without permission", func() {
+			By("executing DeleteUpgradeCampaignHandler as a non-admin user")
+			rr := doCampaignByNameRequest(a.DeleteUpgradeCampaignHandler, http.MethodDelete, nonAdminUser, campaignName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the campaign was not deleted")
+			campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: campaignName1}, campaign)).To(Succeed())
+		})
+
+		It("should delete an upgrade campaign successfully", func() {
+			By("executing DeleteUpgradeCampaignHandler")
+			rr := doCampaignByNameRequest(a.DeleteUpgradeCampaignHandler, http.MethodDelete, adminUser, campaignName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNoContent), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the campaign was deleted")
+			campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: campaignName1}, campaign)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should return 404 when deleting a non-existent upgrade campaign", func() {
+			By("executing DeleteUpgradeCampaignHandler")
+			rr := doCampaignByNameRequest(a.DeleteUpgradeCampaignHandler, http.MethodDelete, adminUser, campaignName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+	})
+})
@@ -0,0 +1,86 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/api/constants"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/auth"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/helper"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspacemetrics"
+	repository "github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspacemetrics"
+)
+
+type WorkspaceMetricsEnvelope Envelope[*models.WorkspaceMetrics]
+
+// GetWorkspaceMetricsHandler returns the resource usage of a workspace.
+//
+//	@Summary		Get workspace resource usage
+//	@Description	Returns the current and last 24 hours of CPU, memory and GPU usage of a workspace (from a Prometheus-compatible API), the usage of its volumes (from the kubelet volume metrics in the same API), and the resources requested by its pod config. Metrics which are not available are reported as warnings.
+//	@Tags			workspaces
+//	@ID				getWorkspaceMetrics
+//	@Produce		application/json
+//	@Param			namespace	path		string						true	"Namespace of the workspace"	extensions(x-example=default)
+//	@Param			name		path		string						true	"Name of the workspace"			extensions(x-example=my-workspace)
+//	@Success		200			{object}	WorkspaceMetricsEnvelope	"Successful operation. Returns the resource usage of the workspace."
+//	@Failure		401			{object}	ErrorEnvelope				"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope				"Forbidden. User does not have permission to access the workspace."
+//	@Failure		404			{object}	ErrorEnvelope				"Not Found. Workspace does not exist."
+//	@Failure		422			{object}	ErrorEnvelope				"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope				"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspaces/{namespace}/{name}/metrics [get]
+func (a *App) GetWorkspaceMetricsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	workspaceName := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateWorkspaceName(field.NewPath(constants.ResourceNamePathParam), workspaceName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbGet, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: workspaceName}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	workspaceMetrics, err := a.repositories.WorkspaceMetrics.GetWorkspaceMetrics(r.Context(), namespace, workspaceName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error getting workspace metrics: %w", err))
+		return
+	}
+
+	responseEnvelope := &WorkspaceMetricsEnvelope{Data: workspaceMetrics}
+	a.dataResponse(w, r, responseEnvelope)
+}
@@ -0,0 +1,351 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"github.com/julienschmidt/httprouter"
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/api/constants"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/config"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/metrics"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspacemetrics"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspacemetrics"
+)
+
+// stubPrometheusHandler serves canned responses for the queries of the workspace metrics repository.
+//   - if failing is true, every query returns a Prometheus error
+//   - only the home PVC has kubelet volume metrics
+func stubPrometheusHandler(failing *atomic.Bool, homePVCName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"unavailable","error":"prometheus is down"}`))
+			return
+		}
+
+		query := r.URL.Query().Get("query")
+		var response string
+		switch {
+		case r.URL.Path == "/api/v1/query_range" && strings.Contains(query, "container_cpu_usage_seconds_total"):
+			response = `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1700000000,"0.25"],[1700000900,"0.5"]]}]}}`
+		case r.URL.Path == "/api/v1/query_range":
+			response = `{"status":"success","data":{"resultType":"matrix","result":[]}}`
+		case strings.Contains(query, "container_cpu_usage_seconds_total"):
+			response = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000900,"0.5"]}]}}`
+		case strings.Contains(query, "container_memory_working_set_bytes"):
+			response = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000900,"134217728"]}]}}`
+		case strings.Contains(query, "kubelet_volume_stats_capacity_bytes"):
+			response = fmt.Sprintf(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"persistentvolumeclaim":%q},"value":[1700000900,"10737418240"]}]}}`, homePVCName)
+		case strings.Contains(query, "kubelet_volume_stats_used_bytes"):
+			response = fmt.Sprintf(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"persistentvolumeclaim":%q},"value":[1700000900,"1073741824"]}]}}`, homePVCName)
+		case strings.Contains(query, "kubelet_volume_stats_available_bytes"):
+			response = fmt.Sprintf(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"persistentvolumeclaim":%q},"value":[1700000900,"9663676416"]}]}}`, homePVCName)
+		default:
+			// e.g. the GPU query, when there is no DCGM exporter
+			response = `{"status":"success","data":{"resultType":"vector","result":[]}}`
+		}
+		_, _ = w.Write([]byte(response))
+	}
+}
+
+var _ = Describe("Workspace Metrics Handler", func() {
+
+	// NOTE: the tests in this context work on the same resources, they must be run in order.
+	//       also, they assume a specific state of the cluster, so cannot be run in parallel with other tests.
+	//       therefore, we run them using the `Ordered` and `Serial` Ginkgo decorators.
+	Context("with a running Workspace", Serial, Ordered, func() {
+
+		const (
+			namespaceName1 = "ws-metrics-ns1"
+			nonAdminUser   = "non-admin-user"
+
+			// NOTE: these are the PVCs mounted by `NewExampleWorkspace`
+			homePVCName = "my-home-pvc"
+			dataPVCName = "my-repositories-pvc"
+		)
+
+		var (
+			workspaceName1    string
+			workspaceKey1     types.NamespacedName
+			workspaceKindName string
+			statefulSetName   string
+
+			prometheusServer   *httptest.Server
+			prometheusFailing  atomic.Bool
+			originalRepository *workspacemetrics.WorkspaceMetricsRepository
+		)
+
+		// doGetMetricsRequest calls GetWorkspaceMetricsHandler for a workspace.
+		doGetMetricsRequest := func(user string, workspaceName string) *httptest.ResponseRecorder {
+			path := strings.Replace(constants.WorkspaceMetricsPath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			path = strings.Replace(path, ":"+constants.ResourceNamePathParam, workspaceName, 1)
+			req, err := http.NewRequest(http.MethodGet, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: workspaceName},
+			}
+			rr := httptest.NewRecorder()
+			a.GetWorkspaceMetricsHandler(rr, req, ps)
+			return rr
+		}
+
+		// readMetricsResponse decodes the body of a successful GetWorkspaceMetricsHandler response.
+		readMetricsResponse := func(rr *httptest.ResponseRecorder) *models.WorkspaceMetrics {
+			rs := rr.Result()
+			defer rs.Body.Close()
+			Expect(rs.StatusCode).To(Equal(http.StatusOK), descUnexpectedHTTPStatus, rr.Body.String())
+
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+			var response WorkspaceMetricsEnvelope
+			Expect(json.Unmarshal(body, &response)).To(Succeed())
+			Expect(response.Data).NotTo(BeNil())
+			return response.Data
+		}
+
+		BeforeAll(func() {
+			uniqueName := "ws-metrics-test"
+			workspaceName1 = fmt.Sprintf("workspace-1-%s", uniqueName)
+			workspaceKey1 = types.NamespacedName{Name: workspaceName1, Namespace: namespaceName1}
+			workspaceKindName = fmt.Sprintf("workspacekind-%s", uniqueName)
+			statefulSetName = fmt.Sprintf("ws-%s", workspaceName1)
+
+			By("creating Namespace 1")
+			namespace1 := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Create(ctx, namespace1)).To(Succeed())
+
+			By("creating a WorkspaceKind")
+			workspaceKind := NewExampleWorkspaceKind(workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspaceKind)).To(Succeed())
+
+			By("creating Workspace 1 in Namespace 1")
+			workspace1 := NewExampleWorkspace(workspaceName1, namespaceName1, workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspace1)).To(Succeed())
+
+			By("creating the StatefulSet of Workspace 1")
+			// NOTE: there is no controller in envtest, so we create the StatefulSet and set the status ourselves
+			podLabels := map[string]string{"notebooks.kubeflow.org/workspace-name": workspaceName1}
+			statefulSet := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      statefulSetName,
+					Namespace: namespaceName1,
+					Labels:    podLabels,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: kubefloworgv1beta1.GroupVersion.String(),
+							Kind:       "Workspace",
+							Name:       workspace1.Name,
+							UID:        workspace1.UID,
+							Controller: ptr.To(true),
+						},
+					},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "main",
+									Image: "busybox",
+								},
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, statefulSet)).To(Succeed())
+
+			By("setting the Pod of Workspace 1 on its status")
+			Expect(k8sClient.Get(ctx, workspaceKey1, workspace1)).To(Succeed())
+			workspace1.Status.PodTemplatePod.Name = statefulSetName + "-0"
+			Expect(k8sClient.Status().Update(ctx, workspace1)).To(Succeed())
+
+			By("starting a stub Prometheus server")
+			prometheusFailing.Store(false)
+			prometheusServer = httptest.NewServer(stubPrometheusHandler(&prometheusFailing, homePVCName))
+
+			By("using the stub Prometheus server for workspace metrics")
+			originalRepository = a.repositories.WorkspaceMetrics
+			a.repositories.WorkspaceMetrics = workspacemetrics.NewWorkspaceMetricsRepository(&config.EnvConfig{}, k8sClient, metrics.NewPrometheusClient(prometheusServer.URL))
+		})
+
+		AfterAll(func() {
+			By("restoring the workspace metrics repository")
+			a.repositories.WorkspaceMetrics = originalRepository
+
+			By("stopping the stub Prometheus server")
+			prometheusServer.Close()
+
+			By("deleting the StatefulSet of Workspace 1")
+			statefulSet := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      statefulSetName,
+					Namespace: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, statefulSet)).To(Succeed())
+
+			By("deleting Workspace 1 from Namespace 1")
+			workspace1 := &kubefloworgv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      workspaceName1,
+					Namespace: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, workspace1)).To(Succeed())
+
+			By("deleting WorkspaceKind")
+			workspaceKind := &kubefloworgv1beta1.WorkspaceKind{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: workspaceKindName,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, workspaceKind)).To(Succeed())
+
+			By("deleting Namespace 1")
+			namespace1 := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, namespace1)).To(Succeed())
+		})
+
+		It("should return the resource and volume usage of a workspace", func() {
+			By("executing GetWorkspaceMetricsHandler")
+			workspaceMetrics := readMetricsResponse(doGetMetricsRequest(adminUser, workspaceName1))
+
+			By("verifying the requested resources of the pod config")
+			Expect(workspaceMetrics.Requests.CPU).To(HaveValue(BeNumerically("~", 0.1)))
+			Expect(workspaceMetrics.Requests.MemoryBytes).To(HaveValue(Equal(int64(128 * 1024 * 1024))))
+
+			By("verifying the current resource usage")
+			Expect(workspaceMetrics.Current.CPU).To(HaveValue(Equal(0.5)))
+			Expect(workspaceMetrics.Current.MemoryBytes).To(HaveValue(Equal(int64(134217728))))
+			Expect(workspaceMetrics.Current.GPUUtilization).To(BeNil())
+
+			By("verifying the resource usage history")
+			Expect(workspaceMetrics.History).To(HaveLen(2))
+			Expect(workspaceMetrics.History[0].Timestamp).To(Equal(int64(1700000000)))
+			Expect(workspaceMetrics.History[0].CPU).To(HaveValue(Equal(0.25)))
+
+			By("verifying the volume usage")
+			Expect(workspaceMetrics.Volumes).To(HaveLen(2))
+			Expect(workspaceMetrics.Volumes[0].PVCName).To(Equal(homePVCName))
+			Expect(workspaceMetrics.Volumes[0].Type).To(Equal(models.VolumeTypeHome))
+			Expect(workspaceMetrics.Volumes[0].CapacityBytes).To(HaveValue(Equal(int64(10737418240))))
+			Expect(workspaceMetrics.Volumes[0].UsedBytes).To(HaveValue(Equal(int64(1073741824))))
+			Expect(workspaceMetrics.Volumes[0].AvailableBytes).To(HaveValue(Equal(int64(9663676416))))
+
+			By("verifying a volume without kubelet metrics has no usage")
+			Expect(workspaceMetrics.Volumes[1].PVCName).To(Equal(dataPVCName))
+			Expect(workspaceMetrics.Volumes[1].Type).To(Equal(models.VolumeTypeData))
+			Expect(workspaceMetrics.Volumes[1].UsedBytes).To(BeNil())
+
+			By("verifying there are no warnings")
+			Expect(workspaceMetrics.Warnings).To(BeEmpty())
+		})
+
+		It("should return warnings when Prometheus is unavailable", func() {
+			By("making the stub Prometheus server fail")
+			prometheusFailing.Store(true)
+			defer prometheusFailing.Store(false)
+
+			By("executing GetWorkspaceMetricsHandler")
+			workspaceMetrics := readMetricsResponse(doGetMetricsRequest(adminUser, workspaceName1))
+
+			By("verifying the requested resources are still returned")
+			Expect(workspaceMetrics.Requests.CPU).NotTo(BeNil())
+
+			By("verifying no usage is returned")
+			Expect(workspaceMetrics.Current.CPU).To(BeNil())
+			Expect(workspaceMetrics.Current.MemoryBytes).To(BeNil())
+			Expect(workspaceMetrics.Volumes).To(HaveLen(2))
+			Expect(workspaceMetrics.Volumes[0].UsedBytes).To(BeNil())
+
+			By("verifying a warning is returned for each failed source")
+			Expect(workspaceMetrics.Warnings).To(ContainElement(ContainSubstring("CPU usage is not available")))
+			Expect(workspaceMetrics.Warnings).To(ContainElement(ContainSubstring("volume usage is not available")))
+			Expect(workspaceMetrics.Warnings).To(ContainElement(ContainSubstring("prometheus is down")))
+		})
+
+		It("should return a warning when no Prometheus URL is configured", func() {
+			By("using a workspace metrics repository without a Prometheus URL")
+			a.repositories.WorkspaceMetrics = workspacemetrics.NewWorkspaceMetricsRepository(&config.EnvConfig{}, k8sClient, metrics.NewPrometheusClient(""))
+			defer func() {
+				a.repositories.WorkspaceMetrics = workspacemetrics.NewWorkspaceMetricsRepository(&config.EnvConfig{}, k8sClient, metrics.NewPrometheusClient(prometheusServer.URL))
+			}()
+
+			By("executing GetWorkspaceMetricsHandler")
+			workspaceMetrics := readMetricsResponse(doGetMetricsRequest(adminUser, workspaceName1))
+
+			By("verifying no usage is returned")
+			Expect(workspaceMetrics.Current.CPU).To(BeNil())
+			Expect(workspaceMetrics.History).To(BeEmpty())
+			Expect(workspaceMetrics.Volumes[0].UsedBytes).To(BeNil())
+
+			By("verifying the warning is returned")
+			Expect(workspaceMetrics.Warnings).To(ConsistOf(ContainSubstring("no Prometheus URL is configured")))
+		})
+
+		It("should return 404 for a non-existent workspace", func() {
+			By("executing GetWorkspaceMetricsHandler")
+			rr := doGetMetricsRequest(adminUser, "non-existent-workspace")
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 403 when getting the metrics of a workspace without permission", func() {
+			By("executing GetWorkspaceMetricsHandler as a non-admin user")
+			rr := doGetMetricsRequest(nonAdminUser, workspaceName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+	})
+})
@@ -0,0 +1,251 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/api/constants"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/auth"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/helper"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspaceshares"
+	repository "github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspaceshares"
+)
+
+type WorkspaceShareEnvelope Envelope[*models.WorkspaceShare]
+type WorkspaceShareListEnvelope Envelope[[]models.WorkspaceShare]
+type WorkspaceShareCreateEnvelope Envelope[*models.WorkspaceShareCreate]
+
+// GetWorkspaceSharesHandler returns the users a workspace is shared with.
+//
+//	@Summary		List workspace shares
+//	@Description	Returns the users a workspace is shared with, and their access level.
+//	@Tags			workspaces
+//	@ID				listWorkspaceShares
+//	@Produce		application/json
+//	@Param			namespace	path		string						true	"Namespace of the workspace"	extensions(x-example=default)
+//	@Param			name		path		string						true	"Name of the workspace"			extensions(x-example=my-workspace)
+//	@Success		200			{object}	WorkspaceShareListEnvelope	"Successful operation. Returns the shares of the workspace."
+//	@Failure		401			{object}	ErrorEnvelope				"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope				"Forbidden. User does not have permission to access the workspace."
+//	@Failure		404			{object}	ErrorEnvelope				"Not Found. Workspace does not exist."
+//	@Failure		422			{object}	ErrorEnvelope				"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope				"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspaces/{namespace}/{name}/shares [get]
+func (a *App) GetWorkspaceSharesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	workspaceName := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateWorkspaceName(field.NewPath(constants.ResourceNamePathParam), workspaceName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbGet, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: workspaceName}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	shares, err := a.repositories.WorkspaceShare.GetWorkspaceShares(r.Context(), namespace, workspaceName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		a.serverErrorResponse(w, r, err)
+		return
+	}
+
+	responseEnvelope := &WorkspaceShareListEnvelope{Data: shares}
+	a.dataResponse(w, r, responseEnvelope)
+}
+
+// CreateWorkspaceShareHandler shares a workspace with a user.
+//
+//	@Summary		Share a workspace
+//	@Description	Shares a workspace with a user, in read-only or edit mode. The user is granted access to this workspace only, not to the rest of the namespace.
+//	@Tags			workspaces
+//	@ID				createWorkspaceShare
+//	@Accept			json
+//	@Produce		json
+//	@Param			namespace	path		string							true	"Namespace of the workspace"	extensions(x-example=default)
+//	@Param			name		path		string							true	"Name of the workspace"			extensions(x-example=my-workspace)
+//	@Param			body		body		WorkspaceShareCreateEnvelope	true	"Workspace share configuration"
+//	@Success		201			{object}	WorkspaceShareEnvelope			"Workspace share created successfully"
+//	@Failure		400			{object}	ErrorEnvelope					"Bad Request."
+//	@Failure		401			{object}	ErrorEnvelope					"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope					"Forbidden. User does not have permission to share the workspace."
+//	@Failure		404			{object}	ErrorEnvelope					"Not Found. Workspace does not exist."
+//	@Failure		409			{object}	ErrorEnvelope					"Conflict. Workspace is already shared with the user."
+//	@Failure		413			{object}	ErrorEnvelope					"Request Entity Too Large. The request body is too large."
+//	@Failure		415			{object}	ErrorEnvelope					"Unsupported Media Type. Content-Type header is not correct."
+//	@Failure		422			{object}	ErrorEnvelope					"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope					"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspaces/{namespace}/{name}/shares [post]
+func (a *App) CreateWorkspaceShareHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	workspaceName := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateWorkspaceName(field.NewPath(constants.ResourceNamePathParam), workspaceName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// validate the Content-Type header
+	if success := a.ValidateContentType(w, r, constants.MediaTypeJson); !success {
+		return
+	}
+
+	// decode the request body
+	bodyEnvelope := &WorkspaceShareCreateEnvelope{}
+	err := a.DecodeJSON(r, bodyEnvelope)
+	if err != nil {
+		if a.IsMaxBytesError(err) {
+			a.requestEntityTooLargeResponse(w, r, err)
+			return
+		}
+		a.badRequestResponse(w, r, fmt.Errorf("error decoding request body: %w", err))
+		return
+	}
+
+	// validate the request body
+	dataPath := field.NewPath("data")
+	if bodyEnvelope.Data == nil {
+		valErrs = field.ErrorList{field.Required(dataPath, "data is required")}
+		a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+		return
+	}
+	valErrs = bodyEnvelope.Data.Validate(dataPath)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+		return
+	}
+
+	// give the request data a clear name
+	shareCreate := bodyEnvelope.Data
+
+	// =========================== AUTH ===========================
+	// NOTE: we require "delete" so that users the workspace is shared with (in edit mode) can't re-share it
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbUpdate, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: workspaceName}),
+		auth.NewResourcePolicy(auth.VerbDelete, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: workspaceName}),
+	}
+	actor, ok := a.requireAuth(w, r, authPolicies)
+	if !ok {
+		return
+	}
+	// ============================================================
+
+	createdShare, err := a.repositories.WorkspaceShare.CreateWorkspaceShare(r.Context(), actor, shareCreate, namespace, workspaceName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		if errors.Is(err, repository.ErrWorkspaceShareAlreadyExists) {
+			a.conflictResponse(w, r, err, nil)
+			return
+		}
+		if apierrors.IsInvalid(err) {
+			causes := helper.StatusCausesFromAPIStatus(err)
+			a.failedValidationResponse(w, r, errMsgKubernetesValidation, nil, causes)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error creating workspace share: %w", err))
+		return
+	}
+
+	// calculate the GET location for the shares of the workspace (for the Location header)
+	location := a.LocationGetWorkspaceShares(namespace, workspaceName)
+
+	responseEnvelope := &WorkspaceShareEnvelope{Data: createdShare}
+	a.createdResponse(w, r, responseEnvelope, location)
+}
+
+// DeleteWorkspaceShareHandler stops sharing a workspace with a user.
+//
+//	@Summary		Delete a workspace share
+//	@Description	Stops sharing a workspace with a user.
+//	@Tags			workspaces
+//	@ID				deleteWorkspaceShare
+//	@Produce		application/json
+//	@Param			namespace	path	string	true	"Namespace of the workspace"	extensions(x-example=default)
+//	@Param			name		path	string	true	"Name of the workspace"			extensions(x-example=my-workspace)
+//	@Param			share		path	string	true	"Name of the workspace share"	extensions(x-example=my-workspace-share-0123456789)
+//	@Success		204			"No Content"
+//	@Failure		401			{object}	ErrorEnvelope	"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope	"Forbidden. User does not have permission to share the workspace."
+//	@Failure		404			{object}	ErrorEnvelope	"Not Found. Workspace share does not exist."
+//	@Failure		422			{object}	ErrorEnvelope	"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope	"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspaces/{namespace}/{name}/shares/{share} [delete]
+func (a *App) DeleteWorkspaceShareHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	workspaceName := ps.ByName(constants.ResourceNamePathParam)
+	shareName := ps.ByName(constants.SharePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateWorkspaceName(field.NewPath(constants.ResourceNamePathParam), workspaceName)...)
+	valErrs = append(valErrs, helper.ValidateFieldIsDNS1123Subdomain(field.NewPath(constants.SharePathParam), shareName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	// NOTE: we require "delete" so that users the workspace is shared with (in edit mode) can't un-share it
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbUpdate, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: workspaceName}),
+		auth.NewResourcePolicy(auth.VerbDelete, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: workspaceName}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	err := a.repositories.WorkspaceShare.DeleteWorkspaceShare(r.Context(), namespace, workspaceName, shareName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceShareNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error deleting workspace share: %w", err))
+		return
+	}
+
+	a.deletedResponse(w, r)
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/api/constants"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspaceshares"
+)
+
+var _ = Describe("Workspace Shares Handler", func() {
+
+	// NOTE: the tests in this context work on the same resources, they must be run in order.
+	//       also, they assume a specific state of the cluster, so cannot be run in parallel with other tests.
+	//       therefore, we run them using the `Ordered` and `Serial` Ginkgo decorators.
+	Context("with an existing Workspace", Serial, Ordered, func() {
+
+		const (
+			namespaceName1 = "ws-share-ns1"
+
+			// editUser is the user the workspace is shared with in edit mode
+			editUser = "share-edit-user@example.com"
+
+			// targetUser is a user which the edit user tries to share the workspace with
+			targetUser = "share-target-user@example.com"
+
+			// unrelatedRoleBindingName is a RoleBinding which does not share the workspace
+			unrelatedRoleBindingName = "unrelated-rolebinding"
+		)
+
+		var (
+			workspaceName1    string
+			workspaceKindName string
+			editShareName     string
+		)
+
+		// doGetSharesRequest calls GetWorkspaceSharesHandler for a workspace.
+		doGetSharesRequest := func(user string, workspaceName string) *httptest.ResponseRecorder {
+			path := strings.Replace(constants.WorkspaceSharesPath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			path = strings.Replace(path, ":"+constants.ResourceNamePathParam, workspaceName, 1)
+			req, err := http.NewRequest(http.MethodGet, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: workspaceName},
+			}
+			rr := httptest.NewRecorder()
+			a.GetWorkspaceSharesHandler(rr, req, ps)
+			return rr
+		}
+
+		// doCreateShareRequest calls CreateWorkspaceShareHandler for a workspace.
+		doCreateShareRequest := func(user string, shareUser string, access models.WorkspaceAccess) *httptest.ResponseRecorder {
+			requestBody := &WorkspaceShareCreateEnvelope{
+				Data: &models.WorkspaceShareCreate{
+					User:   shareUser,
+					Access: access,
+				},
+			}
+			bodyBytes, err := json.Marshal(requestBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			path := strings.Replace(constants.WorkspaceSharesPath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			path = strings.Replace(path, ":"+constants.ResourceNamePathParam, workspaceName1, 1)
+			req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(string(bodyBytes)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+			req.Header.Set("Content-Type", "application/json")
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: workspaceName1},
+			}
+			rr := httptest.NewRecorder()
+			a.CreateWorkspaceShareHandler(rr, req, ps)
+			return rr
+		}
+
+		// doDeleteShareRequest calls DeleteWorkspaceShareHandler for a workspace share.
+		doDeleteShareRequest := func(user string, shareName string) *httptest.ResponseRecorder {
+			path := strings.Replace(constants.WorkspaceSharesByNamePath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			path = strings.Replace(path, ":"+constants.ResourceNamePathParam, workspaceName1, 1)
+			path = strings.Replace(path, ":"+constants.SharePathParam, shareName, 1)
+			req, err := http.NewRequest(http.MethodDelete, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: workspaceName1},
+				httprouter.Param{Key: constants.SharePathParam, Value: shareName},
+			}
+			rr := httptest.NewRecorder()
+			a.DeleteWorkspaceShareHandler(rr, req, ps)
+			return rr
+		}
+
+		BeforeAll(func() {
+			uniqueName := "ws-share-test"
+			workspaceName1 = fmt.Sprintf("workspace-1-%s", uniqueName)
+			workspaceKindName = fmt.Sprintf("workspacekind-%s", uniqueName)
+			editShareName = models.RoleBindingName(workspaceName1, editUser)
+
+			By("creating Namespace 1")
+			namespace1 := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Create(ctx, namespace1)).To(Succeed())
+
+			By("creating a WorkspaceKind")
+			workspaceKind := NewExampleWorkspaceKind(workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspaceKind)).To(Succeed())
+
+			By("creating Workspace 1 in Namespace 1")
+			workspace1 := NewExampleWorkspace(workspaceName1, namespaceName1, workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspace1)).To(Succeed())
+
+			By("creating a RoleBinding which does not share the workspace")
+			unrelatedRoleBinding := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      unrelatedRoleBindingName,
+					Namespace: namespaceName1,
+				},
+				Subjects: []rbacv1.Subject{
+					{
+						APIGroup: rbacv1.GroupName,
+						Kind:     rbacv1.UserKind,
+						Name:     targetUser,
+					},
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "ClusterRole",
+					Name:     "view",
+				},
+			}
+			Expect(k8sClient.Create(ctx, unrelatedRoleBinding)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			By("deleting the unrelated RoleBinding")
+			unrelatedRoleBinding := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      unrelatedRoleBindingName,
+					Namespace: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, unrelatedRoleBinding)).To(Succeed())
+
+			By("deleting Workspace 1 from Namespace 1")
+			workspace1 := &kubefloworgv1beta1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      workspaceName1,
+					Namespace: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, workspace1)).To(Succeed())
+
+			By("deleting WorkspaceKind")
+			workspaceKind := &kubefloworgv1beta1.WorkspaceKind{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: workspaceKindName,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, workspaceKind)).To(Succeed())
+
+			By("deleting Namespace 1")
+			namespace1 := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, namespace1)).To(Succeed())
+		})
+
+		It("should return an empty list when the workspace is not shared", func() {
+			By("executing GetWorkspaceSharesHandler")
+			rr := doGetSharesRequest(adminUser, workspaceName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusOK), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains no shares")
+			var response WorkspaceShareListEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).To(BeEmpty())
+		})
+
+		It("should return 404 when listing the shares of a non-existent workspace", func() {
+			By("executing GetWorkspaceSharesHandler")
+			rr := doGetSharesRequest(adminUser, "non-existent-workspace")
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 403 when sharing a workspace without permission", func() {
+			By("executing CreateWorkspaceShareHandler as a user the workspace is not shared with")
+			rr := doCreateShareRequest(editUser, targetUser, models.WorkspaceAccessRead)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should share a workspace successfully", func() {
+			By("executing CreateWorkspaceShareHandler")
+			rr := doCreateShareRequest(adminUser, editUser, models.WorkspaceAccessEdit)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusCreated), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the share")
+			var response WorkspaceShareEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).NotTo(BeNil())
+			Expect(response.Data.Name).To(Equal(editShareName))
+			Expect(response.Data.User).To(Equal(editUser))
+			Expect(response.Data.Access).To(Equal(models.WorkspaceAccessEdit))
+
+			By("getting the RoleBinding from the Kubernetes API")
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: editShareName, Namespace: namespaceName1}, roleBinding)).To(Succeed())
+			Expect(roleBinding.RoleRef.Name).To(Equal(models.RoleName(workspaceName1, models.WorkspaceAccessEdit)))
+
+			By("getting the Role from the Kubernetes API")
+			role := &rbacv1.Role{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: roleBinding.RoleRef.Name, Namespace: namespaceName1}, role)).To(Succeed())
+			Expect(role.Rules).To(HaveLen(1))
+			Expect(role.Rules[0].ResourceNames).To(ConsistOf(workspaceName1))
+			Expect(role.Rules[0].Verbs).NotTo(ContainElement("delete"))
+		})
+
+		It("should return 409 when the workspace is already shared with the user", func() {
+			By("executing CreateWorkspaceShareHandler")
+			rr := doCreateShareRequest(adminUser, editUser, models.WorkspaceAccessRead)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusConflict), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should allow the edit user to list the shares of the workspace", func() {
+			By("waiting for the share to be authorized")
+			// NOTE: the RBAC authorizer of the Kubernetes API server caches RoleBindings, so this may take a moment
+			Eventually(func() int {
+				return doGetSharesRequest(editUser, workspaceName1).Code
+			}, "10s", "250ms").Should(Equal(http.StatusOK))
+
+			By("executing GetWorkspaceSharesHandler as the edit user")
+			rr := doGetSharesRequest(editUser, workspaceName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the share")
+			var response WorkspaceShareListEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).To(HaveLen(1))
+			Expect(response.Data[0].User).To(Equal(editUser))
+		})
+
+		It("should not allow the edit user to re-share the workspace", func() {
+			By("executing CreateWorkspaceShareHandler as the edit user")
+			rr := doCreateShareRequest(editUser, targetUser, models.WorkspaceAccessEdit)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the RoleBinding was not created")
+			roleBinding := &rbacv1.RoleBinding{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: models.RoleBindingName(workspaceName1, targetUser), Namespace: namespaceName1}, roleBinding)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should not allow the edit user to un-share the workspace", func() {
+			By("executing DeleteWorkspaceShareHandler as the edit user")
+			rr := doDeleteShareRequest(editUser, editShareName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the RoleBinding was not deleted")
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: editShareName, Namespace: namespaceName1}, roleBinding)).To(Succeed())
+		})
+
+		It("should return 404 when deleting a RoleBinding which does not share the workspace", func() {
+			By("executing DeleteWorkspaceShareHandler")
+			rr := doDeleteShareRequest(adminUser, unrelatedRoleBindingName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the RoleBinding was not deleted")
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: unrelatedRoleBindingName, Namespace: namespaceName1}, roleBinding)).To(Succeed())
+		})
+
+		It("should delete a workspace share successfully", func() {
+			By("executing DeleteWorkspaceShareHandler")
+			rr := doDeleteShareRequest(adminUser, editShareName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNoContent), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the RoleBinding was deleted")
+			roleBinding := &rbacv1.RoleBinding{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: editShareName, Namespace: namespaceName1}, roleBinding)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should return 404 when deleting a non-existent workspace share", func() {
+			By("executing DeleteWorkspaceShareHandler")
+			rr := doDeleteShareRequest(adminUser, editShareName)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+	})
+})
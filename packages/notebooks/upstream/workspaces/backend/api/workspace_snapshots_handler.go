@@ -0,0 +1,415 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/api/constants"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/auth"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/helper"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspacesnapshots"
+	repository "github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspacesnapshots"
+)
+
+type WorkspaceSnapshotEnvelope Envelope[*models.WorkspaceSnapshot]
+type WorkspaceSnapshotListEnvelope Envelope[[]models.WorkspaceSnapshot]
+type WorkspaceSnapshotCreateEnvelope Envelope[*models.WorkspaceSnapshotCreate]
+type WorkspaceSnapshotCloneEnvelope Envelope[*models.WorkspaceSnapshotClone]
+
+// SnapshotActionWorkspaceHandler takes a snapshot of the volumes of a workspace.
+//
+//	@Summary		Snapshot a workspace
+//	@Description	Takes a CSI VolumeSnapshot of the home and data volumes of a workspace. The snapshot can later be cloned into a new workspace.
+//	@Tags			workspaces
+//	@ID				snapshotWorkspace
+//	@Accept			json
+//	@Produce		json
+//	@Param			namespace	path		string							true	"Namespace of the workspace"	extensions(x-example=default)
+//	@Param			name		path		string							true	"Name of the workspace"			extensions(x-example=my-workspace)
+//	@Param			body		body		WorkspaceSnapshotCreateEnvelope	true	"Workspace snapshot configuration"
+//	@Success		201			{object}	WorkspaceSnapshotEnvelope		"Workspace snapshot created successfully"
+//	@Failure		400			{object}	ErrorEnvelope					"Bad Request. Workspace has no volumes to snapshot."
+//	@Failure		401			{object}	ErrorEnvelope					"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope					"Forbidden. User does not have permission to snapshot the workspace."
+//	@Failure		404			{object}	ErrorEnvelope					"Not Found. Workspace does not exist."
+//	@Failure		409			{object}	ErrorEnvelope					"Conflict. Workspace snapshot with the same name already exists."
+//	@Failure		413			{object}	ErrorEnvelope					"Request Entity Too Large. The request body is too large."
+//	@Failure		415			{object}	ErrorEnvelope					"Unsupported Media Type. Content-Type header is not correct."
+//	@Failure		422			{object}	ErrorEnvelope					"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope					"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspaces/{namespace}/{name}/actions/snapshot [post]
+func (a *App) SnapshotActionWorkspaceHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	workspaceName := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateWorkspaceName(field.NewPath(constants.ResourceNamePathParam), workspaceName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// validate the Content-Type header
+	if success := a.ValidateContentType(w, r, constants.MediaTypeJson); !success {
+		return
+	}
+
+	// decode the request body
+	bodyEnvelope := &WorkspaceSnapshotCreateEnvelope{}
+	err := a.DecodeJSON(r, bodyEnvelope)
+	if err != nil {
+		if a.IsMaxBytesError(err) {
+			a.requestEntityTooLargeResponse(w, r, err)
+			return
+		}
+		a.badRequestResponse(w, r, fmt.Errorf("error decoding request body: %w", err))
+		return
+	}
+
+	// validate the request body
+	dataPath := field.NewPath("data")
+	if bodyEnvelope.Data == nil {
+		valErrs = field.ErrorList{field.Required(dataPath, "data is required")}
+		a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+		return
+	}
+	valErrs = bodyEnvelope.Data.Validate(dataPath)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+		return
+	}
+
+	// give the request data a clear name
+	snapshotCreate := bodyEnvelope.Data
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbGet, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: workspaceName}),
+		auth.NewResourcePolicy(auth.VerbCreate, auth.VolumeSnapshots, auth.ResourcePolicyResourceMeta{Namespace: namespace}),
+	}
+	actor, ok := a.requireAuth(w, r, authPolicies)
+	if !ok {
+		return
+	}
+	// ============================================================
+
+	createdSnapshot, err := a.repositories.WorkspaceSnapshot.CreateWorkspaceSnapshot(r.Context(), actor, snapshotCreate, namespace, workspaceName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		if errors.Is(err, repository.ErrWorkspaceHasNoVolumes) {
+			a.badRequestResponse(w, r, err)
+			return
+		}
+		if helper.IsInternalValidationError(err) {
+			fieldErrs := helper.FieldErrorsFromInternalValidationError(err)
+			a.failedValidationResponse(w, r, errMsgInternalValidation, fieldErrs, nil)
+			return
+		}
+		if errors.Is(err, repository.ErrWorkspaceSnapshotAlreadyExists) {
+			causes := helper.StatusCausesFromAPIStatus(err)
+			a.conflictResponse(w, r, err, causes)
+			return
+		}
+		if apierrors.IsInvalid(err) {
+			causes := helper.StatusCausesFromAPIStatus(err)
+			a.failedValidationResponse(w, r, errMsgKubernetesValidation, nil, causes)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error creating workspace snapshot: %w", err))
+		return
+	}
+
+	// calculate the GET location for the created snapshot (for the Location header)
+	location := a.LocationGetWorkspaceSnapshot(namespace, createdSnapshot.Name)
+
+	responseEnvelope := &WorkspaceSnapshotEnvelope{Data: createdSnapshot}
+	a.createdResponse(w, r, responseEnvelope, location)
+}
+
+// GetWorkspaceSnapshotsHandler returns a list of workspace snapshots in a specific namespace.
+//
+//	@Summary		List workspace snapshots by namespace
+//	@Description	Returns a list of workspace snapshots in a specific namespace.
+//	@Tags			workspacesnapshots
+//	@ID				listWorkspaceSnapshots
+//	@Produce		application/json
+//	@Param			namespace	path		string							true	"Namespace name"	extensions(x-example=my-namespace)
+//	@Success		200			{object}	WorkspaceSnapshotListEnvelope	"Successful operation. Returns a list of workspace snapshots in the specified namespace."
+//	@Failure		401			{object}	ErrorEnvelope					"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope					"Forbidden. User does not have permission to list workspace snapshots."
+//	@Failure		422			{object}	ErrorEnvelope					"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope					"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspacesnapshots/{namespace} [get]
+func (a *App) GetWorkspaceSnapshotsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbList, auth.VolumeSnapshots, auth.ResourcePolicyResourceMeta{Namespace: namespace}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	snapshots, err := a.repositories.WorkspaceSnapshot.GetWorkspaceSnapshots(r.Context(), namespace)
+	if err != nil {
+		a.serverErrorResponse(w, r, err)
+		return
+	}
+
+	responseEnvelope := &WorkspaceSnapshotListEnvelope{Data: snapshots}
+	a.dataResponse(w, r, responseEnvelope)
+}
+
+// GetWorkspaceSnapshotHandler retrieves a specific workspace snapshot by namespace and name.
+//
+//	@Summary		Get workspace snapshot
+//	@Description	Returns details of a specific workspace snapshot identified by namespace and name.
+//	@Tags			workspacesnapshots
+//	@ID				getWorkspaceSnapshot
+//	@Produce		application/json
+//	@Param			namespace	path		string						true	"Namespace name"			extensions(x-example=my-namespace)
+//	@Param			name		path		string						true	"Workspace snapshot name"	extensions(x-example=my-snapshot)
+//	@Success		200			{object}	WorkspaceSnapshotEnvelope	"Successful operation. Returns the requested workspace snapshot details."
+//	@Failure		401			{object}	ErrorEnvelope				"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope				"Forbidden. User does not have permission to access the workspace snapshot."
+//	@Failure		404			{object}	ErrorEnvelope				"Not Found. Workspace snapshot does not exist."
+//	@Failure		422			{object}	ErrorEnvelope				"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope				"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspacesnapshots/{namespace}/{name} [get]
+func (a *App) GetWorkspaceSnapshotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	snapshotName := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateFieldIsDNS1123Label(field.NewPath(constants.ResourceNamePathParam), snapshotName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbList, auth.VolumeSnapshots, auth.ResourcePolicyResourceMeta{Namespace: namespace}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	snapshot, err := a.repositories.WorkspaceSnapshot.GetWorkspaceSnapshot(r.Context(), namespace, snapshotName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceSnapshotNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		a.serverErrorResponse(w, r, err)
+		return
+	}
+
+	responseEnvelope := &WorkspaceSnapshotEnvelope{Data: snapshot}
+	a.dataResponse(w, r, responseEnvelope)
+}
+
+// DeleteWorkspaceSnapshotHandler deletes a specific workspace snapshot by namespace and name.
+//
+//	@Summary		Delete workspace snapshot
+//	@Description	Deletes the VolumeSnapshots of a workspace snapshot. Workspaces cloned from the snapshot are not affected.
+//	@Tags			workspacesnapshots
+//	@ID				deleteWorkspaceSnapshot
+//	@Param			namespace	path	string	true	"Namespace name"			extensions(x-example=my-namespace)
+//	@Param			name		path	string	true	"Workspace snapshot name"	extensions(x-example=my-snapshot)
+//	@Success		204			"No Content"
+//	@Failure		401			{object}	ErrorEnvelope	"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope	"Forbidden. User does not have permission to delete the workspace snapshot."
+//	@Failure		404			{object}	ErrorEnvelope	"Not Found. Workspace snapshot does not exist."
+//	@Failure		422			{object}	ErrorEnvelope	"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope	"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspacesnapshots/{namespace}/{name} [delete]
+func (a *App) DeleteWorkspaceSnapshotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	snapshotName := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateFieldIsDNS1123Label(field.NewPath(constants.ResourceNamePathParam), snapshotName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// =========================== AUTH ===========================
+	// NOTE: a workspace snapshot is made of several VolumeSnapshots, so we don't restrict the policies by name
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbList, auth.VolumeSnapshots, auth.ResourcePolicyResourceMeta{Namespace: namespace}),
+		auth.NewResourcePolicy(auth.VerbDelete, auth.VolumeSnapshots, auth.ResourcePolicyResourceMeta{Namespace: namespace}),
+	}
+	if _, ok := a.requireAuth(w, r, authPolicies); !ok {
+		return
+	}
+	// ============================================================
+
+	err := a.repositories.WorkspaceSnapshot.DeleteWorkspaceSnapshot(r.Context(), namespace, snapshotName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceSnapshotNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error deleting workspace snapshot: %w", err))
+		return
+	}
+
+	a.deletedResponse(w, r)
+}
+
+// CloneWorkspaceSnapshotHandler creates a new workspace from a workspace snapshot.
+//
+//	@Summary		Clone a workspace snapshot
+//	@Description	Restores a PVC from each volume of a workspace snapshot, and creates a new workspace with the same kind and pod template options as the snapshotted workspace, mounting the restored PVCs.
+//	@Tags			workspacesnapshots
+//	@ID				cloneWorkspaceSnapshot
+//	@Accept			json
+//	@Produce		json
+//	@Param			namespace	path		string							true	"Namespace name"			extensions(x-example=my-namespace)
+//	@Param			name		path		string							true	"Workspace snapshot name"	extensions(x-example=my-snapshot)
+//	@Param			body		body		WorkspaceSnapshotCloneEnvelope	true	"Workspace clone configuration"
+//	@Success		201			{object}	WorkspaceCreateEnvelope			"Workspace created successfully"
+//	@Failure		400			{object}	ErrorEnvelope					"Bad Request."
+//	@Failure		401			{object}	ErrorEnvelope					"Unauthorized. Authentication is required."
+//	@Failure		403			{object}	ErrorEnvelope					"Forbidden. User does not have permission to clone the workspace snapshot."
+//	@Failure		404			{object}	ErrorEnvelope					"Not Found. Workspace snapshot does not exist."
+//	@Failure		409			{object}	ErrorEnvelope					"Conflict. Workspace snapshot is not ready, or a workspace or PVC with the same name already exists."
+//	@Failure		413			{object}	ErrorEnvelope					"Request Entity Too Large. The request body is too large."
+//	@Failure		415			{object}	ErrorEnvelope					"Unsupported Media Type. Content-Type header is not correct."
+//	@Failure		422			{object}	ErrorEnvelope					"Unprocessable Entity. Validation error."
+//	@Failure		500			{object}	ErrorEnvelope					"Internal server error. An unexpected error occurred on the server."
+//	@Router			/workspacesnapshots/{namespace}/{name}/actions/clone [post]
+func (a *App) CloneWorkspaceSnapshotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	namespace := ps.ByName(constants.NamespacePathParam)
+	snapshotName := ps.ByName(constants.ResourceNamePathParam)
+
+	// validate path parameters
+	var valErrs field.ErrorList
+	valErrs = append(valErrs, helper.ValidateKubernetesNamespaceName(field.NewPath(constants.NamespacePathParam), namespace)...)
+	valErrs = append(valErrs, helper.ValidateFieldIsDNS1123Label(field.NewPath(constants.ResourceNamePathParam), snapshotName)...)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgPathParamsInvalid, valErrs, nil)
+		return
+	}
+
+	// validate the Content-Type header
+	if success := a.ValidateContentType(w, r, constants.MediaTypeJson); !success {
+		return
+	}
+
+	// decode the request body
+	bodyEnvelope := &WorkspaceSnapshotCloneEnvelope{}
+	err := a.DecodeJSON(r, bodyEnvelope)
+	if err != nil {
+		if a.IsMaxBytesError(err) {
+			a.requestEntityTooLargeResponse(w, r, err)
+			return
+		}
+		a.badRequestResponse(w, r, fmt.Errorf("error decoding request body: %w", err))
+		return
+	}
+
+	// validate the request body
+	dataPath := field.NewPath("data")
+	if bodyEnvelope.Data == nil {
+		valErrs = field.ErrorList{field.Required(dataPath, "data is required")}
+		a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+		return
+	}
+	valErrs = bodyEnvelope.Data.Validate(dataPath)
+	if len(valErrs) > 0 {
+		a.failedValidationResponse(w, r, errMsgRequestBodyInvalid, valErrs, nil)
+		return
+	}
+
+	// give the request data a clear name
+	snapshotClone := bodyEnvelope.Data
+
+	// =========================== AUTH ===========================
+	authPolicies := []*auth.ResourcePolicy{
+		auth.NewResourcePolicy(auth.VerbList, auth.VolumeSnapshots, auth.ResourcePolicyResourceMeta{Namespace: namespace}),
+		auth.NewResourcePolicy(auth.VerbCreate, auth.PersistentVolumeClaims, auth.ResourcePolicyResourceMeta{Namespace: namespace}),
+		auth.NewResourcePolicy(auth.VerbCreate, auth.Workspaces, auth.ResourcePolicyResourceMeta{Namespace: namespace, Name: snapshotClone.Name}),
+	}
+	actor, ok := a.requireAuth(w, r, authPolicies)
+	if !ok {
+		return
+	}
+	// ============================================================
+
+	createdWorkspace, err := a.repositories.WorkspaceSnapshot.CloneWorkspaceSnapshot(r.Context(), actor, snapshotClone, namespace, snapshotName)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceSnapshotNotFound) {
+			a.notFoundResponse(w, r)
+			return
+		}
+		if helper.IsInternalValidationError(err) {
+			fieldErrs := helper.FieldErrorsFromInternalValidationError(err)
+			a.failedValidationResponse(w, r, errMsgInternalValidation, fieldErrs, nil)
+			return
+		}
+		if errors.Is(err, repository.ErrWorkspaceSnapshotNotReady) ||
+			errors.Is(err, repository.ErrWorkspaceAlreadyExists) ||
+			errors.Is(err, repository.ErrPVCAlreadyExists) {
+			causes := helper.StatusCausesFromAPIStatus(err)
+			a.conflictResponse(w, r, err, causes)
+			return
+		}
+		if apierrors.IsInvalid(err) {
+			causes := helper.StatusCausesFromAPIStatus(err)
+			a.failedValidationResponse(w, r, errMsgKubernetesValidation, nil, causes)
+			return
+		}
+		a.serverErrorResponse(w, r, fmt.Errorf("error cloning workspace snapshot: %w", err))
+		return
+	}
+
+	// calculate the GET location for the created workspace (for the Location header)
+	location := a.LocationGetWorkspace(namespace, createdWorkspace.Name)
+
+	responseEnvelope := &WorkspaceCreateEnvelope{Data: createdWorkspace}
+	a.createdResponse(w, r, responseEnvelope, location)
+}
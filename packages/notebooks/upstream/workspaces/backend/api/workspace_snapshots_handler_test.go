@@ -0,0 +1,580 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/api/constants"
+	modelsWorkspaces "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspaces"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspacesnapshots"
+)
+
+// newWorkspaceSnapshotTestPVC returns a PVC which can be snapshotted by the tests.
+func newWorkspaceSnapshotTestPVC(name string, namespace string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+}
+
+// expectPVCDeleted asserts that a PVC does not exist, or is being deleted.
+func expectPVCDeleted(key types.NamespacedName) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := k8sClient.Get(ctx, key, pvc)
+	if err != nil {
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	} else {
+		// in envtest, PVCs may have finalizers preventing immediate deletion
+		Expect(pvc.DeletionTimestamp).NotTo(BeNil())
+	}
+}
+
+var _ = Describe("Workspace Snapshots Handler", func() {
+
+	// NOTE: the tests in this context work on the same resources, they must be run in order.
+	//       also, they assume a specific state of the cluster, so cannot be run in parallel with other tests.
+	//       therefore, we run them using the `Ordered` and `Serial` Ginkgo decorators.
+	Context("with an existing Workspace", Serial, Ordered, func() {
+
+		const (
+			namespaceName1 = "ws-snapshot-ns1"
+			nonAdminUser   = "non-admin-user"
+
+			// NOTE: these are the PVCs mounted by `NewExampleWorkspace`
+			homePVCName = "my-home-pvc"
+			dataPVCName = "my-repositories-pvc"
+		)
+
+		var (
+			workspaceName1    string
+			workspaceKindName string
+			snapshotName1     string
+			cloneName1        string
+			cloneName2        string
+		)
+
+		// doSnapshotRequest calls SnapshotActionWorkspaceHandler for a workspace.
+		doSnapshotRequest := func(user string, workspaceName string, snapshotName string) *httptest.ResponseRecorder {
+			requestBody := &WorkspaceSnapshotCreateEnvelope{
+				Data: &models.WorkspaceSnapshotCreate{
+					Name: snapshotName,
+				},
+			}
+			bodyBytes, err := json.Marshal(requestBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			path := strings.Replace(constants.SnapshotWorkspacePath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			path = strings.Replace(path, ":"+constants.ResourceNamePathParam, workspaceName, 1)
+			req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(string(bodyBytes)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+			req.Header.Set("Content-Type", "application/json")
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: workspaceName},
+			}
+			rr := httptest.NewRecorder()
+			a.SnapshotActionWorkspaceHandler(rr, req, ps)
+			return rr
+		}
+
+		// doSnapshotByNameRequest calls a handler which takes the name of a workspace snapshot.
+		doSnapshotByNameRequest := func(handler httprouter.Handle, method string, user string, snapshotName string) *httptest.ResponseRecorder {
+			path := strings.Replace(constants.WorkspaceSnapshotsByNamePath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			path = strings.Replace(path, ":"+constants.ResourceNamePathParam, snapshotName, 1)
+			req, err := http.NewRequest(method, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: snapshotName},
+			}
+			rr := httptest.NewRecorder()
+			handler(rr, req, ps)
+			return rr
+		}
+
+		// doCloneRequest calls CloneWorkspaceSnapshotHandler for a workspace snapshot.
+		doCloneRequest := func(user string, snapshotName string, cloneName string) *httptest.ResponseRecorder {
+			requestBody := &WorkspaceSnapshotCloneEnvelope{
+				Data: &models.WorkspaceSnapshotClone{
+					Name:   cloneName,
+					Paused: true,
+				},
+			}
+			bodyBytes, err := json.Marshal(requestBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			path := strings.Replace(constants.CloneWorkspaceSnapshotPath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			path = strings.Replace(path, ":"+constants.ResourceNamePathParam, snapshotName, 1)
+			req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(string(bodyBytes)))
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set(userIdHeader, user)
+			req.Header.Set("Content-Type", "application/json")
+
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+				httprouter.Param{Key: constants.ResourceNamePathParam, Value: snapshotName},
+			}
+			rr := httptest.NewRecorder()
+			a.CloneWorkspaceSnapshotHandler(rr, req, ps)
+			return rr
+		}
+
+		// listVolumeSnapshots returns the VolumeSnapshots of a workspace snapshot.
+		listVolumeSnapshots := func(snapshotName string) []unstructured.Unstructured {
+			volumeSnapshotList := &unstructured.UnstructuredList{}
+			volumeSnapshotList.SetGroupVersionKind(models.VolumeSnapshotListGVK)
+			Expect(k8sClient.List(ctx, volumeSnapshotList,
+				client.InNamespace(namespaceName1),
+				client.MatchingLabels{models.LabelWorkspaceSnapshot: snapshotName},
+			)).To(Succeed())
+			return volumeSnapshotList.Items
+		}
+
+		BeforeAll(func() {
+			uniqueName := "ws-snapshot-test"
+			workspaceName1 = fmt.Sprintf("workspace-1-%s", uniqueName)
+			workspaceKindName = fmt.Sprintf("workspacekind-%s", uniqueName)
+			snapshotName1 = fmt.Sprintf("snapshot-1-%s", uniqueName)
+			cloneName1 = fmt.Sprintf("clone-1-%s", uniqueName)
+			cloneName2 = fmt.Sprintf("clone-2-%s", uniqueName)
+
+			By("creating Namespace 1")
+			namespace1 := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Create(ctx, namespace1)).To(Succeed())
+
+			By("creating the home and data PVCs")
+			Expect(k8sClient.Create(ctx, newWorkspaceSnapshotTestPVC(homePVCName, namespaceName1))).To(Succeed())
+			Expect(k8sClient.Create(ctx, newWorkspaceSnapshotTestPVC(dataPVCName, namespaceName1))).To(Succeed())
+
+			By("creating a WorkspaceKind")
+			workspaceKind := NewExampleWorkspaceKind(workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspaceKind)).To(Succeed())
+
+			By("creating Workspace 1 in Namespace 1")
+			workspace1 := NewExampleWorkspace(workspaceName1, namespaceName1, workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspace1)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			By("deleting the Workspaces from Namespace 1")
+			for _, workspaceName := range []string{workspaceName1, cloneName1} {
+				workspace := &kubefloworgv1beta1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      workspaceName,
+						Namespace: namespaceName1,
+					},
+				}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, workspace))).To(Succeed())
+			}
+
+			By("deleting the PVCs from Namespace 1")
+			pvcNames := []string{
+				homePVCName,
+				dataPVCName,
+				cloneName1 + "-home",
+				cloneName1 + "-data-0",
+				cloneName2 + "-home",
+			}
+			for _, pvcName := range pvcNames {
+				pvc := &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      pvcName,
+						Namespace: namespaceName1,
+					},
+				}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, pvc))).To(Succeed())
+			}
+
+			By("deleting WorkspaceKind")
+			workspaceKind := &kubefloworgv1beta1.WorkspaceKind{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: workspaceKindName,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, workspaceKind)).To(Succeed())
+
+			By("deleting Namespace 1")
+			namespace1 := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespaceName1,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, namespace1)).To(Succeed())
+		})
+
+		It("should return 403 when snapshotting a workspace without permission", func() {
+			By("executing SnapshotActionWorkspaceHandler as a non-admin user")
+			rr := doSnapshotRequest(nonAdminUser, workspaceName1, snapshotName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring no VolumeSnapshots were created")
+			Expect(listVolumeSnapshots(snapshotName1)).To(BeEmpty())
+		})
+
+		It("should return 404 when snapshotting a non-existent workspace", func() {
+			By("executing SnapshotActionWorkspaceHandler")
+			rr := doSnapshotRequest(adminUser, "non-existent-workspace", snapshotName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should snapshot a workspace successfully", func() {
+			By("executing SnapshotActionWorkspaceHandler")
+			rr := doSnapshotRequest(adminUser, workspaceName1, snapshotName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusCreated), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the snapshot")
+			var response WorkspaceSnapshotEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).NotTo(BeNil())
+			Expect(response.Data.Name).To(Equal(snapshotName1))
+			Expect(response.Data.Workspace.Name).To(Equal(workspaceName1))
+			Expect(response.Data.Workspace.Kind).To(Equal(workspaceKindName))
+			Expect(response.Data.ReadyToUse).To(BeFalse())
+			Expect(response.Data.Volumes).To(HaveLen(2))
+			Expect(response.Data.Volumes[0].Type).To(Equal(models.VolumeTypeHome))
+			Expect(response.Data.Volumes[0].SourcePVCName).To(Equal(homePVCName))
+			Expect(response.Data.Volumes[1].Type).To(Equal(models.VolumeTypeData))
+			Expect(response.Data.Volumes[1].SourcePVCName).To(Equal(dataPVCName))
+
+			By("ensuring a VolumeSnapshot was created for each volume")
+			volumeSnapshots := listVolumeSnapshots(snapshotName1)
+			Expect(volumeSnapshots).To(HaveLen(2))
+			for _, volumeSnapshot := range volumeSnapshots {
+				Expect(volumeSnapshot.GetLabels()).To(HaveKeyWithValue(models.LabelWorkspaceSnapshotSource, workspaceName1))
+			}
+		})
+
+		It("should return 409 when the workspace snapshot already exists", func() {
+			By("executing SnapshotActionWorkspaceHandler")
+			rr := doSnapshotRequest(adminUser, workspaceName1, snapshotName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusConflict), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring no extra VolumeSnapshots were created")
+			Expect(listVolumeSnapshots(snapshotName1)).To(HaveLen(2))
+		})
+
+		It("should list workspace snapshots successfully", func() {
+			By("creating the HTTP request")
+			path := strings.Replace(constants.WorkspaceSnapshotsByNamespacePath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			req, err := http.NewRequest(http.MethodGet, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("setting the auth headers")
+			req.Header.Set(userIdHeader, adminUser)
+
+			By("executing GetWorkspaceSnapshotsHandler")
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+			}
+			rr := httptest.NewRecorder()
+			a.GetWorkspaceSnapshotsHandler(rr, req, ps)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusOK), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the snapshot")
+			var response WorkspaceSnapshotListEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).To(HaveLen(1))
+			Expect(response.Data[0].Name).To(Equal(snapshotName1))
+		})
+
+		It("should return 403 when listing workspace snapshots without permission", func() {
+			By("creating the HTTP request")
+			path := strings.Replace(constants.WorkspaceSnapshotsByNamespacePath, ":"+constants.NamespacePathParam, namespaceName1, 1)
+			req, err := http.NewRequest(http.MethodGet, path, http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("setting the auth headers")
+			req.Header.Set(userIdHeader, nonAdminUser)
+
+			By("executing GetWorkspaceSnapshotsHandler")
+			ps := httprouter.Params{
+				httprouter.Param{Key: constants.NamespacePathParam, Value: namespaceName1},
+			}
+			rr := httptest.NewRecorder()
+			a.GetWorkspaceSnapshotsHandler(rr, req, ps)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should get a workspace snapshot successfully", func() {
+			By("executing GetWorkspaceSnapshotHandler")
+			rr := doSnapshotByNameRequest(a.GetWorkspaceSnapshotHandler, http.MethodGet, adminUser, snapshotName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusOK), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the snapshot")
+			var response WorkspaceSnapshotEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).NotTo(BeNil())
+			Expect(response.Data.Name).To(Equal(snapshotName1))
+			Expect(response.Data.Volumes).To(HaveLen(2))
+		})
+
+		It("should return 404 for a non-existent workspace snapshot", func() {
+			By("executing GetWorkspaceSnapshotHandler")
+			rr := doSnapshotByNameRequest(a.GetWorkspaceSnapshotHandler, http.MethodGet, adminUser, "non-existent-snapshot")
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 409 when cloning a workspace snapshot which is not ready", func() {
+			By("executing CloneWorkspaceSnapshotHandler")
+			rr := doCloneRequest(adminUser, snapshotName1, cloneName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusConflict), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring no PVCs were restored")
+			pvc := &corev1.PersistentVolumeClaim{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: cloneName1 + "-home", Namespace: namespaceName1}, pvc)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should return 404 when cloning a non-existent workspace snapshot", func() {
+			By("executing CloneWorkspaceSnapshotHandler")
+			rr := doCloneRequest(adminUser, "non-existent-snapshot", cloneName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should return 403 when cloning a workspace snapshot without permission", func() {
+			By("executing CloneWorkspaceSnapshotHandler as a non-admin user")
+			rr := doCloneRequest(nonAdminUser, snapshotName1, cloneName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should clone a workspace snapshot successfully", func() {
+			By("marking the VolumeSnapshots as ready to use")
+			// NOTE: there is no CSI snapshot controller in envtest, so we set the status ourselves
+			volumeSnapshots := listVolumeSnapshots(snapshotName1)
+			for i := range volumeSnapshots {
+				volumeSnapshot := &volumeSnapshots[i]
+				Expect(unstructured.SetNestedField(volumeSnapshot.Object, true, "status", "readyToUse")).To(Succeed())
+				Expect(unstructured.SetNestedField(volumeSnapshot.Object, "2Gi", "status", "restoreSize")).To(Succeed())
+				Expect(k8sClient.Status().Update(ctx, volumeSnapshot)).To(Succeed())
+			}
+
+			By("executing CloneWorkspaceSnapshotHandler")
+			rr := doCloneRequest(adminUser, snapshotName1, cloneName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusCreated), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("reading the HTTP response body")
+			body, err := io.ReadAll(rs.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("verifying the response contains the cloned workspace")
+			var response WorkspaceCreateEnvelope
+			err = json.Unmarshal(body, &response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Data).NotTo(BeNil())
+			Expect(response.Data.Name).To(Equal(cloneName1))
+			Expect(response.Data.Kind).To(Equal(workspaceKindName))
+			Expect(response.Data.Paused).To(BeTrue())
+			Expect(response.Data.PodTemplate.Volumes.Home).To(HaveValue(Equal(cloneName1 + "-home")))
+			Expect(response.Data.PodTemplate.Volumes.Data).To(ConsistOf(modelsWorkspaces.PodVolumeMount{
+				PVCName:   cloneName1 + "-data-0",
+				MountPath: "/repositories/my-repositories",
+			}))
+
+			By("getting the cloned Workspace from the Kubernetes API")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cloneName1, Namespace: namespaceName1}, workspace)).To(Succeed())
+			Expect(workspace.Spec.PodTemplate.Volumes.Home).To(HaveValue(Equal(cloneName1 + "-home")))
+
+			By("ensuring the PVCs were restored from the VolumeSnapshots")
+			for _, suffix := range []string{"-home", "-data-0"} {
+				pvc := &corev1.PersistentVolumeClaim{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cloneName1 + suffix, Namespace: namespaceName1}, pvc)).To(Succeed())
+				Expect(pvc.Spec.DataSource).NotTo(BeNil())
+				Expect(pvc.Spec.DataSource.Kind).To(Equal(models.VolumeSnapshotGVK.Kind))
+				Expect(pvc.Spec.DataSource.Name).To(Equal(snapshotName1 + suffix))
+				Expect(pvc.Spec.Resources.Requests.Storage().String()).To(Equal("2Gi"))
+			}
+		})
+
+		It("should return 409 when the cloned workspace already exists", func() {
+			By("executing CloneWorkspaceSnapshotHandler")
+			rr := doCloneRequest(adminUser, snapshotName1, cloneName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusConflict), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+
+		It("should roll back the restored PVCs when a clone fails", func() {
+			By("creating a PVC with the name of the second restored PVC")
+			// NOTE: VolumeSnapshots are listed in name order, so the `-data-0` PVC is restored before the `-home` PVC
+			Expect(k8sClient.Create(ctx, newWorkspaceSnapshotTestPVC(cloneName2+"-home", namespaceName1))).To(Succeed())
+
+			By("executing CloneWorkspaceSnapshotHandler")
+			rr := doCloneRequest(adminUser, snapshotName1, cloneName2)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusConflict), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the first restored PVC was rolled back")
+			expectPVCDeleted(types.NamespacedName{Name: cloneName2 + "-data-0", Namespace: namespaceName1})
+
+			By("ensuring the existing PVC was not deleted")
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cloneName2 + "-home", Namespace: namespaceName1}, pvc)).To(Succeed())
+			Expect(pvc.DeletionTimestamp).To(BeNil())
+
+			By("ensuring the Workspace was not created")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: cloneName2, Namespace: namespaceName1}, workspace)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should return 403 when deleting a workspace snapshot without permission", func() {
+			By("executing DeleteWorkspaceSnapshotHandler as a non-admin user")
+			rr := doSnapshotByNameRequest(a.DeleteWorkspaceSnapshotHandler, http.MethodDelete, nonAdminUser, snapshotName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusForbidden), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the VolumeSnapshots were not deleted")
+			Expect(listVolumeSnapshots(snapshotName1)).To(HaveLen(2))
+		})
+
+		It("should delete a workspace snapshot successfully", func() {
+			By("executing DeleteWorkspaceSnapshotHandler")
+			rr := doSnapshotByNameRequest(a.DeleteWorkspaceSnapshotHandler, http.MethodDelete, adminUser, snapshotName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNoContent), descUnexpectedHTTPStatus, rr.Body.String())
+
+			By("ensuring the VolumeSnapshots were deleted")
+			Expect(listVolumeSnapshots(snapshotName1)).To(BeEmpty())
+
+			By("ensuring the PVCs restored from the snapshot were not deleted")
+			pvc := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cloneName1 + "-home", Namespace: namespaceName1}, pvc)).To(Succeed())
+			Expect(pvc.DeletionTimestamp).To(BeNil())
+		})
+
+		It("should return 404 when deleting a non-existent workspace snapshot", func() {
+			By("executing DeleteWorkspaceSnapshotHandler")
+			rr := doSnapshotByNameRequest(a.DeleteWorkspaceSnapshotHandler, http.MethodDelete, adminUser, snapshotName1)
+			rs := rr.Result()
+			defer rs.Body.Close()
+
+			By("verifying the HTTP response status code")
+			Expect(rs.StatusCode).To(Equal(http.StatusNotFound), descUnexpectedHTTPStatus, rr.Body.String())
+		})
+	})
+})
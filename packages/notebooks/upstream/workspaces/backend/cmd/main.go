@@ -131,6 +131,13 @@ func main() {
 		"Directory containing frontend static assets",
 	)
 
+	flag.StringVar(
+		&cfg.PrometheusURL,
+		"prometheus-url",
+		getEnvAsStr("PROMETHEUS_URL", ""),
+		"Base URL of a Prometheus-compatible API for workspace resource usage metrics (disabled if empty)",
+	)
+
 	flag.Parse()
 
 	// Override Swagger metadata with runtime config (must be after flag.Parse)
@@ -75,12 +75,14 @@ func NewRequestAuthorizer(restConfig *rest.Config, httpClient *http.Client) (aut
 
 // resourceGVRMap maps resource policy resources to their API group and version.
 var resourceGVRMap = map[ResourcePolicyResource]schema.GroupVersionResource{
-	Namespaces:             corev1.SchemeGroupVersion.WithResource(string(Namespaces)),
-	PersistentVolumeClaims: corev1.SchemeGroupVersion.WithResource(string(PersistentVolumeClaims)),
-	Secrets:                corev1.SchemeGroupVersion.WithResource(string(Secrets)),
-	StorageClasses:         storagev1.SchemeGroupVersion.WithResource(string(StorageClasses)),
-	WorkspaceKinds:         kubefloworgv1beta1.GroupVersion.WithResource(string(WorkspaceKinds)),
-	Workspaces:             kubefloworgv1beta1.GroupVersion.WithResource(string(Workspaces)),
+	Namespaces:                corev1.SchemeGroupVersion.WithResource(string(Namespaces)),
+	PersistentVolumeClaims:    corev1.SchemeGroupVersion.WithResource(string(PersistentVolumeClaims)),
+	Secrets:                   corev1.SchemeGroupVersion.WithResource(string(Secrets)),
+	StorageClasses:            storagev1.SchemeGroupVersion.WithResource(string(StorageClasses)),
+	VolumeSnapshots:           schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: string(VolumeSnapshots)},
+	WorkspaceKinds:            kubefloworgv1beta1.GroupVersion.WithResource(string(WorkspaceKinds)),
+	Workspaces:                kubefloworgv1beta1.GroupVersion.WithResource(string(Workspaces)),
+	WorkspaceUpgradeCampaigns: kubefloworgv1beta1.GroupVersion.WithResource(string(WorkspaceUpgradeCampaigns)),
 }
 
 // NewResourcePolicy returns a resource policy for the given verb and resource type.
@@ -144,12 +146,14 @@ const (
 	//          URLs of Kubernetes APIs are structured as: /apis/<group>/<version>/<plural>
 	//
 
-	Namespaces             ResourcePolicyResource = "namespaces"
-	PersistentVolumeClaims ResourcePolicyResource = "persistentvolumeclaims"
-	Secrets                ResourcePolicyResource = "secrets"
-	StorageClasses         ResourcePolicyResource = "storageclasses"
-	WorkspaceKinds         ResourcePolicyResource = "workspacekinds"
-	Workspaces             ResourcePolicyResource = "workspaces"
+	Namespaces                ResourcePolicyResource = "namespaces"
+	PersistentVolumeClaims    ResourcePolicyResource = "persistentvolumeclaims"
+	Secrets                   ResourcePolicyResource = "secrets"
+	StorageClasses            ResourcePolicyResource = "storageclasses"
+	VolumeSnapshots           ResourcePolicyResource = "volumesnapshots"
+	WorkspaceKinds            ResourcePolicyResource = "workspacekinds"
+	Workspaces                ResourcePolicyResource = "workspaces"
+	WorkspaceUpgradeCampaigns ResourcePolicyResource = "workspaceupgradecampaigns"
 )
 
 // ResourcePolicyResourceMeta selects specific resources based on their object metadata.
@@ -161,5 +165,6 @@ type ResourcePolicyResourceMeta struct {
 
 	// Name is the name of the resource which the action will be performed on.
 	// "" (empty) means the caller must be authorized to perform the action on all resources of this type.
+	// NOTE: shared workspaces are only accessible with a Name, as their Roles are scoped with `resourceNames`
 	Name string
 }
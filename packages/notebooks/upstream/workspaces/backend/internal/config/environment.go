@@ -36,4 +36,7 @@ type EnvConfig struct {
 	SwaggerScheme   string
 	// StaticAssetsDir is the directory containing frontend static assets
 	StaticAssetsDir string
+
+	// PrometheusURL is the base URL of a Prometheus-compatible API used for workspace resource usage metrics
+	PrometheusURL string
 }
@@ -0,0 +1,102 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// the volume metrics exported by the kubelet for each mounted PVC
+	// See: https://kubernetes.io/docs/reference/instrumentation/metrics/
+	kubeletVolumeCapacityMetric  = "kubelet_volume_stats_capacity_bytes"
+	kubeletVolumeUsedMetric      = "kubelet_volume_stats_used_bytes"
+	kubeletVolumeAvailableMetric = "kubelet_volume_stats_available_bytes"
+
+	// the label of the kubelet volume metrics with the PVC name
+	kubeletVolumePVCLabel = "persistentvolumeclaim"
+)
+
+// VolumeStats is the usage of one PVC, as reported by the kubelet
+type VolumeStats struct {
+	PVCName        string
+	CapacityBytes  *uint64
+	UsedBytes      *uint64
+	AvailableBytes *uint64
+}
+
+// NewVolumeStatsQuery returns the PromQL query for a kubelet volume metric of the given PVCs, by PVC.
+// The max is taken as a PVC mounted on multiple nodes (e.g. ReadWriteMany) is reported by each kubelet.
+func NewVolumeStatsQuery(metric, namespace string, pvcNames []string) string {
+	pvcRegexes := make([]string, len(pvcNames))
+	for i, pvcName := range pvcNames {
+		pvcRegexes[i] = regexp.QuoteMeta(pvcName)
+	}
+	selector := fmt.Sprintf(`namespace=%q, %s=~%q`, namespace, kubeletVolumePVCLabel, strings.Join(pvcRegexes, "|"))
+	return fmt.Sprintf(`max by (%s) (%s{%s})`, kubeletVolumePVCLabel, metric, selector)
+}
+
+// GetVolumeStats returns the usage of the given PVCs, from the kubelet volume metrics scraped by Prometheus.
+// PVCs which are not mounted by a running Pod are not reported by the kubelet, so they are not returned.
+func (c *PrometheusClient) GetVolumeStats(ctx context.Context, namespace string, pvcNames []string, t time.Time) ([]VolumeStats, error) {
+	if len(pvcNames) == 0 {
+		return []VolumeStats{}, nil
+	}
+
+	valuesByMetric := make(map[string]map[string]float64, 3)
+	for _, metric := range []string{kubeletVolumeCapacityMetric, kubeletVolumeUsedMetric, kubeletVolumeAvailableMetric} {
+		values, err := c.QueryByLabel(ctx, NewVolumeStatsQuery(metric, namespace, pvcNames), t, kubeletVolumePVCLabel)
+		if err != nil {
+			return nil, err
+		}
+		valuesByMetric[metric] = values
+	}
+
+	return getVolumeStats(pvcNames, valuesByMetric), nil
+}
+
+// getVolumeStats returns the usage of each PVC which has at least one kubelet volume metric, in the order of pvcNames
+func getVolumeStats(pvcNames []string, valuesByMetric map[string]map[string]float64) []VolumeStats {
+	bytesOf := func(metric, pvcName string) *uint64 {
+		value, ok := valuesByMetric[metric][pvcName]
+		if !ok || value < 0 {
+			return nil
+		}
+		bytes := uint64(math.Round(value))
+		return &bytes
+	}
+
+	volumeStats := make([]VolumeStats, 0, len(pvcNames))
+	for _, pvcName := range pvcNames {
+		stats := VolumeStats{
+			PVCName:        pvcName,
+			CapacityBytes:  bytesOf(kubeletVolumeCapacityMetric, pvcName),
+			UsedBytes:      bytesOf(kubeletVolumeUsedMetric, pvcName),
+			AvailableBytes: bytesOf(kubeletVolumeAvailableMetric, pvcName),
+		}
+		if stats.CapacityBytes == nil && stats.UsedBytes == nil && stats.AvailableBytes == nil {
+			continue
+		}
+		volumeStats = append(volumeStats, stats)
+	}
+	return volumeStats
+}
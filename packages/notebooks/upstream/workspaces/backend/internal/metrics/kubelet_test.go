@@ -0,0 +1,71 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("GetVolumeStats", func() {
+
+	It("should query the kubelet volume metrics of the PVCs", func() {
+		Expect(NewVolumeStatsQuery(kubeletVolumeUsedMetric, "default", []string{"home-pvc", "data.pvc"})).To(Equal(
+			`max by (persistentvolumeclaim) (kubelet_volume_stats_used_bytes{namespace="default", persistentvolumeclaim=~"home-pvc|data\\.pvc"})`,
+		))
+	})
+
+	It("should return the usage of the PVCs reported by the kubelet", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query().Get("query")
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case strings.Contains(query, kubeletVolumeCapacityMetric):
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"persistentvolumeclaim":"home-pvc"},"value":[1700000000,"100"]}]}}`))
+			case strings.Contains(query, kubeletVolumeUsedMetric):
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"persistentvolumeclaim":"home-pvc"},"value":[1700000000,"40"]}]}}`))
+			default:
+				_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"persistentvolumeclaim":"home-pvc"},"value":[1700000000,"60"]}]}}`))
+			}
+		}))
+		defer server.Close()
+
+		client := NewPrometheusClient(server.URL)
+		volumeStats, err := client.GetVolumeStats(context.Background(), "default", []string{"home-pvc", "data-pvc"}, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(volumeStats).To(Equal([]VolumeStats{
+			{
+				PVCName:        "home-pvc",
+				CapacityBytes:  ptr.To(uint64(100)),
+				UsedBytes:      ptr.To(uint64(40)),
+				AvailableBytes: ptr.To(uint64(60)),
+			},
+		}))
+	})
+
+	It("should not query Prometheus without PVCs", func() {
+		client := NewPrometheusClient("")
+		Expect(client.GetVolumeStats(context.Background(), "default", nil, time.Now())).To(BeEmpty())
+	})
+})
@@ -0,0 +1,229 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// the timeout of each request to the Prometheus API
+	prometheusTimeout = 10 * time.Second
+)
+
+var (
+	ErrPrometheusNotConfigured = errors.New("prometheus URL is not configured")
+)
+
+// Sample is a single value of a time series
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// PrometheusClient queries a Prometheus-compatible HTTP API (e.g. Prometheus, Thanos, Mimir).
+// See: https://prometheus.io/docs/prometheus/latest/querying/api/
+type PrometheusClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewPrometheusClient creates a new PrometheusClient for the given base URL.
+// If the base URL is empty, all queries return ErrPrometheusNotConfigured.
+func NewPrometheusClient(baseURL string) *PrometheusClient {
+	return &PrometheusClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: prometheusTimeout},
+	}
+}
+
+// Configured returns true if the client has a base URL.
+func (c *PrometheusClient) Configured() bool {
+	return c.baseURL != ""
+}
+
+// Query runs an instant query, and returns the value of its result.
+// The query must return at most one series, found is false if it returned none.
+func (c *PrometheusClient) Query(ctx context.Context, query string, t time.Time) (value float64, found bool, err error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("time", formatTime(t))
+
+	data, err := c.do(ctx, "/api/v1/query", params)
+	if err != nil {
+		return 0, false, err
+	}
+	if data.ResultType != "vector" {
+		return 0, false, fmt.Errorf("unexpected prometheus result type %q for instant query", data.ResultType)
+	}
+	if len(data.Result) == 0 {
+		return 0, false, nil
+	}
+	if len(data.Result) > 1 {
+		return 0, false, fmt.Errorf("prometheus query returned %d series, expected at most 1", len(data.Result))
+	}
+
+	sample, err := parseSample(data.Result[0].Value)
+	if err != nil {
+		return 0, false, err
+	}
+	return sample.Value, true, nil
+}
+
+// QueryByLabel runs an instant query, and returns the value of each series of its result by the value of the given label.
+func (c *PrometheusClient) QueryByLabel(ctx context.Context, query string, t time.Time, label string) (map[string]float64, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("time", formatTime(t))
+
+	data, err := c.do(ctx, "/api/v1/query", params)
+	if err != nil {
+		return nil, err
+	}
+	if data.ResultType != "vector" {
+		return nil, fmt.Errorf("unexpected prometheus result type %q for instant query", data.ResultType)
+	}
+
+	values := make(map[string]float64, len(data.Result))
+	for _, series := range data.Result {
+		sample, err := parseSample(series.Value)
+		if err != nil {
+			return nil, err
+		}
+		values[series.Metric[label]] = sample.Value
+	}
+	return values, nil
+}
+
+// QueryRange runs a range query, and returns the samples of its result.
+// The query must return at most one series, no samples are returned if it returned none.
+func (c *PrometheusClient) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]Sample, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", formatTime(start))
+	params.Set("end", formatTime(end))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	data, err := c.do(ctx, "/api/v1/query_range", params)
+	if err != nil {
+		return nil, err
+	}
+	if data.ResultType != "matrix" {
+		return nil, fmt.Errorf("unexpected prometheus result type %q for range query", data.ResultType)
+	}
+	if len(data.Result) == 0 {
+		return nil, nil
+	}
+	if len(data.Result) > 1 {
+		return nil, fmt.Errorf("prometheus query returned %d series, expected at most 1", len(data.Result))
+	}
+
+	samples := make([]Sample, 0, len(data.Result[0].Values))
+	for _, rawSample := range data.Result[0].Values {
+		sample, err := parseSample(rawSample)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// prometheusResponse is the envelope of all Prometheus HTTP API responses
+type prometheusResponse struct {
+	Status    string         `json:"status"`
+	Data      prometheusData `json:"data"`
+	ErrorType string         `json:"errorType"`
+	Error     string         `json:"error"`
+}
+
+type prometheusData struct {
+	ResultType string             `json:"resultType"`
+	Result     []prometheusSeries `json:"result"`
+}
+
+type prometheusSeries struct {
+	Metric map[string]string `json:"metric"`
+
+	// Value is set for "vector" results, as [<unix_time>, "<value>"]
+	Value []any `json:"value"`
+
+	// Values is set for "matrix" results, as [[<unix_time>, "<value>"], ...]
+	Values [][]any `json:"values"`
+}
+
+func (c *PrometheusClient) do(ctx context.Context, path string, params url.Values) (*prometheusData, error) {
+	if !c.Configured() {
+		return nil, ErrPrometheusNotConfigured
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build prometheus request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prometheus: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	// NOTE: Prometheus returns a JSON body for most errors (e.g. 400 for invalid queries)
+	body := &prometheusResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(body); err != nil {
+		return nil, fmt.Errorf("failed to decode prometheus response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if body.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed (HTTP %d): %s: %s", resp.StatusCode, body.ErrorType, body.Error)
+	}
+	return &body.Data, nil
+}
+
+// parseSample parses a [<unix_time>, "<value>"] pair
+func parseSample(raw []any) (Sample, error) {
+	if len(raw) != 2 {
+		return Sample{}, fmt.Errorf("invalid prometheus sample: %v", raw)
+	}
+	unixTime, ok := raw[0].(float64)
+	if !ok {
+		return Sample{}, fmt.Errorf("invalid prometheus sample time: %v", raw[0])
+	}
+	valueStr, ok := raw[1].(string)
+	if !ok {
+		return Sample{}, fmt.Errorf("invalid prometheus sample value: %v", raw[1])
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("invalid prometheus sample value: %w", err)
+	}
+	sec, frac := int64(unixTime), unixTime-float64(int64(unixTime))
+	return Sample{
+		Time:  time.Unix(sec, int64(frac*float64(time.Second))),
+		Value: value,
+	}, nil
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', -1, 64)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusClient", func() {
+
+	var (
+		server    *httptest.Server
+		client    *PrometheusClient
+		response  string
+		lastQuery string
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lastQuery = r.URL.Query().Get("query")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(response))
+		}))
+		client = NewPrometheusClient(server.URL + "/")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should return ErrPrometheusNotConfigured without a base URL", func() {
+		client := NewPrometheusClient("")
+		Expect(client.Configured()).To(BeFalse())
+
+		_, _, err := client.Query(context.Background(), "up", time.Now())
+		Expect(err).To(MatchError(ErrPrometheusNotConfigured))
+	})
+
+	It("should parse an instant query result", func() {
+		response = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000.5,"0.25"]}]}}`
+
+		value, found, err := client.Query(context.Background(), "up", time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal(0.25))
+		Expect(lastQuery).To(Equal("up"))
+	})
+
+	It("should return not found for an empty instant query result", func() {
+		response = `{"status":"success","data":{"resultType":"vector","result":[]}}`
+
+		_, found, err := client.Query(context.Background(), "up", time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("should parse a range query result", func() {
+		response = `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1700000000,"1"],[1700000900,"2"]]}]}}`
+
+		samples, err := client.QueryRange(context.Background(), "up", time.Now().Add(-time.Hour), time.Now(), 15*time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples).To(Equal([]Sample{
+			{Time: time.Unix(1700000000, 0), Value: 1},
+			{Time: time.Unix(1700000900, 0), Value: 2},
+		}))
+	})
+
+	It("should return an error for a failed query", func() {
+		response = `{"status":"error","errorType":"bad_data","error":"parse error"}`
+
+		_, _, err := client.Query(context.Background(), "up{", time.Now())
+		Expect(err).To(MatchError(ContainSubstring("bad_data: parse error")))
+	})
+
+	It("should return the value of each series by label", func() {
+		response = `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pvc":"a"},"value":[1,"1"]},{"metric":{"pvc":"b"},"value":[1,"2"]}]}}`
+
+		values, err := client.QueryByLabel(context.Background(), "up", time.Now(), "pvc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal(map[string]float64{"a": 1, "b": 2}))
+	})
+
+	It("should return an error for multiple series", func() {
+		response = `{"status":"success","data":{"resultType":"vector","result":[{"value":[1,"1"]},{"value":[1,"2"]}]}}`
+
+		_, _, err := client.Query(context.Background(), "up", time.Now())
+		Expect(err).To(MatchError(ContainSubstring("returned 2 series")))
+	})
+})
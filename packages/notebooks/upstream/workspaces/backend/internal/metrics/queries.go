@@ -0,0 +1,58 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// the name of the main container of Workspace Pods
+	// NOTE: this must match the container name set by the controller
+	workspaceContainerName = "main"
+
+	// the window over which CPU usage rates are computed
+	cpuRateWindow = "5m"
+)
+
+// WorkspaceQueries are the PromQL queries for the resource usage of one Workspace.
+type WorkspaceQueries struct {
+	// CPU is the CPU usage of the main container (cores)
+	CPU string
+
+	// Memory is the working set memory of the main container (bytes)
+	Memory string
+
+	// GPU is the average utilization of the GPUs attached to the main container (percent),
+	// it requires the NVIDIA DCGM exporter (https://github.com/NVIDIA/dcgm-exporter)
+	GPU string
+}
+
+// NewWorkspaceQueries returns the PromQL queries for the Pods of a Workspace StatefulSet.
+// Pods are selected by name rather than by label, as cAdvisor metrics don't include Pod labels.
+func NewWorkspaceQueries(namespace, statefulSetName string) WorkspaceQueries {
+	// StatefulSet Pods are named "<statefulset>-<ordinal>"
+	podRegex := regexp.QuoteMeta(statefulSetName) + `-[0-9]+`
+	selector := fmt.Sprintf(`namespace=%q, pod=~%q, container=%q`, namespace, podRegex, workspaceContainerName)
+
+	return WorkspaceQueries{
+		CPU:    fmt.Sprintf(`sum(rate(container_cpu_usage_seconds_total{%s}[%s]))`, selector, cpuRateWindow),
+		Memory: fmt.Sprintf(`sum(container_memory_working_set_bytes{%s})`, selector),
+		GPU:    fmt.Sprintf(`avg(DCGM_FI_DEV_GPU_UTIL{%s})`, selector),
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecampaigns
+
+import (
+	"sort"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+)
+
+const (
+	// AnnotationUpgradeCampaignDeferral is set on a Workspace to defer its upgrade,
+	// its value is the name of the campaign being deferred.
+	// NOTE: this must match the annotation read by the controller
+	AnnotationUpgradeCampaignDeferral = "notebooks.kubeflow.org/upgrade-campaign-deferral"
+)
+
+/*
+===============================================================================
+                              Model to Kubernetes
+===============================================================================
+*/
+
+// NewWorkspaceUpgradeCampaign creates a WorkspaceUpgradeCampaign object from an UpgradeCampaignCreate model.
+func NewWorkspaceUpgradeCampaign(campaignCreate *UpgradeCampaignCreate) *kubefloworgv1beta1.WorkspaceUpgradeCampaign {
+	campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: campaignCreate.Name,
+		},
+		Spec: kubefloworgv1beta1.WorkspaceUpgradeCampaignSpec{
+			WorkspaceKind: campaignCreate.WorkspaceKind,
+			Paused:        ptr.To(campaignCreate.Paused),
+		},
+	}
+	if campaignCreate.BatchSize != nil {
+		campaign.Spec.BatchSize = *campaignCreate.BatchSize
+	}
+	if campaignCreate.MinIdleSeconds != nil {
+		campaign.Spec.MinIdleSeconds = *campaignCreate.MinIdleSeconds
+	}
+	if campaignCreate.DeferralSeconds != nil {
+		campaign.Spec.DeferralSeconds = *campaignCreate.DeferralSeconds
+	}
+	if campaignCreate.TimeoutSeconds != nil {
+		campaign.Spec.TimeoutSeconds = *campaignCreate.TimeoutSeconds
+	}
+	return campaign
+}
+
+/*
+===============================================================================
+                              Kubernetes to Model
+===============================================================================
+*/
+
+// NewUpgradeCampaignsFromWorkspaceUpgradeCampaigns creates a list of UpgradeCampaign models, sorted by name.
+func NewUpgradeCampaignsFromWorkspaceUpgradeCampaigns(campaigns []kubefloworgv1beta1.WorkspaceUpgradeCampaign) []UpgradeCampaign {
+	campaignModels := make([]UpgradeCampaign, len(campaigns))
+	for i := range campaigns {
+		campaignModels[i] = NewUpgradeCampaignFromWorkspaceUpgradeCampaign(&campaigns[i])
+	}
+	sort.Slice(campaignModels, func(i, j int) bool {
+		return campaignModels[i].Name < campaignModels[j].Name
+	})
+	return campaignModels
+}
+
+// NewUpgradeCampaignFromWorkspaceUpgradeCampaign creates an UpgradeCampaign model from a WorkspaceUpgradeCampaign object.
+func NewUpgradeCampaignFromWorkspaceUpgradeCampaign(campaign *kubefloworgv1beta1.WorkspaceUpgradeCampaign) UpgradeCampaign {
+	// NOTE: the phase is empty until the controller has reconciled the campaign
+	phase := UpgradeCampaignPhase(campaign.Status.Phase)
+	if phase == "" {
+		phase = UpgradeCampaignPhaseRunning
+		if ptr.Deref(campaign.Spec.Paused, false) {
+			phase = UpgradeCampaignPhasePaused
+		}
+	}
+
+	workspaces := make([]WorkspaceUpgrade, len(campaign.Status.Workspaces))
+	for i, entry := range campaign.Status.Workspaces {
+		workspaces[i] = WorkspaceUpgrade{
+			Namespace:     entry.Namespace,
+			Name:          entry.Name,
+			State:         UpgradeState(entry.State),
+			Message:       entry.Message,
+			Deferred:      entry.Deferred,
+			DeferredUntil: entry.DeferredUntil,
+			UpgradeTime:   entry.UpgradeTime,
+		}
+	}
+
+	summary := campaign.Status.Summary
+	return UpgradeCampaign{
+		Name:            campaign.Name,
+		WorkspaceKind:   campaign.Spec.WorkspaceKind,
+		Paused:          ptr.Deref(campaign.Spec.Paused, false),
+		BatchSize:       campaign.Spec.BatchSize,
+		MinIdleSeconds:  campaign.Spec.MinIdleSeconds,
+		DeferralSeconds: campaign.Spec.DeferralSeconds,
+		TimeoutSeconds:  campaign.Spec.TimeoutSeconds,
+		Phase:           phase,
+		Summary: UpgradeSummary{
+			Pending:   summary.Pending,
+			Deferred:  summary.Deferred,
+			Upgrading: summary.Upgrading,
+			Succeeded: summary.Succeeded,
+			Failed:    summary.Failed,
+		},
+		Workspaces: workspaces,
+		Audit:      common.NewAuditFromObjectMeta(&campaign.ObjectMeta),
+	}
+}
+
+// IsWorkspaceUpgradePending returns true if a Workspace has a redirect which would be applied by an upgrade campaign.
+func IsWorkspaceUpgradePending(workspace *kubefloworgv1beta1.Workspace) bool {
+	options := workspace.Status.PodTemplateOptions
+	return len(options.ImageConfig.RedirectChain) > 0 || len(options.PodConfig.RedirectChain) > 0
+}
+
+// NewPendingUpgradesFromWorkspaces creates a list of PendingUpgrade models for the Workspaces with a pending redirect,
+// sorted by namespace and name.
+func NewPendingUpgradesFromWorkspaces(workspaces []kubefloworgv1beta1.Workspace) []PendingUpgrade {
+	pendingUpgrades := make([]PendingUpgrade, 0)
+	for i := range workspaces {
+		workspace := &workspaces[i]
+		if !IsWorkspaceUpgradePending(workspace) {
+			continue
+		}
+		pendingUpgrades = append(pendingUpgrades, NewPendingUpgradeFromWorkspace(workspace))
+	}
+	sort.Slice(pendingUpgrades, func(i, j int) bool {
+		if pendingUpgrades[i].Namespace != pendingUpgrades[j].Namespace {
+			return pendingUpgrades[i].Namespace < pendingUpgrades[j].Namespace
+		}
+		return pendingUpgrades[i].Name < pendingUpgrades[j].Name
+	})
+	return pendingUpgrades
+}
+
+// NewPendingUpgradeFromWorkspace creates a PendingUpgrade model from a Workspace object.
+func NewPendingUpgradeFromWorkspace(workspace *kubefloworgv1beta1.Workspace) PendingUpgrade {
+	options := workspace.Status.PodTemplateOptions
+	return PendingUpgrade{
+		Namespace:    workspace.Namespace,
+		Name:         workspace.Name,
+		Paused:       ptr.Deref(workspace.Spec.Paused, false),
+		ImageConfig:  newOptionUpgrade(workspace.Spec.PodTemplate.Options.ImageConfig, options.ImageConfig),
+		PodConfig:    newOptionUpgrade(workspace.Spec.PodTemplate.Options.PodConfig, options.PodConfig),
+		LastActivity: workspace.Status.Activity.LastActivity,
+	}
+}
+
+func newOptionUpgrade(current string, optionInfo kubefloworgv1beta1.WorkspacePodOptionInfo) OptionUpgrade {
+	redirectChain := make([]RedirectStep, len(optionInfo.RedirectChain))
+	for i, step := range optionInfo.RedirectChain {
+		redirectChain[i] = RedirectStep{
+			Source: step.Source,
+			Target: step.Target,
+		}
+	}
+	desired := optionInfo.Desired
+	if desired == "" {
+		desired = current
+	}
+	return OptionUpgrade{
+		Current:       current,
+		Desired:       desired,
+		RedirectChain: redirectChain,
+	}
+}
+
+// GetWorkspaceUpgrade returns the upgrade status of a Workspace in a campaign, if the Workspace is part of the campaign.
+func GetWorkspaceUpgrade(campaign *kubefloworgv1beta1.WorkspaceUpgradeCampaign, namespace, name string) (*kubefloworgv1beta1.WorkspaceUpgradeStatus, bool) {
+	for i := range campaign.Status.Workspaces {
+		entry := &campaign.Status.Workspaces[i]
+		if entry.Namespace == namespace && entry.Name == name {
+			return entry, true
+		}
+	}
+	return nil, false
+}
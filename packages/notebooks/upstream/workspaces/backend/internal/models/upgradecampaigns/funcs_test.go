@@ -0,0 +1,132 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecampaigns
+
+import (
+	"testing"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestUpgradeCampaigns(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Upgrade Campaigns Models Suite")
+}
+
+func newTestWorkspace(namespace, name string, redirected bool) kubefloworgv1beta1.Workspace {
+	workspace := kubefloworgv1beta1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kubefloworgv1beta1.WorkspaceSpec{
+			Kind: "jupyterlab",
+			PodTemplate: kubefloworgv1beta1.WorkspacePodTemplate{
+				Options: kubefloworgv1beta1.WorkspacePodOptions{
+					ImageConfig: "jupyterlab_scipy_180",
+					PodConfig:   "tiny_cpu",
+				},
+			},
+		},
+	}
+	workspace.Status.PodTemplateOptions.PodConfig.Desired = "tiny_cpu"
+	workspace.Status.PodTemplateOptions.ImageConfig.Desired = "jupyterlab_scipy_180"
+	if redirected {
+		workspace.Status.PodTemplateOptions.ImageConfig.Desired = "jupyterlab_scipy_190"
+		workspace.Status.PodTemplateOptions.ImageConfig.RedirectChain = []kubefloworgv1beta1.WorkspacePodOptionRedirectStep{
+			{Source: "jupyterlab_scipy_180", Target: "jupyterlab_scipy_190"},
+		}
+	}
+	return workspace
+}
+
+var _ = Describe("UpgradeCampaignCreate", func() {
+	It("accepts a campaign with default settings", func() {
+		campaignCreate := &UpgradeCampaignCreate{Name: "jupyterlab-upgrade", WorkspaceKind: "jupyterlab"}
+		Expect(campaignCreate.Validate(field.NewPath("data"))).To(BeEmpty())
+	})
+
+	It("rejects an invalid workspace kind and batch size", func() {
+		campaignCreate := &UpgradeCampaignCreate{Name: "jupyterlab-upgrade", WorkspaceKind: "", BatchSize: ptr.To[int32](0)}
+		errs := campaignCreate.Validate(field.NewPath("data"))
+		Expect(errs).NotTo(BeEmpty())
+		Expect(errs[0].Field).To(Equal("data.workspaceKind"))
+		Expect(errs[len(errs)-1].Field).To(Equal("data.batchSize"))
+	})
+})
+
+var _ = Describe("UpgradeCampaign models", func() {
+	It("only sets the settings which were provided", func() {
+		campaign := NewWorkspaceUpgradeCampaign(&UpgradeCampaignCreate{
+			Name:          "jupyterlab-upgrade",
+			WorkspaceKind: "jupyterlab",
+			BatchSize:     ptr.To[int32](2),
+		})
+		Expect(campaign.Spec.WorkspaceKind).To(Equal("jupyterlab"))
+		Expect(campaign.Spec.Paused).To(Equal(ptr.To(false)))
+		Expect(campaign.Spec.BatchSize).To(Equal(int32(2)))
+		Expect(campaign.Spec.MinIdleSeconds).To(BeZero())
+	})
+
+	It("reports a paused phase before the campaign is reconciled", func() {
+		campaign := NewWorkspaceUpgradeCampaign(&UpgradeCampaignCreate{Name: "jupyterlab-upgrade", WorkspaceKind: "jupyterlab", Paused: true})
+		campaignModel := NewUpgradeCampaignFromWorkspaceUpgradeCampaign(campaign)
+		Expect(campaignModel.Phase).To(Equal(UpgradeCampaignPhasePaused))
+		Expect(campaignModel.Workspaces).To(BeEmpty())
+	})
+
+	It("lists only the workspaces with a redirect, sorted by namespace and name", func() {
+		workspaces := []kubefloworgv1beta1.Workspace{
+			newTestWorkspace("ns-b", "ws-a", true),
+			newTestWorkspace("ns-a", "ws-b", true),
+			newTestWorkspace("ns-a", "ws-c", false),
+		}
+		workspaces[0].Spec.Paused = ptr.To(true)
+
+		pendingUpgrades := NewPendingUpgradesFromWorkspaces(workspaces)
+		Expect(pendingUpgrades).To(HaveLen(2))
+		Expect(pendingUpgrades[0].Name).To(Equal("ws-b"))
+		Expect(pendingUpgrades[1].Name).To(Equal("ws-a"))
+		Expect(pendingUpgrades[1].Paused).To(BeTrue())
+		Expect(pendingUpgrades[0].ImageConfig).To(Equal(OptionUpgrade{
+			Current:       "jupyterlab_scipy_180",
+			Desired:       "jupyterlab_scipy_190",
+			RedirectChain: []RedirectStep{{Source: "jupyterlab_scipy_180", Target: "jupyterlab_scipy_190"}},
+		}))
+		Expect(pendingUpgrades[0].PodConfig.Desired).To(Equal("tiny_cpu"))
+		Expect(pendingUpgrades[0].PodConfig.RedirectChain).To(BeEmpty())
+	})
+
+	It("finds the upgrade status of a workspace in a campaign", func() {
+		campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{}
+		campaign.Status.Workspaces = []kubefloworgv1beta1.WorkspaceUpgradeStatus{
+			{Namespace: "ns-a", Name: "ws-a", State: kubefloworgv1beta1.WorkspaceUpgradeStateDeferred, Deferred: true},
+		}
+
+		entry, ok := GetWorkspaceUpgrade(campaign, "ns-a", "ws-a")
+		Expect(ok).To(BeTrue())
+		Expect(entry.Deferred).To(BeTrue())
+
+		_, ok = GetWorkspaceUpgrade(campaign, "ns-b", "ws-a")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -0,0 +1,116 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecampaigns
+
+import (
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+)
+
+// UpgradeCampaign represents a campaign which applies the pending redirects of a WorkspaceKind to its Workspaces.
+// It is backed by a WorkspaceUpgradeCampaign, which is run by the controller.
+type UpgradeCampaign struct {
+	Name            string               `json:"name"`
+	WorkspaceKind   string               `json:"workspaceKind"`
+	Paused          bool                 `json:"paused"`
+	BatchSize       int32                `json:"batchSize"`
+	MinIdleSeconds  int32                `json:"minIdleSeconds"`
+	DeferralSeconds int32                `json:"deferralSeconds"`
+	TimeoutSeconds  int32                `json:"timeoutSeconds"`
+	Phase           UpgradeCampaignPhase `json:"phase"`
+	Summary         UpgradeSummary       `json:"summary"`
+	Workspaces      []WorkspaceUpgrade   `json:"workspaces"`
+	Audit           common.Audit         `json:"audit"`
+}
+
+type UpgradeCampaignPhase string
+
+const (
+	UpgradeCampaignPhaseRunning   UpgradeCampaignPhase = "Running"
+	UpgradeCampaignPhasePaused    UpgradeCampaignPhase = "Paused"
+	UpgradeCampaignPhaseCompleted UpgradeCampaignPhase = "Completed"
+)
+
+// UpgradeSummary is the number of Workspaces in each upgrade state
+type UpgradeSummary struct {
+	Pending   int32 `json:"pending"`
+	Deferred  int32 `json:"deferred"`
+	Upgrading int32 `json:"upgrading"`
+	Succeeded int32 `json:"succeeded"`
+	Failed    int32 `json:"failed"`
+}
+
+// WorkspaceUpgrade is the upgrade status of one Workspace in a campaign
+type WorkspaceUpgrade struct {
+	Namespace string       `json:"namespace"`
+	Name      string       `json:"name"`
+	State     UpgradeState `json:"state"`
+	Message   string       `json:"message"`
+
+	// Deferred is true if the user has deferred the upgrade, each Workspace can only be deferred once per campaign.
+	Deferred bool `json:"deferred"`
+
+	// DeferredUntil is the time until which the upgrade is deferred (UNIX epoch), it is only set while deferred.
+	DeferredUntil int64 `json:"deferredUntil,omitempty"`
+
+	// UpgradeTime is the time when the Workspace was restarted (UNIX epoch).
+	UpgradeTime int64 `json:"upgradeTime,omitempty"`
+}
+
+type UpgradeState string
+
+const (
+	UpgradeStatePending   UpgradeState = "Pending"
+	UpgradeStateDeferred  UpgradeState = "Deferred"
+	UpgradeStateUpgrading UpgradeState = "Upgrading"
+	UpgradeStateSucceeded UpgradeState = "Succeeded"
+	UpgradeStateFailed    UpgradeState = "Failed"
+)
+
+// PendingUpgrade is a Workspace which would be upgraded by a campaign for its WorkspaceKind,
+// because one of its podTemplate options has a redirect.
+type PendingUpgrade struct {
+	Namespace   string        `json:"namespace"`
+	Name        string        `json:"name"`
+	Paused      bool          `json:"paused"`
+	ImageConfig OptionUpgrade `json:"imageConfig"`
+	PodConfig   OptionUpgrade `json:"podConfig"`
+
+	// LastActivity is the last time activity was observed on the Workspace (UNIX epoch),
+	// it is 0 if the activity is unknown, in which case a running Workspace is never upgraded.
+	LastActivity int64 `json:"lastActivity"`
+}
+
+// OptionUpgrade describes how one podTemplate option of a Workspace is upgraded
+type OptionUpgrade struct {
+	Current       string         `json:"current"`
+	Desired       string         `json:"desired"`
+	RedirectChain []RedirectStep `json:"redirectChain"`
+}
+
+type RedirectStep struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// UpgradeDeferral represents the outcome of deferring the upgrade of a Workspace
+type UpgradeDeferral struct {
+	// Campaign is the name of the campaign whose upgrade was deferred
+	Campaign string `json:"campaign"`
+
+	// DeferralSeconds is how long the upgrade is postponed, from when the controller observes the deferral.
+	DeferralSeconds int32 `json:"deferralSeconds"`
+}
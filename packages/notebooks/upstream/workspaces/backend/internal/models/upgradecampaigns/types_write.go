@@ -0,0 +1,67 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecampaigns
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/helper"
+)
+
+// UpgradeCampaignCreate is used to create a new upgrade campaign.
+// Unset fields are defaulted by the WorkspaceUpgradeCampaign CRD.
+type UpgradeCampaignCreate struct {
+	Name            string `json:"name"`
+	WorkspaceKind   string `json:"workspaceKind"`
+	Paused          bool   `json:"paused"`
+	BatchSize       *int32 `json:"batchSize,omitempty"`
+	MinIdleSeconds  *int32 `json:"minIdleSeconds,omitempty"`
+	DeferralSeconds *int32 `json:"deferralSeconds,omitempty"`
+	TimeoutSeconds  *int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// Validate validates the UpgradeCampaignCreate struct.
+// NOTE: we only do basic validation, more complex validation is done by the controller when attempting to create the campaign.
+func (c *UpgradeCampaignCreate) Validate(prefix *field.Path) []*field.Error {
+	var errs []*field.Error
+
+	// validate the campaign name
+	namePath := prefix.Child("name")
+	errs = append(errs, helper.ValidateFieldIsDNS1123Subdomain(namePath, c.Name)...)
+
+	// validate the workspace kind name
+	workspaceKindPath := prefix.Child("workspaceKind")
+	errs = append(errs, helper.ValidateWorkspaceKindName(workspaceKindPath, c.WorkspaceKind)...)
+
+	// validate the numeric settings
+	errs = append(errs, validateMinimum(prefix.Child("batchSize"), c.BatchSize, 1)...)
+	errs = append(errs, validateMinimum(prefix.Child("minIdleSeconds"), c.MinIdleSeconds, 0)...)
+	errs = append(errs, validateMinimum(prefix.Child("deferralSeconds"), c.DeferralSeconds, 0)...)
+	errs = append(errs, validateMinimum(prefix.Child("timeoutSeconds"), c.TimeoutSeconds, 1)...)
+
+	return errs
+}
+
+// validateMinimum validates that an optional value is at least the minimum
+func validateMinimum(path *field.Path, value *int32, minimum int32) []*field.Error {
+	if value != nil && *value < minimum {
+		return []*field.Error{field.Invalid(path, *value, fmt.Sprintf("must be greater than or equal to %d", minimum))}
+	}
+	return nil
+}
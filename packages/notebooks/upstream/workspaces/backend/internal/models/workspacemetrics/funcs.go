@@ -0,0 +1,158 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemetrics
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/metrics"
+)
+
+const (
+	// gpuResourceSuffix is the suffix of extended resources which are counted as GPUs (e.g. "nvidia.com/gpu", "amd.com/gpu")
+	gpuResourceSuffix = "/gpu"
+)
+
+// NewResourceAmountsFromResourceRequirements creates the requests and limits from the resources of a pod config.
+// Like Kubernetes, requests which are not set default to their limit.
+func NewResourceAmountsFromResourceRequirements(resources *corev1.ResourceRequirements) (requests ResourceAmounts, limits ResourceAmounts) {
+	if resources == nil {
+		return ResourceAmounts{}, ResourceAmounts{}
+	}
+
+	limits = newResourceAmounts(resources.Limits)
+	requests = newResourceAmounts(resources.Requests)
+	if requests.CPU == nil {
+		requests.CPU = limits.CPU
+	}
+	if requests.MemoryBytes == nil {
+		requests.MemoryBytes = limits.MemoryBytes
+	}
+	if requests.GPU == nil {
+		requests.GPU = limits.GPU
+	}
+	return requests, limits
+}
+
+func newResourceAmounts(resourceList corev1.ResourceList) ResourceAmounts {
+	amounts := ResourceAmounts{}
+	if cpu, ok := resourceList[corev1.ResourceCPU]; ok {
+		amounts.CPU = ptr.To(cpu.AsApproximateFloat64())
+	}
+	if memory, ok := resourceList[corev1.ResourceMemory]; ok {
+		amounts.MemoryBytes = ptr.To(memory.Value())
+	}
+	for name, quantity := range resourceList {
+		if strings.HasSuffix(string(name), gpuResourceSuffix) {
+			amounts.GPU = ptr.To(ptr.Deref(amounts.GPU, 0) + quantity.Value())
+		}
+	}
+	return amounts
+}
+
+// NewResourceUsageHistory merges the samples of each resource into one list of ResourceUsage, sorted by time.
+func NewResourceUsageHistory(cpuSamples, memorySamples, gpuSamples []metrics.Sample) []ResourceUsage {
+	usageByTime := make(map[int64]*ResourceUsage)
+	getUsage := func(sample metrics.Sample) *ResourceUsage {
+		timestamp := sample.Time.Unix()
+		usage, ok := usageByTime[timestamp]
+		if !ok {
+			usage = &ResourceUsage{Timestamp: timestamp}
+			usageByTime[timestamp] = usage
+		}
+		return usage
+	}
+	for _, sample := range cpuSamples {
+		getUsage(sample).CPU = ptr.To(sample.Value)
+	}
+	for _, sample := range memorySamples {
+		getUsage(sample).MemoryBytes = ptr.To(int64(math.Round(sample.Value)))
+	}
+	for _, sample := range gpuSamples {
+		getUsage(sample).GPUUtilization = ptr.To(sample.Value)
+	}
+
+	history := make([]ResourceUsage, 0, len(usageByTime))
+	for _, usage := range usageByTime {
+		history = append(history, *usage)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].Timestamp < history[j].Timestamp
+	})
+	return history
+}
+
+// NewVolumeUsages creates the usage of each home and data volume of a Workspace.
+//   - pvcCapacities is the capacity of each PVC from its status, used when the kubelet doesn't report the volume
+//   - volumeStats is the usage from the kubelet volume metrics, it is empty if the Workspace is not running
+func NewVolumeUsages(workspace *kubefloworgv1beta1.Workspace, pvcCapacities map[string]resource.Quantity, volumeStats []metrics.VolumeStats) []VolumeUsage {
+	statsByPVC := make(map[string]metrics.VolumeStats, len(volumeStats))
+	for _, stats := range volumeStats {
+		statsByPVC[stats.PVCName] = stats
+	}
+
+	newVolumeUsage := func(pvcName string, volumeType VolumeType, mountPath string) VolumeUsage {
+		usage := VolumeUsage{
+			PVCName:   pvcName,
+			Type:      volumeType,
+			MountPath: mountPath,
+		}
+		if capacity, ok := pvcCapacities[pvcName]; ok {
+			usage.CapacityBytes = ptr.To(capacity.Value())
+		}
+		if stats, ok := statsByPVC[pvcName]; ok {
+			if stats.CapacityBytes != nil {
+				usage.CapacityBytes = ptr.To(int64(*stats.CapacityBytes)) //nolint:gosec
+			}
+			if stats.UsedBytes != nil {
+				usage.UsedBytes = ptr.To(int64(*stats.UsedBytes)) //nolint:gosec
+			}
+			if stats.AvailableBytes != nil {
+				usage.AvailableBytes = ptr.To(int64(*stats.AvailableBytes)) //nolint:gosec
+			}
+		}
+		return usage
+	}
+
+	volumes := workspace.Spec.PodTemplate.Volumes
+	volumeUsages := make([]VolumeUsage, 0, len(volumes.Data)+1)
+	if volumes.Home != nil && *volumes.Home != "" {
+		volumeUsages = append(volumeUsages, newVolumeUsage(*volumes.Home, VolumeTypeHome, ""))
+	}
+	for _, data := range volumes.Data {
+		volumeUsages = append(volumeUsages, newVolumeUsage(data.PVCName, VolumeTypeData, data.MountPath))
+	}
+	return volumeUsages
+}
+
+// GetPodConfigResources returns the resources of the pod config used by a Workspace, or nil if it is not found.
+func GetPodConfigResources(workspace *kubefloworgv1beta1.Workspace, workspaceKind *kubefloworgv1beta1.WorkspaceKind) *corev1.ResourceRequirements {
+	podConfigId := workspace.Spec.PodTemplate.Options.PodConfig
+	for _, podConfig := range workspaceKind.Spec.PodTemplate.Options.PodConfig.Values {
+		if podConfig.Id == podConfigId {
+			return podConfig.Spec.Resources
+		}
+	}
+	return nil
+}
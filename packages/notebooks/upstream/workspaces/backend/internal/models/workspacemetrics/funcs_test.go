@@ -0,0 +1,135 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemetrics
+
+import (
+	"testing"
+	"time"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/metrics"
+)
+
+func TestWorkspaceMetrics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workspace Metrics Models Suite")
+}
+
+var _ = Describe("Workspace Metrics Models", func() {
+
+	Context("NewResourceAmountsFromResourceRequirements", func() {
+
+		It("should return empty amounts for nil resources", func() {
+			requests, limits := NewResourceAmountsFromResourceRequirements(nil)
+			Expect(requests).To(Equal(ResourceAmounts{}))
+			Expect(limits).To(Equal(ResourceAmounts{}))
+		})
+
+		It("should default requests to limits and count GPUs", func() {
+			requests, limits := NewResourceAmountsFromResourceRequirements(&corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					"nvidia.com/gpu":      resource.MustParse("1"),
+				},
+			})
+
+			Expect(requests.CPU).To(Equal(ptr.To(0.5)))
+			Expect(requests.MemoryBytes).To(Equal(ptr.To(int64(1024 * 1024 * 1024))))
+			Expect(requests.GPU).To(Equal(ptr.To(int64(1))))
+			Expect(limits.CPU).To(Equal(ptr.To(2.0)))
+			Expect(limits.MemoryBytes).To(Equal(ptr.To(int64(1024 * 1024 * 1024))))
+			Expect(limits.GPU).To(Equal(ptr.To(int64(1))))
+		})
+	})
+
+	Context("NewResourceUsageHistory", func() {
+
+		It("should merge samples by timestamp and sort them", func() {
+			t0 := time.Unix(1700000000, 0)
+			t1 := t0.Add(15 * time.Minute)
+
+			history := NewResourceUsageHistory(
+				[]metrics.Sample{{Time: t1, Value: 0.25}, {Time: t0, Value: 0.5}},
+				[]metrics.Sample{{Time: t0, Value: 1024.4}},
+				nil,
+			)
+
+			Expect(history).To(Equal([]ResourceUsage{
+				{Timestamp: t0.Unix(), CPU: ptr.To(0.5), MemoryBytes: ptr.To(int64(1024))},
+				{Timestamp: t1.Unix(), CPU: ptr.To(0.25)},
+			}))
+		})
+	})
+
+	Context("NewVolumeUsages", func() {
+
+		It("should prefer kubelet stats over the PVC capacity", func() {
+			workspace := &kubefloworgv1beta1.Workspace{
+				Spec: kubefloworgv1beta1.WorkspaceSpec{
+					PodTemplate: kubefloworgv1beta1.WorkspacePodTemplate{
+						Volumes: kubefloworgv1beta1.WorkspacePodVolumes{
+							Home: ptr.To("home-pvc"),
+							Data: []kubefloworgv1beta1.PodVolumeMount{
+								{PVCName: "data-pvc", MountPath: "/data"},
+							},
+						},
+					},
+				},
+			}
+			pvcCapacities := map[string]resource.Quantity{
+				"home-pvc": resource.MustParse("10Gi"),
+				"data-pvc": resource.MustParse("5Gi"),
+			}
+			volumeStats := []metrics.VolumeStats{
+				{
+					PVCName:        "home-pvc",
+					CapacityBytes:  ptr.To(uint64(1000)),
+					UsedBytes:      ptr.To(uint64(400)),
+					AvailableBytes: ptr.To(uint64(600)),
+				},
+			}
+
+			volumeUsages := NewVolumeUsages(workspace, pvcCapacities, volumeStats)
+
+			Expect(volumeUsages).To(Equal([]VolumeUsage{
+				{
+					PVCName:        "home-pvc",
+					Type:           VolumeTypeHome,
+					CapacityBytes:  ptr.To(int64(1000)),
+					UsedBytes:      ptr.To(int64(400)),
+					AvailableBytes: ptr.To(int64(600)),
+				},
+				{
+					PVCName:       "data-pvc",
+					Type:          VolumeTypeData,
+					MountPath:     "/data",
+					CapacityBytes: ptr.To(int64(5 * 1024 * 1024 * 1024)),
+				},
+			}))
+		})
+	})
+})
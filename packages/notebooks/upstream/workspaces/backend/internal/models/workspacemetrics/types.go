@@ -0,0 +1,89 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemetrics
+
+// VolumeType is the type of Workspace volume.
+type VolumeType string
+
+const (
+	VolumeTypeHome VolumeType = "home"
+	VolumeTypeData VolumeType = "data"
+)
+
+// WorkspaceMetrics represents the resource usage of a Workspace, alongside the resources requested by its pod config.
+// All usage comes from a Prometheus-compatible API, volume usage from the kubelet volume metrics it scrapes.
+type WorkspaceMetrics struct {
+	// Requests are the resources requested by the pod config of the Workspace.
+	Requests ResourceAmounts `json:"requests"`
+
+	// Limits are the resource limits of the pod config of the Workspace.
+	Limits ResourceAmounts `json:"limits"`
+
+	// Current is the resource usage at the time of the request, values are unset if the Workspace is not running.
+	Current ResourceUsage `json:"current"`
+
+	// History is the resource usage over the last 24 hours, one sample per HistoryStepSeconds.
+	History            []ResourceUsage `json:"history"`
+	HistoryStepSeconds int64           `json:"historyStepSeconds"`
+
+	// Volumes is the usage of the home and data volumes of the Workspace,
+	// usage is only reported (by the kubelet volume metrics) while the Workspace is running.
+	Volumes []VolumeUsage `json:"volumes"`
+
+	// Warnings describe metrics which could not be retrieved (e.g. the metrics source is unavailable).
+	Warnings []string `json:"warnings"`
+}
+
+// ResourceAmounts is an amount of each resource, unset values are not requested (or not limited).
+type ResourceAmounts struct {
+	// CPU is the number of CPU cores
+	CPU *float64 `json:"cpu,omitempty"`
+
+	// MemoryBytes is the amount of memory in bytes
+	MemoryBytes *int64 `json:"memoryBytes,omitempty"`
+
+	// GPU is the number of GPUs (of any vendor, e.g. "nvidia.com/gpu")
+	GPU *int64 `json:"gpu,omitempty"`
+}
+
+// ResourceUsage is the resource usage of a Workspace at a point in time, unset values are not available.
+type ResourceUsage struct {
+	// Timestamp is the time of the sample (UNIX epoch)
+	Timestamp int64 `json:"timestamp"`
+
+	// CPU is the number of CPU cores used
+	CPU *float64 `json:"cpu,omitempty"`
+
+	// MemoryBytes is the working set memory in bytes
+	MemoryBytes *int64 `json:"memoryBytes,omitempty"`
+
+	// GPUUtilization is the average utilization of the GPUs of the Workspace (0-100)
+	GPUUtilization *float64 `json:"gpuUtilization,omitempty"`
+}
+
+// VolumeUsage is the usage of one PVC of a Workspace.
+type VolumeUsage struct {
+	PVCName string     `json:"pvcName"`
+	Type    VolumeType `json:"type"`
+
+	// MountPath is only set for data volumes, the mount path of the home volume is defined by the WorkspaceKind.
+	MountPath string `json:"mountPath,omitempty"`
+
+	CapacityBytes  *int64 `json:"capacityBytes,omitempty"`
+	UsedBytes      *int64 `json:"usedBytes,omitempty"`
+	AvailableBytes *int64 `json:"availableBytes,omitempty"`
+}
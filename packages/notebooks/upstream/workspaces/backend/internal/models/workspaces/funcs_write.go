@@ -234,6 +234,41 @@ func NewWorkspaceFromWorkspaceCreateModel(ctx context.Context, k8sClient client.
 	return workspace, nil
 }
 
+// NewWorkspaceFromRestoredWorkspaceCreateModel creates a Workspace object from a WorkspaceCreate model
+// whose home and data PVCs were just restored by the caller (e.g. from a snapshot).
+// Only the referenced Secrets are validated, as the restored PVCs may not be visible in the cache yet.
+func NewWorkspaceFromRestoredWorkspaceCreateModel(ctx context.Context, k8sClient client.Client, workspaceCreate *WorkspaceCreate, namespace string) (*kubefloworgv1beta1.Workspace, error) {
+	volumes := workspaceCreate.PodTemplate.Volumes
+	_, _, secretMounts, err := validateAndUnpackVolumes(ctx, k8sClient, PodVolumesMutate{Secrets: volumes.Secrets}, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	dataVolumeMounts := make([]kubefloworgv1beta1.PodVolumeMount, len(volumes.Data))
+	for i, dataVolume := range volumes.Data {
+		dataVolumeMounts[i] = kubefloworgv1beta1.PodVolumeMount{
+			PVCName:   dataVolume.PVCName,
+			MountPath: dataVolume.MountPath,
+			ReadOnly:  ptr.To(dataVolume.ReadOnly),
+		}
+	}
+
+	// construct workspace object from model
+	workspace := &kubefloworgv1beta1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workspaceCreate.Name,
+			Namespace: namespace,
+		},
+		Spec: kubefloworgv1beta1.WorkspaceSpec{
+			Paused:      &workspaceCreate.Paused,
+			Kind:        workspaceCreate.Kind,
+			PodTemplate: buildWorkspacePodTemplate(&workspaceCreate.PodTemplate, volumes.Home, dataVolumeMounts, secretMounts),
+		},
+	}
+
+	return workspace, nil
+}
+
 // ApplyWorkspaceUpdateModelToWorkspace applies a WorkspaceUpdate model to an existing Workspace object.
 // It validates that referenced PVCs and Secrets exist and are mountable in the given namespace.
 func ApplyWorkspaceUpdateModelToWorkspace(ctx context.Context, k8sClient client.Client, workspaceUpdate *WorkspaceUpdate, workspace *kubefloworgv1beta1.Workspace) error {
@@ -0,0 +1,176 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceshares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+)
+
+const (
+	// LabelWorkspaceName is the name of the Workspace a Role or RoleBinding shares
+	// NOTE: this is the same label the controller sets on the resources of a Workspace
+	LabelWorkspaceName = "notebooks.kubeflow.org/workspace-name"
+
+	// LabelWorkspaceShareAccess marks a Role or RoleBinding as sharing a Workspace, its value is the access level
+	// NOTE: the controller watches RoleBindings with this label to route shared users to the Workspace
+	LabelWorkspaceShareAccess = "notebooks.kubeflow.org/workspace-share-access"
+
+	// the length of the hash of the user in the RoleBinding name
+	userHashLength = 10
+)
+
+// workspaceAccessVerbs are the verbs on the Workspace which each access level grants
+var workspaceAccessVerbs = map[WorkspaceAccess][]string{
+	WorkspaceAccessRead: {"get"},
+	WorkspaceAccessEdit: {"get", "update", "patch"},
+}
+
+// RoleName returns the name of the Role granting the given access to a Workspace.
+func RoleName(workspaceName string, access WorkspaceAccess) string {
+	return fmt.Sprintf("%s-share-%s", workspaceName, access)
+}
+
+// RoleBindingName returns the name of the RoleBinding sharing a Workspace with a user.
+//   - the user is hashed, as user names are often emails which are not valid object names
+func RoleBindingName(workspaceName string, user string) string {
+	hash := sha256.Sum256([]byte(user))
+	return fmt.Sprintf("%s-share-%s", workspaceName, hex.EncodeToString(hash[:])[:userHashLength])
+}
+
+/*
+===============================================================================
+                              Model to Kubernetes
+===============================================================================
+*/
+
+// NewRole creates the Role granting the given access to a Workspace.
+// The Role is owned by the Workspace, so it is deleted with the Workspace.
+func NewRole(workspace *kubefloworgv1beta1.Workspace, access WorkspaceAccess) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            RoleName(workspace.Name, access),
+			Namespace:       workspace.Namespace,
+			Labels:          newLabels(workspace.Name, access),
+			OwnerReferences: newOwnerReferences(workspace),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{kubefloworgv1beta1.GroupVersion.Group},
+				Resources:     []string{"workspaces"},
+				ResourceNames: []string{workspace.Name},
+				Verbs:         workspaceAccessVerbs[access],
+			},
+		},
+	}
+}
+
+// NewRoleBinding creates the RoleBinding sharing a Workspace with a user.
+// The RoleBinding is owned by the Workspace, so it is deleted with the Workspace.
+func NewRoleBinding(workspace *kubefloworgv1beta1.Workspace, shareCreate *WorkspaceShareCreate) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            RoleBindingName(workspace.Name, shareCreate.User),
+			Namespace:       workspace.Namespace,
+			Labels:          newLabels(workspace.Name, shareCreate.Access),
+			OwnerReferences: newOwnerReferences(workspace),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.UserKind,
+				Name:     shareCreate.User,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     RoleName(workspace.Name, shareCreate.Access),
+		},
+	}
+}
+
+func newLabels(workspaceName string, access WorkspaceAccess) map[string]string {
+	return map[string]string{
+		LabelWorkspaceName:        workspaceName,
+		LabelWorkspaceShareAccess: string(access),
+	}
+}
+
+func newOwnerReferences(workspace *kubefloworgv1beta1.Workspace) []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         kubefloworgv1beta1.GroupVersion.String(),
+			Kind:               "Workspace",
+			Name:               workspace.Name,
+			UID:                workspace.UID,
+			BlockOwnerDeletion: ptr.To(false),
+		},
+	}
+}
+
+/*
+===============================================================================
+                              Kubernetes to Model
+===============================================================================
+*/
+
+// NewWorkspaceSharesFromRoleBindings creates WorkspaceShare models from share RoleBindings, sorted by user.
+func NewWorkspaceSharesFromRoleBindings(roleBindings []rbacv1.RoleBinding) []WorkspaceShare {
+	shares := make([]WorkspaceShare, 0, len(roleBindings))
+	for i := range roleBindings {
+		share, ok := NewWorkspaceShareFromRoleBinding(&roleBindings[i])
+		if !ok {
+			continue
+		}
+		shares = append(shares, *share)
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].User < shares[j].User
+	})
+	return shares
+}
+
+// NewWorkspaceShareFromRoleBinding creates a WorkspaceShare model from a share RoleBinding.
+// Returns false if the RoleBinding is not a valid share (e.g. it was modified outside the backend).
+func NewWorkspaceShareFromRoleBinding(roleBinding *rbacv1.RoleBinding) (*WorkspaceShare, bool) {
+	access := WorkspaceAccess(roleBinding.Labels[LabelWorkspaceShareAccess])
+	if _, ok := workspaceAccessVerbs[access]; !ok {
+		return nil, false
+	}
+
+	// NOTE: we only create RoleBindings with a single User subject
+	if len(roleBinding.Subjects) != 1 || roleBinding.Subjects[0].Kind != rbacv1.UserKind {
+		return nil, false
+	}
+
+	return &WorkspaceShare{
+		Name:   roleBinding.Name,
+		User:   roleBinding.Subjects[0].Name,
+		Access: access,
+		Audit:  common.NewAuditFromObjectMeta(&roleBinding.ObjectMeta),
+	}, true
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceshares
+
+import (
+	"testing"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestWorkspaceShares(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workspace Shares Models Suite")
+}
+
+func newTestWorkspace() *kubefloworgv1beta1.Workspace {
+	return &kubefloworgv1beta1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-workspace",
+			Namespace: "my-namespace",
+			UID:       "0000-1111",
+		},
+	}
+}
+
+var _ = Describe("WorkspaceShareCreate", func() {
+	It("accepts read and edit access", func() {
+		for _, access := range []WorkspaceAccess{WorkspaceAccessRead, WorkspaceAccessEdit} {
+			shareCreate := &WorkspaceShareCreate{User: "user@example.com", Access: access}
+			Expect(shareCreate.Validate(field.NewPath("data"))).To(BeEmpty())
+		}
+	})
+
+	It("rejects an empty user and unknown access", func() {
+		shareCreate := &WorkspaceShareCreate{User: "", Access: "admin"}
+		errs := shareCreate.Validate(field.NewPath("data"))
+		Expect(errs).To(HaveLen(2))
+		Expect(errs[0].Field).To(Equal("data.user"))
+		Expect(errs[1].Field).To(Equal("data.access"))
+		Expect(errs[1].Type).To(Equal(field.ErrorTypeNotSupported))
+	})
+})
+
+var _ = Describe("NewRole", func() {
+	It("scopes the Role to the workspace", func() {
+		workspace := newTestWorkspace()
+
+		role := NewRole(workspace, WorkspaceAccessRead)
+		Expect(role.Name).To(Equal("my-workspace-share-read"))
+		Expect(role.Namespace).To(Equal("my-namespace"))
+		Expect(role.Labels).To(HaveKeyWithValue(LabelWorkspaceName, "my-workspace"))
+		Expect(role.Labels).To(HaveKeyWithValue(LabelWorkspaceShareAccess, "read"))
+		Expect(role.OwnerReferences).To(HaveLen(1))
+		Expect(role.OwnerReferences[0].UID).To(Equal(workspace.UID))
+		Expect(role.Rules).To(HaveLen(1))
+		Expect(role.Rules[0].ResourceNames).To(ConsistOf("my-workspace"))
+		Expect(role.Rules[0].Verbs).To(ConsistOf("get"))
+	})
+
+	It("grants update to edit access", func() {
+		role := NewRole(newTestWorkspace(), WorkspaceAccessEdit)
+		Expect(role.Name).To(Equal("my-workspace-share-edit"))
+		Expect(role.Rules[0].Verbs).To(ConsistOf("get", "update", "patch"))
+	})
+})
+
+var _ = Describe("NewRoleBinding", func() {
+	It("binds the user to the Role of the access level", func() {
+		workspace := newTestWorkspace()
+		shareCreate := &WorkspaceShareCreate{User: "user@example.com", Access: WorkspaceAccessEdit}
+
+		roleBinding := NewRoleBinding(workspace, shareCreate)
+		Expect(roleBinding.Name).To(Equal(RoleBindingName("my-workspace", "user@example.com")))
+		Expect(roleBinding.Name).To(MatchRegexp(`^my-workspace-share-[0-9a-f]{10}$`))
+		Expect(roleBinding.RoleRef.Name).To(Equal("my-workspace-share-edit"))
+		Expect(roleBinding.Subjects).To(ConsistOf(rbacv1.Subject{
+			APIGroup: rbacv1.GroupName,
+			Kind:     rbacv1.UserKind,
+			Name:     "user@example.com",
+		}))
+
+		// the RoleBinding name does not depend on the access level, so a user can only be shared with once
+		shareCreate.Access = WorkspaceAccessRead
+		Expect(NewRoleBinding(workspace, shareCreate).Name).To(Equal(roleBinding.Name))
+	})
+})
+
+var _ = Describe("NewWorkspaceSharesFromRoleBindings", func() {
+	It("converts share RoleBindings, sorted by user", func() {
+		workspace := newTestWorkspace()
+		roleBindings := []rbacv1.RoleBinding{
+			*NewRoleBinding(workspace, &WorkspaceShareCreate{User: "user-b", Access: WorkspaceAccessRead}),
+			*NewRoleBinding(workspace, &WorkspaceShareCreate{User: "user-a", Access: WorkspaceAccessEdit}),
+		}
+
+		shares := NewWorkspaceSharesFromRoleBindings(roleBindings)
+		Expect(shares).To(HaveLen(2))
+		Expect(shares[0].User).To(Equal("user-a"))
+		Expect(shares[0].Access).To(Equal(WorkspaceAccessEdit))
+		Expect(shares[0].Name).To(Equal(roleBindings[1].Name))
+		Expect(shares[1].User).To(Equal("user-b"))
+		Expect(shares[1].Access).To(Equal(WorkspaceAccessRead))
+	})
+
+	It("skips RoleBindings which are not valid shares", func() {
+		workspace := newTestWorkspace()
+		invalidAccess := NewRoleBinding(workspace, &WorkspaceShareCreate{User: "user-a", Access: WorkspaceAccessRead})
+		invalidAccess.Labels[LabelWorkspaceShareAccess] = "admin"
+		groupSubject := NewRoleBinding(workspace, &WorkspaceShareCreate{User: "user-b", Access: WorkspaceAccessRead})
+		groupSubject.Subjects[0].Kind = rbacv1.GroupKind
+
+		Expect(NewWorkspaceSharesFromRoleBindings([]rbacv1.RoleBinding{*invalidAccess, *groupSubject})).To(BeEmpty())
+	})
+})
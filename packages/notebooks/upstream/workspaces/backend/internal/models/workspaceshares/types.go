@@ -0,0 +1,42 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceshares
+
+import (
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+)
+
+// WorkspaceAccess is the access level a Workspace is shared with.
+type WorkspaceAccess string
+
+const (
+	// WorkspaceAccessRead allows the user to view and connect to the Workspace.
+	WorkspaceAccessRead WorkspaceAccess = "read"
+
+	// WorkspaceAccessEdit additionally allows the user to update the Workspace (e.g. pause or restart it).
+	WorkspaceAccessEdit WorkspaceAccess = "edit"
+)
+
+// WorkspaceShare represents a user a Workspace is shared with.
+// It is backed by a RoleBinding which grants the user a Role scoped to the Workspace.
+type WorkspaceShare struct {
+	// Name is the name of the RoleBinding of the share
+	Name   string          `json:"name"`
+	User   string          `json:"user"`
+	Access WorkspaceAccess `json:"access"`
+	Audit  common.Audit    `json:"audit"`
+}
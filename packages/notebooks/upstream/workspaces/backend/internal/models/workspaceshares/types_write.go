@@ -0,0 +1,48 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceshares
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/helper"
+)
+
+// WorkspaceShareCreate is used to share a Workspace with a user.
+type WorkspaceShareCreate struct {
+	User   string          `json:"user"`
+	Access WorkspaceAccess `json:"access"`
+}
+
+// Validate validates the WorkspaceShareCreate struct.
+func (s *WorkspaceShareCreate) Validate(prefix *field.Path) []*field.Error {
+	var errs []*field.Error
+
+	// validate the user
+	userPath := prefix.Child("user")
+	errs = append(errs, helper.ValidateFieldIsNotEmpty(userPath, s.User)...)
+
+	// validate the access level
+	accessPath := prefix.Child("access")
+	switch s.Access {
+	case WorkspaceAccessRead, WorkspaceAccessEdit:
+	default:
+		errs = append(errs, field.NotSupported(accessPath, s.Access, []WorkspaceAccess{WorkspaceAccessRead, WorkspaceAccessEdit}))
+	}
+
+	return errs
+}
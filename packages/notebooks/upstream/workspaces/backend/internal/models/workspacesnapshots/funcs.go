@@ -0,0 +1,259 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesnapshots
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+	modelsWorkspaces "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspaces"
+)
+
+const (
+	// LabelWorkspaceSnapshot is set on every VolumeSnapshot of a workspace snapshot, its value is the snapshot name
+	LabelWorkspaceSnapshot = "notebooks.kubeflow.org/workspace-snapshot"
+
+	// LabelWorkspaceSnapshotSource is the name of the Workspace a VolumeSnapshot was taken from
+	LabelWorkspaceSnapshotSource = "notebooks.kubeflow.org/workspace-snapshot-source"
+
+	// AnnotationWorkspaceSnapshotTemplate holds the JSON encoded WorkspaceCreate of the source Workspace
+	AnnotationWorkspaceSnapshotTemplate = "notebooks.kubeflow.org/workspace-snapshot-template"
+
+	// AnnotationWorkspaceSnapshotVolume holds the JSON encoded VolumeSource of a VolumeSnapshot
+	AnnotationWorkspaceSnapshotVolume = "notebooks.kubeflow.org/workspace-snapshot-volume"
+)
+
+// we use unstructured objects for VolumeSnapshots, so that we don't depend on the external-snapshotter client
+var (
+	VolumeSnapshotGVK     = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"}
+	VolumeSnapshotListGVK = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotList"}
+)
+
+// VolumeNameSuffix returns the suffix used for the name of the VolumeSnapshot of a volume,
+// and for the name of the PVC which is restored from it.
+func VolumeNameSuffix(volumeType VolumeType, index int) string {
+	if volumeType == VolumeTypeHome {
+		return "-home"
+	}
+	return fmt.Sprintf("-data-%d", index)
+}
+
+/*
+===============================================================================
+                              Model to Kubernetes
+===============================================================================
+*/
+
+// NewVolumeSnapshot creates a VolumeSnapshot object for one volume of a workspace snapshot.
+func NewVolumeSnapshot(namespace string, snapshotCreate *WorkspaceSnapshotCreate, workspaceTemplate *modelsWorkspaces.WorkspaceCreate, source *VolumeSource) (*unstructured.Unstructured, error) {
+	templateJSON, err := json.Marshal(workspaceTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workspace template: %w", err)
+	}
+	sourceJSON, err := json.Marshal(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode volume source: %w", err)
+	}
+
+	volumeSnapshot := &unstructured.Unstructured{}
+	volumeSnapshot.SetGroupVersionKind(VolumeSnapshotGVK)
+	volumeSnapshot.SetNamespace(namespace)
+	volumeSnapshot.SetName(snapshotCreate.Name + VolumeNameSuffix(source.Type, source.Index))
+	volumeSnapshot.SetLabels(map[string]string{
+		LabelWorkspaceSnapshot:       snapshotCreate.Name,
+		LabelWorkspaceSnapshotSource: workspaceTemplate.Name,
+	})
+	volumeSnapshot.SetAnnotations(map[string]string{
+		AnnotationWorkspaceSnapshotTemplate: string(templateJSON),
+		AnnotationWorkspaceSnapshotVolume:   string(sourceJSON),
+	})
+
+	spec := map[string]any{
+		"source": map[string]any{
+			"persistentVolumeClaimName": source.PVCName,
+		},
+	}
+	if snapshotCreate.VolumeSnapshotClassName != nil {
+		spec["volumeSnapshotClassName"] = *snapshotCreate.VolumeSnapshotClassName
+	}
+	volumeSnapshot.Object["spec"] = spec
+
+	return volumeSnapshot, nil
+}
+
+// NewPVCFromVolumeSnapshot creates a PersistentVolumeClaim which restores the given VolumeSnapshot.
+//   - the PVC has the same storage class and access modes as the PVC the snapshot was taken from
+//   - the PVC requests the size of the source PVC, or the restore size of the snapshot if that is larger
+func NewPVCFromVolumeSnapshot(namespace, pvcName string, volumeSnapshot *unstructured.Unstructured, source *VolumeSource) (*corev1.PersistentVolumeClaim, error) {
+	storage, err := resource.ParseQuantity(source.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage request %q for VolumeSnapshot %q: %w", source.Storage, volumeSnapshot.GetName(), err)
+	}
+	restoreSizeString, _, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "restoreSize")
+	if restoreSize, err := resource.ParseQuantity(restoreSizeString); err == nil && restoreSize.Cmp(storage) > 0 {
+		storage = restoreSize
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvcName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				common.LabelCanMount:  "true",
+				common.LabelCanUpdate: "true",
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      source.AccessModes,
+			StorageClassName: source.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storage,
+				},
+			},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &VolumeSnapshotGVK.Group,
+				Kind:     VolumeSnapshotGVK.Kind,
+				Name:     volumeSnapshot.GetName(),
+			},
+		},
+	}
+
+	return pvc, nil
+}
+
+/*
+===============================================================================
+                              Kubernetes to Model
+===============================================================================
+*/
+
+// NewWorkspaceSnapshotsFromVolumeSnapshots groups VolumeSnapshots by their workspace snapshot,
+// and creates a WorkspaceSnapshot model for each group, sorted by name.
+// VolumeSnapshots which are not part of a workspace snapshot are ignored.
+func NewWorkspaceSnapshotsFromVolumeSnapshots(volumeSnapshots []unstructured.Unstructured) []WorkspaceSnapshot {
+	snapshotToVolumeSnapshots := make(map[string][]unstructured.Unstructured)
+	for _, volumeSnapshot := range volumeSnapshots {
+		snapshotName := volumeSnapshot.GetLabels()[LabelWorkspaceSnapshot]
+		if snapshotName == "" {
+			continue
+		}
+		snapshotToVolumeSnapshots[snapshotName] = append(snapshotToVolumeSnapshots[snapshotName], volumeSnapshot)
+	}
+
+	snapshotModels := make([]WorkspaceSnapshot, 0, len(snapshotToVolumeSnapshots))
+	for snapshotName, snapshotVolumeSnapshots := range snapshotToVolumeSnapshots {
+		snapshotModels = append(snapshotModels, NewWorkspaceSnapshotFromVolumeSnapshots(snapshotName, snapshotVolumeSnapshots))
+	}
+	sort.Slice(snapshotModels, func(i, j int) bool {
+		return snapshotModels[i].Name < snapshotModels[j].Name
+	})
+
+	return snapshotModels
+}
+
+// NewWorkspaceSnapshotFromVolumeSnapshots creates a WorkspaceSnapshot model from the VolumeSnapshots of a workspace snapshot.
+func NewWorkspaceSnapshotFromVolumeSnapshots(snapshotName string, volumeSnapshots []unstructured.Unstructured) WorkspaceSnapshot {
+	snapshot := WorkspaceSnapshot{
+		Name:       snapshotName,
+		Volumes:    make([]VolumeSnapshotInfo, 0, len(volumeSnapshots)),
+		ReadyToUse: len(volumeSnapshots) > 0,
+	}
+
+	volumeSources := make(map[string]*VolumeSource, len(volumeSnapshots))
+	for i := range volumeSnapshots {
+		volumeSnapshot := &volumeSnapshots[i]
+
+		// the source workspace and audit info are the same on all VolumeSnapshots, so we take them from the first one
+		if i == 0 {
+			snapshot.Workspace.Name = volumeSnapshot.GetLabels()[LabelWorkspaceSnapshotSource]
+			if workspaceTemplate, err := GetWorkspaceTemplateFromVolumeSnapshot(volumeSnapshot); err == nil {
+				snapshot.Workspace.Kind = workspaceTemplate.Kind
+			}
+			snapshot.Audit = common.NewAuditFromObjectMeta(&metav1.ObjectMeta{
+				CreationTimestamp: volumeSnapshot.GetCreationTimestamp(),
+				DeletionTimestamp: volumeSnapshot.GetDeletionTimestamp(),
+				Annotations:       volumeSnapshot.GetAnnotations(),
+			})
+		}
+
+		volumeInfo := VolumeSnapshotInfo{
+			Name: volumeSnapshot.GetName(),
+		}
+		volumeInfo.SourcePVCName, _, _ = unstructured.NestedString(volumeSnapshot.Object, "spec", "source", "persistentVolumeClaimName")
+		volumeInfo.ReadyToUse, _, _ = unstructured.NestedBool(volumeSnapshot.Object, "status", "readyToUse")
+		volumeInfo.RestoreSize, _, _ = unstructured.NestedString(volumeSnapshot.Object, "status", "restoreSize")
+		volumeInfo.Error, _, _ = unstructured.NestedString(volumeSnapshot.Object, "status", "error", "message")
+		if volumeSource, err := GetVolumeSourceFromVolumeSnapshot(volumeSnapshot); err == nil {
+			volumeInfo.Type = volumeSource.Type
+			volumeInfo.MountPath = volumeSource.MountPath
+			volumeSources[volumeInfo.Name] = volumeSource
+		}
+
+		snapshot.ReadyToUse = snapshot.ReadyToUse && volumeInfo.ReadyToUse
+		snapshot.Volumes = append(snapshot.Volumes, volumeInfo)
+	}
+
+	// sort the volumes in the order they are mounted in the workspace (home first, then data volumes)
+	sort.SliceStable(snapshot.Volumes, func(i, j int) bool {
+		si, sj := volumeSources[snapshot.Volumes[i].Name], volumeSources[snapshot.Volumes[j].Name]
+		switch {
+		case si == nil || sj == nil:
+			return si != nil
+		case si.Type != sj.Type:
+			return si.Type == VolumeTypeHome
+		default:
+			return si.Index < sj.Index
+		}
+	})
+
+	return snapshot
+}
+
+// GetWorkspaceTemplateFromVolumeSnapshot decodes the workspace template stored on a VolumeSnapshot.
+func GetWorkspaceTemplateFromVolumeSnapshot(volumeSnapshot *unstructured.Unstructured) (*modelsWorkspaces.WorkspaceCreate, error) {
+	templateJSON, ok := volumeSnapshot.GetAnnotations()[AnnotationWorkspaceSnapshotTemplate]
+	if !ok {
+		return nil, fmt.Errorf("VolumeSnapshot %q has no %s annotation", volumeSnapshot.GetName(), AnnotationWorkspaceSnapshotTemplate)
+	}
+	workspaceTemplate := &modelsWorkspaces.WorkspaceCreate{}
+	if err := json.Unmarshal([]byte(templateJSON), workspaceTemplate); err != nil {
+		return nil, fmt.Errorf("failed to decode %s annotation of VolumeSnapshot %q: %w", AnnotationWorkspaceSnapshotTemplate, volumeSnapshot.GetName(), err)
+	}
+	return workspaceTemplate, nil
+}
+
+// GetVolumeSourceFromVolumeSnapshot decodes the volume source stored on a VolumeSnapshot.
+func GetVolumeSourceFromVolumeSnapshot(volumeSnapshot *unstructured.Unstructured) (*VolumeSource, error) {
+	sourceJSON, ok := volumeSnapshot.GetAnnotations()[AnnotationWorkspaceSnapshotVolume]
+	if !ok {
+		return nil, fmt.Errorf("VolumeSnapshot %q has no %s annotation", volumeSnapshot.GetName(), AnnotationWorkspaceSnapshotVolume)
+	}
+	volumeSource := &VolumeSource{}
+	if err := json.Unmarshal([]byte(sourceJSON), volumeSource); err != nil {
+		return nil, fmt.Errorf("failed to decode %s annotation of VolumeSnapshot %q: %w", AnnotationWorkspaceSnapshotVolume, volumeSnapshot.GetName(), err)
+	}
+	return volumeSource, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesnapshots
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	modelsWorkspaces "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspaces"
+)
+
+func TestWorkspaceSnapshots(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workspace Snapshots Models Suite")
+}
+
+// newTestVolumeSnapshot returns a VolumeSnapshot of a snapshot of "my-workspace" with the given status
+func newTestVolumeSnapshot(snapshotName string, source *VolumeSource, readyToUse bool, restoreSize string) *unstructured.Unstructured {
+	workspaceTemplate := &modelsWorkspaces.WorkspaceCreate{
+		Name: "my-workspace",
+		Kind: "jupyterlab",
+	}
+	volumeSnapshot, err := NewVolumeSnapshot("my-namespace", &WorkspaceSnapshotCreate{Name: snapshotName}, workspaceTemplate, source)
+	Expect(err).NotTo(HaveOccurred())
+
+	volumeSnapshot.Object["status"] = map[string]any{
+		"readyToUse":  readyToUse,
+		"restoreSize": restoreSize,
+	}
+	return volumeSnapshot
+}
+
+var _ = Describe("NewVolumeSnapshot", func() {
+	It("labels, annotates and names the VolumeSnapshot of each volume", func() {
+		workspaceTemplate := &modelsWorkspaces.WorkspaceCreate{
+			Name: "my-workspace",
+			Kind: "jupyterlab",
+		}
+		snapshotCreate := &WorkspaceSnapshotCreate{
+			Name:                    "my-snapshot",
+			VolumeSnapshotClassName: ptr.To("csi-snapclass"),
+		}
+		source := &VolumeSource{
+			Type:      VolumeTypeData,
+			Index:     2,
+			PVCName:   "my-data-pvc",
+			MountPath: "/data",
+			Storage:   "5Gi",
+		}
+
+		volumeSnapshot, err := NewVolumeSnapshot("my-namespace", snapshotCreate, workspaceTemplate, source)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(volumeSnapshot.GroupVersionKind()).To(Equal(VolumeSnapshotGVK))
+		Expect(volumeSnapshot.GetNamespace()).To(Equal("my-namespace"))
+		Expect(volumeSnapshot.GetName()).To(Equal("my-snapshot-data-2"))
+		Expect(volumeSnapshot.GetLabels()).To(Equal(map[string]string{
+			LabelWorkspaceSnapshot:       "my-snapshot",
+			LabelWorkspaceSnapshotSource: "my-workspace",
+		}))
+
+		pvcName, _, _ := unstructured.NestedString(volumeSnapshot.Object, "spec", "source", "persistentVolumeClaimName")
+		Expect(pvcName).To(Equal("my-data-pvc"))
+		className, _, _ := unstructured.NestedString(volumeSnapshot.Object, "spec", "volumeSnapshotClassName")
+		Expect(className).To(Equal("csi-snapclass"))
+
+		By("round-tripping the annotations")
+		decodedTemplate, err := GetWorkspaceTemplateFromVolumeSnapshot(volumeSnapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decodedTemplate).To(Equal(workspaceTemplate))
+		decodedSource, err := GetVolumeSourceFromVolumeSnapshot(volumeSnapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decodedSource).To(Equal(source))
+	})
+
+	It("omits the volume snapshot class when unset", func() {
+		source := &VolumeSource{Type: VolumeTypeHome, PVCName: "my-home-pvc", Storage: "1Gi"}
+		volumeSnapshot := newTestVolumeSnapshot("my-snapshot", source, false, "")
+
+		Expect(volumeSnapshot.GetName()).To(Equal("my-snapshot-home"))
+		_, found, _ := unstructured.NestedString(volumeSnapshot.Object, "spec", "volumeSnapshotClassName")
+		Expect(found).To(BeFalse())
+	})
+})
+
+var _ = Describe("NewPVCFromVolumeSnapshot", func() {
+	source := &VolumeSource{
+		Type:             VolumeTypeHome,
+		PVCName:          "my-home-pvc",
+		StorageClassName: ptr.To("csi-rbd"),
+		AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Storage:          "5Gi",
+	}
+
+	It("restores the VolumeSnapshot with the spec of the source PVC", func() {
+		volumeSnapshot := newTestVolumeSnapshot("my-snapshot", source, true, "1Gi")
+
+		pvc, err := NewPVCFromVolumeSnapshot("my-namespace", "my-clone-home", volumeSnapshot, source)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(pvc.Name).To(Equal("my-clone-home"))
+		Expect(pvc.Namespace).To(Equal("my-namespace"))
+		Expect(pvc.Spec.StorageClassName).To(Equal(ptr.To("csi-rbd")))
+		Expect(pvc.Spec.AccessModes).To(Equal(source.AccessModes))
+		Expect(pvc.Spec.Resources.Requests.Storage().Equal(resource.MustParse("5Gi"))).To(BeTrue())
+		Expect(pvc.Spec.DataSource).To(Equal(&corev1.TypedLocalObjectReference{
+			APIGroup: ptr.To("snapshot.storage.k8s.io"),
+			Kind:     "VolumeSnapshot",
+			Name:     "my-snapshot-home",
+		}))
+	})
+
+	It("requests the restore size when it is larger than the source PVC", func() {
+		volumeSnapshot := newTestVolumeSnapshot("my-snapshot", source, true, "8Gi")
+
+		pvc, err := NewPVCFromVolumeSnapshot("my-namespace", "my-clone-home", volumeSnapshot, source)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pvc.Spec.Resources.Requests.Storage().Equal(resource.MustParse("8Gi"))).To(BeTrue())
+	})
+})
+
+var _ = Describe("NewWorkspaceSnapshotsFromVolumeSnapshots", func() {
+	It("groups VolumeSnapshots by snapshot and orders volumes like the workspace", func() {
+		homeSource := &VolumeSource{Type: VolumeTypeHome, PVCName: "my-home-pvc", Storage: "1Gi"}
+		dataSource0 := &VolumeSource{Type: VolumeTypeData, Index: 0, PVCName: "my-data-pvc-0", MountPath: "/data/a", Storage: "1Gi"}
+		dataSource1 := &VolumeSource{Type: VolumeTypeData, Index: 1, PVCName: "my-data-pvc-1", MountPath: "/data/b", Storage: "1Gi"}
+		unrelated := &unstructured.Unstructured{}
+		unrelated.SetGroupVersionKind(VolumeSnapshotGVK)
+		unrelated.SetName("unrelated")
+
+		snapshots := NewWorkspaceSnapshotsFromVolumeSnapshots([]unstructured.Unstructured{
+			*newTestVolumeSnapshot("snapshot-b", dataSource1, true, "1Gi"),
+			*newTestVolumeSnapshot("snapshot-b", dataSource0, true, "1Gi"),
+			*newTestVolumeSnapshot("snapshot-b", homeSource, false, ""),
+			*newTestVolumeSnapshot("snapshot-a", homeSource, true, "1Gi"),
+			*unrelated,
+		})
+
+		Expect(snapshots).To(HaveLen(2))
+
+		Expect(snapshots[0].Name).To(Equal("snapshot-a"))
+		Expect(snapshots[0].Workspace).To(Equal(WorkspaceInfo{Name: "my-workspace", Kind: "jupyterlab"}))
+		Expect(snapshots[0].ReadyToUse).To(BeTrue())
+		Expect(snapshots[0].Volumes).To(Equal([]VolumeSnapshotInfo{
+			{Name: "snapshot-a-home", Type: VolumeTypeHome, SourcePVCName: "my-home-pvc", ReadyToUse: true, RestoreSize: "1Gi"},
+		}))
+
+		Expect(snapshots[1].Name).To(Equal("snapshot-b"))
+		Expect(snapshots[1].ReadyToUse).To(BeFalse())
+		Expect(snapshots[1].Volumes).To(HaveLen(3))
+		Expect(snapshots[1].Volumes[0].Name).To(Equal("snapshot-b-home"))
+		Expect(snapshots[1].Volumes[1].Name).To(Equal("snapshot-b-data-0"))
+		Expect(snapshots[1].Volumes[1].MountPath).To(Equal("/data/a"))
+		Expect(snapshots[1].Volumes[2].Name).To(Equal("snapshot-b-data-1"))
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesnapshots
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+)
+
+// VolumeType is the type of Workspace volume a VolumeSnapshot was taken from.
+type VolumeType string
+
+const (
+	VolumeTypeHome VolumeType = "home"
+	VolumeTypeData VolumeType = "data"
+)
+
+// WorkspaceSnapshot represents a point-in-time snapshot of the volumes of a Workspace.
+// It is backed by one VolumeSnapshot per home/data volume of the Workspace.
+type WorkspaceSnapshot struct {
+	Name      string               `json:"name"`
+	Workspace WorkspaceInfo        `json:"workspace"`
+	Volumes   []VolumeSnapshotInfo `json:"volumes"`
+	Audit     common.Audit         `json:"audit"`
+
+	// ReadyToUse is true once all VolumeSnapshots of the snapshot are ready,
+	// a Workspace can only be cloned from a snapshot which is ready to use.
+	ReadyToUse bool `json:"readyToUse"`
+}
+
+// WorkspaceInfo represents the Workspace a snapshot was taken from
+type WorkspaceInfo struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// VolumeSnapshotInfo represents one VolumeSnapshot of a WorkspaceSnapshot
+type VolumeSnapshotInfo struct {
+	Name          string     `json:"name"`
+	Type          VolumeType `json:"type"`
+	SourcePVCName string     `json:"sourcePVCName"`
+	ReadyToUse    bool       `json:"readyToUse"`
+
+	// MountPath is only set for data volumes, the mount path of the home volume is defined by the WorkspaceKind.
+	MountPath string `json:"mountPath,omitempty"`
+
+	// RestoreSize is empty until the snapshot has been taken by the CSI driver.
+	RestoreSize string `json:"restoreSize,omitempty"`
+
+	// Error is the last error reported by the snapshot controller, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// VolumeSource describes the PVC a VolumeSnapshot was taken from, and how it was mounted in the Workspace.
+// It is stored as JSON in the AnnotationWorkspaceSnapshotVolume annotation of each VolumeSnapshot,
+// so that a PVC with the same spec can be restored from it.
+type VolumeSource struct {
+	Type VolumeType `json:"type"`
+
+	// Index is the position of the volume in the data volumes of the Workspace (only set for data volumes).
+	Index int `json:"index,omitempty"`
+
+	PVCName          string                              `json:"pvcName"`
+	MountPath        string                              `json:"mountPath,omitempty"`
+	ReadOnly         bool                                `json:"readOnly,omitempty"`
+	StorageClassName *string                             `json:"storageClassName,omitempty"`
+	AccessModes      []corev1.PersistentVolumeAccessMode `json:"accessModes"`
+	Storage          string                              `json:"storage"`
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesnapshots
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/helper"
+)
+
+// WorkspaceSnapshotCreate is used to create a snapshot of a workspace.
+type WorkspaceSnapshotCreate struct {
+	Name string `json:"name"`
+
+	// VolumeSnapshotClassName is the VolumeSnapshotClass used for all volumes of the snapshot.
+	// If unset, the default VolumeSnapshotClass of each CSI driver is used.
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// Validate validates the WorkspaceSnapshotCreate struct.
+func (s *WorkspaceSnapshotCreate) Validate(prefix *field.Path) []*field.Error {
+	var errs []*field.Error
+
+	// validate the snapshot name
+	// NOTE: the name is used as a label value, so it must be a DNS label (max 63 characters)
+	namePath := prefix.Child("name")
+	errs = append(errs, helper.ValidateFieldIsDNS1123Label(namePath, s.Name)...)
+
+	// validate the volume snapshot class name
+	if s.VolumeSnapshotClassName != nil {
+		volumeSnapshotClassNamePath := prefix.Child("volumeSnapshotClassName")
+		errs = append(errs, helper.ValidateFieldIsDNS1123Subdomain(volumeSnapshotClassNamePath, *s.VolumeSnapshotClassName)...)
+	}
+
+	return errs
+}
+
+// WorkspaceSnapshotClone is used to create a new workspace from a snapshot.
+type WorkspaceSnapshotClone struct {
+	Name   string `json:"name"`
+	Paused bool   `json:"paused"`
+}
+
+// Validate validates the WorkspaceSnapshotClone struct.
+func (c *WorkspaceSnapshotClone) Validate(prefix *field.Path) []*field.Error {
+	var errs []*field.Error
+
+	// validate the workspace name
+	namePath := prefix.Child("name")
+	errs = append(errs, helper.ValidateWorkspaceName(namePath, c.Name)...)
+
+	return errs
+}
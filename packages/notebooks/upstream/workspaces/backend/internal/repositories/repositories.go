@@ -20,35 +20,48 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kubeflow/notebooks/workspaces/backend/internal/config"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/metrics"
 	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/health_check"
 	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/namespaces"
 	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/pvcs"
 	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/secrets"
 	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/storageclasses"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/upgradecampaigns"
 	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspacekinds"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspacemetrics"
 	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspaces"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspaceshares"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/repositories/workspacesnapshots"
 )
 
 // Repositories is a single convenient container to hold and represent all our repositories.
 type Repositories struct {
-	HealthCheck   *health_check.HealthCheckRepository
-	Namespace     *namespaces.NamespaceRepository
-	PVC           *pvcs.PVCRepository
-	Secret        *secrets.SecretRepository
-	StorageClass  *storageclasses.StorageClassRepository
-	Workspace     *workspaces.WorkspaceRepository
-	WorkspaceKind *workspacekinds.WorkspaceKindRepository
+	HealthCheck       *health_check.HealthCheckRepository
+	Namespace         *namespaces.NamespaceRepository
+	PVC               *pvcs.PVCRepository
+	Secret            *secrets.SecretRepository
+	StorageClass      *storageclasses.StorageClassRepository
+	UpgradeCampaign   *upgradecampaigns.UpgradeCampaignRepository
+	Workspace         *workspaces.WorkspaceRepository
+	WorkspaceKind     *workspacekinds.WorkspaceKindRepository
+	WorkspaceMetrics  *workspacemetrics.WorkspaceMetricsRepository
+	WorkspaceShare    *workspaceshares.WorkspaceShareRepository
+	WorkspaceSnapshot *workspacesnapshots.WorkspaceSnapshotRepository
 }
 
 // NewRepositories creates a new Repositories instance from a controller-runtime client.
 func NewRepositories(cfg *config.EnvConfig, cl client.Client, configMapClient client.Client) *Repositories {
 	return &Repositories{
-		HealthCheck:   health_check.NewHealthCheckRepository(cfg),
-		Namespace:     namespaces.NewNamespaceRepository(cfg, cl),
-		PVC:           pvcs.NewPVCRepository(cfg, cl),
-		Secret:        secrets.NewSecretRepository(cfg, cl),
-		StorageClass:  storageclasses.NewStorageClassRepository(cfg, cl),
-		Workspace:     workspaces.NewWorkspaceRepository(cfg, cl),
-		WorkspaceKind: workspacekinds.NewWorkspaceKindRepository(cfg, cl, configMapClient),
+		HealthCheck:       health_check.NewHealthCheckRepository(cfg),
+		Namespace:         namespaces.NewNamespaceRepository(cfg, cl),
+		PVC:               pvcs.NewPVCRepository(cfg, cl),
+		Secret:            secrets.NewSecretRepository(cfg, cl),
+		StorageClass:      storageclasses.NewStorageClassRepository(cfg, cl),
+		UpgradeCampaign:   upgradecampaigns.NewUpgradeCampaignRepository(cfg, cl),
+		Workspace:         workspaces.NewWorkspaceRepository(cfg, cl),
+		WorkspaceKind:     workspacekinds.NewWorkspaceKindRepository(cfg, cl, configMapClient),
+		WorkspaceMetrics:  workspacemetrics.NewWorkspaceMetricsRepository(cfg, cl, metrics.NewPrometheusClient(cfg.PrometheusURL)),
+		WorkspaceShare:    workspaceshares.NewWorkspaceShareRepository(cfg, cl),
+		WorkspaceSnapshot: workspacesnapshots.NewWorkspaceSnapshotRepository(cfg, cl),
 	}
 }
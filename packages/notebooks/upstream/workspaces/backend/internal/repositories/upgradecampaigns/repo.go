@@ -0,0 +1,232 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgradecampaigns
+
+import (
+	"context"
+	"errors"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/config"
+	modelsCommon "github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/upgradecampaigns"
+)
+
+var (
+	ErrUpgradeCampaignNotFound      = errors.New("upgrade campaign not found")
+	ErrUpgradeCampaignAlreadyExists = errors.New("upgrade campaign already exists")
+	ErrWorkspaceKindHasCampaign     = errors.New("workspace kind already has an upgrade campaign")
+	ErrWorkspaceKindNotFound        = errors.New("workspace kind not found")
+	ErrWorkspaceNotFound            = errors.New("workspace not found")
+	ErrWorkspaceUpgradeNotPending   = errors.New("workspace has no pending upgrade")
+	ErrWorkspaceUpgradeDeferred     = errors.New("workspace upgrade was already deferred")
+)
+
+type UpgradeCampaignRepository struct {
+	cfg    *config.EnvConfig
+	client client.Client
+}
+
+func NewUpgradeCampaignRepository(cfg *config.EnvConfig, cl client.Client) *UpgradeCampaignRepository {
+	return &UpgradeCampaignRepository{
+		cfg:    cfg,
+		client: cl,
+	}
+}
+
+func (r *UpgradeCampaignRepository) GetUpgradeCampaigns(ctx context.Context) ([]models.UpgradeCampaign, error) {
+	campaignList := &kubefloworgv1beta1.WorkspaceUpgradeCampaignList{}
+	if err := r.client.List(ctx, campaignList); err != nil {
+		return nil, err
+	}
+
+	return models.NewUpgradeCampaignsFromWorkspaceUpgradeCampaigns(campaignList.Items), nil
+}
+
+func (r *UpgradeCampaignRepository) GetUpgradeCampaign(ctx context.Context, name string) (*models.UpgradeCampaign, error) {
+	campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: name}, campaign); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrUpgradeCampaignNotFound
+		}
+		return nil, err
+	}
+
+	campaignModel := models.NewUpgradeCampaignFromWorkspaceUpgradeCampaign(campaign)
+	return &campaignModel, nil
+}
+
+// CreateUpgradeCampaign starts a new upgrade campaign for a workspace kind.
+// Each workspace kind can only have one campaign, so that a workspace is never upgraded (or deferred) by two campaigns.
+func (r *UpgradeCampaignRepository) CreateUpgradeCampaign(ctx context.Context, actor user.Info, campaignCreate *models.UpgradeCampaignCreate) (*models.UpgradeCampaign, error) {
+	// ensure the workspace kind exists
+	workspaceKind := &kubefloworgv1beta1.WorkspaceKind{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: campaignCreate.WorkspaceKind}, workspaceKind); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrWorkspaceKindNotFound
+		}
+		return nil, err
+	}
+
+	// ensure the workspace kind has no other campaign
+	existingCampaign, err := r.getCampaignForWorkspaceKind(ctx, campaignCreate.WorkspaceKind)
+	if err != nil {
+		return nil, err
+	}
+	if existingCampaign != nil {
+		if existingCampaign.Name == campaignCreate.Name {
+			return nil, ErrUpgradeCampaignAlreadyExists
+		}
+		return nil, ErrWorkspaceKindHasCampaign
+	}
+
+	campaign := models.NewWorkspaceUpgradeCampaign(campaignCreate)
+	modelsCommon.UpdateObjectMetaForCreate(&campaign.ObjectMeta, actor)
+
+	if err := r.client.Create(ctx, campaign); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, ErrUpgradeCampaignAlreadyExists
+		}
+		// NOTE: we don't wrap this error so we can unpack it in the caller
+		//       and extract the validation errors returned by the Kubernetes API server
+		return nil, err
+	}
+
+	campaignModel := models.NewUpgradeCampaignFromWorkspaceUpgradeCampaign(campaign)
+	return &campaignModel, nil
+}
+
+// DeleteUpgradeCampaign stops an upgrade campaign, workspaces which are already upgrading are not affected.
+func (r *UpgradeCampaignRepository) DeleteUpgradeCampaign(ctx context.Context, name string) error {
+	campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+
+	if err := r.client.Delete(ctx, campaign); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrUpgradeCampaignNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetPendingUpgrades returns the workspaces of a workspace kind which would be upgraded by a campaign.
+func (r *UpgradeCampaignRepository) GetPendingUpgrades(ctx context.Context, workspaceKindName string) ([]models.PendingUpgrade, error) {
+	// ensure the workspace kind exists
+	workspaceKind := &kubefloworgv1beta1.WorkspaceKind{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: workspaceKindName}, workspaceKind); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrWorkspaceKindNotFound
+		}
+		return nil, err
+	}
+
+	workspaceList := &kubefloworgv1beta1.WorkspaceList{}
+	if err := r.client.List(ctx, workspaceList); err != nil {
+		return nil, err
+	}
+	workspaces := make([]kubefloworgv1beta1.Workspace, 0, len(workspaceList.Items))
+	for _, workspace := range workspaceList.Items {
+		if workspace.Spec.Kind == workspaceKindName {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+
+	return models.NewPendingUpgradesFromWorkspaces(workspaces), nil
+}
+
+// DeferWorkspaceUpgrade postpones the upgrade of a workspace by the campaign of its workspace kind.
+// The deferral is recorded as an annotation on the workspace, which the controller applies to the campaign status,
+// each workspace can only be deferred once per campaign.
+func (r *UpgradeCampaignRepository) DeferWorkspaceUpgrade(ctx context.Context, namespace, workspaceName string) (*models.UpgradeDeferral, error) {
+	// get the workspace
+	workspace := &kubefloworgv1beta1.Workspace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: workspaceName}, workspace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	// get the campaign of the workspace kind
+	campaign, err := r.getCampaignForWorkspaceKind(ctx, workspace.Spec.Kind)
+	if err != nil {
+		return nil, err
+	}
+	if campaign == nil {
+		return nil, ErrWorkspaceUpgradeNotPending
+	}
+
+	// ensure the upgrade can be deferred
+	// NOTE: the controller only adds workspaces to the campaign status periodically,
+	//       so a workspace which is not (yet) part of the campaign can be deferred if it has a pending redirect
+	if workspace.GetAnnotations()[models.AnnotationUpgradeCampaignDeferral] == campaign.Name {
+		return nil, ErrWorkspaceUpgradeDeferred
+	}
+	entry, ok := models.GetWorkspaceUpgrade(campaign, namespace, workspaceName)
+	switch {
+	case ok && entry.Deferred:
+		return nil, ErrWorkspaceUpgradeDeferred
+	case ok && entry.State != kubefloworgv1beta1.WorkspaceUpgradeStatePending:
+		return nil, ErrWorkspaceUpgradeNotPending
+	case !ok && !models.IsWorkspaceUpgradePending(workspace):
+		return nil, ErrWorkspaceUpgradeNotPending
+	}
+
+	// set the deferral annotation
+	patch := client.MergeFrom(workspace.DeepCopy())
+	annotations := workspace.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[models.AnnotationUpgradeCampaignDeferral] = campaign.Name
+	workspace.SetAnnotations(annotations)
+	if err := r.client.Patch(ctx, workspace, patch); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	return &models.UpgradeDeferral{
+		Campaign:        campaign.Name,
+		DeferralSeconds: campaign.Spec.DeferralSeconds,
+	}, nil
+}
+
+// getCampaignForWorkspaceKind returns the campaign of a workspace kind, or nil if there is none.
+func (r *UpgradeCampaignRepository) getCampaignForWorkspaceKind(ctx context.Context, workspaceKindName string) (*kubefloworgv1beta1.WorkspaceUpgradeCampaign, error) {
+	campaignList := &kubefloworgv1beta1.WorkspaceUpgradeCampaignList{}
+	if err := r.client.List(ctx, campaignList); err != nil {
+		return nil, err
+	}
+	for i := range campaignList.Items {
+		if campaignList.Items[i].Spec.WorkspaceKind == workspaceKindName {
+			return &campaignList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/config"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/metrics"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspacemetrics"
+)
+
+const (
+	// the label set by the controller on the StatefulSet of a Workspace
+	labelWorkspaceName = "notebooks.kubeflow.org/workspace-name"
+
+	// the duration and resolution of the usage history
+	historyDuration = 24 * time.Hour
+	historyStep     = 15 * time.Minute
+)
+
+var (
+	ErrWorkspaceNotFound = errors.New("workspace not found")
+)
+
+type WorkspaceMetricsRepository struct {
+	cfg        *config.EnvConfig
+	client     client.Client
+	prometheus *metrics.PrometheusClient
+}
+
+func NewWorkspaceMetricsRepository(cfg *config.EnvConfig, cl client.Client, prometheus *metrics.PrometheusClient) *WorkspaceMetricsRepository {
+	return &WorkspaceMetricsRepository{
+		cfg:        cfg,
+		client:     cl,
+		prometheus: prometheus,
+	}
+}
+
+// GetWorkspaceMetrics returns the current and historical resource usage of a workspace.
+// Failures of the metrics sources are reported as warnings, so that the available metrics are still returned.
+func (r *WorkspaceMetricsRepository) GetWorkspaceMetrics(ctx context.Context, namespace, workspaceName string) (*models.WorkspaceMetrics, error) {
+	// get the workspace
+	workspace := &kubefloworgv1beta1.Workspace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: workspaceName}, workspace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	workspaceMetrics := &models.WorkspaceMetrics{
+		History:            []models.ResourceUsage{},
+		HistoryStepSeconds: int64(historyStep.Seconds()),
+		Warnings:           []string{},
+	}
+
+	// get the resources requested by the pod config
+	workspaceKind := &kubefloworgv1beta1.WorkspaceKind{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: workspace.Spec.Kind}, workspaceKind); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		workspaceMetrics.Warnings = append(workspaceMetrics.Warnings, fmt.Sprintf("workspace kind %q not found, requested resources are unknown", workspace.Spec.Kind))
+	} else {
+		resources := models.GetPodConfigResources(workspace, workspaceKind)
+		workspaceMetrics.Requests, workspaceMetrics.Limits = models.NewResourceAmountsFromResourceRequirements(resources)
+	}
+
+	// get the CPU, memory and GPU usage
+	now := time.Now()
+	workspaceMetrics.Current = models.ResourceUsage{Timestamp: now.Unix()}
+	if !r.prometheus.Configured() {
+		workspaceMetrics.Warnings = append(workspaceMetrics.Warnings, "resource usage is not available, no Prometheus URL is configured")
+	} else {
+		statefulSet, err := r.getWorkspaceStatefulSet(ctx, workspace)
+		if err != nil {
+			return nil, err
+		}
+		if statefulSet != nil {
+			queries := metrics.NewWorkspaceQueries(namespace, statefulSet.Name)
+			warnings := r.getResourceUsage(ctx, queries, now, workspaceMetrics)
+			workspaceMetrics.Warnings = append(workspaceMetrics.Warnings, warnings...)
+		}
+	}
+
+	// get the volume usage
+	pvcNames := getWorkspacePVCNames(workspace)
+	pvcCapacities, err := r.getPVCCapacities(ctx, namespace, pvcNames)
+	if err != nil {
+		return nil, err
+	}
+	var volumeStats []metrics.VolumeStats
+	if r.prometheus.Configured() && workspace.Status.PodTemplatePod.Name != "" {
+		volumeStats, err = r.prometheus.GetVolumeStats(ctx, namespace, pvcNames, now)
+		if err != nil {
+			workspaceMetrics.Warnings = append(workspaceMetrics.Warnings, fmt.Sprintf("volume usage is not available: %s", err.Error()))
+		}
+	}
+	workspaceMetrics.Volumes = models.NewVolumeUsages(workspace, pvcCapacities, volumeStats)
+
+	return workspaceMetrics, nil
+}
+
+// getResourceUsage sets the current and historical resource usage from Prometheus, and returns a warning for each failed query.
+func (r *WorkspaceMetricsRepository) getResourceUsage(ctx context.Context, queries metrics.WorkspaceQueries, now time.Time, workspaceMetrics *models.WorkspaceMetrics) []string {
+	var warnings []string
+	query := func(name, promQL string) (*float64, []metrics.Sample) {
+		value, found, err := r.prometheus.Query(ctx, promQL, now)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s usage is not available: %s", name, err.Error()))
+			return nil, nil
+		}
+		history, err := r.prometheus.QueryRange(ctx, promQL, now.Add(-historyDuration), now, historyStep)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s usage history is not available: %s", name, err.Error()))
+		}
+		if !found {
+			return nil, history
+		}
+		return ptr.To(value), history
+	}
+
+	cpu, cpuHistory := query("CPU", queries.CPU)
+	memory, memoryHistory := query("memory", queries.Memory)
+	gpu, gpuHistory := query("GPU", queries.GPU)
+
+	workspaceMetrics.Current.CPU = cpu
+	if memory != nil {
+		workspaceMetrics.Current.MemoryBytes = ptr.To(int64(math.Round(*memory)))
+	}
+	workspaceMetrics.Current.GPUUtilization = gpu
+	workspaceMetrics.History = models.NewResourceUsageHistory(cpuHistory, memoryHistory, gpuHistory)
+
+	return warnings
+}
+
+// getWorkspaceStatefulSet returns the StatefulSet of a workspace, or nil if it has not been created yet.
+func (r *WorkspaceMetricsRepository) getWorkspaceStatefulSet(ctx context.Context, workspace *kubefloworgv1beta1.Workspace) (*appsv1.StatefulSet, error) {
+	statefulSetList := &appsv1.StatefulSetList{}
+	listOptions := []client.ListOption{
+		client.InNamespace(workspace.Namespace),
+		client.MatchingLabels{labelWorkspaceName: workspace.Name},
+	}
+	if err := r.client.List(ctx, statefulSetList, listOptions...); err != nil {
+		return nil, err
+	}
+	for i := range statefulSetList.Items {
+		if metav1.IsControlledBy(&statefulSetList.Items[i], workspace) {
+			return &statefulSetList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// getWorkspacePVCNames returns the names of the home and data PVCs of a workspace.
+func getWorkspacePVCNames(workspace *kubefloworgv1beta1.Workspace) []string {
+	pvcNames := make([]string, 0, len(workspace.Spec.PodTemplate.Volumes.Data)+1)
+	if home := ptr.Deref(workspace.Spec.PodTemplate.Volumes.Home, ""); home != "" {
+		pvcNames = append(pvcNames, home)
+	}
+	for _, data := range workspace.Spec.PodTemplate.Volumes.Data {
+		pvcNames = append(pvcNames, data.PVCName)
+	}
+	return pvcNames
+}
+
+// getPVCCapacities returns the capacity of each of the given PVCs which exists and is bound.
+func (r *WorkspaceMetricsRepository) getPVCCapacities(ctx context.Context, namespace string, pvcNames []string) (map[string]resource.Quantity, error) {
+	pvcCapacities := make(map[string]resource.Quantity, len(pvcNames))
+	for _, pvcName := range pvcNames {
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: pvcName}, pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			pvcCapacities[pvcName] = capacity
+		}
+	}
+	return pvcCapacities, nil
+}
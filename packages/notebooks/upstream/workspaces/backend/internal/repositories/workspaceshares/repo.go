@@ -0,0 +1,152 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspaceshares
+
+import (
+	"context"
+	"errors"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/config"
+	modelsCommon "github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspaceshares"
+)
+
+var (
+	ErrWorkspaceNotFound           = errors.New("workspace not found")
+	ErrWorkspaceShareNotFound      = errors.New("workspace share not found")
+	ErrWorkspaceShareAlreadyExists = errors.New("workspace is already shared with this user")
+)
+
+type WorkspaceShareRepository struct {
+	cfg    *config.EnvConfig
+	client client.Client
+}
+
+func NewWorkspaceShareRepository(cfg *config.EnvConfig, cl client.Client) *WorkspaceShareRepository {
+	return &WorkspaceShareRepository{
+		cfg:    cfg,
+		client: cl,
+	}
+}
+
+func (r *WorkspaceShareRepository) GetWorkspaceShares(ctx context.Context, namespace, workspaceName string) ([]models.WorkspaceShare, error) {
+	// ensure the workspace exists
+	if _, err := r.getWorkspace(ctx, namespace, workspaceName); err != nil {
+		return nil, err
+	}
+
+	roleBindings, err := r.getRoleBindings(ctx, namespace, workspaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewWorkspaceSharesFromRoleBindings(roleBindings), nil
+}
+
+// CreateWorkspaceShare shares a workspace with a user, by binding the user to a Role scoped to the workspace.
+func (r *WorkspaceShareRepository) CreateWorkspaceShare(ctx context.Context, actor user.Info, shareCreate *models.WorkspaceShareCreate, namespace, workspaceName string) (*models.WorkspaceShare, error) {
+	workspace, err := r.getWorkspace(ctx, namespace, workspaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// ensure the Role of the access level exists
+	// NOTE: the Role is shared by all RoleBindings with the same access level, and is never updated,
+	//       so we don't need to read it (which would require the backend to cache all Roles)
+	role := models.NewRole(workspace, shareCreate.Access)
+	if err := r.client.Create(ctx, role); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, err
+	}
+
+	// create the RoleBinding
+	roleBinding := models.NewRoleBinding(workspace, shareCreate)
+	modelsCommon.UpdateObjectMetaForCreate(&roleBinding.ObjectMeta, actor)
+	if err := r.client.Create(ctx, roleBinding); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, ErrWorkspaceShareAlreadyExists
+		}
+		return nil, err
+	}
+
+	shareModel, ok := models.NewWorkspaceShareFromRoleBinding(roleBinding)
+	if !ok {
+		// this should never happen, as we just created the RoleBinding
+		return nil, errors.New("created RoleBinding is not a valid workspace share")
+	}
+	return shareModel, nil
+}
+
+// DeleteWorkspaceShare stops sharing a workspace with a user.
+// NOTE: the Roles of the workspace are not deleted, they are garbage collected with the workspace.
+func (r *WorkspaceShareRepository) DeleteWorkspaceShare(ctx context.Context, namespace, workspaceName, shareName string) error {
+	roleBinding := &rbacv1.RoleBinding{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: shareName}, roleBinding); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrWorkspaceShareNotFound
+		}
+		return err
+	}
+
+	// ensure the RoleBinding shares this workspace, so the endpoint can't delete unrelated RoleBindings
+	if roleBinding.Labels[models.LabelWorkspaceName] != workspaceName {
+		return ErrWorkspaceShareNotFound
+	}
+	if _, ok := roleBinding.Labels[models.LabelWorkspaceShareAccess]; !ok {
+		return ErrWorkspaceShareNotFound
+	}
+
+	if err := r.client.Delete(ctx, roleBinding); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrWorkspaceShareNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// getWorkspace returns the workspace with the given name.
+func (r *WorkspaceShareRepository) getWorkspace(ctx context.Context, namespace, workspaceName string) (*kubefloworgv1beta1.Workspace, error) {
+	workspace := &kubefloworgv1beta1.Workspace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: workspaceName}, workspace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// getRoleBindings returns the share RoleBindings of a workspace.
+func (r *WorkspaceShareRepository) getRoleBindings(ctx context.Context, namespace, workspaceName string) ([]rbacv1.RoleBinding, error) {
+	roleBindingList := &rbacv1.RoleBindingList{}
+	listOptions := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{models.LabelWorkspaceName: workspaceName},
+		client.HasLabels{models.LabelWorkspaceShareAccess},
+	}
+	if err := r.client.List(ctx, roleBindingList, listOptions...); err != nil {
+		return nil, err
+	}
+	return roleBindingList.Items, nil
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesnapshots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/config"
+	"github.com/kubeflow/notebooks/workspaces/backend/internal/helper"
+	modelsCommon "github.com/kubeflow/notebooks/workspaces/backend/internal/models/common"
+	modelsWorkspaces "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspaces"
+	models "github.com/kubeflow/notebooks/workspaces/backend/internal/models/workspacesnapshots"
+)
+
+var (
+	ErrWorkspaceSnapshotNotFound      = errors.New("workspace snapshot not found")
+	ErrWorkspaceSnapshotAlreadyExists = errors.New("workspace snapshot already exists")
+	ErrWorkspaceSnapshotNotReady      = errors.New("workspace snapshot is not ready to use")
+	ErrWorkspaceNotFound              = errors.New("workspace not found")
+	ErrWorkspaceAlreadyExists         = errors.New("workspace already exists")
+	ErrWorkspaceHasNoVolumes          = errors.New("workspace has no home or data volumes to snapshot")
+	ErrPVCAlreadyExists               = errors.New("PVC already exists")
+)
+
+type WorkspaceSnapshotRepository struct {
+	cfg    *config.EnvConfig
+	client client.Client
+}
+
+func NewWorkspaceSnapshotRepository(cfg *config.EnvConfig, cl client.Client) *WorkspaceSnapshotRepository {
+	return &WorkspaceSnapshotRepository{
+		cfg:    cfg,
+		client: cl,
+	}
+}
+
+func (r *WorkspaceSnapshotRepository) GetWorkspaceSnapshots(ctx context.Context, namespace string) ([]models.WorkspaceSnapshot, error) {
+	volumeSnapshotList := &unstructured.UnstructuredList{}
+	volumeSnapshotList.SetGroupVersionKind(models.VolumeSnapshotListGVK)
+	listOptions := []client.ListOption{
+		client.InNamespace(namespace),
+		client.HasLabels{models.LabelWorkspaceSnapshot},
+	}
+	if err := r.client.List(ctx, volumeSnapshotList, listOptions...); err != nil {
+		return nil, err
+	}
+
+	return models.NewWorkspaceSnapshotsFromVolumeSnapshots(volumeSnapshotList.Items), nil
+}
+
+func (r *WorkspaceSnapshotRepository) GetWorkspaceSnapshot(ctx context.Context, namespace, snapshotName string) (*models.WorkspaceSnapshot, error) {
+	volumeSnapshots, err := r.getVolumeSnapshots(ctx, namespace, snapshotName)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotModel := models.NewWorkspaceSnapshotFromVolumeSnapshots(snapshotName, volumeSnapshots)
+	return &snapshotModel, nil
+}
+
+// getVolumeSnapshots returns the VolumeSnapshots of a workspace snapshot.
+func (r *WorkspaceSnapshotRepository) getVolumeSnapshots(ctx context.Context, namespace, snapshotName string) ([]unstructured.Unstructured, error) {
+	volumeSnapshotList := &unstructured.UnstructuredList{}
+	volumeSnapshotList.SetGroupVersionKind(models.VolumeSnapshotListGVK)
+	listOptions := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels{models.LabelWorkspaceSnapshot: snapshotName},
+	}
+	if err := r.client.List(ctx, volumeSnapshotList, listOptions...); err != nil {
+		return nil, err
+	}
+	if len(volumeSnapshotList.Items) == 0 {
+		return nil, ErrWorkspaceSnapshotNotFound
+	}
+	return volumeSnapshotList.Items, nil
+}
+
+// CreateWorkspaceSnapshot takes a VolumeSnapshot of the home and data PVCs of a workspace.
+// The spec of the workspace is stored on each VolumeSnapshot, so that it can later be cloned.
+func (r *WorkspaceSnapshotRepository) CreateWorkspaceSnapshot(ctx context.Context, actor user.Info, snapshotCreate *models.WorkspaceSnapshotCreate, namespace, workspaceName string) (*models.WorkspaceSnapshot, error) {
+	// get the workspace
+	workspace := &kubefloworgv1beta1.Workspace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: workspaceName}, workspace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+
+	// ensure the snapshot does not already exist
+	// NOTE: the names of VolumeSnapshots depend on the volumes of the workspace,
+	//       so we can't rely on the Kubernetes API to detect that the snapshot exists
+	_, err := r.getVolumeSnapshots(ctx, namespace, snapshotCreate.Name)
+	if err == nil {
+		return nil, ErrWorkspaceSnapshotAlreadyExists
+	}
+	if !errors.Is(err, ErrWorkspaceSnapshotNotFound) {
+		return nil, err
+	}
+
+	// build the volume source for each home/data PVC of the workspace
+	volumeSources, err := r.getWorkspaceVolumeSources(ctx, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if len(volumeSources) == 0 {
+		return nil, ErrWorkspaceHasNoVolumes
+	}
+
+	// create a VolumeSnapshot for each volume
+	// NOTE: we don't snapshot Secrets, they are referenced by name when the workspace is cloned
+	workspaceTemplate := modelsWorkspaces.NewWorkspaceCreateModelFromWorkspace(workspace)
+	createdVolumeSnapshots := make([]unstructured.Unstructured, 0, len(volumeSources))
+	createdObjects := make([]client.Object, 0, len(volumeSources))
+	for _, volumeSource := range volumeSources {
+		volumeSnapshot, err := models.NewVolumeSnapshot(namespace, snapshotCreate, workspaceTemplate, volumeSource)
+		if err != nil {
+			return nil, r.rollback(ctx, err, createdObjects...)
+		}
+
+		// set audit annotations
+		objectMeta := &metav1.ObjectMeta{Annotations: volumeSnapshot.GetAnnotations()}
+		modelsCommon.UpdateObjectMetaForCreate(objectMeta, actor)
+		volumeSnapshot.SetAnnotations(objectMeta.Annotations)
+
+		if err := r.client.Create(ctx, volumeSnapshot); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				err = ErrWorkspaceSnapshotAlreadyExists
+			}
+			return nil, r.rollback(ctx, err, createdObjects...)
+		}
+		createdVolumeSnapshots = append(createdVolumeSnapshots, *volumeSnapshot)
+		createdObjects = append(createdObjects, volumeSnapshot)
+	}
+
+	snapshotModel := models.NewWorkspaceSnapshotFromVolumeSnapshots(snapshotCreate.Name, createdVolumeSnapshots)
+	return &snapshotModel, nil
+}
+
+// getWorkspaceVolumeSources returns the VolumeSource of each distinct home/data PVC of a workspace.
+func (r *WorkspaceSnapshotRepository) getWorkspaceVolumeSources(ctx context.Context, workspace *kubefloworgv1beta1.Workspace) ([]*models.VolumeSource, error) {
+	var volumeSources []*models.VolumeSource
+	var valErrs field.ErrorList
+	seenPVCs := make(map[string]bool)
+
+	addVolumeSource := func(path *field.Path, volumeSource *models.VolumeSource) error {
+		if seenPVCs[volumeSource.PVCName] {
+			return nil
+		}
+		seenPVCs[volumeSource.PVCName] = true
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: workspace.Namespace, Name: volumeSource.PVCName}, pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				valErrs = append(valErrs, field.NotFound(path, volumeSource.PVCName))
+				return nil
+			}
+			return err
+		}
+		volumeSource.StorageClassName = pvc.Spec.StorageClassName
+		volumeSource.AccessModes = pvc.Spec.AccessModes
+		volumeSource.Storage = pvc.Spec.Resources.Requests.Storage().String()
+		volumeSources = append(volumeSources, volumeSource)
+		return nil
+	}
+
+	volumes := workspace.Spec.PodTemplate.Volumes
+	if volumes.Home != nil {
+		homePath := field.NewPath("podTemplate", "volumes", "home")
+		if err := addVolumeSource(homePath, &models.VolumeSource{Type: models.VolumeTypeHome, PVCName: *volumes.Home}); err != nil {
+			return nil, err
+		}
+	}
+	for i, dataVolume := range volumes.Data {
+		dataPath := field.NewPath("podTemplate", "volumes", "data").Index(i).Child("pvcName")
+		volumeSource := &models.VolumeSource{
+			Type:      models.VolumeTypeData,
+			Index:     i,
+			PVCName:   dataVolume.PVCName,
+			MountPath: dataVolume.MountPath,
+			ReadOnly:  dataVolume.ReadOnly != nil && *dataVolume.ReadOnly,
+		}
+		if err := addVolumeSource(dataPath, volumeSource); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(valErrs) > 0 {
+		return nil, helper.NewInternalValidationError(valErrs)
+	}
+	return volumeSources, nil
+}
+
+func (r *WorkspaceSnapshotRepository) DeleteWorkspaceSnapshot(ctx context.Context, namespace, snapshotName string) error {
+	volumeSnapshots, err := r.getVolumeSnapshots(ctx, namespace, snapshotName)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: PVCs which were restored from the snapshot are not affected by deleting it
+	for i := range volumeSnapshots {
+		if err := r.client.Delete(ctx, &volumeSnapshots[i]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CloneWorkspaceSnapshot creates a new workspace from a snapshot.
+// A PVC is restored from each VolumeSnapshot, and the workspace is created with the same kind
+// and pod template options as the workspace the snapshot was taken from, mounting the restored PVCs.
+func (r *WorkspaceSnapshotRepository) CloneWorkspaceSnapshot(ctx context.Context, actor user.Info, snapshotClone *models.WorkspaceSnapshotClone, namespace, snapshotName string) (*modelsWorkspaces.WorkspaceCreate, error) {
+	volumeSnapshots, err := r.getVolumeSnapshots(ctx, namespace, snapshotName)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot := models.NewWorkspaceSnapshotFromVolumeSnapshots(snapshotName, volumeSnapshots); !snapshot.ReadyToUse {
+		return nil, ErrWorkspaceSnapshotNotReady
+	}
+	workspaceTemplate, err := models.GetWorkspaceTemplateFromVolumeSnapshot(&volumeSnapshots[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// ensure the workspace does not already exist, so we don't restore PVCs for nothing
+	existingWorkspace := &kubefloworgv1beta1.Workspace{}
+	err = r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: snapshotClone.Name}, existingWorkspace)
+	if err == nil {
+		return nil, ErrWorkspaceAlreadyExists
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	// restore a PVC from each VolumeSnapshot
+	// the restored PVCs are named after the new workspace, with the same suffix as their VolumeSnapshot
+	restoredPVCNames := make(map[string]string, len(volumeSnapshots))
+	createdPVCs := make([]client.Object, 0, len(volumeSnapshots))
+	for i := range volumeSnapshots {
+		volumeSnapshot := &volumeSnapshots[i]
+		volumeSource, err := models.GetVolumeSourceFromVolumeSnapshot(volumeSnapshot)
+		if err != nil {
+			return nil, r.rollback(ctx, err, createdPVCs...)
+		}
+
+		pvcName := snapshotClone.Name + strings.TrimPrefix(volumeSnapshot.GetName(), snapshotName)
+		pvc, err := models.NewPVCFromVolumeSnapshot(namespace, pvcName, volumeSnapshot, volumeSource)
+		if err != nil {
+			return nil, r.rollback(ctx, err, createdPVCs...)
+		}
+
+		// set audit annotations
+		modelsCommon.UpdateObjectMetaForCreate(&pvc.ObjectMeta, actor)
+
+		if err := r.client.Create(ctx, pvc); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				err = ErrPVCAlreadyExists
+			}
+			return nil, r.rollback(ctx, err, createdPVCs...)
+		}
+		createdPVCs = append(createdPVCs, pvc)
+		restoredPVCNames[volumeSource.PVCName] = pvcName
+	}
+
+	// point the workspace template at the restored PVCs
+	workspaceCreate := workspaceTemplate
+	workspaceCreate.Name = snapshotClone.Name
+	workspaceCreate.Paused = snapshotClone.Paused
+	if home := workspaceCreate.PodTemplate.Volumes.Home; home != nil {
+		if pvcName, ok := restoredPVCNames[*home]; ok {
+			workspaceCreate.PodTemplate.Volumes.Home = &pvcName
+		}
+	}
+	for i, dataVolume := range workspaceCreate.PodTemplate.Volumes.Data {
+		if pvcName, ok := restoredPVCNames[dataVolume.PVCName]; ok {
+			workspaceCreate.PodTemplate.Volumes.Data[i].PVCName = pvcName
+		}
+	}
+
+	// create the workspace
+	workspace, err := modelsWorkspaces.NewWorkspaceFromRestoredWorkspaceCreateModel(ctx, r.client, workspaceCreate, namespace)
+	if err != nil {
+		return nil, r.rollback(ctx, err, createdPVCs...)
+	}
+	modelsCommon.UpdateObjectMetaForCreate(&workspace.ObjectMeta, actor)
+	if err := r.client.Create(ctx, workspace); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			err = ErrWorkspaceAlreadyExists
+		}
+		// NOTE: we don't wrap invalid errors so we can unpack them in the caller
+		//       and extract the validation errors returned by the Kubernetes API server
+		return nil, r.rollback(ctx, err, createdPVCs...)
+	}
+
+	createdWorkspaceModel := modelsWorkspaces.NewWorkspaceCreateModelFromWorkspace(workspace)
+	return createdWorkspaceModel, nil
+}
+
+// rollback deletes the objects which were created by a failed operation, and returns the error of the operation.
+// If some objects could not be deleted, their errors are joined to the returned error.
+func (r *WorkspaceSnapshotRepository) rollback(ctx context.Context, err error, objects ...client.Object) error {
+	errs := []error{err}
+	for _, obj := range objects {
+		if deleteErr := r.client.Delete(ctx, obj); deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+			errs = append(errs, fmt.Errorf("failed to roll back %q: %w", obj.GetName(), deleteErr))
+		}
+	}
+	if len(errs) == 1 {
+		return err
+	}
+	return errors.Join(errs...)
+}
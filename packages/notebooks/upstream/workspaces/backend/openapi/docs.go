@@ -715,6 +715,240 @@ const docTemplate = `{
                 }
             }
         },
+        "/upgradecampaigns": {
+            "get": {
+                "description": "Returns a list of all upgrade campaigns in the cluster, including the upgrade state of each affected workspace.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upgradecampaigns"
+                ],
+                "summary": "List upgrade campaigns",
+                "operationId": "listUpgradeCampaigns",
+                "responses": {
+                    "200": {
+                        "description": "Successful operation. Returns a list of all upgrade campaigns.",
+                        "schema": {
+                            "$ref": "#/definitions/api.UpgradeCampaignListEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to list upgrade campaigns.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Starts a campaign which restarts the workspaces of a workspace kind that have a pending redirect, in batches, while they are idle. Each workspace kind can only have one campaign.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upgradecampaigns"
+                ],
+                "summary": "Create upgrade campaign",
+                "operationId": "createUpgradeCampaign",
+                "parameters": [
+                    {
+                        "description": "Upgrade campaign configuration",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.UpgradeCampaignCreateEnvelope"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Upgrade campaign created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/api.UpgradeCampaignEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to create upgrade campaigns.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict. Upgrade campaign with the same name, or for the same workspace kind, already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large. The request body is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type. Content-Type header is not correct.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/upgradecampaigns/{name}": {
+            "get": {
+                "description": "Returns details of a specific upgrade campaign identified by its name, including the upgrade state of each affected workspace.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "upgradecampaigns"
+                ],
+                "summary": "Get upgrade campaign",
+                "operationId": "getUpgradeCampaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "jupyterlab-upgrade",
+                        "description": "Name of the upgrade campaign",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation. Returns the requested upgrade campaign.",
+                        "schema": {
+                            "$ref": "#/definitions/api.UpgradeCampaignEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to access the upgrade campaign.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Upgrade campaign does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stops and deletes a specific upgrade campaign identified by its name. Workspaces which are already restarting are not affected.",
+                "tags": [
+                    "upgradecampaigns"
+                ],
+                "summary": "Delete upgrade campaign",
+                "operationId": "deleteUpgradeCampaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "jupyterlab-upgrade",
+                        "description": "Name of the upgrade campaign",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Upgrade campaign deleted successfully"
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to delete the upgrade campaign.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Upgrade campaign does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
         "/user": {
             "get": {
                 "description": "Returns the current user's settings including user ID and admin status",
@@ -1242,6 +1476,67 @@ const docTemplate = `{
                 }
             }
         },
+        "/workspacekinds/{name}/pendingupgrades": {
+            "get": {
+                "description": "Returns the workspaces of a workspace kind which would be restarted by an upgrade campaign, because one of their podTemplate options has a redirect.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspacekinds"
+                ],
+                "summary": "List pending upgrades of a workspace kind",
+                "operationId": "listWorkspaceKindPendingUpgrades",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "jupyterlab",
+                        "description": "Name of the workspace kind",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation. Returns the workspaces with a pending upgrade.",
+                        "schema": {
+                            "$ref": "#/definitions/api.PendingUpgradeListEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to list workspaces.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace kind does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
         "/workspacekinds/{name}/podtemplate/options/listvalues": {
             "post": {
                 "description": "Returns filtered imageConfig and podConfig options based on the provided context.",
@@ -1764,12 +2059,87 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/{namespace}/{name}/actions/pause": {
+        "/workspaces/{namespace}/{name}/actions/deferupgrade": {
             "post": {
-                "description": "Pauses or unpauses a workspace, stopping or resuming all associated pods.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Postpones the restart of a workspace by the upgrade campaign of its workspace kind. Each workspace can only be deferred once per campaign.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Defer the upgrade of a workspace",
+                "operationId": "deferWorkspaceUpgrade",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "default",
+                        "description": "Namespace of the workspace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-workspace",
+                        "description": "Name of the workspace",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful action. Returns the deferred campaign.",
+                        "schema": {
+                            "$ref": "#/definitions/api.UpgradeDeferralEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to update the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace does not exist, or has no pending upgrade.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict. Workspace upgrade was already deferred.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{namespace}/{name}/actions/pause": {
+            "post": {
+                "description": "Pauses or unpauses a workspace, stopping or resuming all associated pods.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -1781,35 +2151,745 @@ const docTemplate = `{
                 "parameters": [
                     {
                         "type": "string",
-                        "x-example": "default",
-                        "description": "Namespace of the workspace",
+                        "x-example": "default",
+                        "description": "Namespace of the workspace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-workspace",
+                        "description": "Name of the workspace",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Intended pause state of the workspace",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceActionPauseEnvelope"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful action. Returns the current pause state.",
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceActionPauseEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to access the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict. Workspace not in valid state for action.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large. The request body is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type. Content-Type header is not correct.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{namespace}/{name}/actions/snapshot": {
+            "post": {
+                "description": "Takes a CSI VolumeSnapshot of the home and data volumes of a workspace. The snapshot can later be cloned into a new workspace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Snapshot a workspace",
+                "operationId": "snapshotWorkspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "default",
+                        "description": "Namespace of the workspace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-workspace",
+                        "description": "Name of the workspace",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workspace snapshot configuration",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceSnapshotCreateEnvelope"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Workspace snapshot created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceSnapshotEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request. Workspace has no volumes to snapshot.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to snapshot the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict. Workspace snapshot with the same name already exists.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large. The request body is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type. Content-Type header is not correct.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{namespace}/{name}/metrics": {
+            "get": {
+                "description": "Returns the current and last 24 hours of CPU, memory and GPU usage of a workspace (from a Prometheus-compatible API), the usage of its volumes (from the kubelet volume metrics in the same API), and the resources requested by its pod config. Metrics which are not available are reported as warnings.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Get workspace resource usage",
+                "operationId": "getWorkspaceMetrics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "default",
+                        "description": "Namespace of the workspace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-workspace",
+                        "description": "Name of the workspace",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation. Returns the resource usage of the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceMetricsEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to access the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{namespace}/{name}/shares": {
+            "get": {
+                "description": "Returns the users a workspace is shared with, and their access level.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "List workspace shares",
+                "operationId": "listWorkspaceShares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "default",
+                        "description": "Namespace of the workspace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-workspace",
+                        "description": "Name of the workspace",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation. Returns the shares of the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceShareListEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to access the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Shares a workspace with a user, in read-only or edit mode. The user is granted access to this workspace only, not to the rest of the namespace.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Share a workspace",
+                "operationId": "createWorkspaceShare",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "default",
+                        "description": "Namespace of the workspace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-workspace",
+                        "description": "Name of the workspace",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workspace share configuration",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceShareCreateEnvelope"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Workspace share created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceShareEnvelope"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to share the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict. Workspace is already shared with the user.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large. The request body is too large.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type. Content-Type header is not correct.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{namespace}/{name}/shares/{share}": {
+            "delete": {
+                "description": "Stops sharing a workspace with a user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Delete a workspace share",
+                "operationId": "deleteWorkspaceShare",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "default",
+                        "description": "Namespace of the workspace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-workspace",
+                        "description": "Name of the workspace",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-workspace-share-0123456789",
+                        "description": "Name of the workspace share",
+                        "name": "share",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to share the workspace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace share does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspacesnapshots/{namespace}": {
+            "get": {
+                "description": "Returns a list of workspace snapshots in a specific namespace.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspacesnapshots"
+                ],
+                "summary": "List workspace snapshots by namespace",
+                "operationId": "listWorkspaceSnapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "my-namespace",
+                        "description": "Namespace name",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation. Returns a list of workspace snapshots in the specified namespace.",
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceSnapshotListEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to list workspace snapshots.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspacesnapshots/{namespace}/{name}": {
+            "get": {
+                "description": "Returns details of a specific workspace snapshot identified by namespace and name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspacesnapshots"
+                ],
+                "summary": "Get workspace snapshot",
+                "operationId": "getWorkspaceSnapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "my-namespace",
+                        "description": "Namespace name",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-snapshot",
+                        "description": "Workspace snapshot name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful operation. Returns the requested workspace snapshot details.",
+                        "schema": {
+                            "$ref": "#/definitions/api.WorkspaceSnapshotEnvelope"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to access the workspace snapshot.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace snapshot does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the VolumeSnapshots of a workspace snapshot. Workspaces cloned from the snapshot are not affected.",
+                "tags": [
+                    "workspacesnapshots"
+                ],
+                "summary": "Delete workspace snapshot",
+                "operationId": "deleteWorkspaceSnapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "my-namespace",
+                        "description": "Namespace name",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "x-example": "my-snapshot",
+                        "description": "Workspace snapshot name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized. Authentication is required.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden. User does not have permission to delete the workspace snapshot.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found. Workspace snapshot does not exist.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity. Validation error.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error. An unexpected error occurred on the server.",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorEnvelope"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspacesnapshots/{namespace}/{name}/actions/clone": {
+            "post": {
+                "description": "Restores a PVC from each volume of a workspace snapshot, and creates a new workspace with the same kind and pod template options as the snapshotted workspace, mounting the restored PVCs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspacesnapshots"
+                ],
+                "summary": "Clone a workspace snapshot",
+                "operationId": "cloneWorkspaceSnapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "x-example": "my-namespace",
+                        "description": "Namespace name",
                         "name": "namespace",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "x-example": "my-workspace",
-                        "description": "Name of the workspace",
+                        "x-example": "my-snapshot",
+                        "description": "Workspace snapshot name",
                         "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Intended pause state of the workspace",
+                        "description": "Workspace clone configuration",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.WorkspaceActionPauseEnvelope"
+                            "$ref": "#/definitions/api.WorkspaceSnapshotCloneEnvelope"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successful action. Returns the current pause state.",
+                    "201": {
+                        "description": "Workspace created successfully",
                         "schema": {
-                            "$ref": "#/definitions/api.WorkspaceActionPauseEnvelope"
+                            "$ref": "#/definitions/api.WorkspaceCreateEnvelope"
                         }
                     },
                     "400": {
@@ -1825,19 +2905,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden. User does not have permission to access the workspace.",
+                        "description": "Forbidden. User does not have permission to clone the workspace snapshot.",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorEnvelope"
                         }
                     },
                     "404": {
-                        "description": "Not Found. Workspace does not exist.",
+                        "description": "Not Found. Workspace snapshot does not exist.",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorEnvelope"
                         }
                     },
                     "409": {
-                        "description": "Conflict. Workspace not in valid state for action.",
+                        "description": "Conflict. Workspace snapshot is not ready, or a workspace or PVC with the same name already exists.",
                         "schema": {
                             "$ref": "#/definitions/api.ErrorEnvelope"
                         }
@@ -2004,6 +3084,20 @@ const docTemplate = `{
                 }
             }
         },
+        "api.PendingUpgradeListEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/upgradecampaigns.PendingUpgrade"
+                    }
+                }
+            }
+        },
         "api.PodTemplateOptionsEnvelope": {
             "type": "object",
             "required": [
@@ -2044,11 +3138,196 @@ const docTemplate = `{
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/secrets.SecretUpdate"
+                    "$ref": "#/definitions/secrets.SecretUpdate"
+                }
+            }
+        },
+        "api.SecretListEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/secrets.SecretListItem"
+                    }
+                }
+            }
+        },
+        "api.StorageClassListEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storageclasses.StorageClassListItem"
+                    }
+                }
+            }
+        },
+        "api.UpgradeCampaignCreateEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/upgradecampaigns.UpgradeCampaignCreate"
+                }
+            }
+        },
+        "api.UpgradeCampaignEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/upgradecampaigns.UpgradeCampaign"
+                }
+            }
+        },
+        "api.UpgradeCampaignListEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/upgradecampaigns.UpgradeCampaign"
+                    }
+                }
+            }
+        },
+        "api.UpgradeDeferralEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/upgradecampaigns.UpgradeDeferral"
+                }
+            }
+        },
+        "api.UserEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/api.UserResponse"
+                }
+            }
+        },
+        "api.UserResponse": {
+            "type": "object",
+            "required": [
+                "clusterAdmin",
+                "userId"
+            ],
+            "properties": {
+                "clusterAdmin": {
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ValidationError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "The field of the resource that has caused this error, as named by its JSON serialization.\nMay include dot and postfix notation for nested attributes.\nArrays are zero-indexed.\nFields may appear more than once in an array of causes due to fields having multiple errors.\n\nExamples:\n  \"name\" - the field \"name\" on the current resource\n  \"items[0].name\" - the field \"name\" on the first array entry in \"items\"",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "A human-readable description of the cause of the error.\nThis field may be presented as-is to a reader.",
+                    "type": "string"
+                },
+                "origin": {
+                    "description": "Origin indicates where the validation error originated.\nIf value is empty, the origin is unknown.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/api.ErrorCauseOrigin"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "A machine-readable description of the cause of the error.\nIf value is empty, there is no information available.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/field.ErrorType"
+                        }
+                    ]
+                }
+            }
+        },
+        "api.WorkspaceActionPauseEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/actions.WorkspaceActionPause"
+                }
+            }
+        },
+        "api.WorkspaceCreateEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/workspaces.WorkspaceCreate"
+                }
+            }
+        },
+        "api.WorkspaceEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/workspaces.WorkspaceUpdate"
+                }
+            }
+        },
+        "api.WorkspaceKindCreateEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/workspacekinds.WorkspaceKindCreate"
+                }
+            }
+        },
+        "api.WorkspaceKindEnvelope": {
+            "type": "object",
+            "required": [
+                "data"
+            ],
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/workspacekinds.WorkspaceKindUpdate"
                 }
             }
         },
-        "api.SecretListEnvelope": {
+        "api.WorkspaceKindListEnvelope": {
             "type": "object",
             "required": [
                 "data"
@@ -2057,12 +3336,12 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/secrets.SecretListItem"
+                        "$ref": "#/definitions/workspacekinds.WorkspaceKindListItem"
                     }
                 }
             }
         },
-        "api.StorageClassListEnvelope": {
+        "api.WorkspaceListEnvelope": {
             "type": "object",
             "required": [
                 "data"
@@ -2071,136 +3350,92 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/storageclasses.StorageClassListItem"
+                        "$ref": "#/definitions/workspaces.WorkspaceListItem"
                     }
                 }
             }
         },
-        "api.UserEnvelope": {
+        "api.WorkspaceMetricsEnvelope": {
             "type": "object",
             "required": [
                 "data"
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/api.UserResponse"
-                }
-            }
-        },
-        "api.UserResponse": {
-            "type": "object",
-            "required": [
-                "clusterAdmin",
-                "userId"
-            ],
-            "properties": {
-                "clusterAdmin": {
-                    "type": "boolean"
-                },
-                "userId": {
-                    "type": "string"
-                }
-            }
-        },
-        "api.ValidationError": {
-            "type": "object",
-            "properties": {
-                "field": {
-                    "description": "The field of the resource that has caused this error, as named by its JSON serialization.\nMay include dot and postfix notation for nested attributes.\nArrays are zero-indexed.\nFields may appear more than once in an array of causes due to fields having multiple errors.\n\nExamples:\n  \"name\" - the field \"name\" on the current resource\n  \"items[0].name\" - the field \"name\" on the first array entry in \"items\"",
-                    "type": "string"
-                },
-                "message": {
-                    "description": "A human-readable description of the cause of the error.\nThis field may be presented as-is to a reader.",
-                    "type": "string"
-                },
-                "origin": {
-                    "description": "Origin indicates where the validation error originated.\nIf value is empty, the origin is unknown.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/api.ErrorCauseOrigin"
-                        }
-                    ]
-                },
-                "type": {
-                    "description": "A machine-readable description of the cause of the error.\nIf value is empty, there is no information available.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/field.ErrorType"
-                        }
-                    ]
+                    "$ref": "#/definitions/workspacemetrics.WorkspaceMetrics"
                 }
             }
         },
-        "api.WorkspaceActionPauseEnvelope": {
+        "api.WorkspaceShareCreateEnvelope": {
             "type": "object",
             "required": [
                 "data"
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/actions.WorkspaceActionPause"
+                    "$ref": "#/definitions/workspaceshares.WorkspaceShareCreate"
                 }
             }
         },
-        "api.WorkspaceCreateEnvelope": {
+        "api.WorkspaceShareEnvelope": {
             "type": "object",
             "required": [
                 "data"
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/workspaces.WorkspaceCreate"
+                    "$ref": "#/definitions/workspaceshares.WorkspaceShare"
                 }
             }
         },
-        "api.WorkspaceEnvelope": {
+        "api.WorkspaceShareListEnvelope": {
             "type": "object",
             "required": [
                 "data"
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/workspaces.WorkspaceUpdate"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workspaceshares.WorkspaceShare"
+                    }
                 }
             }
         },
-        "api.WorkspaceKindCreateEnvelope": {
+        "api.WorkspaceSnapshotCloneEnvelope": {
             "type": "object",
             "required": [
                 "data"
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/workspacekinds.WorkspaceKindCreate"
+                    "$ref": "#/definitions/workspacesnapshots.WorkspaceSnapshotClone"
                 }
             }
         },
-        "api.WorkspaceKindEnvelope": {
+        "api.WorkspaceSnapshotCreateEnvelope": {
             "type": "object",
             "required": [
                 "data"
             ],
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/workspacekinds.WorkspaceKindUpdate"
+                    "$ref": "#/definitions/workspacesnapshots.WorkspaceSnapshotCreate"
                 }
             }
         },
-        "api.WorkspaceKindListEnvelope": {
+        "api.WorkspaceSnapshotEnvelope": {
             "type": "object",
             "required": [
                 "data"
             ],
             "properties": {
                 "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/workspacekinds.WorkspaceKindListItem"
-                    }
+                    "$ref": "#/definitions/workspacesnapshots.WorkspaceSnapshot"
                 }
             }
         },
-        "api.WorkspaceListEnvelope": {
+        "api.WorkspaceSnapshotListEnvelope": {
             "type": "object",
             "required": [
                 "data"
@@ -2209,7 +3444,7 @@ const docTemplate = `{
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/workspaces.WorkspaceListItem"
+                        "$ref": "#/definitions/workspacesnapshots.WorkspaceSnapshot"
                     }
                 }
             }
@@ -3019,34 +4254,298 @@ const docTemplate = `{
                 }
             }
         },
-        "secrets.SecretValue": {
+        "secrets.SecretValue": {
+            "type": "object",
+            "properties": {
+                "base64": {
+                    "type": "string"
+                }
+            }
+        },
+        "storageclasses.StorageClassListItem": {
+            "type": "object",
+            "required": [
+                "canUse",
+                "description",
+                "displayName",
+                "name"
+            ],
+            "properties": {
+                "canUse": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "upgradecampaigns.OptionUpgrade": {
+            "type": "object",
+            "required": [
+                "current",
+                "desired",
+                "redirectChain"
+            ],
+            "properties": {
+                "current": {
+                    "type": "string"
+                },
+                "desired": {
+                    "type": "string"
+                },
+                "redirectChain": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/upgradecampaigns.RedirectStep"
+                    }
+                }
+            }
+        },
+        "upgradecampaigns.PendingUpgrade": {
+            "type": "object",
+            "required": [
+                "imageConfig",
+                "lastActivity",
+                "name",
+                "namespace",
+                "paused",
+                "podConfig"
+            ],
+            "properties": {
+                "imageConfig": {
+                    "$ref": "#/definitions/upgradecampaigns.OptionUpgrade"
+                },
+                "lastActivity": {
+                    "description": "LastActivity is the last time activity was observed on the Workspace (UNIX epoch),\nit is 0 if the activity is unknown, in which case a running Workspace is never upgraded.",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "podConfig": {
+                    "$ref": "#/definitions/upgradecampaigns.OptionUpgrade"
+                }
+            }
+        },
+        "upgradecampaigns.RedirectStep": {
+            "type": "object",
+            "required": [
+                "source",
+                "target"
+            ],
+            "properties": {
+                "source": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "upgradecampaigns.UpgradeCampaign": {
+            "type": "object",
+            "required": [
+                "audit",
+                "batchSize",
+                "deferralSeconds",
+                "minIdleSeconds",
+                "name",
+                "paused",
+                "phase",
+                "summary",
+                "timeoutSeconds",
+                "workspaceKind",
+                "workspaces"
+            ],
+            "properties": {
+                "audit": {
+                    "$ref": "#/definitions/common.Audit"
+                },
+                "batchSize": {
+                    "type": "integer"
+                },
+                "deferralSeconds": {
+                    "type": "integer"
+                },
+                "minIdleSeconds": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "phase": {
+                    "$ref": "#/definitions/upgradecampaigns.UpgradeCampaignPhase"
+                },
+                "summary": {
+                    "$ref": "#/definitions/upgradecampaigns.UpgradeSummary"
+                },
+                "timeoutSeconds": {
+                    "type": "integer"
+                },
+                "workspaceKind": {
+                    "type": "string"
+                },
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/upgradecampaigns.WorkspaceUpgrade"
+                    }
+                }
+            }
+        },
+        "upgradecampaigns.UpgradeCampaignCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "paused",
+                "workspaceKind"
+            ],
+            "properties": {
+                "batchSize": {
+                    "type": "integer"
+                },
+                "deferralSeconds": {
+                    "type": "integer"
+                },
+                "minIdleSeconds": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "timeoutSeconds": {
+                    "type": "integer"
+                },
+                "workspaceKind": {
+                    "type": "string"
+                }
+            }
+        },
+        "upgradecampaigns.UpgradeCampaignPhase": {
+            "type": "string",
+            "enum": [
+                "Running",
+                "Paused",
+                "Completed"
+            ],
+            "x-enum-varnames": [
+                "UpgradeCampaignPhaseRunning",
+                "UpgradeCampaignPhasePaused",
+                "UpgradeCampaignPhaseCompleted"
+            ]
+        },
+        "upgradecampaigns.UpgradeDeferral": {
+            "type": "object",
+            "required": [
+                "campaign",
+                "deferralSeconds"
+            ],
+            "properties": {
+                "campaign": {
+                    "description": "Campaign is the name of the campaign whose upgrade was deferred",
+                    "type": "string"
+                },
+                "deferralSeconds": {
+                    "description": "DeferralSeconds is how long the upgrade is postponed, from when the controller observes the deferral.",
+                    "type": "integer"
+                }
+            }
+        },
+        "upgradecampaigns.UpgradeState": {
+            "type": "string",
+            "enum": [
+                "Pending",
+                "Deferred",
+                "Upgrading",
+                "Succeeded",
+                "Failed"
+            ],
+            "x-enum-varnames": [
+                "UpgradeStatePending",
+                "UpgradeStateDeferred",
+                "UpgradeStateUpgrading",
+                "UpgradeStateSucceeded",
+                "UpgradeStateFailed"
+            ]
+        },
+        "upgradecampaigns.UpgradeSummary": {
             "type": "object",
+            "required": [
+                "deferred",
+                "failed",
+                "pending",
+                "succeeded",
+                "upgrading"
+            ],
             "properties": {
-                "base64": {
-                    "type": "string"
+                "deferred": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "upgrading": {
+                    "type": "integer"
                 }
             }
         },
-        "storageclasses.StorageClassListItem": {
+        "upgradecampaigns.WorkspaceUpgrade": {
             "type": "object",
             "required": [
-                "canUse",
-                "description",
-                "displayName",
-                "name"
+                "deferred",
+                "message",
+                "name",
+                "namespace",
+                "state"
             ],
             "properties": {
-                "canUse": {
+                "deferred": {
+                    "description": "Deferred is true if the user has deferred the upgrade, each Workspace can only be deferred once per campaign.",
                     "type": "boolean"
                 },
-                "description": {
-                    "type": "string"
+                "deferredUntil": {
+                    "description": "DeferredUntil is the time until which the upgrade is deferred (UNIX epoch), it is only set while deferred.",
+                    "type": "integer"
                 },
-                "displayName": {
+                "message": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/upgradecampaigns.UpgradeState"
+                },
+                "upgradeTime": {
+                    "description": "UpgradeTime is the time when the Workspace was restarted (UNIX epoch).",
+                    "type": "integer"
                 }
             }
         },
@@ -6974,6 +8473,148 @@ const docTemplate = `{
                 }
             }
         },
+        "workspacemetrics.ResourceAmounts": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "description": "CPU is the number of CPU cores",
+                    "type": "number"
+                },
+                "gpu": {
+                    "description": "GPU is the number of GPUs (of any vendor, e.g. \"nvidia.com/gpu\")",
+                    "type": "integer"
+                },
+                "memoryBytes": {
+                    "description": "MemoryBytes is the amount of memory in bytes",
+                    "type": "integer"
+                }
+            }
+        },
+        "workspacemetrics.ResourceUsage": {
+            "type": "object",
+            "required": [
+                "timestamp"
+            ],
+            "properties": {
+                "cpu": {
+                    "description": "CPU is the number of CPU cores used",
+                    "type": "number"
+                },
+                "gpuUtilization": {
+                    "description": "GPUUtilization is the average utilization of the GPUs of the Workspace (0-100)",
+                    "type": "number"
+                },
+                "memoryBytes": {
+                    "description": "MemoryBytes is the working set memory in bytes",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the time of the sample (UNIX epoch)",
+                    "type": "integer"
+                }
+            }
+        },
+        "workspacemetrics.VolumeType": {
+            "type": "string",
+            "enum": [
+                "home",
+                "data"
+            ],
+            "x-enum-varnames": [
+                "VolumeTypeHome",
+                "VolumeTypeData"
+            ]
+        },
+        "workspacemetrics.VolumeUsage": {
+            "type": "object",
+            "required": [
+                "pvcName",
+                "type"
+            ],
+            "properties": {
+                "availableBytes": {
+                    "type": "integer"
+                },
+                "capacityBytes": {
+                    "type": "integer"
+                },
+                "mountPath": {
+                    "description": "MountPath is only set for data volumes, the mount path of the home volume is defined by the WorkspaceKind.",
+                    "type": "string"
+                },
+                "pvcName": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/workspacemetrics.VolumeType"
+                },
+                "usedBytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "workspacemetrics.WorkspaceMetrics": {
+            "type": "object",
+            "required": [
+                "current",
+                "history",
+                "historyStepSeconds",
+                "limits",
+                "requests",
+                "volumes",
+                "warnings"
+            ],
+            "properties": {
+                "current": {
+                    "description": "Current is the resource usage at the time of the request, values are unset if the Workspace is not running.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/workspacemetrics.ResourceUsage"
+                        }
+                    ]
+                },
+                "history": {
+                    "description": "History is the resource usage over the last 24 hours, one sample per HistoryStepSeconds.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workspacemetrics.ResourceUsage"
+                    }
+                },
+                "historyStepSeconds": {
+                    "type": "integer"
+                },
+                "limits": {
+                    "description": "Limits are the resource limits of the pod config of the Workspace.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/workspacemetrics.ResourceAmounts"
+                        }
+                    ]
+                },
+                "requests": {
+                    "description": "Requests are the resources requested by the pod config of the Workspace.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/workspacemetrics.ResourceAmounts"
+                        }
+                    ]
+                },
+                "volumes": {
+                    "description": "Volumes is the usage of the home and data volumes of the Workspace,\nusage is only reported by the kubelet while the Workspace is running.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workspacemetrics.VolumeUsage"
+                    }
+                },
+                "warnings": {
+                    "description": "Warnings describe metrics which could not be retrieved (e.g. the metrics source is unavailable).",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "workspaces.Activity": {
             "type": "object",
             "required": [
@@ -7529,6 +9170,178 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "workspaceshares.WorkspaceAccess": {
+            "type": "string",
+            "enum": [
+                "read",
+                "edit"
+            ],
+            "x-enum-varnames": [
+                "WorkspaceAccessRead",
+                "WorkspaceAccessEdit"
+            ]
+        },
+        "workspaceshares.WorkspaceShare": {
+            "type": "object",
+            "required": [
+                "access",
+                "audit",
+                "name",
+                "user"
+            ],
+            "properties": {
+                "access": {
+                    "$ref": "#/definitions/workspaceshares.WorkspaceAccess"
+                },
+                "audit": {
+                    "$ref": "#/definitions/common.Audit"
+                },
+                "name": {
+                    "description": "Name is the name of the RoleBinding of the share",
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspaceshares.WorkspaceShareCreate": {
+            "type": "object",
+            "required": [
+                "access",
+                "user"
+            ],
+            "properties": {
+                "access": {
+                    "$ref": "#/definitions/workspaceshares.WorkspaceAccess"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspacesnapshots.VolumeSnapshotInfo": {
+            "type": "object",
+            "required": [
+                "name",
+                "readyToUse",
+                "sourcePVCName",
+                "type"
+            ],
+            "properties": {
+                "error": {
+                    "description": "Error is the last error reported by the snapshot controller, if any.",
+                    "type": "string"
+                },
+                "mountPath": {
+                    "description": "MountPath is only set for data volumes, the mount path of the home volume is defined by the WorkspaceKind.",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "readyToUse": {
+                    "type": "boolean"
+                },
+                "restoreSize": {
+                    "description": "RestoreSize is empty until the snapshot has been taken by the CSI driver.",
+                    "type": "string"
+                },
+                "sourcePVCName": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/workspacesnapshots.VolumeType"
+                }
+            }
+        },
+        "workspacesnapshots.VolumeType": {
+            "type": "string",
+            "enum": [
+                "home",
+                "data"
+            ],
+            "x-enum-varnames": [
+                "VolumeTypeHome",
+                "VolumeTypeData"
+            ]
+        },
+        "workspacesnapshots.WorkspaceInfo": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "workspacesnapshots.WorkspaceSnapshot": {
+            "type": "object",
+            "required": [
+                "audit",
+                "name",
+                "readyToUse",
+                "volumes",
+                "workspace"
+            ],
+            "properties": {
+                "audit": {
+                    "$ref": "#/definitions/common.Audit"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "readyToUse": {
+                    "description": "ReadyToUse is true once all VolumeSnapshots of the snapshot are ready,\na Workspace can only be cloned from a snapshot which is ready to use.",
+                    "type": "boolean"
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/workspacesnapshots.VolumeSnapshotInfo"
+                    }
+                },
+                "workspace": {
+                    "$ref": "#/definitions/workspacesnapshots.WorkspaceInfo"
+                }
+            }
+        },
+        "workspacesnapshots.WorkspaceSnapshotClone": {
+            "type": "object",
+            "required": [
+                "name",
+                "paused"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "workspacesnapshots.WorkspaceSnapshotCreate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "volumeSnapshotClassName": {
+                    "description": "VolumeSnapshotClassName is the VolumeSnapshotClass used for all volumes of the snapshot.\nIf unset, the default VolumeSnapshotClass of each CSI driver is used.",
+                    "type": "string"
+                }
+            }
         }
     }
 }`
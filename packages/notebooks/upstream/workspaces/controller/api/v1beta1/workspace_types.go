@@ -46,8 +46,64 @@ type WorkspaceSpec struct {
 
 	// options for "podTemplate"-type WorkspaceKinds
 	PodTemplate WorkspacePodTemplate `json:"podTemplate"`
+
+	// the schedule on which the workspace is automatically started and paused
+	// +kubebuilder:validation:Optional
+	Schedule *WorkspaceSchedule `json:"schedule,omitempty"`
+}
+
+type WorkspaceSchedule struct {
+	// the IANA time zone of the schedule windows
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=256
+	// +kubebuilder:default="UTC"
+	// +kubebuilder:example="Europe/London"
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// the windows in which the workspace should be running
+	//  - the workspace is started at the start of each window, and paused at its end
+	//  - overlapping windows are merged
+	//  - manual changes to `spec.paused` are respected until the next start or end of a window
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=32
+	Windows []WorkspaceScheduleWindow `json:"windows"`
 }
 
+// +kubebuilder:validation:XValidation:rule="self.startTime != self.pauseTime",message="'startTime' and 'pauseTime' must be different"
+type WorkspaceScheduleWindow struct {
+	// the days of the week on which the window starts
+	// +kubebuilder:validation:MinItems:=1
+	// +kubebuilder:validation:MaxItems:=7
+	// +listType:="set"
+	// +kubebuilder:example={"Monday","Tuesday","Wednesday","Thursday","Friday"}
+	Days []WorkspaceScheduleDay `json:"days"`
+
+	// the time of day at which the workspace is started (24-hour "HH:MM")
+	// +kubebuilder:validation:Pattern:=^([01][0-9]|2[0-3]):[0-5][0-9]$
+	// +kubebuilder:example="08:00"
+	StartTime string `json:"startTime"`
+
+	// the time of day at which the workspace is paused (24-hour "HH:MM")
+	//  - if this is before `startTime`, the window ends on the next day
+	// +kubebuilder:validation:Pattern:=^([01][0-9]|2[0-3]):[0-5][0-9]$
+	// +kubebuilder:example="19:00"
+	PauseTime string `json:"pauseTime"`
+}
+
+// +kubebuilder:validation:Enum:={"Monday","Tuesday","Wednesday","Thursday","Friday","Saturday","Sunday"}
+type WorkspaceScheduleDay string
+
+const (
+	WorkspaceScheduleDayMonday    WorkspaceScheduleDay = "Monday"
+	WorkspaceScheduleDayTuesday   WorkspaceScheduleDay = "Tuesday"
+	WorkspaceScheduleDayWednesday WorkspaceScheduleDay = "Wednesday"
+	WorkspaceScheduleDayThursday  WorkspaceScheduleDay = "Thursday"
+	WorkspaceScheduleDayFriday    WorkspaceScheduleDay = "Friday"
+	WorkspaceScheduleDaySaturday  WorkspaceScheduleDay = "Saturday"
+	WorkspaceScheduleDaySunday    WorkspaceScheduleDay = "Sunday"
+)
+
 type WorkspacePodTemplate struct {
 	// metadata to be applied to the Pod resource
 	// +kubebuilder:validation:Optional
@@ -199,6 +255,10 @@ type WorkspaceStatus struct {
 	// information about the Pod managed by this Workspace (only set for WorkspaceKind of podTemplate kind)
 	PodTemplatePod WorkspacePodStatus `json:"podTemplatePod"`
 
+	// information about the schedule of the Workspace (only set if `spec.schedule` is set)
+	// +kubebuilder:validation:Optional
+	Schedule *WorkspaceScheduleStatus `json:"schedule,omitempty"`
+
 	// the current state of the Workspace
 	// +kubebuilder:default="Unknown"
 	State WorkspaceState `json:"state"`
@@ -221,6 +281,32 @@ type WorkspaceActivity struct {
 	LastUpdate int64 `json:"lastUpdate"`
 }
 
+type WorkspaceScheduleStatus struct {
+	// the time of the last transition applied by the schedule (UNIX epoch)
+	//  - each transition is only applied once, so manual changes to `spec.paused`
+	//    are respected until the next transition
+	// +kubebuilder:default=0
+	// +kubebuilder:example=1704067200
+	LastTransitionTime int64 `json:"lastTransitionTime"`
+
+	// the time of the next transition of the schedule (UNIX epoch)
+	// +kubebuilder:default=0
+	// +kubebuilder:example=1704096000
+	NextTransitionTime int64 `json:"nextTransitionTime"`
+
+	// the action of the next transition of the schedule
+	// +kubebuilder:validation:Optional
+	NextTransitionAction WorkspaceScheduleAction `json:"nextTransitionAction,omitempty"`
+}
+
+// +kubebuilder:validation:Enum:={"Start","Pause"}
+type WorkspaceScheduleAction string
+
+const (
+	WorkspaceScheduleActionStart WorkspaceScheduleAction = "Start"
+	WorkspaceScheduleActionPause WorkspaceScheduleAction = "Pause"
+)
+
 type WorkspacePodOptionsStatus struct {
 	// info about the current imageConfig option
 	ImageConfig WorkspacePodOptionInfo `json:"imageConfig"`
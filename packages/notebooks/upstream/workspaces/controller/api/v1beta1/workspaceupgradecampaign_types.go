@@ -0,0 +1,207 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Important: Run "make" to regenerate code after modifying this file
+
+/*
+===============================================================================
+                        WorkspaceUpgradeCampaign - Spec
+===============================================================================
+*/
+
+// WorkspaceUpgradeCampaignSpec defines the desired state of WorkspaceUpgradeCampaign
+type WorkspaceUpgradeCampaignSpec struct {
+
+	// the WorkspaceKind whose Workspaces are upgraded
+	//  - a Workspace is upgraded by replacing its `spec.podTemplate.options` with the
+	//    desired options from `status.podTemplateOptions` (after following all redirects)
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:MaxLength:=253
+	// +kubebuilder:validation:Pattern:=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="WorkspaceUpgradeCampaign 'workspaceKind' is immutable"
+	// +kubebuilder:example="jupyterlab"
+	WorkspaceKind string `json:"workspaceKind"`
+
+	// if the campaign is paused (no new Workspaces are upgraded)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Paused *bool `json:"paused,omitempty"`
+
+	// the maximum number of Workspaces which are upgrading at the same time
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default=5
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	// how long a running Workspace must have been idle before it is restarted
+	//  - paused Workspaces are always upgraded, as they don't need to be restarted
+	//  - running Workspaces without activity information (culling is disabled) are never upgraded
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default=1800
+	MinIdleSeconds int32 `json:"minIdleSeconds,omitempty"`
+
+	// how long the upgrade of a Workspace is postponed when its user defers it
+	//  - each Workspace can only be deferred once per campaign
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:default=86400
+	DeferralSeconds int32 `json:"deferralSeconds,omitempty"`
+
+	// how long a Workspace may take to become running after it is restarted, before the upgrade is considered failed
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default=900
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+/*
+===============================================================================
+                       WorkspaceUpgradeCampaign - Status
+===============================================================================
+*/
+
+// WorkspaceUpgradeCampaignStatus defines the observed state of WorkspaceUpgradeCampaign
+type WorkspaceUpgradeCampaignStatus struct {
+	// the current phase of the campaign
+	// +kubebuilder:default="Running"
+	Phase WorkspaceUpgradeCampaignPhase `json:"phase"`
+
+	// the number of Workspaces in each upgrade state
+	Summary WorkspaceUpgradeSummary `json:"summary"`
+
+	// the upgrade status of each Workspace which was affected by a redirect
+	// +kubebuilder:validation:Optional
+	// +listType:="map"
+	// +listMapKey:="namespace"
+	// +listMapKey:="name"
+	Workspaces []WorkspaceUpgradeStatus `json:"workspaces,omitempty"`
+}
+
+// +kubebuilder:validation:Enum:={"Running","Paused","Completed"}
+type WorkspaceUpgradeCampaignPhase string
+
+const (
+	WorkspaceUpgradeCampaignPhaseRunning   WorkspaceUpgradeCampaignPhase = "Running"
+	WorkspaceUpgradeCampaignPhasePaused    WorkspaceUpgradeCampaignPhase = "Paused"
+	WorkspaceUpgradeCampaignPhaseCompleted WorkspaceUpgradeCampaignPhase = "Completed"
+)
+
+type WorkspaceUpgradeSummary struct {
+	// +kubebuilder:default=0
+	Pending int32 `json:"pending"`
+
+	// +kubebuilder:default=0
+	Deferred int32 `json:"deferred"`
+
+	// +kubebuilder:default=0
+	Upgrading int32 `json:"upgrading"`
+
+	// +kubebuilder:default=0
+	Succeeded int32 `json:"succeeded"`
+
+	// +kubebuilder:default=0
+	Failed int32 `json:"failed"`
+}
+
+type WorkspaceUpgradeStatus struct {
+	// the namespace of the Workspace
+	Namespace string `json:"namespace"`
+
+	// the name of the Workspace
+	Name string `json:"name"`
+
+	// the upgrade state of the Workspace
+	State WorkspaceUpgradeState `json:"state"`
+
+	// a human-readable message about the upgrade state of the Workspace
+	//  - WARNING: this field is NOT FOR MACHINE USE, subject to change without notice
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// if the user of the Workspace has deferred its upgrade
+	// +kubebuilder:default=false
+	Deferred bool `json:"deferred"`
+
+	// the time until which the upgrade is deferred (UNIX epoch)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:example=1704067200
+	DeferredUntil int64 `json:"deferredUntil,omitempty"`
+
+	// the time when the Workspace was restarted (UNIX epoch)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:example=1704067200
+	UpgradeTime int64 `json:"upgradeTime,omitempty"`
+}
+
+// +kubebuilder:validation:Enum:={"Pending","Deferred","Upgrading","Succeeded","Failed"}
+type WorkspaceUpgradeState string
+
+const (
+	WorkspaceUpgradeStatePending   WorkspaceUpgradeState = "Pending"
+	WorkspaceUpgradeStateDeferred  WorkspaceUpgradeState = "Deferred"
+	WorkspaceUpgradeStateUpgrading WorkspaceUpgradeState = "Upgrading"
+	WorkspaceUpgradeStateSucceeded WorkspaceUpgradeState = "Succeeded"
+	WorkspaceUpgradeStateFailed    WorkspaceUpgradeState = "Failed"
+)
+
+/*
+===============================================================================
+                            WorkspaceUpgradeCampaign
+===============================================================================
+*/
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="WorkspaceKind",type="string",JSONPath=".spec.workspaceKind",description="The WorkspaceKind whose Workspaces are upgraded"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The current phase of the campaign"
+// +kubebuilder:printcolumn:name="Succeeded",type="integer",JSONPath=".status.summary.succeeded",description="The number of Workspaces which were upgraded"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.summary.failed",description="The number of Workspaces which failed to upgrade"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=wsuc
+
+// WorkspaceUpgradeCampaign is the Schema for the WorkspaceUpgradeCampaigns API
+type WorkspaceUpgradeCampaign struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkspaceUpgradeCampaignSpec   `json:"spec,omitempty"`
+	Status WorkspaceUpgradeCampaignStatus `json:"status,omitempty"`
+}
+
+/*
+===============================================================================
+                          WorkspaceUpgradeCampaignList
+===============================================================================
+*/
+
+// +kubebuilder:object:root=true
+
+// WorkspaceUpgradeCampaignList contains a list of WorkspaceUpgradeCampaign
+type WorkspaceUpgradeCampaignList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspaceUpgradeCampaign `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkspaceUpgradeCampaign{}, &WorkspaceUpgradeCampaignList{})
+}
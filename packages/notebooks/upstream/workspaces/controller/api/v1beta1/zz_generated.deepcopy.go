@@ -1188,6 +1188,63 @@ func (in *WorkspacePodVolumes) DeepCopy() *WorkspacePodVolumes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceSchedule) DeepCopyInto(out *WorkspaceSchedule) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]WorkspaceScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceSchedule.
+func (in *WorkspaceSchedule) DeepCopy() *WorkspaceSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceScheduleStatus) DeepCopyInto(out *WorkspaceScheduleStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceScheduleStatus.
+func (in *WorkspaceScheduleStatus) DeepCopy() *WorkspaceScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceScheduleWindow) DeepCopyInto(out *WorkspaceScheduleWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]WorkspaceScheduleDay, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceScheduleWindow.
+func (in *WorkspaceScheduleWindow) DeepCopy() *WorkspaceScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceSpec) DeepCopyInto(out *WorkspaceSpec) {
 	*out = *in
@@ -1197,6 +1254,11 @@ func (in *WorkspaceSpec) DeepCopyInto(out *WorkspaceSpec) {
 		**out = **in
 	}
 	in.PodTemplate.DeepCopyInto(&out.PodTemplate)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(WorkspaceSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceSpec.
@@ -1215,6 +1277,11 @@ func (in *WorkspaceStatus) DeepCopyInto(out *WorkspaceStatus) {
 	out.Activity = in.Activity
 	in.PodTemplateOptions.DeepCopyInto(&out.PodTemplateOptions)
 	in.PodTemplatePod.DeepCopyInto(&out.PodTemplatePod)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(WorkspaceScheduleStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceStatus.
@@ -1226,3 +1293,133 @@ func (in *WorkspaceStatus) DeepCopy() *WorkspaceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceUpgradeCampaign) DeepCopyInto(out *WorkspaceUpgradeCampaign) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceUpgradeCampaign.
+func (in *WorkspaceUpgradeCampaign) DeepCopy() *WorkspaceUpgradeCampaign {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceUpgradeCampaign)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceUpgradeCampaign) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceUpgradeCampaignList) DeepCopyInto(out *WorkspaceUpgradeCampaignList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspaceUpgradeCampaign, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceUpgradeCampaignList.
+func (in *WorkspaceUpgradeCampaignList) DeepCopy() *WorkspaceUpgradeCampaignList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceUpgradeCampaignList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceUpgradeCampaignList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceUpgradeCampaignSpec) DeepCopyInto(out *WorkspaceUpgradeCampaignSpec) {
+	*out = *in
+	if in.Paused != nil {
+		in, out := &in.Paused, &out.Paused
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceUpgradeCampaignSpec.
+func (in *WorkspaceUpgradeCampaignSpec) DeepCopy() *WorkspaceUpgradeCampaignSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceUpgradeCampaignSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceUpgradeCampaignStatus) DeepCopyInto(out *WorkspaceUpgradeCampaignStatus) {
+	*out = *in
+	out.Summary = in.Summary
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceUpgradeStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceUpgradeCampaignStatus.
+func (in *WorkspaceUpgradeCampaignStatus) DeepCopy() *WorkspaceUpgradeCampaignStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceUpgradeCampaignStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceUpgradeStatus) DeepCopyInto(out *WorkspaceUpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceUpgradeStatus.
+func (in *WorkspaceUpgradeStatus) DeepCopy() *WorkspaceUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceUpgradeSummary) DeepCopyInto(out *WorkspaceUpgradeSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceUpgradeSummary.
+func (in *WorkspaceUpgradeSummary) DeepCopy() *WorkspaceUpgradeSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceUpgradeSummary)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	istiov1 "istio.io/client-go/pkg/apis/networking/v1"
+	istiosecurityv1 "istio.io/client-go/pkg/apis/security/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -63,6 +64,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(istiov1.AddToScheme(scheme))
+	utilruntime.Must(istiosecurityv1.AddToScheme(scheme))
 
 	utilruntime.Must(gatewayv1.Install(scheme))
 	utilruntime.Must(gatewayv1beta1.Install(scheme))
@@ -106,6 +108,14 @@ func main() {
 		"The namespace of the Kubernetes Gateway")
 	flag.StringVar(&cfg.KubeRbacProxyImage, "kube-rbac-proxy-image", getEnvAsStr("KUBE_RBAC_PROXY_IMAGE", ""),
 		"The image to use for the kube-rbac-proxy sidecar")
+	flag.BoolVar(&cfg.EnableCulling, "enable-culling", getEnvAsBool("ENABLE_CULLING", true),
+		"If set, idle Workspaces are paused according to the culling config of their WorkspaceKind")
+	flag.DurationVar(&cfg.CullingCheckPeriod, "culling-check-period", getEnvAsDuration("CULLING_CHECK_PERIOD", time.Minute),
+		"How often the activity of each running Workspace is probed")
+	flag.StringVar(&cfg.UserIdHeader, "userid-header", getEnvAsStr("USERID_HEADER", "kubeflow-userid"),
+		"The request header which contains the user id, used to route users a Workspace is shared with")
+	flag.StringVar(&cfg.UserIdPrefix, "userid-prefix", getEnvAsStr("USERID_PREFIX", ""),
+		"The prefix of the user id in the userid-header")
 
 	// Get controller namespace (from service account file or POD_NAMESPACE env var)
 	cfg.ControllerNamespace = getControllerNamespace("kubeflow-workspaces")
@@ -126,6 +136,10 @@ func main() {
 		setupLog.Error(nil, "kube-rbac-proxy-image is required when use-kube-gateway is enabled")
 		os.Exit(1)
 	}
+	if cfg.EnableCulling && cfg.CullingCheckPeriod <= 0 {
+		setupLog.Error(nil, "culling-check-period must be positive when enable-culling is enabled")
+		os.Exit(1)
+	}
 
 	// Log configuration values for debugging
 	setupLog.Info("Configuration loaded",
@@ -137,7 +151,11 @@ func main() {
 		"ClusterDomain", cfg.ClusterDomain,
 		"IstioGateway", cfg.IstioGateway,
 		"IstioHosts", cfg.IstioHosts,
-		"KubeRbacProxyImage", sanitizeImageReference(cfg.KubeRbacProxyImage))
+		"KubeRbacProxyImage", sanitizeImageReference(cfg.KubeRbacProxyImage),
+		"EnableCulling", cfg.EnableCulling,
+		"CullingCheckPeriod", cfg.CullingCheckPeriod,
+		"UserIdHeader", cfg.UserIdHeader,
+		"UserIdPrefix", cfg.UserIdPrefix)
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -247,6 +265,44 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "WorkspaceKind")
 		os.Exit(1)
 	}
+	if cfg.EnableCulling {
+		activityProber, err := controllerInternal.NewWorkspaceActivityProber(mgr.GetClient(), mgr.GetConfig(), cfg)
+		if err != nil {
+			setupLog.Error(err, "unable to create activity prober")
+			os.Exit(1)
+		}
+		if err = (&controllerInternal.CullingReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Config: cfg,
+			Prober: activityProber,
+		}).SetupWithManager(mgr, &controller.Options{
+			RateLimiter: helper.BuildRateLimiter(),
+		}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Culling")
+			os.Exit(1)
+		}
+	}
+	if err = (&controllerInternal.ScheduleReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Config: cfg,
+	}).SetupWithManager(mgr, &controller.Options{
+		RateLimiter: helper.BuildRateLimiter(),
+	}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Schedule")
+		os.Exit(1)
+	}
+	if err = (&controllerInternal.UpgradeCampaignReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Config: cfg,
+	}).SetupWithManager(mgr, &controller.Options{
+		RateLimiter: helper.BuildRateLimiter(),
+	}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "UpgradeCampaign")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
@@ -303,6 +359,15 @@ func getEnvAsStr(name string, defaultVal string) string {
 	return defaultVal
 }
 
+func getEnvAsDuration(name string, defaultVal time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(name); exists {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+	return defaultVal
+}
+
 func getEnvAsBool(name string, defaultVal bool) bool {
 	if value, exists := os.LookupEnv(name); exists {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -16,6 +16,8 @@ limitations under the License.
 
 package config
 
+import "time"
+
 type EnvConfig struct {
 	IstioGateway         string
 	IstioHosts           string
@@ -26,4 +28,8 @@ type EnvConfig struct {
 	KubeGatewayNamespace string
 	ControllerNamespace  string
 	KubeRbacProxyImage   string
+	EnableCulling        bool
+	CullingCheckPeriod   time.Duration
+	UserIdHeader         string
+	UserIdPrefix         string
 }
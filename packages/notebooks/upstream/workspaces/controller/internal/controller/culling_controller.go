@@ -0,0 +1,216 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	"github.com/kubeflow/notebooks/workspaces/controller/internal/config"
+)
+
+const (
+	// the default of `spec.podTemplate.culling.maxInactiveSeconds` on WorkspaceKinds
+	defaultCullingMaxInactiveSeconds = int32(86400)
+)
+
+// CullingReconciler pauses Workspaces which have been idle for longer than the culling
+// threshold of their WorkspaceKind.
+//
+// Every `Config.CullingCheckPeriod`, the activity probe of the WorkspaceKind is run against
+// the running Pod of each Workspace, and `status.activity` is updated with the result.
+// A Workspace is idle since its last observed activity, or since its Pod started if that is later.
+type CullingReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Config *config.EnvConfig
+	Prober ActivityProber
+}
+
+// +kubebuilder:rbac:groups=kubeflow.org,resources=workspaces,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=kubeflow.org,resources=workspaces/status,verbs=get;patch
+// +kubebuilder:rbac:groups=kubeflow.org,resources=workspacekinds,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch
+
+func (r *CullingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("checking Workspace activity")
+
+	checkPeriod := r.Config.CullingCheckPeriod
+
+	// fetch the Workspace
+	workspace := &kubefloworgv1beta1.Workspace{}
+	if err := r.Get(ctx, req.NamespacedName, workspace); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch Workspace")
+		return ctrl.Result{}, err
+	}
+	if !workspace.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// paused Workspaces have nothing to cull
+	// NOTE: un-pausing changes the generation of the Workspace, which triggers a new reconcile
+	if ptr.Deref(workspace.Spec.Paused, false) {
+		return ctrl.Result{}, nil
+	}
+
+	// fetch the WorkspaceKind
+	// NOTE: we keep requeuing if culling is not enabled, so that changes to the WorkspaceKind are picked up
+	workspaceKind := &kubefloworgv1beta1.WorkspaceKind{}
+	if err := r.Get(ctx, client.ObjectKey{Name: workspace.Spec.Kind}, workspaceKind); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{RequeueAfter: checkPeriod}, nil
+		}
+		log.Error(err, "unable to fetch WorkspaceKind for Workspace")
+		return ctrl.Result{}, err
+	}
+	cullingConfig := workspaceKind.Spec.PodTemplate.Culling
+	if cullingConfig == nil || !ptr.Deref(cullingConfig.Enabled, true) {
+		return ctrl.Result{RequeueAfter: checkPeriod}, nil
+	}
+
+	// only probe once per check period
+	// NOTE: the Workspace is also reconciled whenever its spec changes
+	now := time.Now()
+	if wait := getNextActivityCheck(workspace.Status.Activity.LastUpdate, checkPeriod, now); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	// fetch the running Pod
+	pod, err := getRunningWorkspacePod(ctx, r, workspace)
+	if err != nil {
+		log.Error(err, "unable to list Pods")
+		return ctrl.Result{}, err
+	}
+	if pod == nil {
+		log.V(2).Info("Workspace has no running Pod, skipping activity probe")
+		return ctrl.Result{RequeueAfter: checkPeriod}, nil
+	}
+
+	// run the activity probe
+	// NOTE: a failing probe never results in the Workspace being culled
+	lastActivity, err := r.Prober.ProbeActivity(ctx, workspace, workspaceKind, pod)
+	if err != nil {
+		log.V(0).Info("activity probe failed for Workspace", "error", err.Error())
+		return ctrl.Result{RequeueAfter: checkPeriod}, nil
+	}
+
+	// update the Workspace activity status
+	patch := client.MergeFrom(workspace.DeepCopy())
+	workspace.Status.Activity.LastUpdate = now.Unix()
+	if !lastActivity.IsZero() && lastActivity.Unix() > workspace.Status.Activity.LastActivity {
+		workspace.Status.Activity.LastActivity = lastActivity.Unix()
+	}
+	if err := r.Status().Patch(ctx, workspace, patch); err != nil {
+		log.Error(err, "unable to update Workspace activity status")
+		return ctrl.Result{}, err
+	}
+
+	// pause the Workspace if it has been idle for too long
+	idle := getWorkspaceIdleDuration(workspace.Status.Activity.LastActivity, pod, now)
+	maxInactiveSeconds := ptr.Deref(cullingConfig.MaxInactiveSeconds, defaultCullingMaxInactiveSeconds)
+	if idle < time.Duration(maxInactiveSeconds)*time.Second {
+		return ctrl.Result{RequeueAfter: checkPeriod}, nil
+	}
+
+	log.V(0).Info("pausing idle Workspace", "idleSeconds", int64(idle.Seconds()), "maxInactiveSeconds", maxInactiveSeconds)
+	// NOTE: we use an optimistic lock so that we never override a concurrent change to the Workspace
+	//       (e.g. the user un-pausing it after the activity status was updated)
+	patch = client.MergeFromWithOptions(workspace.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	workspace.Spec.Paused = ptr.To(true)
+	if err := r.Patch(ctx, workspace, patch); err != nil {
+		if apierrors.IsConflict(err) {
+			log.V(2).Info("update conflict while pausing Workspace, will requeue")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "unable to pause Workspace")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CullingReconciler) SetupWithManager(mgr ctrl.Manager, opts *controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workspace-culling").
+		WithOptions(*opts).
+		For(&kubefloworgv1beta1.Workspace{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// getRunningWorkspacePod returns the running Pod of a Workspace, or nil if there is none
+func getRunningWorkspacePod(ctx context.Context, c client.Reader, workspace *kubefloworgv1beta1.Workspace) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(workspace.Namespace), client.MatchingLabels{workspaceNameLabel: workspace.Name}); err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodRunning && pod.GetDeletionTimestamp().IsZero() {
+			return pod, nil
+		}
+	}
+	return nil, nil
+}
+
+// getNextActivityCheck returns how long to wait before the next activity probe, or 0 if it is due
+func getNextActivityCheck(lastUpdate int64, checkPeriod time.Duration, now time.Time) time.Duration {
+	if lastUpdate == 0 {
+		return 0
+	}
+	next := time.Unix(lastUpdate, 0).Add(checkPeriod)
+	if next.After(now) {
+		return next.Sub(now)
+	}
+	return 0
+}
+
+// getWorkspaceIdleDuration returns how long a Workspace has been idle
+//   - a Workspace is idle since its last activity, or since its Pod started if that is later,
+//     so a Workspace that was just un-paused is not immediately culled for its old inactivity
+func getWorkspaceIdleDuration(lastActivity int64, pod *corev1.Pod, now time.Time) time.Duration {
+	idleSince := time.Unix(lastActivity, 0)
+	podStart := pod.CreationTimestamp.Time
+	if pod.Status.StartTime != nil {
+		podStart = pod.Status.StartTime.Time
+	}
+	if podStart.After(idleSince) {
+		idleSince = podStart
+	}
+	if idleSince.After(now) {
+		return 0
+	}
+	return now.Sub(idleSince)
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+)
+
+// testActivityProber is the ActivityProber used by the Culling controller in the test environment
+var testActivityProber = &fakeActivityProber{}
+
+// fakeActivityProber returns the activity set for each Workspace by the tests
+//   - Workspaces without a result fail their probe, so they are never culled
+type fakeActivityProber struct {
+	mu      sync.Mutex
+	results map[string]func() (time.Time, error)
+}
+
+func (p *fakeActivityProber) setResult(workspaceName string, result func() (time.Time, error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.results == nil {
+		p.results = make(map[string]func() (time.Time, error))
+	}
+	p.results[workspaceName] = result
+}
+
+func (p *fakeActivityProber) ProbeActivity(_ context.Context, workspace *kubefloworgv1beta1.Workspace, _ *kubefloworgv1beta1.WorkspaceKind, _ *corev1.Pod) (time.Time, error) {
+	p.mu.Lock()
+	result, ok := p.results[workspace.Name]
+	p.mu.Unlock()
+	if !ok {
+		return time.Time{}, fmt.Errorf("no activity probe result for Workspace %q", workspace.Name)
+	}
+	return result()
+}
+
+// newCullingTestPod returns a Pod which the Culling controller will treat as the Pod of the Workspace
+func newCullingTestPod(workspaceName string, namespace string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("ws-%s-pod", workspaceName),
+			Namespace: namespace,
+			Labels: map[string]string{
+				workspaceNameLabel: workspaceName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  workspacePodTemplateContainerName,
+					Image: "ghcr.io/kubeflow/kubeflow/notebook-servers/jupyter-scipy:v1.9.0",
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("Culling Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		namespaceName = "default"
+
+		// how long to wait in "Eventually" blocks
+		timeout = time.Second * 10
+
+		// how long to wait in "Consistently" blocks
+		// NOTE: this is several times the `CullingCheckPeriod` of the test environment
+		duration = time.Second * 5
+
+		// how frequently to poll for conditions
+		interval = time.Millisecond * 250
+
+		// how many seconds a Workspace may be idle before it is culled
+		maxInactiveSeconds = int32(60)
+	)
+
+	Context("When culling Workspaces", Ordered, func() {
+
+		// Define utility variables for object names.
+		// NOTE: to avoid conflicts between parallel tests, resource names are unique to each test
+		var (
+			workspaceKindName         string
+			disabledWorkspaceKindName string
+			createdObjects            []client.Object
+		)
+
+		// createRunningWorkspace creates a Workspace and a running Pod for it, which started at `podStartTime`
+		createRunningWorkspace := func(workspaceName string, workspaceKindName string, podStartTime time.Time) types.NamespacedName {
+			By("creating the Workspace")
+			workspace := NewExampleWorkspace1(workspaceName, namespaceName, workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+			createdObjects = append(createdObjects, workspace)
+
+			By("creating a running Pod for the Workspace")
+			pod := newCullingTestPod(workspaceName, namespaceName)
+			Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+			createdObjects = append(createdObjects, pod)
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.StartTime = ptr.To(metav1.NewTime(podStartTime))
+			Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+			return types.NamespacedName{Name: workspaceName, Namespace: namespaceName}
+		}
+
+		BeforeAll(func() {
+			uniqueName := "ws-culling-test"
+			workspaceKindName = fmt.Sprintf("workspacekind-%s", uniqueName)
+			disabledWorkspaceKindName = fmt.Sprintf("workspacekind-%s-disabled", uniqueName)
+
+			By("creating the WorkspaceKind")
+			workspaceKind := NewExampleWorkspaceKind1(workspaceKindName)
+			workspaceKind.Spec.PodTemplate.Culling.MaxInactiveSeconds = ptr.To(maxInactiveSeconds)
+			Expect(k8sClient.Create(ctx, workspaceKind)).To(Succeed())
+
+			By("creating the WorkspaceKind with culling disabled")
+			disabledWorkspaceKind := NewExampleWorkspaceKind1(disabledWorkspaceKindName)
+			disabledWorkspaceKind.Spec.PodTemplate.Culling.Enabled = ptr.To(false)
+			disabledWorkspaceKind.Spec.PodTemplate.Culling.MaxInactiveSeconds = ptr.To(maxInactiveSeconds)
+			Expect(k8sClient.Create(ctx, disabledWorkspaceKind)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			By("deleting the Workspaces and Pods")
+			for _, obj := range createdObjects {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+			}
+
+			By("deleting the WorkspaceKinds")
+			for _, name := range []string{workspaceKindName, disabledWorkspaceKindName} {
+				workspaceKind := &kubefloworgv1beta1.WorkspaceKind{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+				}
+				Expect(k8sClient.Delete(ctx, workspaceKind)).To(Succeed())
+			}
+		})
+
+		It("should record the activity of an active Workspace and keep it running", func() {
+			workspaceName := "workspace-ws-culling-active"
+			testActivityProber.setResult(workspaceName, func() (time.Time, error) {
+				return time.Now(), nil
+			})
+			workspaceKey := createRunningWorkspace(workspaceName, workspaceKindName, time.Now().Add(-2*time.Hour))
+
+			By("setting the Workspace `status.activity` to the current time")
+			tolerance := int64(5)
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Eventually(func() (kubefloworgv1beta1.WorkspaceActivity, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace.Status.Activity, err
+			}, timeout, interval).Should(And(
+				HaveField("LastActivity", BeNumerically("~", time.Now().Unix(), tolerance)),
+				HaveField("LastUpdate", BeNumerically("~", time.Now().Unix(), tolerance)),
+			))
+
+			By("not pausing the Workspace")
+			Consistently(func() (*bool, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace.Spec.Paused, err
+			}, duration, interval).Should(Equal(ptr.To(false)))
+		})
+
+		It("should pause a Workspace which has been idle for longer than `maxInactiveSeconds`", func() {
+			workspaceName := "workspace-ws-culling-idle"
+			lastActivity := time.Now().Add(-time.Hour)
+			testActivityProber.setResult(workspaceName, func() (time.Time, error) {
+				return lastActivity, nil
+			})
+			workspaceKey := createRunningWorkspace(workspaceName, workspaceKindName, time.Now().Add(-2*time.Hour))
+
+			By("pausing the Workspace")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Eventually(func() (*bool, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace.Spec.Paused, err
+			}, timeout, interval).Should(Equal(ptr.To(true)))
+
+			By("recording the last activity reported by the probe")
+			Expect(workspace.Status.Activity.LastActivity).To(Equal(lastActivity.Unix()))
+			Expect(workspace.Status.Activity.LastUpdate).NotTo(BeZero())
+		})
+
+		It("should not pause a Workspace whose Pod started less than `maxInactiveSeconds` ago", func() {
+			workspaceName := "workspace-ws-culling-new-pod"
+			testActivityProber.setResult(workspaceName, func() (time.Time, error) {
+				return time.Time{}, nil
+			})
+			workspaceKey := createRunningWorkspace(workspaceName, workspaceKindName, time.Now())
+
+			By("probing the Workspace")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Eventually(func() (int64, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace.Status.Activity.LastUpdate, err
+			}, timeout, interval).ShouldNot(BeZero())
+			Expect(workspace.Status.Activity.LastActivity).To(BeZero())
+
+			By("not pausing the Workspace")
+			Consistently(func() (*bool, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace.Spec.Paused, err
+			}, duration, interval).Should(Equal(ptr.To(false)))
+		})
+
+		It("should not pause a Workspace whose activity probe fails", func() {
+			workspaceName := "workspace-ws-culling-probe-error"
+			testActivityProber.setResult(workspaceName, func() (time.Time, error) {
+				return time.Time{}, fmt.Errorf("connection refused")
+			})
+			workspaceKey := createRunningWorkspace(workspaceName, workspaceKindName, time.Now().Add(-2*time.Hour))
+
+			By("not pausing the Workspace or updating its activity")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Consistently(func() (*kubefloworgv1beta1.Workspace, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace, err
+			}, duration, interval).Should(And(
+				HaveField("Spec.Paused", Equal(ptr.To(false))),
+				HaveField("Status.Activity.LastUpdate", BeZero()),
+			))
+		})
+
+		It("should not probe or pause a Workspace when culling is disabled", func() {
+			workspaceName := "workspace-ws-culling-disabled"
+			testActivityProber.setResult(workspaceName, func() (time.Time, error) {
+				return time.Now().Add(-time.Hour), nil
+			})
+			workspaceKey := createRunningWorkspace(workspaceName, disabledWorkspaceKindName, time.Now().Add(-2*time.Hour))
+
+			By("not pausing the Workspace or updating its activity")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Consistently(func() (*kubefloworgv1beta1.Workspace, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace, err
+			}, duration, interval).Should(And(
+				HaveField("Spec.Paused", Equal(ptr.To(false))),
+				HaveField("Status.Activity.LastUpdate", BeZero()),
+			))
+		})
+	})
+
+	Context("When computing Workspace idleness", func() {
+		now := time.Unix(1704067200, 0)
+
+		It("should measure idleness from the last activity", func() {
+			pod := &corev1.Pod{Status: corev1.PodStatus{StartTime: ptr.To(metav1.NewTime(now.Add(-2 * time.Hour)))}}
+			idle := getWorkspaceIdleDuration(now.Add(-time.Hour).Unix(), pod, now)
+			Expect(idle).To(Equal(time.Hour))
+		})
+
+		It("should measure idleness from the Pod start when it is later than the last activity", func() {
+			pod := &corev1.Pod{Status: corev1.PodStatus{StartTime: ptr.To(metav1.NewTime(now.Add(-time.Minute)))}}
+			idle := getWorkspaceIdleDuration(now.Add(-time.Hour).Unix(), pod, now)
+			Expect(idle).To(Equal(time.Minute))
+		})
+
+		It("should never return a negative idleness", func() {
+			pod := &corev1.Pod{Status: corev1.PodStatus{StartTime: ptr.To(metav1.NewTime(now.Add(-time.Minute)))}}
+			idle := getWorkspaceIdleDuration(now.Add(time.Minute).Unix(), pod, now)
+			Expect(idle).To(BeZero())
+		})
+
+		It("should wait for the check period between probes", func() {
+			Expect(getNextActivityCheck(0, time.Minute, now)).To(BeZero())
+			Expect(getNextActivityCheck(now.Add(-20*time.Second).Unix(), time.Minute, now)).To(Equal(40 * time.Second))
+			Expect(getNextActivityCheck(now.Add(-2*time.Minute).Unix(), time.Minute, now)).To(BeZero())
+		})
+	})
+})
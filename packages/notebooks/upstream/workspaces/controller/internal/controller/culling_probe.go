@@ -0,0 +1,223 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	"github.com/kubeflow/notebooks/workspaces/controller/internal/config"
+	"github.com/kubeflow/notebooks/workspaces/controller/internal/helper"
+)
+
+const (
+	// how long a single activity probe may take
+	activityProbeTimeout = 30 * time.Second
+
+	// the path of the Jupyter status API, relative to the Jupyter base URL
+	jupyterStatusPath = "api/status"
+
+	// the maximum size of a Jupyter status API response we will read
+	jupyterStatusMaxBytes = 1 << 20
+)
+
+// ActivityProber runs the activity probe of a WorkspaceKind against the Pod of a Workspace
+type ActivityProber interface {
+	// ProbeActivity returns the last time activity was observed on the Workspace,
+	// or the zero time if the probe observed no activity
+	ProbeActivity(ctx context.Context, workspace *kubefloworgv1beta1.Workspace, workspaceKind *kubefloworgv1beta1.WorkspaceKind, pod *corev1.Pod) (time.Time, error)
+}
+
+// WorkspaceActivityProber runs `exec` probes in the main container of the Workspace Pod,
+// and `jupyter` probes against the Jupyter status API through the Workspace Service
+type WorkspaceActivityProber struct {
+	client.Client
+	Config     *config.EnvConfig
+	RESTConfig *rest.Config
+	Clientset  kubernetes.Interface
+	HTTPClient *http.Client
+}
+
+// NewWorkspaceActivityProber returns a WorkspaceActivityProber for the given REST config
+func NewWorkspaceActivityProber(c client.Client, restConfig *rest.Config, cfg *config.EnvConfig) (*WorkspaceActivityProber, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+	return &WorkspaceActivityProber{
+		Client:     c,
+		Config:     cfg,
+		RESTConfig: restConfig,
+		Clientset:  clientset,
+		HTTPClient: &http.Client{Timeout: activityProbeTimeout},
+	}, nil
+}
+
+// ProbeActivity runs the activity probe configured on the WorkspaceKind
+func (p *WorkspaceActivityProber) ProbeActivity(ctx context.Context, workspace *kubefloworgv1beta1.Workspace, workspaceKind *kubefloworgv1beta1.WorkspaceKind, pod *corev1.Pod) (time.Time, error) {
+	cullingConfig := workspaceKind.Spec.PodTemplate.Culling
+	if cullingConfig == nil {
+		return time.Time{}, fmt.Errorf("WorkspaceKind %q has no culling config", workspaceKind.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, activityProbeTimeout)
+	defer cancel()
+
+	probe := cullingConfig.ActivityProbe
+	switch {
+	case probe.Exec != nil:
+		return p.probeExec(ctx, pod, probe.Exec.Command)
+	case probe.Jupyter != nil && probe.Jupyter.LastActivity:
+		return p.probeJupyter(ctx, workspace, workspaceKind)
+	default:
+		return time.Time{}, fmt.Errorf("WorkspaceKind %q has no supported activity probe", workspaceKind.Name)
+	}
+}
+
+// probeExec runs the command in the main container of the Pod
+//   - an exit status of 0 means there was activity in the last 60 seconds
+//   - any other exit status means there was no activity
+func (p *WorkspaceActivityProber) probeExec(ctx context.Context, pod *corev1.Pod, command []string) (time.Time, error) {
+	req := p.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: workspacePodTemplateContainerName,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(p.RESTConfig, http.MethodPost, req.URL())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create executor for exec activity probe: %w", err)
+	}
+
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: io.Discard,
+		Stderr: io.Discard,
+	})
+	if err != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to run exec activity probe: %w", err)
+	}
+	return time.Now(), nil
+}
+
+// probeJupyter reads `last_activity` from the Jupyter status API of the Workspace
+func (p *WorkspaceActivityProber) probeJupyter(ctx context.Context, workspace *kubefloworgv1beta1.Workspace, workspaceKind *kubefloworgv1beta1.WorkspaceKind) (time.Time, error) {
+	currentImageConfig, _, _, err := getImageConfig(workspace, workspaceKind)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(currentImageConfig.Spec.Ports) == 0 {
+		return time.Time{}, fmt.Errorf("imageConfig %q has no ports", currentImageConfig.Id)
+	}
+
+	serviceName, err := p.getWorkspaceServiceName(ctx, workspace)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	statusURL := getJupyterStatusURL(workspace, workspaceKind, serviceName, p.Config.ClusterDomain, currentImageConfig.Spec.Ports[0])
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, statusURL, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query Jupyter status API: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("jupyter status API returned status %d", resp.StatusCode)
+	}
+	return parseJupyterLastActivity(io.LimitReader(resp.Body, jupyterStatusMaxBytes))
+}
+
+// getWorkspaceServiceName returns the name of the Service owned by the Workspace
+// NOTE: we exclude kube-rbac-proxy services (used with KubeGateway) like the Workspace controller does
+func (p *WorkspaceActivityProber) getWorkspaceServiceName(ctx context.Context, workspace *kubefloworgv1beta1.Workspace) (string, error) {
+	ownedServices := &corev1.ServiceList{}
+	listOpts := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(helper.IndexWorkspaceOwnerField, workspace.Name),
+		Namespace:     workspace.Namespace,
+	}
+	if err := p.List(ctx, ownedServices, listOpts); err != nil {
+		return "", fmt.Errorf("failed to list Services: %w", err)
+	}
+	for _, svc := range ownedServices.Items {
+		if component, ok := svc.Labels["app.kubernetes.io/component"]; ok && component == "kube-rbac-proxy" {
+			continue
+		}
+		return svc.Name, nil
+	}
+	return "", fmt.Errorf("workspace %q has no Service", workspace.Name)
+}
+
+// getJupyterStatusURL returns the in-cluster URL of the Jupyter status API for the given port
+//   - Jupyter serves under the connect path of the port (its `NB_PREFIX`),
+//     unless the WorkspaceKind strips the path prefix in the HTTP proxy
+func getJupyterStatusURL(workspace *kubefloworgv1beta1.Workspace, workspaceKind *kubefloworgv1beta1.WorkspaceKind, serviceName, clusterDomain string, port kubefloworgv1beta1.ImagePort) string {
+	basePath := getWorkspaceConnectPath(workspace.Namespace, workspace.Name, port.Id)
+	for _, kindPort := range workspaceKind.Spec.PodTemplate.Ports {
+		if kindPort.Id == port.Id && kindPort.HTTPProxy != nil && ptr.Deref(kindPort.HTTPProxy.RemovePathPrefix, false) {
+			basePath = "/"
+		}
+	}
+	return fmt.Sprintf("http://%s.%s.svc.%s:%d%s%s", serviceName, workspace.Namespace, clusterDomain, port.Port, basePath, jupyterStatusPath)
+}
+
+// parseJupyterLastActivity parses the `last_activity` field of a Jupyter status API response
+func parseJupyterLastActivity(body io.Reader) (time.Time, error) {
+	var status struct {
+		LastActivity string `json:"last_activity"`
+	}
+	if err := json.NewDecoder(body).Decode(&status); err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode Jupyter status: %w", err)
+	}
+	if status.LastActivity == "" {
+		return time.Time{}, fmt.Errorf("jupyter status has no last_activity")
+	}
+	lastActivity, err := time.Parse(time.RFC3339Nano, status.LastActivity)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse Jupyter last_activity %q: %w", status.LastActivity, err)
+	}
+	return lastActivity, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+)
+
+var _ = Describe("Activity Probes", func() {
+
+	Context("When parsing the Jupyter status API", func() {
+
+		It("should return the `last_activity` time", func() {
+			body := `{"started": "2024-01-01T00:00:00.000000Z", "last_activity": "2024-01-01T01:30:00.123456Z", "connections": 0, "kernels": 1}`
+			lastActivity, err := parseJupyterLastActivity(strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastActivity.Unix()).To(Equal(time.Date(2024, 1, 1, 1, 30, 0, 0, time.UTC).Unix()))
+		})
+
+		It("should accept a `last_activity` with a UTC offset", func() {
+			body := `{"last_activity": "2024-01-01T01:30:00.123456+00:00"}`
+			lastActivity, err := parseJupyterLastActivity(strings.NewReader(body))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastActivity.Unix()).To(Equal(time.Date(2024, 1, 1, 1, 30, 0, 0, time.UTC).Unix()))
+		})
+
+		It("should fail without a `last_activity`", func() {
+			_, err := parseJupyterLastActivity(strings.NewReader(`{"connections": 0}`))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail for an invalid response", func() {
+			_, err := parseJupyterLastActivity(strings.NewReader(`<html>`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When building the Jupyter status API URL", func() {
+		port := kubefloworgv1beta1.ImagePort{Id: "jupyterlab", Port: 8888}
+
+		It("should use the connect path of the port", func() {
+			workspace := NewExampleWorkspace1("my-workspace", "my-namespace", "jupyterlab")
+			workspaceKind := NewExampleWorkspaceKind1("jupyterlab")
+
+			statusURL := getJupyterStatusURL(workspace, workspaceKind, "ws-my-workspace-abcde", "cluster.local", port)
+			Expect(statusURL).To(Equal("http://ws-my-workspace-abcde.my-namespace.svc.cluster.local:8888/workspace/connect/my-namespace/my-workspace/jupyterlab/api/status"))
+		})
+
+		It("should use the root path when the path prefix is removed", func() {
+			workspace := NewExampleWorkspace1("my-workspace", "my-namespace", "jupyterlab")
+			workspaceKind := NewExampleWorkspaceKind1("jupyterlab")
+			workspaceKind.Spec.PodTemplate.Ports[0].HTTPProxy.RemovePathPrefix = ptr.To(true)
+
+			statusURL := getJupyterStatusURL(workspace, workspaceKind, "ws-my-workspace-abcde", "cluster.local", port)
+			Expect(statusURL).To(Equal("http://ws-my-workspace-abcde.my-namespace.svc.cluster.local:8888/api/status"))
+		})
+	})
+})
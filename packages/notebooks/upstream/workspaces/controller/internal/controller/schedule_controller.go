@@ -0,0 +1,278 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	"github.com/kubeflow/notebooks/workspaces/controller/internal/config"
+)
+
+const (
+	// the minimum time to wait before re-checking a schedule
+	// NOTE: this avoids a hot loop if the requeue fires slightly before the transition time
+	minScheduleRequeue = time.Second
+)
+
+// scheduleWeekdays maps the days of a WorkspaceSchedule to time.Weekday
+var scheduleWeekdays = map[kubefloworgv1beta1.WorkspaceScheduleDay]time.Weekday{
+	kubefloworgv1beta1.WorkspaceScheduleDaySunday:    time.Sunday,
+	kubefloworgv1beta1.WorkspaceScheduleDayMonday:    time.Monday,
+	kubefloworgv1beta1.WorkspaceScheduleDayTuesday:   time.Tuesday,
+	kubefloworgv1beta1.WorkspaceScheduleDayWednesday: time.Wednesday,
+	kubefloworgv1beta1.WorkspaceScheduleDayThursday:  time.Thursday,
+	kubefloworgv1beta1.WorkspaceScheduleDayFriday:    time.Friday,
+	kubefloworgv1beta1.WorkspaceScheduleDaySaturday:  time.Saturday,
+}
+
+// ScheduleReconciler starts and pauses Workspaces according to their `spec.schedule`.
+//
+// At each transition of the schedule (the start or end of a window), `spec.paused` is set accordingly.
+// Each transition is only applied once (see `status.schedule.lastTransitionTime`),
+// so if a user manually pauses or starts a Workspace, their choice is kept until the next transition.
+// When a schedule is first set, its most recent transition is applied immediately.
+type ScheduleReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Config *config.EnvConfig
+}
+
+// +kubebuilder:rbac:groups=kubeflow.org,resources=workspaces,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=kubeflow.org,resources=workspaces/status,verbs=get;patch
+
+func (r *ScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("checking Workspace schedule")
+
+	// fetch the Workspace
+	workspace := &kubefloworgv1beta1.Workspace{}
+	if err := r.Get(ctx, req.NamespacedName, workspace); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch Workspace")
+		return ctrl.Result{}, err
+	}
+	if !workspace.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// clear the schedule status if the Workspace has no schedule
+	schedule := workspace.Spec.Schedule
+	if schedule == nil {
+		if workspace.Status.Schedule != nil {
+			patch := client.MergeFrom(workspace.DeepCopy())
+			workspace.Status.Schedule = nil
+			if err := r.Status().Patch(ctx, workspace, patch); err != nil {
+				log.Error(err, "unable to clear Workspace schedule status")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// compute the previous and next transitions of the schedule
+	// NOTE: the time zone is validated by the webhook, so this should only fail if the tzdata is missing
+	location, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		log.Error(err, "unable to load time zone of Workspace schedule", "timeZone", schedule.TimeZone)
+		return ctrl.Result{}, nil
+	}
+	now := time.Now()
+	prevTransition, nextTransition, err := getScheduleTransitions(schedule, location, now)
+	if err != nil {
+		log.Error(err, "invalid Workspace schedule")
+		return ctrl.Result{}, nil
+	}
+
+	scheduleStatus := kubefloworgv1beta1.WorkspaceScheduleStatus{}
+	if workspace.Status.Schedule != nil {
+		scheduleStatus = *workspace.Status.Schedule
+	}
+
+	// apply the previous transition, if it has not already been applied
+	if prevTransition != nil && prevTransition.Time.Unix() > scheduleStatus.LastTransitionTime {
+		paused := prevTransition.Action == kubefloworgv1beta1.WorkspaceScheduleActionPause
+		if ptr.Deref(workspace.Spec.Paused, false) != paused {
+			log.V(0).Info("applying Workspace schedule transition", "action", prevTransition.Action, "transitionTime", prevTransition.Time)
+			// NOTE: we use an optimistic lock so that we never override a concurrent change to the Workspace
+			patch := client.MergeFromWithOptions(workspace.DeepCopy(), client.MergeFromWithOptimisticLock{})
+			workspace.Spec.Paused = ptr.To(paused)
+			if err := r.Patch(ctx, workspace, patch); err != nil {
+				if apierrors.IsConflict(err) {
+					log.V(2).Info("update conflict while applying Workspace schedule, will requeue")
+					return ctrl.Result{Requeue: true}, nil
+				}
+				log.Error(err, "unable to apply Workspace schedule")
+				return ctrl.Result{}, err
+			}
+		}
+		scheduleStatus.LastTransitionTime = prevTransition.Time.Unix()
+	}
+
+	// record the next transition
+	scheduleStatus.NextTransitionTime = nextTransition.Time.Unix()
+	scheduleStatus.NextTransitionAction = nextTransition.Action
+
+	// update the Workspace schedule status
+	if workspace.Status.Schedule == nil || *workspace.Status.Schedule != scheduleStatus {
+		patch := client.MergeFrom(workspace.DeepCopy())
+		workspace.Status.Schedule = &scheduleStatus
+		if err := r.Status().Patch(ctx, workspace, patch); err != nil {
+			log.Error(err, "unable to update Workspace schedule status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// requeue at the next transition
+	// NOTE: changes to the schedule change the generation of the Workspace, which triggers a new reconcile
+	return ctrl.Result{RequeueAfter: max(nextTransition.Time.Sub(now), minScheduleRequeue)}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ScheduleReconciler) SetupWithManager(mgr ctrl.Manager, opts *controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workspace-schedule").
+		WithOptions(*opts).
+		For(&kubefloworgv1beta1.Workspace{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// scheduleTransition is a time at which a schedule starts or pauses a Workspace
+type scheduleTransition struct {
+	Time   time.Time
+	Action kubefloworgv1beta1.WorkspaceScheduleAction
+}
+
+// scheduleInterval is a time interval in which a schedule wants a Workspace to be running
+type scheduleInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// getScheduleTransitions returns the last transition of a schedule at or before `now`, and the first transition after `now`
+//   - the previous transition is nil if the schedule has no transition in the last week
+//   - overlapping or adjacent windows are merged, so a transition is always a change of state
+func getScheduleTransitions(schedule *kubefloworgv1beta1.WorkspaceSchedule, location *time.Location, now time.Time) (*scheduleTransition, *scheduleTransition, error) {
+	// schedules repeat every week, and windows are at most 24 hours long,
+	// so looking one week (plus a day) in each direction is enough to find both transitions
+	intervals, err := getScheduleIntervals(schedule, location, now.In(location), 8)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(intervals) == 0 {
+		return nil, nil, fmt.Errorf("schedule has no windows")
+	}
+
+	var prevTransition, nextTransition *scheduleTransition
+	for _, interval := range intervals {
+		for _, transition := range []scheduleTransition{
+			{Time: interval.Start, Action: kubefloworgv1beta1.WorkspaceScheduleActionStart},
+			{Time: interval.End, Action: kubefloworgv1beta1.WorkspaceScheduleActionPause},
+		} {
+			if !transition.Time.After(now) {
+				prevTransition = &transition
+			} else if nextTransition == nil {
+				nextTransition = &transition
+			}
+		}
+	}
+	if nextTransition == nil {
+		// this should never happen, as schedules repeat every week
+		return nil, nil, fmt.Errorf("schedule has no next transition")
+	}
+	return prevTransition, nextTransition, nil
+}
+
+// getScheduleIntervals returns the sorted and merged intervals of a schedule, for the windows starting
+// within `days` days of `now` (in either direction)
+func getScheduleIntervals(schedule *kubefloworgv1beta1.WorkspaceSchedule, location *time.Location, now time.Time, days int) ([]scheduleInterval, error) {
+	var intervals []scheduleInterval
+	for _, window := range schedule.Windows {
+		startHour, startMinute, err := parseScheduleTime(window.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		pauseHour, pauseMinute, err := parseScheduleTime(window.PauseTime)
+		if err != nil {
+			return nil, err
+		}
+		windowDays := make(map[time.Weekday]bool, len(window.Days))
+		for _, day := range window.Days {
+			weekday, ok := scheduleWeekdays[day]
+			if !ok {
+				return nil, fmt.Errorf("invalid schedule day %q", day)
+			}
+			windowDays[weekday] = true
+		}
+
+		for offset := -days; offset <= days; offset++ {
+			date := now.AddDate(0, 0, offset)
+			if !windowDays[date.Weekday()] {
+				continue
+			}
+			year, month, day := date.Date()
+			start := time.Date(year, month, day, startHour, startMinute, 0, 0, location)
+			end := time.Date(year, month, day, pauseHour, pauseMinute, 0, 0, location)
+			if !end.After(start) {
+				end = time.Date(year, month, day+1, pauseHour, pauseMinute, 0, 0, location)
+			}
+			intervals = append(intervals, scheduleInterval{Start: start, End: end})
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+
+	// merge overlapping and adjacent intervals
+	merged := make([]scheduleInterval, 0, len(intervals))
+	for _, interval := range intervals {
+		last := len(merged) - 1
+		if last >= 0 && !interval.Start.After(merged[last].End) {
+			if interval.End.After(merged[last].End) {
+				merged[last].End = interval.End
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged, nil
+}
+
+// parseScheduleTime parses a 24-hour "HH:MM" time of day
+func parseScheduleTime(value string) (int, int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid schedule time %q: %w", value, err)
+	}
+	return t.Hour(), t.Minute(), nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+)
+
+// allScheduleDays are all the days of the week
+var allScheduleDays = []kubefloworgv1beta1.WorkspaceScheduleDay{
+	kubefloworgv1beta1.WorkspaceScheduleDayMonday,
+	kubefloworgv1beta1.WorkspaceScheduleDayTuesday,
+	kubefloworgv1beta1.WorkspaceScheduleDayWednesday,
+	kubefloworgv1beta1.WorkspaceScheduleDayThursday,
+	kubefloworgv1beta1.WorkspaceScheduleDayFriday,
+	kubefloworgv1beta1.WorkspaceScheduleDaySaturday,
+	kubefloworgv1beta1.WorkspaceScheduleDaySunday,
+}
+
+var _ = Describe("Schedule Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		namespaceName = "default"
+
+		// how long to wait in "Eventually" blocks
+		timeout = time.Second * 10
+
+		// how long to wait in "Consistently" blocks
+		duration = time.Second * 3
+
+		// how frequently to poll for conditions
+		interval = time.Millisecond * 250
+	)
+
+	Context("When applying Workspace schedules", Ordered, func() {
+
+		// Define utility variables for object names.
+		// NOTE: to avoid conflicts between parallel tests, resource names are unique to each test
+		var (
+			workspaceKindName string
+			createdObjects    []client.Object
+		)
+
+		// createScheduledWorkspace creates a Workspace with a daily UTC schedule window from `start` to `pause`
+		createScheduledWorkspace := func(workspaceName string, paused bool, start time.Time, pause time.Time) types.NamespacedName {
+			By("creating the Workspace")
+			workspace := NewExampleWorkspace1(workspaceName, namespaceName, workspaceKindName)
+			workspace.Spec.Paused = ptr.To(paused)
+			workspace.Spec.Schedule = &kubefloworgv1beta1.WorkspaceSchedule{
+				TimeZone: "UTC",
+				Windows: []kubefloworgv1beta1.WorkspaceScheduleWindow{
+					{
+						Days:      allScheduleDays,
+						StartTime: start.UTC().Format("15:04"),
+						PauseTime: pause.UTC().Format("15:04"),
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, workspace)).To(Succeed())
+			createdObjects = append(createdObjects, workspace)
+
+			return types.NamespacedName{Name: workspaceName, Namespace: namespaceName}
+		}
+
+		BeforeAll(func() {
+			uniqueName := "ws-schedule-test"
+			workspaceKindName = fmt.Sprintf("workspacekind-%s", uniqueName)
+
+			By("creating the WorkspaceKind")
+			workspaceKind := NewExampleWorkspaceKind1(workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspaceKind)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			By("deleting the Workspaces")
+			for _, obj := range createdObjects {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+			}
+
+			By("deleting the WorkspaceKind")
+			workspaceKind := &kubefloworgv1beta1.WorkspaceKind{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: workspaceKindName,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, workspaceKind)).To(Succeed())
+		})
+
+		It("should start a paused Workspace inside a window, and record the next transition", func() {
+			now := time.Now()
+			start := now.Add(-2 * time.Hour)
+			pause := now.Add(2 * time.Hour)
+			workspaceKey := createScheduledWorkspace("workspace-ws-schedule-start", true, start, pause)
+
+			By("starting the Workspace")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Eventually(func() (*kubefloworgv1beta1.Workspace, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace, err
+			}, timeout, interval).Should(And(
+				HaveField("Spec.Paused", Equal(ptr.To(false))),
+				HaveField("Status.Schedule", Equal(&kubefloworgv1beta1.WorkspaceScheduleStatus{
+					LastTransitionTime:   start.Truncate(time.Minute).Unix(),
+					NextTransitionTime:   pause.Truncate(time.Minute).Unix(),
+					NextTransitionAction: kubefloworgv1beta1.WorkspaceScheduleActionPause,
+				})),
+			))
+
+			By("respecting a manual pause until the next transition")
+			patch := client.MergeFrom(workspace.DeepCopy())
+			workspace.Spec.Paused = ptr.To(true)
+			Expect(k8sClient.Patch(ctx, workspace, patch)).To(Succeed())
+			Consistently(func() (*bool, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace.Spec.Paused, err
+			}, duration, interval).Should(Equal(ptr.To(true)))
+
+			By("clearing the schedule status when the schedule is removed")
+			patch = client.MergeFrom(workspace.DeepCopy())
+			workspace.Spec.Schedule = nil
+			Expect(k8sClient.Patch(ctx, workspace, patch)).To(Succeed())
+			Eventually(func() (*kubefloworgv1beta1.WorkspaceScheduleStatus, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace.Status.Schedule, err
+			}, timeout, interval).Should(BeNil())
+		})
+
+		It("should pause a running Workspace outside a window", func() {
+			now := time.Now()
+			start := now.Add(2 * time.Hour)
+			pause := now.Add(3 * time.Hour)
+			workspaceKey := createScheduledWorkspace("workspace-ws-schedule-pause", false, start, pause)
+
+			By("pausing the Workspace")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Eventually(func() (*kubefloworgv1beta1.Workspace, error) {
+				err := k8sClient.Get(ctx, workspaceKey, workspace)
+				return workspace, err
+			}, timeout, interval).Should(And(
+				HaveField("Spec.Paused", Equal(ptr.To(true))),
+				HaveField("Status.Schedule.NextTransitionTime", Equal(start.Truncate(time.Minute).Unix())),
+				HaveField("Status.Schedule.NextTransitionAction", Equal(kubefloworgv1beta1.WorkspaceScheduleActionStart)),
+			))
+		})
+	})
+
+	Context("When computing schedule transitions", func() {
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			panic(err)
+		}
+		weekdays := &kubefloworgv1beta1.WorkspaceSchedule{
+			TimeZone: "America/New_York",
+			Windows: []kubefloworgv1beta1.WorkspaceScheduleWindow{
+				{
+					Days:      allScheduleDays[:5],
+					StartTime: "08:00",
+					PauseTime: "19:00",
+				},
+			},
+		}
+
+		It("should pause at the end of a window, and start on the next working day", func() {
+			// Friday 2024-01-05 20:30 in New York
+			now := time.Date(2024, 1, 5, 20, 30, 0, 0, newYork)
+			prev, next, err := getScheduleTransitions(weekdays, newYork, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prev.Time.Equal(time.Date(2024, 1, 5, 19, 0, 0, 0, newYork))).To(BeTrue())
+			Expect(prev.Action).To(Equal(kubefloworgv1beta1.WorkspaceScheduleActionPause))
+			Expect(next.Time.Equal(time.Date(2024, 1, 8, 8, 0, 0, 0, newYork))).To(BeTrue())
+			Expect(next.Action).To(Equal(kubefloworgv1beta1.WorkspaceScheduleActionStart))
+		})
+
+		It("should treat a transition at the current time as the previous transition", func() {
+			now := time.Date(2024, 1, 8, 8, 0, 0, 0, newYork)
+			prev, next, err := getScheduleTransitions(weekdays, newYork, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prev.Time.Equal(now)).To(BeTrue())
+			Expect(prev.Action).To(Equal(kubefloworgv1beta1.WorkspaceScheduleActionStart))
+			Expect(next.Time.Equal(time.Date(2024, 1, 8, 19, 0, 0, 0, newYork))).To(BeTrue())
+		})
+
+		It("should end overnight windows on the next day, and merge overlapping windows", func() {
+			schedule := &kubefloworgv1beta1.WorkspaceSchedule{
+				TimeZone: "UTC",
+				Windows: []kubefloworgv1beta1.WorkspaceScheduleWindow{
+					{Days: allScheduleDays, StartTime: "22:00", PauseTime: "02:00"},
+					{Days: allScheduleDays, StartTime: "01:00", PauseTime: "06:00"},
+				},
+			}
+			now := time.Date(2024, 1, 3, 3, 0, 0, 0, time.UTC)
+			prev, next, err := getScheduleTransitions(schedule, time.UTC, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prev.Time.Equal(time.Date(2024, 1, 2, 22, 0, 0, 0, time.UTC))).To(BeTrue())
+			Expect(prev.Action).To(Equal(kubefloworgv1beta1.WorkspaceScheduleActionStart))
+			Expect(next.Time.Equal(time.Date(2024, 1, 3, 6, 0, 0, 0, time.UTC))).To(BeTrue())
+			Expect(next.Action).To(Equal(kubefloworgv1beta1.WorkspaceScheduleActionPause))
+		})
+	})
+})
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -120,6 +121,10 @@ var _ = BeforeSuite(func() {
 		// TODO: make true once we install Istio CRDs in EnvTest.
 		//       also create unit tests to ensure VirtualService is created by controller.
 		UseIstio: false,
+
+		// probe often, so the culling tests don't have to wait long
+		EnableCulling:      true,
+		CullingCheckPeriod: time.Second,
 	}
 
 	By("setting up the field indexers for the controller manager")
@@ -150,6 +155,37 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
+	By("setting up the Culling controller")
+	err = (&CullingReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+		Config: envConfig,
+		Prober: testActivityProber,
+	}).SetupWithManager(k8sManager, &controller.Options{
+		RateLimiter: helper.BuildRateLimiter(),
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	By("setting up the Schedule controller")
+	err = (&ScheduleReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+		Config: envConfig,
+	}).SetupWithManager(k8sManager, &controller.Options{
+		RateLimiter: helper.BuildRateLimiter(),
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	By("setting up the UpgradeCampaign controller")
+	err = (&UpgradeCampaignReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+		Config: envConfig,
+	}).SetupWithManager(k8sManager, &controller.Options{
+		RateLimiter: helper.BuildRateLimiter(),
+	})
+	Expect(err).NotTo(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = k8sManager.Start(ctx)
@@ -0,0 +1,384 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	"github.com/kubeflow/notebooks/workspaces/controller/internal/config"
+	"github.com/kubeflow/notebooks/workspaces/controller/internal/helper"
+)
+
+const (
+	// annotation set on a Workspace by its user to defer its upgrade,
+	// the value is the name of the WorkspaceUpgradeCampaign being deferred
+	upgradeCampaignDeferralAnnotation = "notebooks.kubeflow.org/upgrade-campaign-deferral"
+
+	// how often each WorkspaceUpgradeCampaign is re-checked
+	// NOTE: idle windows and deferrals are time-based, so we poll rather than watching every Workspace
+	upgradeCampaignCheckPeriod = time.Minute
+
+	// upgrade messages
+	upgradeMsgPending         = "Waiting for Workspace to be idle"
+	upgradeMsgDeferred        = "Upgrade was deferred by the user"
+	upgradeMsgUpgrading       = "Workspace is restarting with the upgraded options"
+	upgradeMsgSucceeded       = "Workspace was upgraded"
+	upgradeMsgSucceededOther  = "Workspace no longer has a pending redirect"
+	upgradeMsgFailedState     = "Workspace failed after the upgrade: %s"
+	upgradeMsgFailedTimeout   = "Workspace did not become running within %d seconds of the upgrade"
+	upgradeMsgFailedRejected  = "Upgrade was rejected: %s"
+	upgradeMsgFailedToUpgrade = "Failed to upgrade Workspace, will retry: %s"
+)
+
+// UpgradeCampaignReconciler applies the pending redirects of a WorkspaceKind to its Workspaces.
+//
+// Every Workspace of the campaign's WorkspaceKind with a redirect in `status.podTemplateOptions` is tracked
+// in the campaign status. Workspaces are upgraded in batches of `spec.batchSize`, and only while they are
+// paused or have been idle for at least `spec.minIdleSeconds`. Upgrading a Workspace sets its
+// `spec.podTemplate.options` to the desired options, which restarts its Pod.
+type UpgradeCampaignReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Config *config.EnvConfig
+}
+
+// +kubebuilder:rbac:groups=kubeflow.org,resources=workspaceupgradecampaigns,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kubeflow.org,resources=workspaceupgradecampaigns/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kubeflow.org,resources=workspaces,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+func (r *UpgradeCampaignReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("reconciling WorkspaceUpgradeCampaign")
+
+	// fetch the WorkspaceUpgradeCampaign
+	campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{}
+	if err := r.Get(ctx, req.NamespacedName, campaign); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch WorkspaceUpgradeCampaign")
+		return ctrl.Result{}, err
+	}
+	if !campaign.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// NOTE: we dereference the DeepCopy of the status field because status fields are NOT pointers,
+	//       so otherwise the `equality.Semantic.DeepEqual` will always return false.
+	currentStatus := *campaign.Status.DeepCopy()
+
+	// fetch all Workspaces that are using the WorkspaceKind
+	workspaces := &kubefloworgv1beta1.WorkspaceList{}
+	listOpts := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(helper.IndexWorkspaceKindField, campaign.Spec.WorkspaceKind),
+		Namespace:     "", // fetch Workspaces in all namespaces
+	}
+	if err := r.List(ctx, workspaces, listOpts); err != nil {
+		log.Error(err, "unable to list Workspaces")
+		return ctrl.Result{}, err
+	}
+
+	previousEntries := make(map[types.NamespacedName]kubefloworgv1beta1.WorkspaceUpgradeStatus, len(campaign.Status.Workspaces))
+	for _, entry := range campaign.Status.Workspaces {
+		previousEntries[types.NamespacedName{Namespace: entry.Namespace, Name: entry.Name}] = entry
+	}
+
+	// update the upgrade status of each Workspace
+	now := time.Now()
+	workspacesByKey := make(map[types.NamespacedName]*kubefloworgv1beta1.Workspace, len(workspaces.Items))
+	entries := make([]kubefloworgv1beta1.WorkspaceUpgradeStatus, 0, len(workspaces.Items))
+	for i := range workspaces.Items {
+		workspace := &workspaces.Items[i]
+		if !workspace.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		key := types.NamespacedName{Namespace: workspace.Namespace, Name: workspace.Name}
+		workspacesByKey[key] = workspace
+
+		entry, exists := previousEntries[key]
+		if !exists {
+			if !isWorkspaceUpgradeAffected(workspace) {
+				continue
+			}
+			entry = kubefloworgv1beta1.WorkspaceUpgradeStatus{
+				Namespace: workspace.Namespace,
+				Name:      workspace.Name,
+				State:     kubefloworgv1beta1.WorkspaceUpgradeStatePending,
+				Message:   upgradeMsgPending,
+			}
+		}
+		delete(previousEntries, key)
+
+		// only upgrading Workspaces need their Pod to be checked
+		var pod *corev1.Pod
+		if entry.State == kubefloworgv1beta1.WorkspaceUpgradeStateUpgrading && !ptr.Deref(workspace.Spec.Paused, false) {
+			var err error
+			pod, err = getRunningWorkspacePod(ctx, r, workspace)
+			if err != nil {
+				log.Error(err, "unable to list Pods")
+				return ctrl.Result{}, err
+			}
+		}
+
+		updateWorkspaceUpgradeStatus(&entry, workspace, campaign, pod, now)
+		entries = append(entries, entry)
+	}
+
+	// keep the final state of Workspaces which were deleted after being upgraded
+	// NOTE: Workspaces which were deleted before their upgrade finished are dropped
+	for _, entry := range previousEntries {
+		if isWorkspaceUpgradeFinished(entry.State) {
+			entries = append(entries, entry)
+		}
+	}
+
+	// start upgrading idle Workspaces, up to the batch size
+	paused := ptr.Deref(campaign.Spec.Paused, false)
+	if !paused {
+		slots := campaign.Spec.BatchSize - getWorkspaceUpgradeSummary(entries).Upgrading
+		for i := range entries {
+			if slots <= 0 {
+				break
+			}
+			entry := &entries[i]
+			if entry.State != kubefloworgv1beta1.WorkspaceUpgradeStatePending {
+				continue
+			}
+			workspace := workspacesByKey[types.NamespacedName{Namespace: entry.Namespace, Name: entry.Name}]
+
+			idle, err := r.isWorkspaceIdle(ctx, workspace, campaign.Spec.MinIdleSeconds, now)
+			if err != nil {
+				log.Error(err, "unable to list Pods")
+				return ctrl.Result{}, err
+			}
+			if !idle {
+				continue
+			}
+
+			log.V(0).Info("upgrading Workspace", "namespace", workspace.Namespace, "name", workspace.Name)
+			// NOTE: we use an optimistic lock so that we never override a concurrent change to the Workspace
+			patch := client.MergeFromWithOptions(workspace.DeepCopy(), client.MergeFromWithOptimisticLock{})
+			setWorkspaceUpgradedOptions(workspace)
+			if err := r.Patch(ctx, workspace, patch); err != nil {
+				switch {
+				case apierrors.IsConflict(err):
+					log.V(2).Info("update conflict while upgrading Workspace, will retry", "namespace", workspace.Namespace, "name", workspace.Name)
+				case apierrors.IsInvalid(err), apierrors.IsForbidden(err):
+					entry.State = kubefloworgv1beta1.WorkspaceUpgradeStateFailed
+					entry.Message = fmt.Sprintf(upgradeMsgFailedRejected, err.Error())
+				default:
+					log.Error(err, "unable to upgrade Workspace", "namespace", workspace.Namespace, "name", workspace.Name)
+					entry.Message = fmt.Sprintf(upgradeMsgFailedToUpgrade, err.Error())
+				}
+				continue
+			}
+			entry.State = kubefloworgv1beta1.WorkspaceUpgradeStateUpgrading
+			entry.Message = upgradeMsgUpgrading
+			entry.UpgradeTime = now.Unix()
+			slots--
+		}
+	}
+
+	// update the WorkspaceUpgradeCampaign status
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Namespace != entries[j].Namespace {
+			return entries[i].Namespace < entries[j].Namespace
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	campaign.Status.Workspaces = entries
+	campaign.Status.Summary = getWorkspaceUpgradeSummary(entries)
+	campaign.Status.Phase = getWorkspaceUpgradeCampaignPhase(paused, campaign.Status.Summary)
+	if !equality.Semantic.DeepEqual(currentStatus, campaign.Status) {
+		if err := r.Status().Update(ctx, campaign); err != nil {
+			if apierrors.IsConflict(err) {
+				log.V(2).Info("update conflict while updating WorkspaceUpgradeCampaign status, will requeue")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			log.Error(err, "unable to update WorkspaceUpgradeCampaign status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: upgradeCampaignCheckPeriod}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *UpgradeCampaignReconciler) SetupWithManager(mgr ctrl.Manager, opts *controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workspace-upgrade-campaign").
+		WithOptions(*opts).
+		For(&kubefloworgv1beta1.WorkspaceUpgradeCampaign{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// isWorkspaceIdle returns true if a Workspace can be restarted without disrupting its user
+//   - paused Workspaces are always idle
+//   - running Workspaces are idle if their activity is known and older than minIdleSeconds
+func (r *UpgradeCampaignReconciler) isWorkspaceIdle(ctx context.Context, workspace *kubefloworgv1beta1.Workspace, minIdleSeconds int32, now time.Time) (bool, error) {
+	if ptr.Deref(workspace.Spec.Paused, false) {
+		return true, nil
+	}
+	if workspace.Status.Activity.LastUpdate == 0 {
+		return false, nil
+	}
+	pod, err := getRunningWorkspacePod(ctx, r, workspace)
+	if err != nil {
+		return false, err
+	}
+	if pod == nil {
+		return false, nil
+	}
+	idle := getWorkspaceIdleDuration(workspace.Status.Activity.LastActivity, pod, now)
+	return idle >= time.Duration(minIdleSeconds)*time.Second, nil
+}
+
+// isWorkspaceUpgradeAffected returns true if a Workspace has a redirect which is not yet applied
+func isWorkspaceUpgradeAffected(workspace *kubefloworgv1beta1.Workspace) bool {
+	options := workspace.Status.PodTemplateOptions
+	return len(options.ImageConfig.RedirectChain) > 0 || len(options.PodConfig.RedirectChain) > 0
+}
+
+// isWorkspaceUpgradeFinished returns true if an upgrade state is final
+func isWorkspaceUpgradeFinished(state kubefloworgv1beta1.WorkspaceUpgradeState) bool {
+	return state == kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded || state == kubefloworgv1beta1.WorkspaceUpgradeStateFailed
+}
+
+// setWorkspaceUpgradedOptions sets the podTemplate options of a Workspace to their desired (redirected) values
+func setWorkspaceUpgradedOptions(workspace *kubefloworgv1beta1.Workspace) {
+	options := workspace.Status.PodTemplateOptions
+	if len(options.ImageConfig.RedirectChain) > 0 && options.ImageConfig.Desired != "" {
+		workspace.Spec.PodTemplate.Options.ImageConfig = options.ImageConfig.Desired
+	}
+	if len(options.PodConfig.RedirectChain) > 0 && options.PodConfig.Desired != "" {
+		workspace.Spec.PodTemplate.Options.PodConfig = options.PodConfig.Desired
+	}
+}
+
+// updateWorkspaceUpgradeStatus moves the upgrade status of a Workspace to its next state
+//   - pod is the running Pod of the Workspace, it is only required for upgrading Workspaces
+func updateWorkspaceUpgradeStatus(entry *kubefloworgv1beta1.WorkspaceUpgradeStatus, workspace *kubefloworgv1beta1.Workspace, campaign *kubefloworgv1beta1.WorkspaceUpgradeCampaign, pod *corev1.Pod, now time.Time) {
+	affected := isWorkspaceUpgradeAffected(workspace)
+
+	switch entry.State {
+	case kubefloworgv1beta1.WorkspaceUpgradeStatePending, kubefloworgv1beta1.WorkspaceUpgradeStateDeferred:
+		if !affected {
+			entry.State = kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded
+			entry.Message = upgradeMsgSucceededOther
+			entry.DeferredUntil = 0
+			return
+		}
+		if entry.State == kubefloworgv1beta1.WorkspaceUpgradeStateDeferred && now.Unix() >= entry.DeferredUntil {
+			entry.State = kubefloworgv1beta1.WorkspaceUpgradeStatePending
+			entry.Message = upgradeMsgPending
+			entry.DeferredUntil = 0
+		}
+		// each Workspace can only be deferred once per campaign
+		if entry.State == kubefloworgv1beta1.WorkspaceUpgradeStatePending && !entry.Deferred &&
+			workspace.GetAnnotations()[upgradeCampaignDeferralAnnotation] == campaign.Name {
+			entry.State = kubefloworgv1beta1.WorkspaceUpgradeStateDeferred
+			entry.Message = upgradeMsgDeferred
+			entry.Deferred = true
+			entry.DeferredUntil = now.Add(time.Duration(campaign.Spec.DeferralSeconds) * time.Second).Unix()
+		}
+
+	case kubefloworgv1beta1.WorkspaceUpgradeStateUpgrading:
+		switch {
+		case workspace.Status.State == kubefloworgv1beta1.WorkspaceStateError:
+			entry.State = kubefloworgv1beta1.WorkspaceUpgradeStateFailed
+			entry.Message = fmt.Sprintf(upgradeMsgFailedState, workspace.Status.StateMessage)
+		case !affected && isWorkspaceUpgradeRestarted(workspace, pod, entry.UpgradeTime):
+			entry.State = kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded
+			entry.Message = upgradeMsgSucceeded
+		case now.Unix()-entry.UpgradeTime > int64(campaign.Spec.TimeoutSeconds):
+			entry.State = kubefloworgv1beta1.WorkspaceUpgradeStateFailed
+			entry.Message = fmt.Sprintf(upgradeMsgFailedTimeout, campaign.Spec.TimeoutSeconds)
+		}
+
+	case kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded:
+		// a new redirect was added to the WorkspaceKind after the Workspace was upgraded
+		if affected {
+			entry.State = kubefloworgv1beta1.WorkspaceUpgradeStatePending
+			entry.Message = upgradeMsgPending
+			entry.UpgradeTime = 0
+		}
+	}
+}
+
+// isWorkspaceUpgradeRestarted returns true if a Workspace is running with a Pod created after its upgrade
+//   - paused Workspaces have no Pod, so their upgrade takes effect immediately
+func isWorkspaceUpgradeRestarted(workspace *kubefloworgv1beta1.Workspace, pod *corev1.Pod, upgradeTime int64) bool {
+	if ptr.Deref(workspace.Spec.Paused, false) {
+		return true
+	}
+	if workspace.Status.State != kubefloworgv1beta1.WorkspaceStateRunning || pod == nil {
+		return false
+	}
+	return pod.CreationTimestamp.Unix() >= upgradeTime
+}
+
+// getWorkspaceUpgradeSummary counts the Workspaces in each upgrade state
+func getWorkspaceUpgradeSummary(entries []kubefloworgv1beta1.WorkspaceUpgradeStatus) kubefloworgv1beta1.WorkspaceUpgradeSummary {
+	summary := kubefloworgv1beta1.WorkspaceUpgradeSummary{}
+	for _, entry := range entries {
+		switch entry.State {
+		case kubefloworgv1beta1.WorkspaceUpgradeStatePending:
+			summary.Pending++
+		case kubefloworgv1beta1.WorkspaceUpgradeStateDeferred:
+			summary.Deferred++
+		case kubefloworgv1beta1.WorkspaceUpgradeStateUpgrading:
+			summary.Upgrading++
+		case kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded:
+			summary.Succeeded++
+		case kubefloworgv1beta1.WorkspaceUpgradeStateFailed:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+// getWorkspaceUpgradeCampaignPhase returns the phase of a campaign
+//   - a campaign is completed when none of its Workspaces are waiting for (or in the middle of) an upgrade
+//   - a completed campaign keeps watching for new redirects, in which case it becomes running again
+func getWorkspaceUpgradeCampaignPhase(paused bool, summary kubefloworgv1beta1.WorkspaceUpgradeSummary) kubefloworgv1beta1.WorkspaceUpgradeCampaignPhase {
+	if paused {
+		return kubefloworgv1beta1.WorkspaceUpgradeCampaignPhasePaused
+	}
+	if summary.Pending == 0 && summary.Deferred == 0 && summary.Upgrading == 0 {
+		return kubefloworgv1beta1.WorkspaceUpgradeCampaignPhaseCompleted
+	}
+	return kubefloworgv1beta1.WorkspaceUpgradeCampaignPhaseRunning
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+)
+
+var _ = Describe("UpgradeCampaign Controller", func() {
+
+	// Define utility constants for object names and testing timeouts/durations and intervals.
+	const (
+		namespaceName = "default"
+
+		// how long to wait in "Eventually" blocks
+		timeout = time.Second * 10
+
+		// how frequently to poll for conditions
+		interval = time.Millisecond * 250
+	)
+
+	Context("When running a WorkspaceUpgradeCampaign", Ordered, func() {
+
+		// Define utility variables for object names.
+		// NOTE: to avoid conflicts between parallel tests, resource names are unique to each test
+		var (
+			workspaceKindName   string
+			pausedWorkspaceKey  types.NamespacedName
+			runningWorkspaceKey types.NamespacedName
+			campaignName        string
+		)
+
+		BeforeAll(func() {
+			uniqueName := "ws-upgrade-test"
+			workspaceKindName = fmt.Sprintf("workspacekind-%s", uniqueName)
+			pausedWorkspaceKey = types.NamespacedName{Name: fmt.Sprintf("workspace-%s-paused", uniqueName), Namespace: namespaceName}
+			runningWorkspaceKey = types.NamespacedName{Name: fmt.Sprintf("workspace-%s-running", uniqueName), Namespace: namespaceName}
+			campaignName = fmt.Sprintf("campaign-%s", uniqueName)
+
+			By("creating the WorkspaceKind")
+			workspaceKind := NewExampleWorkspaceKind1(workspaceKindName)
+			Expect(k8sClient.Create(ctx, workspaceKind)).To(Succeed())
+
+			By("creating the Workspaces")
+			// NOTE: the imageConfig of the example Workspace has a redirect
+			pausedWorkspace := NewExampleWorkspace1(pausedWorkspaceKey.Name, namespaceName, workspaceKindName)
+			pausedWorkspace.Spec.Paused = ptr.To(true)
+			Expect(k8sClient.Create(ctx, pausedWorkspace)).To(Succeed())
+			runningWorkspace := NewExampleWorkspace1(runningWorkspaceKey.Name, namespaceName, workspaceKindName)
+			Expect(k8sClient.Create(ctx, runningWorkspace)).To(Succeed())
+
+			By("waiting for the redirects to be reported")
+			for _, key := range []types.NamespacedName{pausedWorkspaceKey, runningWorkspaceKey} {
+				Eventually(func() ([]kubefloworgv1beta1.WorkspacePodOptionRedirectStep, error) {
+					workspace := &kubefloworgv1beta1.Workspace{}
+					err := k8sClient.Get(ctx, key, workspace)
+					return workspace.Status.PodTemplateOptions.ImageConfig.RedirectChain, err
+				}, timeout, interval).ShouldNot(BeEmpty())
+			}
+		})
+
+		AfterAll(func() {
+			By("deleting the WorkspaceUpgradeCampaign")
+			campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: campaignName,
+				},
+			}
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, campaign))).To(Succeed())
+
+			By("deleting the Workspaces")
+			for _, key := range []types.NamespacedName{pausedWorkspaceKey, runningWorkspaceKey} {
+				workspace := &kubefloworgv1beta1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      key.Name,
+						Namespace: key.Namespace,
+					},
+				}
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, workspace))).To(Succeed())
+			}
+
+			By("deleting the WorkspaceKind")
+			workspaceKind := &kubefloworgv1beta1.WorkspaceKind{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: workspaceKindName,
+				},
+			}
+			Expect(k8sClient.Delete(ctx, workspaceKind)).To(Succeed())
+		})
+
+		It("should upgrade paused Workspaces, and leave running Workspaces without activity pending", func() {
+			By("creating the WorkspaceUpgradeCampaign")
+			campaign := &kubefloworgv1beta1.WorkspaceUpgradeCampaign{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: campaignName,
+				},
+				Spec: kubefloworgv1beta1.WorkspaceUpgradeCampaignSpec{
+					WorkspaceKind: workspaceKindName,
+				},
+			}
+			Expect(k8sClient.Create(ctx, campaign)).To(Succeed())
+
+			By("upgrading the paused Workspace")
+			Eventually(func() (string, error) {
+				workspace := &kubefloworgv1beta1.Workspace{}
+				err := k8sClient.Get(ctx, pausedWorkspaceKey, workspace)
+				return workspace.Spec.PodTemplate.Options.ImageConfig, err
+			}, timeout, interval).Should(Equal("jupyterlab_scipy_190"))
+
+			By("not upgrading the running Workspace")
+			workspace := &kubefloworgv1beta1.Workspace{}
+			Expect(k8sClient.Get(ctx, runningWorkspaceKey, workspace)).To(Succeed())
+			Expect(workspace.Spec.PodTemplate.Options.ImageConfig).To(Equal("jupyterlab_scipy_180"))
+
+			By("reporting the upgrade state of each Workspace")
+			Eventually(func() ([]kubefloworgv1beta1.WorkspaceUpgradeStatus, error) {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: campaignName}, campaign)
+				return campaign.Status.Workspaces, err
+			}, timeout, interval).Should(ContainElements(
+				And(
+					HaveField("Name", pausedWorkspaceKey.Name),
+					HaveField("State", kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded),
+				),
+				And(
+					HaveField("Name", runningWorkspaceKey.Name),
+					HaveField("State", kubefloworgv1beta1.WorkspaceUpgradeStatePending),
+				),
+			))
+			Expect(campaign.Status.Phase).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeCampaignPhaseRunning))
+		})
+	})
+
+	Context("When updating the upgrade status of a Workspace", func() {
+		now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		var (
+			workspace *kubefloworgv1beta1.Workspace
+			campaign  *kubefloworgv1beta1.WorkspaceUpgradeCampaign
+		)
+
+		BeforeEach(func() {
+			workspace = NewExampleWorkspace1("my-workspace", "my-namespace", "jupyterlab")
+			workspace.Status.PodTemplateOptions.ImageConfig.Desired = "jupyterlab_scipy_190"
+			workspace.Status.PodTemplateOptions.ImageConfig.RedirectChain = []kubefloworgv1beta1.WorkspacePodOptionRedirectStep{
+				{Source: "jupyterlab_scipy_180", Target: "jupyterlab_scipy_190"},
+			}
+			campaign = &kubefloworgv1beta1.WorkspaceUpgradeCampaign{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-campaign"},
+				Spec: kubefloworgv1beta1.WorkspaceUpgradeCampaignSpec{
+					WorkspaceKind:   "jupyterlab",
+					BatchSize:       5,
+					DeferralSeconds: 3600,
+					TimeoutSeconds:  900,
+				},
+			}
+		})
+
+		It("should defer a pending Workspace only once", func() {
+			workspace.Annotations = map[string]string{upgradeCampaignDeferralAnnotation: campaign.Name}
+			entry := kubefloworgv1beta1.WorkspaceUpgradeStatus{State: kubefloworgv1beta1.WorkspaceUpgradeStatePending}
+
+			updateWorkspaceUpgradeStatus(&entry, workspace, campaign, nil, now)
+			Expect(entry.State).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeStateDeferred))
+			Expect(entry.Deferred).To(BeTrue())
+			Expect(entry.DeferredUntil).To(Equal(now.Add(time.Hour).Unix()))
+
+			By("returning to pending when the deferral expires")
+			updateWorkspaceUpgradeStatus(&entry, workspace, campaign, nil, now.Add(time.Hour))
+			Expect(entry.State).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeStatePending))
+			Expect(entry.Deferred).To(BeTrue())
+		})
+
+		It("should ignore a deferral for another campaign", func() {
+			workspace.Annotations = map[string]string{upgradeCampaignDeferralAnnotation: "other-campaign"}
+			entry := kubefloworgv1beta1.WorkspaceUpgradeStatus{State: kubefloworgv1beta1.WorkspaceUpgradeStatePending}
+
+			updateWorkspaceUpgradeStatus(&entry, workspace, campaign, nil, now)
+			Expect(entry.State).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeStatePending))
+		})
+
+		It("should succeed when a restarted Workspace is running without a redirect", func() {
+			workspace.Status.PodTemplateOptions.ImageConfig.RedirectChain = nil
+			workspace.Status.State = kubefloworgv1beta1.WorkspaceStateRunning
+			entry := kubefloworgv1beta1.WorkspaceUpgradeStatus{
+				State:       kubefloworgv1beta1.WorkspaceUpgradeStateUpgrading,
+				UpgradeTime: now.Unix(),
+			}
+
+			By("waiting while the old Pod is still running")
+			oldPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))}}
+			updateWorkspaceUpgradeStatus(&entry, workspace, campaign, oldPod, now.Add(time.Minute))
+			Expect(entry.State).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeStateUpgrading))
+
+			By("succeeding once the new Pod is running")
+			newPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(time.Second))}}
+			updateWorkspaceUpgradeStatus(&entry, workspace, campaign, newPod, now.Add(time.Minute))
+			Expect(entry.State).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded))
+		})
+
+		It("should fail when a Workspace does not restart before the timeout", func() {
+			workspace.Status.PodTemplateOptions.ImageConfig.RedirectChain = nil
+			workspace.Status.State = kubefloworgv1beta1.WorkspaceStatePending
+			entry := kubefloworgv1beta1.WorkspaceUpgradeStatus{
+				State:       kubefloworgv1beta1.WorkspaceUpgradeStateUpgrading,
+				UpgradeTime: now.Unix(),
+			}
+
+			updateWorkspaceUpgradeStatus(&entry, workspace, campaign, nil, now.Add(16*time.Minute))
+			Expect(entry.State).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeStateFailed))
+		})
+
+		It("should return a succeeded Workspace to pending when a new redirect is added", func() {
+			entry := kubefloworgv1beta1.WorkspaceUpgradeStatus{
+				State:       kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded,
+				UpgradeTime: now.Unix(),
+			}
+
+			updateWorkspaceUpgradeStatus(&entry, workspace, campaign, nil, now)
+			Expect(entry.State).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeStatePending))
+			Expect(entry.UpgradeTime).To(BeZero())
+		})
+
+		It("should set the podTemplate options to their desired values", func() {
+			setWorkspaceUpgradedOptions(workspace)
+			Expect(workspace.Spec.PodTemplate.Options.ImageConfig).To(Equal("jupyterlab_scipy_190"))
+			Expect(workspace.Spec.PodTemplate.Options.PodConfig).To(Equal("tiny_cpu"))
+		})
+	})
+
+	Context("When computing the campaign phase", func() {
+
+		It("should be completed when no Workspace is waiting for an upgrade", func() {
+			summary := getWorkspaceUpgradeSummary([]kubefloworgv1beta1.WorkspaceUpgradeStatus{
+				{State: kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded},
+				{State: kubefloworgv1beta1.WorkspaceUpgradeStateFailed},
+			})
+			Expect(summary).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeSummary{Succeeded: 1, Failed: 1}))
+			Expect(getWorkspaceUpgradeCampaignPhase(false, summary)).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeCampaignPhaseCompleted))
+		})
+
+		It("should be running while a Workspace is deferred", func() {
+			summary := getWorkspaceUpgradeSummary([]kubefloworgv1beta1.WorkspaceUpgradeStatus{
+				{State: kubefloworgv1beta1.WorkspaceUpgradeStateSucceeded},
+				{State: kubefloworgv1beta1.WorkspaceUpgradeStateDeferred},
+			})
+			Expect(getWorkspaceUpgradeCampaignPhase(false, summary)).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeCampaignPhaseRunning))
+			Expect(getWorkspaceUpgradeCampaignPhase(true, summary)).To(Equal(kubefloworgv1beta1.WorkspaceUpgradeCampaignPhasePaused))
+		})
+	})
+})
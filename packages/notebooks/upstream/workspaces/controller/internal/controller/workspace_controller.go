@@ -26,6 +26,7 @@ import (
 	"github.com/go-logr/logr"
 	networkingv1 "istio.io/api/networking/v1"
 	istiov1 "istio.io/client-go/pkg/apis/networking/v1"
+	istiosecurityv1 "istio.io/client-go/pkg/apis/security/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -133,6 +134,8 @@ type WorkspaceReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=services,verbs=create;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=create;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch
+// +kubebuilder:rbac:groups=security.istio.io,resources=authorizationpolicies,verbs=create;delete;get;list;patch;update;watch
 
 func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) { //nolint:gocyclo
 	log := log.FromContext(ctx)
@@ -409,8 +412,15 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	if r.Config.UseIstio {
+		// fetch the users the Workspace is shared with
+		shares, err := r.getWorkspaceShares(ctx, workspace)
+		if err != nil {
+			log.Error(err, "unable to list Workspace share RoleBindings")
+			return ctrl.Result{}, err
+		}
+
 		// generate VirtualService
-		virtualsvc, err := r.generateVirtualService(workspace, workspaceKind, service, currentImageConfig.Spec)
+		virtualsvc, err := r.generateVirtualService(workspace, workspaceKind, service, currentImageConfig.Spec, shares)
 		if err != nil {
 			return r.updateWorkspaceState(ctx, log, workspace,
 				kubefloworgv1beta1.WorkspaceStateError,
@@ -472,6 +482,15 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				log.V(2).Info("VirtualService updated", "virtualService", virtualServiceName)
 			}
 		}
+
+		// reconcile the AuthorizationPolicies which enforce the access level of shared users
+		policyResult, err := r.reconcileShareAuthorizationPolicies(ctx, log, workspace, shares)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if policyResult != nil {
+			return *policyResult, nil
+		}
 	} else if r.Config.UseKubeGateway {
 		log.Info("Using KubeGateway for workspace access",
 			"workspace", workspace.Name,
@@ -747,6 +766,13 @@ func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager, opts *controlle
 		return labelExists
 	})
 
+	// predicate function to filter RoleBindings which share a workspace
+	predRoleBindingIsWorkspaceShare := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		_, nameLabelExists := object.GetLabels()[workspaceNameLabel]
+		_, accessLabelExists := object.GetLabels()[workspaceShareAccessLabel]
+		return nameLabelExists && accessLabelExists
+	})
+
 	// Build the controller with core resources
 	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(*opts).
@@ -755,7 +781,17 @@ func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager, opts *controlle
 		Owns(&corev1.Service{})
 
 	if r.Config.UseIstio {
-		controllerBuilder = controllerBuilder.Owns(&istiov1.VirtualService{})
+		controllerBuilder = controllerBuilder.
+			Owns(&istiov1.VirtualService{}).
+			Owns(&istiosecurityv1.AuthorizationPolicy{})
+
+		// the VirtualService and AuthorizationPolicies have rules for each user the Workspace is shared with
+		// NOTE: share RoleBindings have the "workspace-name" label, like the pods of the workspace
+		controllerBuilder = controllerBuilder.Watches(
+			&rbacv1.RoleBinding{},
+			handler.EnqueueRequestsFromMapFunc(mapPodToRequest),
+			builder.WithPredicates(predRoleBindingIsWorkspaceShare),
+		)
 	}
 
 	// NOTE: HTTPRoute is NOT owned by Workspace (cross-namespace in gateway namespace)
@@ -1425,7 +1461,8 @@ func (r *WorkspaceReconciler) generateVirtualServiceHTTPRoute(
 }
 
 // generateVirtualService generates a VirtualService for a Workspace
-func (r *WorkspaceReconciler) generateVirtualService(workspace *kubefloworgv1beta1.Workspace, workspaceKind *kubefloworgv1beta1.WorkspaceKind, service *corev1.Service, imageConfigSpec kubefloworgv1beta1.ImageConfigSpec) (*istiov1.VirtualService, error) {
+//   - if the Workspace is shared with users, each HTTP port gets a route per user (see generateSharedHTTPRoutes)
+func (r *WorkspaceReconciler) generateVirtualService(workspace *kubefloworgv1beta1.Workspace, workspaceKind *kubefloworgv1beta1.WorkspaceKind, service *corev1.Service, imageConfigSpec kubefloworgv1beta1.ImageConfigSpec, shares []workspaceShare) (*istiov1.VirtualService, error) {
 	// NOTE: the name prefix is used to generate a unique name for the VirtualService
 	namePrefix := generateNamePrefix(workspace.Name, maxVirtualServiceNameLength)
 
@@ -1465,7 +1502,11 @@ func (r *WorkspaceReconciler) generateVirtualService(workspace *kubefloworgv1bet
 			if err != nil {
 				return nil, err
 			}
-			httpRoutes = append(httpRoutes, httpRoute)
+			if len(shares) > 0 {
+				httpRoutes = append(httpRoutes, generateSharedHTTPRoutes(httpRoute, shares, r.Config.UserIdHeader, r.Config.UserIdPrefix)...)
+			} else {
+				httpRoutes = append(httpRoutes, httpRoute)
+			}
 		}
 	}
 
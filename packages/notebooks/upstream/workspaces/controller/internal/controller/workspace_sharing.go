@@ -0,0 +1,292 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/protobuf/proto"
+	networkingv1 "istio.io/api/networking/v1"
+	securityv1 "istio.io/api/security/v1"
+	typev1beta1 "istio.io/api/type/v1beta1"
+	istiosecurityv1 "istio.io/client-go/pkg/apis/security/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+	"github.com/kubeflow/notebooks/workspaces/controller/internal/helper"
+)
+
+const (
+	// the label which marks a RoleBinding as sharing a Workspace, its value is the access level
+	//  - the RoleBinding also has the `notebooks.kubeflow.org/workspace-name` label
+	//  - these RoleBindings are managed by the backend, see `internal/models/workspaceshares` in the backend
+	workspaceShareAccessLabel = "notebooks.kubeflow.org/workspace-share-access"
+
+	// the request header which tells the Workspace application the access level of the user
+	//  - it is always set by the VirtualService, so it can not be spoofed by clients
+	workspaceAccessHeader = "X-Workspace-Access"
+
+	// the length of the names of the AuthorizationPolicies of a shared Workspace
+	maxAuthorizationPolicyNameLength = 63
+)
+
+// readOnlyHTTPMethods are the HTTP methods users with a read-only share may use
+var readOnlyHTTPMethods = []string{"GET", "HEAD", "OPTIONS"}
+
+// WorkspaceAccess is the access level a user has to a Workspace
+type WorkspaceAccess string
+
+const (
+	// WorkspaceAccessNamespace is the access level of users with access to the whole Namespace
+	WorkspaceAccessNamespace WorkspaceAccess = "namespace"
+
+	// WorkspaceAccessEdit is the access level of users a Workspace was shared with in edit mode
+	WorkspaceAccessEdit WorkspaceAccess = "edit"
+
+	// WorkspaceAccessRead is the access level of users a Workspace was shared with in read-only mode
+	WorkspaceAccessRead WorkspaceAccess = "read"
+)
+
+// workspaceShare is a user a Workspace is shared with
+type workspaceShare struct {
+	User   string
+	Access WorkspaceAccess
+}
+
+// getWorkspaceShares returns the users a Workspace is shared with, sorted by user
+//   - only `User` subjects are returned, as the VirtualService can only match on the user id header
+//   - if a user has multiple shares, the highest access level wins
+func (r *WorkspaceReconciler) getWorkspaceShares(ctx context.Context, workspace *kubefloworgv1beta1.Workspace) ([]workspaceShare, error) {
+	roleBindings := &rbacv1.RoleBindingList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(workspace.Namespace),
+		client.MatchingLabels{workspaceNameLabel: workspace.Name},
+		client.HasLabels{workspaceShareAccessLabel},
+	}
+	if err := r.List(ctx, roleBindings, listOpts...); err != nil {
+		return nil, err
+	}
+	return getWorkspaceSharesFromRoleBindings(roleBindings.Items), nil
+}
+
+// getWorkspaceSharesFromRoleBindings returns the users which are subjects of the given share RoleBindings, sorted by user
+func getWorkspaceSharesFromRoleBindings(roleBindings []rbacv1.RoleBinding) []workspaceShare {
+	userAccess := make(map[string]WorkspaceAccess)
+	for _, roleBinding := range roleBindings {
+		if !roleBinding.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		access := WorkspaceAccess(roleBinding.Labels[workspaceShareAccessLabel])
+		if access != WorkspaceAccessRead && access != WorkspaceAccessEdit {
+			continue
+		}
+		for _, subject := range roleBinding.Subjects {
+			if subject.Kind != rbacv1.UserKind || subject.Name == "" {
+				continue
+			}
+			if userAccess[subject.Name] != WorkspaceAccessEdit {
+				userAccess[subject.Name] = access
+			}
+		}
+	}
+
+	shares := make([]workspaceShare, 0, len(userAccess))
+	for user, access := range userAccess {
+		shares = append(shares, workspaceShare{User: user, Access: access})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		return shares[i].User < shares[j].User
+	})
+	return shares
+}
+
+// generateSharedHTTPRoutes returns the HTTPRoutes for a port of a Workspace which is shared with users
+//   - one route is generated for each user, matching the user id header, which sets the access header to the access level of the share
+//   - the base route (for everyone else) is returned last, and sets the access header to "namespace"
+//   - all routes keep the header operations of the WorkspaceKind port
+//   - NOTE: these routes only tell the application the access level of the user,
+//     the access level is enforced by the AuthorizationPolicies from `generateShareAuthorizationPolicies`
+func generateSharedHTTPRoutes(baseRoute *networkingv1.HTTPRoute, shares []workspaceShare, userIdHeader, userIdPrefix string) []*networkingv1.HTTPRoute {
+	httpRoutes := make([]*networkingv1.HTTPRoute, 0, len(shares)+1)
+	for _, share := range shares {
+		shareRoute := proto.Clone(baseRoute).(*networkingv1.HTTPRoute)
+		for _, match := range shareRoute.Match {
+			if match.Headers == nil {
+				match.Headers = make(map[string]*networkingv1.StringMatch)
+			}
+			match.Headers[userIdHeader] = &networkingv1.StringMatch{
+				MatchType: &networkingv1.StringMatch_Exact{
+					Exact: userIdPrefix + share.User,
+				},
+			}
+		}
+		setWorkspaceAccessHeader(shareRoute, share.Access)
+		httpRoutes = append(httpRoutes, shareRoute)
+	}
+
+	setWorkspaceAccessHeader(baseRoute, WorkspaceAccessNamespace)
+	return append(httpRoutes, baseRoute)
+}
+
+// setWorkspaceAccessHeader sets the access header on the requests of an HTTPRoute
+func setWorkspaceAccessHeader(httpRoute *networkingv1.HTTPRoute, access WorkspaceAccess) {
+	if httpRoute.Headers == nil {
+		httpRoute.Headers = &networkingv1.Headers{}
+	}
+	if httpRoute.Headers.Request == nil {
+		httpRoute.Headers.Request = &networkingv1.Headers_HeaderOperations{}
+	}
+	if httpRoute.Headers.Request.Set == nil {
+		httpRoute.Headers.Request.Set = make(map[string]string)
+	}
+	httpRoute.Headers.Request.Set[workspaceAccessHeader] = string(access)
+}
+
+// generateShareAuthorizationPolicies returns the AuthorizationPolicies which enforce the access level of the users a Workspace is shared with
+//   - a DENY policy (only if there are read-only shares) rejects requests of read-only users which are not
+//     GET, HEAD or OPTIONS, or which upgrade the connection (e.g. the websockets of Jupyter kernels and terminals)
+//   - users are matched on the user id header, like the HTTPRoutes from `generateSharedHTTPRoutes`
+//   - NOTE: we never generate an ALLOW policy, as Istio denies every request which no ALLOW policy of a workload matches,
+//     so it would lock out the owner and the other users of the Namespace. Shared users are admitted by the
+//     policies of the Namespace (e.g. the Kubeflow Profile policy), like every other user.
+func generateShareAuthorizationPolicies(workspace *kubefloworgv1beta1.Workspace, shares []workspaceShare, userIdHeader, userIdPrefix string) []*istiosecurityv1.AuthorizationPolicy {
+	if len(shares) == 0 {
+		return nil
+	}
+
+	readUsers := make([]string, 0, len(shares))
+	for _, share := range shares {
+		if share.Access == WorkspaceAccessRead {
+			readUsers = append(readUsers, userIdPrefix+share.User)
+		}
+	}
+	if len(readUsers) == 0 {
+		return nil
+	}
+
+	userIdKey := fmt.Sprintf("request.headers[%s]", strings.ToLower(userIdHeader))
+	readUserCondition := &securityv1.Condition{Key: userIdKey, Values: readUsers}
+	return []*istiosecurityv1.AuthorizationPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: generateNamePrefix(workspace.Name, maxAuthorizationPolicyNameLength),
+				Namespace:    workspace.Namespace,
+				Labels: map[string]string{
+					workspaceNameLabel: workspace.Name,
+				},
+			},
+			Spec: securityv1.AuthorizationPolicy{
+				Selector: &typev1beta1.WorkloadSelector{
+					MatchLabels: map[string]string{
+						workspaceNameLabel: workspace.Name,
+					},
+				},
+				Action: securityv1.AuthorizationPolicy_DENY,
+				Rules: []*securityv1.Rule{
+					{
+						To:   []*securityv1.Rule_To{{Operation: &securityv1.Operation{NotMethods: readOnlyHTTPMethods}}},
+						When: []*securityv1.Condition{readUserCondition},
+					},
+					{
+						When: []*securityv1.Condition{readUserCondition, {Key: "request.headers[upgrade]", Values: []string{"*"}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// reconcileShareAuthorizationPolicies creates, updates and deletes the AuthorizationPolicies of a Workspace
+//   - the AuthorizationPolicies owned by the Workspace are matched to the desired ones by their action
+//   - owned policies with an action which is no longer generated (e.g. the ALLOW policy of older versions) are deleted
+//   - returns a non-nil result if the reconcile should requeue
+func (r *WorkspaceReconciler) reconcileShareAuthorizationPolicies(ctx context.Context, log logr.Logger, workspace *kubefloworgv1beta1.Workspace, shares []workspaceShare) (*ctrl.Result, error) {
+	desiredPolicies := generateShareAuthorizationPolicies(workspace, shares, r.Config.UserIdHeader, r.Config.UserIdPrefix)
+
+	// fetch AuthorizationPolicies
+	// NOTE: we filter by AuthorizationPolicies that are owned by the Workspace, not by name
+	ownedPolicies := &istiosecurityv1.AuthorizationPolicyList{}
+	listOpts := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(helper.IndexWorkspaceOwnerField, workspace.Name),
+		Namespace:     workspace.Namespace,
+	}
+	if err := r.List(ctx, ownedPolicies, listOpts); err != nil {
+		log.Error(err, "unable to list AuthorizationPolicies")
+		return nil, err
+	}
+	foundPolicies := make(map[securityv1.AuthorizationPolicy_Action]*istiosecurityv1.AuthorizationPolicy)
+	for _, foundPolicy := range ownedPolicies.Items {
+		if _, exists := foundPolicies[foundPolicy.Spec.Action]; !exists {
+			foundPolicies[foundPolicy.Spec.Action] = foundPolicy
+			continue
+		}
+		// delete duplicate AuthorizationPolicies, so only one policy per action is kept
+		if err := r.Delete(ctx, foundPolicy); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to delete AuthorizationPolicy")
+			return nil, err
+		}
+	}
+
+	for _, desiredPolicy := range desiredPolicies {
+		action := desiredPolicy.Spec.Action
+		foundPolicy, exists := foundPolicies[action]
+		delete(foundPolicies, action)
+		if !exists {
+			if err := ctrl.SetControllerReference(workspace, desiredPolicy, r.Scheme); err != nil {
+				log.Error(err, "unable to set controller reference on AuthorizationPolicy")
+				return nil, err
+			}
+			if err := r.Create(ctx, desiredPolicy); err != nil {
+				log.Error(err, "unable to create AuthorizationPolicy")
+				return nil, err
+			}
+			log.V(2).Info("AuthorizationPolicy created", "authorizationPolicy", desiredPolicy.Name)
+			continue
+		}
+		if helper.CopyAuthorizationPolicyFields(desiredPolicy, foundPolicy) {
+			if err := r.Update(ctx, foundPolicy); err != nil {
+				if apierrors.IsConflict(err) {
+					log.V(2).Info("update conflict while updating AuthorizationPolicy, will requeue")
+					return &ctrl.Result{Requeue: true}, nil
+				}
+				log.Error(err, "unable to update AuthorizationPolicy")
+				return nil, err
+			}
+			log.V(2).Info("AuthorizationPolicy updated", "authorizationPolicy", foundPolicy.Name)
+		}
+	}
+
+	// delete the AuthorizationPolicies which are no longer needed (e.g. the last read-only share was removed)
+	for _, stalePolicy := range foundPolicies {
+		if err := r.Delete(ctx, stalePolicy); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "unable to delete AuthorizationPolicy")
+			return nil, err
+		}
+		log.V(2).Info("AuthorizationPolicy deleted", "authorizationPolicy", stalePolicy.Name)
+	}
+
+	return nil, nil
+}
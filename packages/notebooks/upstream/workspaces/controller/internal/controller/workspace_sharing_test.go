@@ -0,0 +1,219 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	networkingv1 "istio.io/api/networking/v1"
+	securityv1 "istio.io/api/security/v1"
+	istiosecurityv1 "istio.io/client-go/pkg/apis/security/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubefloworgv1beta1 "github.com/kubeflow/notebooks/workspaces/controller/api/v1beta1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Workspace Sharing", func() {
+
+	newShareRoleBinding := func(access string, subjects ...rbacv1.Subject) rbacv1.RoleBinding {
+		return rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					workspaceNameLabel:        "my-workspace",
+					workspaceShareAccessLabel: access,
+				},
+			},
+			Subjects: subjects,
+		}
+	}
+	userSubject := func(name string) rbacv1.Subject {
+		return rbacv1.Subject{Kind: rbacv1.UserKind, Name: name}
+	}
+
+	Context("When reading share RoleBindings", func() {
+
+		It("should return the users, sorted, with the highest access level", func() {
+			roleBindings := []rbacv1.RoleBinding{
+				newShareRoleBinding("read", userSubject("user-b"), userSubject("user-a")),
+				newShareRoleBinding("edit", userSubject("user-a")),
+			}
+			Expect(getWorkspaceSharesFromRoleBindings(roleBindings)).To(Equal([]workspaceShare{
+				{User: "user-a", Access: WorkspaceAccessEdit},
+				{User: "user-b", Access: WorkspaceAccessRead},
+			}))
+		})
+
+		It("should ignore group subjects and invalid access levels", func() {
+			roleBindings := []rbacv1.RoleBinding{
+				newShareRoleBinding("read", rbacv1.Subject{Kind: rbacv1.GroupKind, Name: "group-a"}),
+				newShareRoleBinding("admin", userSubject("user-a")),
+			}
+			Expect(getWorkspaceSharesFromRoleBindings(roleBindings)).To(BeEmpty())
+		})
+	})
+
+	Context("When generating the HTTPRoutes of a shared Workspace", func() {
+
+		It("should generate a route for each user before the base route", func() {
+			baseRoute := &networkingv1.HTTPRoute{
+				Match: []*networkingv1.HTTPMatchRequest{
+					{Uri: &networkingv1.StringMatch{MatchType: &networkingv1.StringMatch_Prefix{Prefix: "/workspace/"}}},
+				},
+				Headers: &networkingv1.Headers{
+					Request: &networkingv1.Headers_HeaderOperations{
+						Set: map[string]string{"X-RStudio-Root-Path": "/workspace/"},
+					},
+				},
+			}
+			shares := []workspaceShare{
+				{User: "user-a", Access: WorkspaceAccessEdit},
+				{User: "user-b", Access: WorkspaceAccessRead},
+			}
+
+			httpRoutes := generateSharedHTTPRoutes(baseRoute, shares, "kubeflow-userid", "accounts.google.com:")
+			Expect(httpRoutes).To(HaveLen(3))
+
+			By("matching the user id header of each user")
+			Expect(httpRoutes[0].Match[0].Headers["kubeflow-userid"].GetExact()).To(Equal("accounts.google.com:user-a"))
+			Expect(httpRoutes[0].Match[0].GetUri().GetPrefix()).To(Equal("/workspace/"))
+			Expect(httpRoutes[1].Match[0].Headers["kubeflow-userid"].GetExact()).To(Equal("accounts.google.com:user-b"))
+			Expect(httpRoutes[2].Match[0].Headers).To(BeEmpty())
+
+			By("setting the access header, and keeping the header operations of the WorkspaceKind")
+			Expect(httpRoutes[0].Headers.Request.Set).To(HaveKeyWithValue(workspaceAccessHeader, "edit"))
+			Expect(httpRoutes[1].Headers.Request.Set).To(HaveKeyWithValue(workspaceAccessHeader, "read"))
+			Expect(httpRoutes[2].Headers.Request.Set).To(HaveKeyWithValue(workspaceAccessHeader, "namespace"))
+			for _, httpRoute := range httpRoutes {
+				Expect(httpRoute.Headers.Request.Set).To(HaveKeyWithValue("X-RStudio-Root-Path", "/workspace/"))
+			}
+		})
+	})
+
+	Context("When generating the AuthorizationPolicies of a shared Workspace", func() {
+
+		workspace := &kubefloworgv1beta1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-workspace", Namespace: "my-namespace"},
+		}
+
+		It("should not generate policies for a Workspace without shares", func() {
+			Expect(generateShareAuthorizationPolicies(workspace, nil, "kubeflow-userid", "")).To(BeEmpty())
+		})
+
+		It("should only deny writes of read-only users", func() {
+			shares := []workspaceShare{
+				{User: "user-a", Access: WorkspaceAccessEdit},
+				{User: "user-b", Access: WorkspaceAccessRead},
+			}
+
+			policies := generateShareAuthorizationPolicies(workspace, shares, "Kubeflow-UserId", "accounts.google.com:")
+			Expect(policies).To(HaveLen(1))
+
+			By("denying read-only users other methods and connection upgrades")
+			denyPolicy := policies[0]
+			Expect(denyPolicy.Namespace).To(Equal("my-namespace"))
+			Expect(denyPolicy.Spec.Selector.MatchLabels).To(HaveKeyWithValue(workspaceNameLabel, "my-workspace"))
+			Expect(denyPolicy.Spec.Action).To(Equal(securityv1.AuthorizationPolicy_DENY))
+			Expect(denyPolicy.Spec.Rules).To(HaveLen(2))
+			Expect(denyPolicy.Spec.Rules[0].To[0].Operation.NotMethods).To(Equal([]string{"GET", "HEAD", "OPTIONS"}))
+			Expect(denyPolicy.Spec.Rules[0].When[0].Key).To(Equal("request.headers[kubeflow-userid]"))
+			Expect(denyPolicy.Spec.Rules[0].When[0].Values).To(Equal([]string{"accounts.google.com:user-b"}))
+			Expect(denyPolicy.Spec.Rules[1].When[0].Values).To(Equal([]string{"accounts.google.com:user-b"}))
+			Expect(denyPolicy.Spec.Rules[1].When[1].Key).To(Equal("request.headers[upgrade]"))
+		})
+
+		It("should not generate policies if there are no read-only shares", func() {
+			shares := []workspaceShare{{User: "user-a", Access: WorkspaceAccessEdit}}
+			Expect(generateShareAuthorizationPolicies(workspace, shares, "kubeflow-userid", "")).To(BeEmpty())
+		})
+
+		It("should not lock out the owner when there is no profile policy", func() {
+			shares := []workspaceShare{
+				{User: "user-a", Access: WorkspaceAccessEdit},
+				{User: "user-b", Access: WorkspaceAccessRead},
+			}
+			policies := generateShareAuthorizationPolicies(workspace, shares, "kubeflow-userid", "")
+
+			By("admitting the owner, who is not in the shares")
+			Expect(istioAllowsRequest(policies, "POST", map[string]string{"kubeflow-userid": "owner"})).To(BeTrue())
+			Expect(istioAllowsRequest(policies, "GET", map[string]string{"kubeflow-userid": "owner", "upgrade": "websocket"})).To(BeTrue())
+
+			By("admitting the edit user")
+			Expect(istioAllowsRequest(policies, "POST", map[string]string{"kubeflow-userid": "user-a"})).To(BeTrue())
+
+			By("only admitting read-only requests of the read user")
+			Expect(istioAllowsRequest(policies, "GET", map[string]string{"kubeflow-userid": "user-b"})).To(BeTrue())
+			Expect(istioAllowsRequest(policies, "POST", map[string]string{"kubeflow-userid": "user-b"})).To(BeFalse())
+			Expect(istioAllowsRequest(policies, "GET", map[string]string{"kubeflow-userid": "user-b", "upgrade": "websocket"})).To(BeFalse())
+		})
+	})
+})
+
+// istioAllowsRequest evaluates AuthorizationPolicies like Istio does for a single workload
+//   - DENY policies are evaluated first, and any matching rule denies the request
+//   - if there are ALLOW policies, the request is denied unless one of their rules matches
+//   - only the `methods`/`notMethods` operations and `request.headers[...]` conditions are supported
+func istioAllowsRequest(policies []*istiosecurityv1.AuthorizationPolicy, method string, headers map[string]string) bool {
+	matchesValue := func(values []string, value string, present bool) bool {
+		for _, v := range values {
+			if present && (v == "*" || v == value) {
+				return true
+			}
+		}
+		return false
+	}
+	matchesRule := func(rule *securityv1.Rule) bool {
+		for _, to := range rule.To {
+			op := to.Operation
+			if len(op.Methods) > 0 && !slices.Contains(op.Methods, method) {
+				return false
+			}
+			if len(op.NotMethods) > 0 && slices.Contains(op.NotMethods, method) {
+				return false
+			}
+		}
+		for _, condition := range rule.When {
+			name := strings.TrimSuffix(strings.TrimPrefix(condition.Key, "request.headers["), "]")
+			value, present := headers[name]
+			if !matchesValue(condition.Values, value, present) {
+				return false
+			}
+		}
+		return true
+	}
+
+	hasAllow := false
+	allowed := false
+	for _, policy := range policies {
+		for _, rule := range policy.Spec.Rules {
+			switch policy.Spec.Action {
+			case securityv1.AuthorizationPolicy_DENY:
+				if matchesRule(rule) {
+					return false
+				}
+			case securityv1.AuthorizationPolicy_ALLOW:
+				hasAllow = true
+				allowed = allowed || matchesRule(rule)
+			}
+		}
+	}
+	return !hasAllow || allowed
+}
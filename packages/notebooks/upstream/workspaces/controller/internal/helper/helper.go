@@ -19,6 +19,7 @@ package helper
 import (
 	"google.golang.org/protobuf/proto"
 	istiov1 "istio.io/client-go/pkg/apis/networking/v1"
+	istiosecurityv1 "istio.io/client-go/pkg/apis/security/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -172,6 +173,33 @@ func CopyVirtualServiceFields(desired *istiov1.VirtualService, target *istiov1.V
 	return requireUpdate
 }
 
+// CopyAuthorizationPolicyFields updates a target AuthorizationPolicy with the fields from a desired AuthorizationPolicy, returning true if an update is required.
+func CopyAuthorizationPolicyFields(desired *istiosecurityv1.AuthorizationPolicy, target *istiosecurityv1.AuthorizationPolicy) bool {
+	requireUpdate := false
+
+	// copy `metadata.labels`
+	var updated bool
+	target.Labels, updated = copyLabelFields(desired.Labels, target.Labels)
+	if updated {
+		requireUpdate = true
+	}
+
+	// copy `metadata.annotations`
+	target.Annotations, updated = copyAnnotationFields(desired.Annotations, target.Annotations)
+	if updated {
+		requireUpdate = true
+	}
+
+	// copy `spec`
+	// NOTE: we use proto.Equal to compare the specs of Istio resources are protobuf messages
+	if !proto.Equal(&target.Spec, &desired.Spec) {
+		target.Spec = *desired.Spec.DeepCopy()
+		requireUpdate = true
+	}
+
+	return requireUpdate
+}
+
 // CopyHTTPRouteFields updates a target HTTPRoute with the fields from a desired HTTPRoute, returning true if an update is required.
 func CopyHTTPRouteFields(desired *gatewayv1.HTTPRoute, target *gatewayv1.HTTPRoute) bool {
 	requireUpdate := false
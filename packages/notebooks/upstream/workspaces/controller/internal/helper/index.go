@@ -20,6 +20,7 @@ import (
 	"context"
 
 	istiov1 "istio.io/client-go/pkg/apis/networking/v1"
+	istiosecurityv1 "istio.io/client-go/pkg/apis/security/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -98,6 +99,21 @@ func SetupManagerFieldIndexers(mgr ctrl.Manager, cfg *config.EnvConfig) error {
 		}); err != nil {
 			return err
 		}
+
+		// Index AuthorizationPolicy by its owner Workspace
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), &istiosecurityv1.AuthorizationPolicy{}, IndexWorkspaceOwnerField, func(rawObj client.Object) []string {
+			authorizationPolicy := rawObj.(*istiosecurityv1.AuthorizationPolicy)
+			owner := metav1.GetControllerOf(authorizationPolicy)
+			if owner == nil {
+				return nil
+			}
+			if owner.APIVersion != kubefloworgv1beta1.GroupVersion.String() || owner.Kind != OwnerKindWorkspace {
+				return nil
+			}
+			return []string{owner.Name}
+		}); err != nil {
+			return err
+		}
 	}
 
 	// Index Workspace by WorkspaceKind
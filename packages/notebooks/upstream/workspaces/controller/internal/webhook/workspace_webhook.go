@@ -19,6 +19,7 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -84,6 +85,7 @@ func (v *WorkspaceValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 	allErrs = append(allErrs, v.validatePodTemplatePodMetadata(workspace)...)
 	allErrs = append(allErrs, v.validateImageConfig(workspace, workspaceKind)...)
 	allErrs = append(allErrs, v.validatePodConfig(workspace, workspaceKind)...)
+	allErrs = append(allErrs, v.validateSchedule(workspace)...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -163,6 +165,11 @@ func (v *WorkspaceValidator) ValidateUpdate(ctx context.Context, oldObj, newObj
 		}
 	}
 
+	// validate the new schedule
+	if !equality.Semantic.DeepEqual(newWorkspace.Spec.Schedule, oldWorkspace.Spec.Schedule) {
+		allErrs = append(allErrs, v.validateSchedule(newWorkspace)...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -281,3 +288,25 @@ func (v *WorkspaceValidator) validatePodConfig(workspace *kubefloworgv1beta1.Wor
 
 	return errs
 }
+
+// validateSchedule validates the schedule of a Workspace
+//   - the CRD validates the format of the windows, but not the time zone
+func (v *WorkspaceValidator) validateSchedule(workspace *kubefloworgv1beta1.Workspace) []*field.Error {
+	var errs []*field.Error
+
+	schedule := workspace.Spec.Schedule
+	if schedule == nil {
+		return nil
+	}
+
+	// validate the time zone
+	timeZonePath := field.NewPath("spec", "schedule", "timeZone")
+	// NOTE: "Local" is accepted by `time.LoadLocation`, but would be the time zone of the controller
+	if schedule.TimeZone == time.Local.String() {
+		errs = append(errs, field.Invalid(timeZonePath, schedule.TimeZone, "time zone must be an IANA time zone name"))
+	} else if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+		errs = append(errs, field.Invalid(timeZonePath, schedule.TimeZone, fmt.Sprintf("unknown time zone: %v", err)))
+	}
+
+	return errs
+}
@@ -121,6 +121,26 @@ var _ = Describe("Workspace Webhook", func() {
 			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("podConfig with id %q not found in workspace kind %q", invalidPodConfig, workspaceKindName)))
 		})
 
+		It("should reject an unknown schedule time zone", func() {
+			invalidTimeZone := "Mars/Olympus_Mons"
+
+			By("creating the Workspace")
+			workspace := NewExampleWorkspace(workspaceName, namespaceName, workspaceKindName)
+			workspace.Spec.Schedule = &kubefloworgv1beta1.WorkspaceSchedule{
+				TimeZone: invalidTimeZone,
+				Windows: []kubefloworgv1beta1.WorkspaceScheduleWindow{
+					{
+						Days:      []kubefloworgv1beta1.WorkspaceScheduleDay{kubefloworgv1beta1.WorkspaceScheduleDayMonday},
+						StartTime: "08:00",
+						PauseTime: "19:00",
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, workspace)
+			Expect(err).NotTo(Succeed())
+			Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Invalid value: %q", invalidTimeZone)))
+		})
+
 		It("should accept a valid workspace", func() {
 			By("creating the Workspace")
 			workspace := NewExampleWorkspace(workspaceName, namespaceName, workspaceKindName)